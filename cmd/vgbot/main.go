@@ -1,5 +1,7 @@
 // VGBot - Etik SEO ve Performans Test Aracı
 // Varsayılan: Modern web arayüzü. -cli ile konsol modu.
+// Dağıtık mod: "vgbot master [bayraklar]" ve "vgbot worker [bayraklar]".
+// Acil durdurma: "vgbot killswitch [-rearm|-status]" çalışan sunucudaki tüm çalıştırmaları durdurur.
 package main
 
 import (
@@ -19,9 +21,12 @@ import (
 	"time"
 
 	"vgbot/internal/config"
+	"vgbot/internal/node"
 	"vgbot/internal/server"
 	"vgbot/internal/simulator"
+	"vgbot/pkg/antidetect"
 	"vgbot/pkg/banner"
+	"vgbot/pkg/consent"
 	"vgbot/pkg/configfiles"
 	"vgbot/pkg/i18n"
 	"vgbot/pkg/sysinfo"
@@ -31,7 +36,28 @@ import (
 // Global language variable
 var currentLang = "tr"
 
+// exitSLAFailed CLI çalıştırması bitti ama SLA koşulları sağlanmadığında çıkış kodu (CI için; 1 = hata)
+const exitSLAFailed = 2
+
+// subcommands os.Args[1] ile seçilen roller (bayraklar rolün kendi bayraklarıdır)
+var subcommands = map[string]func(args []string) error{
+	"master": node.RunMaster,
+	"worker": node.RunWorker,
+	"killswitch": server.RunKillSwitch,
+}
+
 func main() {
+	// Dağıtık mod alt komutları: tek binary tüm rolleri kapsar
+	if len(os.Args) > 1 {
+		if run := subcommands[os.Args[1]]; run != nil {
+			if err := run(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
 	cliMode := flag.Bool("cli", false, "Konsol (CLI) modunda çalıştır")
 	port := flag.Int("port", 8754, "Web arayüzü portu")
 	showSysInfo := flag.Bool("sysinfo", false, "Sistem bilgilerini göster (neofetch benzeri)")
@@ -215,6 +241,59 @@ func printBanner(url, lang string) {
 	fmt.Println()
 }
 
+// confirmTarget kullanım koşulları onaylanmamışsa koşulları gösterip onay ister; hedef izin listesinde
+// değilse domain'in aynen yazılmasını ister. Onaylar ve reddedilen denemeler denetim kaydına yazılır.
+func confirmTarget(cfg *config.Config, lang string) bool {
+	store, err := consent.Open(cfg.ConsentFile, cfg.AuditLogFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T(lang, i18n.MsgWarning, err))
+	}
+	actor := os.Getenv("USER")
+	if actor == "" {
+		actor = os.Getenv("USERNAME")
+	}
+	rd := bufio.NewReader(os.Stdin)
+
+	if !store.Acknowledged() {
+		fmt.Println()
+		fmt.Println(i18n.T(lang, i18n.MsgTermsOfUse))
+		fmt.Print("\n" + i18n.T(lang, i18n.MsgTermsAcceptPrompt))
+		line, _ := rd.ReadString('\n')
+		if answer := strings.ToLower(strings.TrimSpace(line)); answer != "kabul" && answer != "accept" {
+			fmt.Println(i18n.T(lang, i18n.MsgTermsDeclined))
+			return false
+		}
+		if err := store.Acknowledge("cli", actor); err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T(lang, i18n.MsgError, err))
+			return false
+		}
+	}
+	if store.Allowed(cfg.TargetDomain) {
+		return true
+	}
+
+	domain, err := consent.Normalize(cfg.TargetDomain)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T(lang, i18n.MsgError, err))
+		return false
+	}
+	fmt.Println()
+	fmt.Println(i18n.T(lang, i18n.MsgTargetNotWhitelisted, domain))
+	fmt.Print(i18n.T(lang, i18n.MsgTargetConfirmPrompt, domain))
+	line, _ := rd.ReadString('\n')
+	if typed, _ := consent.Normalize(line); typed != domain {
+		store.Audit(consent.AuditEntry{Action: consent.ActionTargetBlocked, Domain: domain, Source: "cli", Actor: actor})
+		fmt.Println(i18n.T(lang, i18n.MsgTargetNotConfirmed))
+		return false
+	}
+	if _, err := store.Confirm(domain, "cli", actor, ""); err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T(lang, i18n.MsgError, err))
+		return false
+	}
+	fmt.Println(i18n.T(lang, i18n.MsgTargetConfirmed, domain, cfg.ConsentFile))
+	return true
+}
+
 // padRight pads a string to the right with spaces (rune-safe for UTF-8)
 func padRight(s string, length int) string {
 	runes := []rune(s)
@@ -270,6 +349,11 @@ func runCLI(autoOptimize bool, lang string) {
 	durationMinutes := 60
 	hitsPerMinute := 35
 	maxConcurrent := 10
+	var seed int64
+	replayPath := ""
+	profileName := ""
+	dryRun := false
+	var assertions []string
 	
 	// Argümanları manuel parse et (flag zaten parse edildi)
 	args := flag.Args()
@@ -280,6 +364,11 @@ func runCLI(autoOptimize bool, lang string) {
 				configPath = args[i+1]
 				i++
 			}
+		case "-profile":
+			if i+1 < len(args) {
+				profileName = args[i+1]
+				i++
+			}
 		case "-domain":
 			if i+1 < len(args) {
 				targetDomain = args[i+1]
@@ -305,6 +394,23 @@ func runCLI(autoOptimize bool, lang string) {
 				fmt.Sscanf(args[i+1], "%d", &maxConcurrent)
 				i++
 			}
+		case "-seed":
+			if i+1 < len(args) {
+				fmt.Sscanf(args[i+1], "%d", &seed)
+				i++
+			}
+		case "-replay":
+			if i+1 < len(args) {
+				replayPath = args[i+1]
+				i++
+			}
+		case "-dry-run":
+			dryRun = true
+		case "-assert":
+			if i+1 < len(args) {
+				assertions = append(assertions, args[i+1])
+				i++
+			}
 		}
 	}
 
@@ -364,6 +470,14 @@ func runCLI(autoOptimize bool, lang string) {
 		cfg.ApplyDefaults()
 		cfg.ComputeDerived()
 	}
+	// -profile: config.json yanındaki <ad>.profile.json yüklenir; diğer CLI bayrakları üstüne yazılır
+	if profileName != "" {
+		cfg, err = config.LoadProfile(filepath.Dir(configPath), profileName)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Profil yüklenemedi:", err)
+			os.Exit(1)
+		}
+	}
 
 	if targetDomain != "" {
 		cfg.TargetDomain = targetDomain
@@ -380,6 +494,10 @@ func runCLI(autoOptimize bool, lang string) {
 	if maxConcurrent > 0 {
 		cfg.MaxConcurrentVisits = maxConcurrent
 	}
+	if seed != 0 {
+		cfg.Seed = seed
+	}
+	cfg.Assertions = append(cfg.Assertions, assertions...)
 	cfg.ApplyDefaults()
 	cfg.ComputeDerived()
 
@@ -396,9 +514,33 @@ func runCLI(autoOptimize bool, lang string) {
 		fmt.Println("  " + i18n.T(lang, i18n.MsgCLIFlagDurationFlag))
 		fmt.Println("  " + i18n.T(lang, i18n.MsgCLIFlagHpm))
 		fmt.Println("  " + i18n.T(lang, i18n.MsgCLIFlagConcurrent))
+		fmt.Println("  " + i18n.T(lang, i18n.MsgCLIFlagSeed))
+		fmt.Println("  " + i18n.T(lang, i18n.MsgCLIFlagReplay))
+		fmt.Println("  " + i18n.T(lang, i18n.MsgCLIFlagDryRun))
+		fmt.Println("  " + i18n.T(lang, i18n.MsgCLIFlagAssert))
+		os.Exit(1)
+	}
+
+	// İlk çalıştırmada kullanım koşulları; izin listesinde olmayan hedef için açık domain onayı.
+	// Dry-run de hedefin sitemap'ini çektiği için onaydan sonra çalışır.
+	if !confirmTarget(cfg, lang) {
 		os.Exit(1)
 	}
 
+	// Dry-run: planı yazdır ve çık (sayfa isteği yapılmaz)
+	if dryRun {
+		plan, err := simulator.DryRun(context.Background(), cfg, nil, time.Now())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, i18n.T(lang, i18n.MsgError, err)+"\n")
+			os.Exit(1)
+		}
+		fmt.Println(i18n.T(lang, i18n.MsgDryRunPlan))
+		for _, line := range plan.Lines() {
+			fmt.Println("  " + line)
+		}
+		return
+	}
+
 	// Banner göster
 	banner.PrintRainbow(banner.VGBotASCII)
 	fmt.Println()
@@ -411,20 +553,47 @@ func runCLI(autoOptimize bool, lang string) {
 	fmt.Println("╚════════════════════════════════════════════════════════════╝")
 	fmt.Println()
 
+	var replay *simulator.ReplayPlan
+	if replayPath != "" {
+		replay, err = simulator.LoadReplay(replayPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, i18n.T(lang, i18n.MsgError, err)+"\n")
+			os.Exit(1)
+		}
+	}
+
 	agentLoader := useragent.LoadFromDirs([]string{".", ".."})
 	sim, err := simulator.New(cfg, agentLoader, nil, nil)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, i18n.T(lang, i18n.MsgError, err)+"\n")
 		os.Exit(1)
 	}
+	// Panelden oluşturulan keyword cluster'ları varsa arama referrer kelimeleri onlardan gelir; seçimler
+	// çalıştırmanın seed'ini izler
+	clusters := antidetect.NewKeywordClusterManager(antidetect.RotationAntiPattern, sim.Seeder())
+	if err := clusters.LoadClustersFile(cfg.KeywordClustersFile); err != nil {
+		fmt.Fprintf(os.Stderr, i18n.T(lang, i18n.MsgError, err)+"\n")
+		os.Exit(1)
+	}
+	if clusters.ClusterCount() > 0 {
+		sim.SetKeywordSource(clusters)
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 	go func() { <-sigChan; cancel() }()
 
-	if err := sim.Run(ctx); err != nil && err != context.Canceled {
+	run := sim.Run
+	if replay != nil {
+		run = func(ctx context.Context) error { return sim.Replay(ctx, replay) }
+	}
+	if err := run(ctx); err != nil && err != context.Canceled {
 		fmt.Fprintf(os.Stderr, i18n.T(lang, i18n.MsgSimulationError, err)+"\n")
 		os.Exit(1)
 	}
+	if sla := sim.Reporter().SLA(); sla != nil && !sla.Passed {
+		fmt.Fprintln(os.Stderr, i18n.T(lang, i18n.MsgCLISLAExit, exitSLAFailed))
+		os.Exit(exitSLAFailed)
+	}
 }
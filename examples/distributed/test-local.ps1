@@ -1,4 +1,4 @@
-# Test script for ErosHit Distributed Mode (PowerShell)
+# Test script for VGBot Distributed Mode (PowerShell)
 # Bu script tek makinede test etmek için kullanılır
 
 param(
@@ -10,7 +10,7 @@ param(
 $ErrorActionPreference = "Stop"
 
 Write-Host "╔════════════════════════════════════════════════════════════╗" -ForegroundColor Cyan
-Write-Host "║     ErosHit Distributed Mode - Local Test                  ║" -ForegroundColor Cyan
+Write-Host "║     VGBot Distributed Mode - Local Test                    ║" -ForegroundColor Cyan
 Write-Host "╚════════════════════════════════════════════════════════════╝" -ForegroundColor Cyan
 Write-Host ""
 
@@ -20,8 +20,7 @@ $secretKey = "test-secret-key"
 # Function to cleanup processes
 function Cleanup {
     Write-Host "`nCleaning up..." -ForegroundColor Yellow
-    Get-Process -Name "master" -ErrorAction SilentlyContinue | Stop-Process -Force -ErrorAction SilentlyContinue
-    Get-Process -Name "worker" -ErrorAction SilentlyContinue | Stop-Process -Force -ErrorAction SilentlyContinue
+    Get-Process -Name "vgbot" -ErrorAction SilentlyContinue | Stop-Process -Force -ErrorAction SilentlyContinue
 }
 
 # Cleanup on exit
@@ -31,17 +30,14 @@ trap {
 }
 
 # Build binaries
-Write-Host "Building binaries..." -ForegroundColor Green
+Write-Host "Building vgbot..." -ForegroundColor Green
 $rootDir = Split-Path -Parent (Split-Path -Parent $PSScriptRoot)
 cd $rootDir
 
-go build -o bin/master.exe cmd/eroshit/master.go
-if ($LASTEXITCODE -ne 0) { throw "Failed to build master" }
+go build -o bin/vgbot.exe ./cmd/vgbot
+if ($LASTEXITCODE -ne 0) { throw "Failed to build vgbot" }
 
-go build -o bin/worker.exe cmd/eroshit/worker.go
-if ($LASTEXITCODE -ne 0) { throw "Failed to build worker" }
-
-Write-Host "Binaries built successfully" -ForegroundColor Green
+Write-Host "Binary built successfully" -ForegroundColor Green
 Write-Host ""
 
 # Start Master
@@ -49,7 +45,7 @@ Write-Host "Starting Master on port $MasterPort..." -ForegroundColor Green
 $masterJob = Start-Job -ScriptBlock {
     param($port, $secret)
     cd $using:rootDir
-    .\bin\master.exe -bind "127.0.0.1:$port" -secret $secret
+    .\bin\vgbot.exe master -bind "127.0.0.1:$port" -secret $secret
 } -ArgumentList $MasterPort, $secretKey
 
 Start-Sleep -Seconds 2
@@ -83,7 +79,7 @@ for ($i = 1; $i -le $WorkerCount; $i++) {
     $job = Start-Job -ScriptBlock {
         param($url, $secret, $id)
         cd $using:rootDir
-        .\bin\worker.exe -master $url -secret $secret -concurrency 5
+        .\bin\vgbot.exe worker -master $url -secret $secret -concurrency 5
     } -ArgumentList $masterURL, $secretKey, $i
     $workerJobs += $job
 }
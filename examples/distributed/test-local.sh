@@ -1,5 +1,5 @@
 #!/bin/bash
-# Test script for ErosHit Distributed Mode (Bash)
+# Test script for VGBot Distributed Mode (Bash)
 # Bu script tek makinede test etmek için kullanılır
 
 set -e
@@ -18,7 +18,7 @@ YELLOW='\033[1;33m'
 NC='\033[0m' # No Color
 
 echo -e "${CYAN}╔════════════════════════════════════════════════════════════╗${NC}"
-echo -e "${CYAN}║     ErosHit Distributed Mode - Local Test                  ║${NC}"
+echo -e "${CYAN}║     VGBot Distributed Mode - Local Test                    ║${NC}"
 echo -e "${CYAN}╚════════════════════════════════════════════════════════════╝${NC}"
 echo ""
 
@@ -29,25 +29,24 @@ ROOT_DIR="$(dirname "$(dirname "$SCRIPT_DIR")")"
 cd "$ROOT_DIR"
 
 # Build binaries
-echo -e "${GREEN}Building binaries...${NC}"
+echo -e "${GREEN}Building vgbot...${NC}"
 mkdir -p bin
-go build -o bin/master cmd/eroshit/master.go
-go build -o bin/worker cmd/eroshit/worker.go
-echo -e "${GREEN}Binaries built successfully${NC}"
+go build -o bin/vgbot ./cmd/vgbot
+echo -e "${GREEN}Binary built successfully${NC}"
 echo ""
 
 # Cleanup function
 cleanup() {
     echo -e "\n${YELLOW}Cleaning up...${NC}"
-    pkill -f "bin/master" 2>/dev/null || true
-    pkill -f "bin/worker" 2>/dev/null || true
+    pkill -f "bin/vgbot master" 2>/dev/null || true
+    pkill -f "bin/vgbot worker" 2>/dev/null || true
 }
 
 trap cleanup EXIT
 
 # Start Master
 echo -e "${GREEN}Starting Master on port $MASTER_PORT...${NC}"
-./bin/master -bind "127.0.0.1:$MASTER_PORT" -secret "$SECRET_KEY" &
+./bin/vgbot master -bind "127.0.0.1:$MASTER_PORT" -secret "$SECRET_KEY" &
 MASTER_PID=$!
 sleep 2
 
@@ -64,7 +63,7 @@ echo ""
 echo -e "${GREEN}Starting $WORKER_COUNT Workers...${NC}"
 WORKER_PIDS=()
 for i in $(seq 1 $WORKER_COUNT); do
-    ./bin/worker -master "$MASTER_URL" -secret "$SECRET_KEY" -concurrency 5 &
+    ./bin/vgbot worker -master "$MASTER_URL" -secret "$SECRET_KEY" -concurrency 5 &
     WORKER_PIDS+=($!)
     echo "Worker $i started (PID: ${WORKER_PIDS[-1]})"
 done
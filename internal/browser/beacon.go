@@ -0,0 +1,175 @@
+package browser
+
+import (
+	"context"
+	"encoding/base64"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+)
+
+// Beacon sekmeden giden tek bir GA4 collect isteği (/g/collect)
+type Beacon struct {
+	MeasurementID string
+	Events        []string // en= parametreleri; gtag birden çok event'i tek istekte (gövde satırları) gönderebilir
+	Bytes         int      // Sorgu + gövde boyutu
+	Status        int      // HTTP status (sendBeacon isteklerinde yanıt olayı gelmeyebilir: 0)
+	Finished      bool
+	Failed        string // Ağ hatası / engelleme nedeni
+}
+
+// Delivered isteğin GA4'e (veya sGTM'e) ulaştığını döner
+func (b *Beacon) Delivered() bool {
+	return b.Failed == "" && (b.Status >= 200 && b.Status < 300 || b.Finished && b.Status == 0)
+}
+
+// BeaconSummary bir ziyaretteki collect isteklerinin özeti
+type BeaconSummary struct {
+	Beacons   int
+	Delivered int
+	Bytes     int64
+	Events    []string // Teslim edilen isteklerdeki event adları (gönderim sırasıyla)
+}
+
+// BeaconMeter sekmede GA4 collect isteklerini CDP network olaylarından yakalar. "Analytics teslim edildi"
+// kararı gtag'in sayfada var olmasına değil, gerçekten gönderilip yanıt alan isteklere dayanır.
+// Host'tan bağımsızdır: google-analytics.com ve birinci taraf sGTM uç noktaları aynı şekilde sayılır.
+type BeaconMeter struct {
+	mu      sync.Mutex
+	beacons map[network.RequestID]*Beacon
+	order   []network.RequestID
+}
+
+// Listen sekme context'indeki network olaylarını dinlemeye başlar (network.Enable gerekir)
+func (m *BeaconMeter) Listen(tabCtx context.Context) {
+	chromedp.ListenTarget(tabCtx, func(ev interface{}) {
+		switch ev := ev.(type) {
+		case *network.EventRequestWillBeSent:
+			if ev.Request == nil {
+				return
+			}
+			id, events, size, ok := parseBeacon(ev.Request.URL, requestBody(ev.Request))
+			if !ok {
+				return
+			}
+			m.mu.Lock()
+			if m.beacons == nil {
+				m.beacons = make(map[network.RequestID]*Beacon)
+			}
+			if _, seen := m.beacons[ev.RequestID]; !seen {
+				m.order = append(m.order, ev.RequestID)
+			}
+			m.beacons[ev.RequestID] = &Beacon{MeasurementID: id, Events: events, Bytes: size}
+			m.mu.Unlock()
+		case *network.EventResponseReceived:
+			m.update(ev.RequestID, func(b *Beacon) {
+				if ev.Response != nil {
+					b.Status = int(ev.Response.Status)
+				}
+			})
+		case *network.EventLoadingFinished:
+			m.update(ev.RequestID, func(b *Beacon) { b.Finished = true })
+		case *network.EventLoadingFailed:
+			m.update(ev.RequestID, func(b *Beacon) {
+				b.Failed = ev.ErrorText
+				if ev.BlockedReason != "" {
+					b.Failed = string(ev.BlockedReason)
+				}
+				if b.Failed == "" {
+					b.Failed = "failed"
+				}
+			})
+		}
+	})
+}
+
+func (m *BeaconMeter) update(id network.RequestID, fn func(*Beacon)) {
+	m.mu.Lock()
+	if b, ok := m.beacons[id]; ok {
+		fn(b)
+	}
+	m.mu.Unlock()
+}
+
+// Summary measurementID'ye giden istekleri özetler (boşsa tüm GA4 istekleri)
+func (m *BeaconMeter) Summary(measurementID string) BeaconSummary {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var s BeaconSummary
+	for _, id := range m.order {
+		b := m.beacons[id]
+		if measurementID != "" && !strings.EqualFold(b.MeasurementID, measurementID) {
+			continue
+		}
+		s.Beacons++
+		s.Bytes += int64(b.Bytes)
+		if b.Delivered() {
+			s.Delivered++
+			s.Events = append(s.Events, b.Events...)
+		}
+	}
+	return s
+}
+
+// Wait measurementID'ye en az bir istek teslim edilene kadar (en fazla timeout) bekler ve özeti döner
+func (m *BeaconMeter) Wait(ctx context.Context, measurementID string, timeout time.Duration) BeaconSummary {
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	tick := time.NewTicker(100 * time.Millisecond)
+	defer tick.Stop()
+	for {
+		if s := m.Summary(measurementID); s.Delivered > 0 {
+			return s
+		}
+		select {
+		case <-ctx.Done():
+			return m.Summary(measurementID)
+		case <-deadline.C:
+			return m.Summary(measurementID)
+		case <-tick.C:
+		}
+	}
+}
+
+// parseBeacon URL'nin bir GA4 collect isteği olup olmadığını kontrol eder; öyleyse measurement ID'yi,
+// event adlarını ve payload boyutunu döner. gtag toplu gönderimde her event'i gövdede ayrı satıra yazar.
+func parseBeacon(rawURL, body string) (measurementID string, events []string, size int, ok bool) {
+	u, err := url.Parse(rawURL)
+	if err != nil || !strings.HasSuffix(u.Path, "/g/collect") {
+		return "", nil, 0, false
+	}
+	q := u.Query()
+	measurementID = q.Get("tid")
+	if !strings.HasPrefix(strings.ToUpper(measurementID), "G-") {
+		return "", nil, 0, false
+	}
+	if en := q.Get("en"); en != "" {
+		events = append(events, en)
+	}
+	for _, line := range strings.Split(body, "\n") {
+		if lq, err := url.ParseQuery(strings.TrimSpace(line)); err == nil {
+			if en := lq.Get("en"); en != "" {
+				events = append(events, en)
+			}
+		}
+	}
+	return measurementID, events, len(u.RawQuery) + len(body), true
+}
+
+// requestBody isteğin gövdesini döner (CDP büyük gövdeleri yalnızca base64 parçalar olarak verir)
+func requestBody(r *network.Request) string {
+	if r.PostData != "" || len(r.PostDataEntries) == 0 {
+		return r.PostData
+	}
+	var sb strings.Builder
+	for _, e := range r.PostDataEntries {
+		if b, err := base64.StdEncoding.DecodeString(e.Bytes); err == nil {
+			sb.Write(b)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,54 @@
+package browser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/chromedp/cdproto/network"
+)
+
+func TestParseBeacon(t *testing.T) {
+	for _, u := range []string{
+		"https://www.google-analytics.com/j/collect?tid=UA-1-1",
+		"https://www.google-analytics.com/g/collect?v=2&tid=UA-1-1",
+		"https://site.example/g/collect",
+	} {
+		if _, _, _, ok := parseBeacon(u, ""); ok {
+			t.Errorf("%s parsed as GA4 beacon", u)
+		}
+	}
+
+	raw := "https://region1.google-analytics.com/g/collect?v=2&tid=G-ABC1234567&en=page_view"
+	id, events, size, ok := parseBeacon(raw, "")
+	if !ok || id != "G-ABC1234567" || !reflect.DeepEqual(events, []string{"page_view"}) || size != len("v=2&tid=G-ABC1234567&en=page_view") {
+		t.Errorf("single = %q %v %d %v", id, events, size, ok)
+	}
+
+	// Toplu gönderim: ortak parametreler sorguda, event'ler gövde satırlarında (sGTM aynı yolu kullanır)
+	body := "en=scroll&epn.percent_scrolled=90\r\nen=user_engagement&_et=1200"
+	id, events, size, ok = parseBeacon("https://sgtm.example.com/g/collect?v=2&tid=G-ABC1234567", body)
+	if !ok || id != "G-ABC1234567" || !reflect.DeepEqual(events, []string{"scroll", "user_engagement"}) || size != len("v=2&tid=G-ABC1234567")+len(body) {
+		t.Errorf("batch = %q %v %d %v", id, events, size, ok)
+	}
+}
+
+func TestBeaconSummary(t *testing.T) {
+	var m BeaconMeter
+	m.beacons = map[network.RequestID]*Beacon{
+		"1": {MeasurementID: "G-ABC1234567", Events: []string{"page_view"}, Bytes: 100, Status: 204},
+		"2": {MeasurementID: "G-ABC1234567", Events: []string{"scroll"}, Bytes: 50, Finished: true},
+		"3": {MeasurementID: "G-ABC1234567", Events: []string{"click"}, Bytes: 40, Failed: "net::ERR_BLOCKED_BY_CLIENT"},
+		"4": {MeasurementID: "G-OTHER12345", Events: []string{"page_view"}, Bytes: 10, Status: 204},
+		"5": {MeasurementID: "G-ABC1234567", Events: []string{"page_view"}, Bytes: 30, Status: 503, Finished: true},
+	}
+	m.order = []network.RequestID{"1", "2", "3", "4", "5"}
+
+	s := m.Summary("g-abc1234567")
+	want := BeaconSummary{Beacons: 4, Delivered: 2, Bytes: 220, Events: []string{"page_view", "scroll"}}
+	if !reflect.DeepEqual(s, want) {
+		t.Errorf("Summary = %+v, want %+v", s, want)
+	}
+	if all := m.Summary(""); all.Beacons != 5 || all.Delivered != 3 {
+		t.Errorf("Summary(\"\") = %+v", all)
+	}
+}
@@ -0,0 +1,125 @@
+package browser
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	mrand "math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"vgbot/pkg/utils"
+)
+
+// HTTPCacheDirName tarayıcı profil dizini altındaki HTTP önbelleği klasörü (yedeklere alınmaz)
+const HTTPCacheDirName = "http_cache"
+
+// cacheProfiles tekrar eden ziyaretçiler için profil başına HTTP disk önbelleği dizinleri.
+// Returning ziyaret önceki bir profilin önbelleğini kullanır; Chrome Cache-Control/ETag
+// kurallarına göre kaynakları önbellekten alır veya 304 ile doğrular.
+type cacheProfiles struct {
+	mu     sync.Mutex
+	dir    string
+	rate   int           // Returning ziyaret oranı (%)
+	max    int           // En fazla profil sayısı (aşılınca en eski silinir)
+	maxAge time.Duration // Bu süreden eski profiller returning için seçilmez ve silinir
+	inUse  map[string]bool
+	rng    *mrand.Rand // Seed'e bağlı; mu altında kullanılır
+}
+
+// newCacheProfiles dir altında önbellek profilleri yöneticisi oluşturur; seçim RNG'si seeder'dan türetilir
+func newCacheProfiles(dir string, rate, max, days int, seeder *utils.Seeder) *cacheProfiles {
+	if max <= 0 {
+		max = 100
+	}
+	if days <= 0 {
+		days = 7
+	}
+	return &cacheProfiles{
+		dir:    dir,
+		rate:   rate,
+		max:    max,
+		maxAge: time.Duration(days) * 24 * time.Hour,
+		inUse:  make(map[string]bool),
+		rng:    seeder.NewRand(),
+	}
+}
+
+type cacheProfileEntry struct {
+	id      string
+	modTime time.Time
+}
+
+// entries diskteki profilleri en eskiden yeniye döner
+func (c *cacheProfiles) entries() []cacheProfileEntry {
+	dirs, err := os.ReadDir(c.dir)
+	if err != nil {
+		return nil
+	}
+	out := make([]cacheProfileEntry, 0, len(dirs))
+	for _, d := range dirs {
+		if !d.IsDir() {
+			continue
+		}
+		info, err := d.Info()
+		if err != nil {
+			continue
+		}
+		out = append(out, cacheProfileEntry{id: d.Name(), modTime: info.ModTime()})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].modTime.Before(out[j].modTime) })
+	return out
+}
+
+// acquire ziyaret için önbellek dizini seçer. Oran tutarsa kullanımda olmayan, süresi dolmamış
+// bir profil (returning) döner; yoksa yeni profil oluşturur. Aynı dizin iki tarayıcıya aynı anda verilmez.
+func (c *cacheProfiles) acquire() (id, dir string, returning bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	var idle []cacheProfileEntry
+	for _, e := range c.entries() {
+		if c.inUse[e.id] {
+			continue
+		}
+		if now.Sub(e.modTime) > c.maxAge {
+			os.RemoveAll(filepath.Join(c.dir, e.id))
+			continue
+		}
+		idle = append(idle, e)
+	}
+
+	if len(idle) > 0 && c.rng.Intn(100) < c.rate {
+		e := idle[c.rng.Intn(len(idle))]
+		c.inUse[e.id] = true
+		return e.id, filepath.Join(c.dir, e.id), true
+	}
+
+	// Limit aşılıyorsa kullanımda olmayan en eski profili sil
+	if len(idle)+len(c.inUse) >= c.max && len(idle) > 0 {
+		os.RemoveAll(filepath.Join(c.dir, idle[0].id))
+	}
+	id = newCacheProfileID()
+	dir = filepath.Join(c.dir, id)
+	_ = os.MkdirAll(dir, 0755)
+	c.inUse[id] = true
+	return id, dir, false
+}
+
+// release profili serbest bırakır ve son kullanım zamanını günceller
+func (c *cacheProfiles) release(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.inUse, id)
+	now := time.Now()
+	_ = os.Chtimes(filepath.Join(c.dir, id), now, now)
+}
+
+func newCacheProfileID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
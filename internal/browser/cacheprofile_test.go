@@ -0,0 +1,58 @@
+package browser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCacheProfilesReturningReusesIdleProfile(t *testing.T) {
+	c := newCacheProfiles(t.TempDir(), 100, 10, 7, nil)
+	id, dir, returning := c.acquire()
+	if returning {
+		t.Fatal("first visit must start with an empty cache")
+	}
+	if _, err := os.Stat(dir); err != nil {
+		t.Fatalf("cache dir not created: %v", err)
+	}
+	// Kullanımdaki profil başka ziyarete verilmez
+	if id2, _, returning := c.acquire(); returning || id2 == id {
+		t.Fatalf("in-use profile reused: %s returning=%v", id2, returning)
+	}
+	c.release(id)
+	if id3, _, returning := c.acquire(); !returning {
+		t.Fatalf("expected returning visit, got new profile %s", id3)
+	}
+}
+
+func TestCacheProfilesRateZeroAlwaysNew(t *testing.T) {
+	c := newCacheProfiles(t.TempDir(), 0, 10, 7, nil)
+	id, _, _ := c.acquire()
+	c.release(id)
+	if _, _, returning := c.acquire(); returning {
+		t.Fatal("rate 0 must never reuse a cache profile")
+	}
+}
+
+func TestCacheProfilesEvictsExpiredAndOldest(t *testing.T) {
+	root := t.TempDir()
+	c := newCacheProfiles(root, 0, 2, 1, nil)
+	old := time.Now().Add(-48 * time.Hour)
+	os.MkdirAll(filepath.Join(root, "expired"), 0755)
+	os.Chtimes(filepath.Join(root, "expired"), old, old)
+	os.MkdirAll(filepath.Join(root, "a"), 0755)
+	os.Chtimes(filepath.Join(root, "a"), time.Now().Add(-time.Hour), time.Now().Add(-time.Hour))
+	os.MkdirAll(filepath.Join(root, "b"), 0755)
+
+	id, _, _ := c.acquire()
+	if _, err := os.Stat(filepath.Join(root, "expired")); !os.IsNotExist(err) {
+		t.Fatal("expired profile not removed")
+	}
+	if _, err := os.Stat(filepath.Join(root, "a")); !os.IsNotExist(err) {
+		t.Fatal("oldest profile not evicted at limit")
+	}
+	if _, err := os.Stat(filepath.Join(root, id)); err != nil {
+		t.Fatalf("new profile missing: %v", err)
+	}
+}
@@ -0,0 +1,33 @@
+package browser
+
+import (
+	"strings"
+	"testing"
+
+	"vgbot/pkg/analytics"
+)
+
+func TestGtagInjectScript(t *testing.T) {
+	if GtagInjectScript("G-ABC'); alert(1", nil) != "" {
+		t.Fatal("invalid ID injected")
+	}
+	direct := GtagInjectScript("G-ABC1234567", nil)
+	if !strings.Contains(direct, "https://www.googletagmanager.com/gtag/js?id=G-ABC1234567") || strings.Contains(direct, "transport_url") {
+		t.Errorf("direct script = %s", direct)
+	}
+
+	server, err := analytics.NewServerEndpoint("https://sgtm.example.com", "", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	script := GtagInjectScript("G-ABC1234567", server)
+	for _, want := range []string{
+		"s.src='https://sgtm.example.com/gtag/js?id=G-ABC1234567'",
+		"server_container_url:'https://sgtm.example.com'",
+		"transport_url:'https://sgtm.example.com'",
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script missing %q:\n%s", want, script)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package browser
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/chromedp/cdproto/fetch"
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/chromedp"
+
+	"vgbot/pkg/marker"
+)
+
+// HeaderInjector kampanya header'larını (basic auth, staging token) fetch yakalamasıyla yalnızca hedef
+// host'a giden isteklere ekler; üçüncü taraf kaynaklar ve başka host'a yönlendirmeler header almaz
+type HeaderInjector struct {
+	host    string
+	headers map[string]string
+}
+
+// NewHeaderInjector targetURL'nin host'u için injector döner; header yoksa nil (nil injector istekleri olduğu gibi geçirir)
+func NewHeaderInjector(targetURL string, headers map[string]string) *HeaderInjector {
+	if len(headers) == 0 {
+		return nil
+	}
+	u, err := url.Parse(targetURL)
+	if err != nil || u.Hostname() == "" {
+		return nil
+	}
+	return &HeaderInjector{host: strings.ToLower(u.Hostname()), headers: headers}
+}
+
+// matches istek URL'si hedef host'a mı gidiyor (port ve büyük/küçük harf farkı önemsiz)
+func (hi *HeaderInjector) matches(reqURL string) bool {
+	u, err := url.Parse(reqURL)
+	return err == nil && strings.EqualFold(u.Hostname(), hi.host)
+}
+
+// Continue duraklatılan isteği devam ettirir; istek hedef host'a gidiyorsa header'ları ekler.
+// Yönlendirmenin her adımı ayrıca duraklatıldığı için başka host'a yönlendirilen istek header almaz.
+func (hi *HeaderInjector) Continue(ev *fetch.EventRequestPaused) *fetch.ContinueRequestParams {
+	p := fetch.ContinueRequest(ev.RequestID)
+	if hi == nil || ev.Request == nil || !hi.matches(ev.Request.URL) {
+		return p
+	}
+	merged := make(map[string]string, len(ev.Request.Headers)+len(hi.headers))
+	for k, v := range ev.Request.Headers {
+		merged[http.CanonicalHeaderKey(k)] = fmt.Sprint(v)
+	}
+	for k, v := range hi.headers {
+		merged[http.CanonicalHeaderKey(k)] = v
+	}
+	entries := make([]*fetch.HeaderEntry, 0, len(merged))
+	for k, v := range merged {
+		entries = append(entries, &fetch.HeaderEntry{Name: k, Value: v})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	return p.WithHeaders(entries)
+}
+
+// Listen sekmede duraklatılan istekleri Continue ile devam ettirir. Kendi fetch dinleyicisi olmayan
+// sekmeler içindir; fetch.Enable ile birlikte kullanılır.
+func (hi *HeaderInjector) Listen(ctx context.Context) {
+	chromedp.ListenTarget(ctx, func(ev interface{}) {
+		if ev, ok := ev.(*fetch.EventRequestPaused); ok {
+			go func() {
+				_ = chromedp.Run(ctx, hi.Continue(ev))
+			}()
+		}
+	})
+}
+
+// MarkerCookieAction trafik işareti çerezini navigasyon URL'sinin host'una yazar (ClearBrowserCookies sonrası çalışmalı)
+func MarkerCookieAction(m *marker.Marker, navURL string) chromedp.Action {
+	return network.SetCookie(m.Name, m.Value).WithURL(navURL)
+}
+
+// gtagSetJS gtag('set') ile parametreleri dataLayer'a yazar; sayfanın gtag config'inden önce
+// çalıştığı için page_view dahil tüm GA event'leri parametreleri taşır
+const gtagSetJS = `window.dataLayer=window.dataLayer||[];(function(){dataLayer.push(arguments)})('set',%s);`
+
+// gtagSetAction params'ı her yeni dokümanda gtag('set') ile ekler
+func gtagSetAction(params map[string]string) chromedp.Action {
+	encoded, _ := json.Marshal(params)
+	script := fmt.Sprintf(gtagSetJS, encoded)
+	return chromedp.ActionFunc(func(ctx context.Context) error {
+		_, err := page.AddScriptToEvaluateOnNewDocument(script).Do(ctx)
+		return err
+	})
+}
+
+// ExperimentTagAction A/B deney varyantını GA event parametresi (experiment_id, experiment_variant) olarak ekler
+func ExperimentTagAction(param, variant string) chromedp.Action {
+	return gtagSetAction(map[string]string{"experiment_id": param, "experiment_variant": variant})
+}
+
+// TrafficTypeAction tüm GA4 event'lerine traffic_type parametresini ekler (GA4 iç trafik filtresi için)
+func TrafficTypeAction(trafficType string) chromedp.Action {
+	return gtagSetAction(map[string]string{"traffic_type": trafficType})
+}
@@ -0,0 +1,46 @@
+package browser
+
+import (
+	"testing"
+
+	"github.com/chromedp/cdproto/fetch"
+	"github.com/chromedp/cdproto/network"
+)
+
+func TestHeaderInjectorScopesToTargetHost(t *testing.T) {
+	hi := NewHeaderInjector("https://Staging.example.com/landing", map[string]string{
+		"Authorization":   "Basic c3RhZ2luZzpwdw==",
+		"X-Staging-Token": "abc",
+	})
+	paused := func(u string) *fetch.EventRequestPaused {
+		return &fetch.EventRequestPaused{RequestID: "1", Request: &network.Request{
+			URL:     u,
+			Headers: network.Headers{"accept": "text/html", "X-Staging-Token": "old"},
+		}}
+	}
+
+	p := hi.Continue(paused("https://staging.example.com:443/app.js"))
+	got := map[string]string{}
+	for _, h := range p.Headers {
+		got[h.Name] = h.Value
+	}
+	if got["Authorization"] == "" || got["X-Staging-Token"] != "abc" || got["Accept"] != "text/html" {
+		t.Fatalf("target host headers = %v", got)
+	}
+
+	// Üçüncü taraf kaynaklar ve başka host'a yönlendirmeler header almaz
+	for _, u := range []string{"https://www.google-analytics.com/g/collect", "https://example.com/", "https://staging.example.com.evil.test/"} {
+		if p := hi.Continue(paused(u)); p.Headers != nil {
+			t.Errorf("%s got headers %v", u, p.Headers)
+		}
+	}
+
+	// Header yoksa injector nil ve istekler olduğu gibi devam eder
+	none := NewHeaderInjector("https://staging.example.com/", nil)
+	if none != nil {
+		t.Fatal("injector without headers")
+	}
+	if p := none.Continue(paused("https://staging.example.com/")); p.Headers != nil || p.RequestID != "1" {
+		t.Errorf("nil injector params = %+v", p)
+	}
+}
@@ -2,8 +2,11 @@ package browser
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	mrand "math/rand"
 	"net/url"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
@@ -17,18 +20,28 @@ import (
 	"vgbot/internal/reporter"
 	"vgbot/pkg/analytics"
 	"vgbot/pkg/behavior"
+	"vgbot/pkg/browserpool"
 	"vgbot/pkg/canvas"
+	"vgbot/pkg/chromeflags"
+	"vgbot/pkg/chromelocator"
+	"vgbot/pkg/contentcheck"
 	"vgbot/pkg/engagement"
+	"vgbot/pkg/errclass"
 	"vgbot/pkg/fingerprint"
+	"vgbot/pkg/marker"
 	"vgbot/pkg/mobile"
 	"vgbot/pkg/referrer"
 	"vgbot/pkg/stealth"
 	"vgbot/pkg/useragent"
+	"vgbot/pkg/utils"
 )
 
 // Varsayılan ziyaret süresi; yavaş sayfalar ve yüksek paralellik için yeterli süre.
 const defaultVisitTimeout = 90 * time.Second
 
+// BeaconWait ziyaret sonunda GA4 collect isteğinin teslimi için beklenecek en uzun süre
+const BeaconWait = 3 * time.Second
+
 type HitVisitorConfig struct {
 	ProxyURL          string
 	ProxyUser         string
@@ -38,7 +51,9 @@ type HitVisitorConfig struct {
 	ScrollStrategy    string   // "gradual","fast","reader"
 	SendScrollEvent   bool     // GA4 scroll %75 event
 	AnalyticsManager  *analytics.Manager
+	Properties        *analytics.PropertySplit // Çoklu GA4 mülkü (nil = yalnızca GtagID)
 	Keywords          []string // Arama referrer için anahtar kelimeler
+	KeywordSource     KeywordSource // Keyword cluster'ları (nil veya boşsa Keywords kullanılır)
 	VisitTimeout      time.Duration // 0 ise defaultVisitTimeout kullanılır
 	// Cihaz emülasyonu
 	DeviceType        string   // "desktop", "mobile", "tablet", "mixed"
@@ -46,6 +61,29 @@ type HitVisitorConfig struct {
 	// Referrer ayarları
 	ReferrerKeyword   string   // Google arama referrer için kelime
 	ReferrerEnabled   bool     // Referrer simülasyonu aktif mi
+	// HTTP önbelleği (tekrar eden ziyaretçi): CacheDir boşsa her ziyaret boş önbellekle başlar
+	CacheDir          string // Profil başına disk önbelleği dizinlerinin kökü
+	ReturningRate     int    // Önceki bir profilin önbelleğiyle ziyaret oranı (%)
+	MaxCacheProfiles  int    // Saklanan en fazla önbellek profili
+	CacheProfileDays  int    // Bu süreden eski profiller silinir
+	// Giriş senaryosu (nil = girişsiz ziyaret); önbellek profilleri açıksa oturum profil başına saklanır
+	Login             *LoginScenario
+	// Hedef host'a giden isteklere eklenen header'lar (basic auth, staging token); korumalı staging ortamları için
+	ExtraHeaders      map[string]string
+	// Trafik işareti (?vgbot=1 / çerez): site sahibi simüle trafiği analytics'te filtreleyebilir (nil = kapalı)
+	Marker            *marker.Marker
+	// Outbound click: ziyaretlerin OutboundRate%'inde partner bağlantısı için GA4 click (outbound:true) gönderilir
+	OutboundDomains   []string
+	OutboundRate      int
+	// A/B deneyi: her ziyaret bir varyanta atanır (çerez/sorgu parametresi) ve GA event'leri varyantla etiketlenir (nil = kapalı)
+	Experiment        *marker.Split
+	// Sıcak Chrome havuzu: ziyaretler hazır süreçlerde ayrı browser context'inde açılır (nil = her ziyaret
+	// kendi Chrome'unu başlatır). Önbellek profilleri açıksa kullanılmaz; profil dizini süreç başınadır.
+	Pool              *browserpool.Config
+	// Yanıt içerik kontrolleri: örneklenen ziyaretlerde ana belge doğrulanır, başarısızlık fonksiyonel hatadır (nil = kapalı)
+	ContentChecks     *contentcheck.Checker
+	// Çalıştırmanın seed kaynağı (nil = paket varsayılanı); ziyaret RNG'leri buradan türetilir
+	Seeder            *utils.Seeder
 }
 
 // HitVisitor JS çalıştıran, her ziyarette farklı fingerprint, proxy destekli
@@ -57,30 +95,24 @@ type HitVisitor struct {
 	config   HitVisitorConfig
 	allocCtx context.Context
 	allocCan context.CancelFunc
+	opts     []chromedp.ExecAllocatorOption
+	cache    *cacheProfiles // nil = önbellek profilleri kapalı
+	pool     *browserpool.Pool // nil = havuz kapalı
 	mu       sync.Mutex
+	rngMu    sync.Mutex
+	rng      *mrand.Rand // Run seed'inden türetilir (deney varyantı, arama motoru, outbound, içerik örneklemesi)
 }
 
 func NewHitVisitor(agentProvider interface {
 	RandomWithHeaders() (ua string, headers map[string]string)
 }, rep *reporter.Reporter, cfg HitVisitorConfig) (*HitVisitor, error) {
-	opts := append(chromedp.DefaultExecAllocatorOptions[:],
-		chromedp.Flag("headless", true),
-		chromedp.Flag("disable-gpu", true),
-		chromedp.Flag("no-sandbox", true),
-		chromedp.Flag("disable-dev-shm-usage", true),
-		chromedp.Flag("disable-setuid-sandbox", true),
-		// Headless bypass - KRITIK
-		chromedp.Flag("disable-blink-features", "AutomationControlled"),
-		chromedp.Flag("disable-background-timer-throttling", true),
-		chromedp.Flag("disable-backgrounding-occluded-windows", true),
-		chromedp.Flag("disable-renderer-backgrounding", true),
-		chromedp.Flag("disable-features", "IsolateOrigins,site-per-process,TranslateUI"),
-		chromedp.Flag("no-first-run", true),
-		chromedp.Flag("no-default-browser-check", true),
-		chromedp.Flag("disable-hang-monitor", true),
-		chromedp.Flag("disable-prompt-on-repost", true),
-		chromedp.Flag("disable-sync", true),
-	)
+	// Flag'ler chromeflags'ten gelir (headless modu ve config'teki ek flag'ler dahil)
+	opts := chromeflags.AllocatorOptions(chromeflags.ProfileHit)
+
+	// chromelocator uyumlu bir tarayıcı bulduysa onu kullan; yoksa chromedp kendi arar
+	if p := chromelocator.ExecPath(); p != "" {
+		opts = append(opts, chromedp.ExecPath(p))
+	}
 
 	// SECURITY FIX: Proxy URL'den auth bilgisini ayır
 	// Chrome --proxy-server flag'i auth bilgisi kabul etmiyor
@@ -108,33 +140,85 @@ func NewHitVisitor(agentProvider interface {
 		
 		opts = append(opts,
 			chromedp.ProxyServer(proxyServerURL),
-			chromedp.Flag("proxy-bypass-list", "<-loopback>"),
+			chromedp.Flag("proxy-bypass-list", chromeflags.ProxyBypassList()),
 		)
 	}
 
 	allocCtx, allocCan := chromedp.NewExecAllocator(context.Background(), opts...)
 
-	return &HitVisitor{
+	h := &HitVisitor{
 		agentProvider: agentProvider,
 		reporter:      rep,
 		config:        cfg,
 		allocCtx:      allocCtx,
 		allocCan:      allocCan,
-	}, nil
+		opts:          opts,
+		rng:           cfg.Seeder.NewRand(),
+	}
+	if cfg.CacheDir != "" {
+		h.cache = newCacheProfiles(cfg.CacheDir, cfg.ReturningRate, cfg.MaxCacheProfiles, cfg.CacheProfileDays, cfg.Seeder)
+	} else if cfg.Pool != nil {
+		pc := *cfg.Pool
+		pc.AllocatorOptions = opts
+		h.pool = browserpool.New(pc)
+	}
+	return h, nil
 }
 
 func (h *HitVisitor) Close() {
+	if h.pool != nil {
+		h.pool.Close()
+	}
 	h.allocCan()
 }
 
+// PoolStats sıcak Chrome havuzunun durumu; havuz kapalıysa ok false
+func (h *HitVisitor) PoolStats() (stats browserpool.Stats, ok bool) {
+	if h.pool == nil {
+		return stats, false
+	}
+	return h.pool.Stats(), true
+}
+
+// intn run seed'inden türetilen kaynaktan [0,n) döner; ziyaretler eşzamanlı çağırır
+func (h *HitVisitor) intn(n int) int {
+	h.rngMu.Lock()
+	defer h.rngMu.Unlock()
+	return h.rng.Intn(n)
+}
+
+// SetKeywordSource arama referrer kelimelerini keyword cluster kaynağından alır; ziyaretlerden önce çağrılmalı
+func (h *HitVisitor) SetKeywordSource(src KeywordSource) {
+	h.config.KeywordSource = src
+}
+
 
 func (h *HitVisitor) VisitURL(ctx context.Context, urlStr string) error {
+	return h.VisitURLAs(ctx, urlStr, "")
+}
+
+// VisitURLAs verilen user agent ile ziyaret eder (replay: önceki çalıştırmanın cihazı).
+// forcedUA boşsa VisitURL ile aynı şekilde cihaz seçilir.
+func (h *HitVisitor) VisitURLAs(ctx context.Context, urlStr string, forcedUA string) (err error) {
+	// Her ziyaret zaman çizelgesi tutar; test ziyaretinde iz çağırandan gelir
+	trace := traceFrom(ctx)
+	if trace == nil {
+		trace = NewVisitTrace()
+	}
+
 	// Cihaz emülasyonu: DeviceType ve DeviceBrands'e göre cihaz seç
 	var deviceProfile *mobile.DeviceProfile
 	var ua string
 	var isMobile bool
 	
-	if h.config.DeviceType != "" && h.config.DeviceType != "mixed" {
+	if forcedUA != "" {
+		// Bilinen bir cihaz profiliyse ekran/touch ayarlarıyla birlikte kullan
+		if device, ok := mobile.FindDeviceByUserAgent(forcedUA); ok {
+			deviceProfile = &device
+			isMobile = device.Mobile
+		}
+		ua = forcedUA
+	} else if h.config.DeviceType != "" && h.config.DeviceType != "mixed" {
 		// Belirli bir cihaz tipi seçilmiş
 		device := mobile.GetRandomDeviceFiltered(h.config.DeviceType, h.config.DeviceBrands)
 		deviceProfile = &device
@@ -250,8 +334,41 @@ func (h *HitVisitor) VisitURL(ctx context.Context, urlStr string) error {
 		chromedp.WithLogf(func(string, ...interface{}) {}),
 	}
 
-	tabCtx, tabCancel := chromedp.NewContext(h.allocCtx, browserOpts...)
+	// Önbellek profili: tarayıcı profilin disk önbelleğiyle başlatılır; returning ziyarette Chrome
+	// Cache-Control/ETag'e göre kaynakları önbellekten alır veya koşullu istekle (304) doğrular
+	allocCtx := h.allocCtx
+	cacheMode, profileDir := "", ""
+	if h.cache != nil {
+		var cacheID string
+		var returning bool
+		cacheID, profileDir, returning = h.cache.acquire()
+		defer h.cache.release(cacheID)
+		opts := append(h.opts[:len(h.opts):len(h.opts)], chromedp.Flag("disk-cache-dir", filepath.Join(profileDir, "cache")))
+		var allocCancel context.CancelFunc
+		allocCtx, allocCancel = chromedp.NewExecAllocator(h.allocCtx, opts...)
+		defer allocCancel()
+		cacheMode = "new"
+		if returning {
+			cacheMode = "returning"
+		}
+	}
+
+	// Sıcak havuz: ziyaret hazır bir Chrome'da açılır; havuz doluysa ziyaret kendi Chrome'unu başlatır
+	var tabCtx context.Context
+	var tabCancel context.CancelFunc
+	if h.pool != nil {
+		if lease, errLease := h.pool.Acquire(ctx); errLease == nil {
+			defer lease.Release()
+			tabCtx, tabCancel = lease.NewTab(browserOpts...)
+		}
+	}
+	if tabCtx == nil {
+		tabCtx, tabCancel = chromedp.NewContext(allocCtx, browserOpts...)
+	}
 	defer tabCancel()
+	// Sekme allocator'dan türediği için ziyaret context'i iptal edilince sekmeyi de kapat (Stop)
+	stopTab := context.AfterFunc(ctx, tabCancel)
+	defer stopTab()
 
 	visitTimeout := h.config.VisitTimeout
 	if visitTimeout <= 0 {
@@ -265,17 +382,31 @@ func (h *HitVisitor) VisitURL(ctx context.Context, urlStr string) error {
 
 	// BUG FIX #10: Gerçek HTTP status kodunu yakala
 	var realStatusCode int
+	var docRequestID network.RequestID // İçerik kontrolü için ana belgenin gövdesi bu istekten okunur
+	var responses, cachedResponses int
 	var statusMu sync.Mutex
 	chromedp.ListenTarget(tabCtx, func(ev interface{}) {
 		if resp, ok := ev.(*network.EventResponseReceived); ok {
+			statusMu.Lock()
 			if resp.Type == network.ResourceTypeDocument {
-				statusMu.Lock()
 				realStatusCode = int(resp.Response.Status)
-				statusMu.Unlock()
+				docRequestID = resp.RequestID
+			}
+			responses++
+			if resp.Response.FromDiskCache {
+				cachedResponses++
 			}
+			statusMu.Unlock()
 		}
 	})
 
+	// Sayfa ağırlığı: ziyaret boyunca tamamlanan istekler ve aktarılan baytlar
+	var meter ResourceMeter
+	meter.Listen(tabCtx)
+	// GA4 teslimatı: sayfadan giden /g/collect istekleri ve yanıtları
+	var beacons BeaconMeter
+	beacons.Listen(tabCtx)
+
 	// Proxy auth (proxy kullanıcı/şifre varsa)
 	if h.config.ProxyUser != "" || h.config.ProxyPass != "" {
 		chromedp.ListenTarget(tabCtx, func(ev interface{}) {
@@ -298,6 +429,15 @@ func (h *HitVisitor) VisitURL(ctx context.Context, urlStr string) error {
 	}
 
 	// Resource blocking: block heavy resources but allow GA4 tracking requests through
+	sgtmHost := ""
+	if h.config.AnalyticsManager != nil && h.config.AnalyticsManager.Server != nil {
+		sgtmHost = h.config.AnalyticsManager.Server.Host()
+	}
+	if tracker := h.config.AnalyticsManager.Tracker(); tracker != nil {
+		sgtmHost = tracker.Host() // Matomo/Plausible izleme pikselleri
+	}
+	// Kampanya header'ları yalnızca hedef host'a giden isteklere eklenir
+	injector := NewHeaderInjector(urlStr, h.config.ExtraHeaders)
 	chromedp.ListenTarget(tabCtx, func(ev interface{}) {
 		if ev, ok := ev.(*fetch.EventRequestPaused); ok {
 			go func() {
@@ -305,7 +445,7 @@ func (h *HitVisitor) VisitURL(ctx context.Context, urlStr string) error {
 				// Documents, scripts, XHR always pass through (GA4 beacons, gtag.js, etc.)
 				if rt == network.ResourceTypeDocument || rt == network.ResourceTypeScript ||
 					rt == network.ResourceTypeXHR || rt == "" {
-					_ = chromedp.Run(tabCtx, fetch.ContinueRequest(ev.RequestID))
+					_ = chromedp.Run(tabCtx, injector.Continue(ev))
 					return
 				}
 				// Block heavy resources: images (except GA4 collect pixels), stylesheets, fonts, media
@@ -317,13 +457,14 @@ func (h *HitVisitor) VisitURL(ctx context.Context, urlStr string) error {
 					reqURL := ev.Request.URL
 					if strings.Contains(reqURL, "google-analytics.com") ||
 						strings.Contains(reqURL, "googletagmanager.com") ||
-						strings.Contains(reqURL, "analytics.google.com") {
-						_ = chromedp.Run(tabCtx, fetch.ContinueRequest(ev.RequestID))
+						strings.Contains(reqURL, "analytics.google.com") ||
+						(sgtmHost != "" && strings.Contains(reqURL, sgtmHost)) {
+						_ = chromedp.Run(tabCtx, injector.Continue(ev))
 					} else {
 						_ = chromedp.Run(tabCtx, fetch.FailRequest(ev.RequestID, network.ErrorReasonBlockedByClient))
 					}
 				default:
-					_ = chromedp.Run(tabCtx, fetch.ContinueRequest(ev.RequestID))
+					_ = chromedp.Run(tabCtx, injector.Continue(ev))
 				}
 			}()
 		}
@@ -334,51 +475,6 @@ func (h *HitVisitor) VisitURL(ctx context.Context, urlStr string) error {
 		fetchOpt = fetch.Enable().WithHandleAuthRequests(true)
 	}
 
-	gtagScript := ""
-	if h.config.GtagID != "" {
-		// SECURITY FIX: Validate GtagID format to prevent XSS injection
-		// Valid GA4 format: G-XXXXXXXXXX or GT-XXXXXXXXXX (10-12 alphanumeric chars after prefix)
-		// Valid UA format: UA-XXXXXXXX-X (numeric with dashes)
-		gtagID := h.config.GtagID
-		isValidGtagID := false
-		
-		// Check GA4 format (G-XXXXXXXXXX or GT-XXXXXXXXXX)
-		if len(gtagID) >= 10 && len(gtagID) <= 15 {
-			if (strings.HasPrefix(gtagID, "G-") || strings.HasPrefix(gtagID, "GT-")) {
-				suffix := gtagID[strings.Index(gtagID, "-")+1:]
-				isValidGtagID = true
-				for _, c := range suffix {
-					if !((c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')) {
-						isValidGtagID = false
-						break
-					}
-				}
-			}
-		}
-		
-		// Check UA format (UA-XXXXXXXX-X)
-		if !isValidGtagID && len(gtagID) >= 10 && len(gtagID) <= 20 && strings.HasPrefix(gtagID, "UA-") {
-			isValidGtagID = true
-			for _, c := range gtagID[3:] {
-				if !((c >= '0' && c <= '9') || c == '-') {
-					isValidGtagID = false
-					break
-				}
-			}
-		}
-		
-		if isValidGtagID {
-			gtagScript = `(function(){
-				var s=document.createElement('script');s.async=true;
-				s.src='https://www.googletagmanager.com/gtag/js?id=` + gtagID + `';
-				document.head.appendChild(s);
-				window.dataLayer=window.dataLayer||[];function gtag(){dataLayer.push(arguments);}
-				gtag('js',new Date());
-				gtag('config','` + gtagID + `',{send_page_view:true});
-			})();`
-		}
-		// If invalid GtagID, gtagScript remains empty (no injection)
-	}
 
 	// Stealth script - sayfa yüklenmeden ÖNCE (headless bypass)
 	stealthScript := stealth.GetOnNewDocumentScript(stealthCfg)
@@ -391,6 +487,10 @@ func (h *HitVisitor) VisitURL(ctx context.Context, urlStr string) error {
 	if idx := strings.Index(targetDomain, "/"); idx >= 0 {
 		targetDomain = targetDomain[:idx]
 	}
+	// Navigasyon URL'si trafik işaretini taşır; raporda işaretsiz URL kullanılır
+	navURL := h.config.Marker.ApplyURL(urlStr)
+	variant, expMarker := h.config.Experiment.Pick(h.intn)
+	navURL = expMarker.ApplyURL(navURL)
 	navActions := []chromedp.Action{
 		fetchOpt,
 		network.Enable(),
@@ -406,6 +506,21 @@ func (h *HitVisitor) VisitURL(ctx context.Context, urlStr string) error {
 		}),
 	}
 	
+	if h.config.Marker.CookieValue() != "" {
+		navActions = append(navActions, MarkerCookieAction(h.config.Marker, navURL))
+	}
+	if variant != "" {
+		if expMarker.CookieValue() != "" {
+			navActions = append(navActions, MarkerCookieAction(expMarker, navURL))
+		}
+		navActions = append(navActions, ExperimentTagAction(expMarker.Name, variant))
+		trace.Step("experiment", expMarker.String(), true)
+	}
+	if h.config.AnalyticsManager != nil && h.config.AnalyticsManager.TrafficType != "" {
+		navActions = append(navActions, TrafficTypeAction(h.config.AnalyticsManager.TrafficType))
+		trace.Step("traffic_type", h.config.AnalyticsManager.TrafficType, true)
+	}
+
 	// Mobil cihaz için touch emülasyonu
 	if isMobile && deviceProfile != nil {
 		navActions = append(navActions, chromedp.ActionFunc(func(ctx context.Context) error {
@@ -413,19 +528,38 @@ func (h *HitVisitor) VisitURL(ctx context.Context, urlStr string) error {
 		}))
 	}
 	
-	// Referrer ayarla - öncelik: ReferrerKeyword > Keywords
+	// Giriş senaryosu: hedef sayfadan önce oturum açılır (veya profilin kayıtlı oturumu yüklenir)
+	loginRestored := false
+	if h.config.Login != nil {
+		navActions = append(navActions, chromedp.ActionFunc(func(ctx context.Context) error {
+			var err error
+			loginRestored, err = h.ensureLogin(ctx, profileDir, trace)
+			return err
+		}))
+	}
+
+	// Referrer ayarla - öncelik: ReferrerKeyword > keyword cluster'ları > Keywords
 	var referrerURL string
 	if h.config.ReferrerEnabled && h.config.ReferrerKeyword != "" {
 		// Kullanıcının girdiği kelime ile Google arama referrer'ı oluştur
 		encodedKeyword := url.QueryEscape(h.config.ReferrerKeyword)
 		referrerURL = fmt.Sprintf("https://www.google.com/search?q=%s", encodedKeyword)
+	} else if src := h.config.KeywordSource; src != nil && src.ClusterCount() > 0 {
+		// Keyword cluster'ları: motor dağılımı Keywords ile aynı, kelime cluster rotasyonundan gelir;
+		// ziyaret sonucu cluster istatistiğine yazılır
+		if engine := searchEngine(h.intn(100)); engine != "" {
+			if kw, clusterID, ok := src.NextKeyword(); ok {
+				referrerURL = searchReferrerURL(engine, kw)
+				defer func() {
+					src.RecordUsage(kw, clusterID, trace.SessionID, err == nil, time.Since(start).Milliseconds())
+				}()
+			}
+		}
 	} else if len(h.config.Keywords) > 0 {
 		// Eski davranış: Keywords listesinden referrer oluştur
-		refCfg := &referrer.ReferrerConfig{
-			GooglePercent: 50, BingPercent: 20, DirectPercent: 30,
-			Keywords: h.config.Keywords,
-		}
-		refChain := referrer.NewReferrerChain(targetDomain, refCfg)
+		refCfg := keywordReferrerMix
+		refCfg.Keywords = h.config.Keywords
+		refChain := referrer.NewReferrerChain(targetDomain, &refCfg)
 		src := refChain.Generate()
 		if src != nil && src.URL != "" && (src.Type == "search" || src.Type == "social") {
 			referrerURL = src.URL
@@ -438,21 +572,94 @@ func (h *HitVisitor) VisitURL(ctx context.Context, urlStr string) error {
 		navActions = append(navActions,
 			chromedp.ActionFunc(func(ctx context.Context) error {
 				// page.Navigate ile referrer parametresi kullan
-				_, _, _, err := page.Navigate(urlStr).WithReferrer(referrerURL).Do(ctx)
+				_, _, _, err := page.Navigate(navURL).WithReferrer(referrerURL).Do(ctx)
 				return err
 			}),
 		)
 	} else {
 		navActions = append(navActions,
-			chromedp.Navigate(urlStr),
+			chromedp.Navigate(navURL),
 		)
 	}
 	navActions = append(navActions,
 		chromedp.WaitReady("body", chromedp.ByQuery),
 		chromedp.Sleep(500*time.Millisecond),
 	)
-	navErr := chromedp.Run(tabCtx, navActions...)
+	navErr := errclass.Classify(chromedp.Run(tabCtx, navActions...))
+	// Kayıtlı oturumun süresi dolmuşsa: çerezleri unut, yeniden giriş yap ve sayfayı tekrar aç
+	if navErr == nil && loginRestored && !h.config.Login.loggedIn(tabCtx) {
+		trace.Step("login", "saved session expired", false)
+		forgetCookies(profileDir)
+		loginRestored = false
+		navErr = errclass.Classify(chromedp.Run(tabCtx,
+			chromedp.ActionFunc(func(ctx context.Context) error {
+				_, err := h.ensureLogin(ctx, "", trace)
+				if err == nil && profileDir != "" {
+					_ = h.config.Login.saveCookies(ctx, profileDir)
+				}
+				return err
+			}),
+			chromedp.Navigate(navURL),
+			chromedp.WaitReady("body", chromedp.ByQuery),
+		))
+	}
+	trace.update(func(t *VisitTrace) {
+		t.URL, t.UserAgent, t.Mobile = urlStr, ua, isMobile
+		t.Referrer = referrerURL
+	})
+	trace.Step("device", ua, true)
+	if referrerURL != "" {
+		trace.Step("referrer", referrerURL, true)
+	} else {
+		trace.Step("referrer", "direct", true)
+	}
+	if navErr != nil {
+		trace.Step("navigate", navErr.Error(), false)
+	} else {
+		trace.Step("navigate", urlStr, true)
+	}
+	if cacheMode != "" {
+		statusMu.Lock()
+		trace.Step("cache", fmt.Sprintf("%s, %d/%d from disk cache", cacheMode, cachedResponses, responses), true)
+		statusMu.Unlock()
+	}
 
+	// Sayfanın kendi aktif measurement ID'si: varsa yapılandırılan GtagID yalnızca fallback'tir,
+	// enjekte edilmez (çift page_view olmasın) ve event'ler sayfanın ID'sine yönlendirilir.
+	// GA dışı backend'de (Matomo, Plausible) measurement ID ve gtag kullanılmaz.
+	tracker := h.config.AnalyticsManager.Tracker()
+	var pageIDs []string
+	if navErr == nil && tracker == nil {
+		pageIDs, _ = analytics.PageMeasurementIDs(tabCtx)
+	}
+	measurementID := analytics.ResolveMeasurementID(pageIDs, h.config.GtagID)
+	analyticsMgr := h.config.AnalyticsManager.ForMeasurementID(measurementID)
+	// Çoklu mülk: ziyaret paya göre seçilen mülke (ör. sandbox) raporlanır
+	if h.config.Properties != nil && tracker == nil {
+		prop := h.config.Properties.Pick()
+		measurementID = prop.MeasurementID
+		analyticsMgr = h.config.AnalyticsManager.ForProperty(prop)
+	}
+	if tracker != nil {
+		measurementID = tracker.ID()
+	}
+	// Seçilen ID sayfada zaten aktif değilse gtag ile yüklenir
+	gtagScript := ""
+	if tracker == nil && !containsFold(pageIDs, measurementID) {
+		var server *analytics.ServerEndpoint
+		if analyticsMgr != nil {
+			server = analyticsMgr.Server
+		}
+		gtagScript = GtagInjectScript(measurementID, server)
+	}
+	analyticsMethod := "none"
+	if navErr == nil && containsFold(pageIDs, measurementID) {
+		analyticsMethod = "page_gtag"
+	}
+	trace.Step("measurement_id", fmt.Sprintf("page=%v selected=%s", pageIDs, measurementID), measurementID != "")
+
+	// Analytics yüklenmediyse hit başarılı sayılır ama analytics_missing olarak işaretlenir
+	var analyticsErr error
 	if navErr == nil && gtagScript != "" {
 		if err := chromedp.Run(tabCtx, chromedp.Evaluate(gtagScript, nil)); err != nil {
 			// gtag script hatası kritik değil, devam et
@@ -461,6 +668,36 @@ func (h *HitVisitor) VisitURL(ctx context.Context, urlStr string) error {
 		if err := chromedp.Run(tabCtx, chromedp.Sleep(1000*time.Millisecond)); err != nil {
 			_ = err
 		}
+		analyticsMethod = "injected_gtag"
+		if err := chromedp.Run(tabCtx, chromedp.ActionFunc(analytics.VerifyGA4Loaded)); errors.Is(err, errclass.ErrAnalyticsMissing) {
+			analyticsErr = err
+			analyticsMethod = "missing"
+			// gtag yüklenemedi: API secret varsa page_view'ı Measurement Protocol ile gönder
+			if analyticsMgr != nil && analyticsMgr.MPAPISecret != "" {
+				var title string
+				_ = chromedp.Run(tabCtx, chromedp.Title(&title))
+				if mpErr := analyticsMgr.SendMeasurementProtocolPageView(title, navURL, ""); mpErr == nil {
+					analyticsErr = nil
+					analyticsMethod = "measurement_protocol"
+				}
+			}
+		}
+	}
+	if navErr == nil && tracker != nil {
+		// Sayfadaki izleyici page view'ı kendisi gönderir; yoksa backend'in HTTP API'si kullanılır
+		method, err := tracker.PageView(tabCtx)
+		analyticsMethod = tracker.Name() + "_" + method
+		if err != nil {
+			analyticsErr = fmt.Errorf("%w: %v", errclass.ErrAnalyticsMissing, err)
+			analyticsMethod = "missing"
+		}
+	}
+	trace.Step("analytics", analyticsMethod, analyticsMethod != "missing" && analyticsMethod != "none")
+
+	// Ziyarette gönderilen GA event'leri (BigQuery export ile GA4 verisi karşılaştırması için)
+	var events []string
+	if navErr == nil && analyticsErr == nil && measurementID != "" {
+		events = append(events, "page_view")
 	}
 
 	// Stealth scripts already injected via AddScriptToEvaluateOnNewDocument (runs before page load).
@@ -482,16 +719,21 @@ func (h *HitVisitor) VisitURL(ctx context.Context, urlStr string) error {
 			Strategy:    strategy,
 			ReadSpeed:   200,
 		}); err != nil {
-			_ = err
+			trace.Step("scroll", err.Error(), false)
+		} else {
+			trace.Step("scroll", strategy, true)
 		}
 
 		// Scroll event (GA4)
-		if h.config.SendScrollEvent && h.config.AnalyticsManager != nil {
-			if err := h.config.AnalyticsManager.SendEvent(tabCtx, analytics.Event{
+		if h.config.SendScrollEvent && analyticsMgr != nil {
+			if err := analyticsMgr.SendEvent(tabCtx, analytics.Event{
 				Type: analytics.EventScroll, Category: "engagement",
 				Action: "scroll", Label: "75%", Value: 75,
-			}); err != nil {
-				_ = err
+			}); err == nil {
+				events = append(events, "scroll")
+				trace.Step("scroll_event", "75%", true)
+			} else {
+				trace.Step("scroll_event", err.Error(), false)
 			}
 		}
 
@@ -501,6 +743,7 @@ func (h *HitVisitor) VisitURL(ctx context.Context, urlStr string) error {
 			mouseMoveProb = 0.0 // Mobil cihazlarda mouse hareketi yok
 		}
 		hum := behavior.NewHumanBehavior(&behavior.BehaviorConfig{
+			Rand:                 h.config.Seeder.NewRand(),
 			MinPageDuration:      1 * time.Second,
 			MaxPageDuration:      3 * time.Second,
 			ScrollProbability:    0.5,
@@ -508,9 +751,51 @@ func (h *HitVisitor) VisitURL(ctx context.Context, urlStr string) error {
 			ClickProbability:     0,
 		})
 		hum.SimulatePageVisit(tabCtx, 0)
+		trace.Step("behavior", "human page visit", true)
+
+		// Oturum sonu outbound click (partner sitesine gidilmez, yalnızca event)
+		if analyticsMgr != nil && analyticsErr == nil && measurementID != "" &&
+			len(h.config.OutboundDomains) > 0 && h.intn(100) < h.config.OutboundRate {
+			if href, err := OutboundClick(tabCtx, analyticsMgr, h.config.OutboundDomains); err != nil {
+				trace.Step("outbound_click", err.Error(), false)
+			} else if href == "" {
+				trace.Step("outbound_click", "no partner link on page", false)
+			} else {
+				events = append(events, "click")
+				trace.Step("outbound_click", href, true)
+			}
+		}
+		var depth int
+		if err := chromedp.Run(tabCtx, chromedp.Evaluate(scrollDepthJS, &depth)); err == nil {
+			trace.update(func(t *VisitTrace) { t.ScrollDepth = depth })
+			trace.Step("scroll_depth", fmt.Sprintf("%d%%", depth), true)
+		}
+	}
+
+	// "Analytics teslim edildi" gtag'in varlığına değil yakalanan collect isteklerine dayanır.
+	// Measurement Protocol yedeği sunucudan gider; onun sonucu gönderim hatasıyla belirlenir.
+	var delivery BeaconSummary
+	if navErr == nil && tracker == nil && measurementID != "" && analyticsMethod != "measurement_protocol" {
+		delivery = beacons.Wait(tabCtx, measurementID, BeaconWait)
+		if delivery.Delivered == 0 && analyticsErr == nil {
+			analyticsErr = errclass.ErrAnalyticsMissing
+			analyticsMethod = "missing"
+			events = nil
+		}
+		trace.Step("beacons", fmt.Sprintf("%d/%d delivered, %d bytes", delivery.Delivered, delivery.Beacons, delivery.Bytes), delivery.Delivered > 0)
 	}
 
 	elapsed := time.Since(start).Milliseconds()
+	trace.update(func(t *VisitTrace) {
+		t.PageIDs = append(t.PageIDs, pageIDs...)
+		t.MeasurementID = measurementID
+		t.AnalyticsMethod = analyticsMethod
+		t.Events = append(t.Events, events...)
+		t.DurationMs = elapsed
+		if navErr != nil {
+			t.Error, t.ErrorClass = navErr.Error(), errclass.Of(navErr)
+		}
+	})
 	
 	// SECURITY FIX: Proxy bilgisini extract et (callback için)
 	proxyStr := ""
@@ -520,14 +805,25 @@ func (h *HitVisitor) VisitURL(ctx context.Context, urlStr string) error {
 		}
 	}
 
+	requests, bytes := meter.Totals()
+	device, keyword := deviceLabel(deviceProfile, isMobile), searchKeyword(referrerURL)
 	if navErr != nil {
 		h.reporter.Record(reporter.HitRecord{
-			Timestamp: time.Now(),
-			URL:       urlStr,
-			Error:     navErr.Error(),
-			UserAgent: ua,
-			Proxy:     proxyStr,
+			Timestamp:  time.Now(),
+			URL:        urlStr,
+			Error:      navErr.Error(),
+			ErrorClass: errclass.Of(navErr),
+			UserAgent:  ua,
+			Proxy:      proxyStr,
+			SessionID:  trace.SessionID,
+			Device:     device,
+			Keyword:    keyword,
+			Requests:   requests,
+			Bytes:      bytes,
+			Variant:    variant,
 		})
+		trace.Step("exit", navErr.Error(), false)
+		h.reporter.RecordTimeline(trace.timeline(proxyStr, false))
 		return navErr
 	}
 
@@ -538,13 +834,190 @@ func (h *HitVisitor) VisitURL(ctx context.Context, urlStr string) error {
 	if statusCode == 0 {
 		statusCode = 200 // Fallback - event yakalanmadıysa
 	}
+	trace.update(func(t *VisitTrace) {
+		t.StatusCode = statusCode
+		t.Proxy = proxyStr
+		t.score()
+	})
+	// 403/429: hedef (veya WAF) bu IP'yi engelliyor - başarısız hit
+	if bannedErr := errclass.Status(statusCode); bannedErr != nil {
+		h.reporter.Record(reporter.HitRecord{
+			Timestamp:    time.Now(),
+			URL:          urlStr,
+			StatusCode:   statusCode,
+			ResponseTime: elapsed,
+			Error:        bannedErr.Error(),
+			ErrorClass:   errclass.Banned,
+			UserAgent:    ua,
+			Proxy:        proxyStr,
+			SessionID:    trace.SessionID,
+			Device:       device,
+			Keyword:      keyword,
+			Requests:     requests,
+			Bytes:        bytes,
+			Variant:      variant,
+		})
+		trace.update(func(t *VisitTrace) { t.Error, t.ErrorClass = bannedErr.Error(), errclass.Banned })
+		trace.Step("exit", bannedErr.Error(), false)
+		h.reporter.RecordTimeline(trace.timeline(proxyStr, false))
+		return bannedErr
+	}
+	// İçerik kontrolü (örneklenen ziyaretlerde): sayfa yüklendi ama beklenen içerik yoksa fonksiyonel hata
+	if cc := h.config.ContentChecks; cc.Sample(urlStr, h.intn) {
+		statusMu.Lock()
+		reqID := docRequestID
+		statusMu.Unlock()
+		checkErr := cc.Check(urlStr, statusCode, documentBody(tabCtx, reqID))
+		h.reporter.RecordContentCheck(checkErr)
+		if checkErr != nil {
+			h.reporter.Record(reporter.HitRecord{
+				Timestamp:    time.Now(),
+				URL:          urlStr,
+				StatusCode:   statusCode,
+				ResponseTime: elapsed,
+				Error:        checkErr.Error(),
+				ErrorClass:   errclass.Functional,
+				UserAgent:    ua,
+				Proxy:        proxyStr,
+				SessionID:    trace.SessionID,
+				Device:       device,
+				Keyword:      keyword,
+				Requests:     requests,
+				Bytes:        bytes,
+				Variant:      variant,
+			})
+			trace.update(func(t *VisitTrace) { t.Error, t.ErrorClass = checkErr.Error(), errclass.Functional })
+			trace.Step("content_check", checkErr.Error(), false)
+			h.reporter.RecordTimeline(trace.timeline(proxyStr, false))
+			return checkErr
+		}
+		trace.Step("content_check", "passed", true)
+	}
+	trace.Step("exit", fmt.Sprintf("%d, %s", statusCode, analyticsMethod), analyticsErr == nil)
+	h.reporter.RecordTimeline(trace.timeline(proxyStr, true))
 	h.reporter.Record(reporter.HitRecord{
 		Timestamp:    time.Now(),
 		URL:          urlStr,
 		StatusCode:   statusCode,
-		ResponseTime: elapsed,
-		UserAgent:    ua,
-		Proxy:        proxyStr,
+		ResponseTime:  elapsed,
+		ErrorClass:    errclass.Of(analyticsErr),
+		UserAgent:     ua,
+		Proxy:         proxyStr,
+		SessionID:     trace.SessionID,
+		Device:        device,
+		Keyword:       keyword,
+		MeasurementID: measurementID,
+		Events:        events,
+		Requests:      requests,
+		Bytes:         bytes,
+		Variant:       variant,
+		Beacons:       delivery.Beacons,
+		BeaconsDelivered: delivery.Delivered,
+		BeaconBytes:   delivery.Bytes,
 	})
 	return nil
 }
+
+// documentBody ana belgenin ham yanıt gövdesini döner; CDP gövdeyi bırakmışsa render edilmiş HTML'e düşer
+func documentBody(ctx context.Context, reqID network.RequestID) []byte {
+	if reqID != "" {
+		var body []byte
+		err := chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+			var err error
+			body, err = network.GetResponseBody(reqID).Do(ctx)
+			return err
+		}))
+		if err == nil {
+			return body
+		}
+	}
+	var html string
+	_ = chromedp.Run(ctx, chromedp.OuterHTML("html", &html, chromedp.ByQuery))
+	return []byte(html)
+}
+
+// deviceLabel hit kaydındaki cihaz adı: emüle edilen profil, yoksa UA havuzu için desktop/mobile
+func deviceLabel(d *mobile.DeviceProfile, isMobile bool) string {
+	switch {
+	case d != nil:
+		return d.Name
+	case isMobile:
+		return "mobile"
+	default:
+		return "desktop"
+	}
+}
+
+// searchKeyword arama referrer URL'sindeki anahtar kelimeyi (q parametresi) döner
+func searchKeyword(referrerURL string) string {
+	u, err := url.Parse(referrerURL)
+	if err != nil {
+		return ""
+	}
+	return u.Query().Get("q")
+}
+
+// GtagInjectScript gtagID için gtag.js yükleyip config eden script'i döner; ID geçersizse "" döner.
+// server ayarlıysa gtag.js birinci taraf sGTM'den yüklenir ve beacon'lar oraya gönderilir.
+func GtagInjectScript(gtagID string, server *analytics.ServerEndpoint) string {
+	if gtagID == "" {
+		return ""
+	}
+	// SECURITY FIX: Validate GtagID format to prevent XSS injection
+	// Valid GA4 format: G-XXXXXXXXXX or GT-XXXXXXXXXX (10-12 alphanumeric chars after prefix)
+	// Valid UA format: UA-XXXXXXXX-X (numeric with dashes)
+	isValidGtagID := false
+	
+	// Check GA4 format (G-XXXXXXXXXX or GT-XXXXXXXXXX)
+	if len(gtagID) >= 10 && len(gtagID) <= 15 {
+		if (strings.HasPrefix(gtagID, "G-") || strings.HasPrefix(gtagID, "GT-")) {
+			suffix := gtagID[strings.Index(gtagID, "-")+1:]
+			isValidGtagID = true
+			for _, c := range suffix {
+				if !((c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')) {
+					isValidGtagID = false
+					break
+				}
+			}
+		}
+	}
+	
+	// Check UA format (UA-XXXXXXXX-X)
+	if !isValidGtagID && len(gtagID) >= 10 && len(gtagID) <= 20 && strings.HasPrefix(gtagID, "UA-") {
+		isValidGtagID = true
+		for _, c := range gtagID[3:] {
+			if !((c >= '0' && c <= '9') || c == '-') {
+				isValidGtagID = false
+				break
+			}
+		}
+	}
+	
+	if !isValidGtagID {
+		return "" // If invalid GtagID, no injection
+	}
+	src := "https://www.googletagmanager.com/gtag/js?id=" + gtagID
+	params := "send_page_view:true"
+	if server != nil {
+		// server_container_url güncel ad, transport_url eski gtag sürümleri için
+		src = server.ScriptURL(gtagID)
+		params += ",server_container_url:'" + server.BaseURL() + "',transport_url:'" + server.BaseURL() + "'"
+	}
+	return `(function(){
+			var s=document.createElement('script');s.async=true;
+			s.src='` + src + `';
+			document.head.appendChild(s);
+			window.dataLayer=window.dataLayer||[];function gtag(){dataLayer.push(arguments);}
+			gtag('js',new Date());
+			gtag('config','` + gtagID + `',{` + params + `});
+		})();`
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,173 @@
+package browser
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/cdproto/storage"
+	"github.com/chromedp/chromedp"
+
+	"vgbot/pkg/vault"
+)
+
+// loginTimeout giriş formunun doldurulup başarı öğesinin görünmesi için üst süre
+const loginTimeout = 30 * time.Second
+
+// loginCookieFile profil dizininde giriş sonrası çerezlerin (vault anahtarıyla şifreli) saklandığı dosya
+const loginCookieFile = "cookies.enc"
+
+// LoginScenario ziyaretten önce yapılan betikli giriş: form doldurulur, başarı öğesi beklenir.
+// Önbellek profilleri açıksa giriş çerezleri profil başına saklanır ve sonraki ziyaretlerde yeniden kullanılır.
+type LoginScenario struct {
+	URL             string // Giriş sayfası
+	UserSelector    string // Kullanıcı adı alanı (CSS)
+	PassSelector    string // Şifre alanı (CSS)
+	SubmitSelector  string // Gönder düğmesi (CSS)
+	SuccessSelector string // Giriş yapılmış sayfalarda görünen öğe (ör. çıkış bağlantısı)
+	Credential      vault.Credential
+	Vault           *vault.Vault // Profil çerezlerini şifreler
+}
+
+// Validate senaryonun form alanlarının eksiksiz olduğunu kontrol eder (giriş bilgisi ve vault çağıranın işi)
+func (l *LoginScenario) Validate() error {
+	switch {
+	case l.URL == "":
+		return errors.New("login_url boş")
+	case !strings.HasPrefix(l.URL, "http://") && !strings.HasPrefix(l.URL, "https://"):
+		return errors.New("login_url http:// veya https:// ile başlamalı")
+	case l.UserSelector == "", l.PassSelector == "", l.SubmitSelector == "":
+		return errors.New("login_user_selector, login_pass_selector ve login_submit_selector gerekli")
+	case l.SuccessSelector == "":
+		return errors.New("login_success_selector gerekli (girişin doğrulanması için)")
+	}
+	return nil
+}
+
+// perform giriş sayfasını açar, formu doldurur ve başarı öğesini bekler
+func (l *LoginScenario) perform(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, loginTimeout)
+	defer cancel()
+	err := chromedp.Run(ctx,
+		chromedp.Navigate(l.URL),
+		chromedp.WaitVisible(l.UserSelector, chromedp.ByQuery),
+		chromedp.SendKeys(l.UserSelector, l.Credential.Username, chromedp.ByQuery),
+		chromedp.SendKeys(l.PassSelector, l.Credential.Password, chromedp.ByQuery),
+		chromedp.Click(l.SubmitSelector, chromedp.ByQuery),
+		chromedp.WaitVisible(l.SuccessSelector, chromedp.ByQuery),
+	)
+	if err != nil {
+		return fmt.Errorf("giriş başarısız (%s): %w", l.URL, err)
+	}
+	return nil
+}
+
+// loggedIn mevcut sayfada başarı öğesinin olup olmadığını döner
+func (l *LoginScenario) loggedIn(ctx context.Context) bool {
+	sel, _ := json.Marshal(l.SuccessSelector)
+	var ok bool
+	if err := chromedp.Run(ctx, chromedp.Evaluate("!!document.querySelector("+string(sel)+")", &ok)); err != nil {
+		return false
+	}
+	return ok
+}
+
+// saveCookies tarayıcının tüm çerezlerini profil dizinine şifreli yazar
+func (l *LoginScenario) saveCookies(ctx context.Context, profileDir string) error {
+	cookies, err := storage.GetCookies().Do(ctx)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(cookies)
+	if err != nil {
+		return err
+	}
+	sealed, err := l.Vault.Seal(data)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(profileDir, loginCookieFile), sealed, 0600)
+}
+
+// restoreCookies profilin kayıtlı çerezlerini tarayıcıya yükler; kayıt yoksa false döner
+func (l *LoginScenario) restoreCookies(ctx context.Context, profileDir string) (bool, error) {
+	sealed, err := os.ReadFile(filepath.Join(profileDir, loginCookieFile))
+	if err != nil {
+		return false, nil
+	}
+	data, err := l.Vault.Unseal(sealed)
+	if err != nil {
+		return false, err
+	}
+	var cookies []*network.Cookie
+	if err := json.Unmarshal(data, &cookies); err != nil {
+		return false, err
+	}
+	params := make([]*network.CookieParam, 0, len(cookies))
+	for _, c := range cookies {
+		p := &network.CookieParam{
+			Name:         c.Name,
+			Value:        c.Value,
+			Domain:       c.Domain,
+			Path:         c.Path,
+			Secure:       c.Secure,
+			HTTPOnly:     c.HTTPOnly,
+			SameSite:     c.SameSite,
+			Priority:     c.Priority,
+			SourceScheme: c.SourceScheme,
+			SourcePort:   c.SourcePort,
+			PartitionKey: c.PartitionKey,
+		}
+		if !c.Session {
+			exp := cdp.TimeSinceEpoch(time.Unix(int64(c.Expires), 0))
+			p.Expires = &exp
+		}
+		params = append(params, p)
+	}
+	if len(params) == 0 {
+		return false, nil
+	}
+	if err := storage.SetCookies(params).Do(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// forgetCookies profilin kayıtlı giriş çerezlerini siler (oturum süresi dolduğunda)
+func forgetCookies(profileDir string) {
+	if profileDir != "" {
+		os.Remove(filepath.Join(profileDir, loginCookieFile))
+	}
+}
+
+// ensureLogin ziyaret öncesi giriş: profilde kayıtlı oturum varsa çerezleri yükler, yoksa giriş yapar
+// ve çerezleri profile kaydeder. restored, kayıtlı oturumun kullanıldığını bildirir (ziyaret sonrası doğrulanır).
+func (h *HitVisitor) ensureLogin(ctx context.Context, profileDir string, trace *VisitTrace) (restored bool, err error) {
+	l := h.config.Login
+	if profileDir != "" {
+		ok, err := l.restoreCookies(ctx, profileDir)
+		if err != nil {
+			forgetCookies(profileDir)
+		}
+		if ok {
+			trace.Step("login", "saved session", true)
+			return true, nil
+		}
+	}
+	if err := l.perform(ctx); err != nil {
+		trace.Step("login", err.Error(), false)
+		return false, err
+	}
+	trace.Step("login", l.Credential.Username, true)
+	if profileDir != "" {
+		_ = l.saveCookies(ctx, profileDir)
+	}
+	return false, nil
+}
@@ -0,0 +1,26 @@
+package browser
+
+import "testing"
+
+func TestLoginScenarioValidate(t *testing.T) {
+	l := &LoginScenario{
+		URL:             "https://example.com/login",
+		UserSelector:    "#user",
+		PassSelector:    "#pass",
+		SubmitSelector:  "button[type=submit]",
+		SuccessSelector: "a.logout",
+	}
+	if err := l.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	bad := *l
+	bad.SuccessSelector = ""
+	if bad.Validate() == nil {
+		t.Fatal("missing success selector accepted")
+	}
+	bad = *l
+	bad.URL = "example.com/login"
+	if bad.Validate() == nil {
+		t.Fatal("relative login url accepted")
+	}
+}
@@ -0,0 +1,69 @@
+package browser
+
+import (
+	"net/url"
+
+	"vgbot/pkg/mobile"
+	"vgbot/pkg/referrer"
+)
+
+// keywordReferrerMix Keywords listesi verildiğinde referrer zincirinin dağılımı (yüzde)
+var keywordReferrerMix = referrer.ReferrerConfig{GooglePercent: 50, BingPercent: 20, DirectPercent: 30}
+
+// KeywordSource arama referrer kelimelerini düz Keywords listesi yerine sağlayan kaynak
+// (antidetect.KeywordClusterManager). Kaynakta cluster yoksa Keywords listesi kullanılır.
+type KeywordSource interface {
+	ClusterCount() int
+	NextKeyword() (keyword, clusterID string, ok bool)
+	RecordUsage(keyword, clusterID, sessionID string, success bool, responseMS int64)
+}
+
+// searchEngine keywordReferrerMix'e göre roll (0-99) için arama motorunu döner; "" = direct
+func searchEngine(roll int) string {
+	m := keywordReferrerMix
+	switch {
+	case roll < m.GooglePercent:
+		return "google"
+	case roll < m.GooglePercent+m.BingPercent:
+		return "bing"
+	}
+	return ""
+}
+
+// searchReferrerURL motorun arama sonuç sayfası URL'si (rapordaki keyword searchKeyword ile q'dan okunur)
+func searchReferrerURL(engine, keyword string) string {
+	if engine == "bing" {
+		return "https://www.bing.com/search?q=" + url.QueryEscape(keyword)
+	}
+	return "https://www.google.com/search?q=" + url.QueryEscape(keyword)
+}
+
+// DeviceShares VisitURLAs'ın cihaz seçimine göre beklenen cihaz tipi dağılımını döner (0-1).
+// Cihaz tipi/marka filtresi yoksa UA havuzu kullanılır ("user_agent_pool").
+func DeviceShares(deviceType string, brands []string) map[string]float64 {
+	if (deviceType == "" || deviceType == "mixed") && len(brands) == 0 {
+		return map[string]float64{"user_agent_pool": 1}
+	}
+	devices := mobile.FilterDevices(deviceType, brands)
+	shares := make(map[string]float64)
+	for _, d := range devices {
+		shares[string(d.Type)] += 1 / float64(len(devices))
+	}
+	return shares
+}
+
+// ReferrerShares VisitURLAs'ın referrer önceliğine göre beklenen referrer dağılımını döner (0-1)
+func ReferrerShares(enabled bool, keyword string, keywords []string) map[string]float64 {
+	switch {
+	case enabled && keyword != "":
+		return map[string]float64{"google": 1}
+	case len(keywords) > 0:
+		m := keywordReferrerMix
+		return map[string]float64{
+			"google": float64(m.GooglePercent) / 100,
+			"bing":   float64(m.BingPercent) / 100,
+			"direct": float64(m.DirectPercent) / 100,
+		}
+	}
+	return map[string]float64{"direct": 1}
+}
@@ -0,0 +1,78 @@
+package browser
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/chromedp/chromedp"
+
+	"vgbot/pkg/analytics"
+)
+
+// NormalizeOutboundDomains partner domain girdilerini küçük harfli host'lara çevirir ("https://x.com/a" → "x.com")
+func NormalizeOutboundDomains(entries []string) ([]string, error) {
+	var out []string
+	for _, e := range entries {
+		e = strings.ToLower(strings.TrimSpace(e))
+		if e == "" {
+			continue
+		}
+		if strings.Contains(e, "://") {
+			u, err := url.Parse(e)
+			if err != nil || u.Hostname() == "" {
+				return nil, fmt.Errorf("geçersiz outbound domain %q", e)
+			}
+			e = u.Hostname()
+		}
+		e = strings.TrimPrefix(strings.TrimSuffix(e, "/"), "www.")
+		if strings.ContainsAny(e, "/ :?#") {
+			return nil, fmt.Errorf("geçersiz outbound domain %q", e)
+		}
+		out = append(out, e)
+	}
+	return out, nil
+}
+
+// outboundLinkJS sayfadaki ilk partner domain bağlantısının href'ini döner (yoksa "")
+const outboundLinkJS = `(function(domains){
+	var links = document.querySelectorAll('a[href]');
+	for (var i = 0; i < links.length; i++) {
+		var a = links[i];
+		if (!/^https?:$/.test(a.protocol) || a.hostname === location.hostname) continue;
+		var host = a.hostname.toLowerCase().replace(/^www\./, '');
+		for (var j = 0; j < domains.length; j++) {
+			var d = domains[j];
+			if (host === d || host.slice(-(d.length + 1)) === '.' + d) return a.href;
+		}
+	}
+	return '';
+})(%s)`
+
+// OutboundClick sayfadaki partner bağlantısı için GA4 outbound click event'ini gönderir.
+// Partner sitesine gidilmez: yalnızca hedef mülkün outbound link takibi test edilir.
+// Sayfada partner bağlantısı yoksa boş href ve nil döner.
+func OutboundClick(ctx context.Context, mgr *analytics.Manager, domains []string) (string, error) {
+	list, _ := json.Marshal(domains)
+	var href string
+	if err := chromedp.Run(ctx, chromedp.Evaluate(fmt.Sprintf(outboundLinkJS, list), &href)); err != nil || href == "" {
+		return "", err
+	}
+	u, err := url.Parse(href)
+	if err != nil {
+		return "", err
+	}
+	return href, mgr.SendEvent(ctx, analytics.Event{
+		Type:     analytics.EventClick,
+		Category: "outbound",
+		Action:   "click",
+		Label:    href,
+		Parameters: map[string]interface{}{
+			"link_url":    href,
+			"link_domain": u.Hostname(),
+			"outbound":    true,
+		},
+	})
+}
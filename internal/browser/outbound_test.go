@@ -0,0 +1,21 @@
+package browser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeOutboundDomains(t *testing.T) {
+	got, err := NormalizeOutboundDomains([]string{"Partner.com", " https://www.shop.example/path ", "", "www.blog.net/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"partner.com", "shop.example", "blog.net"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("domains = %v, want %v", got, want)
+	}
+	for _, bad := range []string{"partner.com/a", "host:8080", "https://"} {
+		if _, err := NormalizeOutboundDomains([]string{bad}); err == nil {
+			t.Errorf("%q accepted", bad)
+		}
+	}
+}
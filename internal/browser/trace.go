@@ -0,0 +1,118 @@
+package browser
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"vgbot/internal/reporter"
+	"vgbot/pkg/analytics"
+)
+
+// scrollDepthJS sayfanın ne kadarının görüntülendiğini yüzde olarak döner
+const scrollDepthJS = `(() => {
+	const h = Math.max(document.documentElement.scrollHeight, document.body ? document.body.scrollHeight : 0);
+	if (!h) return 0;
+	return Math.min(100, Math.round((window.scrollY + window.innerHeight) / h * 100));
+})()`
+
+// TraceStep ziyaretteki tek bir adım; dashboard zaman çizelgesiyle aynı biçim
+type TraceStep = reporter.TimelineStep
+
+// VisitTrace tek bir ziyaretin adım adım izi (/api/testvisit ve son oturum zaman çizelgeleri)
+type VisitTrace struct {
+	mu              sync.Mutex
+	start           time.Time
+	SessionID       string      `json:"session_id"`
+	URL             string      `json:"url"`
+	UserAgent       string      `json:"user_agent"`
+	Mobile          bool        `json:"mobile"`
+	Proxy           string      `json:"proxy,omitempty"`
+	Referrer        string      `json:"referrer,omitempty"`
+	StatusCode      int         `json:"status_code,omitempty"`
+	PageIDs         []string    `json:"page_measurement_ids"`
+	MeasurementID   string      `json:"measurement_id,omitempty"`
+	AnalyticsMethod string      `json:"analytics_method"` // page_gtag, injected_gtag, measurement_protocol, missing, none
+	Events          []string    `json:"events"`
+	ScrollDepth     int         `json:"scroll_depth"`
+	DurationMs      int64       `json:"duration_ms"`
+	QualityScore    float64     `json:"quality_score"`
+	QualityGrade    string      `json:"quality_grade"`
+	Error           string      `json:"error,omitempty"`
+	ErrorClass      string      `json:"error_class,omitempty"`
+	Steps           []TraceStep `json:"steps"`
+}
+
+// NewVisitTrace boş iz oluşturur
+func NewVisitTrace() *VisitTrace {
+	return &VisitTrace{start: time.Now(), SessionID: reporter.NewSessionID(), PageIDs: []string{}, Events: []string{}, Steps: []TraceStep{}}
+}
+
+// Step ize adım ekler (nil iz üzerinde no-op)
+func (t *VisitTrace) Step(step, detail string, ok bool) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.Steps = append(t.Steps, TraceStep{
+		AtMs:   time.Since(t.start).Milliseconds(),
+		Step:   step,
+		Detail: detail,
+		OK:     ok,
+	})
+}
+
+// update iz alanlarını kilit altında günceller (nil iz üzerinde no-op)
+func (t *VisitTrace) update(fn func(t *VisitTrace)) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	fn(t)
+}
+
+// score tek sayfalık ziyaretin kalite skorunu hesaplar (t.mu tutulurken çağrılır)
+func (t *VisitTrace) score() {
+	m := analytics.TrafficQualityMetrics{
+		SessionDuration: time.Duration(t.DurationMs) * time.Millisecond,
+		PageViews:       1,
+		ScrollDepth:     t.ScrollDepth,
+		BounceRate:      1,
+	}
+	// GA4: 10sn+ ön planda kalan oturum engaged sayılır
+	if m.SessionDuration >= 10*time.Second {
+		m.EngagementRate = 1
+	}
+	t.QualityScore = m.CalculateQualityScore()
+	t.QualityGrade = m.GetQualityGrade()
+}
+
+// timeline izi dashboard'a gönderilecek zaman çizelgesine çevirir
+func (t *VisitTrace) timeline(proxy string, success bool) reporter.SessionTimeline {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return reporter.SessionTimeline{
+		SessionID: t.SessionID,
+		URL:       t.URL,
+		Start:     t.start,
+		UserAgent: t.UserAgent,
+		Proxy:     proxy,
+		Success:   success,
+		Steps:     append([]TraceStep(nil), t.Steps...),
+	}
+}
+
+type traceKey struct{}
+
+// WithTrace ziyaret context'ine iz ekler; HitVisitor adımları buna yazar
+func WithTrace(ctx context.Context, t *VisitTrace) context.Context {
+	return context.WithValue(ctx, traceKey{}, t)
+}
+
+// traceFrom context'teki izi döner (yoksa nil)
+func traceFrom(ctx context.Context) *VisitTrace {
+	t, _ := ctx.Value(traceKey{}).(*VisitTrace)
+	return t
+}
@@ -0,0 +1,35 @@
+package browser
+
+import (
+	"context"
+	"testing"
+)
+
+func TestVisitTraceNilSafe(t *testing.T) {
+	var tr *VisitTrace
+	tr.Step("navigate", "", true)
+	tr.update(func(t *VisitTrace) { t.URL = "x" })
+	if traceFrom(context.Background()) != nil {
+		t.Fatal("expected nil trace without WithTrace")
+	}
+}
+
+func TestVisitTraceStepsAndScore(t *testing.T) {
+	tr := NewVisitTrace()
+	ctx := WithTrace(context.Background(), tr)
+	traceFrom(ctx).Step("referrer", "direct", true)
+	traceFrom(ctx).Step("analytics", "missing", false)
+	if len(tr.Steps) != 2 || tr.Steps[0].Step != "referrer" || tr.Steps[1].OK {
+		t.Fatalf("steps = %+v", tr.Steps)
+	}
+
+	tr.update(func(t *VisitTrace) {
+		t.DurationMs = 45000
+		t.ScrollDepth = 60
+		t.score()
+	})
+	// 10 (süre) + 5 (1 sayfa) + 10 (scroll) + 0 (bounce) + 15 (engaged)
+	if tr.QualityScore != 40 || tr.QualityGrade == "" {
+		t.Fatalf("score = %v grade = %q", tr.QualityScore, tr.QualityGrade)
+	}
+}
@@ -0,0 +1,40 @@
+package browser
+
+import (
+	"context"
+	"sync"
+
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+)
+
+// ResourceMeter bir sekmede tamamlanan istekleri ve aktarılan baytları (CDP network olayları) sayar.
+// Önbellekten gelen yanıtlar istek olarak sayılır, aktarılan bayta eklenmez.
+type ResourceMeter struct {
+	mu       sync.Mutex
+	requests int
+	bytes    int64
+}
+
+// Listen sekme context'indeki network olaylarını dinlemeye başlar (network.Enable gerekir)
+func (m *ResourceMeter) Listen(tabCtx context.Context) {
+	chromedp.ListenTarget(tabCtx, func(ev interface{}) {
+		if ev, ok := ev.(*network.EventLoadingFinished); ok {
+			m.add(int64(ev.EncodedDataLength))
+		}
+	})
+}
+
+func (m *ResourceMeter) add(n int64) {
+	m.mu.Lock()
+	m.requests++
+	m.bytes += n
+	m.mu.Unlock()
+}
+
+// Totals o ana kadar tamamlanan istek sayısını ve aktarılan bayt toplamını döner
+func (m *ResourceMeter) Totals() (requests int, bytes int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.requests, m.bytes
+}
@@ -42,6 +42,22 @@ func (p *PrivateProxy) Key() string {
 	return fmt.Sprintf("%s:%d", p.Host, p.Port)
 }
 
+// URLWeight trafiğin belirli bir payının yönlendirileceği giriş sayfası (ağırlık göreli; 0 = kapalı)
+type URLWeight struct {
+	URL    string `yaml:"url" json:"url"`       // Tam URL veya hedef domain'e göre /path
+	Weight int    `yaml:"weight" json:"weight"`
+}
+
+// ContentCheck URL desenine uyan sayfalarda örneklenen ziyaretlerin yanıt doğrulaması (boş alanlar kontrol edilmez)
+type ContentCheck struct {
+	URL       string `yaml:"url" json:"url"`                               // "/blog/*" (path) veya tam URL deseni; "*" tümü
+	Status    int    `yaml:"status" json:"status,omitempty"`               // Beklenen status kodu
+	Contains  string `yaml:"contains" json:"contains,omitempty"`           // Gövdede geçmesi gereken metin
+	Regex     string `yaml:"regex" json:"regex,omitempty"`                 // Gövdenin eşleşmesi gereken regex
+	JSONPath  string `yaml:"json_path" json:"json_path,omitempty"`         // JSON gövdede bulunması gereken yol (ör. "data.items.0.id")
+	JSONValue string `yaml:"json_value" json:"json_value,omitempty"`       // JSONPath'teki beklenen değer (boşsa yalnızca varlık)
+}
+
 // Config uygulama konfigürasyonu
 type Config struct {
 	TargetDomain        string        `yaml:"target_domain"`
@@ -58,8 +74,28 @@ type Config struct {
 	ProxyURL            string        `yaml:"-"`
 	ProxyBaseURL        string        `yaml:"-"` // auth olmadan host:port
 	GtagID               string        `yaml:"gtag_id"`
+	GA4APISecret         string        `yaml:"ga4_api_secret"` // Measurement Protocol fallback (gtag yüklenemezse)
+	GA4Properties        []GA4Property `yaml:"ga4_properties"` // Çoklu mülk: ziyaretler paylara göre dağıtılır (boşsa GtagID)
+	GA4EventMapping      GA4EventMapping `yaml:"ga4_event_mapping"` // Özel event adları/parametreleri (boşsa standart şema)
+	GA4ServerURL         string        `yaml:"ga4_server_url"`          // Birinci taraf sGTM adresi (https://sgtm.site.com); boşsa Google'a doğrudan
+	GA4ServerScriptPath  string        `yaml:"ga4_server_script_path"`  // sGTM gtag.js yolu (boşsa /gtag/js)
+	GA4ServerCollectPath string        `yaml:"ga4_server_collect_path"` // sGTM Measurement Protocol yolu (boşsa /mp/collect)
+	GA4ServerHeaders     []string      `yaml:"ga4_server_headers"`      // sGTM Measurement Protocol isteklerine eklenen "Name: value" header'ları
+	GA4TrafficType       string        `yaml:"ga4_traffic_type"`        // Doluysa her GA4 event'i traffic_type=<değer> taşır (ör. internal); GA4 iç trafik filtresiyle ayrılır
+	AnalyticsBackend     string        `yaml:"analytics_backend"`       // ga4 (varsayılan), matomo veya plausible; GA dışı backend'de gtag enjekte edilmez
+	MatomoURL            string        `yaml:"matomo_url"`              // Matomo kök adresi (https://matomo.site.com); izleyici sayfada yoksa Tracking API kullanılır
+	MatomoSiteID         int           `yaml:"matomo_site_id"`          // Matomo site ID (idsite)
+	PlausibleURL         string        `yaml:"plausible_url"`           // Plausible adresi (boşsa https://plausible.io)
+	PlausibleDomain      string        `yaml:"plausible_domain"`        // Plausible'daki site adı (boşsa target_domain)
 	LogLevel             string        `yaml:"log_level"`
 	ExportFormat         string        `yaml:"export_format"`
+	HitLogJSONL          bool          `yaml:"hit_log_jsonl"`           // Hit'leri çalıştırma sırasında output_dir/vgbot_hits_<ts>.jsonl dosyasına yazar
+	SessionLogJSONL      bool          `yaml:"session_log_jsonl"`       // Ziyaret adımlarını output_dir/sessions/*.jsonl dosyasına yapılandırılmış olay olarak yazar
+	ReportTitle          string        `yaml:"report_title"`            // HTML rapor markalaması (boşsa varsayılan başlık)
+	ReportLogo           string        `yaml:"report_logo"`             // http(s) URL veya yerel görsel; yerel dosya rapora gömülür
+	ReportPrimaryColor   string        `yaml:"report_primary_color"`    // #rrggbb
+	ReportBackgroundColor string       `yaml:"report_background_color"` // #rrggbb
+	ReportFooter         string        `yaml:"report_footer"`
 	OutputDir            string        `yaml:"output_dir"`
 	MaxConcurrentVisits  int           `yaml:"max_concurrent_visits"`
 	CanvasFingerprint    bool          `yaml:"canvas_fingerprint"`
@@ -67,15 +103,35 @@ type Config struct {
 	SendScrollEvent       bool          `yaml:"send_scroll_event"`
 	UseSitemap            bool          `yaml:"use_sitemap"`
 	SitemapHomepageWeight int           `yaml:"sitemap_homepage_weight"` // 0-100, anasayfa yüzdesi
+	SitemapCacheDir       string        `yaml:"sitemap_cache_dir"`       // Sitemap URL listesi önbelleği
+	SitemapCacheHours     int           `yaml:"sitemap_cache_hours"`     // Önbellekteki liste bu süre boyunca kullanılır (<0 = kapalı)
+	LandingPages          []string      `yaml:"landing_pages"` // Giriş sayfası ağırlıkları ("/fiyatlar=40"); boşsa anasayfa/keşfedilen sayfalar
+	URLWeights            []URLWeight   `yaml:"url_weights"`   // Aynı dağılımın yapılandırılmış hali; landing_pages ile birlikte kullanılabilir
+	NotFoundRate          int           `yaml:"not_found_rate"` // Var olmayan URL ziyareti yüzdesi (404 denemesi; raporda ayrı sayılır)
+	ContentChecks         []ContentCheck `yaml:"content_checks"`    // URL desenine göre yanıt doğrulamaları; başarısızlık fonksiyonel hata sayılır
+	ContentCheckRate      int           `yaml:"content_check_rate"` // Eşleşen ziyaretlerin kontrol edilen yüzdesi (0 = %10)
 	Keywords              []string      `yaml:"keywords"`
+	Seed                  int64         `yaml:"seed"` // 0 = rastgele; aynı seed ile çalıştırma tekrarlanabilir
+	LeakCheck             bool          `yaml:"leak_check"` // Stop sonrası kapanmayan goroutine'leri raporla (debug)
 	// Public proxy: listelerden çek, checker ile test et, çalışanlarla vur
 	UsePublicProxy   bool     `yaml:"use_public_proxy"`
 	ProxySourceURLs  []string `yaml:"proxy_source_urls"`  // Boşsa varsayılan listeler
 	GitHubRepos      []string `yaml:"github_repos"`      // GitHub repo URL'leri: tüm .txt indirilir, test yok
 	CheckerWorkers   int     `yaml:"checker_workers"`   // Aynı anda test eden worker sayısı
+	ProxyCheckCacheHours int  `yaml:"proxy_check_cache_hours"` // Aynı gün bu süre içinde test edilmiş proxy tekrar test edilmez (<0 = kapalı)
+	GitHubFetchConcurrency int      `yaml:"github_fetch_concurrency"` // GitHub'dan aynı anda indirilen dosya sayısı
+	GitHubRepoAllowlist    []string `yaml:"github_repo_allowlist"`    // "owner" veya "owner/repo"; boşsa her repo
 	// Private proxy listesi (kullanıcının kendi proxy'leri)
 	PrivateProxies   []PrivateProxy `yaml:"private_proxies"`
 	UsePrivateProxy  bool           `yaml:"use_private_proxy"` // Private proxy modu aktif mi
+	// Proxy başına kullanım sınırları (0 = sınırsız)
+	ProxyMaxHitsPerHour  int `yaml:"proxy_max_hits_per_hour"` // Son 1 saatte proxy başına en fazla ziyaret
+	ProxyCooldownAfter   int `yaml:"proxy_cooldown_after"`    // Dinlenmeden N kullanımdan sonra proxy dinlenir (proxy başına)
+	ProxyCooldownMinutes int `yaml:"proxy_cooldown_minutes"`  // Dinlenme süresi (dakika)
+	// Proxy sağlık skoru (0 = kapalı): skoru eşiğin altına düşen proxy karantinaya, tekrar edenler kara listeye alınır
+	ProxyMinHealthScore    int `yaml:"proxy_min_health_score"`   // 1-100; son ziyaretlerin başarı oranı ve gecikmesinden
+	ProxyQuarantineMinutes int `yaml:"proxy_quarantine_minutes"` // Karantina süresi (dakika)
+	ProxyBlacklistAfter    int `yaml:"proxy_blacklist_after"`    // Bu kadar karantinadan sonra kalıcı kara liste
 	// Cihaz emülasyonu ayarları
 	DeviceType         string   `yaml:"device_type"`          // "desktop", "mobile", "tablet", "mixed"
 	DeviceBrands       []string `yaml:"device_brands"`        // ["apple", "samsung", "google", "windows", "linux"]
@@ -83,6 +139,9 @@ type Config struct {
 	ReferrerKeyword    string   `yaml:"referrer_keyword"`     // Google arama referrer için kelime
 	ReferrerEnabled    bool     `yaml:"referrer_enabled"`     // Referrer simülasyonu aktif mi
 	ReferrerSource     string   `yaml:"referrer_source"`      // google, bing, yahoo, duckduckgo, mixed, direct
+	// Outbound click: partner bağlantıları için GA4 click (outbound:true) event'i; partner sitesine gidilmez
+	OutboundDomains    []string `yaml:"outbound_domains"`     // Partner domain'leri
+	OutboundClickRate  int      `yaml:"outbound_click_rate"`  // Ziyaretlerin yüzde kaçında (0 = kapalı)
 	
 	// Traffic Simulation Settings
 	MinPageDuration    int      `yaml:"min_page_duration"`    // Minimum sayfa süresi (saniye)
@@ -127,6 +186,41 @@ type Config struct {
 	GscApiKey            string `yaml:"gsc_api_key"`            // GSC API key (JSON)
 	EnableGscIntegration bool   `yaml:"enable_gsc_integration"` // GSC entegrasyonu aktif mi
 	UseGscQueries        bool   `yaml:"use_gsc_queries"`        // GSC sorgularını kullan
+	GscCacheDir          string `yaml:"gsc_cache_dir"`          // GSC sorgu sonuçları önbelleği (property + tarih aralığı)
+	GscCacheHours        int    `yaml:"gsc_cache_hours"`        // Önbellekteki sonuç bu süre kullanılır (<0 = kapalı)
+
+	// BigQuery export: simüle edilen event/session satırları GA4 BigQuery export'u ile karşılaştırma için
+	BigQueryExport          bool   `yaml:"bigquery_export"`
+	BigQueryProject         string `yaml:"bigquery_project"`          // Boşsa Service Account'un projesi
+	BigQueryDataset         string `yaml:"bigquery_dataset"`
+	BigQueryTable           string `yaml:"bigquery_table"`
+	BigQueryCredentialsFile string `yaml:"bigquery_credentials_file"` // Service Account JSON yolu (boşsa GSC anahtarı)
+	
+	// Tarayıcı konumu (chromelocator)
+	BrowserPath           string   `yaml:"browser_path"`            // Chrome/Chromium/Edge yolu (boşsa otomatik bulunur)
+	BrowserAutoDownload   bool     `yaml:"browser_auto_download"`   // Uyumlu tarayıcı yoksa sabitlenmiş Chromium'u indir
+	BrowserCacheDir       string   `yaml:"browser_cache_dir"`       // İndirilen Chromium dizini
+	BrowserDownloadSHA256 string   `yaml:"browser_download_sha256"` // İndirilen Chromium arşivinin beklenen SHA-256'sı (boşsa sabitlenmiş özet)
+	BrowserHeadlessMode   string   `yaml:"browser_headless_mode"`   // old, new (--headless=new), off
+	BrowserExtraFlags     []string `yaml:"browser_extra_flags"`     // Ek Chrome flag'leri ("flag=değer", "pool:flag"); yalnızca chromeflags izin listesindekiler
+	BrowserExtensions     []string `yaml:"browser_extensions"`      // Yüklenecek paketlenmemiş eklenti dizinleri (headless new/off)
+	
+	// Staging provası: production host → staging IP/host ("example.com=10.0.0.5").
+	// URL, referrer ve analytics payload'ları production'ı gösterir; bağlantılar staging'e gider.
+	HostMap []string `yaml:"host_map"`
+	// Korumalı staging: tüm isteklere eklenen basic auth ve ek header'lar ("X-Staging-Token: abc")
+	BasicAuthUser string   `yaml:"basic_auth_user"`
+	BasicAuthPass string   `yaml:"basic_auth_pass"`
+	ExtraHeaders  []string `yaml:"extra_headers"`
+	
+	// Trafik işareti: canlı sitelerde simüle trafiğin analytics'te filtrelenebilmesi için
+	TrafficMarker     string `yaml:"traffic_marker"`      // "vgbot=1" (boş = kapalı)
+	TrafficMarkerMode string `yaml:"traffic_marker_mode"` // query (?vgbot=1), cookie, both
+	
+	// A/B deney bölmesi: oturumlar varyantlara dağıtılır, GA event'leri experiment_variant ile etiketlenir
+	ExperimentParam    string   `yaml:"experiment_param"`    // Çerez/sorgu parametresi adı, ör. "exp" (boş = kapalı)
+	ExperimentVariants []string `yaml:"experiment_variants"` // "control=50", "b=50"
+	ExperimentMode     string   `yaml:"experiment_mode"`     // cookie, query, both
 	
 	// Returning Visitor Simulation
 	ReturningVisitorRate   int  `yaml:"returning_visitor_rate"`   // Returning visitor oranı (%)
@@ -256,7 +350,7 @@ type Config struct {
 	MaxMemoryMB            int  `yaml:"max_memory_mb"`              // Max memory limit (MB)
 	
 	// NETWORK OPTIMIZATIONS
-	EnableHTTP3            bool   `yaml:"enable_http3"`               // HTTP/3 QUIC support
+	EnableHTTP3            bool   `yaml:"enable_http3"`               // Tarayıcısız hit'lerde HTTP/3 (QUIC) dene, olmazsa HTTP/2'ye düş
 	EnableConnectionPool   bool   `yaml:"enable_connection_pool"`     // Connection pooling
 	EnableTCPFastOpen      bool   `yaml:"enable_tcp_fast_open"`       // TCP Fast Open
 	ConnectionPoolSize     int    `yaml:"connection_pool_size"`       // Pool size
@@ -283,6 +377,35 @@ type Config struct {
 	EnableTelegramNotify   bool   `yaml:"enable_telegram_notify"`     // Telegram bildirimi aktif mi
 	TelegramReportInterval int    `yaml:"telegram_report_interval"`   // Periyodik rapor aralığı (dakika)
 	
+	// HATA BİLDİRİMİ: aynı sınıftan hatalar kısa sürede birikince açık kanallara tek bildirim
+	NotifyErrorThreshold int `yaml:"notify_error_threshold"`  // Pencerede bu kadar aynı sınıf hata (<0 = kapalı)
+	NotifyErrorWindowMin int `yaml:"notify_error_window_min"` // Pencere (dakika); aynı sınıf bu süre içinde tekrar bildirilmez
+	// WEBHOOK NOTIFICATION (Slack/Discord/Mattermost veya genel JSON)
+	WebhookURL            string `yaml:"webhook_url"`             // Incoming webhook adresi
+	WebhookFormat         string `yaml:"webhook_format"`          // slack, mattermost, discord, json
+	EnableWebhookNotify   bool   `yaml:"enable_webhook_notify"`   // Webhook bildirimi aktif mi
+	WebhookReportInterval int    `yaml:"webhook_report_interval"` // Periyodik rapor aralığı (dakika)
+	// UPTIME MONITOR: küçük URL kümesini aralıklarla kontrol eder, başarısızlıkta Telegram uyarısı
+	MonitorURLs          []string `yaml:"monitor_urls"`           // Kontrol edilecek URL'ler (boşsa hedef domain ana sayfası; "/path" hedef domaine göre)
+	MonitorIntervalMin   int      `yaml:"monitor_interval_min"`   // Kontrol aralığı (dakika)
+	MonitorProbes        []string `yaml:"monitor_probes"`         // Kontrol noktaları: "direct", "eu=http://host:port", "private" (tüm private proxy'ler)
+	MonitorFailThreshold int      `yaml:"monitor_fail_threshold"` // Uyarı için art arda başarısız kontrol sayısı
+	
+	// SAKLAMA: raporlar, SERP raporları, tarayıcı profilleri ve oturumlar için (0 = sınır yok)
+	RetentionDays        int `yaml:"retention_days"`         // Bundan eski girdiler silinir
+	RetentionMaxMB       int `yaml:"retention_max_mb"`       // Kategori başına en fazla disk kullanımı (MB); aşılırsa en eskiler silinir
+	RetentionIntervalMin int `yaml:"retention_interval_min"` // Otomatik temizlik aralığı (dakika)
+	
+	// GİRİŞLİ OTURUM: ziyaretten önce betikli giriş; bilgiler şifreli vault'tan (parola VGBOT_VAULT_KEY ortam değişkeninde)
+	LoginEnabled         bool   `yaml:"login_enabled"`          // Giriş senaryosu aktif mi
+	LoginURL             string `yaml:"login_url"`              // Giriş sayfası
+	LoginUserSelector    string `yaml:"login_user_selector"`    // Kullanıcı adı alanı (CSS)
+	LoginPassSelector    string `yaml:"login_pass_selector"`    // Şifre alanı (CSS)
+	LoginSubmitSelector  string `yaml:"login_submit_selector"`  // Gönder düğmesi (CSS)
+	LoginSuccessSelector string `yaml:"login_success_selector"` // Giriş yapılmış sayfalarda görünen öğe (ör. çıkış bağlantısı)
+	LoginCredential      string `yaml:"login_credential"`       // Vault'taki giriş bilgisinin adı
+	VaultFile            string `yaml:"vault_file"`             // Şifreli vault dosyası
+	
 	// SOCIAL MEDIA REFERRER
 	EnableSocialReferrer   bool     `yaml:"enable_social_referrer"`   // Sosyal medya referrer aktif mi
 	SocialPlatforms        []string `yaml:"social_platforms"`         // Aktif platformlar
@@ -293,6 +416,31 @@ type Config struct {
 	// SCHEDULER
 	EnableScheduler        bool   `yaml:"enable_scheduler"`           // Scheduler aktif mi
 	SchedulerJobsFile      string `yaml:"scheduler_jobs_file"`        // Scheduler jobs dosyası
+	MetricsHistoryFile     string `yaml:"metrics_history_file"`       // Dashboard için dakikalık metrik geçmişi (metrics_store json iken)
+	KeywordClustersFile    string `yaml:"keyword_clusters_file"`      // Keyword cluster'ları (/api/keywords/clusters); arama referrer kelimeleri buradan gelir
+	KillSwitchFile         string `yaml:"kill_switch_file"`           // Kill switch durumu; devredeyse yeniden başlatmada da çalıştırma başlatılmaz
+	ConsentFile            string `yaml:"consent_file"`               // Kullanım koşulları onayı ve hedef domain izin listesi
+	AuditLogFile           string `yaml:"audit_log_file"`             // Onay/izin listesi denetim kaydı (JSON Lines, yalnızca sona eklenir)
+	WizardSecretFile       string `yaml:"wizard_secret_file"`         // Sihirbazın alan adı doğrulama token'larını üreten anahtar; yeniden başlatmada token'lar değişmez
+	MetricsAddr            string `yaml:"metrics_addr"`               // Prometheus /metrics için ayrı admin adresi (ör. 127.0.0.1:9091); boşsa kapalı
+	MetricsStore           string `yaml:"metrics_store"`              // Metrik geçmişi deposu: json (varsayılan) veya bolt (uzun saklama için)
+	MetricsDBFile          string `yaml:"metrics_db_file"`            // metrics_store bolt iken BoltDB dosyası
+	MetricsRetentionDays   int    `yaml:"metrics_retention_days"`     // Dakikalık metrik geçmişinin saklandığı gün sayısı
+	ActiveWindows          []string `yaml:"active_windows"`           // Yalnızca bu aralıklarda çalış ("weekday 09:00-18:00")
+	BlackoutWindows        []string `yaml:"blackout_windows"`         // Asla çalışma ("02:00-05:00" bakım penceresi)
+	LoadMode               string   `yaml:"load_mode"`                // "open" (varsayılan, HPM ile) veya "closed" (sabit sayıda sanal kullanıcı)
+	LoadLevels             []int    `yaml:"load_levels"`              // closed modda sırayla uygulanan sanal kullanıcı sayıları; süre eşit bölünür
+	LoadProfile            string   `yaml:"load_profile"`             // step, spike, soak veya custom; aşamalar açık modda HPM'i, closed modda sanal kullanıcıyı değiştirir
+	LoadStages             []string `yaml:"load_stages"`              // "süre:seviye" aşamaları (ör. "5m:20"); boşsa profilin varsayılan şekli
+	Assertions             []string `yaml:"assertions"`               // Çalıştırma sonunda kontrol edilen SLA koşulları (ör. "p95_latency<800ms"); CLI başarısızlıkta sıfır dışı kodla çıkar
+	WarmupSeconds          int      `yaml:"warmup_seconds"`           // Hit fazının ilk N saniyesi çalıştırılır ama istatistik ve SLA dışında tutulur (0 = kapalı)
+	
+	// DISTRIBUTED (master/worker)
+	EnableDistributed      bool   `yaml:"enable_distributed"`         // Start butonu kampanyayı worker'lara dağıtır
+	DistributedBindAddr    string `yaml:"distributed_bind_addr"`      // Gömülü master dinleme adresi
+	DistributedSecret      string `yaml:"distributed_secret"`         // Worker kimlik doğrulama anahtarı
+	DistributedTLSCert     string `yaml:"distributed_tls_cert"`       // Doluysa master worker'ları TLS ile dinler
+	DistributedTLSKey      string `yaml:"distributed_tls_key"`
 	
 	// ENHANCED SERP
 	SerpCountryDomain      string   `yaml:"serp_country_domain"`      // Ülke-spesifik Google domain
@@ -386,12 +534,62 @@ func (c *Config) ApplyDefaults() {
 	if c.SitemapHomepageWeight > 100 {
 		c.SitemapHomepageWeight = 100
 	}
+	if c.NotFoundRate < 0 {
+		c.NotFoundRate = 0
+	}
+	if c.NotFoundRate > 100 {
+		c.NotFoundRate = 100
+	}
 	if c.CheckerWorkers <= 0 {
 		c.CheckerWorkers = 25
 	}
 	if c.CheckerWorkers > 100 {
 		c.CheckerWorkers = 100
 	}
+	if c.ProxyCheckCacheHours == 0 {
+		c.ProxyCheckCacheHours = 6
+	}
+	if c.SitemapCacheDir == "" {
+		c.SitemapCacheDir = "./sitemap_cache"
+	}
+	if c.SitemapCacheHours == 0 {
+		c.SitemapCacheHours = 24
+	}
+	if c.GscCacheDir == "" {
+		c.GscCacheDir = "./gsc_cache"
+	}
+	if c.GscCacheHours == 0 {
+		c.GscCacheHours = 12
+	}
+	if c.GitHubFetchConcurrency <= 0 {
+		c.GitHubFetchConcurrency = 8
+	}
+	if c.GitHubFetchConcurrency > 32 {
+		c.GitHubFetchConcurrency = 32
+	}
+	// Proxy kullanım sınırları: negatif değer sınırsız; cooldown süresi verilmemişse 5 dk
+	if c.ProxyMaxHitsPerHour < 0 {
+		c.ProxyMaxHitsPerHour = 0
+	}
+	if c.ProxyCooldownAfter < 0 {
+		c.ProxyCooldownAfter = 0
+	}
+	if c.ProxyCooldownAfter > 0 && c.ProxyCooldownMinutes <= 0 {
+		c.ProxyCooldownMinutes = 5
+	}
+	// Proxy sağlık skoru: eşik verilmişse karantina 10 dk, 3. karantinada kara liste
+	if c.ProxyMinHealthScore < 0 {
+		c.ProxyMinHealthScore = 0
+	}
+	if c.ProxyMinHealthScore > 100 {
+		c.ProxyMinHealthScore = 100
+	}
+	if c.ProxyMinHealthScore > 0 && c.ProxyQuarantineMinutes <= 0 {
+		c.ProxyQuarantineMinutes = 10
+	}
+	if c.ProxyMinHealthScore > 0 && c.ProxyBlacklistAfter <= 0 {
+		c.ProxyBlacklistAfter = 3
+	}
 	// Cihaz tipi varsayılanı
 	if c.DeviceType == "" {
 		c.DeviceType = "mixed"
@@ -579,11 +777,89 @@ func (c *Config) ApplyDefaults() {
 	if c.TelegramReportInterval <= 0 {
 		c.TelegramReportInterval = 10 // 10 dakikada bir
 	}
+	if c.NotifyErrorThreshold == 0 {
+		c.NotifyErrorThreshold = 25
+	}
+	if c.NotifyErrorWindowMin <= 0 {
+		c.NotifyErrorWindowMin = 5
+	}
+	if c.WebhookFormat == "" {
+		c.WebhookFormat = "slack"
+	}
+	if c.WebhookReportInterval <= 0 {
+		c.WebhookReportInterval = 10
+	}
+	
+	// UPTIME MONITOR defaults
+	if c.MonitorIntervalMin <= 0 {
+		c.MonitorIntervalMin = 5
+	}
+	if c.MonitorFailThreshold <= 0 {
+		c.MonitorFailThreshold = 2
+	}
+	
+	// SAKLAMA defaults
+	if c.RetentionIntervalMin <= 0 {
+		c.RetentionIntervalMin = 60
+	}
+	
+	// Trafik işareti defaults
+	if c.TrafficMarkerMode == "" {
+		c.TrafficMarkerMode = "query"
+	}
+	if c.ExperimentMode == "" {
+		c.ExperimentMode = "cookie"
+	}
+	
+	// GİRİŞLİ OTURUM defaults
+	if c.VaultFile == "" {
+		c.VaultFile = "./vault.enc"
+	}
+	
+	// Tarayıcı konumu defaults
+	if c.BrowserCacheDir == "" {
+		c.BrowserCacheDir = "./browser_cache"
+	}
+	if c.BrowserHeadlessMode == "" {
+		c.BrowserHeadlessMode = "old"
+	}
 	
 	// SCHEDULER defaults
 	if c.SchedulerJobsFile == "" {
 		c.SchedulerJobsFile = "./scheduler_jobs.json"
 	}
+	if c.KeywordClustersFile == "" {
+		c.KeywordClustersFile = "./keyword_clusters.json"
+	}
+	if c.MetricsHistoryFile == "" {
+		c.MetricsHistoryFile = "./metrics_history.json"
+	}
+	if c.MetricsStore == "" {
+		c.MetricsStore = "json"
+	}
+	if c.MetricsDBFile == "" {
+		c.MetricsDBFile = "./metrics.db"
+	}
+	if c.MetricsRetentionDays <= 0 {
+		c.MetricsRetentionDays = 1
+	}
+	if c.KillSwitchFile == "" {
+		c.KillSwitchFile = "./killswitch.json"
+	}
+	if c.ConsentFile == "" {
+		c.ConsentFile = "./consent.json"
+	}
+	if c.AuditLogFile == "" {
+		c.AuditLogFile = "./audit.log"
+	}
+	if c.WizardSecretFile == "" {
+		c.WizardSecretFile = "./wizard_secret.key"
+	}
+	
+	// DISTRIBUTED defaults
+	if c.DistributedBindAddr == "" {
+		c.DistributedBindAddr = "0.0.0.0:8090"
+	}
 	
 	// ENHANCED SERP defaults
 	if c.SerpMaxRetries <= 0 {
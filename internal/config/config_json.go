@@ -17,22 +17,52 @@ type ConfigJSON struct {
 	TargetQueries       []string `json:"targetQueries"`
 	TargetDomain        string   `json:"targetDomain"`
 	FallbackGAID        string   `json:"fallbackGAID"`
+	GA4APISecret        string   `json:"ga4ApiSecret,omitempty"`
+	GA4Properties       []GA4Property `json:"ga4Properties,omitempty"`
+	GA4EventMapping     GA4EventMapping `json:"ga4EventMapping,omitempty"`
+	GA4ServerURL         string   `json:"ga4ServerUrl,omitempty"`
+	GA4ServerScriptPath  string   `json:"ga4ServerScriptPath,omitempty"`
+	GA4ServerCollectPath string   `json:"ga4ServerCollectPath,omitempty"`
+	GA4ServerHeaders     []string `json:"ga4ServerHeaders,omitempty"`
+	GA4TrafficType       string   `json:"ga4TrafficType,omitempty"`
+	AnalyticsBackend     string   `json:"analyticsBackend,omitempty"`
+	MatomoURL            string   `json:"matomoUrl,omitempty"`
+	MatomoSiteID         int      `json:"matomoSiteId,omitempty"`
+	PlausibleURL         string   `json:"plausibleUrl,omitempty"`
+	PlausibleDomain      string   `json:"plausibleDomain,omitempty"`
 	MaxPages            int      `json:"maxPages"`
 	DurationMinutes     int      `json:"durationMinutes"`
 	HitsPerMinute       int      `json:"hitsPerMinute"`
 	MaxConcurrentVisits int      `json:"maxConcurrentVisits"`
 	OutputDir           string   `json:"outputDir"`
 	ExportFormat        string   `json:"exportFormat"`
+	HitLogJSONL         bool     `json:"hitLogJsonl,omitempty"`
+	SessionLogJSONL     bool     `json:"sessionLogJsonl,omitempty"`
+	ReportTitle           string `json:"reportTitle,omitempty"`
+	ReportLogo            string `json:"reportLogo,omitempty"`
+	ReportPrimaryColor    string `json:"reportPrimaryColor,omitempty"`
+	ReportBackgroundColor string `json:"reportBackgroundColor,omitempty"`
+	ReportFooter          string `json:"reportFooter,omitempty"`
 	CanvasFingerprint   bool     `json:"canvasFingerprint"`
 	ScrollStrategy        string   `json:"scrollStrategy"`
 	SendScrollEvent       bool     `json:"sendScrollEvent"`
 	UseSitemap            bool     `json:"useSitemap"`
 	SitemapHomepageWeight int      `json:"sitemapHomepageWeight"`
+	SitemapCacheDir       string   `json:"sitemapCacheDir,omitempty"`
+	SitemapCacheHours     int      `json:"sitemapCacheHours,omitempty"`
+	LandingPages          []string `json:"landingPages,omitempty"`
+	URLWeights            []URLWeight `json:"urlWeights,omitempty"`
+	NotFoundRate          int      `json:"notFoundRate,omitempty"`
+	ContentChecks         []ContentCheck `json:"contentChecks,omitempty"`
+	ContentCheckRate      int      `json:"contentCheckRate,omitempty"`
 	Keywords              []string `json:"keywords"`
 	UsePublicProxy        bool     `json:"usePublicProxy"`
 	ProxySourceURLs       []string `json:"proxySourceURLs"`
 	GitHubRepos           []string `json:"githubRepos"`
 	CheckerWorkers        int      `json:"checkerWorkers"`
+	ProxyCheckCacheHours  int      `json:"proxyCheckCacheHours,omitempty"`
+	GitHubFetchConcurrency int      `json:"githubFetchConcurrency,omitempty"`
+	GitHubRepoAllowlist    []string `json:"githubRepoAllowlist,omitempty"`
 	// Private proxy listesi
 	PrivateProxies        []PrivateProxyJSON `json:"privateProxies"`
 	UsePrivateProxy       bool               `json:"usePrivateProxy"`
@@ -41,6 +71,96 @@ type ConfigJSON struct {
 	DeviceBrands      []string `json:"deviceBrands"`
 	ReferrerKeyword   string   `json:"referrerKeyword"`
 	ReferrerEnabled   bool     `json:"referrerEnabled"`
+	OutboundDomains   []string `json:"outboundDomains,omitempty"`
+	OutboundClickRate int      `json:"outboundClickRate,omitempty"`
+	// Distributed (cluster) alanları
+	EnableDistributed   bool   `json:"enableDistributed"`
+	DistributedBindAddr string `json:"distributedBindAddr"`
+	DistributedSecret   string `json:"distributedSecret"`
+	DistributedTLSCert  string `json:"distributedTlsCert,omitempty"`
+	DistributedTLSKey   string `json:"distributedTlsKey,omitempty"`
+	EnableScheduler     bool   `json:"enableScheduler,omitempty"`
+	MetricsAddr         string `json:"metricsAddr,omitempty"`
+	// Metrik geçmişi
+	MetricsStore         string `json:"metricsStore,omitempty"`
+	MetricsRetentionDays int    `json:"metricsRetentionDays,omitempty"`
+	// Sıcak Chrome havuzu
+	BrowserPoolEnabled bool `json:"browserPoolEnabled,omitempty"`
+	BrowserPoolMin     int  `json:"browserPoolMin,omitempty"`
+	BrowserPoolMax     int  `json:"browserPoolMax,omitempty"`
+	EnableAutoScaling  bool `json:"enableAutoScaling,omitempty"`
+	BrowserMaxSessions int  `json:"browserMaxSessions,omitempty"`
+	BrowserMaxAge      int  `json:"browserMaxAge,omitempty"`
+	// Zaman pencereleri
+	ActiveWindows   []string `json:"activeWindows,omitempty"`
+	BlackoutWindows []string `json:"blackoutWindows,omitempty"`
+	// Yük modeli (closed: sabit eşzamanlılık)
+	LoadMode   string `json:"loadMode,omitempty"`
+	LoadLevels []int  `json:"loadLevels,omitempty"`
+	LoadProfile string   `json:"loadProfile,omitempty"`
+	LoadStages  []string `json:"loadStages,omitempty"`
+	// SLA koşulları (CI için)
+	Assertions []string `json:"assertions,omitempty"`
+	// Isınma penceresi (istatistik dışı)
+	WarmupSeconds int `json:"warmupSeconds,omitempty"`
+	// HTTP/3 (QUIC, HTTP/2'ye düşer)
+	EnableHTTP3 bool `json:"enableHttp3,omitempty"`
+	// Tekrarlanabilir çalıştırma
+	Seed int64 `json:"seed,omitempty"`
+	// Debug: Stop sonrası goroutine sızıntı kontrolü
+	LeakCheck bool `json:"leakCheck,omitempty"`
+	// Proxy başına kullanım sınırları
+	ProxyMaxHitsPerHour  int `json:"proxyMaxHitsPerHour,omitempty"`
+	ProxyCooldownAfter   int `json:"proxyCooldownAfter,omitempty"`
+	ProxyCooldownMinutes int `json:"proxyCooldownMinutes,omitempty"`
+	// Proxy sağlık skoru, karantina ve kara liste
+	ProxyMinHealthScore    int `json:"proxyMinHealthScore,omitempty"`
+	ProxyQuarantineMinutes int `json:"proxyQuarantineMinutes,omitempty"`
+	ProxyBlacklistAfter    int `json:"proxyBlacklistAfter,omitempty"`
+	// BigQuery export
+	BigQueryExport          bool   `json:"bigQueryExport,omitempty"`
+	BigQueryProject         string `json:"bigQueryProject,omitempty"`
+	BigQueryDataset         string `json:"bigQueryDataset,omitempty"`
+	BigQueryTable           string `json:"bigQueryTable,omitempty"`
+	BigQueryCredentialsFile string `json:"bigQueryCredentialsFile,omitempty"`
+	// Tarayıcı konumu
+	BrowserPath           string   `json:"browserPath,omitempty"`
+	BrowserAutoDownload   bool     `json:"browserAutoDownload,omitempty"`
+	BrowserCacheDir       string   `json:"browserCacheDir,omitempty"`
+	BrowserDownloadSHA256 string   `json:"browserDownloadSha256,omitempty"`
+	BrowserHeadlessMode   string   `json:"browserHeadlessMode,omitempty"`
+	BrowserExtraFlags     []string `json:"browserExtraFlags,omitempty"`
+	BrowserExtensions     []string `json:"browserExtensions,omitempty"`
+	// Staging provası
+	HostMap       []string `json:"hostMap,omitempty"`
+	BasicAuthUser string   `json:"basicAuthUser,omitempty"`
+	BasicAuthPass string   `json:"basicAuthPass,omitempty"`
+	ExtraHeaders  []string `json:"extraHeaders,omitempty"`
+	// Trafik işareti
+	TrafficMarker     string `json:"trafficMarker,omitempty"`
+	TrafficMarkerMode string `json:"trafficMarkerMode,omitempty"`
+	// A/B deney bölmesi
+	ExperimentParam    string   `json:"experimentParam,omitempty"`
+	ExperimentVariants []string `json:"experimentVariants,omitempty"`
+	ExperimentMode     string   `json:"experimentMode,omitempty"`
+	// Uptime monitor
+	MonitorURLs          []string `json:"monitorUrls,omitempty"`
+	MonitorIntervalMin   int      `json:"monitorIntervalMin,omitempty"`
+	MonitorProbes        []string `json:"monitorProbes,omitempty"`
+	MonitorFailThreshold int      `json:"monitorFailThreshold,omitempty"`
+	// Saklama
+	RetentionDays        int `json:"retentionDays,omitempty"`
+	RetentionMaxMB       int `json:"retentionMaxMb,omitempty"`
+	RetentionIntervalMin int `json:"retentionIntervalMin,omitempty"`
+	// Girişli oturum
+	LoginEnabled         bool   `json:"loginEnabled,omitempty"`
+	LoginURL             string `json:"loginUrl,omitempty"`
+	LoginUserSelector    string `json:"loginUserSelector,omitempty"`
+	LoginPassSelector    string `json:"loginPassSelector,omitempty"`
+	LoginSubmitSelector  string `json:"loginSubmitSelector,omitempty"`
+	LoginSuccessSelector string `json:"loginSuccessSelector,omitempty"`
+	LoginCredential      string `json:"loginCredential,omitempty"`
+	VaultFile            string `json:"vaultFile,omitempty"`
 }
 
 // PrivateProxyJSON JSON formatında private proxy
@@ -58,7 +178,11 @@ func LoadFromJSON(path string) (*Config, error) {
 	if err != nil {
 		return nil, err
 	}
+	return ParseJSON(data)
+}
 
+// ParseJSON config.json içeriğini Config'e dönüştürür (yedekten geri yükleme için)
+func ParseJSON(data []byte) (*Config, error) {
 	var j ConfigJSON
 	if err := json.Unmarshal(data, &j); err != nil {
 		return nil, err
@@ -85,22 +209,52 @@ func LoadFromJSON(path string) (*Config, error) {
 		HitsPerMinute:      j.HitsPerMinute,
 		OutputDir:          j.OutputDir,
 		ExportFormat:       j.ExportFormat,
+		HitLogJSONL:        j.HitLogJSONL,
+		SessionLogJSONL:    j.SessionLogJSONL,
+		ReportTitle:           j.ReportTitle,
+		ReportLogo:            j.ReportLogo,
+		ReportPrimaryColor:    j.ReportPrimaryColor,
+		ReportBackgroundColor: j.ReportBackgroundColor,
+		ReportFooter:          j.ReportFooter,
 		MaxConcurrentVisits: j.MaxConcurrentVisits,
 		CanvasFingerprint:  j.CanvasFingerprint,
 		ScrollStrategy:        j.ScrollStrategy,
 		SendScrollEvent:       j.SendScrollEvent,
 		UseSitemap:            j.UseSitemap,
 		SitemapHomepageWeight: j.SitemapHomepageWeight,
+		SitemapCacheDir:       j.SitemapCacheDir,
+		SitemapCacheHours:     j.SitemapCacheHours,
+		LandingPages:          j.LandingPages,
+		URLWeights:            j.URLWeights,
+		NotFoundRate:          j.NotFoundRate,
+		ContentChecks:         j.ContentChecks,
+		ContentCheckRate:      j.ContentCheckRate,
 		Keywords:              j.Keywords,
 		UsePublicProxy:        j.UsePublicProxy,
 		ProxySourceURLs:       j.ProxySourceURLs,
 		GitHubRepos:           j.GitHubRepos,
 		CheckerWorkers:        j.CheckerWorkers,
+		ProxyCheckCacheHours:  j.ProxyCheckCacheHours,
+		GitHubFetchConcurrency: j.GitHubFetchConcurrency,
+		GitHubRepoAllowlist:    j.GitHubRepoAllowlist,
 		ProxyHost:          j.ProxyHost,
 		ProxyPort:          j.ProxyPort,
 		ProxyUser:          j.ProxyUser,
 		ProxyPass:          j.ProxyPass,
 		GtagID:             j.FallbackGAID,
+		GA4APISecret:       j.GA4APISecret,
+		GA4Properties:      j.GA4Properties,
+		GA4EventMapping:    j.GA4EventMapping,
+		GA4ServerURL:         j.GA4ServerURL,
+		GA4ServerScriptPath:  j.GA4ServerScriptPath,
+		GA4ServerCollectPath: j.GA4ServerCollectPath,
+		GA4ServerHeaders:     j.GA4ServerHeaders,
+		GA4TrafficType:       j.GA4TrafficType,
+		AnalyticsBackend:     j.AnalyticsBackend,
+		MatomoURL:            j.MatomoURL,
+		MatomoSiteID:         j.MatomoSiteID,
+		PlausibleURL:         j.PlausibleURL,
+		PlausibleDomain:      j.PlausibleDomain,
 		// Private proxy alanları
 		PrivateProxies:    privateProxies,
 		UsePrivateProxy:   j.UsePrivateProxy,
@@ -109,6 +263,95 @@ func LoadFromJSON(path string) (*Config, error) {
 		DeviceBrands:      j.DeviceBrands,
 		ReferrerKeyword:   j.ReferrerKeyword,
 		ReferrerEnabled:   j.ReferrerEnabled,
+		OutboundDomains:   j.OutboundDomains,
+		OutboundClickRate: j.OutboundClickRate,
+		// Distributed (cluster) alanları
+		EnableDistributed:   j.EnableDistributed,
+		DistributedBindAddr: j.DistributedBindAddr,
+		DistributedSecret:   j.DistributedSecret,
+		DistributedTLSCert:  j.DistributedTLSCert,
+		DistributedTLSKey:   j.DistributedTLSKey,
+		EnableScheduler:     j.EnableScheduler,
+		MetricsAddr:         j.MetricsAddr,
+		// Metrik geçmişi
+		MetricsStore:         j.MetricsStore,
+		MetricsRetentionDays: j.MetricsRetentionDays,
+		// Sıcak Chrome havuzu
+		BrowserPoolEnabled: j.BrowserPoolEnabled,
+		BrowserPoolMin:     j.BrowserPoolMin,
+		BrowserPoolMax:     j.BrowserPoolMax,
+		EnableAutoScaling:  j.EnableAutoScaling,
+		BrowserMaxSessions: j.BrowserMaxSessions,
+		BrowserMaxAge:      j.BrowserMaxAge,
+		// Zaman pencereleri
+		ActiveWindows:   j.ActiveWindows,
+		BlackoutWindows: j.BlackoutWindows,
+		// Yük modeli
+		LoadMode:   j.LoadMode,
+		LoadLevels: j.LoadLevels,
+		LoadProfile: j.LoadProfile,
+		LoadStages:  j.LoadStages,
+		// SLA koşulları
+		Assertions: j.Assertions,
+		// Isınma penceresi
+		WarmupSeconds: j.WarmupSeconds,
+		// HTTP/3
+		EnableHTTP3: j.EnableHTTP3,
+		// Tekrarlanabilir çalıştırma
+		Seed: j.Seed,
+		LeakCheck: j.LeakCheck,
+		// Proxy kullanım sınırları
+		ProxyMaxHitsPerHour:  j.ProxyMaxHitsPerHour,
+		ProxyCooldownAfter:   j.ProxyCooldownAfter,
+		ProxyCooldownMinutes: j.ProxyCooldownMinutes,
+		// Proxy sağlık skoru
+		ProxyMinHealthScore:    j.ProxyMinHealthScore,
+		ProxyQuarantineMinutes: j.ProxyQuarantineMinutes,
+		ProxyBlacklistAfter:    j.ProxyBlacklistAfter,
+		// BigQuery export
+		BigQueryExport:          j.BigQueryExport,
+		BigQueryProject:         j.BigQueryProject,
+		BigQueryDataset:         j.BigQueryDataset,
+		BigQueryTable:           j.BigQueryTable,
+		BigQueryCredentialsFile: j.BigQueryCredentialsFile,
+		// Tarayıcı konumu
+		BrowserPath:           j.BrowserPath,
+		BrowserAutoDownload:   j.BrowserAutoDownload,
+		BrowserCacheDir:       j.BrowserCacheDir,
+		BrowserDownloadSHA256: j.BrowserDownloadSHA256,
+		BrowserHeadlessMode:   j.BrowserHeadlessMode,
+		BrowserExtraFlags:     j.BrowserExtraFlags,
+		BrowserExtensions:     j.BrowserExtensions,
+		// Staging provası
+		HostMap:       j.HostMap,
+		BasicAuthUser: j.BasicAuthUser,
+		BasicAuthPass: j.BasicAuthPass,
+		ExtraHeaders:  j.ExtraHeaders,
+		// Trafik işareti
+		TrafficMarker:     j.TrafficMarker,
+		TrafficMarkerMode: j.TrafficMarkerMode,
+		// A/B deney bölmesi
+		ExperimentParam:    j.ExperimentParam,
+		ExperimentVariants: j.ExperimentVariants,
+		ExperimentMode:     j.ExperimentMode,
+		// Uptime monitor
+		MonitorURLs:          j.MonitorURLs,
+		MonitorIntervalMin:   j.MonitorIntervalMin,
+		MonitorProbes:        j.MonitorProbes,
+		MonitorFailThreshold: j.MonitorFailThreshold,
+		// Saklama
+		RetentionDays:        j.RetentionDays,
+		RetentionMaxMB:       j.RetentionMaxMB,
+		RetentionIntervalMin: j.RetentionIntervalMin,
+		// Girişli oturum
+		LoginEnabled:         j.LoginEnabled,
+		LoginURL:             j.LoginURL,
+		LoginUserSelector:    j.LoginUserSelector,
+		LoginPassSelector:    j.LoginPassSelector,
+		LoginSubmitSelector:  j.LoginSubmitSelector,
+		LoginSuccessSelector: j.LoginSuccessSelector,
+		LoginCredential:      j.LoginCredential,
+		VaultFile:            j.VaultFile,
 	}
 	if cfg.OutputDir == "" {
 		cfg.OutputDir = "./reports"
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// GA4Property ek GA4 mülkü (ör. production + test); Share ile ziyaretlerin bir kısmı bu mülke yönlendirilir
+type GA4Property struct {
+	MeasurementID string  `yaml:"measurement_id" json:"measurement_id"`
+	APISecret     string  `yaml:"api_secret" json:"api_secret,omitempty"` // Measurement Protocol fallback için
+	Share         float64 `yaml:"share" json:"share"`                     // Göreli pay (ör. 90 ve 10)
+	Label         string  `yaml:"label" json:"label,omitempty"`           // prod, sandbox...
+}
+
+// ParseGA4Properties "G-XXXX pay [etiket] [api_secret]" satırlarını okur (boş satır ve # yorum atlanır)
+func ParseGA4Properties(text string) ([]GA4Property, error) {
+	var out []GA4Property
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		f := strings.Fields(line)
+		if len(f) < 2 {
+			return nil, fmt.Errorf("GA4 mülkü satır %d: \"measurement_id pay\" bekleniyor", i+1)
+		}
+		if !strings.HasPrefix(strings.ToUpper(f[0]), "G-") {
+			return nil, fmt.Errorf("GA4 mülkü satır %d: geçersiz measurement ID %q", i+1, f[0])
+		}
+		share, err := strconv.ParseFloat(f[1], 64)
+		if err != nil || share < 0 {
+			return nil, fmt.Errorf("GA4 mülkü satır %d: geçersiz pay %q", i+1, f[1])
+		}
+		p := GA4Property{MeasurementID: strings.ToUpper(f[0]), Share: share}
+		if len(f) > 2 && f[2] != "-" { // "-": etiketsiz, yalnızca secret
+			p.Label = f[2]
+		}
+		if len(f) > 3 {
+			p.APISecret = f[3]
+		}
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+// FormatGA4Properties mülkleri ParseGA4Properties'in okuduğu satır formatına çevirir (GUI textarea)
+func FormatGA4Properties(props []GA4Property) string {
+	lines := make([]string, 0, len(props))
+	for _, p := range props {
+		line := p.MeasurementID + " " + strconv.FormatFloat(p.Share, 'f', -1, 64)
+		label := p.Label
+		if label == "" && p.APISecret != "" {
+			label = "-"
+		}
+		if label != "" {
+			line += " " + label
+		}
+		if p.APISecret != "" {
+			line += " " + p.APISecret
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// GA4EventMapping sitenin özel GA4 event şeması: standart event adlarının yeniden adlandırılması,
+// her event'e eklenen varsayılan parametreler ve event başına şablonlu parametreler ({label}, {page_path}...)
+type GA4EventMapping struct {
+	Rename        map[string]string            `yaml:"rename" json:"rename,omitempty"`                 // scroll: page_scroll
+	DefaultParams map[string]string            `yaml:"default_params" json:"default_params,omitempty"` // site_section: blog
+	EventParams   map[string]map[string]string `yaml:"event_params" json:"event_params,omitempty"`     // click: {link_type: "{category}"}
+}
+
+// Empty eşleme tanımlı değilse true
+func (m GA4EventMapping) Empty() bool {
+	return len(m.Rename) == 0 && len(m.DefaultParams) == 0 && len(m.EventParams) == 0
+}
@@ -0,0 +1,34 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseGA4PropertiesRoundTrip(t *testing.T) {
+	text := "# prod + sandbox\nG-PROD000001 90 prod\ng-test000001 10 sandbox s3cret\n\nG-NOLABEL01 5 - k3y"
+	props, err := ParseGA4Properties(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []GA4Property{
+		{MeasurementID: "G-PROD000001", Share: 90, Label: "prod"},
+		{MeasurementID: "G-TEST000001", Share: 10, Label: "sandbox", APISecret: "s3cret"},
+		{MeasurementID: "G-NOLABEL01", Share: 5, APISecret: "k3y"},
+	}
+	if !reflect.DeepEqual(props, want) {
+		t.Fatalf("props = %+v", props)
+	}
+	again, err := ParseGA4Properties(FormatGA4Properties(props))
+	if err != nil || !reflect.DeepEqual(again, props) {
+		t.Fatalf("round trip = %+v, %v", again, err)
+	}
+}
+
+func TestParseGA4PropertiesErrors(t *testing.T) {
+	for _, text := range []string{"G-PROD000001", "UA-1234-1 10", "G-PROD000001 abc", "G-PROD000001 -5"} {
+		if _, err := ParseGA4Properties(text); err == nil {
+			t.Errorf("ParseGA4Properties(%q) should fail", text)
+		}
+	}
+}
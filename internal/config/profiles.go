@@ -0,0 +1,119 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ProfileSuffix profil dosyalarının uzantısı; profiller config.json ile aynı dizinde
+// <ad>.profile.json olarak, config.json biçiminde saklanır (dosya doğrudan -config ile de kullanılabilir)
+const ProfileSuffix = ".profile.json"
+
+// ErrProfileNotFound istenen profil dosyası yoksa döner
+var ErrProfileNotFound = errors.New("profil bulunamadı")
+
+// profileNameRe geçerli profil adı: harf/rakam ile başlar, en fazla 64 karakter (ör. low-and-slow)
+var profileNameRe = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]{0,63}$`)
+
+// ProfileInfo kayıtlı profil
+type ProfileInfo struct {
+	Name     string    `json:"name"`
+	Modified time.Time `json:"modified"`
+	Size     int64     `json:"size"`
+}
+
+// ValidateProfileName profil adını doğrular
+func ValidateProfileName(name string) error {
+	if !profileNameRe.MatchString(name) {
+		return fmt.Errorf("geçersiz profil adı %q (harf, rakam, - ve _; en fazla 64 karakter)", name)
+	}
+	return nil
+}
+
+// ProfilePath dir içindeki profil dosyasının yolu
+func ProfilePath(dir, name string) string {
+	return filepath.Join(dir, name+ProfileSuffix)
+}
+
+// SaveProfile config.json biçimindeki data'yı profil olarak yazar (varsa üzerine). İçerik önce
+// çözümlenir; yüklenemeyecek bir profil kaydedilmez.
+func SaveProfile(dir, name string, data []byte) error {
+	if err := ValidateProfileName(name); err != nil {
+		return err
+	}
+	if _, err := ParseJSON(data); err != nil {
+		return fmt.Errorf("profil içeriği geçersiz: %w", err)
+	}
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+	path := ProfilePath(dir, name)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// LoadProfile profili yükler (varsayılanlar LoadFromJSON'daki gibi uygulanır)
+func LoadProfile(dir, name string) (*Config, error) {
+	if err := ValidateProfileName(name); err != nil {
+		return nil, err
+	}
+	cfg, err := LoadFromJSON(ProfilePath(dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrProfileNotFound, name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("profil %s okunamadı: %w", name, err)
+	}
+	return cfg, nil
+}
+
+// ListProfiles dir'deki profilleri ada göre sıralı döner (dizin yoksa boş liste)
+func ListProfiles(dir string) ([]ProfileInfo, error) {
+	if dir == "" {
+		dir = "."
+	}
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return []ProfileInfo{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	profiles := []ProfileInfo{}
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), ProfileSuffix)
+		if !ok || e.IsDir() || ValidateProfileName(name) != nil {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		profiles = append(profiles, ProfileInfo{Name: name, Modified: info.ModTime(), Size: info.Size()})
+	}
+	sort.Slice(profiles, func(i, j int) bool { return profiles[i].Name < profiles[j].Name })
+	return profiles, nil
+}
+
+// DeleteProfile profili siler
+func DeleteProfile(dir, name string) error {
+	if err := ValidateProfileName(name); err != nil {
+		return err
+	}
+	err := os.Remove(ProfilePath(dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w: %s", ErrProfileNotFound, name)
+	}
+	return err
+}
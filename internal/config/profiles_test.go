@@ -0,0 +1,48 @@
+package config
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestProfilesRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	if err := SaveProfile(dir, "low-and-slow", []byte(`{"targetDomain":"example.com","hitsPerMinute":5}`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveProfile(dir, "burst", []byte(`{"targetDomain":"example.com","hitsPerMinute":120}`)); err != nil {
+		t.Fatal(err)
+	}
+	list, err := ListProfiles(dir)
+	if err != nil || len(list) != 2 || list[0].Name != "burst" || list[1].Name != "low-and-slow" {
+		t.Fatalf("list = %+v, %v", list, err)
+	}
+	cfg, err := LoadProfile(dir, "low-and-slow")
+	if err != nil || cfg.HitsPerMinute != 5 || cfg.TargetDomain != "example.com" {
+		t.Fatalf("load = %+v, %v", cfg, err)
+	}
+	if err := DeleteProfile(dir, "burst"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadProfile(dir, "burst"); !errors.Is(err, ErrProfileNotFound) {
+		t.Fatalf("deleted profile load err = %v", err)
+	}
+	if err := DeleteProfile(dir, "burst"); !errors.Is(err, ErrProfileNotFound) {
+		t.Fatalf("double delete err = %v", err)
+	}
+}
+
+func TestProfilesRejectBadInput(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"", "../config", "a/b", "-x", "has space"} {
+		if err := SaveProfile(dir, name, []byte(`{}`)); err == nil {
+			t.Errorf("name %q accepted", name)
+		}
+	}
+	if err := SaveProfile(dir, "broken", []byte(`{not json`)); err == nil {
+		t.Error("invalid JSON saved")
+	}
+	if list, _ := ListProfiles(dir); len(list) != 0 {
+		t.Errorf("list = %+v", list)
+	}
+}
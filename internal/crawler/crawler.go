@@ -10,6 +10,9 @@ import (
 	"github.com/gocolly/colly/v2"
 
 	"vgbot/internal/reporter"
+	"vgbot/pkg/marker"
+	"vgbot/pkg/network"
+	"vgbot/pkg/secaudit"
 	"vgbot/pkg/useragent"
 )
 
@@ -24,6 +27,47 @@ type Crawler struct {
 	pages         []string
 	reporter      *reporter.Reporter
 	agentProvider AgentProvider
+	headers       map[string]string // Her isteğe eklenen kampanya header'ları (basic auth, staging token)
+	marker        *marker.Marker    // Trafik işareti (nil = kapalı)
+	transport     *http.Transport   // TCP transport (HTTP/1.1, HTTP/2); HTTP/3 açıkken fallback
+	protos        *protoRecorder    // Yanıtların müzakere edilen HTTP sürümü
+	proxied       bool
+}
+
+// protoRecorder yanıtın HTTP sürümünü URL'ye göre saklar; colly.Response sürümü taşımaz
+type protoRecorder struct {
+	base   http.RoundTripper
+	mu     sync.Mutex
+	protos map[string]string
+	// Hedefin ilk yanıtı bir kez güvenlik denetiminden geçirilir (ek istek atılmaz)
+	auditHost string
+	audit     func(*http.Response)
+	audited   sync.Once
+}
+
+func (p *protoRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	p.mu.Lock()
+	base := p.base
+	p.mu.Unlock()
+	resp, err := base.RoundTrip(req)
+	if err == nil {
+		p.mu.Lock()
+		p.protos[req.URL.String()] = resp.Proto
+		p.mu.Unlock()
+		if p.audit != nil && req.URL.Hostname() == p.auditHost {
+			p.audited.Do(func() { p.audit(resp) })
+		}
+	}
+	return resp, err
+}
+
+// take URL'nin kaydedilen HTTP sürümünü döner ve kaydı siler
+func (p *protoRecorder) take(u string) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	proto := p.protos[u]
+	delete(p.protos, u)
+	return proto
 }
 
 // AgentProvider UA ve opsiyonel headers sağlar
@@ -69,11 +113,18 @@ func New(domain string, maxPages int, rep *reporter.Reporter, proxyURL string, a
 	}
 
 	// Keep-alive için transport
-	c.WithTransport(&http.Transport{
+	transport := &http.Transport{
+		DialContext:         network.MappedDialContext(nil), // host_map (staging provası)
+		ForceAttemptHTTP2:   true,                           // Özel DialContext HTTP/2'yi kapatmasın
 		MaxIdleConns:        10,
 		IdleConnTimeout:     90 * time.Second,
 		DisableCompression:  false,
-	})
+	}
+	protos := &protoRecorder{base: transport, protos: make(map[string]string), auditHost: baseDomain}
+	if rep != nil {
+		protos.audit = func(resp *http.Response) { rep.SetSecurity(secaudit.FromResponse(resp, time.Now())) }
+	}
+	c.WithTransport(protos)
 
 	cr := &Crawler{
 		collector:      c,
@@ -84,12 +135,41 @@ func New(domain string, maxPages int, rep *reporter.Reporter, proxyURL string, a
 		pages:          make([]string, 0, maxPages),
 		reporter:       rep,
 		agentProvider:  agentProvider,
+		transport:      transport,
+		protos:         protos,
+		proxied:        proxyURL != "",
 	}
 
 	cr.setupHandlers()
 	return cr, nil
 }
 
+// SetHeaders her isteğe eklenecek header'ları ayarlar (Crawl'dan önce çağrılmalı)
+func (cr *Crawler) SetHeaders(h map[string]string) {
+	cr.headers = h
+}
+
+// SetMarker istekleri trafik işaretiyle etiketler (Crawl'dan önce çağrılmalı)
+func (cr *Crawler) SetMarker(m *marker.Marker) {
+	cr.marker = m
+}
+
+// EnableHTTP3 istekleri önce HTTP/3 (QUIC) ile dener; desteklemeyen host'larda HTTP/2'ye düşer.
+// Proxy ayarlıysa false döner: QUIC proxy üzerinden tünellenemez. Crawl'dan önce çağrılmalı.
+func (cr *Crawler) EnableHTTP3() (bool, error) {
+	if cr.proxied {
+		return false, nil
+	}
+	client, err := network.NewHTTP3Client(cr.transport, 0)
+	if err != nil {
+		return false, err
+	}
+	cr.protos.mu.Lock()
+	cr.protos.base = client.Transport
+	cr.protos.mu.Unlock()
+	return true, nil
+}
+
 func (cr *Crawler) setupHandlers() {
 	var startTimeMu sync.Mutex
 	startTime := make(map[string]time.Time)
@@ -108,6 +188,20 @@ func (cr *Crawler) setupHandlers() {
 		for k, v := range headers {
 			r.Headers.Set(k, v)
 		}
+		for k, v := range cr.headers {
+			r.Headers.Set(k, v)
+		}
+		if cr.marker != nil {
+			if u, err := url.Parse(cr.marker.ApplyURL(r.URL.String())); err == nil {
+				r.URL.RawQuery = u.RawQuery
+			}
+			if c := cr.marker.CookieValue(); c != "" {
+				if prev := r.Headers.Get("Cookie"); prev != "" {
+					c = prev + "; " + c
+				}
+				r.Headers.Set("Cookie", c)
+			}
+		}
 		startTimeMu.Lock()
 		startTime[r.URL.String()] = time.Now()
 		startTimeMu.Unlock()
@@ -132,6 +226,9 @@ func (cr *Crawler) setupHandlers() {
 			StatusCode:   r.StatusCode,
 			ResponseTime: elapsed,
 			UserAgent:    r.Request.Headers.Get("User-Agent"),
+			Requests:     1,
+			Bytes:        int64(len(r.Body)),
+			Protocol:     cr.protos.take(u),
 		})
 	})
 
@@ -144,6 +241,7 @@ func (cr *Crawler) setupHandlers() {
 			Timestamp: time.Now(),
 			URL:       u,
 			Error:     err.Error(),
+			Protocol:  cr.protos.take(u),
 		})
 	})
 
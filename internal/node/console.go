@@ -0,0 +1,350 @@
+package node
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/chzyer/readline"
+
+	"vgbot/pkg/api"
+	"vgbot/pkg/distributed"
+)
+
+// console is the master's interactive prompt: line editing, persistent history, tab completion of
+// commands and worker IDs, and an output writer that redraws the prompt instead of printing through it.
+type console struct {
+	rl     *readline.Instance
+	master *distributed.Master
+}
+
+// defaultHistoryFile is ~/.vgbot_master_history, or empty when the home directory is unknown
+func defaultHistoryFile() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".vgbot_master_history")
+}
+
+// newConsole opens the prompt on the terminal; it returns nil when stdin/stdout is not a terminal
+// (service, piped or redirected output), where the master runs without a console.
+func newConsole(historyFile string) (*console, error) {
+	if !readline.DefaultIsTerminal() {
+		return nil, nil
+	}
+	c := &console{}
+	rl, err := readline.NewEx(&readline.Config{
+		Prompt:            "master> ",
+		HistoryFile:       historyFile,
+		HistoryLimit:      1000,
+		HistorySearchFold: true,
+		AutoComplete:      c.completer(),
+		InterruptPrompt:   "^C",
+		EOFPrompt:         "exit",
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.rl = rl
+	return c, nil
+}
+
+// Stdout returns a writer that clears the prompt line, prints and redraws the prompt with the typed input
+func (c *console) Stdout() io.Writer {
+	return c.rl.Stdout()
+}
+
+// Close restores the terminal and flushes history
+func (c *console) Close() error {
+	return c.rl.Close()
+}
+
+func (c *console) completer() readline.AutoCompleter {
+	return readline.NewPrefixCompleter(
+		readline.PcItem("help"),
+		readline.PcItem("status"),
+		readline.PcItem("stats"),
+		readline.PcItem("submit"),
+		readline.PcItem("batch"),
+		readline.PcItem("workers"),
+		readline.PcItem("worker", readline.PcItemDynamic(c.workerIDs)),
+		readline.PcItem("tasks",
+			readline.PcItem(string(api.TaskPending)),
+			readline.PcItem(string(api.TaskAssigned)),
+			readline.PcItem(string(api.TaskRetrying)),
+			readline.PcItem(string(api.TaskCompleted)),
+			readline.PcItem(string(api.TaskFailed)),
+			readline.PcItem(string(api.TaskCancelled)),
+		),
+		readline.PcItem("task", readline.PcItemDynamic(c.taskIDs,
+			readline.PcItem("show"),
+			readline.PcItem("cancel"),
+			readline.PcItem("requeue"),
+		)),
+		readline.PcItem("quit"),
+		readline.PcItem("exit"),
+	)
+}
+
+// workerIDs lists registered worker IDs for tab completion
+func (c *console) workerIDs(string) []string {
+	if c.master == nil {
+		return nil
+	}
+	workers := c.master.ListWorkers()
+	ids := make([]string, 0, len(workers))
+	for _, w := range workers {
+		ids = append(ids, w.ID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// taskIDs lists the most recent task IDs for tab completion
+func (c *console) taskIDs(string) []string {
+	if c.master == nil {
+		return nil
+	}
+	tasks := c.master.Tasks("", recentTasks)
+	ids := make([]string, 0, len(tasks))
+	for _, t := range tasks {
+		ids = append(ids, t.ID)
+	}
+	return ids
+}
+
+// run reads commands until the master stops. Ctrl+C discards the typed line; Ctrl+C on an empty
+// line, Ctrl+D and quit/exit call stop.
+func (c *console) run(master *distributed.Master, stop func()) {
+	c.master = master
+	out := c.Stdout()
+	for {
+		line, err := c.rl.Readline()
+		if errors.Is(err, readline.ErrInterrupt) {
+			if line == "" {
+				stop()
+				return
+			}
+			continue
+		}
+		if err != nil { // io.EOF: Ctrl+D or console closed on shutdown
+			stop()
+			return
+		}
+		if !execCommand(out, master, strings.Fields(line)) {
+			stop()
+			return
+		}
+	}
+}
+
+// execCommand runs one console command; it returns false when the command asks the master to stop
+func execCommand(out io.Writer, master *distributed.Master, parts []string) bool {
+	if len(parts) == 0 {
+		return true
+	}
+
+	switch cmd := parts[0]; cmd {
+	case "help":
+		printHelp(out)
+	case "status", "stats":
+		printStats(out, master)
+	case "submit":
+		if len(parts) < 2 {
+			fmt.Fprintln(out, "Usage: submit <url>")
+			return true
+		}
+		submitTask(out, master, parts[1])
+	case "batch":
+		if len(parts) < 3 {
+			fmt.Fprintln(out, "Usage: batch <url> <count>")
+			return true
+		}
+		count := 1
+		fmt.Sscanf(parts[2], "%d", &count)
+		submitBatch(out, master, parts[1], count)
+	case "workers":
+		printWorkers(out, master)
+	case "worker":
+		if len(parts) < 2 {
+			fmt.Fprintln(out, "Usage: worker <id>")
+			return true
+		}
+		printWorker(out, master, parts[1])
+	case "tasks":
+		status := ""
+		if len(parts) > 1 {
+			status = parts[1]
+		}
+		printTasks(out, master, api.TaskStatus(status))
+	case "task":
+		if len(parts) < 2 {
+			fmt.Fprintln(out, "Usage: task <id> [show|cancel|requeue]")
+			return true
+		}
+		action := "show"
+		if len(parts) > 2 {
+			action = parts[2]
+		}
+		taskCommand(out, master, parts[1], action)
+	case "quit", "exit":
+		return false
+	default:
+		fmt.Fprintf(out, "Unknown command: %s\n", cmd)
+	}
+	return true
+}
+
+func printHelp(out io.Writer) {
+	fmt.Fprintln(out, "Commands:")
+	fmt.Fprintln(out, "  help            - Show this help")
+	fmt.Fprintln(out, "  status/stats    - Show master statistics")
+	fmt.Fprintln(out, "  submit <url>    - Submit a single task")
+	fmt.Fprintln(out, "  batch <url> <n> - Submit n tasks for URL")
+	fmt.Fprintln(out, "  workers         - List connected workers")
+	fmt.Fprintln(out, "  worker <id>     - Show one worker (Tab completes IDs)")
+	fmt.Fprintln(out, "  tasks [status]  - List recent tasks (optionally only pending, failed, ...)")
+	fmt.Fprintln(out, "  task <id> [show|cancel|requeue]")
+	fmt.Fprintln(out, "                  - Show, cancel or requeue one task (Tab completes IDs)")
+	fmt.Fprintln(out, "  quit/exit       - Stop the master (same as Ctrl+C on an empty line)")
+	fmt.Fprintln(out, "Up/Down and Ctrl+R search the command history.")
+}
+
+func printStats(out io.Writer, master *distributed.Master) {
+	stats := master.GetStats()
+	data, _ := json.MarshalIndent(stats, "", "  ")
+	fmt.Fprintln(out, string(data))
+}
+
+func submitTask(out io.Writer, master *distributed.Master, url string) {
+	task := &api.Task{
+		URL:       url,
+		SessionID: fmt.Sprintf("session_%d", time.Now().Unix()),
+	}
+
+	if err := master.SubmitTask(task); err != nil {
+		fmt.Fprintf(out, "Error submitting task: %v\n", err)
+		return
+	}
+
+	fmt.Fprintf(out, "Task submitted: %s\n", task.ID)
+}
+
+func submitBatch(out io.Writer, master *distributed.Master, url string, count int) {
+	var tasks []*api.Task
+	baseSession := fmt.Sprintf("session_%d", time.Now().Unix())
+
+	for i := 0; i < count; i++ {
+		task := &api.Task{
+			URL:       url,
+			SessionID: fmt.Sprintf("%s_%d", baseSession, i),
+		}
+		tasks = append(tasks, task)
+	}
+
+	if err := master.SubmitTasks(tasks); err != nil {
+		fmt.Fprintf(out, "Error submitting tasks: %v\n", err)
+		return
+	}
+
+	fmt.Fprintf(out, "Submitted %d tasks\n", count)
+}
+
+func printWorkers(out io.Writer, master *distributed.Master) {
+	workers := master.GetHealthyWorkers()
+	if len(workers) == 0 {
+		fmt.Fprintln(out, "No healthy workers connected")
+		return
+	}
+
+	fmt.Fprintf(out, "%-20s %-15s %-10s %-10s %-10s %-10s\n",
+		"ID", "Hostname", "Status", "Active", "Total", "Success")
+	fmt.Fprintln(out, strings.Repeat("-", 80))
+
+	for _, w := range workers {
+		fmt.Fprintf(out, "%-20s %-15s %-10s %-10d %-10d %-10d\n",
+			truncate(w.ID, 20),
+			truncate(w.Hostname, 15),
+			w.Status,
+			w.ActiveTasks,
+			w.TotalTasks,
+			w.SuccessCount,
+		)
+	}
+}
+
+// printWorker prints one registered worker (healthy or not) in full
+func printWorker(out io.Writer, master *distributed.Master, id string) {
+	for _, w := range master.ListWorkers() {
+		if w.ID == id {
+			data, _ := json.MarshalIndent(w, "", "  ")
+			fmt.Fprintln(out, string(data))
+			return
+		}
+	}
+	fmt.Fprintf(out, "Unknown worker: %s\n", id)
+}
+
+// recentTasks is how many tasks the tasks command lists and Tab completion offers
+const recentTasks = 20
+
+func printTasks(out io.Writer, master *distributed.Master, status api.TaskStatus) {
+	tasks := master.Tasks(status, recentTasks)
+	if len(tasks) == 0 {
+		fmt.Fprintln(out, "No tasks")
+		return
+	}
+
+	fmt.Fprintf(out, "%-26s %-10s %-20s %-8s %s\n", "ID", "Status", "Worker", "Tries", "URL")
+	fmt.Fprintln(out, strings.Repeat("-", 100))
+	for _, t := range tasks {
+		fmt.Fprintf(out, "%-26s %-10s %-20s %-8d %s\n",
+			t.ID,
+			t.Status,
+			truncate(t.WorkerID, 20),
+			t.Attempts,
+			truncate(t.URL, 40),
+		)
+	}
+}
+
+// taskCommand shows, cancels or requeues a single task
+func taskCommand(out io.Writer, master *distributed.Master, id, action string) {
+	var task api.Task
+	var err error
+	switch action {
+	case "show":
+		var ok bool
+		if task, ok = master.GetTask(id); !ok {
+			fmt.Fprintf(out, "Unknown task: %s\n", id)
+			return
+		}
+	case "cancel":
+		task, err = master.CancelTask(id)
+	case "requeue":
+		task, err = master.RequeueTask(id)
+	default:
+		fmt.Fprintln(out, "Usage: task <id> [show|cancel|requeue]")
+		return
+	}
+	if err != nil {
+		fmt.Fprintf(out, "Error: %v\n", err)
+		return
+	}
+	data, _ := json.MarshalIndent(task, "", "  ")
+	fmt.Fprintln(out, string(data))
+}
+
+func truncate(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	return s[:maxLen-3] + "..."
+}
@@ -0,0 +1,200 @@
+// Package node runs the distributed master and worker roles; used by the
+// "vgbot master" / "vgbot worker" subcommands and the standalone binaries.
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"vgbot/pkg/consent"
+	"vgbot/pkg/distributed"
+)
+
+// RunMaster parses master flags from args and runs the master node until interrupted
+func RunMaster(args []string) error {
+	fs := flag.NewFlagSet("master", flag.ExitOnError)
+	var (
+		bindAddr    = fs.String("bind", "0.0.0.0:8080", "Master bind address")
+		secretKey   = fs.String("secret", "", "Secret key for worker authentication")
+		configFile  = fs.String("config", "", "Tasks to submit on start: JSON or CSV file, CSV URL, or sitemap/site URL")
+		tlsCert     = fs.String("tls-cert", "", "TLS certificate file (serves workers over HTTPS and gRPC over TLS)")
+		tlsKey      = fs.String("tls-key", "", "TLS private key file")
+		maxRetries  = fs.Int("max-retries", 2, "Retries for a failed task before it moves to the dead-letter queue")
+		backoff     = fs.Duration("retry-backoff", 5*time.Second, "Delay before the first retry (doubles on each attempt)")
+		maxBackoff  = fs.Duration("max-retry-backoff", 5*time.Minute, "Upper limit for the retry delay")
+		history     = fs.String("history", defaultHistoryFile(), "Console command history file (empty: in-memory only)")
+		consentFile = fs.String("consent", "./consent.json", "Terms acknowledgment and target whitelist (tasks for other domains are rejected)")
+		auditLog    = fs.String("audit-log", "./audit.log", "Audit log for rejected targets")
+		killFile    = fs.String("kill-switch", "./killswitch.json", "Dashboard kill switch state file; while engaged the master cancels open tasks and accepts none")
+	)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	fmt.Println("╔════════════════════════════════════════════════════════════╗")
+	fmt.Println("║           VGBot - Distributed Master Node                 ║")
+	fmt.Println("╚════════════════════════════════════════════════════════════╝")
+	fmt.Println()
+
+	// Interactive console (terminal only); master output goes through it so logs don't break the prompt
+	out := io.Writer(os.Stdout)
+	console, err := newConsole(*history)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[Master] Warning: interactive console disabled: %v\n", err)
+	}
+	if console != nil {
+		defer console.Close()
+		out = console.Stdout()
+	}
+
+	// Tasks reach only whitelisted targets; the whitelist is managed from the dashboard or "vgbot -cli"
+	store, err := consent.Open(*consentFile, *auditLog)
+	if err != nil {
+		return fmt.Errorf("[Master] consent: %w", err)
+	}
+
+	// Create master
+	config := distributed.MasterConfig{
+		BindAddr:          *bindAddr,
+		SecretKey:         *secretKey,
+		MaxWorkers:        100,
+		TaskTimeout:       5 * time.Minute,
+		HeartbeatInterval: 10 * time.Second,
+		TLSCertFile:       *tlsCert,
+		TLSKeyFile:        *tlsKey,
+		MaxRetries:        *maxRetries,
+		RetryBackoff:      *backoff,
+		MaxRetryBackoff:   *maxBackoff,
+		Output:            out,
+		TargetCheck:       targetCheck(store),
+	}
+
+	master := distributed.NewMaster(config)
+
+	// Handle shutdown gracefully
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+
+	// The console puts the terminal in raw mode, so Ctrl+C reaches it as a key rather than SIGINT
+	stop := sync.OnceFunc(func() {
+		fmt.Fprintln(out, "\n[Master] Shutting down...")
+		master.Stop()
+		cancel()
+	})
+	go func() {
+		<-sigChan
+		stop()
+	}()
+
+	// The dashboard's kill switch also halts this master; checked once before any task is loaded
+	if *killFile != "" {
+		watchKillSwitch(ctx, master, *killFile, 2*time.Second, out)
+	}
+
+	// Load tasks from config if provided
+	if *configFile != "" {
+		go loadTasksFromFile(master, *configFile, out)
+	}
+
+	// Start interactive console in background
+	if console != nil {
+		go console.run(master, stop)
+	}
+
+	// Print status URL
+	scheme := "http"
+	if *tlsCert != "" {
+		scheme = "https"
+	}
+	fmt.Fprintf(out, "[Master] Listening on %s://%s\n", scheme, *bindAddr)
+	fmt.Fprintf(out, "[Master] Status: %s://%s/api/v1/master/status\n", scheme, *bindAddr)
+	fmt.Fprintf(out, "[Master] Workers: %s://%s/api/v1/master/workers\n", scheme, *bindAddr)
+	fmt.Fprintf(out, "[Master] Tasks: %s://%s/api/v1/master/tasks\n", scheme, *bindAddr)
+	fmt.Fprintf(out, "[Master] Dead tasks: %s://%s/api/v1/master/tasks/dead\n", scheme, *bindAddr)
+	fmt.Fprintf(out, "[Master] Task: %s://%s/api/v1/master/task/{id}[/cancel|/requeue]\n", scheme, *bindAddr)
+	fmt.Fprintf(out, "[Master] Stats: %s://%s/api/v1/master/stats\n", scheme, *bindAddr)
+	fmt.Fprintf(out, "[Master] Halt: POST %s://%s/api/v1/master/halt (resume: /api/v1/master/resume)\n", scheme, *bindAddr)
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Press Ctrl+C to stop")
+	fmt.Fprintln(out)
+
+	// Start master (blocking)
+	if err := master.Start(); err != nil && err != http.ErrServerClosed {
+		return fmt.Errorf("[Master] %w", err)
+	}
+
+	<-ctx.Done()
+	fmt.Fprintln(out, "[Master] Stopped")
+	return nil
+}
+
+// targetCheck approves task URLs against the consent store; blocked attempts go to the audit log
+func targetCheck(store *consent.Store) func(string) error {
+	return func(rawURL string) error {
+		err := store.Check(rawURL)
+		if errors.Is(err, consent.ErrNotWhitelisted) {
+			d, _ := consent.Normalize(rawURL)
+			store.Audit(consent.AuditEntry{Action: consent.ActionTargetBlocked, Domain: d, Source: "master", Detail: rawURL})
+		}
+		return err
+	}
+}
+
+// watchKillSwitch halts the master while the kill switch file says engaged and resumes it after a
+// rearm. The file is checked once before returning, then polled in the background until ctx ends.
+// Only a halt that came from the file is lifted here; a halt through the API stays in place.
+func watchKillSwitch(ctx context.Context, master *distributed.Master, path string, every time.Duration, out io.Writer) {
+	haltedByFile := false
+	check := func() {
+		engaged := killSwitchEngaged(path)
+		switch {
+		case engaged && !master.Halted():
+			n := master.Halt()
+			haltedByFile = true
+			fmt.Fprintf(out, "[Master] Kill switch engaged (%s): %d tasks cancelled\n", path, n)
+		case !engaged && haltedByFile:
+			master.Resume()
+			haltedByFile = false
+			fmt.Fprintln(out, "[Master] Kill switch rearmed: accepting tasks again")
+		}
+	}
+
+	check()
+	go func() {
+		ticker := time.NewTicker(every)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				check()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// killSwitchEngaged reports whether the kill switch file written by the web server is engaged
+func killSwitchEngaged(path string) bool {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	var state struct {
+		Engaged bool `json:"engaged"`
+	}
+	return json.Unmarshal(data, &state) == nil && state.Engaged
+}
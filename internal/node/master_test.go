@@ -0,0 +1,43 @@
+package node
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"vgbot/pkg/distributed"
+)
+
+func TestWatchKillSwitch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "killswitch.json")
+	os.WriteFile(path, []byte(`{"engaged": true, "reason": "stop"}`), 0644)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	master := distributed.NewMaster(distributed.DefaultMasterConfig())
+
+	// The first check is synchronous: tasks loaded after it already see the halt
+	watchKillSwitch(ctx, master, path, 20*time.Millisecond, io.Discard)
+	if !master.Halted() {
+		t.Fatal("master not halted while the kill switch is engaged")
+	}
+
+	os.WriteFile(path, []byte(`{"engaged": false}`), 0644)
+	deadline := time.Now().Add(2 * time.Second)
+	for master.Halted() {
+		if time.Now().After(deadline) {
+			t.Fatal("master not resumed after rearm")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// A halt through the API is not lifted by a disengaged file
+	master.Halt()
+	time.Sleep(100 * time.Millisecond)
+	if !master.Halted() {
+		t.Error("watcher resumed a master halted through the API")
+	}
+}
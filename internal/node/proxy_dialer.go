@@ -0,0 +1,155 @@
+package node
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	xproxy "golang.org/x/net/proxy"
+
+	"vgbot/pkg/proxy"
+)
+
+// proxyDialTimeout bounds the TCP connect to the proxy itself
+const proxyDialTimeout = 15 * time.Second
+
+// applyProxy routes transport through the task's proxy. HTTP(S) proxies use CONNECT via
+// Transport.Proxy; SOCKS5 and SOCKS4/4a replace the dialer so every connection, including
+// TLS, is tunnelled. Credentials come from the task, so each task can use its own login.
+func applyProxy(transport *http.Transport, pc *proxy.ProxyConfig) error {
+	addr := net.JoinHostPort(pc.Host, strconv.Itoa(pc.Port))
+	base := &net.Dialer{Timeout: proxyDialTimeout, KeepAlive: 30 * time.Second}
+
+	switch scheme := strings.ToLower(pc.Protocol); scheme {
+	case "", "http", "https":
+		if scheme == "" {
+			scheme = "http"
+		}
+		u := &url.URL{Scheme: scheme, Host: addr}
+		if pc.Username != "" {
+			u.User = url.UserPassword(pc.Username, pc.Password)
+		}
+		transport.Proxy = http.ProxyURL(u)
+	case "socks5", "socks5h":
+		var auth *xproxy.Auth
+		if pc.Username != "" {
+			auth = &xproxy.Auth{User: pc.Username, Password: pc.Password}
+		}
+		d, err := xproxy.SOCKS5("tcp", addr, auth, base)
+		if err != nil {
+			return fmt.Errorf("socks5 proxy %s: %w", addr, err)
+		}
+		cd, ok := d.(xproxy.ContextDialer)
+		if !ok {
+			return fmt.Errorf("socks5 proxy %s: dialer does not support contexts", addr)
+		}
+		transport.Proxy = nil
+		transport.DialContext = cd.DialContext
+	case "socks4", "socks4a":
+		d := &socks4Dialer{proxyAddr: addr, userID: pc.Username, remoteDNS: scheme == "socks4a", forward: base}
+		transport.Proxy = nil
+		transport.DialContext = d.DialContext
+	default:
+		return fmt.Errorf("unsupported proxy protocol %q (http, https, socks5, socks4, socks4a)", pc.Protocol)
+	}
+	return nil
+}
+
+// SOCKS4 protocol constants
+const (
+	socks4Version   = 0x04
+	socks4Connect   = 0x01
+	socks4Granted   = 0x5a
+	socks4ReplySize = 8
+)
+
+// socks4Dialer is a SOCKS4/4a client; golang.org/x/net/proxy only speaks SOCKS5.
+// SOCKS4 resolves the target locally (IPv4 only); SOCKS4a lets the proxy resolve it.
+// The user ID is the only credential SOCKS4 carries.
+type socks4Dialer struct {
+	proxyAddr string
+	userID    string
+	remoteDNS bool
+	forward   *net.Dialer
+}
+
+// DialContext connects to addr through the proxy
+func (d *socks4Dialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	if network != "tcp" && network != "tcp4" {
+		return nil, fmt.Errorf("socks4: network %q not supported", network)
+	}
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil || port < 1 || port > 65535 {
+		return nil, fmt.Errorf("socks4: invalid port in %q", addr)
+	}
+
+	req := []byte{socks4Version, socks4Connect, 0, 0}
+	binary.BigEndian.PutUint16(req[2:], uint16(port))
+	var hostname string
+	ip := net.ParseIP(host).To4()
+	switch {
+	case ip != nil:
+	case d.remoteDNS:
+		// 0.0.0.x with x != 0 tells a SOCKS4a proxy that a hostname follows the user ID
+		ip, hostname = net.IPv4(0, 0, 0, 1).To4(), host
+	default:
+		ips, err := net.DefaultResolver.LookupIP(ctx, "ip4", host)
+		if err != nil {
+			return nil, fmt.Errorf("socks4: resolve %s: %w", host, err)
+		}
+		ip = ips[0].To4()
+	}
+	req = append(req, ip...)
+	req = append(append(req, d.userID...), 0)
+	if hostname != "" {
+		req = append(append(req, hostname...), 0)
+	}
+
+	conn, err := d.forward.DialContext(ctx, "tcp", d.proxyAddr)
+	if err != nil {
+		return nil, err
+	}
+	// The handshake honours ctx cancellation through the deadline
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	if err := socks4Handshake(conn, req); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("socks4 proxy %s: %w", d.proxyAddr, err)
+	}
+	conn.SetDeadline(time.Time{})
+	return conn, nil
+}
+
+func socks4Handshake(conn net.Conn, req []byte) error {
+	if _, err := conn.Write(req); err != nil {
+		return err
+	}
+	var reply [socks4ReplySize]byte
+	if _, err := io.ReadFull(conn, reply[:]); err != nil {
+		return err
+	}
+	if reply[0] != 0 {
+		return errors.New("malformed reply")
+	}
+	switch reply[1] {
+	case socks4Granted:
+		return nil
+	case 0x5c, 0x5d:
+		return errors.New("rejected: identd check failed")
+	default:
+		return fmt.Errorf("request rejected (code 0x%02x)", reply[1])
+	}
+}
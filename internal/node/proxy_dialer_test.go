@@ -0,0 +1,164 @@
+package node
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"vgbot/pkg/proxy"
+)
+
+// fakeSOCKS accepts one kind of SOCKS handshake, records what the client sent and pipes the
+// connection to the requested target
+func fakeSOCKS(t *testing.T, handshake func(c net.Conn) (target string, ok bool)) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			c, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer c.Close()
+				target, ok := handshake(c)
+				if !ok {
+					return
+				}
+				up, err := net.Dial("tcp", target)
+				if err != nil {
+					return
+				}
+				defer up.Close()
+				go io.Copy(up, c)
+				io.Copy(c, up)
+			}()
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func readCString(r io.Reader) string {
+	var b [1]byte
+	var out []byte
+	for {
+		if _, err := r.Read(b[:]); err != nil || b[0] == 0 {
+			return string(out)
+		}
+		out = append(out, b[0])
+	}
+}
+
+func proxyConfigFor(addr, protocol, user, pass string) *proxy.ProxyConfig {
+	host, port, _ := net.SplitHostPort(addr)
+	p, _ := strconv.Atoi(port)
+	return &proxy.ProxyConfig{Host: host, Port: p, Protocol: protocol, Username: user, Password: pass}
+}
+
+func getThrough(t *testing.T, pc *proxy.ProxyConfig, target string) {
+	t.Helper()
+	client, err := createHTTPClient(pc, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.CloseIdleConnections()
+	resp, err := client.Get(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if body, _ := io.ReadAll(resp.Body); string(body) != "ok" {
+		t.Fatalf("body = %q", body)
+	}
+}
+
+func TestWorkerClientSOCKS5Auth(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.Write([]byte("ok")) }))
+	defer srv.Close()
+
+	var gotUser, gotPass string
+	addr := fakeSOCKS(t, func(c net.Conn) (string, bool) {
+		hdr := make([]byte, 2)
+		io.ReadFull(c, hdr)
+		io.ReadFull(c, make([]byte, hdr[1]))
+		c.Write([]byte{5, 2}) // username/password
+		io.ReadFull(c, hdr)
+		user := make([]byte, hdr[1])
+		io.ReadFull(c, user)
+		io.ReadFull(c, hdr[:1])
+		pass := make([]byte, hdr[0])
+		io.ReadFull(c, pass)
+		gotUser, gotPass = string(user), string(pass)
+		c.Write([]byte{1, 0})
+
+		req := make([]byte, 4)
+		io.ReadFull(c, req)
+		var host string
+		switch req[3] {
+		case 1:
+			ip := make([]byte, 4)
+			io.ReadFull(c, ip)
+			host = net.IP(ip).String()
+		case 3:
+			io.ReadFull(c, hdr[:1])
+			name := make([]byte, hdr[0])
+			io.ReadFull(c, name)
+			host = string(name)
+		}
+		port := make([]byte, 2)
+		io.ReadFull(c, port)
+		c.Write([]byte{5, 0, 0, 1, 0, 0, 0, 0, 0, 0})
+		return net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(port)))), true
+	})
+
+	getThrough(t, proxyConfigFor(addr, "socks5", "task-user", "task-pass"), srv.URL)
+	if gotUser != "task-user" || gotPass != "task-pass" {
+		t.Errorf("auth = %q/%q", gotUser, gotPass)
+	}
+}
+
+func TestWorkerClientSOCKS4(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.Write([]byte("ok")) }))
+	defer srv.Close()
+	_, srvPort, _ := net.SplitHostPort(srv.Listener.Addr().String())
+
+	var gotUser, gotHost string
+	addr := fakeSOCKS(t, func(c net.Conn) (string, bool) {
+		req := make([]byte, 8)
+		io.ReadFull(c, req)
+		if req[0] != 4 || req[1] != 1 {
+			return "", false
+		}
+		gotUser = readCString(c)
+		ip := net.IP(req[4:8])
+		host := ip.String()
+		if bytes.Equal(req[4:7], []byte{0, 0, 0}) {
+			gotHost = readCString(c) // SOCKS4a
+			host = "127.0.0.1"
+		}
+		c.Write([]byte{0, 0x5a, 0, 0, 0, 0, 0, 0})
+		return net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(req[2:4])))), true
+	})
+
+	getThrough(t, proxyConfigFor(addr, "socks4", "ident", ""), srv.URL)
+	if gotUser != "ident" || gotHost != "" {
+		t.Errorf("socks4 user = %q, host = %q", gotUser, gotHost)
+	}
+	getThrough(t, proxyConfigFor(addr, "socks4a", "", ""), "http://target.test:"+srvPort)
+	if gotHost != "target.test" {
+		t.Errorf("socks4a host = %q", gotHost)
+	}
+
+	if _, err := createHTTPClient(proxyConfigFor(addr, "ftp", "", ""), nil); err == nil {
+		t.Error("unsupported protocol accepted")
+	}
+}
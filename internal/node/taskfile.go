@@ -0,0 +1,323 @@
+package node
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"vgbot/pkg/api"
+	"vgbot/pkg/distributed"
+	"vgbot/pkg/proxy"
+	"vgbot/pkg/sitemap"
+)
+
+// maxRowCount caps the visits a single task file row can expand to
+const maxRowCount = 10000
+
+// taskSpec is one row of a task file: a URL, how many visits to submit for it and
+// the optional keyword/proxy every visit of the row carries
+type taskSpec struct {
+	URL       string `json:"url"`
+	SessionID string `json:"session_id"`
+	Count     int    `json:"count"`
+	Keyword   string `json:"keyword"` // "a|b|c" rotates keywords across the row's visits
+	Proxy     string `json:"proxy"`   // host:port, host:port:user:pass or scheme://[user:pass@]host:port
+}
+
+// loadTasksFromFile submits the tasks defined by source. Source is a JSON task file, a CSV file
+// (local or http(s) URL ending in .csv) or a sitemap URL (a sitemap/.xml.gz or a site root whose
+// sitemap is discovered via /sitemap.xml or robots.txt).
+func loadTasksFromFile(master *distributed.Master, source string, out io.Writer) {
+	specs, err := readTaskSource(source, nil, master.CheckTarget)
+	if err != nil {
+		fmt.Fprintf(out, "[Master] Warning: Could not load tasks from %s: %v\n", source, err)
+		return
+	}
+	tasks, err := buildTasks(specs)
+	if err != nil {
+		fmt.Fprintf(out, "[Master] Warning: Invalid task file %s: %v\n", source, err)
+		return
+	}
+
+	total := 0
+	var submitErr error
+	for _, task := range tasks {
+		if err := master.SubmitTask(task); err != nil {
+			submitErr = err
+			continue
+		}
+		total++
+	}
+	if submitErr != nil {
+		fmt.Fprintf(out, "[Master] Loaded %d of %d tasks from %s (%d rows): %v\n", total, len(tasks), source, len(specs), submitErr)
+		return
+	}
+	fmt.Fprintf(out, "[Master] Loaded %d tasks from %s (%d rows)\n", total, source, len(specs))
+}
+
+// readTaskSource reads the task rows of a JSON/CSV file or sitemap URL. check (may be nil)
+// approves every remote URL before it is fetched.
+func readTaskSource(source string, client *http.Client, check func(string) error) ([]taskSpec, error) {
+	if client == nil {
+		client = sitemap.NewClient(source, nil)
+	}
+	if check == nil {
+		check = func(string) error { return nil }
+	}
+	remote := strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://")
+	if remote {
+		if err := check(source); err != nil {
+			return nil, err
+		}
+	}
+	if remote && !isCSVSource(source) {
+		return sitemapSpecs(taskSpec{URL: source}, client)
+	}
+
+	var data []byte
+	var err error
+	if remote {
+		data, err = fetchTaskFile(source, client)
+	} else {
+		data, err = os.ReadFile(source)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if isCSVSource(source) {
+		return parseTaskCSV(data)
+	}
+	return parseTaskJSON(data, client, check)
+}
+
+// isCSVSource reports whether the file or URL path has a .csv extension
+func isCSVSource(source string) bool {
+	if u, err := url.Parse(source); err == nil && u.Scheme != "" {
+		source = u.Path
+	}
+	return strings.HasSuffix(strings.ToLower(source), ".csv")
+}
+
+// fetchTaskFile downloads a remote task file
+func fetchTaskFile(rawURL string, client *http.Client) ([]byte, error) {
+	resp, err := client.Get(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", rawURL, resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, 16<<20))
+}
+
+// parseTaskJSON parses the JSON task file format:
+//
+//	{"urls": [...], "tasks": [{"url", "count", "keyword", "proxy", "session_id"}],
+//	 "sitemaps": [{"url", "count", "keyword", "proxy"}]}
+//
+// Every page of a "sitemaps" entry becomes a row with the entry's count, keyword and proxy.
+func parseTaskJSON(data []byte, client *http.Client, check func(string) error) ([]taskSpec, error) {
+	var file struct {
+		URLs     []string   `json:"urls"`
+		Tasks    []taskSpec `json:"tasks"`
+		Sitemaps []taskSpec `json:"sitemaps"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+
+	specs := make([]taskSpec, 0, len(file.URLs)+len(file.Tasks))
+	for _, u := range file.URLs {
+		specs = append(specs, taskSpec{URL: u})
+	}
+	specs = append(specs, file.Tasks...)
+	for _, sm := range file.Sitemaps {
+		if err := check(sm.URL); err != nil {
+			return nil, err
+		}
+		pages, err := sitemapSpecs(sm, client)
+		if err != nil {
+			return nil, err
+		}
+		specs = append(specs, pages...)
+	}
+	return specs, nil
+}
+
+// taskCSVColumns is the column order of a CSV task file without a header row
+var taskCSVColumns = []string{"url", "count", "keyword", "proxy", "session_id"}
+
+// parseTaskCSV parses a CSV task file. A header row naming the columns (url, count, keyword,
+// proxy, session_id; any order, only url required) is optional; without it the columns are
+// read in taskCSVColumns order. Lines starting with # and rows with an empty URL are skipped.
+func parseTaskCSV(data []byte) ([]taskSpec, error) {
+	r := csv.NewReader(strings.NewReader(string(data)))
+	r.Comment = '#'
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	var columns map[string]int
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var specs []taskSpec
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			return specs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := r.FieldPos(0)
+		if columns == nil {
+			columns = map[string]int{}
+			for i, name := range record {
+				columns[strings.ToLower(strings.TrimSpace(name))] = i
+			}
+			if _, ok := columns["url"]; ok {
+				continue
+			}
+			columns = map[string]int{}
+			for i, name := range taskCSVColumns {
+				columns[name] = i
+			}
+		}
+
+		spec := taskSpec{
+			URL:       field(record, "url"),
+			Keyword:   field(record, "keyword"),
+			Proxy:     field(record, "proxy"),
+			SessionID: field(record, "session_id"),
+		}
+		if spec.URL == "" {
+			continue
+		}
+		if c := field(record, "count"); c != "" {
+			n, err := strconv.Atoi(c)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid count %q", line, c)
+			}
+			spec.Count = n
+		}
+		specs = append(specs, spec)
+	}
+}
+
+// sitemapSpecs expands a sitemap into one row per page, each with spec's count, keyword and proxy.
+// An .xml/.gz URL is read as the sitemap itself; any other URL is treated as the site root.
+func sitemapSpecs(spec taskSpec, client *http.Client) ([]taskSpec, error) {
+	u, err := url.Parse(spec.URL)
+	if err != nil || u.Hostname() == "" {
+		return nil, fmt.Errorf("invalid sitemap URL %q", spec.URL)
+	}
+	var pages []string
+	if path := strings.ToLower(u.Path); strings.HasSuffix(path, ".xml") || strings.HasSuffix(path, ".gz") {
+		pages, err = sitemap.FetchURL(spec.URL, client)
+	} else {
+		pages, err = sitemap.Fetch(spec.URL, client)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("sitemap %s: %w", spec.URL, err)
+	}
+	if len(pages) == 0 {
+		return nil, fmt.Errorf("sitemap %s: no URLs found", spec.URL)
+	}
+
+	specs := make([]taskSpec, len(pages))
+	for i, page := range pages {
+		specs[i] = spec
+		specs[i].URL = page
+	}
+	return specs, nil
+}
+
+// buildTasks validates the rows and expands them into tasks (count visits per row, keywords
+// rotated across the row's visits). Any invalid row rejects the whole file.
+func buildTasks(specs []taskSpec) ([]*api.Task, error) {
+	var tasks []*api.Task
+	for i, spec := range specs {
+		u, err := url.Parse(spec.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+			return nil, fmt.Errorf("row %d: invalid URL %q", i+1, spec.URL)
+		}
+		count := spec.Count
+		if count <= 0 {
+			count = 1
+		}
+		if count > maxRowCount {
+			return nil, fmt.Errorf("row %d: count %d exceeds %d", i+1, count, maxRowCount)
+		}
+		pc, err := parseTaskProxy(spec.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", i+1, err)
+		}
+		var keywords []string
+		for _, kw := range strings.Split(spec.Keyword, "|") {
+			if kw = strings.TrimSpace(kw); kw != "" {
+				keywords = append(keywords, kw)
+			}
+		}
+
+		for j := 0; j < count; j++ {
+			sessionID := spec.SessionID
+			if sessionID == "" {
+				sessionID = fmt.Sprintf("session_%d_%d", time.Now().UnixNano(), j)
+			}
+			task := &api.Task{URL: spec.URL, SessionID: sessionID, Proxy: pc}
+			if len(keywords) > 0 {
+				task.Keyword = keywords[j%len(keywords)]
+			}
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks, nil
+}
+
+// parseTaskProxy parses a row proxy: host:port, host:port:user:pass or scheme://[user:pass@]host:port.
+// An empty value means the worker connects directly.
+func parseTaskProxy(raw string) (*proxy.ProxyConfig, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	// Errors name only host:port so credentials don't end up in the master log
+	proxyURL := raw
+	if !strings.Contains(raw, "://") {
+		parts := strings.Split(raw, ":")
+		switch len(parts) {
+		case 2:
+			proxyURL = "http://" + raw
+		case 4:
+			proxyURL = "http://" + url.UserPassword(parts[2], parts[3]).String() + "@" + parts[0] + ":" + parts[1]
+		default:
+			return nil, fmt.Errorf("invalid proxy: expected host:port or host:port:user:pass")
+		}
+	}
+	u, err := url.Parse(proxyURL)
+	if err != nil || u.Hostname() == "" {
+		return nil, fmt.Errorf("invalid proxy URL")
+	}
+	port, err := strconv.Atoi(u.Port())
+	if err != nil || port < 1 || port > 65535 {
+		return nil, fmt.Errorf("invalid proxy port in %s", u.Host)
+	}
+	pc := &proxy.ProxyConfig{Host: u.Hostname(), Port: port, Protocol: u.Scheme}
+	if u.User != nil {
+		pc.Username = u.User.Username()
+		pc.Password, _ = u.User.Password()
+	}
+	return pc, nil
+}
@@ -0,0 +1,109 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTaskFileCSV(t *testing.T) {
+	csv := `# campaign
+keyword,url,count,proxy
+"running shoes|trail shoes",https://shop.example/a,3,10.0.0.1:8080:bob:s3cret
+,https://shop.example/b,,socks5://10.0.0.2:1080
+`
+	path := filepath.Join(t.TempDir(), "tasks.csv")
+	os.WriteFile(path, []byte(csv), 0644)
+
+	specs, err := readTaskSource(path, nil, nil)
+	if err != nil || len(specs) != 2 {
+		t.Fatalf("specs = %+v, %v", specs, err)
+	}
+	tasks, err := buildTasks(specs)
+	if err != nil || len(tasks) != 4 {
+		t.Fatalf("tasks = %d, %v", len(tasks), err)
+	}
+	if kws := tasks[0].Keyword + "," + tasks[1].Keyword + "," + tasks[2].Keyword; kws != "running shoes,trail shoes,running shoes" {
+		t.Errorf("keywords = %s", kws)
+	}
+	if p := tasks[0].Proxy; p == nil || p.Host != "10.0.0.1" || p.Port != 8080 || p.Username != "bob" || p.Password != "s3cret" {
+		t.Errorf("proxy = %+v", p)
+	}
+	if last := tasks[3]; last.URL != "https://shop.example/b" || last.Keyword != "" || last.Proxy.Protocol != "socks5" {
+		t.Errorf("last = %+v", last)
+	}
+
+	// Without a header the columns are url,count,keyword,proxy
+	specs, _ = parseTaskCSV([]byte("https://shop.example/c,2,boots\n"))
+	if len(specs) != 1 || specs[0].Count != 2 || specs[0].Keyword != "boots" {
+		t.Errorf("headerless = %+v", specs)
+	}
+	for _, bad := range []string{"https://shop.example/c,two\n", "ftp://shop.example/c\n", "https://shop.example/c,1,,10.0.0.1\n"} {
+		specs, err := parseTaskCSV([]byte(bad))
+		if err == nil {
+			_, err = buildTasks(specs)
+		}
+		if err == nil {
+			t.Errorf("%q: expected error", bad)
+		}
+	}
+}
+
+func TestTaskFileSitemap(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/sitemap.xml":
+			fmt.Fprintf(w, `<urlset><url><loc>%[1]s/a</loc></url><url><loc>%[1]s/b</loc></url><url><loc>https://other.example/x</loc></url></urlset>`, srv.URL)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	// Site root: sitemap discovered at /sitemap.xml, foreign URLs dropped
+	specs, err := readTaskSource(srv.URL, srv.Client(), nil)
+	if err != nil || len(specs) != 2 || specs[1].URL != srv.URL+"/b" {
+		t.Fatalf("site root = %+v, %v", specs, err)
+	}
+
+	path := filepath.Join(t.TempDir(), "tasks.json")
+	os.WriteFile(path, []byte(fmt.Sprintf(`{"urls":["%[1]s/"],"sitemaps":[{"url":"%[1]s/sitemap.xml","count":2,"keyword":"shoes"}]}`, srv.URL)), 0644)
+	specs, err = readTaskSource(path, srv.Client(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tasks, err := buildTasks(specs)
+	if err != nil || len(tasks) != 5 || tasks[4].URL != srv.URL+"/b" || tasks[4].Keyword != "shoes" {
+		t.Fatalf("json tasks = %d, %v", len(tasks), err)
+	}
+
+	if _, err := readTaskSource(srv.URL+"/missing.xml", srv.Client(), nil); err == nil {
+		t.Error("empty sitemap: expected error")
+	}
+}
+
+func TestTaskSourceCheckedBeforeFetch(t *testing.T) {
+	fetched := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetched++
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+	reject := func(string) error { return errors.New("not whitelisted") }
+
+	path := filepath.Join(t.TempDir(), "tasks.json")
+	os.WriteFile(path, []byte(fmt.Sprintf(`{"sitemaps":[{"url":"%s/sitemap.xml"}]}`, srv.URL)), 0644)
+	for _, source := range []string{srv.URL, srv.URL + "/tasks.csv", path} {
+		if _, err := readTaskSource(source, srv.Client(), reject); err == nil {
+			t.Errorf("%s: expected error", source)
+		}
+	}
+	if fetched != 0 {
+		t.Errorf("fetched %d times before the target check", fetched)
+	}
+}
@@ -0,0 +1,109 @@
+package proxy
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultCheckCacheTTL checker sonucunun varsayılan geçerlilik süresi
+const DefaultCheckCacheTTL = 6 * time.Hour
+
+// CheckCache checker sonuçlarını proxy+gün anahtarıyla tutar. TTL içinde (ve aynı gün) test edilmiş
+// proxy yeniden test edilmez: canlıysa önceki sonuç kullanılır, ölüyse atlanır.
+type CheckCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	day     string                  // entries'in ait olduğu gün; gün değişince önbellek boşalır
+	entries map[string]checkVerdict // proxy key → sonuç
+	hits    int64
+	misses  int64
+}
+
+type checkVerdict struct {
+	live      *LiveProxy // nil = ölü
+	checkedAt time.Time
+}
+
+// CheckCacheStats önbellek istatistikleri (API için)
+type CheckCacheStats struct {
+	Hits    int64 `json:"cache_hits"`
+	Misses  int64 `json:"cache_misses"`
+	Entries int   `json:"cache_entries"`
+}
+
+// NewCheckCache ttl <= 0 ise önbellek kapalıdır
+func NewCheckCache(ttl time.Duration) *CheckCache {
+	return &CheckCache{ttl: ttl, entries: make(map[string]checkVerdict)}
+}
+
+// SetTTL geçerlilik süresini değiştirir (<= 0 kapatır)
+func (c *CheckCache) SetTTL(ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ttl = ttl
+}
+
+// rollLocked gün değiştiyse önceki günün sonuçlarını atar
+func (c *CheckCache) rollLocked(now time.Time) {
+	day := now.Format("2006-01-02")
+	if day != c.day {
+		c.day = day
+		c.entries = make(map[string]checkVerdict)
+	}
+}
+
+// Lookup proxy'nin taze sonucunu döner. ok=false ise test edilmeli; ok=true ve live=nil ise proxy ölü.
+func (c *CheckCache) Lookup(p *ProxyConfig, now time.Time) (live *LiveProxy, ok bool) {
+	if c == nil || p == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ttl <= 0 {
+		return nil, false
+	}
+	c.rollLocked(now)
+	v, found := c.entries[p.Key()]
+	if !found || now.Sub(v.checkedAt) > c.ttl {
+		c.misses++
+		return nil, false
+	}
+	c.hits++
+	if v.live == nil {
+		return nil, true
+	}
+	copied := *v.live
+	copied.ProxyConfig = p
+	return &copied, true
+}
+
+// Store test sonucunu kaydeder (live nil = ölü)
+func (c *CheckCache) Store(p *ProxyConfig, live *LiveProxy, now time.Time) {
+	if c == nil || p == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ttl <= 0 {
+		return
+	}
+	c.rollLocked(now)
+	c.entries[p.Key()] = checkVerdict{live: live, checkedAt: now}
+}
+
+// ResetStats isabet/ıskalama sayaçlarını sıfırlar (her checker çalıştırmasında)
+func (c *CheckCache) ResetStats() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.hits, c.misses = 0, 0
+}
+
+// Stats anlık istatistikler
+func (c *CheckCache) Stats() CheckCacheStats {
+	if c == nil {
+		return CheckCacheStats{}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return CheckCacheStats{Hits: c.hits, Misses: c.misses, Entries: len(c.entries)}
+}
@@ -0,0 +1,83 @@
+package proxy
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCheckCacheTTLAndDay(t *testing.T) {
+	c := NewCheckCache(time.Hour)
+	live := &ProxyConfig{Host: "10.0.0.1", Port: 8080, Protocol: "http"}
+	dead := &ProxyConfig{Host: "10.0.0.2", Port: 8080, Protocol: "http"}
+	now := time.Date(2026, 5, 1, 10, 0, 0, 0, time.Local)
+
+	if _, ok := c.Lookup(live, now); ok {
+		t.Fatal("empty cache hit")
+	}
+	c.Store(live, &LiveProxy{ProxyConfig: live, Country: "TR"}, now)
+	c.Store(dead, nil, now)
+
+	fresh := &ProxyConfig{Host: "10.0.0.1", Port: 8080, Protocol: "http"}
+	if lp, ok := c.Lookup(fresh, now.Add(30*time.Minute)); !ok || lp == nil || lp.Country != "TR" || lp.ProxyConfig != fresh {
+		t.Fatalf("live lookup = %+v, %v", lp, ok)
+	}
+	if lp, ok := c.Lookup(dead, now.Add(30*time.Minute)); !ok || lp != nil {
+		t.Fatalf("dead lookup = %+v, %v", lp, ok)
+	}
+	if _, ok := c.Lookup(live, now.Add(2*time.Hour)); ok {
+		t.Error("expired verdict reused")
+	}
+	c.Store(live, &LiveProxy{ProxyConfig: live}, now.Add(13*time.Hour+30*time.Minute))
+	if _, ok := c.Lookup(live, now.Add(14*time.Hour+10*time.Minute)); ok {
+		t.Error("verdict from previous day reused")
+	}
+	if st := c.Stats(); st.Hits != 2 || st.Misses != 3 {
+		t.Errorf("stats = %+v", st)
+	}
+
+	c.SetTTL(0)
+	c.Store(live, &LiveProxy{ProxyConfig: live}, now)
+	if _, ok := c.Lookup(live, now); ok {
+		t.Error("disabled cache hit")
+	}
+}
+
+func TestRunSliceSkipsCachedProxies(t *testing.T) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write([]byte(`{"status":"success","country":"TR"}`))
+	}))
+	defer srv.Close()
+	host, port, _ := net.SplitHostPort(srv.Listener.Addr().String())
+	p, _ := strconv.Atoi(port)
+
+	checker := NewChecker(2)
+	checker.TestURL = "http://check.invalid/"
+	checker.Cache = NewCheckCache(time.Hour)
+	run := func() int {
+		out := make(chan *LiveProxy, 4)
+		checker.RunSlice(context.Background(), []*ProxyConfig{{Host: host, Port: p, Protocol: "http"}}, out)
+		n := 0
+		for range out {
+			n++
+		}
+		return n
+	}
+
+	if got := run(); got != 1 {
+		t.Fatalf("first run live = %d", got)
+	}
+	if got := run(); got != 1 {
+		t.Fatalf("second run live = %d", got)
+	}
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Errorf("checks = %d, want 1 (second run from cache)", n)
+	}
+}
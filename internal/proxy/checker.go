@@ -3,9 +3,12 @@ package proxy
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"sync"
 	"time"
+
+	"vgbot/pkg/errclass"
 )
 
 // LiveProxy çalışan proxy + hız ve ülke bilgisi
@@ -29,6 +32,7 @@ type Checker struct {
 	TestURL      string // Boşsa ip-api kullanılır
 	Workers      int
 	TimeoutPerProxy time.Duration
+	Cache        *CheckCache // nil değilse yakın zamanda test edilmiş proxy'ler tekrar test edilmez
 }
 
 // NewChecker varsayılan ayarlarla checker oluşturur
@@ -84,12 +88,15 @@ func (c *Checker) CheckOne(ctx context.Context, proxy *ProxyConfig) (*LiveProxy,
 	start := time.Now()
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, errclass.Wrap(errclass.ErrProxyDead, err)
 	}
 	defer resp.Body.Close()
 	elapsed := time.Since(start).Milliseconds()
 	if resp.StatusCode != http.StatusOK {
-		return nil, nil
+		if bannedErr := errclass.Status(resp.StatusCode); bannedErr != nil {
+			return nil, bannedErr
+		}
+		return nil, fmt.Errorf("%w: HTTP %d", errclass.ErrProxyDead, resp.StatusCode)
 	}
 	var apiResp ipAPIResponse
 	if json.NewDecoder(resp.Body).Decode(&apiResp) != nil {
@@ -112,6 +119,18 @@ func (c *Checker) CheckOne(ctx context.Context, proxy *ProxyConfig) (*LiveProxy,
 	}, nil
 }
 
+// checkAndStore proxy'yi test eder ve sonucu önbelleğe yazar (iptal edilen testler yazılmaz)
+func (c *Checker) checkAndStore(ctx context.Context, proxy *ProxyConfig) (*LiveProxy, error) {
+	live, err := c.CheckOne(ctx, proxy)
+	if ctx.Err() == nil {
+		if err != nil {
+			live = nil
+		}
+		c.Cache.Store(proxy, live, time.Now())
+	}
+	return live, err
+}
+
 // Run queue'dan proxy alır, test eder; çalışanları onLive gönderir
 func (c *Checker) Run(ctx context.Context, queue <-chan *ProxyConfig, onLive func(*LiveProxy)) {
 	sem := make(chan struct{}, c.Workers)
@@ -123,10 +142,16 @@ func (c *Checker) Run(ctx context.Context, queue <-chan *ProxyConfig, onLive fun
 			if !ok {
 				return
 			}
+			if live, hit := c.Cache.Lookup(proxy, time.Now()); hit {
+				if live != nil {
+					onLive(live)
+				}
+				continue
+			}
 			sem <- struct{}{}
 			go func(p *ProxyConfig) {
 				defer func() { <-sem }()
-				live, err := c.CheckOne(ctx, p)
+				live, err := c.checkAndStore(ctx, p)
 				if err == nil && live != nil {
 					onLive(live)
 				}
@@ -147,9 +172,21 @@ func (c *Checker) RunSlice(ctx context.Context, queue []*ProxyConfig, liveChan c
 	
 	// PERFORMANCE: Her batch arasında kısa pause, sistem yükünü azaltmak için
 	batchSize := c.Workers * 2
-	for i, p := range queue {
+	checked := 0
+	for _, p := range queue {
+		// Önbellekte taze sonucu olan proxy test edilmez (batch sayacına da girmez)
+		if live, hit := c.Cache.Lookup(p, time.Now()); hit {
+			if live != nil {
+				select {
+				case liveChan <- live:
+				case <-ctx.Done():
+				}
+			}
+			continue
+		}
+
 		// Batch boundary'de kısa pause
-		if i > 0 && i%batchSize == 0 {
+		if checked > 0 && checked%batchSize == 0 {
 			select {
 			case <-ctx.Done():
 				wg.Wait()
@@ -168,12 +205,13 @@ func (c *Checker) RunSlice(ctx context.Context, queue []*ProxyConfig, liveChan c
 		default:
 		}
 		
+		checked++
 		sem <- struct{}{}
 		wg.Add(1)
 		go func(proxy *ProxyConfig) {
 			defer wg.Done()
 			defer func() { <-sem }()
-			live, err := c.CheckOne(ctx, proxy)
+			live, err := c.checkAndStore(ctx, proxy)
 			if err == nil && live != nil {
 				select {
 				case liveChan <- live:
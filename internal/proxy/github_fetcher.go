@@ -5,13 +5,24 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"path"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 )
 
+// GitHub uç noktaları (testlerde httptest sunucusuna çevrilir)
+var (
+	githubAPIBase = "https://api.github.com"
+	githubRawBase = "https://raw.githubusercontent.com"
+)
+
+// DefaultGitHubFetchConcurrency aynı anda indirilen dosya sayısı (GitHubFetchOptions.Concurrency 0 ise)
+const DefaultGitHubFetchConcurrency = 8
+
 // GitHub API (minimal) yanıtları
 type githubRepo struct {
 	DefaultBranch string `json:"default_branch"`
@@ -21,10 +32,126 @@ type githubTree struct {
 	Tree []struct {
 		Path string `json:"path"`
 		Type string `json:"type"`
+		SHA  string `json:"sha"`
 	} `json:"tree"`
 }
 
-var githubRepoURLRe = regexp.MustCompile(`(?i)github\.com[/:]([^/]+)/([^/]+?)(?:/.*)?$`)
+// githubFile repo ağacındaki bir blob (yol + içerik hash'i)
+type githubFile struct {
+	Path string
+	SHA  string
+}
+
+var (
+	githubRepoURLRe = regexp.MustCompile(`(?i)github\.com[/:]([^/]+)/([^/]+?)(?:/.*)?$`)
+	githubOwnerRe   = regexp.MustCompile(`^[A-Za-z0-9](?:[A-Za-z0-9-]{0,38})$`)
+	githubRepoRe    = regexp.MustCompile(`^[A-Za-z0-9._-]{1,100}$`)
+)
+
+// GitHubFetchOptions GitHub'dan proxy listesi çekme ayarları
+type GitHubFetchOptions struct {
+	Concurrency int          // Aynı anda indirilen dosya sayısı (0 = DefaultGitHubFetchConcurrency)
+	Allowlist   []string     // "owner" veya "owner/repo"; boşsa geçerli her repo kabul edilir
+	Cache       *GitHubCache // nil ise önbellek kullanılmaz
+}
+
+// GitHubFetchStats bir çekme işleminin özeti
+type GitHubFetchStats struct {
+	Repos      int      `json:"repos"`
+	Rejected   []string `json:"rejected,omitempty"` // Geçersiz veya allowlist dışı repolar
+	Downloaded int      `json:"downloaded"`         // İndirilen dosya
+	Cached     int      `json:"cached"`             // Değişmediği için atlanan dosya (blob SHA aynı)
+	Failed     int      `json:"failed"`
+}
+
+// GitHubCache GitHub API yanıtlarını ETag ile, dosyaları blob SHA ile önbelleğe alır;
+// değişmeyen repo ağaçları 304 ile döner, değişmeyen dosyalar tekrar indirilmez.
+type GitHubCache struct {
+	mu    sync.Mutex
+	api   map[string]cachedAPIResponse // URL → ETag + gövde
+	files map[string]cachedFile        // owner/repo/path → SHA + parse edilmiş proxy'ler
+}
+
+type cachedAPIResponse struct {
+	etag string
+	body []byte
+}
+
+type cachedFile struct {
+	sha  string
+	list []*ProxyConfig
+}
+
+// NewGitHubCache boş önbellek oluşturur
+func NewGitHubCache() *GitHubCache {
+	return &GitHubCache{api: make(map[string]cachedAPIResponse), files: make(map[string]cachedFile)}
+}
+
+func (c *GitHubCache) apiResponse(url string) (cachedAPIResponse, bool) {
+	if c == nil {
+		return cachedAPIResponse{}, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	r, ok := c.api[url]
+	return r, ok
+}
+
+func (c *GitHubCache) storeAPIResponse(url, etag string, body []byte) {
+	if c == nil || etag == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.api[url] = cachedAPIResponse{etag: etag, body: body}
+}
+
+func (c *GitHubCache) file(key, sha string) ([]*ProxyConfig, bool) {
+	if c == nil || sha == "" {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	f, ok := c.files[key]
+	if !ok || f.sha != sha {
+		return nil, false
+	}
+	return f.list, true
+}
+
+func (c *GitHubCache) storeFile(key, sha string, list []*ProxyConfig) {
+	if c == nil || sha == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.files[key] = cachedFile{sha: sha, list: list}
+}
+
+// validGitHubRepo owner/repo adlarının GitHub kurallarına uyduğunu doğrular
+func validGitHubRepo(owner, repo string) bool {
+	return githubOwnerRe.MatchString(owner) && githubRepoRe.MatchString(repo) && repo != "." && repo != ".."
+}
+
+// githubRepoAllowed repo allowlist'te mi ("owner" tüm repolarına, "owner/repo" tek repoya izin verir)
+func githubRepoAllowed(owner, repo string, allowlist []string) bool {
+	if len(allowlist) == 0 {
+		return true
+	}
+	for _, entry := range allowlist {
+		entry = strings.TrimSpace(entry)
+		if o, r, ok := parseGitHubRepoURL(entry); ok {
+			if strings.EqualFold(o, owner) && strings.EqualFold(r, repo) {
+				return true
+			}
+			continue
+		}
+		if strings.EqualFold(strings.Trim(entry, "/"), owner) {
+			return true
+		}
+	}
+	return false
+}
 
 // parseGitHubRepoURL "https://github.com/owner/repo" veya "owner/repo" -> owner, repo
 func parseGitHubRepoURL(raw string) (owner, repo string, ok bool) {
@@ -57,6 +184,13 @@ func parseGitHubRepoURL(raw string) (owner, repo string, ok bool) {
 // FetchFromGitHubRepos verilen GitHub repo URL'lerinden tüm .txt dosyalarını indirir,
 // proxy satırlarını parse edip tek listede birleştirir (tekrarsız).
 func FetchFromGitHubRepos(ctx context.Context, repoURLs []string, client *http.Client) ([]*ProxyConfig, error) {
+	list, _, err := FetchFromGitHubReposWith(ctx, repoURLs, client, GitHubFetchOptions{})
+	return list, err
+}
+
+// FetchFromGitHubReposWith FetchFromGitHubRepos'un ayarlı hali: repolar allowlist'e göre doğrulanır,
+// dosyalar opts.Concurrency kadar paralel indirilir, önbellekte aynı SHA ile duran dosyalar atlanır.
+func FetchFromGitHubReposWith(ctx context.Context, repoURLs []string, client *http.Client, opts GitHubFetchOptions) ([]*ProxyConfig, GitHubFetchStats, error) {
 	if client == nil {
 		client = &http.Client{
 			Timeout: 30 * time.Second,
@@ -66,61 +200,128 @@ func FetchFromGitHubRepos(ctx context.Context, repoURLs []string, client *http.C
 			},
 		}
 	}
-	all := make([]*ProxyConfig, 0, 8192)
-	seen := make(map[string]struct{})
+	workers := opts.Concurrency
+	if workers <= 0 {
+		workers = DefaultGitHubFetchConcurrency
+	}
 
+	type fileJob struct {
+		owner, repo, branch string
+		file                githubFile
+	}
+	var stats GitHubFetchStats
+	var jobs []fileJob
 	for _, rawURL := range repoURLs {
 		owner, repo, ok := parseGitHubRepoURL(rawURL)
-		if !ok {
+		if !ok || !validGitHubRepo(owner, repo) || !githubRepoAllowed(owner, repo, opts.Allowlist) {
+			stats.Rejected = append(stats.Rejected, strings.TrimSpace(rawURL))
 			continue
 		}
-		branch, err := getDefaultBranch(ctx, client, owner, repo)
+		stats.Repos++
+		branch, err := getDefaultBranch(ctx, client, opts.Cache, owner, repo)
 		if err != nil {
 			continue
 		}
-		paths, err := getRepoFilePaths(ctx, client, owner, repo, branch)
+		files, err := getRepoFiles(ctx, client, opts.Cache, owner, repo, branch)
 		if err != nil {
 			continue
 		}
-		for _, p := range paths {
-			if !strings.HasSuffix(strings.ToLower(p), ".txt") {
-				continue
+		for _, f := range files {
+			if strings.HasSuffix(strings.ToLower(f.Path), ".txt") {
+				jobs = append(jobs, fileJob{owner, repo, branch, f})
 			}
-			list, err := fetchRawFile(ctx, client, owner, repo, branch, p)
+		}
+	}
+	if stats.Repos == 0 && len(stats.Rejected) > 0 {
+		return nil, stats, fmt.Errorf("izin verilen GitHub repo yok (reddedilen: %s)", strings.Join(stats.Rejected, ", "))
+	}
+
+	// Dosyalar paralel indirilir; sonuçlar iş sırasıyla birleştirilir (tekrarsız, deterministik)
+	results := make([][]*ProxyConfig, len(jobs))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, workers)
+	for i, job := range jobs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, job fileJob) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			key := job.owner + "/" + job.repo + "/" + job.file.Path
+			if list, ok := opts.Cache.file(key, job.file.SHA); ok {
+				results[i] = list
+				mu.Lock()
+				stats.Cached++
+				mu.Unlock()
+				return
+			}
+			list, err := fetchRawFile(ctx, client, job.owner, job.repo, job.branch, job.file.Path)
+			mu.Lock()
+			defer mu.Unlock()
 			if err != nil {
-				continue
+				stats.Failed++
+				return
 			}
-			for _, cfg := range list {
-				k := cfg.Key()
-				if _, ok := seen[k]; ok {
-					continue
-				}
-				seen[k] = struct{}{}
-				all = append(all, cfg)
+			stats.Downloaded++
+			results[i] = list
+			opts.Cache.storeFile(key, job.file.SHA, list)
+		}(i, job)
+	}
+	wg.Wait()
+
+	all := make([]*ProxyConfig, 0, 8192)
+	seen := make(map[string]struct{})
+	for _, list := range results {
+		for _, cfg := range list {
+			k := cfg.Key()
+			if _, ok := seen[k]; ok {
+				continue
 			}
+			seen[k] = struct{}{}
+			all = append(all, cfg)
 		}
 	}
-	return all, nil
+	return all, stats, nil
 }
 
-func getDefaultBranch(ctx context.Context, client *http.Client, owner, repo string) (string, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s", owner, repo)
+// getGitHubAPI GitHub API'ye GET atar; önbellekte ETag varsa If-None-Match gönderir ve 304'te önbellekteki gövdeyi kullanır
+func getGitHubAPI(ctx context.Context, client *http.Client, cache *GitHubCache, url string, v interface{}) error {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return "", err
+		return err
 	}
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	cached, hasCached := cache.apiResponse(url)
+	if hasCached {
+		req.Header.Set("If-None-Match", cached.etag)
+	}
 	resp, err := client.Do(req)
 	if err != nil {
-		return "", err
+		return err
 	}
 	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("status %d", resp.StatusCode)
+	var body []byte
+	switch {
+	case resp.StatusCode == http.StatusNotModified && hasCached:
+		body = cached.body
+	case resp.StatusCode == http.StatusOK:
+		if body, err = io.ReadAll(resp.Body); err != nil {
+			return err
+		}
+		cache.storeAPIResponse(url, resp.Header.Get("ETag"), body)
+	default:
+		return fmt.Errorf("status %d", resp.StatusCode)
+	}
+	if json.Unmarshal(body, v) != nil {
+		return fmt.Errorf("decode %s", url)
 	}
+	return nil
+}
+
+func getDefaultBranch(ctx context.Context, client *http.Client, cache *GitHubCache, owner, repo string) (string, error) {
 	var r githubRepo
-	if json.NewDecoder(resp.Body).Decode(&r) != nil {
-		return "", fmt.Errorf("decode repo")
+	if err := getGitHubAPI(ctx, client, cache, fmt.Sprintf("%s/repos/%s/%s", githubAPIBase, owner, repo), &r); err != nil {
+		return "", err
 	}
 	if r.DefaultBranch != "" {
 		return r.DefaultBranch, nil
@@ -128,38 +329,25 @@ func getDefaultBranch(ctx context.Context, client *http.Client, owner, repo stri
 	return "main", nil
 }
 
-func getRepoFilePaths(ctx context.Context, client *http.Client, owner, repo, branch string) ([]string, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/git/trees/%s?recursive=1", owner, repo, branch)
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("Accept", "application/vnd.github.v3+json")
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("status %d", resp.StatusCode)
-	}
+func getRepoFiles(ctx context.Context, client *http.Client, cache *GitHubCache, owner, repo, branch string) ([]githubFile, error) {
 	var t githubTree
-	if json.NewDecoder(resp.Body).Decode(&t) != nil {
-		return nil, fmt.Errorf("decode tree")
+	url := fmt.Sprintf("%s/repos/%s/%s/git/trees/%s?recursive=1", githubAPIBase, owner, repo, branch)
+	if err := getGitHubAPI(ctx, client, cache, url, &t); err != nil {
+		return nil, err
 	}
-	var paths []string
+	var files []githubFile
 	for _, e := range t.Tree {
 		if e.Type == "blob" && e.Path != "" {
-			paths = append(paths, e.Path)
+			files = append(files, githubFile{Path: e.Path, SHA: e.SHA})
 		}
 	}
-	return paths, nil
+	return files, nil
 }
 
 func fetchRawFile(ctx context.Context, client *http.Client, owner, repo, branch, filePath string) ([]*ProxyConfig, error) {
 	escapedPath := strings.TrimPrefix(path.Clean("/"+filePath), "/")
-	rawURL := fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/%s/%s",
-		owner, repo, branch, escapedPath)
+	rawURL := fmt.Sprintf("%s/%s/%s/%s/%s",
+		githubRawBase, owner, repo, branch, escapedPath)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,84 @@
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestFetchFromGitHubReposCachesUnchangedFiles(t *testing.T) {
+	var rawHits int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/owner/list", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"default_branch":"main"}`)
+	})
+	mux.HandleFunc("/repos/owner/list/git/trees/main", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"tree1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"tree1"`)
+		fmt.Fprint(w, `{"tree":[{"path":"http.txt","type":"blob","sha":"a1"},{"path":"socks.txt","type":"blob","sha":"b2"},{"path":"README.md","type":"blob","sha":"c3"}]}`)
+	})
+	mux.HandleFunc("/owner/list/main/", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&rawHits, 1)
+		if strings.HasSuffix(r.URL.Path, "http.txt") {
+			fmt.Fprint(w, "1.1.1.1:80\n2.2.2.2:8080\n")
+			return
+		}
+		fmt.Fprint(w, "2.2.2.2:8080\n3.3.3.3:3128\n")
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	oldAPI, oldRaw := githubAPIBase, githubRawBase
+	githubAPIBase, githubRawBase = srv.URL, srv.URL
+	defer func() { githubAPIBase, githubRawBase = oldAPI, oldRaw }()
+
+	opts := GitHubFetchOptions{Concurrency: 2, Cache: NewGitHubCache()}
+	list, stats, err := FetchFromGitHubReposWith(context.Background(), []string{"https://github.com/owner/list"}, srv.Client(), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 3 || stats.Downloaded != 2 || stats.Cached != 0 {
+		t.Fatalf("first fetch: %d proxies, stats %+v", len(list), stats)
+	}
+
+	list, stats, err = FetchFromGitHubReposWith(context.Background(), []string{"owner/list"}, srv.Client(), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 3 || stats.Cached != 2 || stats.Downloaded != 0 {
+		t.Fatalf("second fetch: %d proxies, stats %+v", len(list), stats)
+	}
+	if got := atomic.LoadInt32(&rawHits); got != 2 {
+		t.Fatalf("raw downloads = %d, want 2", got)
+	}
+}
+
+func TestGitHubRepoAllowlist(t *testing.T) {
+	_, stats, err := FetchFromGitHubReposWith(context.Background(),
+		[]string{"https://github.com/evil/repo", "bad owner/repo"}, http.DefaultClient,
+		GitHubFetchOptions{Allowlist: []string{"TheSpeedX", "https://github.com/mmpx12/proxy-list"}})
+	if err == nil || len(stats.Rejected) != 2 {
+		t.Fatalf("err = %v, rejected = %v", err, stats.Rejected)
+	}
+
+	allowlist := []string{"TheSpeedX", "https://github.com/mmpx12/proxy-list"}
+	for _, tc := range []struct {
+		owner, repo string
+		want        bool
+	}{
+		{"thespeedx", "SOCKS-List", true},
+		{"mmpx12", "proxy-list", true},
+		{"mmpx12", "other", false},
+		{"someone", "proxy-list", false},
+	} {
+		if got := githubRepoAllowed(tc.owner, tc.repo, allowlist); got != tc.want {
+			t.Errorf("githubRepoAllowed(%s/%s) = %v, want %v", tc.owner, tc.repo, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package proxy
+
+import (
+	"math"
+	"time"
+)
+
+// Sağlık skorunun hesaplandığı pencere: son healthWindow sonuç, en az healthMinSamples sonuçla
+const (
+	healthWindow     = 20
+	healthMinSamples = 5
+	// Bu gecikmeye kadar tam, healthSlowLatency'de sıfır gecikme puanı
+	healthFastLatency = time.Second
+	healthSlowLatency = 10 * time.Second
+)
+
+// HealthPolicy sağlık skoruna göre karantina ve kara liste kuralları; MinScore 0 ise kapalıdır.
+// Skor (0-100) son ziyaretlerin başarı oranı (%80) ve ortalama gecikmesinden (%20) hesaplanır.
+type HealthPolicy struct {
+	MinScore       float64       // Bu skorun altına düşen proxy karantinaya alınır
+	Quarantine     time.Duration // Karantina süresi
+	BlacklistAfter int           // Bu kadar karantinadan sonra proxy kalıcı olarak kara listeye alınır
+}
+
+// Enabled karantina kuralı tanımlı mı
+func (h HealthPolicy) Enabled() bool {
+	return h.MinScore > 0 && h.Quarantine > 0
+}
+
+// HealthEvent bir sonucun kaydedilmesinden sonra proxy'ye ne olduğu
+type HealthEvent int
+
+const (
+	HealthKept        HealthEvent = iota // Proxy rotasyonda kalır
+	HealthQuarantined                    // Geçici olarak rotasyon dışı
+	HealthBlacklisted                    // Kalıcı olarak havuzdan çıkarıldı
+	HealthRemoved                        // Politika kapalı: havuzdan silindi (eski davranış)
+)
+
+type healthSample struct {
+	ok      bool
+	latency time.Duration
+}
+
+// proxyHealth tek proxy'nin son sonuçları ve karantina geçmişi
+type proxyHealth struct {
+	samples     []healthSample // Son healthWindow sonuç (eskiden yeniye)
+	quarantines int
+	until       time.Time // Karantina bitişi
+}
+
+// score son sonuçlardan sağlık skorunu hesaplar; yeterli örnek yoksa ok=false
+func (h *proxyHealth) score() (score, successRate float64, avgLatency time.Duration, ok bool) {
+	if h == nil || len(h.samples) == 0 {
+		return 0, 0, 0, false
+	}
+	var success int
+	var total time.Duration
+	for _, s := range h.samples {
+		if s.ok {
+			success++
+			total += s.latency
+		}
+	}
+	successRate = float64(success) / float64(len(h.samples))
+	latencyScore := 0.0
+	if success > 0 {
+		avgLatency = total / time.Duration(success)
+		latencyScore = 1 - float64(avgLatency-healthFastLatency)/float64(healthSlowLatency-healthFastLatency)
+		latencyScore = math.Max(0, math.Min(1, latencyScore))
+	}
+	score = math.Round((successRate*80+latencyScore*20)*10) / 10
+	return score, successRate, avgLatency, len(h.samples) >= healthMinSamples
+}
+
+// SetHealthPolicy karantina ve kara liste kurallarını ayarlar (çalışma sırasında da değiştirilebilir)
+func (p *LivePool) SetHealthPolicy(h HealthPolicy) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.policy = h
+}
+
+// HealthPolicy geçerli sağlık politikasını döner
+func (p *LivePool) HealthPolicy() HealthPolicy {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.policy
+}
+
+// RecordResult proxy ile yapılan ziyaretin sonucunu kaydeder. Politika açıksa skoru eşiğin altına
+// düşen proxy karantinaya, BlacklistAfter kez karantinaya giren proxy kara listeye alınır.
+func (p *LivePool) RecordResult(proxy *ProxyConfig, ok bool, latency time.Duration) HealthEvent {
+	if proxy == nil {
+		return HealthKept
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	h := p.healthLocked(proxy.Key())
+	h.samples = append(h.samples, healthSample{ok: ok, latency: latency})
+	if len(h.samples) > healthWindow {
+		h.samples = h.samples[len(h.samples)-healthWindow:]
+	}
+	if !p.policy.Enabled() {
+		return HealthKept
+	}
+	if score, _, _, known := h.score(); known && score < p.policy.MinScore {
+		return p.quarantineLocked(proxy, h, time.Now())
+	}
+	return HealthKept
+}
+
+// Evict proxy kaynaklı bir hatadan (ölü, engellenmiş) sonra proxy'yi rotasyondan çıkarır.
+// Politika açıksa proxy silinmez, hemen karantinaya alınır; kapalıysa havuzdan silinir.
+func (p *LivePool) Evict(proxy *ProxyConfig) HealthEvent {
+	if proxy == nil {
+		return HealthKept
+	}
+	p.mu.Lock()
+	if !p.policy.Enabled() {
+		p.mu.Unlock()
+		p.Remove(proxy)
+		return HealthRemoved
+	}
+	defer p.mu.Unlock()
+	h := p.healthLocked(proxy.Key())
+	h.samples = append(h.samples, healthSample{ok: false})
+	return p.quarantineLocked(proxy, h, time.Now())
+}
+
+func (p *LivePool) healthLocked(key string) *proxyHealth {
+	h := p.health[key]
+	if h == nil {
+		h = &proxyHealth{}
+		p.health[key] = h
+	}
+	return h
+}
+
+// quarantineLocked proxy'yi karantinaya alır; tekrar eden proxy'yi kara listeye ekleyip havuzdan siler
+func (p *LivePool) quarantineLocked(proxy *ProxyConfig, h *proxyHealth, now time.Time) HealthEvent {
+	h.quarantines++
+	h.samples = h.samples[:0] // Karantina sonrası skor yeni sonuçlarla hesaplanır
+	if p.policy.BlacklistAfter > 0 && h.quarantines >= p.policy.BlacklistAfter {
+		key := proxy.Key()
+		p.blacklist[key] = true
+		delete(p.health, key)
+		p.removeLocked(key)
+		return HealthBlacklisted
+	}
+	h.until = now.Add(p.policy.Quarantine)
+	return HealthQuarantined
+}
+
+// quarantinedLocked proxy karantinada mı (p.mu tutulurken çağrılır)
+func (p *LivePool) quarantinedLocked(key string, now time.Time) bool {
+	h := p.health[key]
+	return h != nil && now.Before(h.until)
+}
+
+// preferenceLocked proxy seçiminde kullanılan skor; henüz ölçülmemiş proxy'ler nötr puan alır
+func (p *LivePool) preferenceLocked(key string) float64 {
+	if score, _, _, ok := p.health[key].score(); ok {
+		return score
+	}
+	return 70
+}
+
+// Blacklisted kara listedeki proxy anahtarlarını döner
+func (p *LivePool) Blacklisted() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	out := make([]string, 0, len(p.blacklist))
+	for key := range p.blacklist {
+		out = append(out, key)
+	}
+	return out
+}
+
+// ProxyHealth proxy başına sağlık özeti (UI tablosu)
+type ProxyHealth struct {
+	HealthScore      *float64   `json:"health_score,omitempty"` // Yeterli örnek yoksa boş
+	SuccessRate      float64    `json:"success_rate"`           // Son ziyaretlerdeki başarı yüzdesi
+	AvgLatencyMs     int64      `json:"avg_latency_ms"`
+	Samples          int        `json:"samples"`
+	Quarantines      int        `json:"quarantines,omitempty"`
+	QuarantinedUntil *time.Time `json:"quarantined_until,omitempty"`
+}
+
+// healthSummaryLocked proxy'nin sağlık özetini hesaplar (p.mu tutulurken çağrılır)
+func (p *LivePool) healthSummaryLocked(key string, now time.Time) ProxyHealth {
+	h := p.health[key]
+	if h == nil {
+		return ProxyHealth{}
+	}
+	score, rate, latency, ok := h.score()
+	out := ProxyHealth{
+		SuccessRate:  math.Round(rate*1000) / 10,
+		AvgLatencyMs: latency.Milliseconds(),
+		Samples:      len(h.samples),
+		Quarantines:  h.quarantines,
+	}
+	if ok {
+		out.HealthScore = &score
+	}
+	if now.Before(h.until) {
+		until := h.until
+		out.QuarantinedUntil = &until
+	}
+	return out
+}
@@ -0,0 +1,86 @@
+package proxy
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHealthQuarantineAndBlacklist(t *testing.T) {
+	pool := NewLivePool()
+	bad := &ProxyConfig{Host: "10.0.0.1", Port: 8080}
+	good := &ProxyConfig{Host: "10.0.0.2", Port: 8080}
+	pool.AddUnchecked(bad)
+	pool.AddUnchecked(good)
+	pool.SetHealthPolicy(HealthPolicy{MinScore: 50, Quarantine: time.Hour, BlacklistAfter: 2})
+
+	for i := 0; i < healthMinSamples; i++ {
+		pool.RecordResult(good, true, 300*time.Millisecond)
+	}
+	var event HealthEvent
+	for i := 0; i < healthMinSamples; i++ {
+		event = pool.RecordResult(bad, false, 0)
+	}
+	if event != HealthQuarantined {
+		t.Fatalf("event = %v, want quarantined after %d failures", event, healthMinSamples)
+	}
+	for i := 0; i < 4; i++ {
+		if got := pool.GetNext(); got == nil || got.Key() != good.Key() {
+			t.Fatalf("GetNext = %v, quarantined proxy must be skipped", got)
+		}
+	}
+
+	api := pool.SnapshotForAPI()
+	for _, p := range api {
+		switch p.Proxy {
+		case good.Key():
+			if p.HealthScore == nil || *p.HealthScore != 100 || p.SuccessRate != 100 {
+				t.Errorf("good proxy health = %+v", p.ProxyHealth)
+			}
+		case bad.Key():
+			if p.QuarantinedUntil == nil || p.Quarantines != 1 {
+				t.Errorf("bad proxy health = %+v", p.ProxyHealth)
+			}
+		}
+	}
+
+	if event := pool.Evict(bad); event != HealthBlacklisted {
+		t.Fatalf("second quarantine = %v, want blacklisted", event)
+	}
+	if pool.Count() != 1 || pool.AddUnchecked(bad) {
+		t.Error("blacklisted proxy must leave the pool and not be re-added")
+	}
+	pool.Clear()
+	if pool.AddUnchecked(bad) {
+		t.Error("blacklist must survive Clear")
+	}
+}
+
+func TestHealthPrefersHighScore(t *testing.T) {
+	pool := NewLivePool()
+	slow := &ProxyConfig{Host: "10.0.0.1", Port: 1}
+	fast := &ProxyConfig{Host: "10.0.0.2", Port: 1}
+	pool.AddUnchecked(slow)
+	pool.AddUnchecked(fast)
+	pool.SetHealthPolicy(HealthPolicy{MinScore: 10, Quarantine: time.Minute, BlacklistAfter: 3})
+	for i := 0; i < healthMinSamples; i++ {
+		pool.RecordResult(slow, i%2 == 0, 8*time.Second)
+		pool.RecordResult(fast, true, 200*time.Millisecond)
+	}
+	for i := 0; i < 4; i++ {
+		if got := pool.GetNext(); got.Key() != fast.Key() {
+			t.Errorf("GetNext #%d = %s, want the higher-scoring proxy", i, got.Key())
+		}
+	}
+}
+
+func TestEvictWithoutPolicyRemoves(t *testing.T) {
+	pool := NewLivePool()
+	pc := &ProxyConfig{Host: "10.0.0.1", Port: 1}
+	pool.AddUnchecked(pc)
+	if event := pool.Evict(pc); event != HealthRemoved || pool.Count() != 0 {
+		t.Errorf("Evict = %v, count %d; want removed", event, pool.Count())
+	}
+	if !pool.AddUnchecked(pc) {
+		t.Error("removed (not blacklisted) proxy can be re-added")
+	}
+}
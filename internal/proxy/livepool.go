@@ -9,19 +9,27 @@ import (
 // LivePool sadece çalışan proxy'leri tutar; başarısız olanlar silinir
 // PERFORMANCE FIX: Map eklendi O(1) lookup için
 type LivePool struct {
-	mu      sync.RWMutex
-	list    []*LiveProxy
-	index   map[string]int // PERFORMANCE: key -> list index mapping for O(1) lookup
-	next    uint32         // round-robin
-	added   int64          // toplam eklenen (checker'dan veya unchecked)
-	removed int64          // başarısız diye silinen
+	mu        sync.RWMutex
+	list      []*LiveProxy
+	index     map[string]int          // PERFORMANCE: key -> list index mapping for O(1) lookup
+	next      uint32                  // round-robin
+	added     int64                   // toplam eklenen (checker'dan veya unchecked)
+	removed   int64                   // başarısız diye silinen
+	limits    ProxyLimits             // Proxy başına kullanım sınırları (usage.go)
+	usage     map[string]*proxyUsage  // key -> kullanım geçmişi
+	policy    HealthPolicy            // Karantina / kara liste kuralları (health.go)
+	health    map[string]*proxyHealth // key -> son sonuçlar ve karantina geçmişi
+	blacklist map[string]bool         // Kalıcı olarak dışlanan proxy'ler; Clear sonrası da eklenmez
 }
 
 // NewLivePool boş canlı havuz oluşturur
 func NewLivePool() *LivePool {
 	return &LivePool{
-		list:  make([]*LiveProxy, 0, 256),
-		index: make(map[string]int, 256), // PERFORMANCE: Pre-allocate map
+		list:      make([]*LiveProxy, 0, 256),
+		index:     make(map[string]int, 256), // PERFORMANCE: Pre-allocate map
+		usage:     make(map[string]*proxyUsage),
+		health:    make(map[string]*proxyHealth),
+		blacklist: make(map[string]bool),
 	}
 }
 
@@ -32,13 +40,16 @@ func (p *LivePool) Clear() {
 	p.list = p.list[:0]
 	// PERFORMANCE FIX: Map'i de temizle
 	p.index = make(map[string]int, 256)
+	p.usage = make(map[string]*proxyUsage)
+	p.health = make(map[string]*proxyHealth)
 	atomic.StoreUint32(&p.next, 0)
 }
 
-// AddUnchecked test edilmemiş proxy'yi havuza ekler; kullanımda başarısız olursa Remove ile silinir
-func (p *LivePool) AddUnchecked(cfg *ProxyConfig) {
+// AddUnchecked test edilmemiş proxy'yi havuza ekler; kullanımda başarısız olursa Remove ile silinir.
+// Proxy yeni eklendiyse true döner (zaten havuzdaysa false).
+func (p *LivePool) AddUnchecked(cfg *ProxyConfig) bool {
 	if cfg == nil {
-		return
+		return false
 	}
 	lp := &LiveProxy{
 		ProxyConfig: cfg,
@@ -46,25 +57,27 @@ func (p *LivePool) AddUnchecked(cfg *ProxyConfig) {
 		SpeedMs:     0,
 		CheckedAt:   time.Now(),
 	}
-	p.Add(lp)
+	return p.Add(lp)
 }
 
-// Add çalışan proxy'yi havuza ekler
+// Add çalışan proxy'yi havuza ekler; yeni eklendiyse true döner (kara listedeki proxy eklenmez).
+// Çalışma sırasında da güvenle çağrılabilir: simulator GetNext ile yeni proxy'leri hemen kullanır.
 // PERFORMANCE FIX: O(1) lookup için map kullan
-func (p *LivePool) Add(live *LiveProxy) {
+func (p *LivePool) Add(live *LiveProxy) bool {
 	if live == nil || live.ProxyConfig == nil {
-		return
+		return false
 	}
 	p.mu.Lock()
 	defer p.mu.Unlock()
 	key := live.Key()
 	// PERFORMANCE FIX: O(1) map lookup instead of O(n) slice iteration
-	if _, exists := p.index[key]; exists {
-		return
+	if _, exists := p.index[key]; exists || p.blacklist[key] {
+		return false
 	}
 	p.index[key] = len(p.list)
 	p.list = append(p.list, live)
 	atomic.AddInt64(&p.added, 1)
+	return true
 }
 
 // Remove proxy'yi havuzdan kaldırır (başarısız kullanım sonrası)
@@ -75,7 +88,11 @@ func (p *LivePool) Remove(proxy *ProxyConfig) {
 	}
 	p.mu.Lock()
 	defer p.mu.Unlock()
-	key := proxy.Key()
+	p.removeLocked(proxy.Key())
+}
+
+// removeLocked proxy'yi listeden çıkarır (p.mu tutulurken çağrılır)
+func (p *LivePool) removeLocked(key string) {
 	// PERFORMANCE FIX: O(1) map lookup
 	idx, exists := p.index[key]
 	if !exists {
@@ -94,7 +111,10 @@ func (p *LivePool) Remove(proxy *ProxyConfig) {
 	atomic.AddInt64(&p.removed, 1)
 }
 
-// GetNext round-robin sıradaki proxy'yi döner (hitter için)
+// GetNext round-robin sıradaki proxy'yi döner (hitter için).
+// Kullanım sınırları tanımlıysa saatlik sınırı dolmuş veya dinlenen proxy'ler, sağlık politikası
+// açıksa karantinadakiler atlanır; hiçbiri uygun değilse nil döner. Sağlık politikası açıkken
+// sıradaki birkaç uygun proxy'den sağlık skoru en yüksek olan seçilir.
 // SECURITY FIX: Race condition düzeltildi - Write lock kullanılıyor
 // çünkü atomic.AddUint32 ile list erişimi arasında tutarlılık gerekli
 func (p *LivePool) GetNext() *ProxyConfig {
@@ -111,9 +131,37 @@ func (p *LivePool) GetNext() *ProxyConfig {
 	if idx < 0 || idx >= len(p.list) {
 		idx = 0
 	}
-	return p.list[idx].ProxyConfig
+	if !p.limits.Enabled() && !p.policy.Enabled() {
+		return p.list[idx].ProxyConfig
+	}
+	now := time.Now()
+	var best *LiveProxy
+	bestScore, candidates := -1.0, 0
+	for i := 0; i < n && candidates < preferCandidates; i++ {
+		lp := p.list[(idx+i)%n]
+		key := lp.Key()
+		if !p.availableLocked(key, now) || p.quarantinedLocked(key, now) {
+			continue
+		}
+		if !p.policy.Enabled() {
+			p.next = uint32(idx + i + 1)
+			return lp.ProxyConfig
+		}
+		candidates++
+		if score := p.preferenceLocked(key); score > bestScore {
+			best, bestScore = lp, score
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	return best.ProxyConfig
 }
 
+// preferCandidates sağlık politikası açıkken skorları karşılaştırılan sıradaki uygun proxy sayısı;
+// rotasyon sürer ama düşük skorlu proxy daha seyrek seçilir
+const preferCandidates = 3
+
 // Snapshot canlı proxy listesinin kopyasını döner
 func (p *LivePool) Snapshot() []*LiveProxy {
 	p.mu.RLock()
@@ -155,18 +203,23 @@ type LiveProxyWithCountry struct {
 	Proxy   string `json:"proxy"`
 	Country string `json:"country"`
 	SpeedMs int64  `json:"speed_ms"`
+	ProxyUtilization
+	ProxyHealth
 }
 
 // SnapshotForAPI API için ülke/hız bilgili liste
 func (p *LivePool) SnapshotForAPI() []LiveProxyWithCountry {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
+	now := time.Now()
 	out := make([]LiveProxyWithCountry, len(p.list))
 	for i, lp := range p.list {
 		out[i] = LiveProxyWithCountry{
-			Proxy:   lp.Key(),
-			Country: lp.Country,
-			SpeedMs: lp.SpeedMs,
+			Proxy:            lp.Key(),
+			Country:          lp.Country,
+			SpeedMs:          lp.SpeedMs,
+			ProxyUtilization: p.utilizationLocked(lp.Key(), now),
+			ProxyHealth:      p.healthSummaryLocked(lp.Key(), now),
 		}
 	}
 	return out
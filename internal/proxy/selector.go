@@ -34,11 +34,11 @@ func (sm *SelectorManager) SetSelectorType(selectorType string) {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
 	
-	// Geo selector için özel kontrol
+	// Havuz kampanyalar arasında paylaşılır; seçim tek bir çalıştırmanın seed'ine bağlanmaz
 	if selectorType == "geo" {
-		sm.selector = pkgproxy.NewGeoSelector(sm.countries)
+		sm.selector = pkgproxy.NewGeoSelector(sm.countries, nil)
 	} else {
-		sm.selector = pkgproxy.NewSelectorFromString(selectorType)
+		sm.selector = pkgproxy.NewSelectorFromString(selectorType, nil)
 	}
 }
 
@@ -297,7 +297,7 @@ func BenchmarkWeightedSelectorWithMetrics(b *testing.B) {
 		}
 	}
 
-	selector := pkgproxy.NewWeightedSelector()
+	selector := pkgproxy.NewWeightedSelector(nil)
 	adapter := &livePoolAdapter{pool: pool}
 
 	b.ResetTimer()
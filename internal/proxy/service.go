@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -17,11 +18,18 @@ type Service struct {
 	checking   int32 // 1 = checker çalışıyor
 	done       int32 // checker'ın işlediği
 	cancel     context.CancelFunc
+	github     *GitHubCache // GitHub çekmeleri arasında ETag/SHA önbelleği
+	checks     *CheckCache  // Checker sonuçları (proxy+gün); yakın zamanda test edilenler atlanır
 }
 
 // NewService yeni proxy servisi oluşturur
 func NewService() *Service {
-	return &Service{LivePool: NewLivePool()}
+	return &Service{LivePool: NewLivePool(), github: NewGitHubCache(), checks: NewCheckCache(DefaultCheckCacheTTL)}
+}
+
+// SetCheckCacheTTL checker önbelleğinin geçerlilik süresini ayarlar (<= 0 kapatır)
+func (s *Service) SetCheckCacheTTL(ttl time.Duration) {
+	s.checks.SetTTL(ttl)
 }
 
 // Status proxy servis durumu (API için)
@@ -32,18 +40,20 @@ type Status struct {
 	CheckedDone  int32 `json:"checked_done"`
 	AddedTotal   int64 `json:"added_total"`
 	RemovedTotal int64 `json:"removed_total"`
+	CheckCacheStats
 }
 
 // Status anlık durum
 func (s *Service) Status() Status {
 	added, removed := s.LivePool.AddedRemoved()
 	return Status{
-		QueueCount:   int(atomic.LoadInt32(&s.queueCount)),
-		LiveCount:    s.LivePool.Count(),
-		Checking:     atomic.LoadInt32(&s.checking) == 1,
-		CheckedDone:  atomic.LoadInt32(&s.done),
-		AddedTotal:   added,
-		RemovedTotal: removed,
+		QueueCount:      int(atomic.LoadInt32(&s.queueCount)),
+		LiveCount:       s.LivePool.Count(),
+		Checking:        atomic.LoadInt32(&s.checking) == 1,
+		CheckedDone:     atomic.LoadInt32(&s.done),
+		AddedTotal:      added,
+		RemovedTotal:    removed,
+		CheckCacheStats: s.checks.Stats(),
 	}
 }
 
@@ -60,6 +70,7 @@ func (s *Service) FetchAndCheck(ctx context.Context, sources []string, checkerWo
 	atomic.StoreInt32(&s.checking, 1)
 	atomic.StoreInt32(&s.done, 0)
 	atomic.StoreInt32(&s.queueCount, 0)
+	s.checks.ResetStats()
 	s.mu.Unlock()
 
 	if onLog != nil {
@@ -80,6 +91,7 @@ func (s *Service) FetchAndCheck(ctx context.Context, sources []string, checkerWo
 	}
 
 	checker := NewChecker(checkerWorkers)
+	checker.Cache = s.checks
 	liveChan := make(chan *LiveProxy, 256)
 	go func() {
 		checker.RunSlice(ctx, queue, liveChan)
@@ -91,7 +103,9 @@ func (s *Service) FetchAndCheck(ctx context.Context, sources []string, checkerWo
 		s.LivePool.Add(lp)
 	}
 	if onLog != nil {
-		onLog("Proxy testi bitti. Canlı: " + strconv.Itoa(s.LivePool.Count()))
+		st := s.checks.Stats()
+		onLog("Proxy testi bitti. Canlı: " + strconv.Itoa(s.LivePool.Count()) +
+			" (önbellekten: " + strconv.FormatInt(st.Hits, 10) + ", test edilen: " + strconv.FormatInt(st.Misses, 10) + ")")
 	}
 }
 
@@ -123,7 +137,8 @@ func (s *Service) StopCheck() {
 // FetchFromGitHubNoCheck GitHub repo URL'lerinden tüm .txt dosyalarını indirir,
 // tek listede birleştirir ve canlılık testi yapmadan hepsini havuza ekler.
 // Başarısız proxy'ler kullanım sırasında (simulator tarafında) havuzdan silinir.
-func (s *Service) FetchFromGitHubNoCheck(ctx context.Context, repoURLs []string, onLog func(string)) (int, error) {
+// Önceki çekmeden beri değişmeyen dosyalar servis önbelleğinden alınır.
+func (s *Service) FetchFromGitHubNoCheck(ctx context.Context, repoURLs []string, opts GitHubFetchOptions, onLog func(string)) (int, error) {
 	if onLog != nil {
 		onLog("GitHub repo'larından dosyalar indiriliyor (test yok)...")
 	}
@@ -134,7 +149,16 @@ func (s *Service) FetchFromGitHubNoCheck(ctx context.Context, repoURLs []string,
 			IdleConnTimeout: 20 * time.Second,
 		},
 	}
-	list, err := FetchFromGitHubRepos(ctx, repoURLs, client)
+	if opts.Cache == nil {
+		opts.Cache = s.github
+	}
+	list, stats, err := FetchFromGitHubReposWith(ctx, repoURLs, client, opts)
+	if onLog != nil && len(stats.Rejected) > 0 {
+		onLog("İzin verilmeyen GitHub repoları atlandı: " + strings.Join(stats.Rejected, ", "))
+	}
+	if onLog != nil {
+		onLog("GitHub: " + strconv.Itoa(stats.Downloaded) + " dosya indirildi, " + strconv.Itoa(stats.Cached) + " değişmedi, " + strconv.Itoa(stats.Failed) + " başarısız.")
+	}
 	if err != nil {
 		if onLog != nil {
 			onLog("GitHub fetch hatası: " + err.Error())
@@ -156,4 +180,3 @@ func (s *Service) FetchFromGitHubNoCheck(ctx context.Context, repoURLs []string,
 	}
 	return len(list), nil
 }
-
@@ -0,0 +1,138 @@
+package proxy
+
+import (
+	"time"
+)
+
+// ProxyLimits proxy başına kullanım sınırları; sıfır değerler sınırsız demektir.
+// Küçük bir havuzun hedefe birkaç IP'den yüklenmesini önlemek için rotasyon bu sınırlara uyar.
+type ProxyLimits struct {
+	MaxHitsPerHour int           // Son 1 saatte proxy başına en fazla ziyaret
+	CooldownAfter  int           // Dinlenmeden bu kadar kullanımdan sonra proxy dinlenir
+	Cooldown       time.Duration // Dinlenme süresi; proxy bu kadar boşta kalırsa da dinlenmiş sayılır
+}
+
+// Enabled herhangi bir sınır tanımlı mı
+func (l ProxyLimits) Enabled() bool {
+	return l.MaxHitsPerHour > 0 || (l.CooldownAfter > 0 && l.Cooldown > 0)
+}
+
+// proxyUsage tek proxy'nin kullanım geçmişi
+type proxyUsage struct {
+	hits        []time.Time // Son 1 saatteki ziyaretler (eskiden yeniye)
+	consecutive int         // Son dinlenmeden beri kullanım (araya başka proxy girmesi sayacı sıfırlamaz)
+	last        time.Time   // Son kullanım
+	coolUntil   time.Time
+	total       int64
+}
+
+// prune 1 saatten eski kayıtları atar
+func (u *proxyUsage) prune(now time.Time) {
+	cut := now.Add(-time.Hour)
+	i := 0
+	for i < len(u.hits) && !u.hits[i].After(cut) {
+		i++
+	}
+	u.hits = u.hits[i:]
+}
+
+// SetLimits kullanım sınırlarını ayarlar (çalışma sırasında da değiştirilebilir)
+func (p *LivePool) SetLimits(l ProxyLimits) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.limits = l
+}
+
+// Limits geçerli kullanım sınırlarını döner
+func (p *LivePool) Limits() ProxyLimits {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.limits
+}
+
+// availableLocked proxy şu an kullanılabilir mi (p.mu tutulurken çağrılır)
+func (p *LivePool) availableLocked(key string, now time.Time) bool {
+	u := p.usage[key]
+	if u == nil {
+		return true
+	}
+	if now.Before(u.coolUntil) {
+		return false
+	}
+	if p.limits.MaxHitsPerHour > 0 {
+		u.prune(now)
+		if len(u.hits) >= p.limits.MaxHitsPerHour {
+			return false
+		}
+	}
+	return true
+}
+
+// Use proxy ile bir ziyaret yapılacağını kaydeder. Saatlik sınır dolmuşsa veya proxy
+// dinleniyorsa false döner; çağıran başka proxy'ye geçmelidir.
+func (p *LivePool) Use(proxy *ProxyConfig) bool {
+	if proxy == nil {
+		return false
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	key := proxy.Key()
+	now := time.Now()
+	if !p.availableLocked(key, now) {
+		return false
+	}
+	u := p.usage[key]
+	if u == nil {
+		u = &proxyUsage{}
+		p.usage[key] = u
+	}
+	u.total++
+	if p.limits.MaxHitsPerHour > 0 {
+		u.hits = append(u.hits, now)
+	}
+	// Sayaç proxy başınadır: rotasyonda araya giren proxy'ler dinlenme sayılmaz, ancak Cooldown kadar
+	// boşta kalan proxy dinlenmiş kabul edilir
+	if !u.last.IsZero() && now.Sub(u.last) >= p.limits.Cooldown {
+		u.consecutive = 0
+	}
+	u.last = now
+	u.consecutive++
+	if p.limits.CooldownAfter > 0 && p.limits.Cooldown > 0 && u.consecutive >= p.limits.CooldownAfter {
+		u.coolUntil = now.Add(p.limits.Cooldown)
+		u.consecutive = 0
+	}
+	return true
+}
+
+// ProxyUtilization proxy başına kullanım özeti (UI tablosu)
+type ProxyUtilization struct {
+	HitsLastHour int        `json:"hits_last_hour"`
+	MaxPerHour   int        `json:"max_per_hour,omitempty"`
+	Utilization  float64    `json:"utilization"` // Saatlik sınırın yüzdesi (sınır yoksa 0)
+	TotalHits    int64      `json:"total_hits"`
+	CoolingUntil *time.Time `json:"cooling_until,omitempty"`
+}
+
+// utilizationLocked proxy'nin kullanım özetini hesaplar (p.mu tutulurken çağrılır)
+func (p *LivePool) utilizationLocked(key string, now time.Time) ProxyUtilization {
+	out := ProxyUtilization{MaxPerHour: p.limits.MaxHitsPerHour}
+	u := p.usage[key]
+	if u == nil {
+		return out
+	}
+	cut := now.Add(-time.Hour)
+	for _, t := range u.hits {
+		if t.After(cut) {
+			out.HitsLastHour++
+		}
+	}
+	out.TotalHits = u.total
+	if out.MaxPerHour > 0 {
+		out.Utilization = float64(out.HitsLastHour) / float64(out.MaxPerHour) * 100
+	}
+	if now.Before(u.coolUntil) {
+		until := u.coolUntil
+		out.CoolingUntil = &until
+	}
+	return out
+}
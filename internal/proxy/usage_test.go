@@ -0,0 +1,101 @@
+package proxy
+
+import (
+	"testing"
+	"time"
+)
+
+func newTestPool(n int) *LivePool {
+	p := NewLivePool()
+	for i := 0; i < n; i++ {
+		p.AddUnchecked(&ProxyConfig{Host: "10.0.0.1", Port: 8000 + i, Protocol: "http"})
+	}
+	return p
+}
+
+func TestUseHourlyCap(t *testing.T) {
+	p := newTestPool(2)
+	p.SetLimits(ProxyLimits{MaxHitsPerHour: 3})
+	pc := p.GetNext()
+	for i := 0; i < 3; i++ {
+		if !p.Use(pc) {
+			t.Fatalf("use %d rejected before cap", i+1)
+		}
+	}
+	if p.Use(pc) {
+		t.Fatal("use allowed past hourly cap")
+	}
+	// Rotasyon sınırdaki proxy'yi atlamalı
+	for i := 0; i < 4; i++ {
+		if next := p.GetNext(); next == nil || next.Key() == pc.Key() {
+			t.Fatalf("GetNext returned capped proxy: %v", next)
+		}
+	}
+}
+
+func TestUseCooldownAfterConsecutive(t *testing.T) {
+	p := newTestPool(1)
+	p.SetLimits(ProxyLimits{CooldownAfter: 2, Cooldown: time.Minute})
+	pc := p.GetNext()
+	if !p.Use(pc) || !p.Use(pc) {
+		t.Fatal("first two uses should be allowed")
+	}
+	if p.Use(pc) {
+		t.Fatal("use allowed during cooldown")
+	}
+	if p.GetNext() != nil {
+		t.Fatal("GetNext should return nil when the only proxy is cooling down")
+	}
+	u := p.SnapshotForAPI()[0]
+	if u.TotalHits != 2 || u.CoolingUntil == nil {
+		t.Fatalf("utilization = %+v", u.ProxyUtilization)
+	}
+}
+
+func TestUseStreakSurvivesInterleavedProxies(t *testing.T) {
+	p := newTestPool(2)
+	p.SetLimits(ProxyLimits{CooldownAfter: 2, Cooldown: time.Minute})
+	a, b := p.GetNext(), p.GetNext()
+	// a, b, a: araya b girse de a iki kez kullanıldı ve dinlenmeye girer
+	if !p.Use(a) || !p.Use(b) || !p.Use(a) {
+		t.Fatal("interleaved uses rejected before the limit")
+	}
+	if p.Use(a) {
+		t.Fatal("a used past the limit because b was used in between")
+	}
+	if !p.Use(b) || p.Use(b) {
+		t.Fatal("b should cool down after its second use")
+	}
+}
+
+func TestUseStreakResetsAfterIdleCooldown(t *testing.T) {
+	p := newTestPool(1)
+	p.SetLimits(ProxyLimits{CooldownAfter: 2, Cooldown: time.Minute})
+	pc := p.GetNext()
+	if !p.Use(pc) {
+		t.Fatal("first use rejected")
+	}
+	// Cooldown kadar boşta kalan proxy dinlenmiş sayılır
+	p.usage[pc.Key()].last = time.Now().Add(-2 * time.Minute)
+	if !p.Use(pc) || !p.Use(pc) {
+		t.Fatal("streak not reset after idling for the cooldown")
+	}
+	if p.Use(pc) {
+		t.Fatal("use allowed during cooldown")
+	}
+}
+
+func TestNoLimitsRoundRobin(t *testing.T) {
+	p := newTestPool(3)
+	seen := map[string]bool{}
+	for i := 0; i < 3; i++ {
+		pc := p.GetNext()
+		if !p.Use(pc) {
+			t.Fatal("use rejected without limits")
+		}
+		seen[pc.Key()] = true
+	}
+	if len(seen) != 3 {
+		t.Fatalf("round robin visited %d proxies, want 3", len(seen))
+	}
+}
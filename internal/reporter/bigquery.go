@@ -0,0 +1,345 @@
+package reporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"vgbot/pkg/googleauth"
+)
+
+// bigQueryBaseURL insertAll API kökü (testlerde değiştirilir)
+var bigQueryBaseURL = "https://bigquery.googleapis.com/bigquery/v2"
+
+// bigQueryMaxAttempts ağ hatası, 429 ve 5xx yanıtlarında bir batch'in en fazla deneme sayısı
+const bigQueryMaxAttempts = 3
+
+// bigQueryRetryBackoff ilk tekrar denemeden önceki bekleme; her denemede iki katına çıkar (testlerde kısaltılır)
+var bigQueryRetryBackoff = time.Second
+
+// BigQuery satır tipleri
+const (
+	BQRowEvent   = "event"   // Gönderilen tek bir GA event'i (page_view, scroll)
+	BQRowSession = "session" // Ziyaret özeti (her hit için bir satır)
+)
+
+// BigQueryConfig exporter ayarları
+type BigQueryConfig struct {
+	ProjectID     string
+	Dataset       string
+	Table         string
+	Account       *googleauth.ServiceAccount
+	RunID         string        // Satırlar ve insertId'ler bu çalıştırma ID'si ile etiketlenir (boşsa rastgele üretilir)
+	BatchSize     int           // Bu kadar satır birikince gönderilir (varsayılan 500)
+	FlushInterval time.Duration // Batch dolmasa da en geç bu aralıkla gönderilir (varsayılan 10sn)
+}
+
+// BigQueryRow tabloya yazılan satır. Event ve session satırları aynı tabloda row_type ile ayrılır;
+// GA4 BigQuery export'u ile event_name/page_location/measurement_id üzerinden SQL ile karşılaştırılır.
+type BigQueryRow struct {
+	RowType        string `json:"row_type"`
+	RunID          string `json:"run_id"`
+	Seed           int64  `json:"seed,omitempty"`
+	Timestamp      string `json:"event_timestamp"` // RFC3339 (BigQuery TIMESTAMP)
+	EventName      string `json:"event_name,omitempty"`
+	Domain         string `json:"domain,omitempty"`
+	PageLocation   string `json:"page_location"`
+	MeasurementID  string `json:"measurement_id,omitempty"`
+	Variant        string `json:"experiment_variant,omitempty"`
+	Success        bool   `json:"success"`
+	StatusCode     int    `json:"status_code,omitempty"`
+	ResponseTimeMs int64  `json:"response_time_ms,omitempty"`
+	ErrorClass     string `json:"error_class,omitempty"`
+	UserAgent      string `json:"user_agent,omitempty"`
+	EventCount     int    `json:"event_count"` // Session satırında ziyaret boyunca gönderilen event sayısı
+}
+
+// HitRows bir hit kaydını satırlara çevirir: gönderilen her event için bir event satırı
+// ve ziyaretin kendisi için bir session satırı.
+func HitRows(h HitRecord, domain, runID string, seed int64) []BigQueryRow {
+	base := BigQueryRow{
+		RunID:          runID,
+		Seed:           seed,
+		Timestamp:      h.Timestamp.UTC().Format(time.RFC3339Nano),
+		Domain:         domain,
+		PageLocation:   h.URL,
+		MeasurementID:  h.MeasurementID,
+		Variant:        h.Variant,
+		Success:        h.Error == "",
+		StatusCode:     h.StatusCode,
+		ResponseTimeMs: h.ResponseTime,
+		ErrorClass:     h.ErrorClass,
+		UserAgent:      h.UserAgent,
+	}
+	rows := make([]BigQueryRow, 0, len(h.Events)+1)
+	for _, name := range h.Events {
+		row := base
+		row.RowType = BQRowEvent
+		row.EventName = name
+		row.EventCount = 1
+		rows = append(rows, row)
+	}
+	session := base
+	session.RowType = BQRowSession
+	session.EventCount = len(h.Events)
+	return append(rows, session)
+}
+
+// BigQueryExporter simüle edilen event'leri ve ziyaret özetlerini BigQuery tablosuna
+// insertAll (streaming) ile yazar. Gönderim arka planda batch'ler halinde yapılır;
+// Close kalan satırları gönderip bekler.
+type BigQueryExporter struct {
+	cfg    BigQueryConfig
+	client *http.Client
+
+	mu        sync.Mutex
+	pending   []BigQueryRow
+	seq       int64
+	lastFlush time.Time
+	flushing  bool
+	wg        sync.WaitGroup
+	written   int
+	failed    int
+	lastErr   error
+
+	token    string
+	tokenExp time.Time
+}
+
+// NewBigQueryExporter exporter oluşturur; proje boşsa Service Account'un projesi kullanılır
+func NewBigQueryExporter(cfg BigQueryConfig) (*BigQueryExporter, error) {
+	if cfg.Account == nil {
+		return nil, fmt.Errorf("bigquery: service account gerekli")
+	}
+	if cfg.ProjectID == "" {
+		cfg.ProjectID = cfg.Account.ProjectID
+	}
+	if cfg.ProjectID == "" || cfg.Dataset == "" || cfg.Table == "" {
+		return nil, fmt.Errorf("bigquery: proje, dataset ve tablo gerekli")
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 500
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = 10 * time.Second
+	}
+	if cfg.RunID == "" {
+		cfg.RunID = "vgbot-" + NewSessionID()
+	}
+	return &BigQueryExporter{
+		cfg:       cfg,
+		client:    &http.Client{Timeout: 30 * time.Second},
+		lastFlush: time.Now(),
+	}, nil
+}
+
+// Target "proje.dataset.tablo" biçiminde hedef tabloyu döner
+func (e *BigQueryExporter) Target() string {
+	return e.cfg.ProjectID + "." + e.cfg.Dataset + "." + e.cfg.Table
+}
+
+// RunID satırların etiketlendiği çalıştırma ID'si
+func (e *BigQueryExporter) RunID() string {
+	return e.cfg.RunID
+}
+
+// Add satırları kuyruğa ekler; batch dolduysa veya süre geçtiyse arka planda gönderir
+func (e *BigQueryExporter) Add(rows ...BigQueryRow) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.pending = append(e.pending, rows...)
+	if e.flushing {
+		return
+	}
+	if len(e.pending) >= e.cfg.BatchSize || time.Since(e.lastFlush) >= e.cfg.FlushInterval {
+		e.flushing = true
+		e.wg.Add(1)
+		go func() {
+			defer e.wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+			_ = e.Flush(ctx)
+			e.mu.Lock()
+			e.flushing = false
+			e.mu.Unlock()
+		}()
+	}
+}
+
+// Flush bekleyen tüm satırları BatchSize'lık parçalar halinde gönderir. Bir batch'in hatası
+// (ör. reddedilen satırlar) sonraki batch'leri durdurmaz; hatalar birleştirilip döner.
+// Yalnızca ctx iptal edilirse kalan satırlar kuyrukta bırakılır.
+func (e *BigQueryExporter) Flush(ctx context.Context) error {
+	var errs []error
+	for {
+		e.mu.Lock()
+		e.lastFlush = time.Now()
+		n := len(e.pending)
+		if n == 0 || ctx.Err() != nil {
+			e.mu.Unlock()
+			if n > 0 {
+				errs = append(errs, ctx.Err())
+			}
+			return errors.Join(errs...)
+		}
+		if n > e.cfg.BatchSize {
+			n = e.cfg.BatchSize
+		}
+		batch := e.pending[:n:n]
+		e.pending = e.pending[n:]
+		first := e.seq
+		e.seq += int64(n)
+		e.mu.Unlock()
+
+		rejected, err := e.insert(ctx, batch, first)
+		e.mu.Lock()
+		e.failed += rejected
+		e.written += len(batch) - rejected
+		if err != nil {
+			e.lastErr = err
+		}
+		e.mu.Unlock()
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+}
+
+// Close arka plandaki gönderimi bekler ve kalan satırları gönderir
+func (e *BigQueryExporter) Close(ctx context.Context) error {
+	e.wg.Wait()
+	if err := e.Flush(ctx); err != nil {
+		return err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.lastErr
+}
+
+// Stats yazılan ve başarısız satır sayılarını döner
+func (e *BigQueryExporter) Stats() (written, failed int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.written, e.failed
+}
+
+// accessToken önbellekteki token'ı döner, süresi dolmak üzereyse yeniler
+func (e *BigQueryExporter) accessToken(ctx context.Context) (string, error) {
+	e.mu.Lock()
+	token, exp := e.token, e.tokenExp
+	e.mu.Unlock()
+	if token != "" && time.Until(exp) > 5*time.Minute {
+		return token, nil
+	}
+	token, err := googleauth.AccessToken(ctx, e.cfg.Account.ClientEmail, e.cfg.Account.PrivateKey, googleauth.ScopeBigQueryInsert)
+	if err != nil {
+		return "", err
+	}
+	e.mu.Lock()
+	e.token, e.tokenExp = token, time.Now().Add(time.Hour)
+	e.mu.Unlock()
+	return token, nil
+}
+
+// insert bir batch'i insertAll ile gönderir ve yazılamayan satır sayısını döner. Ağ hatası, 429 ve
+// 5xx yanıtlarında aynı gövde bigQueryMaxAttempts kez denenir; satırlar aynı insertId'yi (run_id-sıra)
+// taşıdığı için BigQuery tekrar denemede gelen kopyaları en iyi çabayla ayıklar. Geçersiz satırlar
+// atlanır (skipInvalidRows), yalnızca onlar reddedilmiş sayılır; batch'in geri kalanı yazılır.
+func (e *BigQueryExporter) insert(ctx context.Context, rows []BigQueryRow, first int64) (int, error) {
+	type insertRow struct {
+		InsertID string      `json:"insertId"`
+		JSON     BigQueryRow `json:"json"`
+	}
+	body := struct {
+		Kind                string      `json:"kind"`
+		SkipInvalidRows     bool        `json:"skipInvalidRows"`
+		IgnoreUnknownValues bool        `json:"ignoreUnknownValues"`
+		Rows                []insertRow `json:"rows"`
+	}{Kind: "bigquery#tableDataInsertAllRequest", SkipInvalidRows: true, IgnoreUnknownValues: true}
+	for i, r := range rows {
+		body.Rows = append(body.Rows, insertRow{
+			InsertID: fmt.Sprintf("%s-%d", e.cfg.RunID, first+int64(i)),
+			JSON:     r,
+		})
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return len(rows), err
+	}
+
+	backoff := bigQueryRetryBackoff
+	for attempt := 1; ; attempt++ {
+		rejected, retry, err := e.insertOnce(ctx, data, len(rows))
+		if err == nil || !retry || attempt >= bigQueryMaxAttempts {
+			return rejected, err
+		}
+		select {
+		case <-ctx.Done():
+			return len(rows), err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// insertOnce tek bir insertAll isteği atar; retry hatanın geçici olup olmadığını bildirir
+func (e *BigQueryExporter) insertOnce(ctx context.Context, data []byte, n int) (rejected int, retry bool, err error) {
+	token, err := e.accessToken(ctx)
+	if err != nil {
+		return n, false, err
+	}
+	apiURL := fmt.Sprintf("%s/projects/%s/datasets/%s/tables/%s/insertAll", bigQueryBaseURL,
+		url.PathEscape(e.cfg.ProjectID), url.PathEscape(e.cfg.Dataset), url.PathEscape(e.cfg.Table))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiURL, bytes.NewReader(data))
+	if err != nil {
+		return n, false, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return n, ctx.Err() == nil, fmt.Errorf("bigquery: %w", err)
+	}
+	defer resp.Body.Close()
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if resp.StatusCode != http.StatusOK {
+		retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		return n, retry, fmt.Errorf("bigquery API hatası (%d): %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	// 200 dönse de satır bazlı hatalar olabilir
+	var result struct {
+		InsertErrors []struct {
+			Index  int `json:"index"`
+			Errors []struct {
+				Reason  string `json:"reason"`
+				Message string `json:"message"`
+			} `json:"errors"`
+		} `json:"insertErrors"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return n, false, fmt.Errorf("bigquery yanıt parse hatası: %w", err)
+	}
+	if len(result.InsertErrors) == 0 {
+		return 0, false, nil
+	}
+	seen := make(map[int]bool, len(result.InsertErrors))
+	for _, ie := range result.InsertErrors {
+		seen[ie.Index] = true
+	}
+	ie := result.InsertErrors[0]
+	msg := ""
+	if len(ie.Errors) > 0 {
+		msg = ie.Errors[0].Reason + ": " + ie.Errors[0].Message
+	}
+	return len(seen), false, fmt.Errorf("bigquery: %d satır reddedildi (ilk: #%d %s)", len(seen), ie.Index, msg)
+}
@@ -0,0 +1,207 @@
+package reporter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"vgbot/pkg/googleauth"
+)
+
+func TestHitRows(t *testing.T) {
+	h := HitRecord{
+		Timestamp:     time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		URL:           "https://example.com/a",
+		StatusCode:    200,
+		MeasurementID: "G-ABC123",
+		Events:        []string{"page_view", "scroll"},
+	}
+	rows := HitRows(h, "example.com", "run-1", 42)
+	if len(rows) != 3 {
+		t.Fatalf("rows = %d, want 3", len(rows))
+	}
+	if rows[0].RowType != BQRowEvent || rows[0].EventName != "page_view" || rows[1].EventName != "scroll" {
+		t.Fatalf("event rows = %+v", rows[:2])
+	}
+	s := rows[2]
+	if s.RowType != BQRowSession || s.EventCount != 2 || !s.Success || s.RunID != "run-1" || s.Seed != 42 {
+		t.Fatalf("session row = %+v", s)
+	}
+	if s.Timestamp != "2026-01-02T03:04:05Z" {
+		t.Fatalf("timestamp = %q", s.Timestamp)
+	}
+
+	failed := HitRows(HitRecord{URL: "https://example.com/b", Error: "timeout"}, "", "run-1", 0)
+	if len(failed) != 1 || failed[0].Success || failed[0].EventCount != 0 {
+		t.Fatalf("failed hit rows = %+v", failed)
+	}
+}
+
+func TestBigQueryExporterFlushBatches(t *testing.T) {
+	var mu sync.Mutex
+	var batches [][]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/projects/p/datasets/d/tables/t/insertAll") {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer tok" {
+			t.Errorf("auth = %q", got)
+		}
+		var body struct {
+			Rows []struct {
+				InsertID string `json:"insertId"`
+			} `json:"rows"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		var ids []string
+		for _, row := range body.Rows {
+			ids = append(ids, row.InsertID)
+		}
+		mu.Lock()
+		batches = append(batches, ids)
+		mu.Unlock()
+		w.Write([]byte(`{"kind":"bigquery#tableDataInsertAllResponse"}`))
+	}))
+	defer srv.Close()
+	old := bigQueryBaseURL
+	bigQueryBaseURL = srv.URL
+	defer func() { bigQueryBaseURL = old }()
+
+	e, err := NewBigQueryExporter(BigQueryConfig{
+		Dataset: "d", Table: "t", RunID: "r",
+		Account:       &googleauth.ServiceAccount{ProjectID: "p"},
+		BatchSize:     2,
+		FlushInterval: time.Hour,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.token, e.tokenExp = "tok", time.Now().Add(time.Hour)
+
+	e.Add(BigQueryRow{RowType: BQRowSession})
+	e.Add(BigQueryRow{RowType: BQRowSession}, BigQueryRow{RowType: BQRowSession})
+	if err := e.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if written, failed := e.Stats(); written != 3 || failed != 0 {
+		t.Fatalf("stats = %d/%d, want 3/0", written, failed)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	var all []string
+	for _, b := range batches {
+		if len(b) > 2 {
+			t.Fatalf("batch size %d > 2", len(b))
+		}
+		all = append(all, b...)
+	}
+	if strings.Join(all, ",") != "r-0,r-1,r-2" {
+		t.Fatalf("insert ids = %v", all)
+	}
+}
+
+func TestBigQueryExporterInsertErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			SkipInvalidRows bool `json:"skipInvalidRows"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if !body.SkipInvalidRows {
+			t.Error("skipInvalidRows not set; valid rows would be dropped with the invalid one")
+		}
+		if calls.Add(1) > 1 {
+			w.Write([]byte(`{"kind":"bigquery#tableDataInsertAllResponse"}`))
+			return
+		}
+		w.Write([]byte(`{"insertErrors":[{"index":1,"errors":[{"reason":"invalid","message":"no such field"},{"reason":"invalid","message":"bad value"}]}]}`))
+	}))
+	defer srv.Close()
+	old := bigQueryBaseURL
+	bigQueryBaseURL = srv.URL
+	defer func() { bigQueryBaseURL = old }()
+
+	e, _ := NewBigQueryExporter(BigQueryConfig{
+		Dataset: "d", Table: "t",
+		Account:       &googleauth.ServiceAccount{ProjectID: "p"},
+		BatchSize:     2,
+		FlushInterval: time.Hour,
+	})
+	e.token, e.tokenExp = "tok", time.Now().Add(time.Hour)
+	// Arka plan gönderimi olmadan kuyrukta iki batch: hepsini Close gönderir
+	e.pending = []BigQueryRow{{RowType: BQRowEvent}, {RowType: BQRowEvent}, {RowType: BQRowSession}}
+	if err := e.Close(context.Background()); err == nil || !strings.Contains(err.Error(), "no such field") {
+		t.Fatalf("err = %v, want insert error", err)
+	}
+	// Yalnızca reddedilen satır başarısız sayılır; ilk batch'in reddi sonraki batch'i durdurmaz
+	if written, failed := e.Stats(); written != 2 || failed != 1 {
+		t.Fatalf("stats = %d/%d, want 2/1", written, failed)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Fatalf("insertAll calls = %d, want 2", n)
+	}
+}
+
+func TestBigQueryExporterRetries(t *testing.T) {
+	var mu sync.Mutex
+	var calls int
+	status := []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		code := status[calls%len(status)]
+		calls++
+		mu.Unlock()
+		w.WriteHeader(code)
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+	oldURL, oldBackoff := bigQueryBaseURL, bigQueryRetryBackoff
+	bigQueryBaseURL, bigQueryRetryBackoff = srv.URL, time.Millisecond
+	defer func() { bigQueryBaseURL, bigQueryRetryBackoff = oldURL, oldBackoff }()
+
+	newExporter := func() *BigQueryExporter {
+		e, _ := NewBigQueryExporter(BigQueryConfig{
+			Dataset: "d", Table: "t",
+			Account: &googleauth.ServiceAccount{ProjectID: "p"},
+		})
+		e.token, e.tokenExp = "tok", time.Now().Add(time.Hour)
+		return e
+	}
+
+	// Geçici hatalar (503, 429) tekrar denenir, üçüncü deneme yazar
+	e := newExporter()
+	e.Add(BigQueryRow{RowType: BQRowSession})
+	if err := e.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if written, failed := e.Stats(); written != 1 || failed != 0 || calls != 3 {
+		t.Fatalf("stats = %d/%d after %d calls, want 1/0 after 3", written, failed, calls)
+	}
+
+	// Deneme sayısı sınırlıdır
+	status = []int{http.StatusInternalServerError}
+	calls = 0
+	e = newExporter()
+	e.Add(BigQueryRow{RowType: BQRowSession})
+	if err := e.Close(context.Background()); err == nil {
+		t.Fatal("want error after exhausting retries")
+	}
+	if _, failed := e.Stats(); failed != 1 || calls != bigQueryMaxAttempts {
+		t.Fatalf("failed = %d after %d calls, want 1 after %d", failed, calls, bigQueryMaxAttempts)
+	}
+
+	// İstemci hataları tekrar denenmez
+	status = []int{http.StatusBadRequest}
+	calls = 0
+	e = newExporter()
+	e.Add(BigQueryRow{RowType: BQRowSession})
+	if err := e.Close(context.Background()); err == nil || calls != 1 {
+		t.Fatalf("400: err = %v after %d calls, want error after 1", err, calls)
+	}
+}
@@ -0,0 +1,113 @@
+package reporter
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// maxLogoSize rapora gömülen yerel logo dosyasının üst sınırı
+const maxLogoSize = 1 << 20
+
+// Varsayılan rapor görünümü (markalama verilmezse)
+const (
+	defaultReportTitle     = "Eros Hit Bot Report"
+	defaultReportFooter    = "Eros Hit Bot | Report generated automatically"
+	defaultPrimaryColor    = "#38bdf8"
+	defaultBackgroundColor = "#0f172a"
+)
+
+var hexColor = regexp.MustCompile(`^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// logoTypes gömülebilen logo uzantıları ve MIME tipleri
+var logoTypes = map[string]string{
+	".png":  "image/png",
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".gif":  "image/gif",
+	".svg":  "image/svg+xml",
+	".webp": "image/webp",
+}
+
+// Branding HTML raporun markalaması (ajansların müşteriye giden raporları): başlık, logo, renkler, alt bilgi.
+// Boş alanlar varsayılan görünümü kullanır.
+type Branding struct {
+	Title           string // Rapor başlığı
+	Logo            string // http(s) URL veya yerel görsel dosyası (rapora data URI olarak gömülür)
+	PrimaryColor    string // #rrggbb; değerler ve grafikler
+	BackgroundColor string // #rrggbb; sayfa arka planı
+	Footer          string // Alt bilgi metni
+}
+
+// Validate renklerin hex, logonun http(s) URL veya desteklenen bir görsel dosyası olduğunu kontrol eder
+func (b Branding) Validate() error {
+	for name, c := range map[string]string{"report_primary_color": b.PrimaryColor, "report_background_color": b.BackgroundColor} {
+		if c != "" && !hexColor.MatchString(c) {
+			return fmt.Errorf("%s #rgb veya #rrggbb olmalı: %q", name, c)
+		}
+	}
+	if b.Logo == "" || isLogoURL(b.Logo) {
+		return nil
+	}
+	if _, ok := logoTypes[strings.ToLower(filepath.Ext(b.Logo))]; !ok {
+		return fmt.Errorf("report_logo: desteklenmeyen görsel türü %q (png, jpg, gif, svg, webp)", b.Logo)
+	}
+	info, err := os.Stat(b.Logo)
+	if err != nil {
+		return fmt.Errorf("report_logo: %w", err)
+	}
+	if info.Size() > maxLogoSize {
+		return fmt.Errorf("report_logo en fazla %d KB olabilir", maxLogoSize>>10)
+	}
+	return nil
+}
+
+// isLogoURL logonun uzak bir http(s) adresi olup olmadığını döner
+func isLogoURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// logoSrc <img src> değerini döner; yerel dosya okunup base64 data URI'ye çevrilir (rapor tek dosya kalır)
+func (b Branding) logoSrc() (string, error) {
+	if b.Logo == "" || isLogoURL(b.Logo) {
+		return b.Logo, nil
+	}
+	if err := b.Validate(); err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(b.Logo)
+	if err != nil {
+		return "", err
+	}
+	mime := logoTypes[strings.ToLower(filepath.Ext(b.Logo))]
+	return "data:" + mime + ";base64," + base64.StdEncoding.EncodeToString(data), nil
+}
+
+// withDefaults boş alanları (ve CSS'e yazılamayacak geçersiz renkleri) varsayılan görünümle doldurur
+func (b Branding) withDefaults() Branding {
+	if b.Title == "" {
+		b.Title = defaultReportTitle
+	}
+	if b.Footer == "" {
+		b.Footer = defaultReportFooter
+	}
+	if !hexColor.MatchString(b.PrimaryColor) {
+		b.PrimaryColor = defaultPrimaryColor
+	}
+	if !hexColor.MatchString(b.BackgroundColor) {
+		b.BackgroundColor = defaultBackgroundColor
+	}
+	return b
+}
+
+// SetBranding HTML rapor markalamasını ayarlar
+func (r *Reporter) SetBranding(b Branding) {
+	r.mu.Lock()
+	r.branding = b
+	r.mu.Unlock()
+}
@@ -0,0 +1,83 @@
+package reporter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBrandingValidate(t *testing.T) {
+	dir := t.TempDir()
+	logo := filepath.Join(dir, "logo.png")
+	if err := os.WriteFile(logo, []byte("\x89PNG"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ok := []Branding{
+		{},
+		{PrimaryColor: "#fff", BackgroundColor: "#0A0B0C"},
+		{Logo: "https://cdn.example.com/logo.svg"},
+		{Logo: logo},
+	}
+	for _, b := range ok {
+		if err := b.Validate(); err != nil {
+			t.Errorf("Validate(%+v) = %v", b, err)
+		}
+	}
+	bad := []Branding{
+		{PrimaryColor: "red"},
+		{BackgroundColor: "#12345"},
+		{Logo: filepath.Join(dir, "missing.png")},
+		{Logo: filepath.Join(dir, "logo.bmp")},
+		{Logo: "javascript:alert(1)"},
+	}
+	for _, b := range bad {
+		if err := b.Validate(); err == nil {
+			t.Errorf("Validate(%+v) = nil, want error", b)
+		}
+	}
+}
+
+func TestHTMLReportBranding(t *testing.T) {
+	dir := t.TempDir()
+	logo := filepath.Join(dir, "logo.png")
+	if err := os.WriteFile(logo, []byte("png"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	r := New(dir, "html", "example.com")
+	r.Record(HitRecord{URL: "https://example.com/", StatusCode: 200})
+	r.SetBranding(Branding{
+		Title:        "Acme Agency",
+		Logo:         logo,
+		PrimaryColor: "#ff6600",
+		Footer:       "Acme <b>Digital</b>",
+	})
+	r.Finalize()
+	if err := r.Export(); err != nil {
+		t.Fatal(err)
+	}
+	files, _ := filepath.Glob(filepath.Join(dir, "vgbot_report_*.html"))
+	if len(files) != 1 {
+		t.Fatalf("html reports = %v", files)
+	}
+	data, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	html := string(data)
+	for _, want := range []string{
+		"<title>Acme Agency</title>",
+		"<h1>Acme Agency</h1>",
+		`src="data:image/png;base64,cG5n"`,
+		"color: #ff6600;",
+		"background: " + defaultBackgroundColor + ";",
+		"Acme &lt;b&gt;Digital&lt;/b&gt;",
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("report missing %q", want)
+		}
+	}
+	if strings.Contains(html, defaultReportTitle) {
+		t.Error("default title still rendered")
+	}
+}
@@ -0,0 +1,36 @@
+package reporter
+
+import (
+	"errors"
+
+	"vgbot/pkg/contentcheck"
+)
+
+// ContentCheckStats örneklenen ziyaretlerdeki yanıt içerik kontrolleri. Başarısız kontroller hit olarak
+// "functional" hata sınıfıyla da sayılır; burada ağ hatalarından ayrı, kural bazında toplanır.
+type ContentCheckStats struct {
+	Checked  int            `json:"checked"`
+	Failed   int            `json:"failed"`
+	Failures map[string]int `json:"failures,omitempty"` // Kural -> başarısızlık sayısı
+}
+
+// RecordContentCheck bir içerik kontrolünün sonucunu metriklere ekler (err nil = geçti)
+func (r *Reporter) RecordContentCheck(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.metrics.ContentChecks == nil {
+		r.metrics.ContentChecks = &ContentCheckStats{Failures: make(map[string]int)}
+	}
+	s := r.metrics.ContentChecks
+	s.Checked++
+	if err == nil {
+		return
+	}
+	s.Failed++
+	rule := err.Error()
+	var f *contentcheck.Failure
+	if errors.As(err, &f) {
+		rule = f.Rule
+	}
+	s.Failures[rule]++
+}
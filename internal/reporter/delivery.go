@@ -0,0 +1,27 @@
+package reporter
+
+import "vgbot/pkg/errclass"
+
+// DeliveryStats başarılı sayfa görüntülemelerinde GA4 teslimatının özeti. Kaynak, tarayıcıdan giden
+// /g/collect istekleridir; Measurement Protocol yedeğiyle gönderilenler ayrıca sayılır.
+type DeliveryStats struct {
+	PageViews        int   `json:"page_views"`        // Analytics beklenen başarılı hit'ler
+	Delivered        int   `json:"delivered"`         // En az bir collect isteği teslim edilen (veya MP ile gönderilen)
+	Missing          int   `json:"missing"`           // Hiçbir istek teslim edilmeyen
+	Beacons          int   `json:"beacons"`           // Yakalanan collect istekleri
+	BeaconsDelivered int   `json:"beacons_delivered"` // Başarılı yanıt alan collect istekleri
+	BeaconBytes      int64 `json:"beacon_bytes"`      // Collect payload toplamı
+}
+
+// add hit'in teslimat sonucunu sayar
+func (s *DeliveryStats) add(h HitRecord) {
+	s.PageViews++
+	if h.ErrorClass == errclass.AnalyticsMissing {
+		s.Missing++
+	} else {
+		s.Delivered++
+	}
+	s.Beacons += h.Beacons
+	s.BeaconsDelivered += h.BeaconsDelivered
+	s.BeaconBytes += h.BeaconBytes
+}
@@ -0,0 +1,21 @@
+package reporter
+
+import (
+	"testing"
+
+	"vgbot/pkg/errclass"
+)
+
+func TestAnalyticsDelivery(t *testing.T) {
+	r := New(t.TempDir(), "json", "site.example")
+	r.Record(HitRecord{URL: "https://site.example/", StatusCode: 200, MeasurementID: "G-ABC1234567", Beacons: 2, BeaconsDelivered: 2, BeaconBytes: 900})
+	r.Record(HitRecord{URL: "https://site.example/a", StatusCode: 200, MeasurementID: "G-ABC1234567", ErrorClass: errclass.AnalyticsMissing, Beacons: 1, BeaconBytes: 300})
+	r.Record(HitRecord{URL: "https://site.example/b", StatusCode: 200})
+	r.Record(HitRecord{URL: "https://site.example/c", Error: "timeout", MeasurementID: "G-ABC1234567"})
+
+	got := r.GetMetrics().AnalyticsDelivery
+	want := DeliveryStats{PageViews: 2, Delivered: 1, Missing: 1, Beacons: 3, BeaconsDelivered: 2, BeaconBytes: 1200}
+	if got == nil || *got != want {
+		t.Errorf("AnalyticsDelivery = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,115 @@
+package reporter
+
+import "time"
+
+// eventBuffer olay kanalının kapasitesi; tüketici yetişemezse yeni olaylar atlanır
+const eventBuffer = 1000
+
+// Olay türleri (Event.EventType)
+const (
+	EventHit     = "hit"
+	EventSession = "session"
+	EventError   = "error"
+)
+
+// Hata olaylarının kaynakları (ErrorEvent.Source)
+const (
+	ErrorSourceHit    = "hit"    // Başarısız ziyaret
+	ErrorSourceExport = "export" // Rapor, hit logu veya BigQuery yazılamadı
+)
+
+// Event log kanalına paralel, makine tarafından okunacak olay. Somut türler HitEvent, SessionEvent
+// ve ErrorEvent'tir; tüketiciler type switch ile ayırır, insan okunur log metnini ayrıştırmaz.
+type Event interface {
+	EventType() string
+}
+
+// HitEvent kaydedilen her hit (404 denemeleri hariç)
+type HitEvent struct {
+	Time         time.Time `json:"time"`
+	URL          string    `json:"url"`
+	Proxy        string    `json:"proxy,omitempty"`
+	StatusCode   int       `json:"status_code"`
+	ResponseTime int64     `json:"response_time_ms"`
+	Success      bool      `json:"success"`
+	ErrorClass   string    `json:"error_class,omitempty"`
+	SessionID    string    `json:"session_id,omitempty"`
+	Warmup       bool      `json:"warmup,omitempty"` // Isınma penceresinde; istatistiklere katılmaz
+}
+
+// SessionEvent tamamlanan ziyaretin (zaman çizelgesi) özeti
+type SessionEvent struct {
+	Time       time.Time `json:"time"` // Ziyaret başlangıcı
+	SessionID  string    `json:"session_id,omitempty"`
+	URL        string    `json:"url"`
+	Proxy      string    `json:"proxy,omitempty"`
+	Success    bool      `json:"success"`
+	Steps      int       `json:"steps"`
+	DurationMs int64     `json:"duration_ms"` // Son adıma kadar geçen süre
+}
+
+// ErrorEvent başarısız hit veya reporter'ın kendi hatası
+type ErrorEvent struct {
+	Time    time.Time `json:"time"`
+	Source  string    `json:"source"`          // hit, export
+	Class   string    `json:"class,omitempty"` // errclass etiketi (timeout, proxy, dns...)
+	Message string    `json:"message"`
+	URL     string    `json:"url,omitempty"`
+	Proxy   string    `json:"proxy,omitempty"`
+}
+
+func (HitEvent) EventType() string     { return EventHit }
+func (SessionEvent) EventType() string { return EventSession }
+func (ErrorEvent) EventType() string   { return EventError }
+
+// Events olay kanalı; reporter kapanınca (Close) kapanır
+func (r *Reporter) Events() <-chan Event {
+	return r.events
+}
+
+// emit olayı kanala bırakır; kanal doluysa veya reporter kapandıysa atlanır (hit yolunu bloklamaz)
+func (r *Reporter) emit(e Event) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.closed {
+		return
+	}
+	select {
+	case r.events <- e:
+	default:
+	}
+}
+
+// emitError reporter'ın kendi hatasını (export, hit logu) olay olarak bildirir
+func (r *Reporter) emitError(source, class string, err error) {
+	r.emit(ErrorEvent{Time: time.Now(), Source: source, Class: class, Message: err.Error()})
+}
+
+func hitEvent(h HitRecord, at time.Time) HitEvent {
+	return HitEvent{
+		Time:         at,
+		URL:          h.URL,
+		Proxy:        h.Proxy,
+		StatusCode:   h.StatusCode,
+		ResponseTime: h.ResponseTime,
+		Success:      h.Error == "",
+		ErrorClass:   h.ErrorClass,
+		SessionID:    h.SessionID,
+		Warmup:       h.Warmup,
+	}
+}
+
+func sessionEvent(t SessionTimeline) SessionEvent {
+	e := SessionEvent{
+		Time:      t.Start,
+		SessionID: t.SessionID,
+		URL:       t.URL,
+		Proxy:     t.Proxy,
+		Success:   t.Success,
+		Steps:     len(t.Steps),
+	}
+	if n := len(t.Steps); n > 0 {
+		e.DurationMs = t.Steps[n-1].AtMs
+	}
+	return e
+}
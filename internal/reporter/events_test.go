@@ -0,0 +1,38 @@
+package reporter
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEvents(t *testing.T) {
+	r := New(t.TempDir(), "json", "example.com")
+	r.Record(HitRecord{URL: "https://example.com/", StatusCode: 200, ResponseTime: 120, SessionID: "s1"})
+	r.Record(HitRecord{URL: "https://example.com/x", Error: "i/o timeout", ErrorClass: "timeout", Proxy: "10.0.0.1:8080"})
+	r.RecordTimeline(SessionTimeline{SessionID: "s1", URL: "https://example.com/", Start: time.Now(), Success: true,
+		Steps: []TimelineStep{{AtMs: 0, Step: "navigate"}, {AtMs: 900, Step: "exit"}}})
+	r.Close()
+
+	var got []Event
+	for e := range r.Events() {
+		got = append(got, e)
+	}
+	if len(got) != 4 {
+		t.Fatalf("events = %+v", got)
+	}
+	if h, ok := got[0].(HitEvent); !ok || !h.Success || h.ResponseTime != 120 || h.SessionID != "s1" {
+		t.Errorf("hit = %+v", got[0])
+	}
+	if h, ok := got[1].(HitEvent); !ok || h.Success || h.ErrorClass != "timeout" {
+		t.Errorf("failed hit = %+v", got[1])
+	}
+	if e, ok := got[2].(ErrorEvent); !ok || e.Source != ErrorSourceHit || e.Class != "timeout" || e.Proxy != "10.0.0.1:8080" {
+		t.Errorf("error = %+v", got[2])
+	}
+	if s, ok := got[3].(SessionEvent); !ok || s.EventType() != EventSession || s.Steps != 2 || s.DurationMs != 900 {
+		t.Errorf("session = %+v", got[3])
+	}
+
+	// Kapandıktan sonraki olaylar atlanır (panik yok)
+	r.Record(HitRecord{URL: "https://example.com/"})
+}
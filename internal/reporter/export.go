@@ -0,0 +1,146 @@
+package reporter
+
+import (
+	"archive/zip"
+	"crypto/rand"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// hitColumns CSV/XLSX hit export'unun sütunları (sırası sabittir)
+var hitColumns = []string{"timestamp", "url", "proxy", "status_code", "response_time_ms", "session_id", "error"}
+
+// NewSessionID ziyarete hit kaydı ve zaman çizelgesinde ortak kullanılan rastgele bir oturum ID'si verir
+func NewSessionID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// hitRow kaydı hitColumns sırasıyla hücrelere çevirir
+func hitRow(h HitRecord) []string {
+	return []string{
+		h.Timestamp.Format(time.RFC3339),
+		h.URL,
+		h.Proxy,
+		strconv.Itoa(h.StatusCode),
+		strconv.FormatInt(h.ResponseTime, 10),
+		h.SessionID,
+		h.Error,
+	}
+}
+
+// Records bellekteki hit kayıtlarının kopyasını döner (maxRecords aşıldıysa yalnızca son kayıtlar)
+func (r *Reporter) Records() []HitRecord {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	recs := make([]HitRecord, len(r.records))
+	copy(recs, r.records)
+	return recs
+}
+
+// WriteHitsCSV tüm hit'leri CSV olarak w'ye yazar
+func (r *Reporter) WriteHitsCSV(w io.Writer) error {
+	return writeHitsCSV(w, r.Records())
+}
+
+// WriteHitsXLSX tüm hit'leri tek sayfalık bir Excel (XLSX) dosyası olarak w'ye yazar
+func (r *Reporter) WriteHitsXLSX(w io.Writer) error {
+	return writeHitsXLSX(w, r.Records())
+}
+
+func writeHitsCSV(w io.Writer, recs []HitRecord) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(hitColumns); err != nil {
+		return err
+	}
+	for _, h := range recs {
+		if err := cw.Write(hitRow(h)); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// xlsxParts sayfa dışındaki sabit OOXML parçaları
+var xlsxParts = []struct{ name, body string }{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/></Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+	{"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Hits" sheetId="1" r:id="rId1"/></sheets></workbook>`},
+	{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`},
+}
+
+// writeHitsXLSX harici bağımlılık olmadan minimal bir XLSX yazar: metinler inline string,
+// status ve yanıt süresi sayı hücresi olur. Satırlar zip'e akış halinde yazılır.
+func writeHitsXLSX(w io.Writer, recs []HitRecord) error {
+	zw := zip.NewWriter(w)
+	for _, p := range xlsxParts {
+		f, err := zw.Create(p.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, p.body); err != nil {
+			return err
+		}
+	}
+
+	f, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	var sb strings.Builder
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+	sb.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	writeXLSXRow(&sb, 1, hitColumns, nil)
+	if _, err := io.WriteString(f, sb.String()); err != nil {
+		return err
+	}
+	numeric := map[int]bool{3: true, 4: true}
+	for i, h := range recs {
+		sb.Reset()
+		writeXLSXRow(&sb, i+2, hitRow(h), numeric)
+		if _, err := io.WriteString(f, sb.String()); err != nil {
+			return err
+		}
+	}
+	if _, err := io.WriteString(f, `</sheetData></worksheet>`); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// writeXLSXRow tek satır yazar; numeric'teki sütunlar sayı, diğerleri inline string hücresidir
+func writeXLSXRow(sb *strings.Builder, row int, cells []string, numeric map[int]bool) {
+	fmt.Fprintf(sb, `<row r="%d">`, row)
+	for col, v := range cells {
+		ref := xlsxColumn(col) + strconv.Itoa(row)
+		if numeric[col] {
+			fmt.Fprintf(sb, `<c r="%s"><v>%s</v></c>`, ref, v)
+			continue
+		}
+		fmt.Fprintf(sb, `<c r="%s" t="inlineStr"><is><t>`, ref)
+		_ = xml.EscapeText(sb, []byte(v))
+		sb.WriteString(`</t></is></c>`)
+	}
+	sb.WriteString(`</row>`)
+}
+
+// xlsxColumn 0 tabanlı sütun indeksini Excel harfine çevirir (0 -> A, 26 -> AA)
+func xlsxColumn(i int) string {
+	s := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		s = string(rune('A'+(i-1)%26)) + s
+	}
+	return s
+}
@@ -0,0 +1,86 @@
+package reporter
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func exportFixture(t *testing.T) *Reporter {
+	r := New(t.TempDir(), "json", "example.com")
+	ts := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	r.Record(HitRecord{Timestamp: ts, URL: "https://example.com/?a=1&b=<2>", Proxy: "10.0.0.1:8080", StatusCode: 200, ResponseTime: 350, SessionID: "abc"})
+	r.Record(HitRecord{Timestamp: ts, URL: "https://example.com/x", Error: "timeout", SessionID: "def"})
+	return r
+}
+
+func TestWriteHitsCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := exportFixture(t).WriteHitsCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 || strings.Join(rows[0], ",") != strings.Join(hitColumns, ",") {
+		t.Fatalf("rows = %v", rows)
+	}
+	want := []string{"2026-03-01T12:00:00Z", "https://example.com/?a=1&b=<2>", "10.0.0.1:8080", "200", "350", "abc", ""}
+	if strings.Join(rows[1], "|") != strings.Join(want, "|") {
+		t.Errorf("row 1 = %v, want %v", rows[1], want)
+	}
+	if rows[2][6] != "timeout" || rows[2][5] != "def" {
+		t.Errorf("row 2 = %v", rows[2])
+	}
+}
+
+func TestWriteHitsXLSX(t *testing.T) {
+	var buf bytes.Buffer
+	if err := exportFixture(t).WriteHitsXLSX(&buf); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sheet string
+	names := map[string]bool{}
+	for _, f := range zr.File {
+		names[f.Name] = true
+		if f.Name == "xl/worksheets/sheet1.xml" {
+			rc, _ := f.Open()
+			b, _ := io.ReadAll(rc)
+			rc.Close()
+			sheet = string(b)
+		}
+	}
+	for _, n := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels"} {
+		if !names[n] {
+			t.Errorf("missing part %s", n)
+		}
+	}
+	for _, want := range []string{
+		`<row r="3">`,
+		`<c r="A1" t="inlineStr"><is><t>timestamp</t></is></c>`,
+		`<c r="D2"><v>200</v></c>`,
+		`b=&lt;2&gt;`,
+		`<t>def</t>`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("sheet missing %q", want)
+		}
+	}
+}
+
+func TestXLSXColumn(t *testing.T) {
+	for i, want := range map[int]string{0: "A", 6: "G", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"} {
+		if got := xlsxColumn(i); got != want {
+			t.Errorf("xlsxColumn(%d) = %q, want %q", i, got, want)
+		}
+	}
+}
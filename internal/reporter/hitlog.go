@@ -0,0 +1,102 @@
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// HitLine hits.jsonl dosyasındaki tek satır; alan adları analiz araçlarının (pandas, BigQuery) şemasıdır
+type HitLine struct {
+	Timestamp    time.Time `json:"timestamp"`
+	URL          string    `json:"url"`
+	Proxy        string    `json:"proxy,omitempty"`
+	Device       string    `json:"device,omitempty"`
+	Keyword      string    `json:"keyword,omitempty"`
+	StatusCode   int       `json:"status_code"`
+	ResponseTime int64     `json:"response_time_ms"`
+	SessionID    string    `json:"session_id,omitempty"`
+	Protocol     string    `json:"protocol,omitempty"`
+	Warmup       bool      `json:"warmup,omitempty"`
+	Error        string    `json:"error,omitempty"`
+}
+
+// jsonlFile değerleri JSON Lines olarak dosyaya yazar (her değer bir satır, yazıldığı anda diskte)
+type jsonlFile struct {
+	mu  sync.Mutex
+	f   *os.File
+	enc *json.Encoder
+	err error // İlk yazma hatası; sonraki satırlar atlanır
+}
+
+// createJSONL dir içinde dosyayı oluşturur (dizin yoksa açılır)
+func createJSONL(dir, name string) (*jsonlFile, string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, "", err
+	}
+	path := filepath.Join(dir, name)
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, "", err
+	}
+	return &jsonlFile{f: f, enc: json.NewEncoder(f)}, path, nil
+}
+
+func (l *jsonlFile) write(v interface{}) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.err != nil {
+		return
+	}
+	l.err = l.enc.Encode(v)
+}
+
+func (l *jsonlFile) close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := l.f.Close(); l.err == nil {
+		l.err = err
+	}
+	return l.err
+}
+
+// hitLine hit kaydını hits.jsonl satırına çevirir
+func hitLine(h HitRecord) HitLine {
+	return HitLine{
+		Timestamp:    h.Timestamp,
+		URL:          h.URL,
+		Proxy:        h.Proxy,
+		Device:       h.Device,
+		Keyword:      h.Keyword,
+		StatusCode:   h.StatusCode,
+		ResponseTime: h.ResponseTime,
+		SessionID:    h.SessionID,
+		Protocol:     h.Protocol,
+		Warmup:       h.Warmup,
+		Error:        h.Error,
+	}
+}
+
+// OpenHitLog output dizininde vgbot_hits_<ts>.jsonl dosyasını açar; sonraki her hit dosyaya eklenir.
+// Dosya Finalize ile kapatılır. Açılan dosyanın yolunu döner.
+func (r *Reporter) OpenHitLog() (string, error) {
+	l, path, err := createJSONL(r.outputDir, fmt.Sprintf("vgbot_hits_%s.jsonl", time.Now().Format("20060102_150405")))
+	if err != nil {
+		return "", err
+	}
+
+	r.mu.Lock()
+	prev := r.hitLog
+	r.hitLog = l
+	r.mu.Unlock()
+	if prev != nil {
+		prev.close()
+	}
+	return path, nil
+}
@@ -0,0 +1,65 @@
+package reporter
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHitLogJSONL(t *testing.T) {
+	dir := t.TempDir()
+	r := New(dir, "json", "example.com")
+	path, err := r.OpenHitLog()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	r.Record(HitRecord{Timestamp: ts, URL: "https://example.com/", StatusCode: 200, ResponseTime: 420,
+		Proxy: "1.2.3.4:8080", Device: "iPhone 15", Keyword: "vgbot", SessionID: "abc", UserAgent: "UA"})
+	r.Record(HitRecord{Timestamp: ts, URL: "https://example.com/x", Error: "timeout"})
+
+	// Satırlar çalıştırma sırasında (Finalize'dan önce) diskte olmalı
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(data), "\n"); n != 2 {
+		t.Fatalf("lines before Finalize = %d, want 2", n)
+	}
+
+	r.Finalize()
+	r.Record(HitRecord{URL: "https://example.com/late"})
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var lines []HitLine
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		var l HitLine
+		if err := json.Unmarshal(sc.Bytes(), &l); err != nil {
+			t.Fatalf("line %q: %v", sc.Text(), err)
+		}
+		lines = append(lines, l)
+	}
+	if len(lines) != 2 {
+		t.Fatalf("lines = %d, want 2 (no writes after Finalize)", len(lines))
+	}
+	want := HitLine{Timestamp: ts, URL: "https://example.com/", Proxy: "1.2.3.4:8080", Device: "iPhone 15",
+		Keyword: "vgbot", StatusCode: 200, ResponseTime: 420, SessionID: "abc"}
+	if !lines[0].Timestamp.Equal(want.Timestamp) {
+		t.Errorf("timestamp = %v", lines[0].Timestamp)
+	}
+	lines[0].Timestamp = want.Timestamp
+	if lines[0] != want {
+		t.Errorf("line = %+v, want %+v", lines[0], want)
+	}
+	if lines[1].Error != "timeout" {
+		t.Errorf("error = %q", lines[1].Error)
+	}
+}
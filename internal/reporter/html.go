@@ -3,9 +3,10 @@ package reporter
 import (
 	"encoding/json"
 	"fmt"
+	"html/template"
 	"os"
 	"path/filepath"
-	"text/template"
+	"strings"
 	"time"
 )
 
@@ -15,6 +16,7 @@ type HTMLReporter struct {
 	records   []HitRecord
 	domain    string
 	timestamp time.Time
+	branding  Branding
 }
 
 // NewHTMLReporter yeni HTML rapor üretici
@@ -27,6 +29,11 @@ func NewHTMLReporter(metrics Metrics, records []HitRecord, domain string) *HTMLR
 	}
 }
 
+// SetBranding rapor başlığı, logo, renk ve alt bilgisini ayarlar
+func (h *HTMLReporter) SetBranding(b Branding) {
+	h.branding = b
+}
+
 // GenerateReport HTML raporu oluşturur
 func (h *HTMLReporter) GenerateReport(filename string) error {
 	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
@@ -34,6 +41,15 @@ func (h *HTMLReporter) GenerateReport(filename string) error {
 	}
 
 	data := h.prepareTemplateData()
+	logo, err := h.branding.logoSrc()
+	if err != nil {
+		return fmt.Errorf("report logo: %w", err)
+	}
+	data["LogoSrc"] = logo
+	if strings.HasPrefix(logo, "data:") {
+		// Dosyadan üretilen data URI; html/template src'de yalnızca template.URL olarak geçirir
+		data["LogoSrc"] = template.URL(logo)
+	}
 	tmpl, err := template.New("report").Parse(htmlReportTemplate)
 	if err != nil {
 		return err
@@ -56,8 +72,8 @@ func (h *HTMLReporter) prepareTemplateData() map[string]interface{} {
 		successRate = float64(m.SuccessHits) / float64(m.TotalHits) * 100
 	}
 	rpm := 0.0
-	if duration.Minutes() > 0 {
-		rpm = float64(m.TotalHits) / duration.Minutes()
+	if measured := m.EndTime.Sub(measuredStart(*m)); measured.Minutes() > 0 {
+		rpm = float64(m.TotalHits) / measured.Minutes()
 	}
 
 	uniquePages := make(map[string]bool)
@@ -75,6 +91,7 @@ func (h *HTMLReporter) prepareTemplateData() map[string]interface{} {
 		URL         string
 		StatusCode  int
 		ResponseTime int64
+		Warmup      bool
 	}
 	recentViews := make([]recView, len(recent))
 	for i, r := range recent {
@@ -83,14 +100,56 @@ func (h *HTMLReporter) prepareTemplateData() map[string]interface{} {
 			URL:          r.URL,
 			StatusCode:   r.StatusCode,
 			ResponseTime: r.ResponseTime,
+			Warmup:       r.Warmup,
 		}
 	}
 
+	type weightView struct {
+		URL         string
+		Visits      int
+		AvgRequests string
+		AvgSize     string
+		MaxSize     string
+	}
+	var heaviest []weightView
+	for _, p := range heaviestPages(m.PageWeights, heaviestPagesLimit) {
+		heaviest = append(heaviest, weightView{
+			URL:         p.URL,
+			Visits:      p.Visits,
+			AvgRequests: fmt.Sprintf("%.1f", p.AvgRequests()),
+			AvgSize:     formatBytes(p.AvgBytes()),
+			MaxSize:     formatBytes(p.MaxBytes),
+		})
+	}
+
+	type urlView struct {
+		URL     string
+		Hits    int
+		Share   string
+		Success int
+		Failed  int
+	}
+	var urlHits []urlView
+	for _, s := range topURLs(m.URLHits, urlBreakdownLimit) {
+		urlHits = append(urlHits, urlView{
+			URL:     s.URL,
+			Hits:    s.Hits,
+			Share:   fmt.Sprintf("%.1f", float64(s.Hits)*100/float64(m.TotalHits)),
+			Success: s.Success,
+			Failed:  s.Failed,
+		})
+	}
+
 	timelineData := h.buildTimelineData()
 	statusData := h.buildStatusData()
 	responseData := h.buildResponseTimeData()
 
+	brand := h.branding.withDefaults()
 	return map[string]interface{}{
+		"Title":              brand.Title,
+		"Footer":             brand.Footer,
+		"PrimaryColor":       brand.PrimaryColor,
+		"BackgroundColor":    brand.BackgroundColor,
 		"Timestamp":          h.timestamp.Format("2006-01-02 15:04:05"),
 		"Domain":             h.domain,
 		"TotalHits":          m.TotalHits,
@@ -100,9 +159,22 @@ func (h *HTMLReporter) prepareTemplateData() map[string]interface{} {
 		"RequestsPerMinute":  fmt.Sprintf("%.1f", rpm),
 		"UniquePages":        len(uniquePages),
 		"TimelineData":       timelineData,
-		"StatusData":         statusData,
-		"ResponseTimeData":   responseData,
+		"StatusData":         template.JS(statusData),
+		"ResponseTimeData":   template.JS(responseData),
 		"RecentRequests":     recentViews,
+		"Seed":               m.Seed,
+		"ErrorClasses":       m.ErrorClasses,
+		"MeasurementIDs":     m.MeasurementIDs,
+		"HeaviestPages":      heaviest,
+		"URLHits":            urlHits,
+		"TrafficMarker":      m.TrafficMarker,
+		"NotFoundProbes":     m.NotFoundProbes,
+		"Variants":           m.Variants,
+		"Protocols":          m.Protocols,
+		"SLA":                m.SLA,
+		"Warmup":             m.Warmup,
+		"ContentChecks":      m.ContentChecks,
+		"Security":           m.Security,
 	}
 }
 
@@ -146,6 +218,9 @@ func (h *HTMLReporter) buildResponseTimeData() string {
 	bins := []string{"0-200", "200-500", "500-1000", "1000-2000", "2000+"}
 	counts := make([]int, len(bins))
 	for _, r := range h.records {
+		if r.Warmup {
+			continue
+		}
 		rt := int(r.ResponseTime)
 		switch {
 		case rt < 200:
@@ -183,17 +258,18 @@ const htmlReportTemplate = `<!DOCTYPE html>
 <head>
     <meta charset="UTF-8">
     <meta name="viewport" content="width=device-width, initial-scale=1">
-    <title>Eros Hit Bot - Report</title>
+    <title>{{.Title}}</title>
     <script src="https://cdn.jsdelivr.net/npm/chart.js@4.4.0/dist/chart.umd.min.js"></script>
     <style>
         * { margin: 0; padding: 0; box-sizing: border-box; }
-        body { font-family: system-ui, sans-serif; background: #0f172a; color: #e2e8f0; padding: 24px; }
+        body { font-family: system-ui, sans-serif; background: {{.BackgroundColor}}; color: #e2e8f0; padding: 24px; }
         .container { max-width: 1200px; margin: 0 auto; }
-        .header { text-align: center; padding: 32px; background: linear-gradient(135deg,#1e3a5f,#0f172a); border-radius: 12px; margin-bottom: 24px; }
+        .header { text-align: center; padding: 32px; background: linear-gradient(135deg,#1e3a5f,{{.BackgroundColor}}); border-radius: 12px; margin-bottom: 24px; }
         .header h1 { font-size: 2rem; margin-bottom: 8px; }
         .stats { display: grid; grid-template-columns: repeat(auto-fit, minmax(180px, 1fr)); gap: 16px; margin-bottom: 24px; }
         .stat-card { background: #1e293b; padding: 20px; border-radius: 12px; }
-        .stat-card .value { font-size: 2rem; font-weight: bold; color: #38bdf8; }
+        .stat-card .value { font-size: 2rem; font-weight: bold; color: {{.PrimaryColor}}; }
+        .header .logo { max-height: 64px; max-width: 240px; margin-bottom: 12px; }
         .stat-card .label { font-size: 0.85rem; color: #94a3b8; margin-top: 4px; }
         .chart-box { background: #1e293b; padding: 20px; border-radius: 12px; margin-bottom: 24px; height: 300px; }
         .chart-box h2 { font-size: 1.1rem; margin-bottom: 16px; }
@@ -207,8 +283,10 @@ const htmlReportTemplate = `<!DOCTYPE html>
 <body>
     <div class="container">
         <div class="header">
-            <h1>Eros Hit Bot Report</h1>
-            <p>Generated: {{.Timestamp}} | Target: {{.Domain}}</p>
+            {{if .LogoSrc}}<img class="logo" src="{{.LogoSrc}}" alt="">{{end}}
+            <h1>{{.Title}}</h1>
+            <p>Generated: {{.Timestamp}} | Target: {{.Domain}}{{if .Seed}} | Seed: {{.Seed}}{{end}}</p>
+            {{if .TrafficMarker}}<p>Traffic marker: <code>{{.TrafficMarker}}</code> — exclude visits carrying this marker in your analytics views to filter out simulated traffic.</p>{{end}}
         </div>
         <div class="stats">
             <div class="stat-card"><div class="value">{{.TotalHits}}</div><div class="label">Total Requests</div></div>
@@ -218,6 +296,71 @@ const htmlReportTemplate = `<!DOCTYPE html>
             <div class="stat-card"><div class="value">{{.RequestsPerMinute}}</div><div class="label">Req/Min</div></div>
             <div class="stat-card"><div class="value">{{.UniquePages}}</div><div class="label">Unique Pages</div></div>
         </div>
+        {{with .Warmup}}
+        <div style="margin-bottom: 24px;">
+            <h2 style="margin-bottom: 12px;">Warm-up (excluded from statistics)</h2>
+            <table>
+                <thead><tr><th>Duration (s)</th><th>Hits</th><th>Success</th><th>Failed</th></tr></thead>
+                <tbody><tr><td>{{printf "%.0f" .DurationSec}}</td><td>{{.Hits}}</td><td>{{.SuccessHits}}</td><td>{{.FailedHits}}</td></tr></tbody>
+            </table>
+        </div>
+        {{end}}
+        {{with .ContentChecks}}
+        <div style="margin-bottom: 24px;">
+            <h2 style="margin-bottom: 12px;">Content Checks: {{.Failed}} of {{.Checked}} failed</h2>
+            {{if .Failures}}
+            <table>
+                <thead><tr><th>Rule</th><th>Failures</th></tr></thead>
+                <tbody>
+                {{range $rule, $n := .Failures}}
+                <tr><td>{{$rule}}</td><td>{{$n}}</td></tr>
+                {{end}}
+                </tbody>
+            </table>
+            {{end}}
+        </div>
+        {{end}}
+        {{with .Security}}
+        <div style="margin-bottom: 24px;">
+            <h2 style="margin-bottom: 12px;">Security Summary: {{if .TLSVersion}}{{.TLSVersion}} ({{.CipherSuite}}){{else}}<span style="color: #ef4444;">no TLS</span>{{end}}</h2>
+            {{if .Chain}}
+            <table style="margin-bottom: 12px;">
+                <thead><tr><th>Certificate</th><th>Issuer</th><th>Expires</th><th>Days Left</th></tr></thead>
+                <tbody>
+                {{range .Chain}}
+                <tr><td>{{.Subject}}</td><td>{{.Issuer}}</td><td>{{.NotAfter.Format "2006-01-02"}}</td><td>{{.DaysLeft}}</td></tr>
+                {{end}}
+                </tbody>
+            </table>
+            {{end}}
+            <table style="margin-bottom: 12px;">
+                <thead><tr><th>Header</th><th>Value</th></tr></thead>
+                <tbody>
+                {{range .Headers}}
+                <tr><td>{{.Name}}</td><td>{{if .Value}}{{.Value}}{{else}}<span style="color: #ef4444;">missing</span>{{end}}</td></tr>
+                {{end}}
+                </tbody>
+            </table>
+            {{if .Findings}}
+            <p>Findings: {{range $i, $f := .Findings}}{{if $i}}, {{end}}<code>{{$f}}</code>{{end}}</p>
+            {{else}}
+            <p><span style="color: #22c55e;">No findings</span></p>
+            {{end}}
+        </div>
+        {{end}}
+        {{with .SLA}}
+        <div style="margin-bottom: 24px;">
+            <h2 style="margin-bottom: 12px;">SLA: {{if .Passed}}<span style="color: #22c55e;">PASS</span>{{else}}<span style="color: #ef4444;">FAIL</span>{{end}}</h2>
+            <table>
+                <thead><tr><th>Assertion</th><th>Measured</th><th>Result</th></tr></thead>
+                <tbody>
+                {{range .Results}}
+                <tr><td>{{.Assertion}}</td><td>{{printf "%.1f" .Actual}}{{.Unit}}</td><td>{{if .Passed}}<span style="color: #22c55e;">PASS</span>{{else}}<span style="color: #ef4444;">FAIL</span>{{end}}</td></tr>
+                {{end}}
+                </tbody>
+            </table>
+        </div>
+        {{end}}
         <div class="chart-box">
             <h2>Status Code Distribution</h2>
             <canvas id="statusChart"></canvas>
@@ -226,18 +369,105 @@ const htmlReportTemplate = `<!DOCTYPE html>
             <h2>Response Time Distribution</h2>
             <canvas id="responseChart"></canvas>
         </div>
+        {{if .ErrorClasses}}
+        <div style="margin-bottom: 24px;">
+            <h2 style="margin-bottom: 12px;">Errors by Class</h2>
+            <table>
+                <thead><tr><th>Class</th><th>Count</th></tr></thead>
+                <tbody>
+                {{range $class, $n := .ErrorClasses}}
+                <tr><td>{{$class}}</td><td>{{$n}}</td></tr>
+                {{end}}
+                </tbody>
+            </table>
+        </div>
+        {{end}}
+        {{if .MeasurementIDs}}
+        <div style="margin-bottom: 24px;">
+            <h2 style="margin-bottom: 12px;">Hits by Measurement ID</h2>
+            <table>
+                <thead><tr><th>Measurement ID</th><th>Hits</th></tr></thead>
+                <tbody>
+                {{range $id, $n := .MeasurementIDs}}
+                <tr><td>{{$id}}</td><td>{{$n}}</td></tr>
+                {{end}}
+                </tbody>
+            </table>
+        </div>
+        {{end}}
+        {{if .Variants}}
+        <div style="margin-bottom: 24px;">
+            <h2 style="margin-bottom: 12px;">Hits by Experiment Variant</h2>
+            <table>
+                <thead><tr><th>Variant</th><th>Hits</th></tr></thead>
+                <tbody>
+                {{range $v, $n := .Variants}}
+                <tr><td>{{$v}}</td><td>{{$n}}</td></tr>
+                {{end}}
+                </tbody>
+            </table>
+        </div>
+        {{end}}
+        {{if .Protocols}}
+        <div style="margin-bottom: 24px;">
+            <h2 style="margin-bottom: 12px;">Hits by HTTP Protocol</h2>
+            <table>
+                <thead><tr><th>Protocol</th><th>Hits</th></tr></thead>
+                <tbody>
+                {{range $p, $n := .Protocols}}
+                <tr><td>{{$p}}</td><td>{{$n}}</td></tr>
+                {{end}}
+                </tbody>
+            </table>
+        </div>
+        {{end}}
+        {{with .NotFoundProbes}}
+        <div style="margin-bottom: 24px;">
+            <h2 style="margin-bottom: 12px;">404 Probes</h2>
+            <table>
+                <thead><tr><th>Visits</th><th>404/410</th><th>Soft 404 (2xx)</th><th>Other</th></tr></thead>
+                <tbody><tr><td>{{.Visits}}</td><td>{{.Hard}}</td><td>{{.Soft}}</td><td>{{.Other}}</td></tr></tbody>
+            </table>
+        </div>
+        {{end}}
+        {{if .URLHits}}
+        <div style="margin-bottom: 24px;">
+            <h2 style="margin-bottom: 12px;">Hits per URL</h2>
+            <table>
+                <thead><tr><th>URL</th><th>Hits</th><th>Share (%)</th><th>Success</th><th>Failed</th></tr></thead>
+                <tbody>
+                {{range .URLHits}}
+                <tr><td style="max-width:400px;overflow:hidden;text-overflow:ellipsis;">{{.URL}}</td><td>{{.Hits}}</td><td>{{.Share}}</td><td>{{.Success}}</td><td>{{.Failed}}</td></tr>
+                {{end}}
+                </tbody>
+            </table>
+        </div>
+        {{end}}
+        {{if .HeaviestPages}}
+        <div style="margin-bottom: 24px;">
+            <h2 style="margin-bottom: 12px;">Heaviest Pages</h2>
+            <table>
+                <thead><tr><th>URL</th><th>Visits</th><th>Avg Requests</th><th>Avg Transferred</th><th>Max Transferred</th></tr></thead>
+                <tbody>
+                {{range .HeaviestPages}}
+                <tr><td style="max-width:400px;overflow:hidden;text-overflow:ellipsis;">{{.URL}}</td><td>{{.Visits}}</td><td>{{.AvgRequests}}</td><td>{{.AvgSize}}</td><td>{{.MaxSize}}</td></tr>
+                {{end}}
+                </tbody>
+            </table>
+        </div>
+        {{end}}
         <div style="margin-bottom: 24px;">
             <h2 style="margin-bottom: 12px;">Recent Requests</h2>
             <table>
                 <thead><tr><th>Time</th><th>URL</th><th>Status</th><th>Response (ms)</th></tr></thead>
                 <tbody>
                 {{range .RecentRequests}}
-                <tr><td>{{.TimeStr}}</td><td style="max-width:400px;overflow:hidden;text-overflow:ellipsis;">{{.URL}}</td><td>{{.StatusCode}}</td><td>{{.ResponseTime}}</td></tr>
+                <tr{{if .Warmup}} style="opacity: 0.5;" title="warm-up"{{end}}><td>{{.TimeStr}}{{if .Warmup}} (warm-up){{end}}</td><td style="max-width:400px;overflow:hidden;text-overflow:ellipsis;">{{.URL}}</td><td>{{.StatusCode}}</td><td>{{.ResponseTime}}</td></tr>
                 {{end}}
                 </tbody>
             </table>
         </div>
-        <div class="footer">Eros Hit Bot | Report generated automatically</div>
+        <div class="footer">{{.Footer}}</div>
     </div>
     <script>
         const statusData = {{.StatusData}};
@@ -248,7 +478,7 @@ const htmlReportTemplate = `<!DOCTYPE html>
         const respData = {{.ResponseTimeData}};
         new Chart(document.getElementById('responseChart'), {
             type: 'bar',
-            data: { labels: respData.bins, datasets: [{ label: 'Count', data: respData.counts, backgroundColor: '#38bdf8' }] },
+            data: { labels: respData.bins, datasets: [{ label: 'Count', data: respData.counts, backgroundColor: '{{.PrimaryColor}}' }] },
             options: { scales: { y: { beginAtZero: true } } }
         });
     </script>
@@ -0,0 +1,102 @@
+package reporter
+
+import (
+	"sort"
+	"time"
+)
+
+// LoadLevel yük testinde tek bir seviyenin (closed-loop eşzamanlılık veya yük profili aşaması) sonucu
+type LoadLevel struct {
+	Stage            string  `json:"stage,omitempty"`           // Yük profili aşaması (ör. "step 2/4")
+	Concurrency      int     `json:"concurrency,omitempty"`     // Closed-loop: sabit tutulan sanal kullanıcı sayısı
+	HitsPerMinute    int     `json:"hits_per_minute,omitempty"` // Açık mod: aşamanın HPM sınırı
+	DurationSec      float64 `json:"duration_sec"`
+	Hits             int     `json:"hits"`
+	SuccessHits      int     `json:"success_hits"`
+	FailedHits       int     `json:"failed_hits"`
+	ThroughputPerMin float64 `json:"throughput_per_min"` // Tamamlanan hit / dakika
+	AvgLatencyMs     float64 `json:"avg_latency_ms"`     // Başarılı hit'lerin yanıt süresi
+	P50LatencyMs     int64   `json:"p50_latency_ms"`
+	P95LatencyMs     int64   `json:"p95_latency_ms"`
+	MaxLatencyMs     int64   `json:"max_latency_ms"`
+}
+
+// loadLevelAcc aktif seviyede kaydedilen hit'leri toplar (r.mu tutulurken kullanılır)
+type loadLevelAcc struct {
+	level     LoadLevel
+	start     time.Time
+	latencies []int64
+}
+
+func (a *loadLevelAcc) add(h HitRecord) {
+	a.level.Hits++
+	if h.Error != "" {
+		a.level.FailedHits++
+		return
+	}
+	a.level.SuccessHits++
+	a.latencies = append(a.latencies, h.ResponseTime)
+}
+
+// finish süreyi, throughput'u ve gecikme yüzdeliklerini hesaplar
+func (a *loadLevelAcc) finish(end time.Time) LoadLevel {
+	l := a.level
+	l.DurationSec = end.Sub(a.start).Seconds()
+	if l.DurationSec > 0 {
+		l.ThroughputPerMin = float64(l.Hits) / l.DurationSec * 60
+	}
+	if n := len(a.latencies); n > 0 {
+		sort.Slice(a.latencies, func(i, j int) bool { return a.latencies[i] < a.latencies[j] })
+		var total int64
+		for _, ms := range a.latencies {
+			total += ms
+		}
+		l.AvgLatencyMs = float64(total) / float64(n)
+		l.P50LatencyMs = percentile(a.latencies, 50)
+		l.P95LatencyMs = percentile(a.latencies, 95)
+		l.MaxLatencyMs = a.latencies[n-1]
+	}
+	return l
+}
+
+// percentile sıralı dizide en yakın sıra yöntemiyle p. yüzdeliği döner
+func percentile(sorted []int64, p int) int64 {
+	idx := (len(sorted)*p + 99) / 100
+	if idx < 1 {
+		idx = 1
+	}
+	return sorted[idx-1]
+}
+
+// BeginLoadLevel yeni seviyeyi başlatır; EndLoadLevel'a kadar kaydedilen hit'ler bu seviyeye yazılır
+func (r *Reporter) BeginLoadLevel(stage string, concurrency, hitsPerMinute int) {
+	r.mu.Lock()
+	r.loadLevel = &loadLevelAcc{
+		level: LoadLevel{Stage: stage, Concurrency: concurrency, HitsPerMinute: hitsPerMinute},
+		start: time.Now(),
+	}
+	r.mu.Unlock()
+}
+
+// CurrentStage aktif yük profili aşamasının adını döner (yoksa "")
+func (r *Reporter) CurrentStage() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.loadLevel == nil {
+		return ""
+	}
+	return r.loadLevel.level.Stage
+}
+
+// EndLoadLevel aktif seviyeyi kapatıp metriklere ekler ve sonucunu döner (aktif seviye yoksa false)
+func (r *Reporter) EndLoadLevel() (LoadLevel, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.loadLevel == nil {
+		return LoadLevel{}, false
+	}
+	l := r.loadLevel.finish(time.Now())
+	r.loadLevel = nil
+	r.metrics.LoadLevels = append(r.metrics.LoadLevels, l)
+	return l, true
+}
@@ -0,0 +1,38 @@
+package reporter
+
+import "testing"
+
+func TestLoadLevels(t *testing.T) {
+	r := New(t.TempDir(), "json", "example.com")
+	r.Record(HitRecord{URL: "https://example.com/before", ResponseTime: 9999})
+
+	r.BeginLoadLevel("step 1/2", 5, 0)
+	for _, ms := range []int64{100, 300, 200, 400, 1000} {
+		r.Record(HitRecord{URL: "https://example.com/", ResponseTime: ms})
+	}
+	r.Record(HitRecord{URL: "https://example.com/", Error: "timeout"})
+	if got := r.CurrentStage(); got != "step 1/2" {
+		t.Errorf("CurrentStage = %q", got)
+	}
+	l, ok := r.EndLoadLevel()
+	if !ok {
+		t.Fatal("EndLoadLevel: no active level")
+	}
+	if l.Stage != "step 1/2" || l.Concurrency != 5 || l.Hits != 6 || l.SuccessHits != 5 || l.FailedHits != 1 {
+		t.Errorf("counts = %+v", l)
+	}
+	if l.AvgLatencyMs != 400 || l.P50LatencyMs != 300 || l.P95LatencyMs != 1000 || l.MaxLatencyMs != 1000 {
+		t.Errorf("latency = avg %.0f p50 %d p95 %d max %d", l.AvgLatencyMs, l.P50LatencyMs, l.P95LatencyMs, l.MaxLatencyMs)
+	}
+	if l.DurationSec <= 0 || l.ThroughputPerMin <= 0 {
+		t.Errorf("duration = %v, throughput = %v", l.DurationSec, l.ThroughputPerMin)
+	}
+
+	r.Record(HitRecord{URL: "https://example.com/after"})
+	if _, ok := r.EndLoadLevel(); ok {
+		t.Error("EndLoadLevel without an active level returned ok")
+	}
+	if levels := r.GetMetrics().LoadLevels; len(levels) != 1 || levels[0].Hits != 6 {
+		t.Errorf("metrics load levels = %+v", levels)
+	}
+}
@@ -0,0 +1,36 @@
+package reporter
+
+import (
+	"net/url"
+	"strings"
+)
+
+// NotFoundPathPrefix 404 denemesi ziyaretlerinin yol öneki; bu yoldaki hit'ler normal metriklere karışmaz
+const NotFoundPathPrefix = "/vgbot-404-"
+
+// NotFoundStats var olmayan URL ziyaretlerinin (404 denemesi) özeti
+type NotFoundStats struct {
+	Visits int `json:"visits"`
+	Hard   int `json:"hard_404"` // 404/410 dönen
+	Soft   int `json:"soft_404"` // 2xx dönen (soft-404: hata sayfası başarılı yanıtla sunuluyor)
+	Other  int `json:"other"`    // Yönlendirme, 5xx veya ziyaret hatası
+}
+
+// IsNotFoundProbe URL'nin 404 denemesi olup olmadığını döner
+func IsNotFoundProbe(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	return err == nil && strings.HasPrefix(u.Path, NotFoundPathPrefix)
+}
+
+// add hit'i sonucuna göre sayar
+func (s *NotFoundStats) add(h HitRecord) {
+	s.Visits++
+	switch {
+	case h.Error == "" && (h.StatusCode == 404 || h.StatusCode == 410):
+		s.Hard++
+	case h.Error == "" && h.StatusCode >= 200 && h.StatusCode < 300:
+		s.Soft++
+	default:
+		s.Other++
+	}
+}
@@ -0,0 +1,23 @@
+package reporter
+
+import "testing"
+
+func TestNotFoundProbesReportedSeparately(t *testing.T) {
+	r := New(t.TempDir(), "json", "example.com")
+	r.Record(HitRecord{URL: "https://example.com/", StatusCode: 200, Requests: 5, Bytes: 1000})
+	r.Record(HitRecord{URL: "https://example.com" + NotFoundPathPrefix + "0001", StatusCode: 404, Requests: 3, Bytes: 500})
+	r.Record(HitRecord{URL: "https://example.com" + NotFoundPathPrefix + "0002", StatusCode: 200})
+	r.Record(HitRecord{URL: "https://example.com" + NotFoundPathPrefix + "0003", Error: "timeout"})
+
+	m := r.GetMetrics()
+	if m.TotalHits != 1 || m.SuccessHits != 1 || m.FailedHits != 0 || m.StatusCodes[404] != 0 {
+		t.Fatalf("probes leaked into hit metrics: %+v", m)
+	}
+	if len(m.PageWeights) != 1 {
+		t.Fatalf("probes leaked into page weights: %+v", m.PageWeights)
+	}
+	want := NotFoundStats{Visits: 3, Hard: 1, Soft: 1, Other: 1}
+	if m.NotFoundProbes == nil || *m.NotFoundProbes != want {
+		t.Fatalf("probes = %+v, want %+v", m.NotFoundProbes, want)
+	}
+}
@@ -1,15 +1,18 @@
 package reporter
 
 import (
+	"context"
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
 	"vgbot/pkg/i18n"
+	"vgbot/pkg/secaudit"
 )
 
 // PERFORMANCE FIX: Maximum records to prevent memory exhaustion
@@ -23,7 +26,21 @@ type HitRecord struct {
 	ResponseTime int64     `json:"response_time_ms"`
 	UserAgent    string    `json:"user_agent"`
 	Proxy        string    `json:"proxy,omitempty"` // SECURITY FIX: Proxy bilgisi eklendi
+	SessionID    string    `json:"session_id,omitempty"` // Ziyaretin oturum ID'si (zaman çizelgesiyle aynı)
+	Device       string    `json:"device,omitempty"`     // Emüle edilen cihaz profili (UA havuzundan seçildiyse desktop/mobile)
+	Keyword      string    `json:"keyword,omitempty"`    // Arama referrer'ının anahtar kelimesi
 	Error        string    `json:"error,omitempty"`
+	ErrorClass   string    `json:"error_class,omitempty"` // errclass etiketi (başarılı hit'te analytics_missing olabilir)
+	MeasurementID string   `json:"measurement_id,omitempty"` // Sayfada kullanılan GA4 ID (sayfanın kendi ID'si veya fallback)
+	Events       []string  `json:"events,omitempty"`          // Ziyarette gönderilen GA event'leri (page_view, scroll)
+	Requests     int       `json:"requests,omitempty"`        // Sayfanın yüklediği istek sayısı (ölçülmediyse 0)
+	Bytes        int64     `json:"bytes,omitempty"`           // Ziyarette aktarılan toplam bayt
+	Variant      string    `json:"variant,omitempty"`         // A/B deneyinde atanan varyant
+	Beacons          int   `json:"beacons,omitempty"`           // Yakalanan GA4 collect istekleri (/g/collect)
+	BeaconsDelivered int   `json:"beacons_delivered,omitempty"` // Başarılı yanıt alan collect istekleri
+	BeaconBytes      int64 `json:"beacon_bytes,omitempty"`      // Collect isteklerinin payload boyutu
+	Protocol     string    `json:"protocol,omitempty"`        // Müzakere edilen HTTP sürümü (HTTP/1.1, HTTP/2.0, HTTP/3.0); tarayıcısız hit'lerde
+	Warmup       bool      `json:"warmup,omitempty"`          // Isınma penceresinde; istatistiklere katılmaz
 }
 
 // Metrics toplam performans metrikleri
@@ -37,10 +54,25 @@ type Metrics struct {
 	StatusCodes     map[int]int `json:"status_codes"`
 	StartTime       time.Time   `json:"start_time"`
 	EndTime         time.Time   `json:"end_time"`
+	Seed            int64       `json:"seed,omitempty"` // Çalıştırmayı tekrarlamak için RNG seed'i
+	ErrorClasses    map[string]int `json:"error_classes,omitempty"` // errclass etiketine göre sayım
+	MeasurementIDs  map[string]int `json:"measurement_ids,omitempty"` // GA4 measurement ID'ye göre başarılı hit sayısı
+	PageWeights     map[string]PageWeight `json:"page_weights,omitempty"` // Sayfa başına istek/bayt toplamları
+	URLHits         map[string]URLStats `json:"url_hits,omitempty"` // URL başına hit/başarı/hata sayıları
+	TrafficMarker   string `json:"traffic_marker,omitempty"` // Simüle trafiğin işareti (ör. "query ?vgbot=1"); analytics filtresi için
+	NotFoundProbes  *NotFoundStats `json:"not_found_probes,omitempty"` // Var olmayan URL ziyaretleri (başarılı hit'lerden ayrı)
+	Variants        map[string]int `json:"variants,omitempty"` // A/B deney varyantına göre başarılı hit sayısı
+	Protocols       map[string]int `json:"protocols,omitempty"` // Müzakere edilen HTTP sürümüne göre başarılı hit sayısı
+	AnalyticsDelivery *DeliveryStats `json:"analytics_delivery,omitempty"` // Ağdan yakalanan GA4 collect isteklerine göre teslimat
+	LoadLevels      []LoadLevel `json:"load_levels,omitempty"` // Closed-loop yük testinde eşzamanlılık seviyesi başına throughput/gecikme
+	SLA             *SLAResult  `json:"sla,omitempty"`         // Çalıştırma sonunda değerlendirilen SLA koşulları
+	Warmup          *WarmupStats `json:"warmup,omitempty"`     // İstatistik dışı tutulan ısınma penceresi hit'leri
+	ContentChecks   *ContentCheckStats `json:"content_checks,omitempty"` // Örneklenen ziyaretlerin yanıt içerik kontrolleri
+	Security        *secaudit.Summary `json:"security,omitempty"` // Hedefin TLS sertifikası ve güvenlik header'ları (ilk yanıttan)
 }
 
 // HitCallback her hit tamamlandığında çağrılır (anlık UI güncellemesi için)
-type HitCallback func(url string, duration time.Duration, success bool, proxy string)
+type HitCallback func(url string, duration time.Duration, success bool, proxy string, errClass string)
 
 type Reporter struct {
 	mu               sync.RWMutex
@@ -50,11 +82,22 @@ type Reporter struct {
 	outputDir        string
 	format           string
 	logChan          chan string
+	events           chan Event // Log kanalına paralel tipli olaylar (hit, session, error)
 	domain           string
 	locale           string // "tr" veya "en"
 	closed           bool   // kanal kapatıldı mı
 	recordsFlushed   int    // PERFORMANCE: Track flushed records count
 	hitCallback      HitCallback // SECURITY FIX: Anlık hit bildirimi için callback
+	bigQuery         *BigQueryExporter // Opsiyonel: event/session satırlarını BigQuery'ye yazar
+	timelines        []SessionTimeline // Son ziyaretlerin adım adım zaman çizelgeleri (ring)
+	timelineCallback TimelineCallback
+	branding         Branding // HTML rapor markalaması
+	hitLog           *jsonlFile // Opsiyonel: hit'leri JSON Lines olarak anlık yazar
+	sessionLog       *jsonlFile // Opsiyonel: ziyaret adımlarını yapılandırılmış olay olarak yazar
+	logEvents        []LogEvent // Son yapılandırılmış olaylar (ring)
+	loadLevel        *loadLevelAcc // Closed-loop modda aktif eşzamanlılık seviyesi
+	assertions       []Assertion   // Finalize'da değerlendirilen SLA koşulları
+	latencies        []int64       // SLA yüzdelikleri için başarılı hit yanıt süreleri (yalnızca koşul varsa)
 }
 
 func New(outputDir, format string, domain string) *Reporter {
@@ -70,11 +113,18 @@ func NewWithLocale(outputDir, format string, domain, locale string) *Reporter {
 		outputDir: outputDir,
 		format:    format,
 		logChan:   make(chan string, 100),
+		events:    make(chan Event, eventBuffer),
 		domain:    domain,
 		locale:    locale,
 		closed:    false,
 	}
 	r.metrics.StatusCodes = make(map[int]int)
+	r.metrics.ErrorClasses = make(map[string]int)
+	r.metrics.MeasurementIDs = make(map[string]int)
+	r.metrics.Variants = make(map[string]int)
+	r.metrics.Protocols = make(map[string]int)
+	r.metrics.PageWeights = make(map[string]PageWeight)
+	r.metrics.URLHits = make(map[string]URLStats)
 	r.metrics.StartTime = time.Now()
 	return r
 }
@@ -86,7 +136,55 @@ func (r *Reporter) SetHitCallback(cb HitCallback) {
 	r.hitCallback = cb
 }
 
+// SetSeed çalıştırmanın RNG seed'ini rapora yazar
+func (r *Reporter) SetSeed(seed int64) {
+	r.mu.Lock()
+	r.metrics.Seed = seed
+	r.mu.Unlock()
+}
+
+// SetTrafficMarker simüle trafiğe eklenen işaretin açıklamasını rapora yazar
+func (r *Reporter) SetTrafficMarker(desc string) {
+	r.mu.Lock()
+	r.metrics.TrafficMarker = desc
+	r.mu.Unlock()
+}
+
+// SetSecurity hedefin güvenlik denetimi özetini rapora yazar ve loglar
+func (r *Reporter) SetSecurity(s *secaudit.Summary) {
+	r.mu.Lock()
+	r.metrics.Security = s
+	r.mu.Unlock()
+	tls, findings := s.TLSVersion, strings.Join(s.Findings, ", ")
+	if tls == "" {
+		tls = "HTTP"
+	}
+	if findings == "" {
+		findings = "-"
+	}
+	r.LogT(i18n.MsgSecurityAudit, tls, s.DaysLeft, findings)
+}
+
+// SetBigQuery hit'lerin BigQuery'ye export edilmesini açar; Finalize kalan satırları gönderir
+func (r *Reporter) SetBigQuery(e *BigQueryExporter) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.bigQuery = e
+}
+
 func (r *Reporter) Record(h HitRecord) {
+	at := h.Timestamp
+	if at.IsZero() {
+		at = time.Now()
+	}
+	r.mu.RLock()
+	hl := r.hitLog
+	h.Warmup = r.inWarmupLocked(at)
+	r.mu.RUnlock()
+	if hl != nil {
+		hl.write(hitLine(h))
+	}
+
 	r.mu.Lock()
 	
 	// PERFORMANCE FIX: Prevent unbounded memory growth
@@ -99,6 +197,26 @@ func (r *Reporter) Record(h HitRecord) {
 	}
 
 	r.records = append(r.records, h)
+	// Isınma hit'leri ayrı sayılır; canlı gösterim için callback yine çağrılır
+	if h.Warmup {
+		r.metrics.Warmup.add(h)
+		cb := r.hitCallback
+		r.mu.Unlock()
+		r.emit(hitEvent(h, at))
+		if cb != nil {
+			cb(h.URL, time.Duration(h.ResponseTime)*time.Millisecond, h.Error == "", h.Proxy, h.ErrorClass)
+		}
+		return
+	}
+	// 404 denemeleri ayrı sayılır; başarı oranı, status kodları ve sayfa ağırlıklarına karışmaz
+	if IsNotFoundProbe(h.URL) {
+		if r.metrics.NotFoundProbes == nil {
+			r.metrics.NotFoundProbes = &NotFoundStats{}
+		}
+		r.metrics.NotFoundProbes.add(h)
+		r.mu.Unlock()
+		return
+	}
 	r.metrics.TotalHits++
 	
 	success := h.Error == ""
@@ -116,19 +234,54 @@ func (r *Reporter) Record(h HitRecord) {
 		// BUG FIX #19: Kesin ortalama - float drift önleme
 		r.totalResponseTime += h.ResponseTime
 		r.metrics.AvgResponseTime = float64(r.totalResponseTime) / float64(r.metrics.SuccessHits)
+		if len(r.assertions) > 0 {
+			r.latencies = append(r.latencies, h.ResponseTime)
+		}
+		if h.MeasurementID != "" {
+			r.metrics.MeasurementIDs[h.MeasurementID]++
+		}
+		if h.Variant != "" {
+			r.metrics.Variants[h.Variant]++
+		}
+		if h.Protocol != "" {
+			r.metrics.Protocols[h.Protocol]++
+		}
+		if h.MeasurementID != "" {
+			if r.metrics.AnalyticsDelivery == nil {
+				r.metrics.AnalyticsDelivery = &DeliveryStats{}
+			}
+			r.metrics.AnalyticsDelivery.add(h)
+		}
 	} else {
 		r.metrics.FailedHits++
 	}
+	if h.ErrorClass != "" {
+		r.metrics.ErrorClasses[h.ErrorClass]++
+	}
+	addPageWeight(r.metrics.PageWeights, h)
+	addURLHit(r.metrics.URLHits, h)
+	if r.loadLevel != nil {
+		r.loadLevel.add(h)
+	}
 	
 	// SECURITY FIX: Anlık hit bildirimi için callback çağır (lock dışında)
 	cb := r.hitCallback
 	proxyStr := h.Proxy
+	bq, seed := r.bigQuery, r.metrics.Seed
 	r.mu.Unlock()
+
+	if bq != nil {
+		bq.Add(HitRows(h, r.domain, bq.RunID(), seed)...)
+	}
+	r.emit(hitEvent(h, at))
+	if !success {
+		r.emit(ErrorEvent{Time: at, Source: ErrorSourceHit, Class: h.ErrorClass, Message: h.Error, URL: h.URL, Proxy: proxyStr})
+	}
 	
 	// Callback'i lock dışında çağır (deadlock önleme)
 	if cb != nil {
 		duration := time.Duration(h.ResponseTime) * time.Millisecond
-		cb(h.URL, duration, success, proxyStr)
+		cb(h.URL, duration, success, proxyStr, h.ErrorClass)
 	}
 }
 
@@ -156,25 +309,73 @@ func (r *Reporter) LogT(key string, args ...interface{}) {
 func (r *Reporter) Finalize() {
 	r.mu.Lock()
 	r.metrics.EndTime = time.Now()
+	var sla *SLAResult
+	if len(r.assertions) > 0 {
+		res := evaluateSLA(r.assertions, r.metrics, r.latencies)
+		sla = &res
+		r.metrics.SLA = sla
+	}
+	bq := r.bigQuery
+	r.bigQuery = nil
+	hl, sl := r.hitLog, r.sessionLog
+	r.hitLog, r.sessionLog = nil, nil
 	r.mu.Unlock()
+
+	if sla != nil {
+		r.logSLA(*sla)
+	}
+	if hl != nil {
+		if err := hl.close(); err != nil {
+			r.LogT(i18n.MsgHitLogError, err)
+			r.emitError(ErrorSourceExport, "hit_log", err)
+		}
+	}
+	if sl != nil {
+		if err := sl.close(); err != nil {
+			r.LogT(i18n.MsgSessionLogError, err)
+			r.emitError(ErrorSourceExport, "session_log", err)
+		}
+	}
+
+	if bq != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		if err := bq.Close(ctx); err != nil {
+			r.LogT(i18n.MsgBigQueryError, err)
+			r.emitError(ErrorSourceExport, "bigquery", err)
+		}
+		written, failed := bq.Stats()
+		r.LogT(i18n.MsgBigQueryDone, written, failed)
+	}
 }
 
-// Close log kanalını kapatır ve kaynakları temizler
+// Close log ve olay kanallarını kapatır ve kaynakları temizler
 func (r *Reporter) Close() {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	if !r.closed {
 		r.closed = true
 		close(r.logChan)
+		close(r.events)
 	}
 }
 
+// Export raporları format'a göre rapor dizinine yazar; hata olay kanalına da bildirilir
 func (r *Reporter) Export() error {
+	err := r.export()
+	if err != nil {
+		r.emitError(ErrorSourceExport, "report", err)
+	}
+	return err
+}
+
+func (r *Reporter) export() error {
 	if err := os.MkdirAll(r.outputDir, 0755); err != nil {
 		return err
 	}
 
 	ts := time.Now().Format("20060102_150405")
+	var files []string
 
 	if r.format == "csv" || r.format == "both" {
 		path := filepath.Join(r.outputDir, fmt.Sprintf("vgbot_hits_%s.csv", ts))
@@ -182,6 +383,7 @@ func (r *Reporter) Export() error {
 			return fmt.Errorf("CSV export: %w", err)
 		}
 		r.LogT(i18n.MsgReportCSV, path)
+		files = append(files, filepath.Base(path))
 	}
 
 	if r.format == "json" || r.format == "both" {
@@ -190,6 +392,7 @@ func (r *Reporter) Export() error {
 			return fmt.Errorf("JSON export: %w", err)
 		}
 		r.LogT(i18n.MsgReportJSON, path)
+		files = append(files, filepath.Base(path))
 	}
 
 	if r.format == "html" || r.format == "both" {
@@ -197,14 +400,24 @@ func (r *Reporter) Export() error {
 		m := r.metrics
 		recs := make([]HitRecord, len(r.records))
 		copy(recs, r.records)
+		branding := r.branding
 		r.mu.RUnlock()
 		htmlPath := filepath.Join(r.outputDir, fmt.Sprintf("vgbot_report_%s.html", ts))
-		if err := NewHTMLReporter(m, recs, r.domain).GenerateReport(htmlPath); err != nil {
+		hr := NewHTMLReporter(m, recs, r.domain)
+		hr.SetBranding(branding)
+		if err := hr.GenerateReport(htmlPath); err != nil {
 			return fmt.Errorf("HTML export: %w", err)
 		}
 		r.LogT(i18n.MsgReportHTML, htmlPath)
+		files = append(files, filepath.Base(htmlPath))
 	}
 
+	// Çalıştırma geçmişi (/api/runs) için özet; yazılamazsa raporlar yine geçerlidir
+	if len(files) > 0 {
+		if err := r.appendRunIndex(ts, files); err != nil {
+			r.Log(fmt.Sprintf("⚠️ Çalıştırma indeksi yazılamadı: %v", err))
+		}
+	}
 	return nil
 }
 
@@ -216,7 +429,7 @@ func (r *Reporter) exportCSV(path string) error {
 	defer f.Close()
 
 	w := csv.NewWriter(f)
-	_ = w.Write([]string{"timestamp", "url", "status_code", "response_time_ms", "user_agent", "error"})
+	_ = w.Write([]string{"timestamp", "url", "status_code", "response_time_ms", "user_agent", "error", "requests", "bytes"})
 
 	r.mu.RLock()
 	for _, rec := range r.records {
@@ -231,6 +444,8 @@ func (r *Reporter) exportCSV(path string) error {
 			fmt.Sprintf("%d", rec.ResponseTime),
 			rec.UserAgent,
 			errStr,
+			fmt.Sprintf("%d", rec.Requests),
+			fmt.Sprintf("%d", rec.Bytes),
 		})
 	}
 	r.mu.RUnlock()
@@ -242,11 +457,13 @@ func (r *Reporter) exportCSV(path string) error {
 func (r *Reporter) exportJSON(path string) error {
 	r.mu.RLock()
 	out := struct {
-		Records []HitRecord `json:"records"`
-		Metrics Metrics     `json:"metrics"`
+		Records       []HitRecord  `json:"records"`
+		Metrics       Metrics      `json:"metrics"`
+		HeaviestPages []PageWeight `json:"heaviest_pages"`
 	}{
-		Records: make([]HitRecord, len(r.records)),
-		Metrics: r.metrics,
+		Records:       make([]HitRecord, len(r.records)),
+		Metrics:       r.metrics,
+		HeaviestPages: heaviestPages(r.metrics.PageWeights, heaviestPagesLimit),
 	}
 	copy(out.Records, r.records)
 	r.mu.RUnlock()
@@ -0,0 +1,160 @@
+package reporter
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RunsIndexFile her Export'ta çalıştırma özetinin eklendiği dosya (rapor dizininde, JSON Lines)
+const RunsIndexFile = "runs.jsonl"
+
+// RunSummary geçmiş bir çalıştırmanın özeti ve rapor dosyaları
+type RunSummary struct {
+	ID          string    `json:"id"` // Rapor dosyalarındaki zaman damgası (20060102_150405)
+	Domain      string    `json:"domain,omitempty"`
+	StartTime   time.Time `json:"start_time"`
+	EndTime     time.Time `json:"end_time"`
+	TotalHits   int       `json:"total_hits"`
+	SuccessHits int       `json:"success_hits"`
+	FailedHits  int       `json:"failed_hits"`
+	SuccessRate float64   `json:"success_rate"` // Yüzde
+	Files       []string  `json:"files"`        // Rapor dizinindeki dosya adları (csv, json, html)
+}
+
+// appendRunIndex çalıştırma özetini rapor dizinindeki indekse ekler
+func (r *Reporter) appendRunIndex(id string, files []string) error {
+	r.mu.RLock()
+	m := r.metrics
+	run := RunSummary{
+		ID:          id,
+		Domain:      r.domain,
+		StartTime:   m.StartTime,
+		EndTime:     m.EndTime,
+		TotalHits:   m.TotalHits,
+		SuccessHits: m.SuccessHits,
+		FailedHits:  m.FailedHits,
+		Files:       files,
+	}
+	r.mu.RUnlock()
+	if run.EndTime.IsZero() {
+		run.EndTime = time.Now()
+	}
+	run.SuccessRate = successRate(run.SuccessHits, run.TotalHits)
+
+	f, err := os.OpenFile(filepath.Join(r.outputDir, RunsIndexFile), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return json.NewEncoder(f).Encode(run)
+}
+
+// ListRuns rapor dizinindeki çalıştırmaları en yenisi önce döner. İndekste olmayan eski çalıştırmalar
+// (indeksten önce yazılmış raporlar) dosya adlarındaki zaman damgasından, varsa JSON raporun
+// metriklerinden çıkarılır; dosyası silinmiş rapor listeden düşer.
+func ListRuns(outputDir string) ([]RunSummary, error) {
+	entries, err := os.ReadDir(outputDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return []RunSummary{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	// Zaman damgasına göre mevcut rapor dosyaları
+	files := map[string][]string{}
+	for _, e := range entries {
+		if id := reportRunID(e.Name()); id != "" && !e.IsDir() {
+			files[id] = append(files[id], e.Name())
+		}
+	}
+
+	runs := map[string]RunSummary{}
+	if f, err := os.Open(filepath.Join(outputDir, RunsIndexFile)); err == nil {
+		sc := bufio.NewScanner(f)
+		sc.Buffer(make([]byte, 64*1024), 1024*1024)
+		for sc.Scan() {
+			var run RunSummary
+			if json.Unmarshal(sc.Bytes(), &run) == nil && run.ID != "" {
+				runs[run.ID] = run
+			}
+		}
+		f.Close()
+	}
+
+	out := make([]RunSummary, 0, len(files))
+	for id, names := range files {
+		sort.Strings(names)
+		run, ok := runs[id]
+		if !ok {
+			run = legacyRun(outputDir, id, names)
+		}
+		run.Files = names
+		out = append(out, run)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID > out[j].ID })
+	return out, nil
+}
+
+// IsRunReport name bir çalıştırma raporu dosyası mı (vgbot_report_<ts>.json|html, vgbot_hits_<ts>.csv)
+func IsRunReport(name string) bool {
+	return reportRunID(name) != ""
+}
+
+// reportRunID rapor dosyasının zaman damgası (vgbot_report_<ts>.json|html, vgbot_hits_<ts>.csv); değilse ""
+func reportRunID(name string) string {
+	var rest string
+	switch {
+	case strings.HasPrefix(name, "vgbot_report_") && (strings.HasSuffix(name, ".json") || strings.HasSuffix(name, ".html")):
+		rest = strings.TrimPrefix(name, "vgbot_report_")
+	case strings.HasPrefix(name, "vgbot_hits_") && strings.HasSuffix(name, ".csv"):
+		rest = strings.TrimPrefix(name, "vgbot_hits_")
+	default:
+		return ""
+	}
+	id := strings.TrimSuffix(rest, filepath.Ext(rest))
+	if _, err := time.ParseInLocation("20060102_150405", id, time.Local); err != nil {
+		return ""
+	}
+	return id
+}
+
+// legacyRun indekste olmayan çalıştırmanın özeti; JSON rapor yoksa yalnızca bitiş zamanı bilinir
+func legacyRun(outputDir, id string, names []string) RunSummary {
+	run := RunSummary{ID: id}
+	run.EndTime, _ = time.ParseInLocation("20060102_150405", id, time.Local)
+	for _, name := range names {
+		if !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(outputDir, name))
+		if err != nil {
+			break
+		}
+		var rep struct {
+			Metrics Metrics `json:"metrics"`
+		}
+		if json.Unmarshal(data, &rep) == nil {
+			m := rep.Metrics
+			run.StartTime, run.TotalHits, run.SuccessHits, run.FailedHits = m.StartTime, m.TotalHits, m.SuccessHits, m.FailedHits
+			if !m.EndTime.IsZero() {
+				run.EndTime = m.EndTime
+			}
+			run.SuccessRate = successRate(m.SuccessHits, m.TotalHits)
+		}
+		break
+	}
+	return run
+}
+
+func successRate(success, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(int(float64(success)*10000/float64(total))) / 100
+}
@@ -0,0 +1,49 @@
+package reporter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestListRuns(t *testing.T) {
+	dir := t.TempDir()
+	// İndeksten önce yazılmış rapor: yalnızca CSV, özeti dosya adından
+	legacy := "vgbot_hits_20250101_090000.csv"
+	if err := os.WriteFile(filepath.Join(dir, legacy), []byte("timestamp\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(dir, "notes.txt"), nil, 0644)
+
+	r := New(dir, "both", "example.com")
+	r.Record(HitRecord{URL: "https://example.com/", StatusCode: 200, ResponseTime: 100})
+	r.Record(HitRecord{URL: "https://example.com/", StatusCode: 200, ResponseTime: 100})
+	r.Record(HitRecord{URL: "https://example.com/x", Error: "timeout"})
+	r.Finalize()
+	if err := r.Export(); err != nil {
+		t.Fatal(err)
+	}
+
+	runs, err := ListRuns(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 2 {
+		t.Fatalf("runs = %+v", runs)
+	}
+	latest := runs[0]
+	if latest.Domain != "example.com" || latest.TotalHits != 3 || latest.SuccessHits != 2 || latest.SuccessRate != 66.66 || len(latest.Files) != 3 {
+		t.Errorf("latest = %+v", latest)
+	}
+	if old := runs[1]; old.ID != "20250101_090000" || old.EndTime.IsZero() || len(old.Files) != 1 || old.Files[0] != legacy {
+		t.Errorf("legacy = %+v", old)
+	}
+
+	// Dosyaları silinen çalıştırma listeden düşer
+	for _, name := range latest.Files {
+		os.Remove(filepath.Join(dir, name))
+	}
+	if runs, _ = ListRuns(dir); len(runs) != 1 {
+		t.Errorf("after delete = %+v", runs)
+	}
+}
@@ -0,0 +1,125 @@
+package reporter
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+)
+
+// maxLogEvents bellekte tutulan son yapılandırılmış olay sayısı (/api/logs/structured)
+const maxLogEvents = 2000
+
+// Olay seviyeleri (filtrede en düşük seviye olarak kullanılır)
+const (
+	LevelInfo  = "info"
+	LevelWarn  = "warn"
+	LevelError = "error"
+)
+
+var levelRank = map[string]int{LevelInfo: 0, LevelWarn: 1, LevelError: 2}
+
+// LogEvent ziyaret içindeki tek adımın yapılandırılmış log kaydı
+type LogEvent struct {
+	Time       time.Time `json:"time"`
+	Level      string    `json:"level"`
+	SessionID  string    `json:"session_id,omitempty"`
+	VisitID    int64     `json:"visit_id"` // Zaman çizelgesi ID'si; oturumdaki her sayfa ziyareti ayrı
+	URL        string    `json:"url"`
+	Proxy      string    `json:"proxy,omitempty"`
+	Phase      string    `json:"phase"`       // navigate, analytics, scroll, exit...
+	DurationMs int64     `json:"duration_ms"` // Sonraki adıma kadar geçen süre
+	Message    string    `json:"message,omitempty"`
+}
+
+// LogFilter yapılandırılmış olay sorgusu; boş alanlar filtrelemez
+type LogFilter struct {
+	SessionID string
+	Level     string // En düşük seviye: warn, warn ve error olayları döner
+	Limit     int
+}
+
+// timelineEvents zaman çizelgesi adımlarını olaylara çevirir. Başarısız adım warn, başarısız çıkış error olur.
+func timelineEvents(t SessionTimeline) []LogEvent {
+	events := make([]LogEvent, len(t.Steps))
+	for i, st := range t.Steps {
+		level := LevelInfo
+		if !st.OK {
+			level = LevelWarn
+			if st.Step == "exit" {
+				level = LevelError
+			}
+		}
+		var d int64
+		if i+1 < len(t.Steps) {
+			d = t.Steps[i+1].AtMs - st.AtMs
+		}
+		events[i] = LogEvent{
+			Time:       t.Start.Add(time.Duration(st.AtMs) * time.Millisecond),
+			Level:      level,
+			SessionID:  t.SessionID,
+			VisitID:    t.ID,
+			URL:        t.URL,
+			Proxy:      t.Proxy,
+			Phase:      st.Step,
+			DurationMs: d,
+			Message:    st.Detail,
+		}
+	}
+	return events
+}
+
+// addLogEvents olayları ring'e ekler (r.mu tutulurken çağrılır)
+func (r *Reporter) addLogEvents(events []LogEvent) {
+	r.logEvents = append(r.logEvents, events...)
+	if over := len(r.logEvents) - maxLogEvents; over > 0 {
+		r.logEvents = append(r.logEvents[:0], r.logEvents[over:]...)
+	}
+}
+
+// LogEvents filtreye uyan son olayları en yeniden eskiye döner
+func (r *Reporter) LogEvents(f LogFilter) []LogEvent {
+	minRank := levelRank[f.Level]
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	out := []LogEvent{}
+	for i := len(r.logEvents) - 1; i >= 0; i-- {
+		e := r.logEvents[i]
+		if f.SessionID != "" && e.SessionID != f.SessionID {
+			continue
+		}
+		if levelRank[e.Level] < minRank {
+			continue
+		}
+		out = append(out, e)
+		if f.Limit > 0 && len(out) >= f.Limit {
+			break
+		}
+	}
+	return out
+}
+
+// ValidLogLevel seviye filtresinin geçerli olup olmadığını döner (boş: hepsi)
+func ValidLogLevel(level string) bool {
+	_, ok := levelRank[level]
+	return ok || level == ""
+}
+
+// OpenSessionLog output dizininde sessions/vgbot_sessions_<ts>.jsonl dosyasını açar; sonraki her ziyaretin
+// adımları dosyaya yapılandırılmış olay olarak eklenir. Dosya Finalize ile kapatılır.
+func (r *Reporter) OpenSessionLog() (string, error) {
+	l, path, err := createJSONL(filepath.Join(r.outputDir, "sessions"),
+		fmt.Sprintf("vgbot_sessions_%s.jsonl", time.Now().Format("20060102_150405")))
+	if err != nil {
+		return "", err
+	}
+
+	r.mu.Lock()
+	prev := r.sessionLog
+	r.sessionLog = l
+	r.mu.Unlock()
+	if prev != nil {
+		prev.close()
+	}
+	return path, nil
+}
@@ -0,0 +1,69 @@
+package reporter
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSessionLogEvents(t *testing.T) {
+	dir := t.TempDir()
+	r := New(dir, "json", "example.com")
+	path, err := r.OpenSessionLog()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Dir(path) != filepath.Join(dir, "sessions") {
+		t.Errorf("session log path = %s", path)
+	}
+	start := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	r.RecordTimeline(SessionTimeline{SessionID: "s1", URL: "https://example.com/", Start: start, Proxy: "1.2.3.4:8080",
+		Steps: []TimelineStep{
+			{AtMs: 0, Step: "navigate", OK: true},
+			{AtMs: 800, Step: "analytics", Detail: "missing", OK: false},
+			{AtMs: 1500, Step: "exit", Detail: "200", OK: true},
+		}})
+	r.RecordTimeline(SessionTimeline{SessionID: "s2", URL: "https://example.com/x", Start: start,
+		Steps: []TimelineStep{{AtMs: 0, Step: "exit", Detail: "timeout", OK: false}}})
+
+	all := r.LogEvents(LogFilter{})
+	if len(all) != 4 || all[0].SessionID != "s2" || all[0].Level != LevelError {
+		t.Fatalf("events = %+v", all)
+	}
+	s1 := r.LogEvents(LogFilter{SessionID: "s1"})
+	if len(s1) != 3 {
+		t.Fatalf("s1 events = %d", len(s1))
+	}
+	nav := s1[2]
+	if nav.Phase != "navigate" || nav.DurationMs != 800 || nav.Proxy != "1.2.3.4:8080" || nav.VisitID == 0 || !nav.Time.Equal(start) {
+		t.Errorf("navigate event = %+v", nav)
+	}
+	if warn := r.LogEvents(LogFilter{Level: LevelWarn}); len(warn) != 2 {
+		t.Errorf("warn+ events = %+v", warn)
+	}
+	if limited := r.LogEvents(LogFilter{Limit: 1}); len(limited) != 1 {
+		t.Errorf("limit: %d events", len(limited))
+	}
+
+	r.Finalize()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	n := 0
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		var e LogEvent
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			t.Fatalf("line %q: %v", sc.Text(), err)
+		}
+		n++
+	}
+	if n != 4 {
+		t.Errorf("file lines = %d, want 4", n)
+	}
+}
@@ -0,0 +1,190 @@
+package reporter
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"vgbot/pkg/i18n"
+)
+
+// SLA metrikleri: gecikmeler ms, oranlar yüzde, throughput hit/dakika
+var slaMetrics = map[string]string{
+	"avg_latency":        "ms",
+	"p50_latency":        "ms",
+	"p95_latency":        "ms",
+	"p99_latency":        "ms",
+	"max_latency":        "ms",
+	"error_rate":         "%",
+	"availability":       "%",
+	"throughput_per_min": "",
+}
+
+// Assertion çalıştırma sonunda kontrol edilen tek SLA koşulu (ör. "p95_latency < 800ms")
+type Assertion struct {
+	Metric    string  `json:"metric"`
+	Op        string  `json:"op"` // <, <=, >, >=
+	Threshold float64 `json:"threshold"`
+}
+
+func (a Assertion) String() string {
+	return fmt.Sprintf("%s %s %s%s", a.Metric, a.Op, strconv.FormatFloat(a.Threshold, 'f', -1, 64), slaMetrics[a.Metric])
+}
+
+// AssertionResult koşulun ölçülen değer ve sonucu
+type AssertionResult struct {
+	Assertion string  `json:"assertion"`
+	Actual    float64 `json:"actual"`
+	Unit      string  `json:"unit,omitempty"`
+	Passed    bool    `json:"passed"`
+}
+
+// SLAResult tüm koşulların özeti; bir koşul bile başarısızsa Passed false olur
+type SLAResult struct {
+	Passed  bool              `json:"passed"`
+	Results []AssertionResult `json:"results"`
+}
+
+// ParseAssertion "metrik op eşik[birim]" biçimindeki koşulu çözer: "p95_latency<800ms", "error_rate < 1%",
+// "availability>=99.5%". Gecikme eşiği "s" ile saniye olarak da yazılabilir.
+func ParseAssertion(spec string) (Assertion, error) {
+	s := strings.TrimSpace(spec)
+	i := strings.IndexAny(s, "<>")
+	if i <= 0 {
+		return Assertion{}, fmt.Errorf("assertion metrik op eşik biçiminde olmalı (ör. p95_latency<800ms): %q", spec)
+	}
+	a := Assertion{Metric: strings.TrimSpace(s[:i]), Op: s[i : i+1]}
+	rest := s[i+1:]
+	if strings.HasPrefix(rest, "=") {
+		a.Op += "="
+		rest = rest[1:]
+	}
+	unit, ok := slaMetrics[a.Metric]
+	if !ok {
+		return Assertion{}, fmt.Errorf("assertion bilinmeyen metrik %q (p50/p95/p99/avg/max_latency, error_rate, availability, throughput_per_min)", a.Metric)
+	}
+
+	val := strings.TrimSpace(rest)
+	scale := 1.0
+	switch {
+	case unit == "ms" && strings.HasSuffix(val, "ms"):
+		val = strings.TrimSuffix(val, "ms")
+	case unit == "ms" && strings.HasSuffix(val, "s"):
+		val, scale = strings.TrimSuffix(val, "s"), 1000
+	case unit == "%":
+		val = strings.TrimSuffix(val, "%")
+	}
+	n, err := strconv.ParseFloat(strings.TrimSpace(val), 64)
+	if err != nil || n < 0 {
+		return Assertion{}, fmt.Errorf("assertion geçersiz eşik: %q", spec)
+	}
+	a.Threshold = n * scale
+	return a, nil
+}
+
+// ParseAssertions koşul listesini çözer; ilk hatada durur
+func ParseAssertions(specs []string) ([]Assertion, error) {
+	out := make([]Assertion, 0, len(specs))
+	for _, spec := range specs {
+		a, err := ParseAssertion(spec)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, a)
+	}
+	return out, nil
+}
+
+func (a Assertion) check(actual float64) bool {
+	switch a.Op {
+	case "<":
+		return actual < a.Threshold
+	case "<=":
+		return actual <= a.Threshold
+	case ">":
+		return actual > a.Threshold
+	default:
+		return actual >= a.Threshold
+	}
+}
+
+// slaValue metriğin çalıştırmadaki değerini hesaplar; latencies başarılı hit'lerin sıralı yanıt süreleri
+func slaValue(metric string, m Metrics, latencies []int64) float64 {
+	switch metric {
+	case "avg_latency":
+		return m.AvgResponseTime
+	case "p50_latency", "p95_latency", "p99_latency":
+		if len(latencies) == 0 {
+			return 0
+		}
+		p, _ := strconv.Atoi(metric[1:3])
+		return float64(percentile(latencies, p))
+	case "max_latency":
+		return float64(m.MaxResponseTime)
+	case "error_rate", "availability":
+		if m.TotalHits == 0 {
+			return 0
+		}
+		if metric == "error_rate" {
+			return float64(m.FailedHits) / float64(m.TotalHits) * 100
+		}
+		return float64(m.SuccessHits) / float64(m.TotalHits) * 100
+	default:
+		if d := m.EndTime.Sub(measuredStart(m)).Minutes(); d > 0 {
+			return float64(m.TotalHits) / d
+		}
+		return 0
+	}
+}
+
+// evaluateSLA koşulları metriklere uygular. Hiç hit yoksa hiçbir koşul geçmez (boş çalıştırma CI'da başarısız sayılır).
+func evaluateSLA(assertions []Assertion, m Metrics, latencies []int64) SLAResult {
+	sorted := append([]int64(nil), latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	res := SLAResult{Passed: true, Results: make([]AssertionResult, 0, len(assertions))}
+	for _, a := range assertions {
+		actual := slaValue(a.Metric, m, sorted)
+		ok := m.TotalHits > 0 && a.check(actual)
+		res.Results = append(res.Results, AssertionResult{Assertion: a.String(), Actual: actual, Unit: slaMetrics[a.Metric], Passed: ok})
+		if !ok {
+			res.Passed = false
+		}
+	}
+	return res
+}
+
+// SetAssertions çalıştırma sonunda (Finalize) değerlendirilecek SLA koşullarını ayarlar.
+// Koşul varsa yüzdelikler için başarılı hit'lerin yanıt süreleri ayrıca tutulur.
+func (r *Reporter) SetAssertions(assertions []Assertion) {
+	r.mu.Lock()
+	r.assertions = assertions
+	r.mu.Unlock()
+}
+
+// SLA değerlendirilen koşulların sonucunu döner (koşul yoksa veya Finalize çağrılmadıysa nil)
+func (r *Reporter) SLA() *SLAResult {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.metrics.SLA
+}
+
+// logSLA koşul sonuçlarını ve özeti loglar
+func (r *Reporter) logSLA(res SLAResult) {
+	passed := 0
+	for _, ar := range res.Results {
+		mark := "✅"
+		if ar.Passed {
+			passed++
+		} else {
+			mark = "❌"
+		}
+		r.LogT(i18n.MsgSLAAssertion, mark, ar.Assertion, ar.Actual, ar.Unit)
+	}
+	if res.Passed {
+		r.LogT(i18n.MsgSLAPassed, passed, len(res.Results))
+	} else {
+		r.LogT(i18n.MsgSLAFailed, passed, len(res.Results))
+	}
+}
@@ -0,0 +1,67 @@
+package reporter
+
+import "testing"
+
+func TestParseAssertion(t *testing.T) {
+	cases := map[string]Assertion{
+		"p95_latency<800ms":       {Metric: "p95_latency", Op: "<", Threshold: 800},
+		" p99_latency <= 1.5s ":   {Metric: "p99_latency", Op: "<=", Threshold: 1500},
+		"error_rate < 1%":         {Metric: "error_rate", Op: "<", Threshold: 1},
+		"availability>=99.5%":     {Metric: "availability", Op: ">=", Threshold: 99.5},
+		"throughput_per_min > 30": {Metric: "throughput_per_min", Op: ">", Threshold: 30},
+	}
+	for spec, want := range cases {
+		got, err := ParseAssertion(spec)
+		if err != nil || got != want {
+			t.Errorf("ParseAssertion(%q) = %+v, %v; want %+v", spec, got, err, want)
+		}
+	}
+	for _, bad := range []string{"", "p95_latency", "<800", "p90_latency<800", "error_rate<abc", "error_rate<-1%", "p95_latency=800"} {
+		if _, err := ParseAssertion(bad); err == nil {
+			t.Errorf("ParseAssertion(%q): want error", bad)
+		}
+	}
+}
+
+func TestFinalizeEvaluatesSLA(t *testing.T) {
+	as, err := ParseAssertions([]string{"p95_latency<800ms", "error_rate<1%", "availability>=90%"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := New(t.TempDir(), "json", "example.com")
+	r.SetAssertions(as)
+	for i := 1; i <= 19; i++ {
+		r.Record(HitRecord{URL: "https://example.com/", ResponseTime: int64(i * 10)})
+	}
+	r.Record(HitRecord{URL: "https://example.com/", Error: "timeout"})
+	r.Finalize()
+
+	sla := r.SLA()
+	if sla == nil || sla.Passed || len(sla.Results) != 3 {
+		t.Fatalf("sla = %+v", sla)
+	}
+	want := []struct {
+		actual float64
+		passed bool
+	}{{190, true}, {5, false}, {95, true}}
+	for i, w := range want {
+		if res := sla.Results[i]; res.Actual != w.actual || res.Passed != w.passed {
+			t.Errorf("result %d = %+v, want actual %v passed %v", i, res, w.actual, w.passed)
+		}
+	}
+	if r.GetMetrics().SLA != sla {
+		t.Error("metrics.SLA not set")
+	}
+}
+
+func TestSLAEmptyRunFails(t *testing.T) {
+	r := New(t.TempDir(), "json", "example.com")
+	r.SetAssertions([]Assertion{{Metric: "error_rate", Op: "<", Threshold: 1}})
+	r.Finalize()
+	if sla := r.SLA(); sla == nil || sla.Passed {
+		t.Fatalf("empty run sla = %+v", sla)
+	}
+	if New(t.TempDir(), "json", "example.com").SLA() != nil {
+		t.Error("SLA without assertions should be nil")
+	}
+}
@@ -0,0 +1,77 @@
+package reporter
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// maxTimelines bellekte tutulan son oturum zaman çizelgesi sayısı
+const maxTimelines = 50
+
+// TimelineStep ziyaret içindeki tek adım (navigate, analytics, scroll, exit...)
+type TimelineStep struct {
+	AtMs   int64  `json:"at_ms"` // Ziyaret başlangıcından itibaren
+	Step   string `json:"step"`
+	Detail string `json:"detail,omitempty"`
+	OK     bool   `json:"ok"`
+}
+
+// SessionTimeline tek bir ziyaretin adım adım zaman çizelgesi
+type SessionTimeline struct {
+	ID        int64          `json:"id"`
+	SessionID string         `json:"session_id,omitempty"`
+	URL       string         `json:"url"`
+	Start     time.Time      `json:"start"`
+	UserAgent string         `json:"user_agent,omitempty"`
+	Proxy     string         `json:"proxy,omitempty"`
+	Success   bool           `json:"success"`
+	Steps     []TimelineStep `json:"steps"`
+}
+
+// TimelineCallback her ziyaret zaman çizelgesi tamamlandığında çağrılır (dashboard akışı için)
+type TimelineCallback func(t SessionTimeline)
+
+var timelineSeq int64
+
+// SetTimelineCallback zaman çizelgesi callback'ini ayarlar (server tarafından çağrılır)
+func (r *Reporter) SetTimelineCallback(cb TimelineCallback) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.timelineCallback = cb
+}
+
+// RecordTimeline ziyaretin zaman çizelgesini son maxTimelines kayıt içinde saklar ve callback'e iletir
+func (r *Reporter) RecordTimeline(t SessionTimeline) {
+	t.ID = atomic.AddInt64(&timelineSeq, 1)
+	r.mu.Lock()
+	if len(r.timelines) >= maxTimelines {
+		r.timelines = append(r.timelines[:0], r.timelines[1:]...)
+	}
+	r.timelines = append(r.timelines, t)
+	events := timelineEvents(t)
+	r.addLogEvents(events)
+	cb, sl := r.timelineCallback, r.sessionLog
+	r.mu.Unlock()
+
+	if sl != nil {
+		for _, e := range events {
+			sl.write(e)
+		}
+	}
+
+	r.emit(sessionEvent(t))
+	if cb != nil {
+		cb(t)
+	}
+}
+
+// Timelines son zaman çizelgelerini en yeniden eskiye döner
+func (r *Reporter) Timelines() []SessionTimeline {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]SessionTimeline, len(r.timelines))
+	for i, t := range r.timelines {
+		out[len(r.timelines)-1-i] = t
+	}
+	return out
+}
@@ -0,0 +1,23 @@
+package reporter
+
+import "testing"
+
+func TestTimelinesKeepsNewestFirst(t *testing.T) {
+	r := New(t.TempDir(), "json", "example.com")
+	var streamed int
+	r.SetTimelineCallback(func(SessionTimeline) { streamed++ })
+
+	for i := 0; i < maxTimelines+5; i++ {
+		r.RecordTimeline(SessionTimeline{URL: "https://example.com/" + string(rune('a'+i%26)), Steps: []TimelineStep{{Step: "navigate", OK: true}}})
+	}
+	got := r.Timelines()
+	if len(got) != maxTimelines {
+		t.Fatalf("len = %d, want %d", len(got), maxTimelines)
+	}
+	if got[0].ID <= got[1].ID {
+		t.Fatalf("timelines not newest first: %d, %d", got[0].ID, got[1].ID)
+	}
+	if streamed != maxTimelines+5 {
+		t.Fatalf("callback calls = %d", streamed)
+	}
+}
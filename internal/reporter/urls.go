@@ -0,0 +1,55 @@
+package reporter
+
+import "sort"
+
+// urlBreakdownLimit raporlardaki "URL başına hit" tablosunun satır sayısı
+const urlBreakdownLimit = 25
+
+// URLStats bir URL'nin hit sayıları (landing ağırlıklarının gerçekleşen payını görmek için)
+type URLStats struct {
+	URL     string `json:"url"`
+	Hits    int    `json:"hits"`
+	Success int    `json:"success"`
+	Failed  int    `json:"failed"`
+}
+
+// addURLHit hit'i URL'nin sayımına ekler
+func addURLHit(m map[string]URLStats, h HitRecord) {
+	if h.URL == "" {
+		return
+	}
+	s := m[h.URL]
+	s.URL = h.URL
+	s.Hits++
+	if h.Error == "" {
+		s.Success++
+	} else {
+		s.Failed++
+	}
+	m[h.URL] = s
+}
+
+// topURLs hit sayısına göre en çok ziyaret edilen n URL'yi döner (n <= 0: tümü)
+func topURLs(m map[string]URLStats, n int) []URLStats {
+	out := make([]URLStats, 0, len(m))
+	for _, s := range m {
+		out = append(out, s)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Hits != out[j].Hits {
+			return out[i].Hits > out[j].Hits
+		}
+		return out[i].URL < out[j].URL
+	})
+	if n > 0 && len(out) > n {
+		out = out[:n]
+	}
+	return out
+}
+
+// TopURLs hit sayısına göre en çok ziyaret edilen n URL'yi döner
+func (r *Reporter) TopURLs(n int) []URLStats {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return topURLs(r.metrics.URLHits, n)
+}
@@ -0,0 +1,57 @@
+package reporter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestURLHitsBreakdown(t *testing.T) {
+	r := New(t.TempDir(), "json", "example.com")
+	r.Record(HitRecord{URL: "https://example.com/pricing", StatusCode: 200})
+	r.Record(HitRecord{URL: "https://example.com/pricing", StatusCode: 200})
+	r.Record(HitRecord{URL: "https://example.com/pricing", Error: "timeout"})
+	r.Record(HitRecord{URL: "https://example.com/", StatusCode: 200})
+	r.Record(HitRecord{URL: "https://example.com" + NotFoundPathPrefix + "0001", StatusCode: 404})
+
+	got := r.TopURLs(0)
+	want := []URLStats{
+		{URL: "https://example.com/pricing", Hits: 3, Success: 2, Failed: 1},
+		{URL: "https://example.com/", Hits: 1, Success: 1},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("TopURLs = %+v", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("TopURLs[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+	if top := r.TopURLs(1); len(top) != 1 || top[0].URL != "https://example.com/pricing" {
+		t.Errorf("TopURLs(1) = %+v", top)
+	}
+}
+
+func TestHTMLReportEscapesCrawledURLs(t *testing.T) {
+	r := New(t.TempDir(), "html", "example.com")
+	evil := `https://example.com/?q="><script>alert(1)</script>`
+	r.Record(HitRecord{URL: evil, StatusCode: 200, Requests: 1, Bytes: 1 << 10})
+	r.Finalize()
+
+	path := filepath.Join(t.TempDir(), "report.html")
+	if err := NewHTMLReporter(r.GetMetrics(), []HitRecord{{URL: evil, StatusCode: 200}}, "example.com").GenerateReport(path); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	html := string(data)
+	if strings.Contains(html, "<script>alert(1)") {
+		t.Fatal("crawled URL rendered unescaped")
+	}
+	if !strings.Contains(html, "&lt;script&gt;alert(1)") {
+		t.Error("escaped URL missing from URL tables")
+	}
+	if !strings.Contains(html, "background: #0f172a") || !strings.Contains(html, "const statusData = {") {
+		t.Error("branding colours or chart data mangled by escaping")
+	}
+}
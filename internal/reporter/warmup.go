@@ -0,0 +1,54 @@
+package reporter
+
+import "time"
+
+// WarmupStage metrik zaman çizelgesinde ısınma penceresinin aşama adı
+const WarmupStage = "warm-up"
+
+// WarmupStats ısınma penceresinde çalıştırılan hit'ler. Bu hit'ler kayıtlarda işaretli durur ama
+// başarı oranı, gecikme, URL/sayfa dağılımları, yük seviyeleri ve SLA koşullarına karışmaz.
+type WarmupStats struct {
+	DurationSec float64   `json:"duration_sec"`
+	Until       time.Time `json:"until"`
+	Hits        int       `json:"hits"`
+	SuccessHits int       `json:"success_hits"`
+	FailedHits  int       `json:"failed_hits"`
+}
+
+func (w *WarmupStats) add(h HitRecord) {
+	w.Hits++
+	if h.Error == "" {
+		w.SuccessHits++
+	} else {
+		w.FailedHits++
+	}
+}
+
+// StartWarmup şimdiden itibaren d süresince kaydedilen hit'leri istatistik dışı tutar (d <= 0 kapalı)
+func (r *Reporter) StartWarmup(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	r.mu.Lock()
+	r.metrics.Warmup = &WarmupStats{DurationSec: d.Seconds(), Until: time.Now().Add(d)}
+	r.mu.Unlock()
+}
+
+// InWarmup ısınma penceresinin sürüp sürmediğini döner
+func (r *Reporter) InWarmup() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.inWarmupLocked(time.Now())
+}
+
+func (r *Reporter) inWarmupLocked(t time.Time) bool {
+	return r.metrics.Warmup != nil && t.Before(r.metrics.Warmup.Until)
+}
+
+// measuredStart istatistiklerin başladığı an: ısınma varsa pencerenin sonu
+func measuredStart(m Metrics) time.Time {
+	if m.Warmup != nil && m.Warmup.Until.After(m.StartTime) {
+		return m.Warmup.Until
+	}
+	return m.StartTime
+}
@@ -0,0 +1,53 @@
+package reporter
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWarmupHitsExcludedFromStats(t *testing.T) {
+	as, err := ParseAssertions([]string{"error_rate<1%", "max_latency<500ms"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := New(t.TempDir(), "json", "example.com")
+	r.SetAssertions(as)
+	r.StartWarmup(time.Minute)
+	if !r.InWarmup() {
+		t.Fatal("InWarmup = false right after StartWarmup")
+	}
+
+	now := time.Now()
+	r.Record(HitRecord{Timestamp: now, URL: "https://example.com/", ResponseTime: 3000})
+	r.Record(HitRecord{Timestamp: now, URL: "https://example.com/", Error: "timeout"})
+	later := now.Add(2 * time.Minute)
+	r.Record(HitRecord{Timestamp: later, URL: "https://example.com/", StatusCode: 200, ResponseTime: 100})
+	r.Finalize()
+
+	m := r.GetMetrics()
+	if m.TotalHits != 1 || m.SuccessHits != 1 || m.FailedHits != 0 || m.MaxResponseTime != 100 {
+		t.Errorf("metrics = total %d success %d failed %d max %d", m.TotalHits, m.SuccessHits, m.FailedHits, m.MaxResponseTime)
+	}
+	if m.URLHits["https://example.com/"].Hits != 1 {
+		t.Errorf("URLHits = %+v", m.URLHits)
+	}
+	if w := m.Warmup; w == nil || w.Hits != 2 || w.SuccessHits != 1 || w.FailedHits != 1 || w.DurationSec != 60 {
+		t.Errorf("Warmup = %+v", w)
+	}
+	if sla := r.SLA(); sla == nil || !sla.Passed {
+		t.Errorf("sla = %+v, warm-up hits must not count", sla)
+	}
+	recs := r.Records()
+	if len(recs) != 3 || !recs[0].Warmup || !recs[1].Warmup || recs[2].Warmup {
+		t.Errorf("records warm-up flags = %+v", recs)
+	}
+}
+
+func TestWarmupDisabled(t *testing.T) {
+	r := New(t.TempDir(), "json", "example.com")
+	r.StartWarmup(0)
+	r.Record(HitRecord{URL: "https://example.com/", StatusCode: 200})
+	if r.InWarmup() || r.GetMetrics().Warmup != nil || r.GetMetrics().TotalHits != 1 {
+		t.Errorf("metrics = %+v", r.GetMetrics())
+	}
+}
@@ -0,0 +1,87 @@
+package reporter
+
+import (
+	"fmt"
+	"sort"
+)
+
+// heaviestPagesLimit raporlardaki "en ağır sayfalar" tablosunun satır sayısı
+const heaviestPagesLimit = 10
+
+// PageWeight bir sayfanın ziyaretlerdeki toplam kaynak ağırlığı
+type PageWeight struct {
+	URL      string `json:"url"`
+	Visits   int    `json:"visits"`    // Ağırlık ölçülen ziyaret sayısı
+	Requests int    `json:"requests"`  // Tüm ziyaretlerdeki toplam istek
+	Bytes    int64  `json:"bytes"`     // Tüm ziyaretlerde aktarılan toplam bayt
+	MaxBytes int64  `json:"max_bytes"` // Tek ziyarette aktarılan en fazla bayt
+}
+
+// AvgBytes ziyaret başına ortalama aktarılan bayt
+func (p PageWeight) AvgBytes() int64 {
+	if p.Visits == 0 {
+		return 0
+	}
+	return p.Bytes / int64(p.Visits)
+}
+
+// AvgRequests ziyaret başına ortalama istek sayısı
+func (p PageWeight) AvgRequests() float64 {
+	if p.Visits == 0 {
+		return 0
+	}
+	return float64(p.Requests) / float64(p.Visits)
+}
+
+// addPageWeight hit'in ağırlığını sayfanın toplamına ekler (ölçüm yapılmamış hit'ler atlanır)
+func addPageWeight(m map[string]PageWeight, h HitRecord) {
+	if h.Requests == 0 || h.URL == "" {
+		return
+	}
+	p := m[h.URL]
+	p.URL = h.URL
+	p.Visits++
+	p.Requests += h.Requests
+	p.Bytes += h.Bytes
+	if h.Bytes > p.MaxBytes {
+		p.MaxBytes = h.Bytes
+	}
+	m[h.URL] = p
+}
+
+// heaviestPages ziyaret başına ortalama aktarılan bayta göre en ağır n sayfayı döner
+func heaviestPages(m map[string]PageWeight, n int) []PageWeight {
+	out := make([]PageWeight, 0, len(m))
+	for _, p := range m {
+		out = append(out, p)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].AvgBytes() != out[j].AvgBytes() {
+			return out[i].AvgBytes() > out[j].AvgBytes()
+		}
+		return out[i].URL < out[j].URL
+	})
+	if n > 0 && len(out) > n {
+		out = out[:n]
+	}
+	return out
+}
+
+// HeaviestPages ziyaret başına ortalama ağırlığa göre en ağır n sayfayı döner
+func (r *Reporter) HeaviestPages(n int) []PageWeight {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return heaviestPages(r.metrics.PageWeights, n)
+}
+
+// formatBytes bayt değerini okunur biçimde döner (KB/MB)
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
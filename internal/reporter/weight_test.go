@@ -0,0 +1,45 @@
+package reporter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestHeaviestPagesByAverageWeight(t *testing.T) {
+	r := New(t.TempDir(), "json", "example.com")
+	r.Record(HitRecord{URL: "https://example.com/", StatusCode: 200, Requests: 20, Bytes: 400 << 10})
+	r.Record(HitRecord{URL: "https://example.com/", StatusCode: 200, Requests: 10, Bytes: 200 << 10})
+	r.Record(HitRecord{URL: "https://example.com/gallery", StatusCode: 200, Requests: 80, Bytes: 3 << 20})
+	r.Record(HitRecord{URL: "https://example.com/light", StatusCode: 200, Requests: 2, Bytes: 1 << 10})
+	// Ölçüm yapılmamış hit (ör. cluster sonucu) sayfa ağırlığına girmez
+	r.Record(HitRecord{URL: "https://example.com/unmeasured", StatusCode: 200})
+
+	got := r.HeaviestPages(2)
+	if len(got) != 2 || got[0].URL != "https://example.com/gallery" || got[1].URL != "https://example.com/" {
+		t.Fatalf("heaviest = %+v", got)
+	}
+	home := got[1]
+	if home.Visits != 2 || home.AvgBytes() != 300<<10 || home.MaxBytes != 400<<10 || home.AvgRequests() != 15 {
+		t.Fatalf("home weight = %+v", home)
+	}
+	if len(r.HeaviestPages(0)) != 3 {
+		t.Fatalf("unmeasured page must be skipped: %+v", r.HeaviestPages(0))
+	}
+}
+
+func TestHTMLReportHeaviestPagesTable(t *testing.T) {
+	r := New(t.TempDir(), "html", "example.com")
+	r.Record(HitRecord{URL: "https://example.com/gallery", StatusCode: 200, Requests: 80, Bytes: 3 << 20})
+	r.Finalize()
+
+	path := filepath.Join(t.TempDir(), "report.html")
+	if err := NewHTMLReporter(r.GetMetrics(), nil, "example.com").GenerateReport(path); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), "Heaviest Pages") || !strings.Contains(string(data), "3.0 MB") {
+		t.Fatal("heaviest pages table missing from HTML report")
+	}
+}
@@ -0,0 +1,63 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// API sürümü: /api/v1/... kanonik yollardır; /api/... eski istemciler için takma ad olarak kalır
+const (
+	APIVersion       = "1"
+	APIVersionHeader = "X-API-Version" // İstemci isteği sürümü, sunucu yanıtın sürümünü bildirir
+	apiV1Prefix      = "/api/v1/"
+)
+
+// supportedAPIVersions sunucunun yanıt verebildiği sürümler
+var supportedAPIVersions = []string{APIVersion}
+
+// apiVersioning /api/v1/ isteklerini mevcut handler'lara yönlendirir ve X-API-Version başlığını müzakere eder.
+// Desteklenmeyen sürüm isteyen istemciye 406 ve desteklenen sürümler döner.
+func apiVersioning(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/api/") {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if v := strings.TrimPrefix(strings.TrimSpace(r.Header.Get(APIVersionHeader)), "v"); v != "" && v != APIVersion {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotAcceptable)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success":   false,
+				"error":     "Desteklenmeyen API sürümü: " + v,
+				"supported": supportedAPIVersions,
+			})
+			return
+		}
+		w.Header().Set(APIVersionHeader, APIVersion)
+		if strings.HasPrefix(r.URL.Path, apiV1Prefix) {
+			r2 := new(http.Request)
+			*r2 = *r
+			u := *r.URL
+			u.Path = "/api/" + strings.TrimPrefix(r.URL.Path, apiV1Prefix)
+			u.RawPath = ""
+			r2.URL = &u
+			r = r2
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// handleAPIVersion mevcut ve desteklenen API sürümlerini döner
+func (s *Server) handleAPIVersion(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", 405)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"version":   APIVersion,
+		"supported": supportedAPIVersions,
+		"prefix":    apiV1Prefix,
+	})
+}
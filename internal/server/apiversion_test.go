@@ -0,0 +1,39 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIVersioning(t *testing.T) {
+	var gotPath string
+	h := apiVersioning(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+	}))
+
+	for path, want := range map[string]string{
+		"/api/v1/status":         "/api/status",
+		"/api/status":            "/api/status",
+		"/api/v1/wizard/analyze": "/api/wizard/analyze",
+		"/index.html":            "/index.html",
+	} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if gotPath != want {
+			t.Errorf("%s routed to %s, want %s", path, gotPath, want)
+		}
+		if isAPI := want != "/index.html"; isAPI != (rec.Header().Get(APIVersionHeader) == APIVersion) {
+			t.Errorf("%s version header = %q", path, rec.Header().Get(APIVersionHeader))
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/status", nil)
+	req.Header.Set(APIVersionHeader, "2")
+	rec := httptest.NewRecorder()
+	gotPath = ""
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotAcceptable || gotPath != "" {
+		t.Fatalf("v2 request = %d, routed to %q", rec.Code, gotPath)
+	}
+}
@@ -0,0 +1,434 @@
+package server
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"vgbot/internal/browser"
+	"vgbot/internal/config"
+	"vgbot/pkg/antidetect"
+	"vgbot/pkg/scheduler"
+)
+
+// backupFormatVersion yedek arşivinin biçim sürümü (geri yüklemede kontrol edilir)
+const backupFormatVersion = 1
+
+// maxRestoreSize /api/restore gövdesinin üst sınırı
+const maxRestoreSize = 512 << 20
+
+// Arşiv içindeki sabit yollar
+const (
+	backupManifestName = "manifest.json"
+	backupConfigName   = "config.json"
+	backupJobsName     = "scheduler_jobs.json"
+	backupClustersName = "keyword_clusters.json"
+	backupReportsName  = "reports/index.json"
+	backupProfilesDir  = "profiles/"
+)
+
+// backupManifest arşivin içeriğini tanımlar
+type backupManifest struct {
+	Version  int       `json:"version"`
+	Created  time.Time `json:"created"`
+	Host     string    `json:"host,omitempty"`
+	Domain   string    `json:"target_domain"`
+	Profiles int       `json:"profiles"` // profiles/ altındaki dosya sayısı
+	Jobs     int       `json:"scheduler_jobs"`
+	Clusters int       `json:"keyword_clusters"`
+	Reports  int       `json:"reports"`
+}
+
+// backupReport rapor dizinindeki tek dosyanın kaydı (raporların kendisi arşivlenmez)
+type backupReport struct {
+	Name     string    `json:"name"`
+	Size     int64     `json:"size"`
+	Modified time.Time `json:"modified"`
+}
+
+// writeBackup config, tarayıcı profilleri, scheduler işleri, keyword cluster'ları ve rapor dizinini
+// tek zip arşivine yazar. Cluster'lar config'te değil keyword_clusters_file'da tutulur; dosya yoksa arşive girmez.
+func writeBackup(w io.Writer, cfg *config.Config) error {
+	zw := zip.NewWriter(w)
+	m := backupManifest{Version: backupFormatVersion, Created: time.Now().UTC(), Domain: cfg.TargetDomain}
+	m.Host, _ = os.Hostname()
+
+	data, err := marshalConfigFile(cfg)
+	if err != nil {
+		return err
+	}
+	if err := writeZipFile(zw, backupConfigName, data); err != nil {
+		return err
+	}
+
+	// Scheduler işleri: dosya yoksa boş liste
+	jobs := []*scheduler.Job{}
+	if data, err := os.ReadFile(cfg.SchedulerJobsFile); err == nil {
+		if err := json.Unmarshal(data, &jobs); err != nil {
+			return fmt.Errorf("scheduler işleri okunamadı: %w", err)
+		}
+	}
+	m.Jobs = len(jobs)
+	if err := writeZipJSON(zw, backupJobsName, jobs); err != nil {
+		return err
+	}
+
+	if data, err := os.ReadFile(cfg.KeywordClustersFile); err == nil {
+		var clusters []*antidetect.KeywordCluster
+		if err := json.Unmarshal(data, &clusters); err != nil {
+			return fmt.Errorf("keyword cluster'ları okunamadı: %w", err)
+		}
+		m.Clusters = len(clusters)
+		if err := writeZipFile(zw, backupClustersName, data); err != nil {
+			return err
+		}
+	}
+
+	reports := listBackupReports(cfg.OutputDir)
+	m.Reports = len(reports)
+	if err := writeZipJSON(zw, backupReportsName, reports); err != nil {
+		return err
+	}
+
+	// HTTP önbelleği taşınabilir durum değildir ve büyüktür; arşive alınmaz
+	skip := filepath.Join(cfg.BrowserProfilePath, browser.HTTPCacheDirName)
+	if m.Profiles, err = addDirToZip(zw, cfg.BrowserProfilePath, backupProfilesDir, skip); err != nil {
+		return fmt.Errorf("profiller arşivlenemedi: %w", err)
+	}
+
+	if err := writeZipJSON(zw, backupManifestName, m); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// listBackupReports rapor dizinindeki dosyaları listeler
+func listBackupReports(dir string) []backupReport {
+	reports := []backupReport{}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return reports
+	}
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		reports = append(reports, backupReport{Name: e.Name(), Size: info.Size(), Modified: info.ModTime().UTC()})
+	}
+	return reports
+}
+
+// addDirToZip dir altındaki tüm dosyaları prefix altında arşive ekler (skip dizinleri hariç); dizin yoksa 0 döner
+func addDirToZip(zw *zip.Writer, dir, prefix string, skip ...string) (int, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return 0, nil
+	}
+	n := 0
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		for _, sk := range skip {
+			if d.IsDir() && p == sk {
+				return filepath.SkipDir
+			}
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		dst, err := zw.Create(prefix + filepath.ToSlash(rel))
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(dst, f); err != nil {
+			return err
+		}
+		n++
+		return nil
+	})
+	return n, err
+}
+
+func writeZipFile(zw *zip.Writer, name string, data []byte) error {
+	dst, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = dst.Write(data)
+	return err
+}
+
+func writeZipJSON(zw *zip.Writer, name string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeZipFile(zw, name, data)
+}
+
+// restoredBackup arşivden okunan ve diske yazılmaya hazır durum
+type restoredBackup struct {
+	Manifest backupManifest
+	Config   *config.Config
+	Jobs     []*scheduler.Job
+	Reports  []backupReport
+	clusters []byte // keyword_clusters_file içeriği (eski arşivlerde yoksa nil)
+	profiles []*zip.File
+}
+
+// readBackup arşivi doğrular ve okur; diske bir şey yazmaz
+func readBackup(data []byte) (*restoredBackup, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("geçersiz yedek arşivi: %w", err)
+	}
+	b := &restoredBackup{}
+	files := map[string]*zip.File{}
+	for _, f := range zr.File {
+		if strings.HasPrefix(f.Name, backupProfilesDir) {
+			if !f.FileInfo().Mode().IsRegular() {
+				continue
+			}
+			if _, ok := safeProfilePath(f.Name); !ok {
+				return nil, fmt.Errorf("geçersiz arşiv yolu: %s", f.Name)
+			}
+			b.profiles = append(b.profiles, f)
+			continue
+		}
+		files[f.Name] = f
+	}
+
+	if err := readZipJSON(files[backupManifestName], &b.Manifest); err != nil {
+		return nil, fmt.Errorf("manifest okunamadı: %w", err)
+	}
+	if b.Manifest.Version != backupFormatVersion {
+		return nil, fmt.Errorf("desteklenmeyen yedek sürümü: %d", b.Manifest.Version)
+	}
+	raw, err := readZipBytes(files[backupConfigName])
+	if err != nil {
+		return nil, fmt.Errorf("config okunamadı: %w", err)
+	}
+	if b.Config, err = config.ParseJSON(raw); err != nil {
+		return nil, fmt.Errorf("config okunamadı: %w", err)
+	}
+	if err := readZipJSON(files[backupJobsName], &b.Jobs); err != nil {
+		return nil, fmt.Errorf("scheduler işleri okunamadı: %w", err)
+	}
+	for _, job := range b.Jobs {
+		if _, err := scheduler.NewWindowPlan(job.ActiveWindows, job.BlackoutWindows); err != nil {
+			return nil, fmt.Errorf("iş %s: geçersiz zaman penceresi: %w", job.ID, err)
+		}
+	}
+	if f := files[backupClustersName]; f != nil {
+		var clusters []*antidetect.KeywordCluster
+		raw, err := readZipBytes(f)
+		if err == nil {
+			err = json.Unmarshal(raw, &clusters)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("keyword cluster'ları okunamadı: %w", err)
+		}
+		b.clusters = raw
+	}
+	// Rapor dizini bilgi amaçlıdır; eksikse geri yükleme engellenmez
+	_ = readZipJSON(files[backupReportsName], &b.Reports)
+	return b, nil
+}
+
+// safeProfilePath profiles/ altındaki arşiv yolunu göreli dosya yoluna çevirir (dizin dışına çıkan yolları reddeder)
+func safeProfilePath(name string) (string, bool) {
+	rel := path.Clean(strings.TrimPrefix(name, backupProfilesDir))
+	if rel == "." || path.IsAbs(rel) || rel == ".." || strings.HasPrefix(rel, "../") || strings.Contains(rel, `\`) {
+		return "", false
+	}
+	return filepath.FromSlash(rel), true
+}
+
+// keepInstallPaths geri yüklenen config'e bu kurulumun dosya ve dizin yollarını yazar. Yollar kaynak
+// makineye aittir; aynen alınırsa kill switch, onay ve metrik dosyaları çalışan bileşenlerin
+// kullandığı dosyalardan ayrılır ve işler/profiller başka bir yere yazılırdı.
+func keepInstallPaths(restored, live *config.Config) {
+	restored.OutputDir = live.OutputDir
+	restored.SitemapCacheDir = live.SitemapCacheDir
+	restored.GscCacheDir = live.GscCacheDir
+	restored.BigQueryCredentialsFile = live.BigQueryCredentialsFile
+	restored.BrowserPath = live.BrowserPath
+	restored.BrowserCacheDir = live.BrowserCacheDir
+	restored.BrowserProfilePath = live.BrowserProfilePath
+	restored.SessionStoragePath = live.SessionStoragePath
+	restored.VaultFile = live.VaultFile
+	restored.SchedulerJobsFile = live.SchedulerJobsFile
+	restored.MetricsHistoryFile = live.MetricsHistoryFile
+	restored.KeywordClustersFile = live.KeywordClustersFile
+	restored.KillSwitchFile = live.KillSwitchFile
+	restored.ConsentFile = live.ConsentFile
+	restored.AuditLogFile = live.AuditLogFile
+	restored.WizardSecretFile = live.WizardSecretFile
+	restored.MetricsDBFile = live.MetricsDBFile
+	restored.SerpReportDir = live.SerpReportDir
+}
+
+// restore arşivdeki scheduler işlerini, keyword cluster'larını ve profilleri diske yazar (config'i çağıran kaydeder)
+func (b *restoredBackup) restore() error {
+	cfg := b.Config
+	if dir := filepath.Dir(cfg.SchedulerJobsFile); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+	data, err := json.MarshalIndent(b.Jobs, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(cfg.SchedulerJobsFile, data, 0644); err != nil {
+		return fmt.Errorf("scheduler işleri yazılamadı: %w", err)
+	}
+	if b.clusters != nil && cfg.KeywordClustersFile != "" {
+		if err := os.WriteFile(cfg.KeywordClustersFile, b.clusters, 0644); err != nil {
+			return fmt.Errorf("keyword cluster'ları yazılamadı: %w", err)
+		}
+	}
+
+	for _, f := range b.profiles {
+		rel, _ := safeProfilePath(f.Name)
+		dst := filepath.Join(cfg.BrowserProfilePath, rel)
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return err
+		}
+		data, err := readZipBytes(f)
+		if err != nil {
+			return fmt.Errorf("profil dosyası okunamadı (%s): %w", f.Name, err)
+		}
+		if err := os.WriteFile(dst, data, 0644); err != nil {
+			return fmt.Errorf("profil dosyası yazılamadı (%s): %w", dst, err)
+		}
+	}
+	return nil
+}
+
+func readZipBytes(f *zip.File) ([]byte, error) {
+	if f == nil {
+		return nil, fmt.Errorf("dosya eksik")
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+func readZipJSON(f *zip.File, v interface{}) error {
+	data, err := readZipBytes(f)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// handleBackup tüm uygulama durumunu zip arşivi olarak indirir (kurulumlar arası taşıma için)
+func (s *Server) handleBackup(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", 405)
+		return
+	}
+	s.mu.Lock()
+	cfg := *s.cfg
+	s.mu.Unlock()
+
+	// Arşiv önce belleğe yazılır; hata olursa yarım dosya yerine HTTP hatası döner
+	var buf bytes.Buffer
+	if err := writeBackup(&buf, &cfg); err != nil {
+		log.Printf("[ERROR] Yedek oluşturulamadı: %v", err)
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	name := fmt.Sprintf("vgbot_backup_%s.zip", time.Now().Format("20060102_150405"))
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+name+`"`)
+	w.Write(buf.Bytes())
+}
+
+// handleRestore /api/backup arşivini yükler: config, scheduler işleri, keyword cluster'ları ve profiller
+// geri yazılır. Dosya yolları bu kurulumda kalır (keepInstallPaths).
+func (s *Server) handleRestore(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", 405)
+		return
+	}
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRestoreSize))
+	if err != nil {
+		http.Error(w, "Yedek okunamadı: "+err.Error(), 400)
+		return
+	}
+	b, err := readBackup(data)
+	if err != nil {
+		http.Error(w, err.Error(), 400)
+		return
+	}
+
+	s.mu.Lock()
+	if s.cancel != nil {
+		s.mu.Unlock()
+		http.Error(w, "Simülasyon çalışırken geri yükleme yapılamaz", 409)
+		return
+	}
+	keepInstallPaths(b.Config, s.cfg)
+	s.mu.Unlock()
+
+	if err := b.restore(); err != nil {
+		log.Printf("[ERROR] Geri yükleme başarısız: %v", err)
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	s.mu.Lock()
+	s.cfg = b.Config
+	cfgCopy := *s.cfg
+	s.mu.Unlock()
+	saveConfigToFile(&cfgCopy)
+	if err := s.scheduler.GetStorage().Reload(cfgCopy.SchedulerJobsFile); err != nil {
+		log.Printf("[WARN] Geri yüklenen scheduler işleri okunamadı: %v", err)
+	}
+	if b.clusters != nil {
+		if err := s.reloadKeywordClusters(); err != nil {
+			log.Printf("[WARN] Geri yüklenen keyword cluster'ları okunamadı: %v", err)
+		}
+	}
+	s.applyBrowserFlags()
+	go s.locateBrowser()
+	log.Printf("[INFO] Yedek geri yüklendi (%s, %s): %d profil dosyası, %d iş, %d keyword cluster",
+		b.Manifest.Host, b.Manifest.Created.Format(time.RFC3339), len(b.profiles), len(b.Jobs), b.Manifest.Clusters)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":   "ok",
+		"manifest": b.Manifest,
+		"profiles": len(b.profiles),
+		"jobs":     len(b.Jobs),
+		"clusters": b.Manifest.Clusters,
+		"reports":  b.Reports,
+	})
+}
@@ -0,0 +1,97 @@
+package server
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"vgbot/internal/browser"
+	"vgbot/internal/config"
+)
+
+func TestBackupRoundTrip(t *testing.T) {
+	src := t.TempDir()
+	cfg := config.Config{
+		TargetDomain:        "example.com",
+		Keywords:            []string{"a", "b"},
+		OutputDir:           filepath.Join(src, "reports"),
+		BrowserProfilePath:  filepath.Join(src, "profiles"),
+		SchedulerJobsFile:   filepath.Join(src, "jobs.json"),
+		KeywordClustersFile: filepath.Join(src, "clusters.json"),
+		KillSwitchFile:      filepath.Join(src, "killswitch.json"),
+	}
+	cfg.ApplyDefaults()
+	os.MkdirAll(filepath.Join(cfg.BrowserProfilePath, "p1"), 0755)
+	os.WriteFile(filepath.Join(cfg.BrowserProfilePath, "p1", "cookies.json"), []byte(`[]`), 0644)
+	os.MkdirAll(filepath.Join(cfg.BrowserProfilePath, browser.HTTPCacheDirName, "c1"), 0755)
+	os.WriteFile(filepath.Join(cfg.BrowserProfilePath, browser.HTTPCacheDirName, "c1", "data_0"), []byte("x"), 0644)
+	os.MkdirAll(cfg.OutputDir, 0755)
+	os.WriteFile(filepath.Join(cfg.OutputDir, "vgbot_report_1.json"), []byte(`{}`), 0644)
+	os.WriteFile(cfg.SchedulerJobsFile, []byte(`[{"id":"j1","name":"nightly","active_windows":["09:00-18:00"]}]`), 0644)
+	os.WriteFile(cfg.KeywordClustersFile, []byte(`[{"id":"k1","primary_kw":"seo tools"}]`), 0644)
+
+	var buf bytes.Buffer
+	if err := writeBackup(&buf, &cfg); err != nil {
+		t.Fatal(err)
+	}
+	b, err := readBackup(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Config.TargetDomain != "example.com" || len(b.Config.Keywords) != 2 {
+		t.Fatalf("config = %+v", b.Config)
+	}
+	if b.Manifest.Profiles != 1 || b.Manifest.Jobs != 1 || b.Manifest.Clusters != 1 || len(b.Reports) != 1 {
+		t.Fatalf("manifest = %+v reports = %+v", b.Manifest, b.Reports)
+	}
+
+	// Hedef kurulumun yolları korunur; kaynak makinenin yolları geri yüklenmez
+	dst := t.TempDir()
+	live := config.Config{
+		BrowserProfilePath:  filepath.Join(dst, "profiles"),
+		SchedulerJobsFile:   filepath.Join(dst, "jobs.json"),
+		KeywordClustersFile: filepath.Join(dst, "clusters.json"),
+		KillSwitchFile:      filepath.Join(dst, "killswitch.json"),
+	}
+	live.ApplyDefaults()
+	keepInstallPaths(b.Config, &live)
+	if b.Config.KillSwitchFile != live.KillSwitchFile || b.Config.OutputDir != live.OutputDir {
+		t.Fatalf("install paths not kept: kill switch %q, output %q", b.Config.KillSwitchFile, b.Config.OutputDir)
+	}
+	if err := b.restore(); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := os.ReadFile(filepath.Join(dst, "profiles", "p1", "cookies.json")); err != nil || string(data) != `[]` {
+		t.Fatalf("profile not restored: %q %v", data, err)
+	}
+	if data, err := os.ReadFile(b.Config.SchedulerJobsFile); err != nil || !strings.Contains(string(data), `"j1"`) {
+		t.Fatalf("jobs not restored: %q %v", data, err)
+	}
+	if data, err := os.ReadFile(live.KeywordClustersFile); err != nil || !strings.Contains(string(data), `"k1"`) {
+		t.Fatalf("keyword clusters not restored: %q %v", data, err)
+	}
+}
+
+func TestReadBackupRejectsPathTraversal(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	writeZipJSON(zw, backupManifestName, backupManifest{Version: backupFormatVersion})
+	writeZipFile(zw, backupProfilesDir+"../../etc/passwd", []byte("x"))
+	zw.Close()
+	if _, err := readBackup(buf.Bytes()); err == nil {
+		t.Fatal("expected traversal path to be rejected")
+	}
+}
+
+func TestReadBackupRejectsUnknownVersion(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	writeZipJSON(zw, backupManifestName, backupManifest{Version: backupFormatVersion + 1})
+	zw.Close()
+	if _, err := readBackup(buf.Bytes()); err == nil {
+		t.Fatal("expected unsupported version error")
+	}
+}
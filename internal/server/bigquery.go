@@ -0,0 +1,62 @@
+package server
+
+import (
+	"fmt"
+	"os"
+
+	"vgbot/internal/config"
+	"vgbot/internal/reporter"
+	"vgbot/pkg/googleauth"
+	"vgbot/pkg/i18n"
+)
+
+// attachBigQuery cfg'de BigQueryExport açıksa reporter'a exporter bağlar. scope çalıştırmanın
+// kaynağıdır (kampanya ID'si, "run"); satırların run_id'si buna rastgele bir ek alır.
+// Kimlik bilgisi hatası çalıştırmayı durdurmaz, yalnızca loglanır.
+func attachBigQuery(cfg *config.Config, rep *reporter.Reporter, scope string) {
+	if !cfg.BigQueryExport {
+		return
+	}
+	exp, err := newBigQueryExporter(cfg.BigQueryProject, cfg.BigQueryDataset, cfg.BigQueryTable,
+		cfg.BigQueryCredentialsFile, cfg.GscApiKey, bigQueryRunID(scope))
+	if err != nil {
+		rep.LogT(i18n.MsgBigQueryError, err)
+		return
+	}
+	rep.SetBigQuery(exp)
+	rep.LogT(i18n.MsgBigQueryEnabled, exp.Target())
+}
+
+// bigQueryRunID kapsam ve rastgele baytlardan run_id üretir. Aynı saniyede başlayan kampanyalar
+// veya yeniden başlatmadan sonra sıfırlanan kampanya ID'leri insertId'lerde çakışmaz; çakışan
+// insertId'li satırları BigQuery kopya sayıp atardı.
+func bigQueryRunID(scope string) string {
+	return "vgbot-" + scope + "-" + reporter.NewSessionID()
+}
+
+// newBigQueryExporter Service Account'u dosyadan (yoksa GSC anahtarından) okuyup exporter oluşturur
+func newBigQueryExporter(project, dataset, table, credentialsFile, gscKey, runID string) (*reporter.BigQueryExporter, error) {
+	var data []byte
+	if credentialsFile != "" {
+		b, err := os.ReadFile(credentialsFile)
+		if err != nil {
+			return nil, fmt.Errorf("bigquery: service account dosyası okunamadı: %w", err)
+		}
+		data = b
+	} else if gscKey != "" {
+		data = []byte(gscKey)
+	} else {
+		return nil, fmt.Errorf("bigquery: service account tanımlı değil (dosya veya GSC anahtarı gerekli)")
+	}
+	sa, err := googleauth.ParseServiceAccount(data)
+	if err != nil {
+		return nil, err
+	}
+	return reporter.NewBigQueryExporter(reporter.BigQueryConfig{
+		ProjectID: project,
+		Dataset:   dataset,
+		Table:     table,
+		Account:   sa,
+		RunID:     runID,
+	})
+}
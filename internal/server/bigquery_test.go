@@ -0,0 +1,17 @@
+package server
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBigQueryRunIDUnique(t *testing.T) {
+	a, b := bigQueryRunID("campaign_1"), bigQueryRunID("campaign_1")
+	if !strings.HasPrefix(a, "vgbot-campaign_1-") {
+		t.Fatalf("run id = %q, want campaign scope", a)
+	}
+	// Yeniden başlatmadan sonra aynı kampanya ID'si gelse de insertId'ler çakışmaz
+	if a == b {
+		t.Fatalf("run ids collide: %q", a)
+	}
+}
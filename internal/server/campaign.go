@@ -0,0 +1,378 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"vgbot/internal/config"
+	"vgbot/internal/reporter"
+	"vgbot/internal/simulator"
+)
+
+// Kampanya durumları
+const (
+	campaignIdle     = "idle"
+	campaignRunning  = "running"
+	campaignStopped  = "stopped"
+	campaignFinished = "finished"
+	campaignFailed   = "failed"
+)
+
+// campaign ana simülasyondan bağımsız, kendi config'i, raporlayıcısı ve yaşam döngüsü olan simülasyon
+type campaign struct {
+	id      string
+	name    string
+	cfg     *config.Config
+	rep     *reporter.Reporter // Son çalıştırmanın raporlayıcısı (hiç başlamadıysa nil)
+	run     *runScope          // Çalışıyorsa context ağacı
+	status  string
+	err     string
+	created time.Time
+	started time.Time
+	ended   time.Time
+}
+
+// campaignView kampanyanın API görünümü
+type campaignView struct {
+	ID        string            `json:"id"`
+	Name      string            `json:"name"`
+	Domain    string            `json:"domain"`
+	Status    string            `json:"status"`
+	Error     string            `json:"error,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+	StartedAt *time.Time        `json:"started_at,omitempty"`
+	EndedAt   *time.Time        `json:"ended_at,omitempty"`
+	Metrics   *reporter.Metrics `json:"metrics,omitempty"`
+}
+
+// campaignManager eşzamanlı çalışabilen kampanyaları tutar
+type campaignManager struct {
+	mu    sync.Mutex
+	items map[string]*campaign
+	seq   int
+}
+
+func newCampaignManager() *campaignManager {
+	return &campaignManager{items: make(map[string]*campaign)}
+}
+
+// add yeni kampanyayı kaydeder ve ID atar
+func (m *campaignManager) add(name string, cfg *config.Config) *campaign {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.seq++
+	c := &campaign{
+		id:      fmt.Sprintf("campaign_%d", m.seq),
+		name:    name,
+		cfg:     cfg,
+		status:  campaignIdle,
+		created: time.Now(),
+	}
+	if c.name == "" {
+		c.name = cfg.TargetDomain
+	}
+	m.items[c.id] = c
+	return c
+}
+
+// get ID ile kampanyayı döner (yoksa nil)
+func (m *campaignManager) get(id string) *campaign {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.items[id]
+}
+
+// remove kampanyayı durdurup siler; bulunamazsa false döner
+func (m *campaignManager) remove(id string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c, ok := m.items[id]
+	if !ok {
+		return false
+	}
+	if c.run != nil {
+		c.run.Stop()
+	}
+	delete(m.items, id)
+	return true
+}
+
+// stopAll tüm çalışan kampanyaları durdurur (sunucu kapanışı, kill switch); durdurulan sayıyı döner
+func (m *campaignManager) stopAll() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stopped := 0
+	for _, c := range m.items {
+		if c.run != nil {
+			c.run.Stop()
+			c.run = nil
+			c.status, c.ended = campaignStopped, time.Now()
+			stopped++
+		}
+	}
+	return stopped
+}
+
+// list kampanyaları oluşturulma sırasıyla döner
+func (m *campaignManager) list() []campaignView {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]campaignView, 0, len(m.items))
+	for _, c := range m.items {
+		out = append(out, c.view())
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.Before(out[j].CreatedAt) })
+	return out
+}
+
+// view kampanyanın anlık görünümü; m.mu tutulurken çağrılmalı
+func (c *campaign) view() campaignView {
+	v := campaignView{
+		ID:        c.id,
+		Name:      c.name,
+		Domain:    c.cfg.TargetDomain,
+		Status:    c.status,
+		Error:     c.err,
+		CreatedAt: c.created,
+	}
+	if !c.started.IsZero() {
+		t := c.started
+		v.StartedAt = &t
+	}
+	if !c.ended.IsZero() {
+		t := c.ended
+		v.EndedAt = &t
+	}
+	if c.rep != nil {
+		m := c.rep.GetMetrics()
+		v.Metrics = &m
+	}
+	return v
+}
+
+// campaignConfig mevcut sunucu config'inin kopyası üzerine /api/config ile aynı alanları uygular
+func (s *Server) campaignConfig(base *config.Config, raw json.RawMessage) (*config.Config, error) {
+	cfg := *base
+	if len(raw) > 0 {
+		u, err := decodeConfigUpdate(bytes.NewReader(raw), &cfg)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid JSON: %w", err)
+		}
+		if err := u.validate(); err != nil {
+			return nil, err
+		}
+		u.apply(&cfg)
+	}
+	cfg.ApplyDefaults()
+	cfg.ComputeDerived()
+	if cfg.TargetDomain == "" {
+		return nil, fmt.Errorf("Lütfen hedef domain girin")
+	}
+	return &cfg, nil
+}
+
+// startCampaign kampanya için yeni raporlayıcı ve simülatör kurar ve arka planda çalıştırır
+func (s *Server) startCampaign(c *campaign, locale string) error {
+	if s.kill.engaged() {
+		return errKillSwitch
+	}
+	s.campaigns.mu.Lock()
+	if c.run != nil {
+		s.campaigns.mu.Unlock()
+		return fmt.Errorf("Kampanya zaten çalışıyor")
+	}
+	cfg, name := c.cfg, c.name
+	s.campaigns.mu.Unlock()
+	// Kampanya ve zamanlanmış işler de izin listesine tabidir; onay panelden/CLI'dan verilir
+	if err := s.checkTarget(cfg.TargetDomain, "campaign", "", name); err != nil {
+		return err
+	}
+
+	rep := reporter.NewWithLocale(cfg.OutputDir, cfg.ExportFormat, cfg.TargetDomain, locale)
+	attachBigQuery(cfg, rep, c.id)
+	sim, err := simulator.New(cfg, s.agentLoader, rep, s.livePoolFor(cfg, rep))
+	if err != nil {
+		s.campaigns.mu.Lock()
+		c.status, c.err = campaignFailed, err.Error()
+		s.campaigns.mu.Unlock()
+		return err
+	}
+	s.applyKeywordClusters(sim)
+	rep.SetHitCallback(func(url string, duration time.Duration, success bool, proxy string, errClass string) {
+		s.RecordHit(url, proxy, duration, success, errClass)
+	})
+
+	run := newRunScope()
+	s.campaigns.mu.Lock()
+	if c.run != nil {
+		s.campaigns.mu.Unlock()
+		run.Stop()
+		return fmt.Errorf("Kampanya zaten çalışıyor")
+	}
+	c.rep, c.run = rep, run
+	c.status, c.err = campaignRunning, ""
+	c.started, c.ended = time.Now(), time.Time{}
+	s.campaigns.mu.Unlock()
+
+	prefix := "[" + c.name + "] "
+	logChan, events := rep.LogChan(), rep.Events()
+	run.Go("log-forwarder", func(ctx context.Context) {
+		s.forwardLogs(ctx, logChan, prefix)
+	})
+	run.Go("event-forwarder", func(ctx context.Context) {
+		s.forwardEvents(ctx, events, c.name)
+	})
+	run.Go("simulator", func(ctx context.Context) {
+		err := sim.Run(ctx)
+		run.Stop()
+		s.campaigns.mu.Lock()
+		if c.run == run {
+			c.run = nil
+			c.status, c.ended = campaignFinished, time.Now()
+			if err != nil {
+				c.status, c.err = campaignFailed, err.Error()
+			}
+		}
+		s.campaigns.mu.Unlock()
+	})
+	return nil
+}
+
+// stopCampaign çalışan kampanyayı iptal eder
+func (s *Server) stopCampaign(c *campaign) {
+	s.campaigns.mu.Lock()
+	defer s.campaigns.mu.Unlock()
+	if c.run == nil {
+		return
+	}
+	c.run.Stop()
+	c.run = nil
+	c.status, c.ended = campaignStopped, time.Now()
+}
+
+// campaignRequest kampanya oluşturma/güncelleme gövdesi; config /api/config POST alanlarıyla aynıdır
+type campaignRequest struct {
+	Name   string          `json:"name"`
+	Config json.RawMessage `json:"config"`
+	Start  bool            `json:"start"` // Oluşturduktan/güncelledikten sonra başlat
+	Lang   string          `json:"lang"`
+}
+
+func campaignLocale(lang string) string {
+	if lang == "en" {
+		return "en"
+	}
+	return "tr"
+}
+
+// handleCampaigns GET: kampanya listesi, POST: yeni kampanya (ana config üzerine config alanları)
+func (s *Server) handleCampaigns(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	switch r.Method {
+	case http.MethodGet:
+		json.NewEncoder(w).Encode(map[string]interface{}{"campaigns": s.campaigns.list()})
+	case http.MethodPost:
+		var req campaignRequest
+		if err := json.NewDecoder(io.LimitReader(r.Body, 1<<20)).Decode(&req); err != nil {
+			http.Error(w, "Invalid JSON", 400)
+			return
+		}
+		s.mu.Lock()
+		base := *s.cfg
+		s.mu.Unlock()
+		cfg, err := s.campaignConfig(&base, req.Config)
+		if err != nil {
+			http.Error(w, err.Error(), 400)
+			return
+		}
+		c := s.campaigns.add(strings.TrimSpace(req.Name), cfg)
+		if req.Start {
+			if err := s.startCampaign(c, campaignLocale(req.Lang)); err != nil {
+				writeStartError(w, err, 500)
+				return
+			}
+		}
+		s.campaigns.mu.Lock()
+		v := c.view()
+		s.campaigns.mu.Unlock()
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(v)
+	default:
+		http.Error(w, "Method not allowed", 405)
+	}
+}
+
+// handleCampaign /api/campaigns/{id}[/start|/stop]: GET ayrıntı, PUT config güncelle (dururken),
+// DELETE durdur ve sil, POST start/stop
+func (s *Server) handleCampaign(w http.ResponseWriter, r *http.Request) {
+	id, action, _ := strings.Cut(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/campaigns/"), "/"), "/")
+	c := s.campaigns.get(id)
+	if c == nil {
+		http.Error(w, "Kampanya bulunamadı", 404)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case action == "" && r.Method == http.MethodGet:
+	case action == "" && r.Method == http.MethodPut:
+		var req campaignRequest
+		if err := json.NewDecoder(io.LimitReader(r.Body, 1<<20)).Decode(&req); err != nil {
+			http.Error(w, "Invalid JSON", 400)
+			return
+		}
+		s.campaigns.mu.Lock()
+		running := c.run != nil
+		base := *c.cfg
+		s.campaigns.mu.Unlock()
+		if running {
+			http.Error(w, "Çalışan kampanyanın config'i değiştirilemez; önce durdurun", 409)
+			return
+		}
+		cfg, err := s.campaignConfig(&base, req.Config)
+		if err != nil {
+			http.Error(w, err.Error(), 400)
+			return
+		}
+		s.campaigns.mu.Lock()
+		c.cfg = cfg
+		if name := strings.TrimSpace(req.Name); name != "" {
+			c.name = name
+		}
+		s.campaigns.mu.Unlock()
+		if req.Start {
+			if err := s.startCampaign(c, campaignLocale(req.Lang)); err != nil {
+				writeStartError(w, err, 500)
+				return
+			}
+		}
+	case action == "" && r.Method == http.MethodDelete:
+		s.campaigns.remove(id)
+		json.NewEncoder(w).Encode(map[string]string{"status": "deleted", "id": id})
+		return
+	case action == "start" && r.Method == http.MethodPost:
+		if err := s.startCampaign(c, campaignLocale(r.URL.Query().Get("lang"))); err != nil {
+			writeStartError(w, err, 409)
+			return
+		}
+	case action == "stop" && r.Method == http.MethodPost:
+		s.stopCampaign(c)
+	case action == "start", action == "stop", action == "":
+		http.Error(w, "Method not allowed", 405)
+		return
+	default:
+		http.Error(w, "Not found", 404)
+		return
+	}
+	s.campaigns.mu.Lock()
+	v := c.view()
+	s.campaigns.mu.Unlock()
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,61 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCampaignCRUD(t *testing.T) {
+	cfg := testConfig()
+	s := &Server{cfg: &cfg, campaigns: newCampaignManager()}
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		h := s.handleCampaign
+		if path == "/api/campaigns" {
+			h = s.handleCampaigns
+		}
+		h(rec, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return rec
+	}
+
+	rec := do(http.MethodPost, "/api/campaigns", `{"name":"shop","config":{"target_domain":"shop.example.com","hits_per_minute":5}}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create = %d %s", rec.Code, rec.Body)
+	}
+	var v campaignView
+	json.Unmarshal(rec.Body.Bytes(), &v)
+	if v.Domain != "shop.example.com" || v.Status != campaignIdle || v.Name != "shop" {
+		t.Fatalf("created = %+v", v)
+	}
+	c := s.campaigns.get(v.ID)
+	if c.cfg.HitsPerMinute != 5 || c.cfg.GtagID != cfg.GtagID || s.cfg.TargetDomain != "example.com" {
+		t.Fatalf("campaign config = %+v, server domain = %s", c.cfg, s.cfg.TargetDomain)
+	}
+
+	if rec := do(http.MethodPut, "/api/campaigns/"+v.ID, `{"config":{"target_domain":"blog.example.com"}}`); rec.Code != 200 {
+		t.Fatalf("update = %d %s", rec.Code, rec.Body)
+	}
+	if c.cfg.TargetDomain != "blog.example.com" || c.cfg.HitsPerMinute != 5 {
+		t.Fatalf("updated config = %s, hpm %d", c.cfg.TargetDomain, c.cfg.HitsPerMinute)
+	}
+
+	if rec := do(http.MethodPost, "/api/campaigns", `{"config":{"traffic_marker":"vgbot","traffic_marker_mode":"header"}}`); rec.Code != 400 {
+		t.Errorf("invalid config = %d", rec.Code)
+	}
+	if rec := do(http.MethodGet, "/api/campaigns/"+v.ID+"/bogus", ""); rec.Code != 404 {
+		t.Errorf("unknown action = %d", rec.Code)
+	}
+	if got := s.campaigns.list(); len(got) != 1 || got[0].ID != v.ID {
+		t.Fatalf("list = %+v", got)
+	}
+	if rec := do(http.MethodDelete, "/api/campaigns/"+v.ID, ""); rec.Code != 200 || s.campaigns.get(v.ID) != nil {
+		t.Fatalf("delete = %d", rec.Code)
+	}
+	if rec := do(http.MethodGet, "/api/campaigns/"+v.ID, ""); rec.Code != 404 {
+		t.Errorf("get deleted = %d", rec.Code)
+	}
+}
@@ -0,0 +1,327 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"vgbot/internal/config"
+	"vgbot/internal/reporter"
+	"vgbot/internal/simulator"
+	"vgbot/pkg/api"
+	"vgbot/pkg/distributed"
+	"vgbot/pkg/errclass"
+	"vgbot/pkg/i18n"
+	"vgbot/pkg/scheduler"
+	"vgbot/pkg/sitemap"
+	"vgbot/pkg/utils"
+)
+
+// ensureMaster gömülü distributed master'ı (yoksa) başlatır. s.mu tutulurken çağrılmalı.
+// Master yalnızca adres açılabildiyse saklanır; başarısız başlatma sonraki denemeyi engellemez.
+func (s *Server) ensureMaster() (*distributed.Master, error) {
+	if s.master != nil {
+		return s.master, nil
+	}
+	if s.cfg.DistributedSecret == "" {
+		// Master ağa açık dinler; secret olmadan her host task gönderip alabilir
+		return nil, fmt.Errorf("distributed master: distributed_secret ayarlanmadan başlatılamaz")
+	}
+	mcfg := distributed.DefaultMasterConfig()
+	mcfg.BindAddr = s.cfg.DistributedBindAddr
+	mcfg.SecretKey = s.cfg.DistributedSecret
+	mcfg.TLSCertFile = s.cfg.DistributedTLSCert
+	mcfg.TLSKeyFile = s.cfg.DistributedTLSKey
+	mcfg.TargetCheck = func(url string) error { return s.checkTarget(url, "cluster", "", url) }
+	m := distributed.NewMaster(mcfg)
+	m.SetResultHandler(s.handleClusterResult)
+	ln, err := m.Listen()
+	if err != nil {
+		return nil, fmt.Errorf("distributed master: %w", err)
+	}
+	go func() {
+		if err := m.Serve(ln); err != nil && err != http.ErrServerClosed {
+			log.Printf("[ERROR] Distributed master durdu: %v", err)
+		}
+	}()
+	s.master = m
+	return m, nil
+}
+
+// handleClusterResult worker'lardan gelen task sonuçlarını aktif raporlayıcıya aktarır.
+// Böylece dashboard, metrikler ve export yerel çalıştırmayla aynı kaynaktan beslenir.
+func (s *Server) handleClusterResult(task *distributed.Task) {
+	s.mu.Lock()
+	rep := s.clusterRep
+	s.mu.Unlock()
+	if rep == nil || task.Result == nil {
+		return
+	}
+	ts := task.Result.Timestamp
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+	rec := reporter.HitRecord{
+		Timestamp:    ts,
+		URL:          task.URL,
+		StatusCode:   task.Result.StatusCode,
+		ResponseTime: task.Result.ResponseTime.Milliseconds(),
+		Proxy:        taskProxy(task),
+		Protocol:     task.Result.Protocol,
+	}
+	if !task.Result.Success {
+		rec.Error = task.Result.Error
+		if rec.Error == "" {
+			rec.Error = "task failed"
+		}
+		rec.ErrorClass = errclass.OfMessage(rec.Error)
+	}
+	rep.Record(rec)
+}
+
+// taskProxy raporda görünen proxy: task'ın gerçekten kullandığı proxy (host:port), yoksa boş
+func taskProxy(task *distributed.Task) string {
+	if task.Proxy == nil {
+		return ""
+	}
+	return task.Proxy.Key()
+}
+
+// runDistributed kampanyanın hit planını (HPM x süre) task'lara bölerek master kuyruğuna dağıtır.
+// Task'lar dakika başına HPM kadar, dakika içine eşit aralıklarla gönderilir; worker'lar kuyruktan çeker.
+func (s *Server) runDistributed(ctx context.Context, cfg *config.Config, m *distributed.Master, rep *reporter.Reporter) {
+	defer func() {
+		rep.Finalize()
+		metrics := rep.GetMetrics()
+		rep.Log(fmt.Sprintf("📊 Cluster özeti: %d hit (%d başarılı, %d başarısız)",
+			metrics.TotalHits, metrics.SuccessHits, metrics.FailedHits))
+		if err := rep.Export(); err != nil {
+			rep.Log("⚠️ Rapor export hatası: " + err.Error())
+		}
+		rep.Close()
+	}()
+
+	pages := clusterPages(cfg)
+	hpm := cfg.HitsPerMinute
+	if hpm <= 0 {
+		hpm = 35
+	}
+	interval := time.Minute / time.Duration(hpm)
+	deadline := time.Now().Add(cfg.Duration)
+	planned := hpm * cfg.DurationMinutes
+
+	rep.Log(fmt.Sprintf("🌐 Cluster modu: %d hit, %d sayfa, %d aktif worker (master %s)",
+		planned, len(pages), len(m.GetHealthyWorkers()), cfg.DistributedBindAddr))
+	simulator.StartWarmup(cfg, rep)
+
+	windows, err := scheduler.NewWindowPlan(cfg.ActiveWindows, cfg.BlackoutWindows)
+	if err != nil {
+		rep.LogT(i18n.MsgWindowInvalid, err)
+		windows = nil
+	}
+	for _, line := range windows.Describe() {
+		rep.LogT(i18n.MsgWindowPlan, line)
+	}
+
+	homepage := pages[0]
+	weight := cfg.SitemapHomepageWeight
+	if weight <= 0 {
+		weight = 60
+	}
+	sessionID := fmt.Sprintf("campaign_%d", time.Now().Unix())
+	seeder := utils.NewSeeder(cfg.Seed)
+	rep.SetSeed(seeder.Seed())
+	rep.LogT(i18n.MsgRunSeed, seeder.Seed())
+	rng := seeder.NewRand()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	submitted := 0
+	for submitted < planned {
+		select {
+		case <-ctx.Done():
+			rep.Log(fmt.Sprintf("⏹ Cluster dağıtımı durduruldu (%d/%d task gönderildi)", submitted, planned))
+			return
+		case <-ticker.C:
+			if time.Now().After(deadline) {
+				rep.Log(fmt.Sprintf("⏱ Cluster süresi doldu (%d/%d task gönderildi)", submitted, planned))
+				return
+			}
+			// Blackout / aktif pencere dışındaysa task gönderme
+			if !windows.Allowed(time.Now()) {
+				continue
+			}
+			page := homepage
+			if len(pages) > 1 && rng.Intn(100) >= weight {
+				page = pages[rng.Intn(len(pages))]
+			}
+			if err := m.SubmitTask(&distributed.Task{URL: page, SessionID: sessionID}); err != nil {
+				rep.Log("⚠️ Task gönderilemedi: " + err.Error())
+				continue
+			}
+			submitted++
+		}
+	}
+
+	// Tüm task'lar gönderildi; kalan sonuçları süre sonuna kadar bekle
+	rep.Log(fmt.Sprintf("✅ %d task kuyruğa alındı, sonuçlar bekleniyor", submitted))
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Until(deadline)):
+	}
+}
+
+// clusterPages dağıtılacak sayfa listesini döner (sitemap açıksa sitemap, yoksa anasayfa).
+// İlk eleman her zaman anasayfadır.
+func clusterPages(cfg *config.Config) []string {
+	baseURL := cfg.TargetDomain
+	if !strings.HasPrefix(baseURL, "http") {
+		baseURL = "https://" + strings.TrimPrefix(baseURL, "//")
+	}
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	pages := []string{baseURL}
+	if cfg.UseSitemap {
+		cache := &sitemap.Cache{Dir: cfg.SitemapCacheDir, TTL: time.Duration(cfg.SitemapCacheHours) * time.Hour}
+		if urls, _, err := cache.Fetch(baseURL, nil); err == nil {
+			pages = append(pages, urls...)
+		}
+	}
+	return pages
+}
+
+// clusterStatus buildStatusMap için master istatistiklerini döner (master yoksa nil)
+func (s *Server) clusterStatus() *api.ClusterStatus {
+	s.mu.Lock()
+	m := s.master
+	s.mu.Unlock()
+	if m == nil {
+		return nil
+	}
+	return &api.ClusterStatus{MasterStats: m.GetStats(), Workers: m.ListWorkers()}
+}
+
+// handleClusterStatus distributed master durumu ve worker listesi
+func (s *Server) handleClusterStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", 405)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	status := s.clusterStatus()
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"enabled": status != nil,
+		"cluster": status,
+	})
+}
+
+// handleClusterConfig distributed yapılandırması GET/POST
+func (s *Server) handleClusterConfig(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method == http.MethodGet {
+		s.mu.Lock()
+		cfg := s.cfg
+		s.mu.Unlock()
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"enable_distributed":    cfg.EnableDistributed,
+			"distributed_bind_addr": cfg.DistributedBindAddr,
+			"distributed_secret":    maskConfigSecret(cfg.DistributedSecret),
+			"distributed_tls":       cfg.DistributedTLSCert != "",
+		})
+		return
+	}
+
+	if r.Method == http.MethodPost {
+		var body struct {
+			Enabled  bool   `json:"enable_distributed"`
+			BindAddr string `json:"distributed_bind_addr"`
+			Secret   string `json:"distributed_secret"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, "Invalid JSON", 400)
+			return
+		}
+
+		s.mu.Lock()
+		// GET maskeli döner; maske geri gönderilirse mevcut anahtar korunur
+		if body.Secret == secretMask {
+			body.Secret = s.cfg.DistributedSecret
+		}
+		if s.master != nil && ((body.BindAddr != "" && body.BindAddr != s.cfg.DistributedBindAddr) || body.Secret != s.cfg.DistributedSecret) {
+			s.mu.Unlock()
+			http.Error(w, "Master çalışırken adres/anahtar değiştirilemez", 409)
+			return
+		}
+		s.cfg.EnableDistributed = body.Enabled
+		if body.BindAddr != "" {
+			s.cfg.DistributedBindAddr = body.BindAddr
+		}
+		s.cfg.DistributedSecret = body.Secret
+		if s.cfg.EnableDistributed {
+			if _, err := s.ensureMaster(); err != nil {
+				s.cfg.EnableDistributed = false
+				s.mu.Unlock()
+				http.Error(w, err.Error(), 500)
+				return
+			}
+		}
+		cfgCopy := *s.cfg
+		s.mu.Unlock()
+
+		saveConfigToFile(&cfgCopy)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"message": "Cluster yapılandırması güncellendi",
+		})
+		return
+	}
+
+	http.Error(w, "Method not allowed", 405)
+}
+
+// startDistributed handleStart'ın cluster dalı; s.mu tutulurken çağrılır ve kilidi bırakır.
+func (s *Server) startDistributed(w http.ResponseWriter, rep *reporter.Reporter) {
+	m, err := s.ensureMaster()
+	if err != nil {
+		s.mu.Unlock()
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	rep.SetHitCallback(func(url string, duration time.Duration, success bool, proxy string, errClass string) {
+		s.RecordHit(url, proxy, duration, success, errClass)
+		s.hub.Broadcast("status", s.buildStatusMap())
+	})
+
+	cfgCopy := *s.cfg
+	rep.SetBranding(simulator.ReportBranding(&cfgCopy))
+	simulator.AttachHitLog(&cfgCopy, rep)
+	if err := simulator.AttachAssertions(&cfgCopy, rep); err != nil {
+		rep.LogT(i18n.MsgError, err)
+	}
+	run := s.beginRun()
+	s.sim = nil
+	s.clusterRep = rep
+	logChan, events := rep.LogChan(), rep.Events()
+	s.mu.Unlock()
+
+	run.Go("log-forwarder", func(ctx context.Context) {
+		s.forwardLogs(ctx, logChan, "")
+	})
+	run.Go("event-forwarder", func(ctx context.Context) {
+		s.forwardEvents(ctx, events, "")
+	})
+	run.Go("cluster", func(ctx context.Context) {
+		s.runDistributed(ctx, &cfgCopy, m, rep)
+		s.endRun(run)
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"status": "started", "mode": "distributed"})
+}
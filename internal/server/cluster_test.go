@@ -0,0 +1,91 @@
+package server
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"vgbot/internal/reporter"
+	"vgbot/pkg/api"
+	"vgbot/pkg/distributed"
+	"vgbot/pkg/proxy"
+)
+
+func TestClusterConfigMasksSecret(t *testing.T) {
+	cfg := testConfig()
+	cfg.DistributedSecret = "cluster-key"
+	s := &Server{cfg: &cfg}
+
+	rec := httptest.NewRecorder()
+	s.handleClusterConfig(rec, httptest.NewRequest(http.MethodGet, "/api/cluster/config", nil))
+	var got map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got["distributed_secret"] != secretMask {
+		t.Errorf("distributed_secret = %v, want mask", got["distributed_secret"])
+	}
+}
+
+func TestClusterResultRecordsTaskProxy(t *testing.T) {
+	rep := reporter.New(t.TempDir(), "json", "example.com")
+	defer rep.Close()
+	var proxies []string
+	rep.SetHitCallback(func(url string, d time.Duration, success bool, proxy string, errClass string) {
+		proxies = append(proxies, proxy)
+	})
+	s := &Server{clusterRep: rep}
+
+	s.handleClusterResult(&distributed.Task{
+		URL:      "https://example.com/",
+		WorkerID: "worker-1",
+		Proxy:    &proxy.ProxyConfig{Host: "10.0.0.5", Port: 3128, Username: "u", Password: "p"},
+		Result:   &api.TaskResult{Success: true, StatusCode: 200},
+	})
+	s.handleClusterResult(&distributed.Task{
+		URL:      "https://example.com/a",
+		WorkerID: "worker-1",
+		Result:   &api.TaskResult{Success: true, StatusCode: 200},
+	})
+	if len(proxies) != 2 || proxies[0] != "10.0.0.5:3128" || proxies[1] != "" {
+		t.Errorf("proxies = %q, want [10.0.0.5:3128 \"\"]", proxies)
+	}
+}
+
+func TestEnsureMasterCachesOnlyStarted(t *testing.T) {
+	busy, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := testConfig()
+	cfg.DistributedBindAddr = busy.Addr().String()
+	s := &Server{cfg: &cfg}
+
+	// Secret olmadan master ağa açılmaz
+	if m, err := s.ensureMaster(); err == nil || m != nil {
+		t.Fatalf("no secret: master=%v err=%v", m, err)
+	}
+	cfg.DistributedSecret = "cluster-key"
+
+	// Adres kullanımdayken master saklanmaz
+	if m, err := s.ensureMaster(); err == nil || m != nil || s.master != nil {
+		t.Fatalf("busy address: master=%v err=%v cached=%v", m, err, s.master)
+	}
+
+	// Adres boşalınca tekrar denenir
+	busy.Close()
+	m, err := s.ensureMaster()
+	if err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	defer m.Stop()
+	if s.master != m {
+		t.Error("started master not cached")
+	}
+	if again, _ := s.ensureMaster(); again != m {
+		t.Error("second call started another master")
+	}
+}
@@ -0,0 +1,1168 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"vgbot/internal/browser"
+	"vgbot/internal/config"
+	"vgbot/internal/reporter"
+	"vgbot/internal/simulator"
+	"vgbot/pkg/analytics"
+	"vgbot/pkg/marker"
+	"vgbot/pkg/network"
+	"vgbot/pkg/scheduler"
+)
+
+// configUpdate POST/PATCH /api/config gövdesi (snake_case alanlar)
+type configUpdate struct {
+	// Basic Settings
+	TargetDomain          string                `json:"target_domain"`
+	MaxPages              int                   `json:"max_pages"`
+	DurationMinutes       int                   `json:"duration_minutes"`
+	HitsPerMinute         int                   `json:"hits_per_minute"`
+	MaxConcurrentVisits   int                   `json:"max_concurrent_visits"`
+	OutputDir             string                `json:"output_dir"`
+	ExportFormat          string                `json:"export_format"`
+	HitLogJSONL           bool                  `json:"hit_log_jsonl"`
+	SessionLogJSONL       bool                  `json:"session_log_jsonl"`
+	ReportTitle           string                `json:"report_title"`
+	ReportLogo            string                `json:"report_logo"`
+	ReportPrimaryColor    string                `json:"report_primary_color"`
+	ReportBackgroundColor string                `json:"report_background_color"`
+	ReportFooter          string                `json:"report_footer"`
+	CanvasFingerprint     bool                  `json:"canvas_fingerprint"`
+	ScrollStrategy        string                `json:"scroll_strategy"`
+	SendScrollEvent       bool                  `json:"send_scroll_event"`
+	UseSitemap            bool                  `json:"use_sitemap"`
+	SitemapHomepageWeight int                   `json:"sitemap_homepage_weight"`
+	SitemapCacheHours     int                   `json:"sitemap_cache_hours"`
+	LandingPages          []string              `json:"landing_pages"`
+	URLWeights            []config.URLWeight    `json:"url_weights"`
+	NotFoundRate          int                   `json:"not_found_rate"`
+	ContentChecks         []config.ContentCheck `json:"content_checks"`
+	ContentCheckRate      int                   `json:"content_check_rate"`
+	Keywords              []string              `json:"keywords"`
+	GtagID                string                `json:"gtag_id"`
+	GA4APISecret          *string               `json:"ga4_api_secret"`
+	GA4Properties         *string               `json:"ga4_properties"` // "G-XXXX pay [etiket] [secret]" satırları
+	// configUpdateFrom doldurmaz: JSON map'leri mevcut map'in üzerine birleştirir, silinen anahtar kalırdı.
+	// nil = değişmez; gönderilirse eşleme tümüyle değiştirilir.
+	GA4EventMapping *config.GA4EventMapping `json:"ga4_event_mapping"`
+	// Birinci taraf sunucu taraflı GTM (sGTM)
+	GA4ServerURL         string   `json:"ga4_server_url"`
+	GA4ServerScriptPath  string   `json:"ga4_server_script_path"`
+	GA4ServerCollectPath string   `json:"ga4_server_collect_path"`
+	GA4ServerHeaders     []string `json:"ga4_server_headers"`
+	GA4TrafficType       string   `json:"ga4_traffic_type"`
+	// GA dışı analytics backend (Matomo, Plausible)
+	AnalyticsBackend string `json:"analytics_backend"`
+	MatomoURL        string `json:"matomo_url"`
+	MatomoSiteID     int    `json:"matomo_site_id"`
+	PlausibleURL     string `json:"plausible_url"`
+	PlausibleDomain  string `json:"plausible_domain"`
+	AntiDetectMode   bool   `json:"anti_detect_mode"`
+
+	// Device & Traffic
+	DeviceType      string   `json:"device_type"`
+	DeviceBrands    []string `json:"device_brands"`
+	MinPageDuration int      `json:"min_page_duration"`
+	MaxPageDuration int      `json:"max_page_duration"`
+
+	// Session & Bounce
+	EnableSessionDepth  bool `json:"enable_session_depth"`
+	SessionMinPages     int  `json:"session_min_pages"`
+	SessionMaxPages     int  `json:"session_max_pages"`
+	EnableBounceControl bool `json:"enable_bounce_control"`
+	TargetBounceRate    int  `json:"target_bounce_rate"`
+
+	// Behavior Simulation
+	SimulateMouseMove bool `json:"simulate_mouse_move"`
+	SimulateKeyboard  bool `json:"simulate_keyboard"`
+	SimulateClicks    bool `json:"simulate_clicks"`
+	SimulateFocus     bool `json:"simulate_focus"`
+
+	// Referrer
+	ReferrerEnabled bool   `json:"referrer_enabled"`
+	ReferrerSource  string `json:"referrer_source"`
+	ReferrerKeyword string `json:"referrer_keyword"`
+	// Outbound click
+	OutboundDomains   []string `json:"outbound_domains"`
+	OutboundClickRate int      `json:"outbound_click_rate"`
+
+	// Geo
+	GeoCountry  string `json:"geo_country"`
+	GeoLanguage string `json:"geo_language"`
+	GeoTimezone string `json:"geo_timezone"`
+
+	// Analytics Events
+	SendPageView       bool `json:"send_page_view"`
+	SendSessionStart   bool `json:"send_session_start"`
+	SendUserEngagement bool `json:"send_user_engagement"`
+	SendFirstVisit     bool `json:"send_first_visit"`
+
+	// GSC
+	EnableGscIntegration bool   `json:"enable_gsc_integration"`
+	UseGscQueries        bool   `json:"use_gsc_queries"`
+	GscPropertyUrl       string `json:"gsc_property_url"`
+	GscApiKey            string `json:"gsc_api_key"`
+	GscCacheHours        int    `json:"gsc_cache_hours"`
+
+	// Browser Profile
+	EnableBrowserProfile bool   `json:"enable_browser_profile"`
+	BrowserProfilePath   string `json:"browser_profile_path"`
+	MaxBrowserProfiles   int    `json:"max_browser_profiles"`
+	PersistCookies       bool   `json:"persist_cookies"`
+	PersistLocalStorage  bool   `json:"persist_local_storage"`
+
+	// Returning Visitor
+	EnableReturningVisitor bool `json:"enable_returning_visitor"`
+	ReturningVisitorRate   int  `json:"returning_visitor_rate"`
+	ReturningVisitorDays   int  `json:"returning_visitor_days"`
+
+	// Network
+	EnableHTTP3          bool `json:"enable_http3"`
+	EnableConnectionPool bool `json:"enable_connection_pool"`
+	EnableTCPFastOpen    bool `json:"enable_tcp_fast_open"`
+	MaxIdleConns         int  `json:"max_idle_conns"`
+	MaxConnsPerHost      int  `json:"max_conns_per_host"`
+
+	// System
+	EnableCPUAffinity bool `json:"enable_cpu_affinity"`
+	EnableNUMA        bool `json:"enable_numa"`
+
+	// VM Spoofing
+	EnableVMSpoofing  bool   `json:"enable_vm_spoofing"`
+	HideVMIndicators  bool   `json:"hide_vm_indicators"`
+	SpoofHardwareIDs  bool   `json:"spoof_hardware_ids"`
+	RandomizeVMParams bool   `json:"randomize_vm_params"`
+	VMType            string `json:"vm_type"`
+
+	// Proxy
+	UseProxy               bool     `json:"use_proxy"`
+	ProxyHost              string   `json:"proxy_host"`
+	ProxyPort              int      `json:"proxy_port"`
+	ProxyUser              string   `json:"proxy_user"`
+	ProxyPass              string   `json:"proxy_pass"`
+	UsePublicProxy         bool     `json:"use_public_proxy"`
+	ProxySourceURLs        []string `json:"proxy_source_urls"`
+	GitHubRepos            []string `json:"github_repos"`
+	CheckerWorkers         int      `json:"checker_workers"`
+	ProxyCheckCacheHours   int      `json:"proxy_check_cache_hours"`
+	GitHubFetchConcurrency int      `json:"github_fetch_concurrency"`
+	GitHubRepoAllowlist    []string `json:"github_repo_allowlist"`
+
+	// Private proxy
+	UsePrivateProxy bool                  `json:"use_private_proxy"`
+	PrivateProxies  []config.PrivateProxy `json:"private_proxies"`
+
+	// Proxy List (textarea'dan gelen)
+	ProxyList string `json:"proxy_list"`
+
+	// Zaman pencereleri (gönderilmezse mevcut değer korunur)
+	ActiveWindows   []string `json:"active_windows"`
+	BlackoutWindows []string `json:"blackout_windows"`
+	LoadMode        *string  `json:"load_mode"`
+	LoadLevels      []int    `json:"load_levels"`
+	LoadProfile     *string  `json:"load_profile"`
+	LoadStages      []string `json:"load_stages"`
+	Assertions      []string `json:"assertions"`
+	WarmupSeconds   *int     `json:"warmup_seconds"`
+	Seed            *int64   `json:"seed"`
+	LeakCheck       *bool    `json:"leak_check"`
+	// Proxy kullanım sınırları (gönderilmezse mevcut değer korunur)
+	ProxyMaxHitsPerHour  *int `json:"proxy_max_hits_per_hour"`
+	ProxyCooldownAfter   *int `json:"proxy_cooldown_after"`
+	ProxyCooldownMinutes *int `json:"proxy_cooldown_minutes"`
+	// Proxy sağlık skoru (gönderilmezse mevcut değer korunur)
+	ProxyMinHealthScore    *int `json:"proxy_min_health_score"`
+	ProxyQuarantineMinutes *int `json:"proxy_quarantine_minutes"`
+	ProxyBlacklistAfter    *int `json:"proxy_blacklist_after"`
+	// BigQuery export
+	BigQueryExport          *bool   `json:"bigquery_export"`
+	BigQueryProject         *string `json:"bigquery_project"`
+	BigQueryDataset         *string `json:"bigquery_dataset"`
+	BigQueryTable           *string `json:"bigquery_table"`
+	BigQueryCredentialsFile *string `json:"bigquery_credentials_file"`
+	// Tarayıcı konumu
+	BrowserPath           string   `json:"browser_path"`
+	BrowserAutoDownload   bool     `json:"browser_auto_download"`
+	BrowserCacheDir       string   `json:"browser_cache_dir"`
+	BrowserDownloadSHA256 string   `json:"browser_download_sha256"`
+	BrowserHeadlessMode   string   `json:"browser_headless_mode"`
+	BrowserExtraFlags     []string `json:"browser_extra_flags"`
+	BrowserExtensions     []string `json:"browser_extensions"`
+	// Staging provası
+	HostMap       []string `json:"host_map"`
+	BasicAuthUser string   `json:"basic_auth_user"`
+	BasicAuthPass string   `json:"basic_auth_pass"`
+	ExtraHeaders  []string `json:"extra_headers"`
+	// Trafik işareti
+	TrafficMarker     string `json:"traffic_marker"`
+	TrafficMarkerMode string `json:"traffic_marker_mode"`
+	// A/B deney bölmesi
+	ExperimentParam    string   `json:"experiment_param"`
+	ExperimentVariants []string `json:"experiment_variants"`
+	ExperimentMode     string   `json:"experiment_mode"`
+	// Uptime monitor
+	MonitorURLs          []string `json:"monitor_urls"`
+	MonitorIntervalMin   int      `json:"monitor_interval_min"`
+	MonitorProbes        []string `json:"monitor_probes"`
+	MonitorFailThreshold int      `json:"monitor_fail_threshold"`
+	// Saklama
+	RetentionDays        int `json:"retention_days"`
+	RetentionMaxMB       int `json:"retention_max_mb"`
+	RetentionIntervalMin int `json:"retention_interval_min"`
+	// Girişli oturum
+	LoginEnabled         bool   `json:"login_enabled"`
+	LoginURL             string `json:"login_url"`
+	LoginUserSelector    string `json:"login_user_selector"`
+	LoginPassSelector    string `json:"login_pass_selector"`
+	LoginSubmitSelector  string `json:"login_submit_selector"`
+	LoginSuccessSelector string `json:"login_success_selector"`
+	LoginCredential      string `json:"login_credential"`
+	VaultFile            string `json:"vault_file"`
+}
+
+// configUpdateFrom mevcut config'i güncelleme gövdesine çevirir. PATCH gövdesi bunun üzerine
+// çözülür; böylece gönderilmeyen alanlar mevcut değerini korur.
+func configUpdateFrom(cfg *config.Config) configUpdate {
+	ga4Secret := cfg.GA4APISecret
+	ga4Props := config.FormatGA4Properties(cfg.GA4Properties)
+	seed, leakCheck := cfg.Seed, cfg.LeakCheck
+	warmup := cfg.WarmupSeconds
+	loadMode, loadProfile := cfg.LoadMode, cfg.LoadProfile
+	maxHits, cooldownAfter, cooldownMinutes := cfg.ProxyMaxHitsPerHour, cfg.ProxyCooldownAfter, cfg.ProxyCooldownMinutes
+	minHealth, quarantine, blacklistAfter := cfg.ProxyMinHealthScore, cfg.ProxyQuarantineMinutes, cfg.ProxyBlacklistAfter
+	bqExport, bqProject, bqDataset := cfg.BigQueryExport, cfg.BigQueryProject, cfg.BigQueryDataset
+	bqTable, bqCredentials := cfg.BigQueryTable, cfg.BigQueryCredentialsFile
+	return configUpdate{
+		TargetDomain:            cfg.TargetDomain,
+		MaxPages:                cfg.MaxPages,
+		DurationMinutes:         cfg.DurationMinutes,
+		HitsPerMinute:           cfg.HitsPerMinute,
+		MaxConcurrentVisits:     cfg.MaxConcurrentVisits,
+		OutputDir:               cfg.OutputDir,
+		ExportFormat:            cfg.ExportFormat,
+		HitLogJSONL:             cfg.HitLogJSONL,
+		SessionLogJSONL:         cfg.SessionLogJSONL,
+		ReportTitle:             cfg.ReportTitle,
+		ReportLogo:              cfg.ReportLogo,
+		ReportPrimaryColor:      cfg.ReportPrimaryColor,
+		ReportBackgroundColor:   cfg.ReportBackgroundColor,
+		ReportFooter:            cfg.ReportFooter,
+		CanvasFingerprint:       cfg.CanvasFingerprint,
+		ScrollStrategy:          cfg.ScrollStrategy,
+		SendScrollEvent:         cfg.SendScrollEvent,
+		UseSitemap:              cfg.UseSitemap,
+		SitemapHomepageWeight:   cfg.SitemapHomepageWeight,
+		SitemapCacheHours:       cfg.SitemapCacheHours,
+		LandingPages:            append([]string(nil), cfg.LandingPages...),
+		URLWeights:              append([]config.URLWeight(nil), cfg.URLWeights...),
+		NotFoundRate:            cfg.NotFoundRate,
+		ContentChecks:           append([]config.ContentCheck(nil), cfg.ContentChecks...),
+		ContentCheckRate:        cfg.ContentCheckRate,
+		Keywords:                cfg.Keywords,
+		GtagID:                  cfg.GtagID,
+		AntiDetectMode:          cfg.AntiDetectMode,
+		DeviceType:              cfg.DeviceType,
+		DeviceBrands:            cfg.DeviceBrands,
+		MinPageDuration:         cfg.MinPageDuration,
+		MaxPageDuration:         cfg.MaxPageDuration,
+		EnableSessionDepth:      cfg.EnableSessionDepth,
+		SessionMinPages:         cfg.SessionMinPages,
+		SessionMaxPages:         cfg.SessionMaxPages,
+		EnableBounceControl:     cfg.EnableBounceControl,
+		TargetBounceRate:        cfg.TargetBounceRate,
+		SimulateMouseMove:       cfg.SimulateMouseMove,
+		SimulateKeyboard:        cfg.SimulateKeyboard,
+		SimulateClicks:          cfg.SimulateClicks,
+		SimulateFocus:           cfg.SimulateFocus,
+		ReferrerEnabled:         cfg.ReferrerEnabled,
+		ReferrerSource:          cfg.ReferrerSource,
+		OutboundDomains:         append([]string(nil), cfg.OutboundDomains...),
+		OutboundClickRate:       cfg.OutboundClickRate,
+		ReferrerKeyword:         cfg.ReferrerKeyword,
+		GeoCountry:              cfg.GeoCountry,
+		GeoLanguage:             cfg.GeoLanguage,
+		GeoTimezone:             cfg.GeoTimezone,
+		SendPageView:            cfg.SendPageView,
+		SendSessionStart:        cfg.SendSessionStart,
+		SendUserEngagement:      cfg.SendUserEngagement,
+		SendFirstVisit:          cfg.SendFirstVisit,
+		EnableGscIntegration:    cfg.EnableGscIntegration,
+		UseGscQueries:           cfg.UseGscQueries,
+		GscPropertyUrl:          cfg.GscPropertyUrl,
+		GscApiKey:               cfg.GscApiKey,
+		GscCacheHours:           cfg.GscCacheHours,
+		EnableBrowserProfile:    cfg.EnableBrowserProfile,
+		BrowserProfilePath:      cfg.BrowserProfilePath,
+		MaxBrowserProfiles:      cfg.MaxBrowserProfiles,
+		PersistCookies:          cfg.PersistCookies,
+		PersistLocalStorage:     cfg.PersistLocalStorage,
+		EnableReturningVisitor:  cfg.EnableReturningVisitor,
+		ReturningVisitorRate:    cfg.ReturningVisitorRate,
+		ReturningVisitorDays:    cfg.ReturningVisitorDays,
+		EnableHTTP3:             cfg.EnableHTTP3,
+		EnableConnectionPool:    cfg.EnableConnectionPool,
+		EnableTCPFastOpen:       cfg.EnableTCPFastOpen,
+		MaxIdleConns:            cfg.MaxIdleConns,
+		MaxConnsPerHost:         cfg.MaxConnsPerHost,
+		EnableCPUAffinity:       cfg.EnableCPUAffinity,
+		EnableNUMA:              cfg.EnableNUMA,
+		EnableVMSpoofing:        cfg.EnableVMSpoofing,
+		HideVMIndicators:        cfg.HideVMIndicators,
+		SpoofHardwareIDs:        cfg.SpoofHardwareIDs,
+		RandomizeVMParams:       cfg.RandomizeVMParams,
+		VMType:                  cfg.VMType,
+		UseProxy:                cfg.UseProxy,
+		ProxyHost:               cfg.ProxyHost,
+		ProxyPort:               cfg.ProxyPort,
+		ProxyUser:               cfg.ProxyUser,
+		ProxyPass:               cfg.ProxyPass,
+		UsePublicProxy:          cfg.UsePublicProxy,
+		ProxySourceURLs:         cfg.ProxySourceURLs,
+		UsePrivateProxy:         cfg.UsePrivateProxy,
+		GA4APISecret:            &ga4Secret,
+		GA4Properties:           &ga4Props,
+		GA4ServerURL:            cfg.GA4ServerURL,
+		GA4ServerScriptPath:     cfg.GA4ServerScriptPath,
+		GA4ServerCollectPath:    cfg.GA4ServerCollectPath,
+		GA4ServerHeaders:        append([]string(nil), cfg.GA4ServerHeaders...),
+		GA4TrafficType:          cfg.GA4TrafficType,
+		AnalyticsBackend:        cfg.AnalyticsBackend,
+		MatomoURL:               cfg.MatomoURL,
+		MatomoSiteID:            cfg.MatomoSiteID,
+		PlausibleURL:            cfg.PlausibleURL,
+		PlausibleDomain:         cfg.PlausibleDomain,
+		GitHubRepos:             cfg.GitHubRepos,
+		CheckerWorkers:          cfg.CheckerWorkers,
+		ProxyCheckCacheHours:    cfg.ProxyCheckCacheHours,
+		GitHubFetchConcurrency:  cfg.GitHubFetchConcurrency,
+		GitHubRepoAllowlist:     cfg.GitHubRepoAllowlist,
+		PrivateProxies:          append([]config.PrivateProxy(nil), cfg.PrivateProxies...),
+		ActiveWindows:           cfg.ActiveWindows,
+		BlackoutWindows:         cfg.BlackoutWindows,
+		LoadMode:                &loadMode,
+		LoadLevels:              cfg.LoadLevels,
+		LoadProfile:             &loadProfile,
+		LoadStages:              cfg.LoadStages,
+		Assertions:              cfg.Assertions,
+		WarmupSeconds:           &warmup,
+		Seed:                    &seed,
+		LeakCheck:               &leakCheck,
+		ProxyMaxHitsPerHour:     &maxHits,
+		ProxyCooldownAfter:      &cooldownAfter,
+		ProxyCooldownMinutes:    &cooldownMinutes,
+		ProxyMinHealthScore:     &minHealth,
+		ProxyQuarantineMinutes:  &quarantine,
+		ProxyBlacklistAfter:     &blacklistAfter,
+		BigQueryExport:          &bqExport,
+		BigQueryProject:         &bqProject,
+		BigQueryDataset:         &bqDataset,
+		BigQueryTable:           &bqTable,
+		BigQueryCredentialsFile: &bqCredentials,
+		BrowserPath:             cfg.BrowserPath,
+		BrowserAutoDownload:     cfg.BrowserAutoDownload,
+		BrowserCacheDir:         cfg.BrowserCacheDir,
+		BrowserDownloadSHA256:   cfg.BrowserDownloadSHA256,
+		BrowserHeadlessMode:     cfg.BrowserHeadlessMode,
+		BrowserExtraFlags:       append([]string(nil), cfg.BrowserExtraFlags...),
+		BrowserExtensions:       append([]string(nil), cfg.BrowserExtensions...),
+		HostMap:                 append([]string(nil), cfg.HostMap...),
+		BasicAuthUser:           cfg.BasicAuthUser,
+		BasicAuthPass:           cfg.BasicAuthPass,
+		ExtraHeaders:            append([]string(nil), cfg.ExtraHeaders...),
+		TrafficMarker:           cfg.TrafficMarker,
+		TrafficMarkerMode:       cfg.TrafficMarkerMode,
+		ExperimentParam:         cfg.ExperimentParam,
+		ExperimentVariants:      append([]string(nil), cfg.ExperimentVariants...),
+		ExperimentMode:          cfg.ExperimentMode,
+		MonitorURLs:             append([]string(nil), cfg.MonitorURLs...),
+		MonitorIntervalMin:      cfg.MonitorIntervalMin,
+		MonitorProbes:           append([]string(nil), cfg.MonitorProbes...),
+		MonitorFailThreshold:    cfg.MonitorFailThreshold,
+		RetentionDays:           cfg.RetentionDays,
+		RetentionMaxMB:          cfg.RetentionMaxMB,
+		RetentionIntervalMin:    cfg.RetentionIntervalMin,
+		LoginEnabled:            cfg.LoginEnabled,
+		LoginURL:                cfg.LoginURL,
+		LoginUserSelector:       cfg.LoginUserSelector,
+		LoginPassSelector:       cfg.LoginPassSelector,
+		LoginSubmitSelector:     cfg.LoginSubmitSelector,
+		LoginSuccessSelector:    cfg.LoginSuccessSelector,
+		LoginCredential:         cfg.LoginCredential,
+		VaultFile:               cfg.VaultFile,
+	}
+}
+
+// decodeConfigUpdate istek gövdesini mevcut config'in üzerine çözer: gövdede olmayan alanlar
+// (ör. eski arayüz sürümlerinin bilmediği ayarlar) sıfırlanmaz, mevcut değerini korur.
+// POST ve PATCH aynı semantiği kullanır.
+func decodeConfigUpdate(r io.Reader, cur *config.Config) (configUpdate, error) {
+	u := configUpdateFrom(cur)
+	if err := json.NewDecoder(r).Decode(&u); err != nil {
+		return configUpdate{}, err
+	}
+	return u, nil
+}
+
+// validate config'e yazmadan önce alanları doğrular
+func (u *configUpdate) validate() error {
+	if _, err := scheduler.NewWindowPlan(u.ActiveWindows, u.BlackoutWindows); err != nil {
+		return fmt.Errorf("Geçersiz zaman penceresi: %w", err)
+	}
+	if u.LoadMode != nil {
+		switch *u.LoadMode {
+		case "", "open", "closed":
+		default:
+			return fmt.Errorf("load_mode open veya closed olmalı: %q", *u.LoadMode)
+		}
+	}
+	for _, n := range u.LoadLevels {
+		if n < 1 || n > 50 {
+			return fmt.Errorf("load_levels değerleri 1-50 arasında olmalı: %d", n)
+		}
+	}
+	if u.LoadProfile != nil {
+		if _, err := simulator.LoadStages(*u.LoadProfile, u.LoadStages, 0, 1); err != nil {
+			return err
+		}
+	}
+	if _, err := reporter.ParseAssertions(u.Assertions); err != nil {
+		return err
+	}
+	if u.WarmupSeconds != nil && (*u.WarmupSeconds < 0 || (u.DurationMinutes > 0 && *u.WarmupSeconds >= u.DurationMinutes*60)) {
+		return fmt.Errorf("warmup_seconds 0 ile çalıştırma süresi arasında olmalı: %d", *u.WarmupSeconds)
+	}
+	if u.ProxyMinHealthScore != nil && (*u.ProxyMinHealthScore < 0 || *u.ProxyMinHealthScore > 100) {
+		return fmt.Errorf("proxy_min_health_score 0-100 arasında olmalı: %d", *u.ProxyMinHealthScore)
+	}
+	if u.GA4Properties != nil {
+		if _, err := config.ParseGA4Properties(*u.GA4Properties); err != nil {
+			return err
+		}
+	}
+	if u.GA4EventMapping != nil {
+		if _, err := simulator.EventMapping(*u.GA4EventMapping); err != nil {
+			return fmt.Errorf("Geçersiz ga4_event_mapping: %w", err)
+		}
+	}
+	if _, err := analytics.NewServerEndpoint(u.GA4ServerURL, u.GA4ServerScriptPath, u.GA4ServerCollectPath, u.GA4ServerHeaders); err != nil {
+		return err
+	}
+	if err := analytics.ValidateTrafficType(strings.TrimSpace(u.GA4TrafficType)); err != nil {
+		return err
+	}
+	if _, err := analytics.NewTracker(analytics.BackendConfig{
+		Backend:         u.AnalyticsBackend,
+		MatomoURL:       u.MatomoURL,
+		MatomoSiteID:    u.MatomoSiteID,
+		PlausibleURL:    u.PlausibleURL,
+		PlausibleDomain: u.PlausibleDomain,
+	}, u.TargetDomain); err != nil {
+		return err
+	}
+	opts, err := browserFlagOptions(u.BrowserHeadlessMode, u.BrowserExtraFlags, u.BrowserExtensions, u.HostMap)
+	if err != nil {
+		return err
+	}
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	if _, err := monitorProbes(u.MonitorProbes, nil); err != nil {
+		return fmt.Errorf("Geçersiz monitor_probes: %w", err)
+	}
+	if _, err := network.CampaignHeaders(u.BasicAuthUser, u.BasicAuthPass, u.ExtraHeaders); err != nil {
+		return fmt.Errorf("Geçersiz extra_headers: %w", err)
+	}
+	if _, err := simulator.ParseLandingMix(simulator.LandingEntries(u.LandingPages, u.URLWeights), ""); err != nil {
+		return err
+	}
+	if _, err := simulator.ContentChecker(u.ContentChecks, u.ContentCheckRate); err != nil {
+		return fmt.Errorf("Geçersiz content_checks: %w", err)
+	}
+	if u.OutboundClickRate < 0 || u.OutboundClickRate > 100 {
+		return fmt.Errorf("outbound_click_rate 0-100 arası olmalı")
+	}
+	if _, err := browser.NormalizeOutboundDomains(u.OutboundDomains); err != nil {
+		return err
+	}
+	if _, err := marker.Parse(u.TrafficMarker, u.TrafficMarkerMode); err != nil {
+		return err
+	}
+	branding := reporter.Branding{
+		Logo:            strings.TrimSpace(u.ReportLogo),
+		PrimaryColor:    strings.TrimSpace(u.ReportPrimaryColor),
+		BackgroundColor: strings.TrimSpace(u.ReportBackgroundColor),
+	}
+	if err := branding.Validate(); err != nil {
+		return err
+	}
+	if _, err := marker.ParseSplit(u.ExperimentParam, u.ExperimentMode, u.ExperimentVariants); err != nil {
+		return err
+	}
+	if u.RetentionDays < 0 || u.RetentionMaxMB < 0 || u.RetentionIntervalMin < 0 {
+		return fmt.Errorf("retention_days, retention_max_mb ve retention_interval_min negatif olamaz")
+	}
+	if u.LoginEnabled {
+		form := simulator.LoginForm(&config.Config{
+			LoginURL:             u.LoginURL,
+			LoginUserSelector:    u.LoginUserSelector,
+			LoginPassSelector:    u.LoginPassSelector,
+			LoginSubmitSelector:  u.LoginSubmitSelector,
+			LoginSuccessSelector: u.LoginSuccessSelector,
+		})
+		if err := form.Validate(); err != nil {
+			return fmt.Errorf("Geçersiz giriş senaryosu: %w", err)
+		}
+		if u.LoginCredential == "" {
+			return fmt.Errorf("Geçersiz giriş senaryosu: login_credential boş")
+		}
+	}
+	return nil
+}
+
+// apply güncellemeyi cfg'ye yazar (validate sonrası çağrılır; ApplyDefaults çağıranın işi)
+func (u *configUpdate) apply(cfg *config.Config) {
+	// Basic Settings
+	cfg.TargetDomain = u.TargetDomain
+	cfg.MaxPages = u.MaxPages
+	cfg.DurationMinutes = u.DurationMinutes
+	cfg.HitsPerMinute = u.HitsPerMinute
+	cfg.MaxConcurrentVisits = u.MaxConcurrentVisits
+	cfg.OutputDir = u.OutputDir
+	cfg.ExportFormat = u.ExportFormat
+	cfg.HitLogJSONL = u.HitLogJSONL
+	cfg.SessionLogJSONL = u.SessionLogJSONL
+	cfg.ReportTitle = strings.TrimSpace(u.ReportTitle)
+	cfg.ReportLogo = strings.TrimSpace(u.ReportLogo)
+	cfg.ReportPrimaryColor = strings.TrimSpace(u.ReportPrimaryColor)
+	cfg.ReportBackgroundColor = strings.TrimSpace(u.ReportBackgroundColor)
+	cfg.ReportFooter = strings.TrimSpace(u.ReportFooter)
+	cfg.CanvasFingerprint = u.CanvasFingerprint
+	cfg.ScrollStrategy = u.ScrollStrategy
+	cfg.SendScrollEvent = u.SendScrollEvent
+	cfg.UseSitemap = u.UseSitemap
+	cfg.SitemapHomepageWeight = u.SitemapHomepageWeight
+	if u.SitemapCacheHours != 0 {
+		cfg.SitemapCacheHours = u.SitemapCacheHours
+	}
+	cfg.LandingPages = u.LandingPages
+	cfg.URLWeights = u.URLWeights
+	cfg.NotFoundRate = u.NotFoundRate
+	cfg.ContentChecks = u.ContentChecks
+	cfg.ContentCheckRate = u.ContentCheckRate
+	cfg.Keywords = u.Keywords
+	cfg.GtagID = u.GtagID
+	if u.GA4APISecret != nil && *u.GA4APISecret != secretMask {
+		cfg.GA4APISecret = strings.TrimSpace(*u.GA4APISecret)
+	}
+	if u.GA4Properties != nil {
+		props, _ := config.ParseGA4Properties(*u.GA4Properties) // validate() hatayı önceden yakalar
+		cfg.GA4Properties = unmaskGA4Properties(props, cfg.GA4Properties)
+	}
+	if u.GA4EventMapping != nil {
+		cfg.GA4EventMapping = *u.GA4EventMapping
+	}
+	cfg.GA4ServerURL = strings.TrimSpace(u.GA4ServerURL)
+	cfg.GA4ServerScriptPath = strings.TrimSpace(u.GA4ServerScriptPath)
+	cfg.GA4ServerCollectPath = strings.TrimSpace(u.GA4ServerCollectPath)
+	cfg.GA4ServerHeaders = unmaskHeaderValues(u.GA4ServerHeaders, cfg.GA4ServerHeaders)
+	cfg.GA4TrafficType = strings.TrimSpace(u.GA4TrafficType)
+	cfg.AnalyticsBackend = strings.ToLower(strings.TrimSpace(u.AnalyticsBackend))
+	cfg.MatomoURL = strings.TrimSpace(u.MatomoURL)
+	cfg.MatomoSiteID = u.MatomoSiteID
+	cfg.PlausibleURL = strings.TrimSpace(u.PlausibleURL)
+	cfg.PlausibleDomain = strings.TrimSpace(u.PlausibleDomain)
+	cfg.AntiDetectMode = u.AntiDetectMode
+
+	// Device & Traffic
+	cfg.DeviceType = u.DeviceType
+	cfg.DeviceBrands = u.DeviceBrands
+	cfg.MinPageDuration = u.MinPageDuration
+	cfg.MaxPageDuration = u.MaxPageDuration
+
+	// Session & Bounce
+	cfg.EnableSessionDepth = u.EnableSessionDepth
+	cfg.SessionMinPages = u.SessionMinPages
+	cfg.SessionMaxPages = u.SessionMaxPages
+	cfg.EnableBounceControl = u.EnableBounceControl
+	cfg.TargetBounceRate = u.TargetBounceRate
+
+	// Behavior Simulation
+	cfg.SimulateMouseMove = u.SimulateMouseMove
+	cfg.SimulateKeyboard = u.SimulateKeyboard
+	cfg.SimulateClicks = u.SimulateClicks
+	cfg.SimulateFocus = u.SimulateFocus
+
+	// Referrer
+	cfg.ReferrerEnabled = u.ReferrerEnabled
+	cfg.ReferrerSource = u.ReferrerSource
+	cfg.OutboundDomains = u.OutboundDomains
+	cfg.OutboundClickRate = u.OutboundClickRate
+	cfg.ReferrerKeyword = u.ReferrerKeyword
+
+	// Geo
+	cfg.GeoCountry = u.GeoCountry
+	cfg.GeoLanguage = u.GeoLanguage
+	cfg.GeoTimezone = u.GeoTimezone
+
+	// Analytics Events
+	cfg.SendPageView = u.SendPageView
+	cfg.SendSessionStart = u.SendSessionStart
+	cfg.SendUserEngagement = u.SendUserEngagement
+	cfg.SendFirstVisit = u.SendFirstVisit
+
+	// GSC
+	cfg.EnableGscIntegration = u.EnableGscIntegration
+	cfg.UseGscQueries = u.UseGscQueries
+	cfg.GscPropertyUrl = u.GscPropertyUrl
+	cfg.GscApiKey = u.GscApiKey
+	if u.GscCacheHours != 0 {
+		cfg.GscCacheHours = u.GscCacheHours
+	}
+
+	// Browser Profile
+	cfg.EnableBrowserProfile = u.EnableBrowserProfile
+	cfg.BrowserProfilePath = u.BrowserProfilePath
+	cfg.MaxBrowserProfiles = u.MaxBrowserProfiles
+	cfg.PersistCookies = u.PersistCookies
+	cfg.PersistLocalStorage = u.PersistLocalStorage
+
+	// Returning Visitor
+	cfg.EnableReturningVisitor = u.EnableReturningVisitor
+	cfg.ReturningVisitorRate = u.ReturningVisitorRate
+	cfg.ReturningVisitorDays = u.ReturningVisitorDays
+
+	// Network
+	cfg.EnableHTTP3 = u.EnableHTTP3
+	cfg.EnableConnectionPool = u.EnableConnectionPool
+	cfg.EnableTCPFastOpen = u.EnableTCPFastOpen
+	cfg.MaxIdleConns = u.MaxIdleConns
+	cfg.MaxConnsPerHost = u.MaxConnsPerHost
+
+	// System
+	cfg.EnableCPUAffinity = u.EnableCPUAffinity
+	cfg.EnableNUMA = u.EnableNUMA
+
+	// VM Spoofing
+	cfg.EnableVMSpoofing = u.EnableVMSpoofing
+	cfg.HideVMIndicators = u.HideVMIndicators
+	cfg.SpoofHardwareIDs = u.SpoofHardwareIDs
+	cfg.RandomizeVMParams = u.RandomizeVMParams
+	cfg.VMType = u.VMType
+
+	// Proxy
+	cfg.UseProxy = u.UseProxy
+	cfg.ProxyHost = u.ProxyHost
+	cfg.ProxyPort = u.ProxyPort
+	cfg.ProxyUser = u.ProxyUser
+	cfg.ProxyPass = u.ProxyPass
+	cfg.UsePublicProxy = u.UsePublicProxy
+	cfg.ProxySourceURLs = u.ProxySourceURLs
+	if u.GitHubRepos != nil {
+		cfg.GitHubRepos = u.GitHubRepos
+	}
+	if u.CheckerWorkers > 0 {
+		cfg.CheckerWorkers = u.CheckerWorkers
+	}
+	if u.ProxyCheckCacheHours != 0 {
+		cfg.ProxyCheckCacheHours = u.ProxyCheckCacheHours
+	}
+	if u.GitHubFetchConcurrency > 0 {
+		cfg.GitHubFetchConcurrency = u.GitHubFetchConcurrency
+	}
+	if u.GitHubRepoAllowlist != nil {
+		cfg.GitHubRepoAllowlist = u.GitHubRepoAllowlist
+	}
+
+	// Private proxy'leri config'e kaydet
+	cfg.UsePrivateProxy = u.UsePrivateProxy
+	cfg.PrivateProxies = nil // Önce temizle
+	for _, pp := range u.PrivateProxies {
+		if pp.Host != "" && pp.Port > 0 {
+			if pp.Protocol == "" {
+				pp.Protocol = "http"
+			}
+			cfg.PrivateProxies = append(cfg.PrivateProxies, pp)
+		}
+	}
+
+	// Private proxy varsa UsePrivateProxy'yi otomatik aktifleştir
+	if len(cfg.PrivateProxies) > 0 {
+		cfg.UsePrivateProxy = true
+	}
+
+	// Proxy List parsing (textarea'dan gelen)
+	if u.ProxyList != "" {
+		// Satır satır parse et ve private_proxies'e ekle
+		lines := strings.Split(u.ProxyList, "\n")
+		for _, line := range lines {
+			line = strings.TrimSpace(line)
+			if line == "" {
+				continue
+			}
+
+			// Parse proxy URL: protocol://[user:pass@]host:port
+			proxyURL, err := url.Parse(line)
+			if err != nil {
+				log.Printf("[WARN] Invalid proxy URL skipped: %s - %v", line, err)
+				continue
+			}
+
+			host := proxyURL.Hostname()
+			portStr := proxyURL.Port()
+			port := 0
+			if portStr != "" {
+				if p, err := strconv.Atoi(portStr); err == nil {
+					port = p
+				}
+			}
+
+			if host == "" || port == 0 {
+				log.Printf("[WARN] Proxy missing host or port, skipped: %s", line)
+				continue
+			}
+
+			protocol := proxyURL.Scheme
+			if protocol == "" {
+				protocol = "http"
+			}
+
+			user := ""
+			pass := ""
+			if proxyURL.User != nil {
+				user = proxyURL.User.Username()
+				if p, ok := proxyURL.User.Password(); ok {
+					pass = p
+				}
+			}
+
+			cfg.PrivateProxies = append(cfg.PrivateProxies, config.PrivateProxy{
+				Host:     host,
+				Port:     port,
+				User:     user,
+				Pass:     pass,
+				Protocol: protocol,
+			})
+
+			log.Printf("[INFO] Added proxy: %s://%s:%d", protocol, host, port)
+		}
+
+		// Proxy varsa UsePrivateProxy ve UseProxy'yi aktifleştir
+		if len(cfg.PrivateProxies) > 0 {
+			cfg.UsePrivateProxy = true
+			cfg.UseProxy = true
+			cfg.ProxyEnabled = true
+		}
+	}
+
+	// Zaman pencereleri
+	if u.ActiveWindows != nil {
+		cfg.ActiveWindows = u.ActiveWindows
+	}
+	if u.BlackoutWindows != nil {
+		cfg.BlackoutWindows = u.BlackoutWindows
+	}
+	if u.LoadMode != nil {
+		cfg.LoadMode = *u.LoadMode
+	}
+	if u.LoadLevels != nil {
+		cfg.LoadLevels = u.LoadLevels
+	}
+	if u.LoadProfile != nil {
+		cfg.LoadProfile = *u.LoadProfile
+	}
+	if u.LoadStages != nil {
+		cfg.LoadStages = u.LoadStages
+	}
+	if u.Assertions != nil {
+		cfg.Assertions = u.Assertions
+	}
+	if u.WarmupSeconds != nil {
+		cfg.WarmupSeconds = *u.WarmupSeconds
+	}
+	if u.Seed != nil {
+		cfg.Seed = *u.Seed
+	}
+	if u.LeakCheck != nil {
+		cfg.LeakCheck = *u.LeakCheck
+	}
+	if u.ProxyMaxHitsPerHour != nil {
+		cfg.ProxyMaxHitsPerHour = *u.ProxyMaxHitsPerHour
+	}
+	if u.ProxyCooldownAfter != nil {
+		cfg.ProxyCooldownAfter = *u.ProxyCooldownAfter
+	}
+	if u.ProxyCooldownMinutes != nil {
+		cfg.ProxyCooldownMinutes = *u.ProxyCooldownMinutes
+	}
+	if u.ProxyMinHealthScore != nil {
+		cfg.ProxyMinHealthScore = *u.ProxyMinHealthScore
+	}
+	if u.ProxyQuarantineMinutes != nil {
+		cfg.ProxyQuarantineMinutes = *u.ProxyQuarantineMinutes
+	}
+	if u.ProxyBlacklistAfter != nil {
+		cfg.ProxyBlacklistAfter = *u.ProxyBlacklistAfter
+	}
+	if u.BigQueryExport != nil {
+		cfg.BigQueryExport = *u.BigQueryExport
+	}
+	if u.BigQueryProject != nil {
+		cfg.BigQueryProject = strings.TrimSpace(*u.BigQueryProject)
+	}
+	if u.BigQueryDataset != nil {
+		cfg.BigQueryDataset = strings.TrimSpace(*u.BigQueryDataset)
+	}
+	if u.BigQueryTable != nil {
+		cfg.BigQueryTable = strings.TrimSpace(*u.BigQueryTable)
+	}
+	if u.BigQueryCredentialsFile != nil {
+		cfg.BigQueryCredentialsFile = strings.TrimSpace(*u.BigQueryCredentialsFile)
+	}
+
+	// Tarayıcı konumu
+	cfg.BrowserPath = strings.TrimSpace(u.BrowserPath)
+	cfg.BrowserAutoDownload = u.BrowserAutoDownload
+	cfg.BrowserCacheDir = strings.TrimSpace(u.BrowserCacheDir)
+	cfg.BrowserDownloadSHA256 = strings.ToLower(strings.TrimSpace(u.BrowserDownloadSHA256))
+	cfg.BrowserHeadlessMode = strings.TrimSpace(u.BrowserHeadlessMode)
+	cfg.BrowserExtraFlags = u.BrowserExtraFlags
+	cfg.BrowserExtensions = u.BrowserExtensions
+	cfg.HostMap = u.HostMap
+	cfg.BasicAuthUser = u.BasicAuthUser
+	if u.BasicAuthPass != secretMask {
+		cfg.BasicAuthPass = u.BasicAuthPass
+	}
+	cfg.ExtraHeaders = unmaskHeaderValues(u.ExtraHeaders, cfg.ExtraHeaders)
+	cfg.TrafficMarker = u.TrafficMarker
+	cfg.TrafficMarkerMode = u.TrafficMarkerMode
+	cfg.ExperimentParam = u.ExperimentParam
+	cfg.ExperimentVariants = u.ExperimentVariants
+	cfg.ExperimentMode = u.ExperimentMode
+
+	// Uptime monitor
+	cfg.MonitorURLs = u.MonitorURLs
+	cfg.MonitorIntervalMin = u.MonitorIntervalMin
+	cfg.MonitorProbes = u.MonitorProbes
+	cfg.MonitorFailThreshold = u.MonitorFailThreshold
+
+	// Saklama
+	cfg.RetentionDays = u.RetentionDays
+	cfg.RetentionMaxMB = u.RetentionMaxMB
+	cfg.RetentionIntervalMin = u.RetentionIntervalMin
+
+	// Girişli oturum
+	cfg.LoginEnabled = u.LoginEnabled
+	cfg.LoginURL = u.LoginURL
+	cfg.LoginUserSelector = u.LoginUserSelector
+	cfg.LoginPassSelector = u.LoginPassSelector
+	cfg.LoginSubmitSelector = u.LoginSubmitSelector
+	cfg.LoginSuccessSelector = u.LoginSuccessSelector
+	cfg.LoginCredential = u.LoginCredential
+	if u.VaultFile != "" {
+		cfg.VaultFile = u.VaultFile
+	}
+}
+
+// configChange /api/config/diff'te tek bir alan değişikliği
+type configChange struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+}
+
+// secretConfigFields diff çıktısında değeri gösterilmeyen alanlar
+var secretConfigFields = map[string]bool{
+	"proxy_pass":         true,
+	"ga4_api_secret":     true,
+	"ga4_properties":     true, // Mülk başına api_secret içerebilir
+	"gsc_api_key":        true,
+	"basic_auth_pass":    true,
+	"extra_headers":      true, // Staging token'ları içerebilir
+	"ga4_server_headers": true, // sGTM erişim anahtarı içerebilir
+}
+
+// configFieldMap config'i diff için snake_case alan → değer haritasına çevirir
+func configFieldMap(cfg *config.Config) map[string]interface{} {
+	u := configUpdateFrom(cfg)
+	data, _ := json.Marshal(u)
+	m := make(map[string]interface{})
+	_ = json.Unmarshal(data, &m)
+	delete(m, "proxy_list") // config alanı değil; private_proxies'e eklenir
+	// Private proxy parolaları diff'te görünmez
+	keys := make([]interface{}, 0, len(cfg.PrivateProxies))
+	for _, pp := range cfg.PrivateProxies {
+		keys = append(keys, pp.Protocol+"://"+pp.Key())
+	}
+	m["private_proxies"] = keys
+	return m
+}
+
+// diffConfig iki config arasındaki değişen alanları alfabetik sırayla döner
+func diffConfig(old, new *config.Config) []configChange {
+	a, b := configFieldMap(old), configFieldMap(new)
+	changes := []configChange{}
+	for field, nv := range b {
+		ov := a[field]
+		if reflect.DeepEqual(ov, nv) || (isEmptyConfigValue(ov) && isEmptyConfigValue(nv)) {
+			continue
+		}
+		if secretConfigFields[field] {
+			ov, nv = maskConfigSecret(ov), maskConfigSecret(nv)
+		}
+		changes = append(changes, configChange{Field: field, Old: ov, New: nv})
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes
+}
+
+// isEmptyConfigValue nil, "" ve boş liste aynı sayılır (null → [] değişiklik değildir)
+func isEmptyConfigValue(v interface{}) bool {
+	switch x := v.(type) {
+	case nil:
+		return true
+	case string:
+		return x == ""
+	case []interface{}:
+		return len(x) == 0
+	}
+	return false
+}
+
+// secretMask GET yanıtlarında gizli alanların yerine dönen değer; POST'ta geri gelirse alan değişmez
+const secretMask = "••••"
+
+func maskConfigSecret(v interface{}) interface{} {
+	if isEmptyConfigValue(v) {
+		return ""
+	}
+	return secretMask
+}
+
+// maskHeaderValues "Ad: değer" girişlerinin değerlerini maskeler; header adları görünür kalır
+func maskHeaderValues(headers []string) []string {
+	out := make([]string, 0, len(headers))
+	for _, h := range headers {
+		name, _, _ := strings.Cut(h, ":")
+		out = append(out, strings.TrimSpace(name)+": "+secretMask)
+	}
+	return out
+}
+
+// unmaskHeaderValues değeri maskeli gelen girişleri mevcut config'teki aynı adlı header ile değiştirir.
+// Mevcut karşılığı olmayan maskeli giriş düşürülür (maske header değeri olarak gönderilmez).
+func unmaskHeaderValues(posted, current []string) []string {
+	if posted == nil {
+		return nil
+	}
+	out := make([]string, 0, len(posted))
+	for _, h := range posted {
+		name, value, ok := strings.Cut(h, ":")
+		if !ok || strings.TrimSpace(value) != secretMask {
+			out = append(out, h)
+			continue
+		}
+		for _, c := range current {
+			if cn, _, _ := strings.Cut(c, ":"); strings.EqualFold(strings.TrimSpace(cn), strings.TrimSpace(name)) {
+				out = append(out, c)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// maskGA4Properties mülkleri textarea satırlarına çevirir; api_secret değerleri maskelenir
+func maskGA4Properties(props []config.GA4Property) string {
+	masked := make([]config.GA4Property, 0, len(props))
+	for _, p := range props {
+		if p.APISecret != "" {
+			p.APISecret = secretMask
+		}
+		masked = append(masked, p)
+	}
+	return config.FormatGA4Properties(masked)
+}
+
+// unmaskGA4Properties secret'ı maskeli gelen mülklere mevcut config'teki aynı measurement ID'nin secret'ını geri koyar.
+// Mevcut karşılığı olmayan mülkün secret'ı boşaltılır (maske secret olarak gönderilmez).
+func unmaskGA4Properties(posted, current []config.GA4Property) []config.GA4Property {
+	for i := range posted {
+		if posted[i].APISecret != secretMask {
+			continue
+		}
+		posted[i].APISecret = ""
+		for _, c := range current {
+			if c.MeasurementID == posted[i].MeasurementID {
+				posted[i].APISecret = c.APISecret
+				break
+			}
+		}
+	}
+	return posted
+}
+
+// handleConfigDiff gövdedeki güncelleme kaydedilseydi hangi alanların değişeceğini döner (old → new).
+func (s *Server) handleConfigDiff(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", 405)
+		return
+	}
+	s.mu.Lock()
+	cur := *s.cfg
+	s.mu.Unlock()
+
+	u, err := decodeConfigUpdate(r.Body, &cur)
+	if err != nil {
+		http.Error(w, "Invalid JSON: "+err.Error(), 400)
+		return
+	}
+	if err := u.validate(); err != nil {
+		http.Error(w, err.Error(), 400)
+		return
+	}
+	next := cur
+	u.apply(&next)
+	next.ApplyDefaults()
+	next.ComputeDerived()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"changes": diffConfig(&cur, &next)})
+}
+
+// configDir config.json'ın bulunduğu dizin (loadConfig ile aynı öncelik: exe dizini > çalışma dizini);
+// config.json yoksa saveConfigToFile'ın ilk yazdığı dizin. Profiller bu dizinde tutulur.
+func configDir() string {
+	exeDir := ""
+	if exe, err := os.Executable(); err == nil {
+		exeDir = filepath.Dir(exe)
+	}
+	wd, _ := os.Getwd()
+	for _, d := range []string{exeDir, wd} {
+		if d == "" {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(d, "config.json")); err == nil {
+			return d
+		}
+	}
+	if exeDir != "" {
+		return exeDir
+	}
+	return wd
+}
+
+// handleConfigProfiles /api/config/profiles: GET kayıtlı profiller, POST {"name", "action"} mevcut config'i
+// profil olarak kaydeder (action "save", varsayılan) veya profile geçer (action "switch"; geçilen profil
+// config.json'a da yazılır), DELETE ?name= profili siler
+func (s *Server) handleConfigProfiles(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	switch r.Method {
+	case http.MethodGet:
+		profiles, err := config.ListProfiles(s.configDir)
+		if err != nil {
+			http.Error(w, "Profiller okunamadı: "+err.Error(), 500)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"profiles": profiles})
+	case http.MethodPost:
+		var req struct {
+			Name   string `json:"name"`
+			Action string `json:"action"`
+		}
+		if err := json.NewDecoder(io.LimitReader(r.Body, 1<<16)).Decode(&req); err != nil {
+			http.Error(w, "Invalid JSON", 400)
+			return
+		}
+		req.Name = strings.TrimSpace(req.Name)
+		if err := config.ValidateProfileName(req.Name); err != nil {
+			http.Error(w, err.Error(), 400)
+			return
+		}
+		switch req.Action {
+		case "", "save":
+			s.mu.Lock()
+			cur := *s.cfg
+			s.mu.Unlock()
+			data, err := marshalConfigFile(&cur)
+			if err == nil {
+				err = config.SaveProfile(s.configDir, req.Name, data)
+			}
+			if err != nil {
+				http.Error(w, "Profil kaydedilemedi: "+err.Error(), 500)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(map[string]string{"status": "saved", "name": req.Name})
+		case "switch":
+			changes, err := s.switchProfile(req.Name)
+			switch {
+			case errors.Is(err, config.ErrProfileNotFound):
+				http.Error(w, err.Error(), 404)
+			case errors.Is(err, errRunActive):
+				http.Error(w, err.Error(), 409)
+			case err != nil:
+				http.Error(w, err.Error(), 400)
+			default:
+				json.NewEncoder(w).Encode(map[string]interface{}{"status": "ok", "name": req.Name, "changes": changes})
+			}
+		default:
+			http.Error(w, "action save veya switch olmalı", 400)
+		}
+	case http.MethodDelete:
+		name := r.URL.Query().Get("name")
+		err := config.DeleteProfile(s.configDir, name)
+		switch {
+		case errors.Is(err, config.ErrProfileNotFound):
+			http.Error(w, err.Error(), 404)
+		case err != nil:
+			http.Error(w, err.Error(), 400)
+		default:
+			json.NewEncoder(w).Encode(map[string]string{"status": "deleted", "name": name})
+		}
+	default:
+		http.Error(w, "Method not allowed", 405)
+	}
+}
+
+// errRunActive simülasyon çalışırken config değiştirilemeyen işlemlerde döner
+var errRunActive = errors.New("Simülasyon çalışırken profil değiştirilemez")
+
+// switchProfile profili yükleyip aktif config yapar ve config.json'a yazar (yedekten geri yükleme gibi)
+func (s *Server) switchProfile(name string) ([]configChange, error) {
+	next, err := config.LoadProfile(s.configDir, name)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	if s.cancel != nil {
+		s.mu.Unlock()
+		return nil, errRunActive
+	}
+	old := *s.cfg
+	s.cfg = next
+	cfgCopy := *s.cfg
+	s.mu.Unlock()
+
+	saveConfigToFile(&cfgCopy)
+	s.applyBrowserFlags()
+	go s.locateBrowser()
+	if s.hub != nil {
+		s.hub.Broadcast("log", "🗂️ Config profili yüklendi: "+name)
+	}
+	return diffConfig(&old, &cfgCopy), nil
+}
@@ -0,0 +1,313 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"vgbot/internal/config"
+)
+
+func testConfig() config.Config {
+	cfg := config.Config{
+		TargetDomain:    "example.com",
+		HitsPerMinute:   12,
+		GtagID:          "G-ABC1234567",
+		GA4APISecret:    "secret",
+		ProxyPass:       "hunter2",
+		EnableHTTP3:     true,
+		ActiveWindows:   []string{"09:00-18:00"},
+		Seed:            42,
+		PrivateProxies:  []config.PrivateProxy{{Host: "1.2.3.4", Port: 8080, Pass: "pp", Protocol: "http"}},
+		UsePrivateProxy: true,
+	}
+	cfg.ApplyDefaults()
+	cfg.ComputeDerived()
+	return cfg
+}
+
+func TestConfigUpdateFromRoundTrip(t *testing.T) {
+	cur := testConfig()
+	next := cur
+	u := configUpdateFrom(&cur)
+	u.apply(&next)
+	next.ApplyDefaults()
+	next.ComputeDerived()
+	if changes := diffConfig(&cur, &next); len(changes) != 0 {
+		t.Fatalf("round trip changed fields: %+v", changes)
+	}
+}
+
+func TestPatchKeepsOmittedFields(t *testing.T) {
+	cur := testConfig()
+	u, err := decodeConfigUpdate(strings.NewReader(`{"hits_per_minute": 20}`), &cur)
+	if err != nil {
+		t.Fatal(err)
+	}
+	next := cur
+	u.apply(&next)
+	next.ApplyDefaults()
+	if next.HitsPerMinute != 20 || next.TargetDomain != "example.com" || !next.EnableHTTP3 || len(next.PrivateProxies) != 1 {
+		t.Fatalf("patched config = hpm %d domain %q http3 %v proxies %d",
+			next.HitsPerMinute, next.TargetDomain, next.EnableHTTP3, len(next.PrivateProxies))
+	}
+	changes := diffConfig(&cur, &next)
+	if len(changes) != 1 || changes[0].Field != "hits_per_minute" {
+		t.Fatalf("changes = %+v", changes)
+	}
+}
+
+func TestDiffConfigMasksSecrets(t *testing.T) {
+	cur := testConfig()
+	next := cur
+	next.ProxyPass = "changed"
+	next.GA4APISecret = ""
+	changes := diffConfig(&cur, &next)
+	if len(changes) != 2 {
+		t.Fatalf("changes = %+v", changes)
+	}
+	for _, c := range changes {
+		if c.Old == "hunter2" || c.New == "changed" || c.Old == "secret" {
+			t.Fatalf("secret leaked in diff: %+v", c)
+		}
+	}
+}
+
+func TestConfigGetMasksGA4Secret(t *testing.T) {
+	cfg := testConfig()
+	cfg.GA4Properties = []config.GA4Property{{MeasurementID: "G-PROD000001", Share: 90, Label: "prod", APISecret: "propsecret"}}
+	s := &Server{cfg: &cfg}
+	rec := httptest.NewRecorder()
+	s.handleConfig(rec, httptest.NewRequest(http.MethodGet, "/api/config", nil))
+	var got map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got["ga4_api_secret"] != secretMask {
+		t.Fatalf("ga4_api_secret = %v, want mask", got["ga4_api_secret"])
+	}
+	if want := "G-PROD000001 90 prod " + secretMask; got["ga4_properties"] != want || strings.Contains(rec.Body.String(), "propsecret") {
+		t.Fatalf("ga4_properties = %v, want %q", got["ga4_properties"], want)
+	}
+
+	// Arayüz maskeyi geri gönderirse kayıtlı secret'lar korunur; karşılığı olmayan maske düşer
+	body, _ := json.Marshal(map[string]string{
+		"ga4_api_secret": secretMask,
+		"ga4_properties": "G-PROD000001 80 prod " + secretMask + "\nG-TEST000001 20 sandbox " + secretMask,
+	})
+	u, err := decodeConfigUpdate(bytes.NewReader(body), &cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	next := cfg
+	u.apply(&next)
+	if next.GA4APISecret != "secret" {
+		t.Fatalf("masked round trip set secret to %q", next.GA4APISecret)
+	}
+	want := []config.GA4Property{
+		{MeasurementID: "G-PROD000001", Share: 80, Label: "prod", APISecret: "propsecret"},
+		{MeasurementID: "G-TEST000001", Share: 20, Label: "sandbox"},
+	}
+	if !reflect.DeepEqual(next.GA4Properties, want) {
+		t.Fatalf("ga4_properties = %+v, want %+v", next.GA4Properties, want)
+	}
+	if changes := diffConfig(&cfg, &next); strings.Contains(fmt.Sprint(changes), "propsecret") {
+		t.Fatalf("secret leaked in diff: %+v", changes)
+	}
+}
+
+// oldUIBody yeni alanları (zaman pencereleri, seed, private_proxies, github_*) bilmeyen eski arayüz gövdesi
+const oldUIBody = `{
+	"target_domain": "example.org",
+	"hits_per_minute": 30,
+	"max_pages": 5,
+	"device_type": "mobile",
+	"enable_http3": false,
+	"keywords": ["a", "b"]
+}`
+
+func TestPartialUpdateFromOldUIKeepsNewerFields(t *testing.T) {
+	cur := testConfig()
+	cur.GitHubFetchConcurrency = 16
+	u, err := decodeConfigUpdate(strings.NewReader(oldUIBody), &cur)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := u.validate(); err != nil {
+		t.Fatal(err)
+	}
+	next := cur
+	u.apply(&next)
+	next.ApplyDefaults()
+	next.ComputeDerived()
+
+	if next.TargetDomain != "example.org" || next.HitsPerMinute != 30 || next.DeviceType != "mobile" {
+		t.Fatalf("sent fields not applied: %+v", next)
+	}
+	// Açıkça gönderilen sıfır değer uygulanır
+	if next.EnableHTTP3 {
+		t.Fatal("explicit false for enable_http3 ignored")
+	}
+	// Gönderilmeyen alanlar korunur
+	if len(next.PrivateProxies) != 1 || next.PrivateProxies[0].Pass != "pp" {
+		t.Fatalf("private proxies = %+v", next.PrivateProxies)
+	}
+	if next.Seed != 42 || len(next.ActiveWindows) != 1 || next.GitHubFetchConcurrency != 16 {
+		t.Fatalf("seed %d windows %v github concurrency %d", next.Seed, next.ActiveWindows, next.GitHubFetchConcurrency)
+	}
+	if next.GA4APISecret != "secret" || next.ProxyPass != "hunter2" || next.GtagID != "G-ABC1234567" {
+		t.Fatal("omitted secrets/IDs were reset")
+	}
+}
+
+func TestPartialUpdateNullKeepsValue(t *testing.T) {
+	cur := testConfig()
+	u, err := decodeConfigUpdate(strings.NewReader(`{"seed": null, "target_domain": null}`), &cur)
+	if err != nil {
+		t.Fatal(err)
+	}
+	next := cur
+	u.apply(&next)
+	if next.Seed != 42 || next.TargetDomain != "example.com" {
+		t.Fatalf("null reset value: seed %d domain %q", next.Seed, next.TargetDomain)
+	}
+}
+
+func TestEventMappingUpdateReplaces(t *testing.T) {
+	cur := testConfig()
+	cur.GA4EventMapping = config.GA4EventMapping{Rename: map[string]string{"scroll": "page_scroll", "click": "link_click"}}
+
+	u, err := decodeConfigUpdate(strings.NewReader(`{"hits_per_minute": 20}`), &cur)
+	if err != nil {
+		t.Fatal(err)
+	}
+	next := cur
+	u.apply(&next)
+	if len(next.GA4EventMapping.Rename) != 2 {
+		t.Fatalf("omitted mapping changed: %+v", next.GA4EventMapping)
+	}
+
+	u, err = decodeConfigUpdate(strings.NewReader(`{"ga4_event_mapping": {"rename": {"scroll": "deep_scroll"}}}`), &cur)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := u.validate(); err != nil {
+		t.Fatal(err)
+	}
+	next = cur
+	u.apply(&next)
+	if got := next.GA4EventMapping.Rename; len(got) != 1 || got["scroll"] != "deep_scroll" {
+		t.Fatalf("mapping = %+v", got)
+	}
+	if cur.GA4EventMapping.Rename["scroll"] != "page_scroll" {
+		t.Fatal("update modified the current config's map")
+	}
+
+	u, _ = decodeConfigUpdate(strings.NewReader(`{"ga4_event_mapping": {"rename": {"scroll": "ga_scroll"}}}`), &cur)
+	if err := u.validate(); err == nil {
+		t.Fatal("reserved prefix accepted")
+	}
+}
+
+func TestConfigProfilesHandler(t *testing.T) {
+	cfg := testConfig()
+	cfg.ApplyDefaults()
+	s := &Server{cfg: &cfg, configDir: t.TempDir()}
+	do := func(method, target, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		s.handleConfigProfiles(rec, httptest.NewRequest(method, target, strings.NewReader(body)))
+		return rec
+	}
+
+	if rec := do(http.MethodPost, "/api/config/profiles", `{"name":"low-and-slow"}`); rec.Code != http.StatusCreated {
+		t.Fatalf("save = %d %s", rec.Code, rec.Body)
+	}
+	saved, err := config.LoadProfile(s.configDir, "low-and-slow")
+	if err != nil || saved.HitsPerMinute != cfg.HitsPerMinute || saved.TargetDomain != cfg.TargetDomain {
+		t.Fatalf("saved profile = %+v, %v", saved, err)
+	}
+
+	var list struct {
+		Profiles []config.ProfileInfo `json:"profiles"`
+	}
+	json.Unmarshal(do(http.MethodGet, "/api/config/profiles", "").Body.Bytes(), &list)
+	if len(list.Profiles) != 1 || list.Profiles[0].Name != "low-and-slow" {
+		t.Fatalf("list = %+v", list)
+	}
+
+	s.cancel = func() {}
+	if rec := do(http.MethodPost, "/api/config/profiles", `{"name":"low-and-slow","action":"switch"}`); rec.Code != http.StatusConflict {
+		t.Errorf("switch while running = %d", rec.Code)
+	}
+	s.cancel = nil
+	for body, want := range map[string]int{
+		`{"name":"missing","action":"switch"}`: http.StatusNotFound,
+		`{"name":"../config"}`:                 http.StatusBadRequest,
+		`{"name":"x","action":"merge"}`:        http.StatusBadRequest,
+	} {
+		if rec := do(http.MethodPost, "/api/config/profiles", body); rec.Code != want {
+			t.Errorf("%s = %d, want %d", body, rec.Code, want)
+		}
+	}
+
+	if rec := do(http.MethodDelete, "/api/config/profiles?name=low-and-slow", ""); rec.Code != http.StatusOK {
+		t.Errorf("delete = %d %s", rec.Code, rec.Body)
+	}
+	if rec := do(http.MethodDelete, "/api/config/profiles?name=low-and-slow", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("second delete = %d", rec.Code)
+	}
+}
+
+func TestConfigGetMasksStagingCredentials(t *testing.T) {
+	cfg := testConfig()
+	cfg.BasicAuthUser, cfg.BasicAuthPass = "staging", "pw"
+	cfg.ExtraHeaders = []string{"X-Staging-Token: abc", "X-Env: preprod"}
+	cfg.GA4ServerHeaders = []string{"X-Gateway-Key: sgtmkey"}
+	s := &Server{cfg: &cfg}
+	rec := httptest.NewRecorder()
+	s.handleConfig(rec, httptest.NewRequest(http.MethodGet, "/api/config", nil))
+	var got struct {
+		BasicAuthPass string   `json:"basic_auth_pass"`
+		ExtraHeaders  []string `json:"extra_headers"`
+		ServerHeaders []string `json:"ga4_server_headers"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.BasicAuthPass != secretMask || strings.Contains(rec.Body.String(), "abc") || strings.Contains(rec.Body.String(), "sgtmkey") {
+		t.Fatalf("credentials leaked: %s", rec.Body)
+	}
+	if want := []string{"X-Staging-Token: " + secretMask, "X-Env: " + secretMask}; !reflect.DeepEqual(got.ExtraHeaders, want) {
+		t.Fatalf("extra_headers = %q, want %q", got.ExtraHeaders, want)
+	}
+	if want := []string{"X-Gateway-Key: " + secretMask}; !reflect.DeepEqual(got.ServerHeaders, want) {
+		t.Fatalf("ga4_server_headers = %q, want %q", got.ServerHeaders, want)
+	}
+
+	// Maskeli değerler geri gelirse korunur; yeni değer ve yeni header uygulanır
+	body, _ := json.Marshal(map[string]interface{}{
+		"basic_auth_pass":    secretMask,
+		"extra_headers":      []string{"x-staging-token: " + secretMask, "X-Env: staging", "X-Gone: " + secretMask},
+		"ga4_server_headers": []string{"X-Gateway-Key: " + secretMask},
+	})
+	u, err := decodeConfigUpdate(bytes.NewReader(body), &cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	next := cfg
+	u.apply(&next)
+	if next.BasicAuthPass != "pw" {
+		t.Errorf("basic_auth_pass = %q", next.BasicAuthPass)
+	}
+	if want := []string{"X-Staging-Token: abc", "X-Env: staging"}; !reflect.DeepEqual(next.ExtraHeaders, want) {
+		t.Errorf("extra_headers = %q, want %q", next.ExtraHeaders, want)
+	}
+	if want := []string{"X-Gateway-Key: sgtmkey"}; !reflect.DeepEqual(next.GA4ServerHeaders, want) {
+		t.Errorf("ga4_server_headers = %q, want %q", next.GA4ServerHeaders, want)
+	}
+}
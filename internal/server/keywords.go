@@ -0,0 +1,191 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"vgbot/internal/simulator"
+	"vgbot/pkg/antidetect"
+)
+
+// maxClusterKeywordLen tek cluster anahtar kelimesinin en fazla uzunluğu
+const maxClusterKeywordLen = 200
+
+// keywordClusterRequest POST /api/keywords/clusters gövdesi
+type keywordClusterRequest struct {
+	Keyword string `json:"keyword"`
+	Intent  string `json:"intent"` // Boşsa informational
+}
+
+// initKeywordClusters keyword_clusters_file'dan cluster'ları yükler. Cluster varsa simülatör ve kampanyalar
+// arama referrer kelimelerini düz keywords listesi yerine bu yöneticiden çeker. Yönetici tüm çalıştırmalar
+// arasında paylaşıldığından tek bir çalıştırmanın seed'ine bağlanmaz.
+func (s *Server) initKeywordClusters() {
+	s.keywords = antidetect.NewKeywordClusterManager(antidetect.RotationAntiPattern, nil)
+	if err := s.keywords.LoadClustersFile(s.cfg.KeywordClustersFile); err != nil {
+		log.Printf("[WARN] Keyword cluster'ları yüklenemedi: %v", err)
+	}
+}
+
+// applyKeywordClusters simülatörü cluster yöneticisine bağlar (Run'dan önce)
+func (s *Server) applyKeywordClusters(sim *simulator.Simulator) {
+	if s.keywords != nil {
+		sim.SetKeywordSource(s.keywords)
+	}
+}
+
+// reloadKeywordClusters mevcut cluster'ları keyword_clusters_file'daki ile değiştirir (yedekten geri yükleme)
+func (s *Server) reloadKeywordClusters() error {
+	if s.keywords == nil {
+		return nil
+	}
+	s.mu.Lock()
+	path := s.cfg.KeywordClustersFile
+	s.mu.Unlock()
+	for _, c := range s.keywords.ExportClusters() {
+		s.keywords.RemoveCluster(c.ID)
+	}
+	return s.keywords.LoadClustersFile(path)
+}
+
+// saveKeywordClusters cluster'ları keyword_clusters_file'a yazar
+func (s *Server) saveKeywordClusters() error {
+	s.mu.Lock()
+	path := s.cfg.KeywordClustersFile
+	s.mu.Unlock()
+	if path == "" {
+		return nil
+	}
+	return s.keywords.SaveClustersFile(path)
+}
+
+// handleKeywordClusters /api/keywords/clusters: GET cluster'lar ve rotasyon istatistikleri,
+// POST tek anahtar kelime + niyetten cluster oluştur (varyasyon/long-tail/modifier'lar üretilir)
+func (s *Server) handleKeywordClusters(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	switch r.Method {
+	case http.MethodGet:
+		stats := s.keywords.GetClusterStats()
+		stats["clusters"] = s.keywords.ExportClusters()
+		json.NewEncoder(w).Encode(stats)
+	case http.MethodPost:
+		var req keywordClusterRequest
+		if err := json.NewDecoder(io.LimitReader(r.Body, 1<<20)).Decode(&req); err != nil {
+			http.Error(w, "Invalid JSON", 400)
+			return
+		}
+		keyword := strings.TrimSpace(req.Keyword)
+		intent := antidetect.SearchIntent(strings.ToLower(strings.TrimSpace(req.Intent)))
+		if intent == "" {
+			intent = antidetect.IntentInformational
+		}
+		if err := validateClusterInput(keyword, intent, 1); err != nil {
+			http.Error(w, err.Error(), 400)
+			return
+		}
+		cluster := s.keywords.CreateClusterFromKeyword(keyword, intent)
+		if err := s.saveKeywordClusters(); err != nil {
+			http.Error(w, "Keyword cluster'ları kaydedilemedi: "+err.Error(), 500)
+			return
+		}
+		created, _ := s.keywords.GetCluster(cluster.ID)
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(created)
+	default:
+		http.Error(w, "Method not allowed", 405)
+	}
+}
+
+// handleKeywordCluster /api/keywords/clusters/{id|export|import}: GET/DELETE tek cluster,
+// GET export (JSON dosyası), POST import (export formatı; ?replace=true mevcutları siler)
+func (s *Server) handleKeywordCluster(w http.ResponseWriter, r *http.Request) {
+	id := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/keywords/clusters/"), "/")
+	switch {
+	case id == "export" && r.Method == http.MethodGet:
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Disposition", "attachment; filename=keyword_clusters.json")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.Encode(s.keywords.ExportClusters())
+	case id == "import" && r.Method == http.MethodPost:
+		var clusters []*antidetect.KeywordCluster
+		if err := json.NewDecoder(io.LimitReader(r.Body, 4<<20)).Decode(&clusters); err != nil {
+			http.Error(w, "Invalid JSON", 400)
+			return
+		}
+		for i, c := range clusters {
+			if c == nil {
+				http.Error(w, fmt.Sprintf("cluster %d: boş", i+1), 400)
+				return
+			}
+			c.PrimaryKW = strings.TrimSpace(c.PrimaryKW)
+			if c.Intent == "" {
+				c.Intent = antidetect.IntentInformational
+			}
+			if err := validateClusterInput(c.PrimaryKW, c.Intent, c.Weight); err != nil {
+				http.Error(w, fmt.Sprintf("cluster %d: %v", i+1, err), 400)
+				return
+			}
+			if c.Weight == 0 {
+				c.Weight = 1
+			}
+			if c.Name == "" {
+				c.Name = c.PrimaryKW
+			}
+		}
+		if r.URL.Query().Get("replace") == "true" {
+			for _, c := range s.keywords.ExportClusters() {
+				s.keywords.RemoveCluster(c.ID)
+			}
+		}
+		s.keywords.ImportClusters(clusters)
+		if err := s.saveKeywordClusters(); err != nil {
+			http.Error(w, "Keyword cluster'ları kaydedilemedi: "+err.Error(), 500)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"imported": len(clusters), "total_clusters": s.keywords.ClusterCount()})
+	case id != "" && r.Method == http.MethodGet:
+		cluster, ok := s.keywords.GetCluster(id)
+		if !ok {
+			http.Error(w, "Cluster bulunamadı", 404)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(cluster)
+	case id != "" && id != "export" && id != "import" && r.Method == http.MethodDelete:
+		if !s.keywords.RemoveCluster(id) {
+			http.Error(w, "Cluster bulunamadı", 404)
+			return
+		}
+		if err := s.saveKeywordClusters(); err != nil {
+			http.Error(w, "Keyword cluster'ları kaydedilemedi: "+err.Error(), 500)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"status": "deleted"})
+	default:
+		http.Error(w, "Method not allowed", 405)
+	}
+}
+
+// validateClusterInput cluster anahtar kelimesini, niyetini ve ağırlığını doğrular
+func validateClusterInput(keyword string, intent antidetect.SearchIntent, weight float64) error {
+	if keyword == "" {
+		return fmt.Errorf("anahtar kelime gerekli")
+	}
+	if len(keyword) > maxClusterKeywordLen {
+		return fmt.Errorf("anahtar kelime en fazla %d karakter olabilir", maxClusterKeywordLen)
+	}
+	if !intent.Valid() {
+		return fmt.Errorf("geçersiz arama niyeti %q (informational, navigational, transactional, commercial, local)", intent)
+	}
+	if weight < 0 || weight > 1 {
+		return fmt.Errorf("ağırlık 0-1 arasında olmalı: %v", weight)
+	}
+	return nil
+}
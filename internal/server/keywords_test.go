@@ -0,0 +1,88 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"vgbot/pkg/antidetect"
+)
+
+func TestKeywordClusterAPI(t *testing.T) {
+	cfg := testConfig()
+	cfg.KeywordClustersFile = filepath.Join(t.TempDir(), "clusters.json")
+	s := &Server{cfg: &cfg}
+	s.initKeywordClusters()
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		h := s.handleKeywordCluster
+		if path == "/api/keywords/clusters" {
+			h = s.handleKeywordClusters
+		}
+		h(rec, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return rec
+	}
+
+	rec := do(http.MethodPost, "/api/keywords/clusters", `{"keyword":"running shoes","intent":"Transactional"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create = %d %s", rec.Code, rec.Body)
+	}
+	var created antidetect.KeywordCluster
+	json.Unmarshal(rec.Body.Bytes(), &created)
+	if created.ID == "" || created.PrimaryKW != "running shoes" || created.Intent != antidetect.IntentTransactional || len(created.LongTails) == 0 {
+		t.Fatalf("created = %+v", created)
+	}
+	for _, body := range []string{`{"keyword":" "}`, `{"keyword":"shoes","intent":"buying"}`} {
+		if rec := do(http.MethodPost, "/api/keywords/clusters", body); rec.Code != 400 {
+			t.Errorf("create %s = %d", body, rec.Code)
+		}
+	}
+
+	// Simülatörün kaynağı: tek cluster varken her kelime ondan gelir
+	kw, clusterID, ok := s.keywords.NextKeyword()
+	if !ok || clusterID != created.ID || !strings.Contains(kw, "shoe") {
+		t.Fatalf("NextKeyword = %q, %q, %v", kw, clusterID, ok)
+	}
+	s.keywords.RecordUsage(kw, clusterID, "sess", true, 120)
+
+	rec = do(http.MethodGet, "/api/keywords/clusters", "")
+	var list struct {
+		Clusters      []antidetect.KeywordCluster `json:"clusters"`
+		TotalClusters int                         `json:"total_clusters"`
+		TotalUsage    int                         `json:"total_usage"`
+	}
+	json.Unmarshal(rec.Body.Bytes(), &list)
+	if list.TotalClusters != 1 || list.TotalUsage != 1 || len(list.Clusters) != 1 || list.Clusters[0].UsageCount != 1 {
+		t.Fatalf("list = %s", rec.Body)
+	}
+
+	export := do(http.MethodGet, "/api/keywords/clusters/export", "").Body.String()
+	if rec := do(http.MethodDelete, "/api/keywords/clusters/"+created.ID, ""); rec.Code != 200 || s.keywords.ClusterCount() != 0 {
+		t.Fatalf("delete = %d", rec.Code)
+	}
+	if rec := do(http.MethodDelete, "/api/keywords/clusters/"+created.ID, ""); rec.Code != 404 {
+		t.Errorf("delete missing = %d", rec.Code)
+	}
+
+	if rec := do(http.MethodPost, "/api/keywords/clusters/import", export); rec.Code != 200 {
+		t.Fatalf("import = %d %s", rec.Code, rec.Body)
+	}
+	if rec := do(http.MethodPost, "/api/keywords/clusters/import?replace=true", `[{"primary_kw":"trail shoes","intent":"commercial"}]`); rec.Code != 200 {
+		t.Fatalf("import replace = %d %s", rec.Code, rec.Body)
+	}
+	if rec := do(http.MethodPost, "/api/keywords/clusters/import", `[{"primary_kw":"x","weight":2}]`); rec.Code != 400 {
+		t.Errorf("import invalid weight = %d", rec.Code)
+	}
+
+	// Kalıcılık: yeniden başlatmada dosyadan yüklenir
+	s2 := &Server{cfg: &cfg}
+	s2.initKeywordClusters()
+	got := s2.keywords.ExportClusters()
+	if len(got) != 1 || got[0].PrimaryKW != "trail shoes" || got[0].Weight != 1 || got[0].ID == "" {
+		t.Fatalf("reloaded = %+v", got)
+	}
+}
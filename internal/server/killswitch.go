@@ -0,0 +1,418 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// killSwitchTokenTTL onay token'ının geçerlilik süresi
+const killSwitchTokenTTL = 2 * time.Minute
+
+// errKillSwitch kill switch devredeyken başlatma isteklerinin hatası
+var errKillSwitch = errors.New("Kill switch devrede; yeniden kurulana kadar (/api/killswitch rearm) çalıştırma başlatılamaz")
+
+// killSwitchSummary devreye alındığında durdurulanlar
+type killSwitchSummary struct {
+	StoppedRun       bool `json:"stopped_run"`
+	StoppedCampaigns int  `json:"stopped_campaigns"`
+	CancelledTasks   int  `json:"cancelled_tasks"`
+	SchedulerStopped bool `json:"scheduler_stopped"`
+	MonitorStopped   bool `json:"monitor_stopped"`
+}
+
+// killSwitchState kill switch'in kalıcı durumu
+type killSwitchState struct {
+	Engaged   bool               `json:"engaged"`
+	EngagedAt *time.Time         `json:"engaged_at,omitempty"`
+	Reason    string             `json:"reason,omitempty"`
+	Summary   *killSwitchSummary `json:"summary,omitempty"`
+}
+
+// killSwitch acil durdurma anahtarı. Devredeyken simülasyon, kampanya, test ziyareti, scheduler, uptime
+// monitor ve cluster task'ları başlatılamaz. Ayrı çalışan "vgbot master" aynı dosyayı izler ve kendini durdurur. Durum kill_switch_file'da tutulur: sunucu yeniden başlasa da yeniden kurulana
+// (rearm) kadar devrede kalır. Her işlem tek kullanımlık, kısa ömürlü bir onay token'ı ister.
+type killSwitch struct {
+	mu      sync.Mutex
+	path    string
+	state   killSwitchState
+	token   string // Bekleyen onay token'ı (boş = yok)
+	action  string // Token'ın verildiği işlem: engage veya rearm
+	expires time.Time
+}
+
+// loadKillSwitch durumu dosyadan okur; dosya yoksa anahtar kapalı başlar
+func loadKillSwitch(path string) *killSwitch {
+	k := &killSwitch{path: path}
+	if path == "" {
+		return k
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("[WARN] Kill switch durumu okunamadı: %v", err)
+		}
+		return k
+	}
+	if err := json.Unmarshal(data, &k.state); err != nil {
+		log.Printf("[WARN] Kill switch durumu geçersiz: %v", err)
+	}
+	return k
+}
+
+// engaged anahtar devrede mi (nil = kapalı)
+func (k *killSwitch) engaged() bool {
+	if k == nil {
+		return false
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.state.Engaged
+}
+
+// snapshot durumun kopyası
+func (k *killSwitch) snapshot() killSwitchState {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.state
+}
+
+// issue işlem için yeni onay token'ı üretir; önceki bekleyen token geçersiz olur
+func (k *killSwitch) issue(action string, now time.Time) (string, time.Time) {
+	b := make([]byte, 4)
+	rand.Read(b)
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.token = strings.ToUpper(hex.EncodeToString(b))
+	k.action = action
+	k.expires = now.Add(killSwitchTokenTTL)
+	return k.token, k.expires
+}
+
+// consume token işlem için geçerliyse tüketir
+func (k *killSwitch) consume(action, token string, now time.Time) bool {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	ok := k.token != "" && k.action == action && now.Before(k.expires) &&
+		subtle.ConstantTimeCompare([]byte(k.token), []byte(strings.ToUpper(strings.TrimSpace(token)))) == 1
+	if ok {
+		k.token = ""
+	}
+	return ok
+}
+
+// set durumu günceller ve dosyaya yazar
+func (k *killSwitch) set(state killSwitchState) error {
+	k.mu.Lock()
+	k.state = state
+	path := k.path
+	k.mu.Unlock()
+	if path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// engageKillSwitch ana simülasyonu ve tüm kampanyaları durdurur, scheduler'ı ve uptime monitor'ü kapatır ve
+// gömülü master'daki açık task'ları iptal eder (master yeniden kurulana kadar yeni task kabul etmez)
+func (s *Server) engageKillSwitch(reason string) (killSwitchState, error) {
+	var sum killSwitchSummary
+	s.mu.Lock()
+	sum.StoppedRun = s.run != nil
+	s.stopRun()
+	m := s.master
+	sum.MonitorStopped = s.monitorCancel != nil
+	s.mu.Unlock()
+	s.stopMonitor()
+
+	sum.StoppedCampaigns = s.campaigns.stopAll()
+	if s.scheduler != nil {
+		sum.SchedulerStopped = s.scheduler.IsRunning()
+		s.scheduler.Stop()
+	}
+	if m != nil {
+		sum.CancelledTasks = m.Halt()
+	}
+	if s.notifier != nil && s.notifier.IsEnabled() {
+		s.notifier.StopPeriodicReporting()
+	}
+
+	now := time.Now()
+	state := killSwitchState{Engaged: true, EngagedAt: &now, Reason: reason, Summary: &sum}
+	err := s.kill.set(state)
+	if s.hub != nil {
+		s.hub.Broadcast("log", fmt.Sprintf("🛑 Kill switch devrede: %d kampanya durduruldu, %d cluster task'ı iptal edildi (%s)",
+			sum.StoppedCampaigns, sum.CancelledTasks, reason))
+	}
+	return state, err
+}
+
+// rearmKillSwitch anahtarı kaldırır; enable_scheduler açıksa scheduler yeniden başlar. Durdurulan
+// çalıştırmalar ve iptal edilen task'lar kendiliğinden geri gelmez.
+func (s *Server) rearmKillSwitch() (killSwitchState, error) {
+	state := killSwitchState{}
+	if err := s.kill.set(state); err != nil {
+		return state, err
+	}
+	s.mu.Lock()
+	m := s.master
+	cfgCopy := *s.cfg
+	s.mu.Unlock()
+	if m != nil {
+		m.Resume()
+	}
+	if s.scheduler != nil && cfgCopy.EnableScheduler {
+		s.applySchedulerWindows(&cfgCopy)
+		s.scheduler.Start()
+	}
+	if s.hub != nil {
+		s.hub.Broadcast("log", "✅ Kill switch yeniden kuruldu; çalıştırmalar tekrar başlatılabilir")
+	}
+	return state, nil
+}
+
+// rejectIfKilled kill switch devredeyse 423 döner
+func (s *Server) rejectIfKilled(w http.ResponseWriter) bool {
+	if !s.kill.engaged() {
+		return false
+	}
+	http.Error(w, errKillSwitch.Error(), http.StatusLocked)
+	return true
+}
+
+// killSwitchRequest POST /api/killswitch gövdesi
+type killSwitchRequest struct {
+	Action string `json:"action"` // engage (varsayılan) veya rearm
+	Token  string `json:"token"`  // Boşsa onay token'ı üretilir ve 428 ile döner
+	Reason string `json:"reason"`
+}
+
+// handleKillSwitch /api/killswitch: GET durum; POST iki adımlı engage/rearm. Token'sız istek işlemi yapmaz,
+// 428 ile tek kullanımlık onay token'ı döner; token'lı ikinci istek işlemi uygular.
+func (s *Server) handleKillSwitch(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	switch r.Method {
+	case http.MethodGet:
+		json.NewEncoder(w).Encode(s.kill.snapshot())
+		return
+	case http.MethodPost:
+	default:
+		http.Error(w, "Method not allowed", 405)
+		return
+	}
+
+	var req killSwitchRequest
+	if body, _ := io.ReadAll(io.LimitReader(r.Body, 1<<16)); len(bytes.TrimSpace(body)) > 0 {
+		if err := json.Unmarshal(body, &req); err != nil {
+			http.Error(w, "Invalid JSON", 400)
+			return
+		}
+	}
+	if req.Action == "" {
+		req.Action = "engage"
+	}
+	if req.Action != "engage" && req.Action != "rearm" {
+		http.Error(w, "action engage veya rearm olmalı", 400)
+		return
+	}
+	if req.Action == "rearm" && !s.kill.engaged() {
+		http.Error(w, "Kill switch devrede değil", http.StatusConflict)
+		return
+	}
+
+	now := time.Now()
+	if req.Token == "" {
+		token, expires := s.kill.issue(req.Action, now)
+		w.WriteHeader(http.StatusPreconditionRequired)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"confirm_required": true,
+			"action":           req.Action,
+			"confirm_token":    token,
+			"expires_at":       expires,
+		})
+		return
+	}
+	if !s.kill.consume(req.Action, req.Token, now) {
+		http.Error(w, "Onay token'ı geçersiz veya süresi dolmuş", http.StatusForbidden)
+		return
+	}
+
+	var state killSwitchState
+	var err error
+	if req.Action == "engage" {
+		reason := strings.TrimSpace(req.Reason)
+		if len(reason) > 500 {
+			reason = reason[:500]
+		}
+		state, err = s.engageKillSwitch(reason)
+	} else {
+		state, err = s.rearmKillSwitch()
+	}
+	if err != nil {
+		// Durdurma uygulandı; yalnızca kalıcı kayıt başarısız
+		log.Printf("[WARN] Kill switch durumu kaydedilemedi: %v", err)
+	}
+	json.NewEncoder(w).Encode(state)
+}
+
+// RunKillSwitch "vgbot killswitch" alt komutu: çalışan sunucunun kill switch'ini devreye alır, yeniden kurar
+// veya durumunu gösterir. Sunucunun verdiği onay token'ı yazılmadan (-yes yoksa) işlem yapılmaz.
+func RunKillSwitch(args []string) error {
+	fs := flag.NewFlagSet("killswitch", flag.ContinueOnError)
+	addr := fs.String("server", "http://127.0.0.1:8754", "VGBot web sunucusu adresi")
+	reason := fs.String("reason", "", "Durdurma nedeni (raporlarda görünür)")
+	rearm := fs.Bool("rearm", false, "Kill switch'i kaldır ve çalıştırmalara yeniden izin ver")
+	status := fs.Bool("status", false, "Yalnızca durumu göster")
+	yes := fs.Bool("yes", false, "Onay token'ını sormadan uygula")
+	master := fs.String("master", "", "Başka makinede çalışan distributed master adresi; kill switch ile birlikte durdurulur veya yeniden kurulur")
+	masterSecret := fs.String("master-secret", "", "Master'ın worker secret'ı")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	endpoint := strings.TrimSuffix(*addr, "/") + "/api/killswitch"
+	client := &http.Client{Timeout: 30 * time.Second}
+
+	if *status {
+		resp, err := client.Get(endpoint)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		var state killSwitchState
+		if err := json.NewDecoder(resp.Body).Decode(&state); err != nil {
+			return fmt.Errorf("killswitch: %s", resp.Status)
+		}
+		printKillSwitchState(os.Stdout, state)
+		return nil
+	}
+
+	req := killSwitchRequest{Action: "engage", Reason: *reason}
+	if *rearm {
+		req.Action = "rearm"
+	}
+	post := func() (*http.Response, error) {
+		body, _ := json.Marshal(req)
+		return client.Post(endpoint, "application/json", bytes.NewReader(body))
+	}
+
+	resp, err := post()
+	if err != nil {
+		return err
+	}
+	var challenge struct {
+		Token   string    `json:"confirm_token"`
+		Expires time.Time `json:"expires_at"`
+	}
+	if resp.StatusCode != http.StatusPreconditionRequired {
+		msg, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return fmt.Errorf("killswitch: %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	err = json.NewDecoder(resp.Body).Decode(&challenge)
+	resp.Body.Close()
+	if err != nil || challenge.Token == "" {
+		return fmt.Errorf("killswitch: onay token'ı alınamadı")
+	}
+
+	if !*yes {
+		if req.Action == "engage" {
+			fmt.Println("🛑 Tüm simülasyonlar ve kampanyalar durdurulacak, cluster task'ları iptal edilecek, scheduler ve uptime monitor kapatılacak.")
+		} else {
+			fmt.Println("✅ Kill switch kaldırılacak; çalıştırmalar ve scheduler yeniden başlatılabilir.")
+		}
+		fmt.Printf("Onaylamak için token'ı yazın (%s, %s'e kadar geçerli): ", challenge.Token, challenge.Expires.Local().Format("15:04:05"))
+		line, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if !strings.EqualFold(strings.TrimSpace(line), challenge.Token) {
+			return fmt.Errorf("killswitch: onaylanmadı, işlem yapılmadı")
+		}
+	}
+
+	req.Token = challenge.Token
+	resp, err = post()
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("killswitch: %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	var state killSwitchState
+	if err := json.NewDecoder(resp.Body).Decode(&state); err != nil {
+		return err
+	}
+	printKillSwitchState(os.Stdout, state)
+	if *master != "" {
+		return haltMaster(client, *master, *masterSecret, req.Action == "rearm")
+	}
+	return nil
+}
+
+// haltMaster ayrı çalışan bir distributed master'ı durdurur veya (rearm) yeniden kurar. Aynı makinedeki
+// master kill_switch_file'ı kendisi izler; bu çağrı master başka makinedeyken gerekir.
+func haltMaster(client *http.Client, addr, secret string, resume bool) error {
+	path := "/api/v1/master/halt"
+	if resume {
+		path = "/api/v1/master/resume"
+	}
+	req, err := http.NewRequest(http.MethodPost, strings.TrimSuffix(addr, "/")+path, nil)
+	if err != nil {
+		return err
+	}
+	if secret != "" {
+		req.Header.Set("Authorization", "Bearer "+secret)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("killswitch: master: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("killswitch: master: %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	var st struct {
+		Halted    bool `json:"halted"`
+		Cancelled int  `json:"cancelled_tasks"`
+	}
+	json.NewDecoder(resp.Body).Decode(&st)
+	if st.Halted {
+		fmt.Printf("Master %s: durduruldu, iptal edilen task: %d\n", addr, st.Cancelled)
+	} else {
+		fmt.Printf("Master %s: yeniden kuruldu\n", addr)
+	}
+	return nil
+}
+
+// printKillSwitchState durumu konsola yazar
+func printKillSwitchState(w io.Writer, state killSwitchState) {
+	if !state.Engaged {
+		fmt.Fprintln(w, "Kill switch: kapalı")
+		return
+	}
+	fmt.Fprintf(w, "Kill switch: DEVREDE (%s)\n", state.EngagedAt.Local().Format("2006-01-02 15:04:05"))
+	if state.Reason != "" {
+		fmt.Fprintf(w, "  Neden: %s\n", state.Reason)
+	}
+	if sum := state.Summary; sum != nil {
+		fmt.Fprintf(w, "  Ana simülasyon durduruldu: %v, kampanya: %d, iptal edilen cluster task'ı: %d, scheduler durduruldu: %v, monitor durduruldu: %v\n",
+			sum.StoppedRun, sum.StoppedCampaigns, sum.CancelledTasks, sum.SchedulerStopped, sum.MonitorStopped)
+	}
+}
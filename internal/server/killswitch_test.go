@@ -0,0 +1,90 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestKillSwitch(t *testing.T) {
+	cfg := testConfig()
+	path := filepath.Join(t.TempDir(), "killswitch.json")
+	s := &Server{cfg: &cfg, campaigns: newCampaignManager(), kill: loadKillSwitch(path)}
+
+	post := func(body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		s.handleKillSwitch(rec, httptest.NewRequest(http.MethodPost, "/api/killswitch", strings.NewReader(body)))
+		return rec
+	}
+	challenge := func(action string) string {
+		rec := post(`{"action":"` + action + `"}`)
+		var resp struct {
+			Token string `json:"confirm_token"`
+		}
+		json.Unmarshal(rec.Body.Bytes(), &resp)
+		if rec.Code != http.StatusPreconditionRequired || resp.Token == "" {
+			t.Fatalf("%s challenge = %d %s", action, rec.Code, rec.Body)
+		}
+		return resp.Token
+	}
+
+	// Token'sız istek yalnızca onay token'ı verir
+	token := challenge("engage")
+	if s.kill.engaged() {
+		t.Fatal("engaged without confirmation")
+	}
+	if rec := post(`{"token":"WRONG"}`); rec.Code != http.StatusForbidden {
+		t.Errorf("wrong token = %d", rec.Code)
+	}
+	if rec := post(`{"action":"rearm","token":"` + token + `"}`); rec.Code != http.StatusConflict {
+		t.Errorf("rearm while off = %d", rec.Code)
+	}
+	monitorCtx, stopMonitor := context.WithCancel(context.Background())
+	defer stopMonitor()
+	s.monitorCancel = stopMonitor
+	if rec := post(`{"token":"` + strings.ToLower(token) + `","reason":"customer asked to halt"}`); rec.Code != 200 {
+		t.Fatalf("engage = %d %s", rec.Code, rec.Body)
+	}
+	if monitorCtx.Err() == nil {
+		t.Error("uptime monitor kept running after engage")
+	}
+	if rec := post(`{"token":"` + token + `"}`); rec.Code != http.StatusForbidden {
+		t.Errorf("reused token = %d", rec.Code)
+	}
+
+	// Devredeyken çalıştırma başlatılamaz; durum yeniden başlatmada korunur
+	rec := httptest.NewRecorder()
+	s.handleStart(rec, httptest.NewRequest(http.MethodPost, "/api/start", nil))
+	if rec.Code != http.StatusLocked {
+		t.Errorf("start while engaged = %d", rec.Code)
+	}
+	if err := s.startCampaign(s.campaigns.add("shop", &cfg), "tr"); !errors.Is(err, errKillSwitch) {
+		t.Errorf("campaign start = %v", err)
+	}
+	rec = httptest.NewRecorder()
+	s.handleMonitor(rec, httptest.NewRequest(http.MethodPost, "/api/monitor", strings.NewReader(`{"action":"start"}`)))
+	if rec.Code != http.StatusLocked {
+		t.Errorf("monitor start while engaged = %d", rec.Code)
+	}
+	if st := loadKillSwitch(path).snapshot(); !st.Engaged || st.Reason != "customer asked to halt" || st.Summary == nil || !st.Summary.MonitorStopped {
+		t.Errorf("persisted state = %+v", st)
+	}
+
+	// Rearm token'ı engage için kullanılamaz
+	token = challenge("rearm")
+	if rec := post(`{"token":"` + token + `"}`); rec.Code != http.StatusForbidden {
+		t.Errorf("rearm token used for engage = %d", rec.Code)
+	}
+	token = challenge("rearm")
+	if rec := post(`{"action":"rearm","token":"` + token + `"}`); rec.Code != 200 || s.kill.engaged() {
+		t.Fatalf("rearm = %d %s", rec.Code, rec.Body)
+	}
+	if loadKillSwitch(path).engaged() {
+		t.Error("rearm not persisted")
+	}
+}
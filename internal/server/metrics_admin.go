@@ -0,0 +1,57 @@
+package server
+
+import (
+	"context"
+	"log"
+	"net"
+	"net/http"
+	"time"
+)
+
+// metricsMux admin dinleyicisinin route'ları. Rate limit yok: Prometheus/Grafana kazıması API limitlerine takılmaz.
+func (s *Server) metricsMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", MetricsHandler(s.metrics))
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	})
+	return mux
+}
+
+// startMetricsServer metrics_addr doluysa /metrics'i ayrı bir portta yayınlar.
+// Port dinlenemezse uyarı loglanır; ana sunucu etkilenmez.
+func (s *Server) startMetricsServer() {
+	addr := s.cfg.MetricsAddr
+	if addr == "" {
+		return
+	}
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Printf("[WARN] Metrics admin portu açılamadı (%s): %v", addr, err)
+		return
+	}
+	srv := &http.Server{Handler: s.metricsMux(), ReadHeaderTimeout: 10 * time.Second}
+	s.mu.Lock()
+	s.metricsSrv = srv
+	s.mu.Unlock()
+	log.Printf("[INFO] Prometheus metrikleri: http://%s/metrics", ln.Addr())
+	go func() {
+		if err := srv.Serve(ln); err != nil && err != http.ErrServerClosed {
+			log.Printf("[WARN] Metrics admin sunucusu durdu: %v", err)
+		}
+	}()
+}
+
+// stopMetricsServer admin dinleyicisini kapatır
+func (s *Server) stopMetricsServer() {
+	s.mu.Lock()
+	srv := s.metricsSrv
+	s.metricsSrv = nil
+	s.mu.Unlock()
+	if srv == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	_ = srv.Shutdown(ctx)
+}
@@ -3,6 +3,7 @@ package server
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 	"sync"
 	"time"
 
@@ -375,6 +376,29 @@ func MetricsJSONHandler(collector *metrics.MetricsCollector) http.HandlerFunc {
 	}
 }
 
+// MetricsHistoryHandler returns per-minute metrics for the last ?hours= (default 24, capped by the
+// configured retention) for dashboard chart backfill.
+// offset is the cumulative hit count carried over from before a server restart.
+func MetricsHistoryHandler(history *metrics.History) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		window := metrics.HistoryWindow
+		if v, err := strconv.Atoi(r.URL.Query().Get("hours")); err == nil && v > 0 {
+			window = time.Duration(v) * time.Hour
+		}
+		window = min(window, history.Retention())
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"points":          history.PointsSince(time.Now().Add(-window)),
+			"offset": history.Offset(),
+			"retention_hours": int(history.Retention() / time.Hour),
+		})
+	}
+}
+
 // DashboardHandler returns Grafana dashboard JSON
 func DashboardHandler() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -934,6 +958,50 @@ func generateGrafanaDashboard() map[string]interface{} {
 				"title": "Error & Bounce Rates",
 				"type":  "timeseries",
 			},
+			{
+				"datasource": map[string]string{
+					"type": "prometheus",
+					"uid":  "${datasource}",
+				},
+				"fieldConfig": map[string]interface{}{
+					"defaults": map[string]interface{}{
+						"color": map[string]string{
+							"mode": "palette-classic",
+						},
+						"unit": "s",
+					},
+					"overrides": []interface{}{},
+				},
+				"gridPos": map[string]int{
+					"h": 8,
+					"w": 24,
+					"x": 0,
+					"y": 18,
+				},
+				"id": 13,
+				"targets": []map[string]interface{}{
+					{
+						"datasource": map[string]string{
+							"type": "prometheus",
+							"uid":  "${datasource}",
+						},
+						"expr":         "histogram_quantile(0.5, sum by (le) (rate(vgbot_hit_duration_seconds_bucket[5m])))",
+						"refId":        "A",
+						"legendFormat": "p50",
+					},
+					{
+						"datasource": map[string]string{
+							"type": "prometheus",
+							"uid":  "${datasource}",
+						},
+						"expr":         "histogram_quantile(0.95, sum by (le) (rate(vgbot_hit_duration_seconds_bucket[5m])))",
+						"refId":        "B",
+						"legendFormat": "p95",
+					},
+				},
+				"title": "Hit Duration",
+				"type":  "timeseries",
+			},
 		},
 		"refresh": "5s",
 		"schemaVersion": 38,
@@ -0,0 +1,185 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"vgbot/internal/config"
+	"vgbot/pkg/uptime"
+)
+
+// monitorProbePrivate monitor_probes'ta tüm private proxy'leri kontrol noktası yapan anahtar kelime
+const monitorProbePrivate = "private"
+
+// monitorProbes monitor_probes girdilerini kontrol noktalarına çevirir ("private" → her private proxy)
+func monitorProbes(entries []string, private []config.PrivateProxy) ([]uptime.Probe, error) {
+	var expanded []string
+	for _, e := range entries {
+		if !strings.EqualFold(strings.TrimSpace(e), monitorProbePrivate) {
+			expanded = append(expanded, e)
+			continue
+		}
+		for i := range private {
+			if u := private[i].ToURL(); u != "" {
+				expanded = append(expanded, fmt.Sprintf("%s:%d=%s", private[i].Host, private[i].Port, u))
+			}
+		}
+	}
+	return uptime.ParseProbes(expanded)
+}
+
+// monitorURLs kontrol edilecek URL'leri döner: boşsa hedef domain ana sayfası, "/path" hedef domaine göre
+func monitorURLs(cfg *config.Config) []string {
+	base := cfg.TargetDomain
+	if base != "" && !strings.HasPrefix(base, "http") {
+		base = "https://" + strings.TrimPrefix(base, "//")
+	}
+	base = strings.TrimSuffix(base, "/")
+	var urls []string
+	for _, u := range cfg.MonitorURLs {
+		u = strings.TrimSpace(u)
+		switch {
+		case u == "":
+		case strings.HasPrefix(u, "http://"), strings.HasPrefix(u, "https://"):
+			urls = append(urls, u)
+		case strings.HasPrefix(u, "/") && base != "":
+			urls = append(urls, base+u)
+		}
+	}
+	if len(urls) == 0 && base != "" {
+		urls = []string{base + "/"}
+	}
+	return urls
+}
+
+// startMonitor config'teki URL'ler için uptime monitor'ü başlatır (çalışıyorsa yeniden başlatır).
+// Her URL'nin host'u izin listesinde olmalıdır; değilse hiçbir istek gönderilmeden onay hatası döner.
+// Kill switch devredeyken başlatılamaz.
+func (s *Server) startMonitor(actor string) error {
+	if s.kill.engaged() {
+		return errKillSwitch
+	}
+	s.mu.Lock()
+	cfg := *s.cfg
+	s.mu.Unlock()
+
+	urls := monitorURLs(&cfg)
+	if len(urls) == 0 {
+		return fmt.Errorf("kontrol edilecek URL yok (monitor_urls veya hedef domain gerekli)")
+	}
+	for _, u := range urls {
+		if err := s.checkTarget(u, "monitor", actor, u); err != nil {
+			return err
+		}
+	}
+	probes, err := monitorProbes(cfg.MonitorProbes, cfg.PrivateProxies)
+	if err != nil {
+		return err
+	}
+	m := uptime.New(uptime.Config{
+		URLs:          urls,
+		Probes:        probes,
+		Interval:      time.Duration(cfg.MonitorIntervalMin) * time.Minute,
+		FailThreshold: cfg.MonitorFailThreshold,
+		Alert:         s.monitorAlert,
+		OnRound:       func(st uptime.Status) { s.hub.Broadcast("monitor", st) },
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+
+	s.mu.Lock()
+	if s.monitorCancel != nil {
+		s.monitorCancel()
+	}
+	s.monitor, s.monitorCancel = m, cancel
+	s.mu.Unlock()
+
+	go m.Run(ctx)
+	log.Printf("[INFO] Uptime monitor başladı: %d URL, %d kontrol noktası, %d dk aralık",
+		len(urls), max(len(probes), 1), cfg.MonitorIntervalMin)
+	return nil
+}
+
+// stopMonitor çalışan monitor'ü durdurur; son durum /api/monitor'de kalır
+func (s *Server) stopMonitor() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.monitorCancel != nil {
+		s.monitorCancel()
+		s.monitorCancel = nil
+	}
+}
+
+// monitorAlert hedef erişilemez olduğunda veya düzeldiğinde dashboard'a ve bildirim kanallarına (Telegram/webhook) bildirir
+func (s *Server) monitorAlert(a uptime.Alert) {
+	detail := fmt.Sprintf("%d ms", a.LatencyMs)
+	if a.Down {
+		detail = fmt.Sprintf("%d başarısız kontrol: %s", a.Failures, a.Error)
+		log.Printf("[WARN] Uptime: %s (%s) erişilemiyor: %s", a.URL, a.Probe, detail)
+	} else {
+		log.Printf("[INFO] Uptime: %s (%s) tekrar erişilebilir (%s)", a.URL, a.Probe, detail)
+	}
+	s.hub.Broadcast("monitor_alert", a)
+	if s.notifier != nil && s.notifier.IsEnabled() {
+		if err := s.notifier.SendMonitorAlert(a.URL, a.Probe, a.Down, detail); err != nil {
+			log.Printf("[WARN] Uptime uyarısı gönderilemedi: %v", err)
+		}
+	}
+}
+
+// handleMonitor GET: monitor durumu; POST {"action":"start"|"stop"|"check"}
+func (s *Server) handleMonitor(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		var body struct {
+			Action string `json:"action"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, "Geçersiz istek", 400)
+			return
+		}
+		if body.Action != "stop" && s.rejectIfKilled(w) {
+			return
+		}
+		switch body.Action {
+		case "start":
+			if err := s.startMonitor(requestActor(r)); err != nil {
+				writeStartError(w, err, 400)
+				return
+			}
+		case "stop":
+			s.stopMonitor()
+		case "check":
+			// Aralığı beklemeden tek tur kontrol (durdurulmuş monitor'de de çalışır)
+			s.mu.Lock()
+			m := s.monitor
+			s.mu.Unlock()
+			if m == nil {
+				http.Error(w, "Monitor başlatılmadı", 409)
+				return
+			}
+			m.CheckAll(r.Context())
+		default:
+			http.Error(w, "Bilinmeyen action (start, stop, check)", 400)
+			return
+		}
+	default:
+		http.Error(w, "Method not allowed", 405)
+		return
+	}
+
+	s.mu.Lock()
+	m := s.monitor
+	s.mu.Unlock()
+	st := uptime.Status{Targets: []uptime.TargetStatus{}}
+	if m != nil {
+		st = m.Status()
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(st)
+}
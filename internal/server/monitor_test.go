@@ -0,0 +1,31 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+
+	"vgbot/internal/config"
+)
+
+func TestMonitorURLs(t *testing.T) {
+	cfg := &config.Config{TargetDomain: "example.com"}
+	if got := monitorURLs(cfg); !reflect.DeepEqual(got, []string{"https://example.com/"}) {
+		t.Fatalf("default urls = %v", got)
+	}
+	cfg.MonitorURLs = []string{"/pricing", "https://status.example.com/health", ""}
+	want := []string{"https://example.com/pricing", "https://status.example.com/health"}
+	if got := monitorURLs(cfg); !reflect.DeepEqual(got, want) {
+		t.Fatalf("urls = %v, want %v", got, want)
+	}
+}
+
+func TestMonitorProbesExpandsPrivateProxies(t *testing.T) {
+	private := []config.PrivateProxy{{Host: "1.2.3.4", Port: 8080, User: "u", Pass: "p"}, {Host: "5.6.7.8", Port: 3128}}
+	probes, err := monitorProbes([]string{"direct", "private"}, private)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(probes) != 3 || probes[1].Name != "1.2.3.4:8080" || probes[1].Proxy.User.Username() != "u" {
+		t.Fatalf("probes = %+v", probes)
+	}
+}
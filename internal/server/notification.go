@@ -0,0 +1,93 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"vgbot/pkg/notification"
+)
+
+// handleWebhookConfig webhook bildirim yapılandırması GET/POST
+func (s *Server) handleWebhookConfig(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	switch r.Method {
+	case http.MethodGet:
+		s.mu.Lock()
+		cfg := s.cfg
+		resp := map[string]interface{}{
+			"webhook_url":             cfg.WebhookURL,
+			"webhook_format":          cfg.WebhookFormat,
+			"enable_webhook_notify":   cfg.EnableWebhookNotify,
+			"webhook_report_interval": cfg.WebhookReportInterval,
+		}
+		s.mu.Unlock()
+		json.NewEncoder(w).Encode(resp)
+
+	case http.MethodPost:
+		var body struct {
+			URL            string `json:"webhook_url"`
+			Format         string `json:"webhook_format"`
+			Enabled        bool   `json:"enable_webhook_notify"`
+			ReportInterval int    `json:"webhook_report_interval"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, "Invalid JSON", 400)
+			return
+		}
+		body.URL, body.Format = strings.TrimSpace(body.URL), strings.ToLower(strings.TrimSpace(body.Format))
+		if err := notification.ValidateWebhook(body.URL, body.Format); err != nil {
+			http.Error(w, err.Error(), 400)
+			return
+		}
+		if body.Format == "" {
+			body.Format = notification.WebhookSlack
+		}
+
+		s.mu.Lock()
+		s.cfg.WebhookURL = body.URL
+		s.cfg.WebhookFormat = body.Format
+		s.cfg.EnableWebhookNotify = body.Enabled
+		if body.ReportInterval > 0 {
+			s.cfg.WebhookReportInterval = body.ReportInterval
+		}
+		s.mu.Unlock()
+
+		s.webhook.UpdateConfig(notification.WebhookConfig{
+			URL:            body.URL,
+			Format:         body.Format,
+			Enabled:        body.Enabled,
+			ReportInterval: body.ReportInterval,
+		})
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"message": "Webhook yapılandırması güncellendi",
+		})
+
+	default:
+		http.Error(w, "Method not allowed", 405)
+	}
+}
+
+// handleWebhookTest kayıtlı webhook adresine test mesajı gönderir
+func (s *Server) handleWebhookTest(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", 405)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := s.webhook.TestConnection(); err != nil {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Webhook bağlantısı başarılı",
+	})
+}
@@ -0,0 +1,19 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAnalyticsPreflightRejectsInternalDomain(t *testing.T) {
+	cfg := testConfig()
+	s := &Server{cfg: &cfg}
+	for _, domain := range []string{"localhost", "127.0.0.1:8080", "http://169.254.169.254/latest", "https://10.0.0.5/", "LOCALHOST.", "http://[::1]:9000"} {
+		rec := httptest.NewRecorder()
+		s.handleAnalyticsPreflight(rec, httptest.NewRequest(http.MethodGet, "/api/analytics/preflight?domain="+domain, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("domain %q = %d, want 400", domain, rec.Code)
+		}
+	}
+}
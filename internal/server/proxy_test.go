@@ -0,0 +1,41 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"vgbot/internal/proxy"
+)
+
+func TestProxyAddRejectsInternalHosts(t *testing.T) {
+	cfg := testConfig()
+	pool := proxy.NewLivePool()
+	s := &Server{cfg: &cfg, proxyService: &proxy.Service{LivePool: pool}}
+
+	post := func(body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		s.handleProxyAdd(rec, httptest.NewRequest(http.MethodPost, "/api/proxy/add", strings.NewReader(body)))
+		return rec
+	}
+
+	// validate=false da iç ağ adreslerini havuza almaz
+	if rec := post(`{"text":"127.0.0.1:8080\nlocalhost:3128\n192.168.1.10:8080"}`); rec.Code != http.StatusBadRequest {
+		t.Fatalf("internal only = %d %s", rec.Code, rec.Body)
+	}
+
+	rec := post(`{"text":"10.0.0.5:3128\n203.0.113.7:8080","validate":false}`)
+	if rec.Code != 200 {
+		t.Fatalf("mixed = %d %s", rec.Code, rec.Body)
+	}
+	var resp struct {
+		Added   int `json:"added"`
+		Invalid int `json:"invalid"`
+	}
+	json.Unmarshal(rec.Body.Bytes(), &resp)
+	if resp.Added != 1 || resp.Invalid != 1 || pool.Count() != 1 {
+		t.Errorf("added=%d invalid=%d pool=%d, want 1/1/1", resp.Added, resp.Invalid, pool.Count())
+	}
+}
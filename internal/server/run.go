@@ -0,0 +1,270 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"vgbot/internal/reporter"
+)
+
+// runScope tek bir çalıştırmanın (Start → Stop/bitiş) context ağacı.
+// Ziyaretler, analytics çağrıları, proxy testleri, log forwarder ve notifier döngüsü
+// bu context'ten türetilir; Stop hepsini birlikte iptal eder.
+type runScope struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu         sync.Mutex
+	active     map[string]int // isim → çalışan goroutine sayısı
+	goroutines int            // başlangıçtaki runtime.NumGoroutine (leak raporu için)
+	started    time.Time
+}
+
+func newRunScope() *runScope {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &runScope{
+		ctx:        ctx,
+		cancel:     cancel,
+		active:     make(map[string]int),
+		goroutines: runtime.NumGoroutine(),
+		started:    time.Now(),
+	}
+}
+
+// Go isimli, takip edilen bir goroutine başlatır
+func (r *runScope) Go(name string, fn func(ctx context.Context)) {
+	r.mu.Lock()
+	r.active[name]++
+	r.mu.Unlock()
+	r.wg.Add(1)
+	go func() {
+		defer func() {
+			r.mu.Lock()
+			if r.active[name]--; r.active[name] <= 0 {
+				delete(r.active, name)
+			}
+			r.mu.Unlock()
+			r.wg.Done()
+		}()
+		fn(r.ctx)
+	}()
+}
+
+// Stop tüm alt işleri iptal eder (beklemeden döner)
+func (r *runScope) Stop() {
+	r.cancel()
+}
+
+// Survivors grace süresi boyunca goroutine'lerin bitmesini bekler; hâlâ çalışanların isimlerini döner
+func (r *runScope) Survivors(grace time.Duration) []string {
+	done := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-time.After(grace):
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var names []string
+	for name, n := range r.active {
+		for i := 0; i < n; i++ {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// leakReport Stop sonrası hayatta kalan goroutine raporu (LeakCheck modu)
+type leakReport struct {
+	CheckedAt        time.Time `json:"checked_at"`
+	RunStarted       time.Time `json:"run_started"`
+	Survivors        []string  `json:"survivors"`
+	GoroutinesBefore int       `json:"goroutines_before"`
+	GoroutinesAfter  int       `json:"goroutines_after"`
+	Stacks           []string  `json:"stacks,omitempty"` // vgbot kodunda bekleyen goroutine'ler
+}
+
+// checkLeaks run iptal edildikten sonra grace süresi bekleyip raporu hazırlar
+func checkLeaks(r *runScope, grace time.Duration) *leakReport {
+	survivors := r.Survivors(grace)
+	rep := &leakReport{
+		CheckedAt:        time.Now(),
+		RunStarted:       r.started,
+		Survivors:        survivors,
+		GoroutinesBefore: r.goroutines,
+		GoroutinesAfter:  runtime.NumGoroutine(),
+	}
+	if len(survivors) > 0 || rep.GoroutinesAfter > rep.GoroutinesBefore {
+		rep.Stacks = vgbotStacks()
+	}
+	return rep
+}
+
+// vgbotStacks vgbot paketlerinde çalışan goroutine stack'lerini döner (server'ın kendi döngüleri hariç)
+func vgbotStacks() []string {
+	buf := make([]byte, 1<<20)
+	buf = buf[:runtime.Stack(buf, true)]
+	var out []string
+	for _, g := range strings.Split(string(buf), "\n\n") {
+		if !strings.Contains(g, "vgbot/") || strings.Contains(g, "vgbot/internal/server.vgbotStacks") {
+			continue
+		}
+		out = append(out, g)
+	}
+	return out
+}
+
+// beginRun yeni bir run scope açar; s.mu tutulurken çağrılmalı
+func (s *Server) beginRun() *runScope {
+	run := newRunScope()
+	s.run = run
+	s.cancel = run.cancel
+	return run
+}
+
+// forwardLogs reporter log kanalını hub'a aktarır (prefix boş değilse mesajın önüne eklenir). Kanal reporter
+// kapanınca biter; iptalden sonra son mesajlar (iptal/özet) için kısa bir süre daha okur, sonra çıkar.
+func (s *Server) forwardLogs(ctx context.Context, logChan <-chan string, prefix string) {
+	var drain <-chan time.Time
+	done := ctx.Done()
+	for {
+		select {
+		case msg, ok := <-logChan:
+			if !ok {
+				return
+			}
+			s.hub.Broadcast("log", prefix+msg)
+		case <-done:
+			done = nil
+			drain = time.After(10 * time.Second)
+		case <-drain:
+			return
+		}
+	}
+}
+
+// eventMessage hub'a "event" tipiyle yayınlanan tipli reporter olayı
+type eventMessage struct {
+	Type     string         `json:"type"`               // hit, session, error
+	Campaign string         `json:"campaign,omitempty"` // Kampanya çalıştırmasının adı
+	Event    reporter.Event `json:"event"`
+}
+
+// forwardEvents reporter'ın tipli olaylarını hub'a yayınlar ve hata olaylarını bildirim kuralına iletir.
+// forwardLogs gibi reporter kapanınca biter; iptalden sonra son olaylar için kısa bir süre daha okur.
+func (s *Server) forwardEvents(ctx context.Context, events <-chan reporter.Event, campaign string) {
+	var drain <-chan time.Time
+	done := ctx.Done()
+	for {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			s.hub.Broadcast("event", eventMessage{Type: e.EventType(), Campaign: campaign, Event: e})
+			if ev, isErr := e.(reporter.ErrorEvent); isErr {
+				s.observeError(ev, campaign)
+			}
+		case <-done:
+			done = nil
+			drain = time.After(10 * time.Second)
+		case <-drain:
+			return
+		}
+	}
+}
+
+// observeError hata olayını ErrorBurstRule'a işler; aynı sınıf pencere içinde eşiğe ulaşınca açık
+// bildirim kanallarına tek mesaj gider
+func (s *Server) observeError(ev reporter.ErrorEvent, campaign string) {
+	n, fire := s.errorRule.Observe(ev.Class, ev.Time)
+	if !fire || s.notifier == nil || !s.notifier.IsEnabled() {
+		return
+	}
+	class := ev.Class
+	if class == "" {
+		class = ev.Source
+	}
+	msg := fmt.Sprintf("%d × %s (son %d dk): %s", n, class, int(s.errorRule.Window().Minutes()), ev.Message)
+	if ev.URL != "" {
+		msg += " — " + ev.URL
+	}
+	if campaign != "" {
+		msg = "[" + campaign + "] " + msg
+	}
+	go func() {
+		if err := s.notifier.SendError(msg); err != nil {
+			log.Printf("[WARN] Hata bildirimi gönderilemedi: %v", err)
+		}
+	}()
+}
+
+// stopRun aktif çalıştırmayı iptal eder; s.mu tutulurken çağrılmalı.
+// LeakCheck açıksa arka planda hayatta kalan goroutine'leri raporlar.
+func (s *Server) stopRun() {
+	run := s.run
+	if run == nil {
+		return
+	}
+	run.Stop()
+	s.run = nil
+	s.cancel = nil
+	if s.proxyService != nil {
+		s.proxyService.StopCheck()
+	}
+	if s.cfg.LeakCheck {
+		go func() {
+			rep := checkLeaks(run, 15*time.Second)
+			s.mu.Lock()
+			s.lastLeakReport = rep
+			s.mu.Unlock()
+			if len(rep.Survivors) > 0 {
+				s.hub.Broadcast("log", "⚠️ Stop sonrası kapanmayan goroutine'ler: "+strings.Join(rep.Survivors, ", "))
+			} else {
+				s.hub.Broadcast("log", "✅ Leak check: tüm run goroutine'leri kapandı")
+			}
+		}()
+	}
+}
+
+// endRun run kendi kendine bittiğinde (süre doldu) durumu temizler
+func (s *Server) endRun(run *runScope) {
+	run.Stop()
+	s.mu.Lock()
+	if s.run == run {
+		s.run = nil
+		s.cancel = nil
+	}
+	s.mu.Unlock()
+}
+
+// handleLeakReport son leak check raporunu döner
+func (s *Server) handleLeakReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", 405)
+		return
+	}
+	s.mu.Lock()
+	rep := s.lastLeakReport
+	enabled := s.cfg.LeakCheck
+	s.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"enabled": enabled,
+		"report":  rep,
+	})
+}
@@ -0,0 +1,75 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRunScopeStopCancelsAll(t *testing.T) {
+	run := newRunScope()
+	for _, name := range []string{"simulator", "log-forwarder", "notifier"} {
+		run.Go(name, func(ctx context.Context) { <-ctx.Done() })
+	}
+	run.Stop()
+	if got := run.Survivors(2 * time.Second); len(got) != 0 {
+		t.Fatalf("survivors = %v, want none", got)
+	}
+}
+
+func TestRunScopeSurvivors(t *testing.T) {
+	run := newRunScope()
+	release := make(chan struct{})
+	defer close(release)
+	run.Go("stuck", func(ctx context.Context) { <-release })
+	run.Go("stuck", func(ctx context.Context) { <-release })
+	run.Go("ok", func(ctx context.Context) { <-ctx.Done() })
+	run.Stop()
+	got := run.Survivors(50 * time.Millisecond)
+	if want := []string{"stuck", "stuck"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("survivors = %v, want %v", got, want)
+	}
+}
+
+func TestRunsHandlers(t *testing.T) {
+	cfg := testConfig()
+	cfg.OutputDir = t.TempDir()
+	s := &Server{cfg: &cfg}
+	os.WriteFile(filepath.Join(cfg.OutputDir, "vgbot_report_20250101_090000.html"), []byte("<html></html>"), 0644)
+	os.WriteFile(filepath.Join(cfg.OutputDir, "config.json"), []byte("{}"), 0644)
+
+	rec := httptest.NewRecorder()
+	s.handleRuns(rec, httptest.NewRequest(http.MethodGet, "/api/runs", nil))
+	var resp struct {
+		Runs []struct {
+			ID      string            `json:"id"`
+			Reports map[string]string `json:"reports"`
+		} `json:"runs"`
+	}
+	json.Unmarshal(rec.Body.Bytes(), &resp)
+	if len(resp.Runs) != 1 || resp.Runs[0].Reports["html"] != "/api/runs/file?name=vgbot_report_20250101_090000.html" {
+		t.Fatalf("runs = %s", rec.Body)
+	}
+
+	for target, want := range map[string]int{
+		resp.Runs[0].Reports["html"]:                               200,
+		"/api/runs/file?name=config.json":                          400,
+		"/api/runs/file?name=../vgbot_report_20250101_090000.html": 400,
+		"/api/runs/file?name=vgbot_hits_20250101_090000.csv":       404,
+	} {
+		rec := httptest.NewRecorder()
+		s.handleRunFile(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		if rec.Code != want {
+			t.Errorf("%s = %d, want %d", target, rec.Code, want)
+		}
+		if want == 200 && rec.Header().Get("Content-Security-Policy") != "sandbox allow-scripts" {
+			t.Errorf("%s served without sandbox CSP", target)
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"vgbot/internal/config"
+	"vgbot/pkg/scheduler"
+)
+
+// initScheduler iş deposunu yükler ve enable_scheduler açıksa scheduler'ı başlatır.
+// İşler ve sonraki çalışma zamanları dosyada tutulduğundan yeniden başlatmada kaldığı yerden devam eder.
+func (s *Server) initScheduler() {
+	s.scheduler = scheduler.NewScheduler(scheduler.NewJobStorage(s.cfg.SchedulerJobsFile), s.startScheduledRun, s.stopScheduledRun)
+	s.applySchedulerWindows(s.cfg)
+	if s.cfg.EnableScheduler && !s.kill.engaged() {
+		s.scheduler.Start()
+	}
+}
+
+// applySchedulerWindows config'teki global aktif/blackout pencerelerini scheduler'a uygular
+func (s *Server) applySchedulerWindows(cfg *config.Config) {
+	plan, err := scheduler.NewWindowPlan(cfg.ActiveWindows, cfg.BlackoutWindows)
+	if err != nil {
+		log.Printf("[WARN] Scheduler zaman pencereleri geçersiz: %v", err)
+		return
+	}
+	s.scheduler.SetWindowPlan(plan)
+}
+
+// startScheduledRun zamanı gelen işi, mevcut config üzerine işin override'larıyla bağımsız bir kampanya olarak başlatır.
+// Kampanya /api/campaigns altında görünür; aynı işin önceki (bitmiş) kampanyası yenisiyle değiştirilir.
+func (s *Server) startScheduledRun(job scheduler.Job, durationMin int, hpm int) error {
+	s.mu.Lock()
+	cfg := *s.cfg
+	prev := s.schedCampaign
+	s.mu.Unlock()
+
+	if job.Domain != "" {
+		cfg.TargetDomain = job.Domain
+	}
+	cfg.DurationMinutes = durationMin
+	if hpm > 0 {
+		cfg.HitsPerMinute = hpm
+	}
+	if job.MaxConcurrent > 0 {
+		cfg.MaxConcurrentVisits = job.MaxConcurrent
+	}
+	cfg.ApplyDefaults()
+	cfg.ComputeDerived()
+	if cfg.TargetDomain == "" {
+		return fmt.Errorf("hedef domain yok")
+	}
+
+	if c := s.campaigns.get(prev); c != nil {
+		s.campaigns.mu.Lock()
+		idle := c.run == nil
+		s.campaigns.mu.Unlock()
+		if idle {
+			s.campaigns.remove(prev)
+		}
+	}
+
+	name := job.Name
+	if name == "" {
+		name = job.ID
+	}
+	c := s.campaigns.add("⏰ "+name, &cfg)
+	if err := s.startCampaign(c, "tr"); err != nil {
+		s.campaigns.remove(c.id)
+		return err
+	}
+	s.mu.Lock()
+	s.schedCampaign = c.id
+	s.mu.Unlock()
+	s.hub.Broadcast("log", fmt.Sprintf("⏰ Zamanlanmış iş başladı: %s (%s, %d dk, %d HPM)", name, cfg.TargetDomain, durationMin, cfg.HitsPerMinute))
+	return nil
+}
+
+// stopScheduledRun aktif zamanlanmış işin kampanyasını durdurur
+func (s *Server) stopScheduledRun() error {
+	s.mu.Lock()
+	id := s.schedCampaign
+	s.mu.Unlock()
+	if c := s.campaigns.get(id); c != nil {
+		s.stopCampaign(c)
+	}
+	return nil
+}
+
+// decodeJob istek gövdesindeki işi okur ve doğrular
+func decodeJob(r io.Reader) (*scheduler.Job, error) {
+	var job scheduler.Job
+	if err := json.NewDecoder(io.LimitReader(r, 1<<20)).Decode(&job); err != nil {
+		return nil, fmt.Errorf("Invalid JSON")
+	}
+	if err := job.Validate(); err != nil {
+		return nil, err
+	}
+	if job.OneShot() && !job.RunAt.After(time.Now()) {
+		return nil, fmt.Errorf("run_at geçmişte olamaz")
+	}
+	return &job, nil
+}
+
+// handleSchedulerJobs Scheduler işlerini yönetir. GET: işler + durum, POST: ekle (aynı ID varsa günceller),
+// PUT ?id=: güncelle, DELETE ?id=: sil. Çalışma istatistikleri (last_run, run_count) güncellemede korunur.
+func (s *Server) handleSchedulerJobs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	storage := s.scheduler.GetStorage()
+
+	switch r.Method {
+	case http.MethodGet:
+		json.NewEncoder(w).Encode(s.scheduler.Status())
+
+	case http.MethodPost, http.MethodPut:
+		job, err := decodeJob(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), 400)
+			return
+		}
+		if r.Method == http.MethodPut {
+			job.ID = r.URL.Query().Get("id")
+			if job.ID == "" {
+				http.Error(w, "id parametresi gerekli", 400)
+				return
+			}
+		}
+		existing := storage.GetJob(job.ID)
+		if existing == nil && r.Method == http.MethodPut {
+			http.Error(w, "İş bulunamadı", 404)
+			return
+		}
+		job.NextRun = job.Next(time.Now())
+		job.LastStatus, job.LastError = "", ""
+		message := "İş eklendi"
+		if existing != nil {
+			job.LastRun, job.RunCount = existing.LastRun, existing.RunCount
+			err = storage.Update(job.ID, func(j *scheduler.Job) { *j = *job })
+			message = "İş güncellendi"
+		} else {
+			err = storage.AddJob(job)
+		}
+		if err != nil {
+			http.Error(w, "İş kaydedilemedi: "+err.Error(), 500)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"message": message,
+			"job":     storage.GetJob(job.ID),
+		})
+
+	case http.MethodDelete:
+		// İş sil - query param ile id
+		jobID := r.URL.Query().Get("id")
+		if jobID == "" {
+			http.Error(w, "id parametresi gerekli", 400)
+			return
+		}
+		if storage.GetJob(jobID) == nil {
+			http.Error(w, "İş bulunamadı", 404)
+			return
+		}
+		if err := storage.RemoveJob(jobID); err != nil {
+			http.Error(w, "İş silinemedi: "+err.Error(), 500)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"message": "İş silindi",
+		})
+
+	default:
+		http.Error(w, "Method not allowed", 405)
+	}
+}
+
+// setSchedulerEnabled scheduler'ı başlatır/durdurur ve tercihi config'e yazar (yeniden başlatmada korunur).
+// Kill switch devredeyken tercih kaydedilir, scheduler yeniden kurulunca başlar.
+func (s *Server) setSchedulerEnabled(enabled bool) {
+	s.mu.Lock()
+	s.cfg.EnableScheduler = enabled
+	cfgCopy := *s.cfg
+	s.mu.Unlock()
+	if enabled && !s.kill.engaged() {
+		s.applySchedulerWindows(&cfgCopy)
+		s.scheduler.Start()
+	} else {
+		s.scheduler.Stop()
+	}
+	saveConfigToFile(&cfgCopy)
+}
+
+// handleSchedulerStart Scheduler'ı başlatır
+func (s *Server) handleSchedulerStart(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", 405)
+		return
+	}
+	if s.rejectIfKilled(w) {
+		return
+	}
+	s.setSchedulerEnabled(true)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Scheduler başlatıldı",
+	})
+}
+
+// handleSchedulerStop Scheduler'ı durdurur; çalışan zamanlanmış simülasyon kendi süresi dolunca biter
+func (s *Server) handleSchedulerStop(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", 405)
+		return
+	}
+	s.setSchedulerEnabled(false)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Scheduler durduruldu",
+	})
+}
+
+// handleSchedulerStatus scheduler durumu: çalışıyor mu, aktif iş ve her işin next_run/last_run/last_status bilgisi
+func (s *Server) handleSchedulerStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", 405)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.scheduler.Status())
+}
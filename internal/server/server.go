@@ -2,13 +2,8 @@ package server
 
 import (
 	"context"
-	"crypto"
-	"crypto/rsa"
-	"crypto/sha256"
-	"crypto/x509"
 	"embed"
 	"encoding/json"
-	"encoding/pem"
 	"fmt"
 	"io"
 	"io/fs"
@@ -17,6 +12,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -26,9 +22,20 @@ import (
 	"vgbot/internal/proxy"
 	"vgbot/internal/reporter"
 	"vgbot/internal/simulator"
+	"vgbot/pkg/analytics"
+	"vgbot/pkg/antidetect"
+	"vgbot/pkg/browserpool"
+	"vgbot/pkg/consent"
+	"vgbot/pkg/distributed"
+	"vgbot/pkg/googleauth"
+	"vgbot/pkg/gsc"
+	"vgbot/pkg/i18n"
 	"vgbot/pkg/metrics"
 	"vgbot/pkg/notification"
+	"vgbot/pkg/scheduler"
+	"vgbot/pkg/uptime"
 	"vgbot/pkg/useragent"
+	"vgbot/pkg/vault"
 
 	"github.com/gorilla/websocket"
 	"golang.org/x/time/rate"
@@ -48,12 +55,36 @@ type Server struct {
 	cfg             *config.Config
 	sim             *simulator.Simulator
 	cancel          context.CancelFunc
+	run             *runScope   // Aktif çalıştırmanın context ağacı (Stop hepsini iptal eder)
+	lastLeakReport  *leakReport // LeakCheck modunda son Stop sonrası rapor
 	agentLoader     *useragent.Loader
 	proxyService    *proxy.Service
 	hub             *Hub
 	metrics         *metrics.MetricsCollector
 	metricsWS       *MetricsWebSocket
-	notifier        *notification.TelegramNotifier
+	history         *metrics.History // Dakikalık metrik geçmişi (son 24 saat, dosyada kalıcı)
+	metricsSrv      *http.Server     // metrics_addr doluysa Prometheus için ayrı admin dinleyicisi
+	notifier        *notification.Dispatcher        // Açık tüm bildirim kanallarına gönderir
+	telegram        *notification.TelegramNotifier
+	webhook         *notification.WebhookNotifier
+	errorRule       *notification.ErrorBurstRule // Reporter hata olaylarından toplu hata bildirimi
+	configDir       string                       // config.json ve config profillerinin dizini
+	master          *distributed.Master // Gömülü distributed master (cluster modu)
+	clusterRep      *reporter.Reporter  // Cluster çalıştırmasının raporlayıcısı
+	testVisiting    bool                // /api/testvisit ziyareti sürüyor mu (aynı anda tek test)
+	monitor         *uptime.Monitor     // Uptime monitor (son başlatılan; durdurulunca durumu korunur)
+	monitorCancel   context.CancelFunc  // Çalışan monitor'ü durdurur (nil = çalışmıyor)
+	campaigns       *campaignManager    // Ana simülasyondan bağımsız, eşzamanlı kampanyalar
+	scheduler       *scheduler.Scheduler // Zamanlanmış işler (cron, tek seferlik, haftalık plan)
+	schedCampaign   string               // Aktif zamanlanmış işin başlattığı kampanyanın ID'si
+	keywords        *antidetect.KeywordClusterManager // Arama referrer keyword cluster'ları (/api/keywords/clusters)
+	lastCleanup     *storageCleanup     // Son saklama temizliği (otomatik veya /api/storage)
+	kill            *killSwitch         // Acil durdurma (/api/killswitch); devredeyken çalıştırma başlatılamaz
+	consent         *consent.Store      // Kullanım koşulları onayı ve hedef izin listesi (/api/consent)
+	wizard          *wizardState        // Sihirbazın doğrulama anahtarı ve doğrulanan alan adları
+	gscClients      map[string]*gsc.Client // Service Account başına Search Console istemcisi (token önbelleği)
+	vaultMu         sync.Mutex   // Vault açılışını sıralar; scrypt yavaş olduğundan s.mu tutulmaz
+	vault           *vault.Vault // /api/vault'un açık vault'u (vault_file değişince yeniden açılır)
 	done            chan struct{} // BUG FIX #6/#7: Background goroutine'leri durdurmak için
 }
 
@@ -167,6 +198,23 @@ func New() (*Server, error) {
 		Enabled:        cfg.EnableTelegramNotify,
 		ReportInterval: cfg.TelegramReportInterval,
 	})
+	webhookNotifier := notification.NewWebhookNotifier(notification.WebhookConfig{
+		URL:            cfg.WebhookURL,
+		Format:         cfg.WebhookFormat,
+		Enabled:        cfg.EnableWebhookNotify,
+		ReportInterval: cfg.WebhookReportInterval,
+	})
+
+	consentStore, err := consent.Open(cfg.ConsentFile, cfg.AuditLogFile)
+	if err != nil {
+		log.Printf("[WARN] Onay durumu okunamadı: %v", err)
+	}
+
+	history, err := openHistory(cfg)
+	if err != nil {
+		log.Printf("[WARN] Metrik deposu açılamadı, geçmiş yalnızca bellekte tutulacak: %v", err)
+		history = metrics.NewHistory("")
+	}
 
 	s := &Server{
 		cfg:          cfg,
@@ -175,11 +223,26 @@ func New() (*Server, error) {
 		hub:          NewHub(),
 		metrics:      metricsCollector,
 		metricsWS:    NewMetricsWebSocket(metricsCollector),
-		notifier:     telegramNotifier,
+		campaigns:    newCampaignManager(),
+		kill:         loadKillSwitch(cfg.KillSwitchFile),
+		consent:      consentStore,
+		wizard:       newWizardState(cfg.WizardSecretFile),
+		notifier:     notification.NewDispatcher(telegramNotifier, webhookNotifier),
+		telegram:     telegramNotifier,
+		webhook:      webhookNotifier,
+		history:      history,
+		configDir:    configDir(),
+		errorRule:    notification.NewErrorBurstRule(cfg.NotifyErrorThreshold, time.Duration(cfg.NotifyErrorWindowMin)*time.Minute),
 		done:         make(chan struct{}),
 	}
 	go s.broadcastStatusLoop()
 	go s.metricsUpdateLoop()
+	go s.retentionLoop()
+	s.startMetricsServer()
+	s.initScheduler()
+	s.initKeywordClusters()
+	s.applyBrowserFlags()
+	go s.locateBrowser()
 	return s, nil
 }
 
@@ -205,6 +268,9 @@ func (s *Server) metricsUpdateLoop() {
 		select {
 		case <-ticker.C:
 			s.updateMetricsFromState()
+			if err := s.history.Record(s.metrics.GetSnapshot()); err != nil {
+				log.Printf("[WARN] Metrik geçmişi yazılamadı: %v", err)
+			}
 		case <-s.done:
 			return
 		}
@@ -219,6 +285,39 @@ func (s *Server) Shutdown() {
 	default:
 		close(s.done)
 	}
+	s.mu.Lock()
+	s.stopRun()
+	m := s.master
+	s.mu.Unlock()
+	if m != nil {
+		_ = m.Stop()
+	}
+	s.stopMonitor()
+	s.scheduler.Stop()
+	s.campaigns.stopAll()
+	s.stopMetricsServer()
+	_ = s.history.Close()
+}
+
+// openHistory metrics_store'a göre dakikalık metrik geçmişini açar
+func openHistory(cfg *config.Config) (*metrics.History, error) {
+	path := cfg.MetricsHistoryFile
+	if cfg.MetricsStore == metrics.StoreBolt {
+		path = cfg.MetricsDBFile
+	}
+	store, err := metrics.OpenStore(cfg.MetricsStore, path)
+	if err != nil {
+		return nil, err
+	}
+	return metrics.NewHistoryStore(store, time.Duration(cfg.MetricsRetentionDays)*24*time.Hour), nil
+}
+
+// chartStage metrik zaman çizelgesine işlenen aşama: ısınma penceresi veya yük profili aşaması
+func chartStage(rep *reporter.Reporter) string {
+	if rep.InWarmup() {
+		return reporter.WarmupStage
+	}
+	return rep.CurrentStage()
 }
 
 // updateMetricsFromState updates high-level metrics based on current simulator/proxy state.
@@ -236,6 +335,22 @@ func (s *Server) updateMetricsFromState() {
 		s.metrics.SetQueueSize(int64(st.QueueCount))
 	}
 
+	// Sıcak Chrome havuzu
+	var pool *browserpool.Stats
+	if sim != nil {
+		if st, ok := sim.BrowserPoolStats(); ok {
+			pool = &st
+		}
+	}
+	s.metrics.SetBrowserPool(pool)
+
+	// Isınma penceresi ve yük profili aşaması metrik zaman çizelgesine işlenir
+	stage := ""
+	if sim != nil {
+		stage = chartStage(sim.Reporter())
+	}
+	s.history.SetStage(stage)
+
 	// Oturum metrikleri (reporter'dan sadece aktiflik için faydalanıyoruz)
 	if sim != nil {
 		repMetrics := sim.Reporter().GetMetrics()
@@ -247,11 +362,14 @@ func (s *Server) updateMetricsFromState() {
 }
 
 // RecordHit records a hit in metrics (called from simulator)
-func (s *Server) RecordHit(url string, proxy string, duration time.Duration, success bool) {
+func (s *Server) RecordHit(url string, proxy string, duration time.Duration, success bool, errClass string) {
 	s.metrics.RecordHit()
+	s.metrics.RecordErrorClass(errClass)
 	s.metrics.RecordResponseTime(duration)
+	s.metrics.RecordHitDuration(duration, success)
 	if proxy != "" {
 		s.metrics.RecordProxyLatency(proxy, duration)
+		s.metrics.RecordProxyError(proxy, errClass)
 	}
 	if success {
 		s.metrics.RecordSuccess(proxy)
@@ -326,22 +444,52 @@ type configFile struct {
 	PROXY_PASS             string   `json:"PROXY_PASS"`
 	TargetDomain           string   `json:"targetDomain"`
 	FallbackGAID           string   `json:"fallbackGAID"`
+	GA4APISecret           string   `json:"ga4ApiSecret,omitempty"`
+	GA4Properties          []config.GA4Property `json:"ga4Properties,omitempty"`
+	GA4EventMapping        config.GA4EventMapping `json:"ga4EventMapping,omitempty"`
+	GA4ServerURL           string   `json:"ga4ServerUrl,omitempty"`
+	GA4ServerScriptPath    string   `json:"ga4ServerScriptPath,omitempty"`
+	GA4ServerCollectPath   string   `json:"ga4ServerCollectPath,omitempty"`
+	GA4ServerHeaders       []string `json:"ga4ServerHeaders,omitempty"`
+	GA4TrafficType         string   `json:"ga4TrafficType,omitempty"`
+	AnalyticsBackend       string   `json:"analyticsBackend,omitempty"`
+	MatomoURL              string   `json:"matomoUrl,omitempty"`
+	MatomoSiteID           int      `json:"matomoSiteId,omitempty"`
+	PlausibleURL           string   `json:"plausibleUrl,omitempty"`
+	PlausibleDomain        string   `json:"plausibleDomain,omitempty"`
 	MaxPages               int      `json:"maxPages"`
 	DurationMinutes        int      `json:"durationMinutes"`
 	HitsPerMinute          int      `json:"hitsPerMinute"`
 	MaxConcurrentVisits    int      `json:"maxConcurrentVisits"`
 	OutputDir              string   `json:"outputDir"`
 	ExportFormat           string   `json:"exportFormat"`
+	HitLogJSONL            bool     `json:"hitLogJsonl,omitempty"`
+	SessionLogJSONL        bool     `json:"sessionLogJsonl,omitempty"`
+	ReportTitle            string   `json:"reportTitle,omitempty"`
+	ReportLogo             string   `json:"reportLogo,omitempty"`
+	ReportPrimaryColor     string   `json:"reportPrimaryColor,omitempty"`
+	ReportBackgroundColor  string   `json:"reportBackgroundColor,omitempty"`
+	ReportFooter           string   `json:"reportFooter,omitempty"`
 	CanvasFingerprint      bool     `json:"canvasFingerprint"`
 	ScrollStrategy         string   `json:"scrollStrategy"`
 	SendScrollEvent        bool     `json:"sendScrollEvent"`
 	UseSitemap             bool     `json:"useSitemap"`
 	SitemapHomepageWeight  int      `json:"sitemapHomepageWeight"`
+	SitemapCacheDir        string   `json:"sitemapCacheDir,omitempty"`
+	SitemapCacheHours      int      `json:"sitemapCacheHours,omitempty"`
+	LandingPages           []string `json:"landingPages,omitempty"`
+	URLWeights             []config.URLWeight `json:"urlWeights,omitempty"`
+	NotFoundRate           int      `json:"notFoundRate,omitempty"`
+	ContentChecks          []config.ContentCheck `json:"contentChecks,omitempty"`
+	ContentCheckRate       int      `json:"contentCheckRate,omitempty"`
 	Keywords               []string `json:"keywords"`
 	UsePublicProxy         bool     `json:"usePublicProxy"`
 	ProxySourceURLs        []string `json:"proxySourceURLs"`
 	GitHubRepos            []string `json:"githubRepos"`
 	CheckerWorkers         int      `json:"checkerWorkers"`
+	ProxyCheckCacheHours   int      `json:"proxyCheckCacheHours,omitempty"`
+	GitHubFetchConcurrency int      `json:"githubFetchConcurrency,omitempty"`
+	GitHubRepoAllowlist    []string `json:"githubRepoAllowlist,omitempty"`
 	// Private proxy alanları
 	PrivateProxies    []privateProxyFile `json:"privateProxies"`
 	UsePrivateProxy   bool               `json:"usePrivateProxy"`
@@ -350,6 +498,96 @@ type configFile struct {
 	DeviceBrands      []string `json:"deviceBrands"`
 	ReferrerKeyword   string   `json:"referrerKeyword"`
 	ReferrerEnabled   bool     `json:"referrerEnabled"`
+	OutboundDomains   []string `json:"outboundDomains,omitempty"`
+	OutboundClickRate int      `json:"outboundClickRate,omitempty"`
+	// Distributed (cluster) alanları
+	EnableDistributed   bool   `json:"enableDistributed"`
+	DistributedBindAddr string `json:"distributedBindAddr"`
+	DistributedSecret   string `json:"distributedSecret"`
+	DistributedTLSCert  string `json:"distributedTlsCert,omitempty"`
+	DistributedTLSKey   string `json:"distributedTlsKey,omitempty"`
+	EnableScheduler     bool   `json:"enableScheduler,omitempty"`
+	MetricsAddr         string `json:"metricsAddr,omitempty"`
+	// Metrik geçmişi
+	MetricsStore         string `json:"metricsStore,omitempty"`
+	MetricsRetentionDays int    `json:"metricsRetentionDays,omitempty"`
+	// Sıcak Chrome havuzu
+	BrowserPoolEnabled bool `json:"browserPoolEnabled,omitempty"`
+	BrowserPoolMin     int  `json:"browserPoolMin,omitempty"`
+	BrowserPoolMax     int  `json:"browserPoolMax,omitempty"`
+	EnableAutoScaling  bool `json:"enableAutoScaling,omitempty"`
+	BrowserMaxSessions int  `json:"browserMaxSessions,omitempty"`
+	BrowserMaxAge      int  `json:"browserMaxAge,omitempty"`
+	// Zaman pencereleri
+	ActiveWindows   []string `json:"activeWindows,omitempty"`
+	BlackoutWindows []string `json:"blackoutWindows,omitempty"`
+	// Yük modeli (closed: sabit eşzamanlılık)
+	LoadMode    string   `json:"loadMode,omitempty"`
+	LoadLevels  []int    `json:"loadLevels,omitempty"`
+	LoadProfile string   `json:"loadProfile,omitempty"`
+	LoadStages  []string `json:"loadStages,omitempty"`
+	// SLA koşulları (CI için)
+	Assertions []string `json:"assertions,omitempty"`
+	// Isınma penceresi (istatistik dışı)
+	WarmupSeconds int `json:"warmupSeconds,omitempty"`
+	// HTTP/3 (QUIC, HTTP/2'ye düşer)
+	EnableHTTP3 bool `json:"enableHttp3,omitempty"`
+	// Tekrarlanabilir çalıştırma
+	Seed int64 `json:"seed,omitempty"`
+	// Debug: Stop sonrası goroutine sızıntı kontrolü
+	LeakCheck bool `json:"leakCheck,omitempty"`
+	// Proxy başına kullanım sınırları
+	ProxyMaxHitsPerHour  int `json:"proxyMaxHitsPerHour,omitempty"`
+	ProxyCooldownAfter   int `json:"proxyCooldownAfter,omitempty"`
+	ProxyCooldownMinutes int `json:"proxyCooldownMinutes,omitempty"`
+	// Proxy sağlık skoru, karantina ve kara liste
+	ProxyMinHealthScore    int `json:"proxyMinHealthScore,omitempty"`
+	ProxyQuarantineMinutes int `json:"proxyQuarantineMinutes,omitempty"`
+	ProxyBlacklistAfter    int `json:"proxyBlacklistAfter,omitempty"`
+	// BigQuery export
+	BigQueryExport          bool   `json:"bigQueryExport,omitempty"`
+	BigQueryProject         string `json:"bigQueryProject,omitempty"`
+	BigQueryDataset         string `json:"bigQueryDataset,omitempty"`
+	BigQueryTable           string `json:"bigQueryTable,omitempty"`
+	BigQueryCredentialsFile string `json:"bigQueryCredentialsFile,omitempty"`
+	// Tarayıcı konumu
+	BrowserPath           string   `json:"browserPath,omitempty"`
+	BrowserAutoDownload   bool     `json:"browserAutoDownload,omitempty"`
+	BrowserCacheDir       string   `json:"browserCacheDir,omitempty"`
+	BrowserDownloadSHA256 string   `json:"browserDownloadSha256,omitempty"`
+	BrowserHeadlessMode   string   `json:"browserHeadlessMode,omitempty"`
+	BrowserExtraFlags     []string `json:"browserExtraFlags,omitempty"`
+	BrowserExtensions     []string `json:"browserExtensions,omitempty"`
+	// Staging provası
+	HostMap       []string `json:"hostMap,omitempty"`
+	BasicAuthUser string   `json:"basicAuthUser,omitempty"`
+	BasicAuthPass string   `json:"basicAuthPass,omitempty"`
+	ExtraHeaders  []string `json:"extraHeaders,omitempty"`
+	// Trafik işareti
+	TrafficMarker     string `json:"trafficMarker,omitempty"`
+	TrafficMarkerMode string `json:"trafficMarkerMode,omitempty"`
+	// A/B deney bölmesi
+	ExperimentParam    string   `json:"experimentParam,omitempty"`
+	ExperimentVariants []string `json:"experimentVariants,omitempty"`
+	ExperimentMode     string   `json:"experimentMode,omitempty"`
+	// Uptime monitor
+	MonitorURLs          []string `json:"monitorUrls,omitempty"`
+	MonitorIntervalMin   int      `json:"monitorIntervalMin,omitempty"`
+	MonitorProbes        []string `json:"monitorProbes,omitempty"`
+	MonitorFailThreshold int      `json:"monitorFailThreshold,omitempty"`
+	// Saklama
+	RetentionDays        int `json:"retentionDays,omitempty"`
+	RetentionMaxMB       int `json:"retentionMaxMb,omitempty"`
+	RetentionIntervalMin int `json:"retentionIntervalMin,omitempty"`
+	// Girişli oturum
+	LoginEnabled         bool   `json:"loginEnabled,omitempty"`
+	LoginURL             string `json:"loginUrl,omitempty"`
+	LoginUserSelector    string `json:"loginUserSelector,omitempty"`
+	LoginPassSelector    string `json:"loginPassSelector,omitempty"`
+	LoginSubmitSelector  string `json:"loginSubmitSelector,omitempty"`
+	LoginSuccessSelector string `json:"loginSuccessSelector,omitempty"`
+	LoginCredential      string `json:"loginCredential,omitempty"`
+	VaultFile            string `json:"vaultFile,omitempty"`
 }
 
 type privateProxyFile struct {
@@ -360,6 +598,172 @@ type privateProxyFile struct {
 	Protocol string `json:"protocol"`
 }
 
+// marshalConfigFile config'i config.json biçiminde serileştirir
+func marshalConfigFile(cfg *config.Config) ([]byte, error) {
+	// Private proxy'leri dönüştür
+	var privateProxies []privateProxyFile
+	for _, pp := range cfg.PrivateProxies {
+		privateProxies = append(privateProxies, privateProxyFile{
+			Host:     pp.Host,
+			Port:     pp.Port,
+			User:     pp.User,
+			Pass:     pp.Pass,
+			Protocol: pp.Protocol,
+		})
+	}
+	return json.MarshalIndent(configFile{
+		PROXY_HOST:            cfg.ProxyHost,
+		PROXY_PORT:            cfg.ProxyPort,
+		PROXY_USER:            cfg.ProxyUser,
+		PROXY_PASS:            cfg.ProxyPass,
+		TargetDomain:          cfg.TargetDomain,
+		FallbackGAID:          cfg.GtagID,
+		GA4APISecret:          cfg.GA4APISecret,
+		GA4Properties:         cfg.GA4Properties,
+		GA4EventMapping:       cfg.GA4EventMapping,
+		GA4ServerURL:          cfg.GA4ServerURL,
+		GA4ServerScriptPath:   cfg.GA4ServerScriptPath,
+		GA4ServerCollectPath:  cfg.GA4ServerCollectPath,
+		GA4ServerHeaders:      cfg.GA4ServerHeaders,
+		GA4TrafficType:        cfg.GA4TrafficType,
+		AnalyticsBackend:      cfg.AnalyticsBackend,
+		MatomoURL:             cfg.MatomoURL,
+		MatomoSiteID:          cfg.MatomoSiteID,
+		PlausibleURL:          cfg.PlausibleURL,
+		PlausibleDomain:       cfg.PlausibleDomain,
+		MaxPages:              cfg.MaxPages,
+		DurationMinutes:       cfg.DurationMinutes,
+		HitsPerMinute:         cfg.HitsPerMinute,
+		MaxConcurrentVisits:   cfg.MaxConcurrentVisits,
+		OutputDir:             cfg.OutputDir,
+		ExportFormat:          cfg.ExportFormat,
+		HitLogJSONL:           cfg.HitLogJSONL,
+		SessionLogJSONL:       cfg.SessionLogJSONL,
+		ReportTitle:           cfg.ReportTitle,
+		ReportLogo:            cfg.ReportLogo,
+		ReportPrimaryColor:    cfg.ReportPrimaryColor,
+		ReportBackgroundColor: cfg.ReportBackgroundColor,
+		ReportFooter:          cfg.ReportFooter,
+		CanvasFingerprint:     cfg.CanvasFingerprint,
+		ScrollStrategy:        cfg.ScrollStrategy,
+		SendScrollEvent:       cfg.SendScrollEvent,
+		UseSitemap:            cfg.UseSitemap,
+		SitemapHomepageWeight: cfg.SitemapHomepageWeight,
+		SitemapCacheDir:       cfg.SitemapCacheDir,
+		SitemapCacheHours:     cfg.SitemapCacheHours,
+		LandingPages:          cfg.LandingPages,
+		URLWeights:            cfg.URLWeights,
+		NotFoundRate:          cfg.NotFoundRate,
+		ContentChecks:         cfg.ContentChecks,
+		ContentCheckRate:      cfg.ContentCheckRate,
+		Keywords:              cfg.Keywords,
+		UsePublicProxy:        cfg.UsePublicProxy,
+		ProxySourceURLs:       cfg.ProxySourceURLs,
+		GitHubRepos:           cfg.GitHubRepos,
+		CheckerWorkers:        cfg.CheckerWorkers,
+		ProxyCheckCacheHours:  cfg.ProxyCheckCacheHours,
+		GitHubFetchConcurrency: cfg.GitHubFetchConcurrency,
+		GitHubRepoAllowlist:    cfg.GitHubRepoAllowlist,
+		// Private proxy alanları
+		PrivateProxies:    privateProxies,
+		UsePrivateProxy:   cfg.UsePrivateProxy,
+		// Yeni alanlar
+		DeviceType:        cfg.DeviceType,
+		DeviceBrands:      cfg.DeviceBrands,
+		ReferrerKeyword:   cfg.ReferrerKeyword,
+		ReferrerEnabled:   cfg.ReferrerEnabled,
+		OutboundDomains:   cfg.OutboundDomains,
+		OutboundClickRate: cfg.OutboundClickRate,
+		// Distributed (cluster) alanları
+		EnableDistributed:   cfg.EnableDistributed,
+		DistributedBindAddr: cfg.DistributedBindAddr,
+		DistributedSecret:   cfg.DistributedSecret,
+		DistributedTLSCert:  cfg.DistributedTLSCert,
+		DistributedTLSKey:   cfg.DistributedTLSKey,
+		EnableScheduler:     cfg.EnableScheduler,
+		MetricsAddr:         cfg.MetricsAddr,
+		// Metrik geçmişi
+		MetricsStore:         cfg.MetricsStore,
+		MetricsRetentionDays: cfg.MetricsRetentionDays,
+		// Sıcak Chrome havuzu
+		BrowserPoolEnabled: cfg.BrowserPoolEnabled,
+		BrowserPoolMin:     cfg.BrowserPoolMin,
+		BrowserPoolMax:     cfg.BrowserPoolMax,
+		EnableAutoScaling:  cfg.EnableAutoScaling,
+		BrowserMaxSessions: cfg.BrowserMaxSessions,
+		BrowserMaxAge:      cfg.BrowserMaxAge,
+		// Zaman pencereleri
+		ActiveWindows:   cfg.ActiveWindows,
+		BlackoutWindows: cfg.BlackoutWindows,
+		// Yük modeli
+		LoadMode:    cfg.LoadMode,
+		LoadLevels:  cfg.LoadLevels,
+		LoadProfile: cfg.LoadProfile,
+		LoadStages:  cfg.LoadStages,
+		// SLA koşulları
+		Assertions: cfg.Assertions,
+		// Isınma penceresi
+		WarmupSeconds: cfg.WarmupSeconds,
+		// HTTP/3
+		EnableHTTP3: cfg.EnableHTTP3,
+		// Tekrarlanabilir çalıştırma
+		Seed: cfg.Seed,
+		LeakCheck: cfg.LeakCheck,
+		// Proxy kullanım sınırları
+		ProxyMaxHitsPerHour:  cfg.ProxyMaxHitsPerHour,
+		ProxyCooldownAfter:   cfg.ProxyCooldownAfter,
+		ProxyCooldownMinutes: cfg.ProxyCooldownMinutes,
+		// Proxy sağlık skoru
+		ProxyMinHealthScore:    cfg.ProxyMinHealthScore,
+		ProxyQuarantineMinutes: cfg.ProxyQuarantineMinutes,
+		ProxyBlacklistAfter:    cfg.ProxyBlacklistAfter,
+		// BigQuery export
+		BigQueryExport:          cfg.BigQueryExport,
+		BigQueryProject:         cfg.BigQueryProject,
+		BigQueryDataset:         cfg.BigQueryDataset,
+		BigQueryTable:           cfg.BigQueryTable,
+		BigQueryCredentialsFile: cfg.BigQueryCredentialsFile,
+		// Tarayıcı konumu
+		BrowserPath:           cfg.BrowserPath,
+		BrowserAutoDownload:   cfg.BrowserAutoDownload,
+		BrowserCacheDir:       cfg.BrowserCacheDir,
+		BrowserDownloadSHA256: cfg.BrowserDownloadSHA256,
+		BrowserHeadlessMode:   cfg.BrowserHeadlessMode,
+		BrowserExtraFlags:     cfg.BrowserExtraFlags,
+		BrowserExtensions:     cfg.BrowserExtensions,
+		// Staging provası
+		HostMap:       cfg.HostMap,
+		BasicAuthUser: cfg.BasicAuthUser,
+		BasicAuthPass: cfg.BasicAuthPass,
+		ExtraHeaders:  cfg.ExtraHeaders,
+		// Trafik işareti
+		TrafficMarker:     cfg.TrafficMarker,
+		TrafficMarkerMode: cfg.TrafficMarkerMode,
+		// A/B deney bölmesi
+		ExperimentParam:    cfg.ExperimentParam,
+		ExperimentVariants: cfg.ExperimentVariants,
+		ExperimentMode:     cfg.ExperimentMode,
+		// Uptime monitor
+		MonitorURLs:          cfg.MonitorURLs,
+		MonitorIntervalMin:   cfg.MonitorIntervalMin,
+		MonitorProbes:        cfg.MonitorProbes,
+		MonitorFailThreshold: cfg.MonitorFailThreshold,
+		// Saklama
+		RetentionDays:        cfg.RetentionDays,
+		RetentionMaxMB:       cfg.RetentionMaxMB,
+		RetentionIntervalMin: cfg.RetentionIntervalMin,
+		// Girişli oturum
+		LoginEnabled:         cfg.LoginEnabled,
+		LoginURL:             cfg.LoginURL,
+		LoginUserSelector:    cfg.LoginUserSelector,
+		LoginPassSelector:    cfg.LoginPassSelector,
+		LoginSubmitSelector:  cfg.LoginSubmitSelector,
+		LoginSuccessSelector: cfg.LoginSuccessSelector,
+		LoginCredential:      cfg.LoginCredential,
+		VaultFile:            cfg.VaultFile,
+	}, "", "  ")
+}
+
 func saveConfigToFile(cfg *config.Config) {
 	// SECURITY FIX: Determine config file location - prioritize exe directory, then working directory
 	exeDir := ""
@@ -375,18 +779,6 @@ func saveConfigToFile(cfg *config.Config) {
 		"config.json",
 	}
 	
-	// Private proxy'leri dönüştür
-	var privateProxies []privateProxyFile
-	for _, pp := range cfg.PrivateProxies {
-		privateProxies = append(privateProxies, privateProxyFile{
-			Host:     pp.Host,
-			Port:     pp.Port,
-			User:     pp.User,
-			Pass:     pp.Pass,
-			Protocol: pp.Protocol,
-		})
-	}
-	
 	// SECURITY FIX: Save config to all possible locations, log success/failure
 	var savedPath string
 	var saveErr error
@@ -397,38 +789,7 @@ func saveConfigToFile(cfg *config.Config) {
 			continue
 		}
 		
-		data, err := json.MarshalIndent(configFile{
-			PROXY_HOST:            cfg.ProxyHost,
-			PROXY_PORT:            cfg.ProxyPort,
-			PROXY_USER:            cfg.ProxyUser,
-			PROXY_PASS:            cfg.ProxyPass,
-			TargetDomain:          cfg.TargetDomain,
-			FallbackGAID:          cfg.GtagID,
-			MaxPages:              cfg.MaxPages,
-			DurationMinutes:       cfg.DurationMinutes,
-			HitsPerMinute:         cfg.HitsPerMinute,
-			MaxConcurrentVisits:   cfg.MaxConcurrentVisits,
-			OutputDir:             cfg.OutputDir,
-			ExportFormat:          cfg.ExportFormat,
-			CanvasFingerprint:     cfg.CanvasFingerprint,
-			ScrollStrategy:        cfg.ScrollStrategy,
-			SendScrollEvent:       cfg.SendScrollEvent,
-			UseSitemap:            cfg.UseSitemap,
-			SitemapHomepageWeight: cfg.SitemapHomepageWeight,
-			Keywords:              cfg.Keywords,
-			UsePublicProxy:        cfg.UsePublicProxy,
-			ProxySourceURLs:       cfg.ProxySourceURLs,
-			GitHubRepos:           cfg.GitHubRepos,
-			CheckerWorkers:        cfg.CheckerWorkers,
-			// Private proxy alanları
-			PrivateProxies:    privateProxies,
-			UsePrivateProxy:   cfg.UsePrivateProxy,
-			// Yeni alanlar
-			DeviceType:        cfg.DeviceType,
-			DeviceBrands:      cfg.DeviceBrands,
-			ReferrerKeyword:   cfg.ReferrerKeyword,
-			ReferrerEnabled:   cfg.ReferrerEnabled,
-		}, "", "  ")
+		data, err := marshalConfigFile(cfg)
 		if err != nil {
 			saveErr = err
 			continue
@@ -509,6 +870,10 @@ func (s *Server) Routes() http.Handler {
 	mux.HandleFunc("/api/config", rateLimitMiddleware(s.handleConfig))
 	mux.HandleFunc("/api/start", rateLimitMiddleware(s.handleStart))
 	mux.HandleFunc("/api/stop", rateLimitMiddleware(s.handleStop))
+	mux.HandleFunc("/api/killswitch", rateLimitMiddleware(s.handleKillSwitch))
+	mux.HandleFunc("/api/consent", rateLimitMiddleware(s.handleConsent))
+	mux.HandleFunc("/api/consent/targets", rateLimitMiddleware(s.handleConsentTargets))
+	mux.HandleFunc("/api/consent/audit", rateLimitMiddleware(s.handleConsentAudit))
 	mux.HandleFunc("/api/status", rateLimitMiddleware(s.handleStatus))
 	mux.HandleFunc("/api/logs", rateLimitMiddleware(s.handleLogs))
 	mux.HandleFunc("/api/ws", s.handleWebSocket) // WebSocket has its own handling
@@ -517,6 +882,7 @@ func (s *Server) Routes() http.Handler {
 	mux.HandleFunc("/api/proxy/live", rateLimitMiddleware(s.handleProxyLive))
 	mux.HandleFunc("/api/proxy/export", rateLimitMiddleware(s.handleProxyExport))
 	mux.HandleFunc("/api/proxy/test", rateLimitMiddleware(s.handleProxyTest))
+	mux.HandleFunc("/api/proxy/add", rateLimitMiddleware(s.handleProxyAdd))
 	mux.HandleFunc("/api/gsc/queries", rateLimitMiddleware(s.handleGSCQueries))
 
 	// Metrics endpoints
@@ -524,10 +890,13 @@ func (s *Server) Routes() http.Handler {
 	mux.HandleFunc("/api/metrics/json", rateLimitMiddleware(MetricsJSONHandler(s.metrics))) // JSON format
 	mux.HandleFunc("/api/metrics/stream", s.metricsWS.HandleWebSocket)      // Real-time WebSocket stream
 	mux.HandleFunc("/api/metrics/dashboard", rateLimitMiddleware(DashboardHandler()))       // Grafana dashboard JSON
+	mux.HandleFunc("/api/metrics/history", rateLimitMiddleware(MetricsHistoryHandler(s.history))) // Son 24 saat, dakikalık
 	
 	// System Optimization endpoints
 	mux.HandleFunc("/api/system/info", rateLimitMiddleware(s.handleSystemInfo))
 	mux.HandleFunc("/api/system/optimize", rateLimitMiddleware(s.handleSystemOptimize))
+	mux.HandleFunc("/api/system/browser", rateLimitMiddleware(s.handleSystemBrowser))
+	mux.HandleFunc("/api/system/browserflags", rateLimitMiddleware(s.handleSystemBrowserFlags))
 	
 	// Network Optimization endpoints
 	mux.HandleFunc("/api/network/config", rateLimitMiddleware(s.handleNetworkConfig))
@@ -539,16 +908,72 @@ func (s *Server) Routes() http.Handler {
 	// Telegram Notification endpoints
 	mux.HandleFunc("/api/notification/telegram/test", rateLimitMiddleware(s.handleTelegramTest))
 	mux.HandleFunc("/api/notification/telegram/config", rateLimitMiddleware(s.handleTelegramConfig))
+	mux.HandleFunc("/api/notification/webhook", rateLimitMiddleware(s.handleWebhookConfig))
+	mux.HandleFunc("/api/notification/webhook/test", rateLimitMiddleware(s.handleWebhookTest))
+
+	// Uptime monitor: URL kümesini aralıklarla kontrol et, başarısızlıkta uyar
+	mux.HandleFunc("/api/monitor", rateLimitMiddleware(s.handleMonitor))
+	mux.HandleFunc("/api/vault", rateLimitMiddleware(s.handleVault))
 
 	// Scheduler endpoints
 	mux.HandleFunc("/api/scheduler/jobs", rateLimitMiddleware(s.handleSchedulerJobs))
 	mux.HandleFunc("/api/scheduler/start", rateLimitMiddleware(s.handleSchedulerStart))
 	mux.HandleFunc("/api/scheduler/stop", rateLimitMiddleware(s.handleSchedulerStop))
+	mux.HandleFunc("/api/scheduler/status", rateLimitMiddleware(s.handleSchedulerStatus))
 
 	// SERP Report endpoint
 	mux.HandleFunc("/api/serp/report", rateLimitMiddleware(s.handleSERPReport))
 
-	return mux
+	// Replay (önceki raporu tekrar oynat)
+	mux.HandleFunc("/api/replay/reports", rateLimitMiddleware(s.handleReplayReports))
+	mux.HandleFunc("/api/runs", rateLimitMiddleware(s.handleRuns))           // Geçmiş çalıştırmalar ve rapor linkleri
+	mux.HandleFunc("/api/runs/file", rateLimitMiddleware(s.handleRunFile))   // Rapor dosyası (?name=)
+
+	// Hit kayıtlarını CSV / Excel olarak indir
+	mux.HandleFunc("/api/reports/download", rateLimitMiddleware(s.handleReportDownload))
+
+	// Analytics pre-flight: hedef sayfadaki etiketleri yapılandırmayla karşılaştır
+	mux.HandleFunc("/api/analytics/preflight", rateLimitMiddleware(s.handleAnalyticsPreflight))
+
+	// Config kaydedilmeden önce değişecek alanların önizlemesi
+	mux.HandleFunc("/api/config/diff", rateLimitMiddleware(s.handleConfigDiff))
+	mux.HandleFunc("/api/config/profiles", rateLimitMiddleware(s.handleConfigProfiles))
+
+	// Kurulumlar arası taşıma: tüm uygulama durumunun yedeği / geri yüklenmesi
+	mux.HandleFunc("/api/backup", rateLimitMiddleware(s.handleBackup))
+	mux.HandleFunc("/api/restore", rateLimitMiddleware(s.handleRestore))
+
+	// Son oturumların adım adım zaman çizelgeleri
+	mux.HandleFunc("/api/timelines", rateLimitMiddleware(s.handleTimelines))
+	mux.HandleFunc("/api/logs/structured", rateLimitMiddleware(s.handleStructuredLogs))
+
+	// Onboarding sihirbazı: domain → analytics → proxy → hız → doğrulanmış config
+	mux.HandleFunc("/api/wizard", rateLimitMiddleware(s.handleWizard))
+	mux.HandleFunc("/api/wizard/", rateLimitMiddleware(s.handleWizard))
+
+	// Test sürüşü: mevcut config ile tek ziyaret, adım adım iz
+	mux.HandleFunc("/api/testvisit", rateLimitMiddleware(s.handleTestVisit))
+
+	// Debug: Stop sonrası goroutine sızıntı raporu (leak_check)
+	mux.HandleFunc("/api/debug/leaks", rateLimitMiddleware(s.handleLeakReport))
+
+	// Distributed (cluster) endpoints
+	mux.HandleFunc("/api/cluster/status", rateLimitMiddleware(s.handleClusterStatus))
+	mux.HandleFunc("/api/cluster/config", rateLimitMiddleware(s.handleClusterConfig))
+
+	// Kampanyalar: farklı domain'lere eşzamanlı, bağımsız simülasyonlar
+	mux.HandleFunc("/api/campaigns", rateLimitMiddleware(s.handleCampaigns))
+	mux.HandleFunc("/api/campaigns/", rateLimitMiddleware(s.handleCampaign))
+	mux.HandleFunc("/api/keywords/clusters", rateLimitMiddleware(s.handleKeywordClusters))
+	mux.HandleFunc("/api/keywords/clusters/", rateLimitMiddleware(s.handleKeywordCluster))
+
+	// Saklama: kategori başına disk kullanımı ve elle temizlik
+	mux.HandleFunc("/api/storage", rateLimitMiddleware(s.handleStorage))
+
+	// API sürümü; tüm /api/ yolları /api/v1/ altında da sunulur
+	mux.HandleFunc("/api/version", rateLimitMiddleware(s.handleAPIVersion))
+
+	return apiVersioning(mux)
 }
 
 // SECURITY: Health check endpoint
@@ -599,21 +1024,50 @@ func (s *Server) handleConfig(w http.ResponseWriter, r *http.Request) {
 			"max_concurrent_visits":  cfg.MaxConcurrentVisits,
 			"output_dir":             cfg.OutputDir,
 			"export_format":          cfg.ExportFormat,
+			"hit_log_jsonl":          cfg.HitLogJSONL,
+			"session_log_jsonl":      cfg.SessionLogJSONL,
+			"report_title":            cfg.ReportTitle,
+			"report_logo":             cfg.ReportLogo,
+			"report_primary_color":    cfg.ReportPrimaryColor,
+			"report_background_color": cfg.ReportBackgroundColor,
+			"report_footer":           cfg.ReportFooter,
 			"canvas_fingerprint":     cfg.CanvasFingerprint,
 			"scroll_strategy":        cfg.ScrollStrategy,
 			"send_scroll_event":      cfg.SendScrollEvent,
 			"use_sitemap":            cfg.UseSitemap,
 			"sitemap_homepage_weight": cfg.SitemapHomepageWeight,
+			"sitemap_cache_hours":    cfg.SitemapCacheHours,
+			"landing_pages":          cfg.LandingPages,
+			"url_weights":            cfg.URLWeights,
+			"not_found_rate":         cfg.NotFoundRate,
+			"content_checks":         cfg.ContentChecks,
+			"content_check_rate":     cfg.ContentCheckRate,
 			"keywords":               cfg.Keywords,
 			"proxy_host":             cfg.ProxyHost,
 			"proxy_port":             cfg.ProxyPort,
 			"proxy_user":             cfg.ProxyUser,
 			"proxy_pass":             cfg.ProxyPass,
 			"gtag_id":                cfg.GtagID,
+			"ga4_api_secret":         maskConfigSecret(cfg.GA4APISecret), // POST'ta maske geri gelirse değişmez
+			"ga4_properties":         maskGA4Properties(cfg.GA4Properties), // api_secret'lar maskeli; POST'ta geri yüklenir
+			"ga4_event_mapping":      cfg.GA4EventMapping,
+			"ga4_server_url":          cfg.GA4ServerURL,
+			"ga4_server_script_path":  cfg.GA4ServerScriptPath,
+			"ga4_server_collect_path": cfg.GA4ServerCollectPath,
+			"ga4_server_headers":      maskHeaderValues(cfg.GA4ServerHeaders),
+			"ga4_traffic_type":        cfg.GA4TrafficType,
+			"analytics_backend":       cfg.AnalyticsBackend,
+			"matomo_url":              cfg.MatomoURL,
+			"matomo_site_id":          cfg.MatomoSiteID,
+			"plausible_url":           cfg.PlausibleURL,
+			"plausible_domain":        cfg.PlausibleDomain,
 			"use_public_proxy":       cfg.UsePublicProxy,
 			"proxy_source_urls":      cfg.ProxySourceURLs,
 			"github_repos":           cfg.GitHubRepos,
 			"checker_workers":        cfg.CheckerWorkers,
+			"proxy_check_cache_hours": cfg.ProxyCheckCacheHours,
+			"github_fetch_concurrency": cfg.GitHubFetchConcurrency,
+			"github_repo_allowlist":    cfg.GitHubRepoAllowlist,
 			// Private proxy alanları
 			"private_proxies":        privateProxiesAPI,
 			"use_private_proxy":      cfg.UsePrivateProxy,
@@ -623,6 +1077,8 @@ func (s *Server) handleConfig(w http.ResponseWriter, r *http.Request) {
 			"referrer_keyword":       cfg.ReferrerKeyword,
 			"referrer_enabled":       cfg.ReferrerEnabled,
 			"referrer_source":        cfg.ReferrerSource,
+			"outbound_domains":       cfg.OutboundDomains,
+			"outbound_click_rate":    cfg.OutboundClickRate,
 			// Traffic Simulation
 			"min_page_duration":      cfg.MinPageDuration,
 			"max_page_duration":      cfg.MaxPageDuration,
@@ -658,6 +1114,7 @@ func (s *Server) handleConfig(w http.ResponseWriter, r *http.Request) {
 			"gsc_property_url":       cfg.GscPropertyUrl,
 			"enable_gsc_integration": cfg.EnableGscIntegration,
 			"use_gsc_queries":        cfg.UseGscQueries,
+			"gsc_cache_hours":        cfg.GscCacheHours,
 			// Returning Visitor
 			"returning_visitor_rate": cfg.ReturningVisitorRate,
 			"returning_visitor_days": cfg.ReturningVisitorDays,
@@ -700,9 +1157,12 @@ func (s *Server) handleConfig(w http.ResponseWriter, r *http.Request) {
 			"enable_serp_simulation": cfg.EnableSerpSimulation,
 			"serp_scroll_before_click": cfg.SerpScrollBeforeClick,
 			// Browser Pool
+			"browser_pool_enabled":   cfg.BrowserPoolEnabled,
 			"browser_pool_min":       cfg.BrowserPoolMin,
 			"browser_pool_max":       cfg.BrowserPoolMax,
 			"enable_auto_scaling":    cfg.EnableAutoScaling,
+			"browser_max_sessions":   cfg.BrowserMaxSessions,
+			"browser_max_age":        cfg.BrowserMaxAge,
 			"worker_queue_size":      cfg.WorkerQueueSize,
 			"enable_priority_queue":  cfg.EnablePriorityQueue,
 			"enable_failure_recovery": cfg.EnableFailureRecovery,
@@ -748,328 +1208,95 @@ func (s *Server) handleConfig(w http.ResponseWriter, r *http.Request) {
 			"block_media":            cfg.BlockMedia,
 			// Anti-Detect Mode
 			"anti_detect_mode":       cfg.AntiDetectMode,
+			// Zaman pencereleri
+			"active_windows":         cfg.ActiveWindows,
+			"blackout_windows":       cfg.BlackoutWindows,
+			"load_mode":              cfg.LoadMode,
+			"load_levels":            cfg.LoadLevels,
+			"load_profile":           cfg.LoadProfile,
+			"load_stages":            cfg.LoadStages,
+			"assertions":             cfg.Assertions,
+			"warmup_seconds":         cfg.WarmupSeconds,
+			"enable_http3":           cfg.EnableHTTP3,
+			"seed":                   cfg.Seed,
+			"leak_check":             cfg.LeakCheck,
+			"proxy_max_hits_per_hour": cfg.ProxyMaxHitsPerHour,
+			"proxy_cooldown_after":   cfg.ProxyCooldownAfter,
+			"proxy_cooldown_minutes": cfg.ProxyCooldownMinutes,
+			"proxy_min_health_score":   cfg.ProxyMinHealthScore,
+			"proxy_quarantine_minutes": cfg.ProxyQuarantineMinutes,
+			"proxy_blacklist_after":    cfg.ProxyBlacklistAfter,
+			"bigquery_export":           cfg.BigQueryExport,
+			"bigquery_project":          cfg.BigQueryProject,
+			"bigquery_dataset":          cfg.BigQueryDataset,
+			"bigquery_table":            cfg.BigQueryTable,
+			"bigquery_credentials_file": cfg.BigQueryCredentialsFile,
+			"browser_path":              cfg.BrowserPath,
+			"browser_auto_download":     cfg.BrowserAutoDownload,
+			"browser_cache_dir":         cfg.BrowserCacheDir,
+			"browser_download_sha256":   cfg.BrowserDownloadSHA256,
+			"browser_headless_mode":     cfg.BrowserHeadlessMode,
+			"browser_extra_flags":       cfg.BrowserExtraFlags,
+			"browser_extensions":        cfg.BrowserExtensions,
+			"host_map":                  cfg.HostMap,
+			"basic_auth_user":           cfg.BasicAuthUser,
+			"basic_auth_pass":           maskConfigSecret(cfg.BasicAuthPass), // POST'ta maske geri gelirse değişmez
+			"extra_headers":             maskHeaderValues(cfg.ExtraHeaders),
+			"traffic_marker":            cfg.TrafficMarker,
+			"traffic_marker_mode":       cfg.TrafficMarkerMode,
+			"experiment_param":          cfg.ExperimentParam,
+			"experiment_variants":       cfg.ExperimentVariants,
+			"experiment_mode":           cfg.ExperimentMode,
+			"monitor_urls":              cfg.MonitorURLs,
+			"monitor_interval_min":      cfg.MonitorIntervalMin,
+			"monitor_probes":            cfg.MonitorProbes,
+			"monitor_fail_threshold":    cfg.MonitorFailThreshold,
+			"retention_days":            cfg.RetentionDays,
+			"retention_max_mb":          cfg.RetentionMaxMB,
+			"retention_interval_min":    cfg.RetentionIntervalMin,
+			"login_enabled":             cfg.LoginEnabled,
+			"login_url":                 cfg.LoginURL,
+			"login_user_selector":       cfg.LoginUserSelector,
+			"login_pass_selector":       cfg.LoginPassSelector,
+			"login_submit_selector":     cfg.LoginSubmitSelector,
+			"login_success_selector":    cfg.LoginSuccessSelector,
+			"login_credential":          cfg.LoginCredential,
+			"vault_file":                cfg.VaultFile,
 		})
 		return
 	}
-	if r.Method == http.MethodPost {
-		var body struct {
-			// Basic Settings
-			TargetDomain          string   `json:"target_domain"`
-			MaxPages              int      `json:"max_pages"`
-			DurationMinutes       int      `json:"duration_minutes"`
-			HitsPerMinute         int      `json:"hits_per_minute"`
-			MaxConcurrentVisits   int      `json:"max_concurrent_visits"`
-			OutputDir             string   `json:"output_dir"`
-			ExportFormat          string   `json:"export_format"`
-			CanvasFingerprint     bool     `json:"canvas_fingerprint"`
-			ScrollStrategy        string   `json:"scroll_strategy"`
-			SendScrollEvent       bool     `json:"send_scroll_event"`
-			UseSitemap            bool     `json:"use_sitemap"`
-			SitemapHomepageWeight int      `json:"sitemap_homepage_weight"`
-			Keywords              []string `json:"keywords"`
-			GtagID                string   `json:"gtag_id"`
-			AntiDetectMode        bool     `json:"anti_detect_mode"`
-			
-			// Device & Traffic
-			DeviceType        string   `json:"device_type"`
-			DeviceBrands      []string `json:"device_brands"`
-			MinPageDuration   int      `json:"min_page_duration"`
-			MaxPageDuration   int      `json:"max_page_duration"`
-			
-			// Session & Bounce
-			EnableSessionDepth   bool `json:"enable_session_depth"`
-			SessionMinPages      int  `json:"session_min_pages"`
-			SessionMaxPages      int  `json:"session_max_pages"`
-			EnableBounceControl  bool `json:"enable_bounce_control"`
-			TargetBounceRate     int  `json:"target_bounce_rate"`
-			
-			// Behavior Simulation
-			SimulateMouseMove   bool `json:"simulate_mouse_move"`
-			SimulateKeyboard    bool `json:"simulate_keyboard"`
-			SimulateClicks      bool `json:"simulate_clicks"`
-			SimulateFocus       bool `json:"simulate_focus"`
-			
-			// Referrer
-			ReferrerEnabled   bool   `json:"referrer_enabled"`
-			ReferrerSource    string `json:"referrer_source"`
-			ReferrerKeyword   string `json:"referrer_keyword"`
-			
-			// Geo
-			GeoCountry   string `json:"geo_country"`
-			GeoLanguage  string `json:"geo_language"`
-			GeoTimezone  string `json:"geo_timezone"`
-			
-			// Analytics Events
-			SendPageView        bool `json:"send_page_view"`
-			SendSessionStart    bool `json:"send_session_start"`
-			SendUserEngagement  bool `json:"send_user_engagement"`
-			SendFirstVisit      bool `json:"send_first_visit"`
-			
-			// GSC
-			EnableGscIntegration  bool   `json:"enable_gsc_integration"`
-			UseGscQueries         bool   `json:"use_gsc_queries"`
-			GscPropertyUrl        string `json:"gsc_property_url"`
-			GscApiKey             string `json:"gsc_api_key"`
-			
-			// Browser Profile
-			EnableBrowserProfile  bool   `json:"enable_browser_profile"`
-			BrowserProfilePath    string `json:"browser_profile_path"`
-			MaxBrowserProfiles    int    `json:"max_browser_profiles"`
-			PersistCookies        bool   `json:"persist_cookies"`
-			PersistLocalStorage   bool   `json:"persist_local_storage"`
-			
-			// Returning Visitor
-			EnableReturningVisitor  bool `json:"enable_returning_visitor"`
-			ReturningVisitorRate    int  `json:"returning_visitor_rate"`
-			ReturningVisitorDays    int  `json:"returning_visitor_days"`
-			
-			// Network
-			EnableHTTP3           bool `json:"enable_http3"`
-			EnableConnectionPool  bool `json:"enable_connection_pool"`
-			EnableTCPFastOpen     bool `json:"enable_tcp_fast_open"`
-			MaxIdleConns          int  `json:"max_idle_conns"`
-			MaxConnsPerHost       int  `json:"max_conns_per_host"`
-			
-			// System
-			EnableCPUAffinity  bool `json:"enable_cpu_affinity"`
-			EnableNUMA         bool `json:"enable_numa"`
-			
-			// VM Spoofing
-			EnableVMSpoofing    bool   `json:"enable_vm_spoofing"`
-			HideVMIndicators    bool   `json:"hide_vm_indicators"`
-			SpoofHardwareIDs    bool   `json:"spoof_hardware_ids"`
-			RandomizeVMParams   bool   `json:"randomize_vm_params"`
-			VMType              string `json:"vm_type"`
-			
-			// Proxy
-			UseProxy       bool   `json:"use_proxy"`
-			ProxyHost      string `json:"proxy_host"`
-			ProxyPort      int    `json:"proxy_port"`
-			ProxyUser      string `json:"proxy_user"`
-			ProxyPass      string `json:"proxy_pass"`
-			UsePublicProxy bool   `json:"use_public_proxy"`
-			ProxySourceURLs []string `json:"proxy_source_urls"`
-			GitHubRepos     []string `json:"github_repos"`
-			CheckerWorkers  int      `json:"checker_workers"`
-			
-			// Private proxy
-			UsePrivateProxy   bool     `json:"use_private_proxy"`
-			PrivateProxies    []struct {
-				Host     string `json:"host"`
-				Port     int    `json:"port"`
-				User     string `json:"user"`
-				Pass     string `json:"pass"`
-				Protocol string `json:"protocol"`
-			} `json:"private_proxies"`
-			
-			// Proxy List (textarea'dan gelen)
-			ProxyList string `json:"proxy_list"`
-		}
-		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+	if r.Method == http.MethodPost || r.Method == http.MethodPatch {
+		// Gövdede olmayan alanlar mevcut değerini korur (POST ve PATCH aynı)
+		s.mu.Lock()
+		cur := *s.cfg
+		s.mu.Unlock()
+		body, err := decodeConfigUpdate(r.Body, &cur)
+		if err != nil {
 			log.Printf("[ERROR] Config decode error: %v", err)
 			http.Error(w, "Invalid JSON: "+err.Error(), 400)
 			return
 		}
-		s.mu.Lock()
-		// Basic Settings
-		s.cfg.TargetDomain = body.TargetDomain
-		s.cfg.MaxPages = body.MaxPages
-		s.cfg.DurationMinutes = body.DurationMinutes
-		s.cfg.HitsPerMinute = body.HitsPerMinute
-		s.cfg.MaxConcurrentVisits = body.MaxConcurrentVisits
-		s.cfg.OutputDir = body.OutputDir
-		s.cfg.ExportFormat = body.ExportFormat
-		s.cfg.CanvasFingerprint = body.CanvasFingerprint
-		s.cfg.ScrollStrategy = body.ScrollStrategy
-		s.cfg.SendScrollEvent = body.SendScrollEvent
-		s.cfg.UseSitemap = body.UseSitemap
-		s.cfg.SitemapHomepageWeight = body.SitemapHomepageWeight
-		s.cfg.Keywords = body.Keywords
-		s.cfg.GtagID = body.GtagID
-		s.cfg.AntiDetectMode = body.AntiDetectMode
-		
-		// Device & Traffic
-		s.cfg.DeviceType = body.DeviceType
-		s.cfg.DeviceBrands = body.DeviceBrands
-		s.cfg.MinPageDuration = body.MinPageDuration
-		s.cfg.MaxPageDuration = body.MaxPageDuration
-		
-		// Session & Bounce
-		s.cfg.EnableSessionDepth = body.EnableSessionDepth
-		s.cfg.SessionMinPages = body.SessionMinPages
-		s.cfg.SessionMaxPages = body.SessionMaxPages
-		s.cfg.EnableBounceControl = body.EnableBounceControl
-		s.cfg.TargetBounceRate = body.TargetBounceRate
-		
-		// Behavior Simulation
-		s.cfg.SimulateMouseMove = body.SimulateMouseMove
-		s.cfg.SimulateKeyboard = body.SimulateKeyboard
-		s.cfg.SimulateClicks = body.SimulateClicks
-		s.cfg.SimulateFocus = body.SimulateFocus
-		
-		// Referrer
-		s.cfg.ReferrerEnabled = body.ReferrerEnabled
-		s.cfg.ReferrerSource = body.ReferrerSource
-		s.cfg.ReferrerKeyword = body.ReferrerKeyword
-		
-		// Geo
-		s.cfg.GeoCountry = body.GeoCountry
-		s.cfg.GeoLanguage = body.GeoLanguage
-		s.cfg.GeoTimezone = body.GeoTimezone
-		
-		// Analytics Events
-		s.cfg.SendPageView = body.SendPageView
-		s.cfg.SendSessionStart = body.SendSessionStart
-		s.cfg.SendUserEngagement = body.SendUserEngagement
-		s.cfg.SendFirstVisit = body.SendFirstVisit
-		
-		// GSC
-		s.cfg.EnableGscIntegration = body.EnableGscIntegration
-		s.cfg.UseGscQueries = body.UseGscQueries
-		s.cfg.GscPropertyUrl = body.GscPropertyUrl
-		s.cfg.GscApiKey = body.GscApiKey
-		
-		// Browser Profile
-		s.cfg.EnableBrowserProfile = body.EnableBrowserProfile
-		s.cfg.BrowserProfilePath = body.BrowserProfilePath
-		s.cfg.MaxBrowserProfiles = body.MaxBrowserProfiles
-		s.cfg.PersistCookies = body.PersistCookies
-		s.cfg.PersistLocalStorage = body.PersistLocalStorage
-		
-		// Returning Visitor
-		s.cfg.EnableReturningVisitor = body.EnableReturningVisitor
-		s.cfg.ReturningVisitorRate = body.ReturningVisitorRate
-		s.cfg.ReturningVisitorDays = body.ReturningVisitorDays
-		
-		// Network
-		s.cfg.EnableHTTP3 = body.EnableHTTP3
-		s.cfg.EnableConnectionPool = body.EnableConnectionPool
-		s.cfg.EnableTCPFastOpen = body.EnableTCPFastOpen
-		s.cfg.MaxIdleConns = body.MaxIdleConns
-		s.cfg.MaxConnsPerHost = body.MaxConnsPerHost
-		
-		// System
-		s.cfg.EnableCPUAffinity = body.EnableCPUAffinity
-		s.cfg.EnableNUMA = body.EnableNUMA
-		
-		// VM Spoofing
-		s.cfg.EnableVMSpoofing = body.EnableVMSpoofing
-		s.cfg.HideVMIndicators = body.HideVMIndicators
-		s.cfg.SpoofHardwareIDs = body.SpoofHardwareIDs
-		s.cfg.RandomizeVMParams = body.RandomizeVMParams
-		s.cfg.VMType = body.VMType
-		
-		// Proxy
-		s.cfg.UseProxy = body.UseProxy
-		s.cfg.ProxyHost = body.ProxyHost
-		s.cfg.ProxyPort = body.ProxyPort
-		s.cfg.ProxyUser = body.ProxyUser
-		s.cfg.ProxyPass = body.ProxyPass
-		s.cfg.UsePublicProxy = body.UsePublicProxy
-		s.cfg.ProxySourceURLs = body.ProxySourceURLs
-		if body.GitHubRepos != nil {
-			s.cfg.GitHubRepos = body.GitHubRepos
-		}
-		if body.CheckerWorkers > 0 {
-			s.cfg.CheckerWorkers = body.CheckerWorkers
-		}
-		
-		// Private proxy'leri config'e kaydet
-		s.cfg.UsePrivateProxy = body.UsePrivateProxy
-		s.cfg.PrivateProxies = nil // Önce temizle
-		for _, pp := range body.PrivateProxies {
-			if pp.Host != "" && pp.Port > 0 {
-				protocol := pp.Protocol
-				if protocol == "" {
-					protocol = "http"
-				}
-				s.cfg.PrivateProxies = append(s.cfg.PrivateProxies, config.PrivateProxy{
-					Host:     pp.Host,
-					Port:     pp.Port,
-					User:     pp.User,
-					Pass:     pp.Pass,
-					Protocol: protocol,
-				})
-			}
-		}
-		
-		// Private proxy varsa UsePrivateProxy'yi otomatik aktifleştir
-		if len(s.cfg.PrivateProxies) > 0 {
-			s.cfg.UsePrivateProxy = true
-		}
-		
-		// Proxy List parsing (textarea'dan gelen)
-		if body.ProxyList != "" {
-			// Satır satır parse et ve private_proxies'e ekle
-			lines := strings.Split(body.ProxyList, "\n")
-			for _, line := range lines {
-				line = strings.TrimSpace(line)
-				if line == "" {
-					continue
-				}
-				
-				// Parse proxy URL: protocol://[user:pass@]host:port
-				proxyURL, err := url.Parse(line)
-				if err != nil {
-					log.Printf("[WARN] Invalid proxy URL skipped: %s - %v", line, err)
-					continue
-				}
-				
-				host := proxyURL.Hostname()
-				portStr := proxyURL.Port()
-				port := 0
-				if portStr != "" {
-					if p, err := strconv.Atoi(portStr); err == nil {
-						port = p
-					}
-				}
-				
-				if host == "" || port == 0 {
-					log.Printf("[WARN] Proxy missing host or port, skipped: %s", line)
-					continue
-				}
-				
-				protocol := proxyURL.Scheme
-				if protocol == "" {
-					protocol = "http"
-				}
-				
-				user := ""
-				pass := ""
-				if proxyURL.User != nil {
-					user = proxyURL.User.Username()
-					if p, ok := proxyURL.User.Password(); ok {
-						pass = p
-					}
-				}
-				
-				s.cfg.PrivateProxies = append(s.cfg.PrivateProxies, config.PrivateProxy{
-					Host:     host,
-					Port:     port,
-					User:     user,
-					Pass:     pass,
-					Protocol: protocol,
-				})
-				
-				log.Printf("[INFO] Added proxy: %s://%s:%d", protocol, host, port)
-			}
-			
-			// Proxy varsa UsePrivateProxy ve UseProxy'yi aktifleştir
-			if len(s.cfg.PrivateProxies) > 0 {
-				s.cfg.UsePrivateProxy = true
-				s.cfg.UseProxy = true
-				s.cfg.ProxyEnabled = true
-			}
+		if err := body.validate(); err != nil {
+			http.Error(w, err.Error(), 400)
+			return
 		}
-		
+		s.mu.Lock()
+		old := *s.cfg
+		body.apply(s.cfg)
 		s.cfg.ApplyDefaults()
 		s.cfg.ComputeDerived()
 		// BUG FIX #3: Config kopyasını al - lock dışında save yapmak için
 		cfgCopy := *s.cfg
 		s.mu.Unlock()
 		saveConfigToFile(&cfgCopy)
+		s.applyBrowserFlags()
+		// Tarayıcı ayarları değiştiyse yeniden ara
+		if old.BrowserPath != cfgCopy.BrowserPath || old.BrowserCacheDir != cfgCopy.BrowserCacheDir ||
+			old.BrowserAutoDownload != cfgCopy.BrowserAutoDownload || old.BrowserDownloadSHA256 != cfgCopy.BrowserDownloadSHA256 {
+			go s.locateBrowser()
+		}
 		w.WriteHeader(http.StatusOK)
-		json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+		json.NewEncoder(w).Encode(map[string]interface{}{"status": "ok", "changes": diffConfig(&old, &cfgCopy)})
 		return
 	}
 	http.Error(w, "Method not allowed", 405)
@@ -1080,6 +1307,9 @@ func (s *Server) handleStart(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Method not allowed", 405)
 		return
 	}
+	if s.rejectIfKilled(w) {
+		return
+	}
 
 	s.mu.Lock()
 	if s.cancel != nil {
@@ -1094,44 +1324,78 @@ func (s *Server) handleStart(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// İsteğe bağlı lang (client'tan gelen seçim)
+	// İzin listesinde olmayan hedefe trafik gönderilmeden önce açık onay gerekir (dry-run de sitemap'i çeker)
+	if s.rejectUnconfirmedTarget(w, r, domain) {
+		s.mu.Unlock()
+		return
+	}
+
+	// Dry-run: planı hazırla ve dön; sayfa isteği yapılmaz, çalıştırma başlamaz
+	if dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dry_run")); dryRun {
+		cfg := *s.cfg
+		livePool := s.livePoolFor(&cfg, reporter.New(cfg.OutputDir, cfg.ExportFormat, cfg.TargetDomain))
+		s.mu.Unlock()
+		plan, err := simulator.DryRun(r.Context(), &cfg, livePool, time.Now())
+		if err != nil {
+			http.Error(w, err.Error(), 400)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"dry_run": true,
+			"plan":    plan,
+		})
+		return
+	}
+
+	// İsteğe bağlı lang (client'tan gelen seçim) ve distributed (cluster'a dağıt)
 	locale := "tr"
+	distributedMode := s.cfg.EnableDistributed
+	replayFile := ""
 	if body, err := io.ReadAll(r.Body); err == nil && len(body) > 0 {
 		var req struct {
-			Lang string `json:"lang"`
+			Lang        string `json:"lang"`
+			Distributed *bool  `json:"distributed"`
+			Replay      string `json:"replay"` // OutputDir içindeki rapor dosyası
 		}
-		if json.Unmarshal(body, &req) == nil && (req.Lang == "en" || req.Lang == "tr") {
-			locale = req.Lang
+		if json.Unmarshal(body, &req) == nil {
+			if req.Lang == "en" || req.Lang == "tr" {
+				locale = req.Lang
+			}
+			if req.Distributed != nil {
+				distributedMode = *req.Distributed
+			}
+			replayFile = req.Replay
 		}
 	}
 
-	rep := reporter.NewWithLocale(s.cfg.OutputDir, s.cfg.ExportFormat, s.cfg.TargetDomain, locale)
-	var livePool *proxy.LivePool
-	
-	// Private proxy modu: kullanıcının kendi proxy'lerini LivePool'a ekle
-	if s.cfg.UsePrivateProxy && len(s.cfg.PrivateProxies) > 0 {
-		// Yeni LivePool oluştur ve private proxy'leri ekle
-		livePool = proxy.NewLivePool()
-		for _, pp := range s.cfg.PrivateProxies {
-			if pp.Host != "" && pp.Port > 0 {
-				protocol := pp.Protocol
-				if protocol == "" {
-					protocol = "http"
-				}
-				livePool.AddUnchecked(&proxy.ProxyConfig{
-					Host:     pp.Host,
-					Port:     pp.Port,
-					Username: pp.User,
-					Password: pp.Pass,
-					Protocol: protocol,
-				})
-			}
+	// Replay: önceki raporun ziyaret dizisini tekrar oynat (yalnızca yerel mod)
+	var replay *simulator.ReplayPlan
+	if replayFile != "" {
+		var errReplay error
+		replay, errReplay = simulator.LoadReplay(filepath.Join(s.cfg.OutputDir, filepath.Base(replayFile)))
+		if errReplay != nil {
+			s.mu.Unlock()
+			http.Error(w, "Replay dosyası okunamadı: "+errReplay.Error(), 400)
+			return
 		}
-		// Log: Private proxy sayısını bildir
-		rep.Log(fmt.Sprintf("🔐 Private proxy mode active: %d proxies loaded", livePool.Count()))
-	} else if s.cfg.UsePublicProxy && s.proxyService != nil {
-		// Public proxy modu
-		livePool = s.proxyService.LivePool
+		distributedMode = false
+	}
+
+	rep := reporter.NewWithLocale(s.cfg.OutputDir, s.cfg.ExportFormat, s.cfg.TargetDomain, locale)
+	attachBigQuery(s.cfg, rep, "run")
+
+	// Cluster modu: hit planı worker'lara dağıtılır, sonuçlar aynı dashboard'a akar
+	if distributedMode {
+		s.startDistributed(w, rep)
+		return
+	}
+	livePool := s.livePoolFor(s.cfg, rep)
+	if replay != nil && livePool != nil {
+		s.mu.Unlock()
+		http.Error(w, "Replay proxy havuzu (public/private) modunda desteklenmiyor", 400)
+		return
 	}
 	
 	sim, err := simulator.New(s.cfg, s.agentLoader, rep, livePool)
@@ -1140,45 +1404,53 @@ func (s *Server) handleStart(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), 500)
 		return
 	}
+	s.applyKeywordClusters(sim)
 	s.sim = sim
+	s.clusterRep = nil
 	
 	// SECURITY FIX: Her hit için anlık server bildirimi - callback set et
-	rep.SetHitCallback(func(url string, duration time.Duration, success bool, proxy string) {
+	rep.SetHitCallback(func(url string, duration time.Duration, success bool, proxy string, errClass string) {
 		// Metrics collector'a kaydet
-		s.RecordHit(url, proxy, duration, success)
+		s.RecordHit(url, proxy, duration, success, errClass)
 		
 		// Anlık WebSocket broadcast - status güncellemesi
 		s.hub.Broadcast("status", s.buildStatusMap())
 	})
+	// Ziyaret zaman çizelgeleri: dashboard'daki son oturumlar paneli
+	rep.SetTimelineCallback(func(t reporter.SessionTimeline) {
+		s.hub.Broadcast("timeline", t)
+	})
 	
-	ctx, cancel := context.WithCancel(context.Background())
-	s.cancel = cancel
-	logChan := sim.Reporter().LogChan()
+	run := s.beginRun()
+	logChan, events := sim.Reporter().LogChan(), sim.Reporter().Events()
 	s.mu.Unlock()
 
-	go func() {
-		for msg := range logChan {
-			s.hub.Broadcast("log", msg)
+	run.Go("log-forwarder", func(ctx context.Context) {
+		s.forwardLogs(ctx, logChan, "")
+	})
+	run.Go("event-forwarder", func(ctx context.Context) {
+		s.forwardEvents(ctx, events, "")
+	})
+	run.Go("simulator", func(ctx context.Context) {
+		if replay != nil {
+			sim.Replay(ctx, replay)
+		} else {
+			sim.Run(ctx)
 		}
-	}()
-	go func() {
-		sim.Run(ctx)
-		s.mu.Lock()
-		s.cancel = nil
-		s.mu.Unlock()
-	}()
+		s.endRun(run)
+	})
 
-	// Telegram bildirim: simülasyon başladı
+	// Bildirim (Telegram/webhook): simülasyon başladı
 	if s.notifier != nil && s.notifier.IsEnabled() {
-		go func() {
+		run.Go("notifier", func(ctx context.Context) {
 			_ = s.notifier.SendSimulationStart(
 				s.cfg.TargetDomain,
 				s.cfg.DurationMinutes,
 				s.cfg.HitsPerMinute,
 				s.cfg.MaxConcurrentVisits,
 			)
-			// Periyodik rapor başlat
-			s.notifier.StartPeriodicReporting(func() notification.SimulationStats {
+			// Periyodik rapor döngüsü bu goroutine'de çalışır; run bitince izlenerek kapanır
+			s.notifier.RunPeriodicReporting(ctx, func() notification.SimulationStats {
 				s.mu.Lock()
 				var repM reporter.Metrics
 				if s.sim != nil {
@@ -1200,7 +1472,7 @@ func (s *Server) handleStart(w http.ResponseWriter, r *http.Request) {
 					ActiveProxies:  int(repM.TotalHits), // approximate
 				}
 			})
-		}()
+		})
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -1208,13 +1480,45 @@ func (s *Server) handleStart(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(map[string]string{"status": "started"})
 }
 
+// livePoolFor config'in proxy moduna göre çalıştırmanın proxy havuzunu döner (proxy yoksa nil)
+func (s *Server) livePoolFor(cfg *config.Config, rep *reporter.Reporter) *proxy.LivePool {
+	// Private proxy modu: kullanıcının kendi proxy'lerini LivePool'a ekle
+	if cfg.UsePrivateProxy && len(cfg.PrivateProxies) > 0 {
+		// Yeni LivePool oluştur ve private proxy'leri ekle
+		livePool := proxy.NewLivePool()
+		for _, pp := range cfg.PrivateProxies {
+			if pp.Host != "" && pp.Port > 0 {
+				protocol := pp.Protocol
+				if protocol == "" {
+					protocol = "http"
+				}
+				livePool.AddUnchecked(&proxy.ProxyConfig{
+					Host:     pp.Host,
+					Port:     pp.Port,
+					Username: pp.User,
+					Password: pp.Pass,
+					Protocol: protocol,
+				})
+			}
+		}
+		// Log: Private proxy sayısını bildir
+		rep.Log(fmt.Sprintf("🔐 Private proxy mode active: %d proxies loaded", livePool.Count()))
+		return livePool
+	}
+	if cfg.UsePublicProxy && s.proxyService != nil {
+		// Public proxy modu
+		return s.proxyService.LivePool
+	}
+	return nil
+}
+
 func (s *Server) handleStop(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", 405)
 		return
 	}
 
-	// Telegram bildirim: simülasyon durdu
+	// Bildirim (Telegram/webhook): simülasyon durdu
 	if s.notifier != nil && s.notifier.IsEnabled() {
 		s.notifier.StopPeriodicReporting()
 		go func() {
@@ -1235,10 +1539,7 @@ func (s *Server) handleStop(w http.ResponseWriter, r *http.Request) {
 	}
 
 	s.mu.Lock()
-	if s.cancel != nil {
-		s.cancel()
-		s.cancel = nil
-	}
+	s.stopRun()
 	s.mu.Unlock()
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]string{"status": "stopped"})
@@ -1249,9 +1550,15 @@ func (s *Server) buildStatusMap() map[string]interface{} {
 	s.mu.Lock()
 	running := s.cancel != nil
 	var repMetrics reporter.Metrics
-	if s.sim != nil {
+	if s.clusterRep != nil {
+		repMetrics = s.clusterRep.GetMetrics()
+	} else if s.sim != nil {
 		repMetrics = s.sim.Reporter().GetMetrics()
 	}
+	loadStage := ""
+	if s.sim != nil {
+		loadStage = chartStage(s.sim.Reporter())
+	}
 	ps := s.proxyService
 	s.mu.Unlock()
 
@@ -1260,6 +1567,8 @@ func (s *Server) buildStatusMap() map[string]interface{} {
 
 	out := map[string]interface{}{
 		"running":         running,
+		"kill_switch":     s.kill.engaged(),
+		"terms_accepted":  s.consent.Acknowledged(),
 		"total_hits":      metricsSnapshot.TotalHits,
 		"success_hits":    repMetrics.SuccessHits,
 		"failed_hits":     repMetrics.FailedHits,
@@ -1285,6 +1594,9 @@ func (s *Server) buildStatusMap() map[string]interface{} {
 		"success_rate":   metricsSnapshot.SuccessRate,
 		"active_proxies": metricsSnapshot.ActiveProxies,
 	}
+	if loadStage != "" {
+		out["load_stage"] = loadStage
+	}
 	if ps != nil {
 		st := ps.Status()
 		out["proxy_status"] = map[string]interface{}{
@@ -1297,6 +1609,9 @@ func (s *Server) buildStatusMap() map[string]interface{} {
 		}
 		out["proxy_live"] = ps.LivePool.SnapshotForAPI()
 	}
+	if cluster := s.clusterStatus(); cluster != nil {
+		out["cluster"] = cluster
+	}
 	return out
 }
 
@@ -1353,9 +1668,9 @@ func (s *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 
 func (s *Server) handleLogs(w http.ResponseWriter, r *http.Request) {
 	s.mu.Lock()
-	sim := s.sim
+	active := s.sim != nil || s.clusterRep != nil
 	s.mu.Unlock()
-	if sim == nil {
+	if !active {
 		http.Error(w, "Simülasyon çalışmıyor", 400)
 		return
 	}
@@ -1448,7 +1763,10 @@ func (s *Server) handleProxyFetch(w http.ResponseWriter, r *http.Request) {
 	if len(githubRepos) > 0 {
 		ctx, cancel := context.WithTimeout(r.Context(), 120*time.Second)
 		defer cancel()
-		added, err := ps.FetchFromGitHubNoCheck(ctx, githubRepos, nil)
+		added, err := ps.FetchFromGitHubNoCheck(ctx, githubRepos, proxy.GitHubFetchOptions{
+			Concurrency: cfg.GitHubFetchConcurrency,
+			Allowlist:   cfg.GitHubRepoAllowlist,
+		}, func(msg string) { s.hub.Broadcast("log", msg) })
 		w.Header().Set("Content-Type", "application/json")
 		if err != nil {
 			w.WriteHeader(http.StatusOK)
@@ -1459,6 +1777,7 @@ func (s *Server) handleProxyFetch(w http.ResponseWriter, r *http.Request) {
 		json.NewEncoder(w).Encode(map[string]interface{}{"status": "ok", "added": added})
 		return
 	}
+	ps.SetCheckCacheTTL(time.Duration(cfg.ProxyCheckCacheHours) * time.Hour)
 	ps.FetchAndCheckBackground(sources, checkerWorkers, nil)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -1487,14 +1806,14 @@ func (s *Server) handleProxyLive(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	s.mu.Lock()
-	ps := s.proxyService
+	pool, _ := s.activeLivePool()
 	s.mu.Unlock()
-	if ps == nil {
+	if pool == nil {
 		json.NewEncoder(w).Encode([]interface{}{})
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(ps.LivePool.SnapshotForAPI())
+	json.NewEncoder(w).Encode(pool.SnapshotForAPI())
 }
 
 func (s *Server) handleProxyExport(w http.ResponseWriter, r *http.Request) {
@@ -1521,412 +1840,429 @@ func (s *Server) handleProxyExport(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	w.Write(data)
 }
-func (s *Server) handleProxyTest(w http.ResponseWriter, r *http.Request) {
+// handleProxyAdd proxy'leri yeniden başlatmadan havuza ekler (POST /api/proxy/add).
+// Çalışma aktifse simulator'ın kullandığı havuza (public/private), değilse public havuza eklenir;
+// simulator her yeni slot'ta GetNext çağırdığı için eklenenler hemen kullanılır.
+// validate=true ise proxy'ler önce test edilir ve yalnızca çalışanlar eklenir.
+// İç ağ adresleri (localhost, özel IP) validate'ten bağımsız olarak geçersiz sayılır.
+func (s *Server) handleProxyAdd(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", 405)
 		return
 	}
-	
 	var body struct {
-		Host string `json:"host"`
-		Port int    `json:"port"`
-		User string `json:"user"`
-		Pass string `json:"pass"`
+		Proxies  []string `json:"proxies"` // "ip:port" veya "http://ip:port"
+		Text     string   `json:"text"`    // Satır satır liste (textarea)
+		Validate bool     `json:"validate"`
+		Workers  int      `json:"workers"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		http.Error(w, "Invalid JSON", 400)
 		return
 	}
-	
-	if body.Host == "" || body.Port == 0 {
-		http.Error(w, "Host and port required", 400)
-		return
-	}
+	lines := append(body.Proxies, strings.Split(body.Text, "\n")...)
 
-	// BUG FIX #17: SSRF önleme - internal IP'leri engelle
-	blockedPrefixes := []string{"127.", "10.", "172.16.", "172.17.", "172.18.", "172.19.",
-		"172.20.", "172.21.", "172.22.", "172.23.", "172.24.", "172.25.", "172.26.",
-		"172.27.", "172.28.", "172.29.", "172.30.", "172.31.", "192.168.", "0.", "169.254."}
-	blockedHosts := []string{"localhost", "::1", "0.0.0.0"}
-	hostLower := strings.ToLower(strings.TrimSpace(body.Host))
-	for _, blocked := range blockedHosts {
-		if hostLower == blocked {
-			http.Error(w, "Internal/loopback addresses are not allowed", 400)
-			return
+	var (
+		parsed  []*proxy.ProxyConfig
+		invalid int
+	)
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
 		}
-	}
-	for _, prefix := range blockedPrefixes {
-		if strings.HasPrefix(hostLower, prefix) {
-			http.Error(w, "Internal/private IP addresses are not allowed", 400)
-			return
+		pc, ok := proxy.ParseProxyLine(line)
+		if !ok || isInternalHost(pc.Host) {
+			invalid++
+			continue
 		}
+		parsed = append(parsed, pc)
+	}
+	if len(parsed) == 0 {
+		http.Error(w, "Geçerli proxy yok", 400)
+		return
 	}
 
-	// Proxy test - basit HTTP bağlantı testi
-	proxyURL := fmt.Sprintf("http://%s:%d", body.Host, body.Port)
-	if body.User != "" {
-		proxyURL = fmt.Sprintf("http://%s:%s@%s:%d", body.User, body.Pass, body.Host, body.Port)
+	s.mu.Lock()
+	pool, target := s.activeLivePool()
+	sim := s.sim
+	running := s.cancel != nil
+	ctx := context.Background()
+	if s.run != nil {
+		ctx = s.run.ctx // Stop test'i de iptal eder
 	}
-	
-	// Test için httpbin.org'a istek at
-	client := &http.Client{
-		Timeout: 10 * time.Second,
-		Transport: &http.Transport{
-			Proxy: func(req *http.Request) (*url.URL, error) {
-				return url.Parse(proxyURL)
-			},
-		},
-	}
-	
-	resp, err := client.Get("http://httpbin.org/ip")
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   err.Error(),
-		})
+	s.mu.Unlock()
+	if pool == nil {
+		http.Error(w, "Proxy havuzu yok", 500)
 		return
 	}
-	defer resp.Body.Close()
-	
+
+	added, dead := 0, 0
+	if body.Validate {
+		ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+		defer cancel()
+		stop := context.AfterFunc(r.Context(), cancel) // İstemci bağlantıyı keserse test'i bırak
+		defer stop()
+		liveChan := make(chan *proxy.LiveProxy, len(parsed))
+		go proxy.NewChecker(body.Workers).RunSlice(ctx, parsed, liveChan)
+		alive := 0
+		for live := range liveChan {
+			alive++
+			if pool.Add(live) {
+				added++
+			}
+		}
+		dead = len(parsed) - alive
+	} else {
+		for _, pc := range parsed {
+			if pool.AddUnchecked(pc) {
+				added++
+			}
+		}
+	}
+
+	if running && target == "run" && sim != nil {
+		sim.Reporter().LogT(i18n.MsgProxyHotAdd, added, invalid, dead, pool.Count())
+	}
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]interface{}{
-		"success":     resp.StatusCode == 200,
-		"status_code": resp.StatusCode,
+		"status":    "ok",
+		"target":    target,
+		"parsed":    len(parsed),
+		"added":     added,
+		"duplicate": len(parsed) - dead - added,
+		"invalid":   invalid,
+		"dead":      dead,
+		"pool_size": pool.Count(),
 	})
 }
 
-func (s *Server) handleGSCQueries(w http.ResponseWriter, r *http.Request) {
+// activeLivePool çalışma aktifse simulator'ın havuzunu ("run"), değilse public havuzu döner; s.mu tutulurken çağrılır
+func (s *Server) activeLivePool() (*proxy.LivePool, string) {
+	if s.sim != nil && s.cancel != nil && s.sim.LivePool() != nil {
+		return s.sim.LivePool(), "run"
+	}
+	if s.proxyService != nil {
+		return s.proxyService.LivePool, "public"
+	}
+	return nil, ""
+}
+
+// isInternalHost loopback/özel ağ adreslerini tespit eder (SSRF önleme)
+func isInternalHost(host string) bool {
+	blockedPrefixes := []string{"127.", "10.", "172.16.", "172.17.", "172.18.", "172.19.",
+		"172.20.", "172.21.", "172.22.", "172.23.", "172.24.", "172.25.", "172.26.",
+		"172.27.", "172.28.", "172.29.", "172.30.", "172.31.", "192.168.", "0.", "169.254."}
+	blockedHosts := []string{"localhost", "::1", "0.0.0.0"}
+	hostLower := strings.ToLower(strings.TrimSpace(host))
+	for _, blocked := range blockedHosts {
+		if hostLower == blocked {
+			return true
+		}
+	}
+	for _, prefix := range blockedPrefixes {
+		if strings.HasPrefix(hostLower, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) handleProxyTest(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", 405)
 		return
 	}
 	
 	var body struct {
-		PropertyURL string `json:"property_url"`
-		APIKey      string `json:"api_key"`
-		Days        int    `json:"days"`
+		Host string `json:"host"`
+		Port int    `json:"port"`
+		User string `json:"user"`
+		Pass string `json:"pass"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		http.Error(w, "Invalid JSON", 400)
 		return
 	}
 	
-	if body.PropertyURL == "" {
-		http.Error(w, "Property URL required", 400)
-		return
-	}
-	
-	// Property URL'yi normalize et
-	// Kullanıcı sadece domain girerse (örn: eros.sh), otomatik olarak sc-domain: formatına çevir
-	propertyURL := strings.TrimSpace(body.PropertyURL)
-	propertyURL = strings.TrimSuffix(propertyURL, "/")
-	
-	// Eğer http:// veya https:// ile başlamıyorsa ve sc-domain: değilse
-	if !strings.HasPrefix(propertyURL, "http://") &&
-	   !strings.HasPrefix(propertyURL, "https://") &&
-	   !strings.HasPrefix(propertyURL, "sc-domain:") {
-		// Domain property olarak ayarla (en yaygın format)
-		propertyURL = "sc-domain:" + propertyURL
-	}
-	
-	if body.APIKey == "" {
-		http.Error(w, "API Key (Service Account JSON) required", 400)
-		return
-	}
-	
-	// Service Account JSON'ı parse et
-	var serviceAccount struct {
-		Type                    string `json:"type"`
-		ProjectID               string `json:"project_id"`
-		PrivateKeyID            string `json:"private_key_id"`
-		PrivateKey              string `json:"private_key"`
-		ClientEmail             string `json:"client_email"`
-		ClientID                string `json:"client_id"`
-		AuthURI                 string `json:"auth_uri"`
-		TokenURI                string `json:"token_uri"`
-		AuthProviderX509CertURL string `json:"auth_provider_x509_cert_url"`
-		ClientX509CertURL       string `json:"client_x509_cert_url"`
-	}
-	
-	if err := json.Unmarshal([]byte(body.APIKey), &serviceAccount); err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Invalid Service Account JSON format: " + err.Error(),
-		})
+	if body.Host == "" || body.Port == 0 {
+		http.Error(w, "Host and port required", 400)
 		return
 	}
-	
-	if serviceAccount.Type != "service_account" {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Invalid credential type. Expected 'service_account', got '" + serviceAccount.Type + "'",
-		})
+
+	// BUG FIX #17: SSRF önleme - internal IP'leri engelle
+	if isInternalHost(body.Host) {
+		http.Error(w, "Internal/private IP addresses are not allowed", 400)
 		return
 	}
-	
-	if serviceAccount.PrivateKey == "" || serviceAccount.ClientEmail == "" {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Service Account JSON missing required fields (private_key or client_email)",
-		})
-		return
+
+	// Proxy test - basit HTTP bağlantı testi
+	proxyURL := fmt.Sprintf("http://%s:%d", body.Host, body.Port)
+	if body.User != "" {
+		proxyURL = fmt.Sprintf("http://%s:%s@%s:%d", body.User, body.Pass, body.Host, body.Port)
 	}
 	
-	// GSC API çağrısı yap
-	days := body.Days
-	if days <= 0 {
-		days = 28 // Varsayılan 28 gün
+	// Test için httpbin.org'a istek at
+	client := &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			Proxy: func(req *http.Request) (*url.URL, error) {
+				return url.Parse(proxyURL)
+			},
+		},
 	}
 	
-	queries, err := fetchGSCQueries(propertyURL, serviceAccount.ClientEmail, serviceAccount.PrivateKey, days)
+	resp, err := client.Get("http://httpbin.org/ip")
 	if err != nil {
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]interface{}{
 			"success": false,
-			"error":   "GSC API error: " + err.Error(),
+			"error":   err.Error(),
 		})
 		return
 	}
+	defer resp.Body.Close()
 	
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]interface{}{
-		"success": true,
-		"queries": queries,
+		"success":     resp.StatusCode == 200,
+		"status_code": resp.StatusCode,
 	})
 }
 
-// fetchGSCQueries Google Search Console API'den sorguları çeker
-func fetchGSCQueries(propertyURL, clientEmail, privateKey string, days int) ([]map[string]interface{}, error) {
-	// JWT token oluştur
-	token, err := createGSCJWT(clientEmail, privateKey)
-	if err != nil {
-		return nil, fmt.Errorf("JWT oluşturma hatası: %w", err)
+// handleAnalyticsPreflight hedef ana sayfayı indirip analytics etiketlerini (GA4, GTM, Matomo) tespit eder
+// ve yapılandırılan GtagID ile karşılaştırır. domain/gtag_id query parametreleri kayıtlı config'i geçersiz kılar;
+// query'den gelen domain iç ağ adresiyse (localhost, özel IP) istek yapılmaz.
+func (s *Server) handleAnalyticsPreflight(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", 405)
+		return
 	}
-	
-	// Access token al
-	accessToken, err := exchangeJWTForAccessToken(token)
-	if err != nil {
-		return nil, fmt.Errorf("Access token alma hatası: %w", err)
+	s.mu.Lock()
+	domain := s.cfg.TargetDomain
+	gtagID := s.cfg.GtagID
+	s.mu.Unlock()
+	if q := strings.TrimSpace(r.URL.Query().Get("domain")); q != "" {
+		if host := preflightHost(q); host == "" || isInternalHost(host) {
+			http.Error(w, "Geçersiz veya iç ağ domain'i", 400)
+			return
+		}
+		domain = q
 	}
-	
-	// GSC API'ye istek at
-	endDate := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
-	startDate := time.Now().AddDate(0, 0, -days).Format("2006-01-02")
-	
-	requestBody := map[string]interface{}{
-		"startDate":  startDate,
-		"endDate":    endDate,
-		"dimensions": []string{"query"},
-		"rowLimit":   100,
+	if q, ok := r.URL.Query()["gtag_id"]; ok {
+		gtagID = strings.TrimSpace(q[0])
 	}
-	
-	jsonBody, _ := json.Marshal(requestBody)
-	
-	// Property URL'yi encode et
-	encodedProperty := url.QueryEscape(propertyURL)
-	apiURL := fmt.Sprintf("https://www.googleapis.com/webmasters/v3/sites/%s/searchAnalytics/query", encodedProperty)
-	
-	req, err := http.NewRequest("POST", apiURL, strings.NewReader(string(jsonBody)))
+	if domain == "" {
+		http.Error(w, "Hedef domain yok", 400)
+		return
+	}
+	pageURL := domain
+	if !strings.HasPrefix(pageURL, "http") {
+		pageURL = "https://" + strings.TrimPrefix(pageURL, "//")
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	det, err := analytics.Preflight(r.Context(), pageURL, gtagID)
 	if err != nil {
-		return nil, err
+		json.NewEncoder(w).Encode(map[string]interface{}{"status": "error", "error": err.Error()})
+		return
 	}
-	
-	req.Header.Set("Authorization", "Bearer "+accessToken)
-	req.Header.Set("Content-Type", "application/json")
-	
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(req)
+	json.NewEncoder(w).Encode(map[string]interface{}{"status": "ok", "detection": det})
+}
+
+// preflightHost domain query değerinin host kısmı (şema ve port olmadan); ayrıştırılamazsa boş
+func preflightHost(domain string) string {
+	raw := domain
+	if !strings.HasPrefix(raw, "http") {
+		raw = "https://" + strings.TrimPrefix(raw, "//")
+	}
+	u, err := url.Parse(raw)
 	if err != nil {
-		return nil, err
+		return ""
 	}
-	defer resp.Body.Close()
-	
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("GSC API hatası (%d): %s", resp.StatusCode, string(bodyBytes))
+	return strings.TrimSuffix(u.Hostname(), ".")
+}
+
+// handleTimelines son ziyaretlerin zaman çizelgelerini döner (en yeni önce); limit query ile kısaltılır
+func (s *Server) handleTimelines(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", 405)
+		return
 	}
-	
-	var gscResponse struct {
-		Rows []struct {
-			Keys        []string `json:"keys"`
-			Clicks      float64  `json:"clicks"`
-			Impressions float64  `json:"impressions"`
-			CTR         float64  `json:"ctr"`
-			Position    float64  `json:"position"`
-		} `json:"rows"`
+	s.mu.Lock()
+	var rep *reporter.Reporter
+	if s.sim != nil {
+		rep = s.sim.Reporter()
 	}
-	
-	if err := json.NewDecoder(resp.Body).Decode(&gscResponse); err != nil {
-		return nil, fmt.Errorf("GSC yanıt parse hatası: %w", err)
+	s.mu.Unlock()
+
+	timelines := []reporter.SessionTimeline{}
+	if rep != nil {
+		timelines = rep.Timelines()
 	}
-	
-	queries := make([]map[string]interface{}, 0, len(gscResponse.Rows))
-	for _, row := range gscResponse.Rows {
-		if len(row.Keys) > 0 {
-			queries = append(queries, map[string]interface{}{
-				"query":       row.Keys[0],
-				"clicks":      int(row.Clicks),
-				"impressions": int(row.Impressions),
-				"ctr":         row.CTR,
-				"position":    row.Position,
-			})
-		}
+	if n, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && n >= 0 && n < len(timelines) {
+		timelines = timelines[:n]
 	}
-	
-	return queries, nil
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"timelines": timelines})
 }
 
-// createGSCJWT Service Account için JWT oluşturur
-func createGSCJWT(clientEmail, privateKey string) (string, error) {
-	// JWT Header
-	header := map[string]string{
-		"alg": "RS256",
-		"typ": "JWT",
+// handleStructuredLogs son ziyaretlerin yapılandırılmış olaylarını döner (en yeni önce).
+// session ile oturuma, level ile en düşük seviyeye (info, warn, error) göre filtrelenir; limit varsayılan 200.
+func (s *Server) handleStructuredLogs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", 405)
+		return
 	}
-	headerJSON, _ := json.Marshal(header)
-	headerB64 := base64URLEncode(headerJSON)
-	
-	// JWT Claims
-	now := time.Now().Unix()
-	claims := map[string]interface{}{
-		"iss":   clientEmail,
-		"scope": "https://www.googleapis.com/auth/webmasters.readonly",
-		"aud":   "https://oauth2.googleapis.com/token",
-		"iat":   now,
-		"exp":   now + 3600,
-	}
-	claimsJSON, _ := json.Marshal(claims)
-	claimsB64 := base64URLEncode(claimsJSON)
-	
-	// Signature
-	signatureInput := headerB64 + "." + claimsB64
-	signature, err := signRS256(signatureInput, privateKey)
-	if err != nil {
-		return "", err
+	q := r.URL.Query()
+	f := reporter.LogFilter{SessionID: q.Get("session"), Level: q.Get("level"), Limit: 200}
+	if !reporter.ValidLogLevel(f.Level) {
+		http.Error(w, "level info, warn veya error olmalı", http.StatusBadRequest)
+		return
 	}
-	
-	return signatureInput + "." + signature, nil
+	if n, err := strconv.Atoi(q.Get("limit")); err == nil && n >= 0 {
+		f.Limit = n
+	}
+	s.mu.Lock()
+	var rep *reporter.Reporter
+	if s.sim != nil {
+		rep = s.sim.Reporter()
+	}
+	s.mu.Unlock()
+
+	events := []reporter.LogEvent{}
+	if rep != nil {
+		events = rep.LogEvents(f)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"events": events})
 }
 
-// exchangeJWTForAccessToken JWT'yi access token ile değiştirir
-func exchangeJWTForAccessToken(jwt string) (string, error) {
-	data := url.Values{}
-	data.Set("grant_type", "urn:ietf:params:oauth:grant-type:jwt-bearer")
-	data.Set("assertion", jwt)
-	
-	resp, err := http.PostForm("https://oauth2.googleapis.com/token", data)
-	if err != nil {
-		return "", err
+// handleTestVisit mevcut config ile tek bir tam enstrümanlı ziyaret yapar ve izini döner
+// (referrer, kullanılan analytics yöntemi, gönderilen event'ler, kalite skoru).
+func (s *Server) handleTestVisit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", 405)
+		return
 	}
-	defer resp.Body.Close()
-	
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("token exchange hatası (%d): %s", resp.StatusCode, string(bodyBytes))
+	if s.rejectIfKilled(w) {
+		return
 	}
-	
-	var tokenResponse struct {
-		AccessToken string `json:"access_token"`
-		ExpiresIn   int    `json:"expires_in"`
-		TokenType   string `json:"token_type"`
+	s.mu.Lock()
+	if s.testVisiting {
+		s.mu.Unlock()
+		http.Error(w, "Test ziyareti zaten çalışıyor", http.StatusConflict)
+		return
 	}
-	
-	if err := json.NewDecoder(resp.Body).Decode(&tokenResponse); err != nil {
-		return "", err
+	s.testVisiting = true
+	cfg := *s.cfg
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.testVisiting = false
+		s.mu.Unlock()
+	}()
+	if cfg.TargetDomain == "" {
+		http.Error(w, "Hedef domain yok", 400)
+		return
+	}
+	if s.rejectUnconfirmedTarget(w, r, cfg.TargetDomain) {
+		return
 	}
-	
-	return tokenResponse.AccessToken, nil
-}
 
-// base64URLEncode base64 URL encoding
-func base64URLEncode(data []byte) string {
-	encoded := encodeBase64(data)
-	// URL-safe: + -> -, / -> _, padding kaldır
-	encoded = strings.ReplaceAll(encoded, "+", "-")
-	encoded = strings.ReplaceAll(encoded, "/", "_")
-	encoded = strings.TrimRight(encoded, "=")
-	return encoded
+	ctx, cancel := context.WithTimeout(r.Context(), 2*time.Minute)
+	defer cancel()
+	w.Header().Set("Content-Type", "application/json")
+	trace, err := simulator.TestVisit(ctx, &cfg, s.agentLoader)
+	if err != nil {
+		json.NewEncoder(w).Encode(map[string]interface{}{"status": "error", "error": err.Error()})
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{"status": "ok", "trace": trace})
 }
 
-// encodeBase64 standart base64 encoding
-func encodeBase64(data []byte) string {
-	const base64Chars = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
-	result := make([]byte, ((len(data)+2)/3)*4)
-	
-	for i, j := 0, 0; i < len(data); i, j = i+3, j+4 {
-		var n uint32
-		remaining := len(data) - i
-		
-		n = uint32(data[i]) << 16
-		if remaining > 1 {
-			n |= uint32(data[i+1]) << 8
-		}
-		if remaining > 2 {
-			n |= uint32(data[i+2])
-		}
-		
-		result[j] = base64Chars[(n>>18)&0x3F]
-		result[j+1] = base64Chars[(n>>12)&0x3F]
-		
-		if remaining > 1 {
-			result[j+2] = base64Chars[(n>>6)&0x3F]
-		} else {
-			result[j+2] = '='
-		}
-		
-		if remaining > 2 {
-			result[j+3] = base64Chars[n&0x3F]
-		} else {
-			result[j+3] = '='
-		}
+// handleGSCQueries Search Console sorgu verisini döner. Gövde: property_url, api_key (Service Account JSON),
+// days veya start_date/end_date, dimensions (query, page, country, device, date), row_limit, refresh.
+// Sonuçlar gsc_cache_dir'de property ve tarih aralığına göre gsc_cache_hours boyunca önbellekte tutulur.
+func (s *Server) handleGSCQueries(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", 405)
+		return
 	}
 	
-	return string(result)
-}
-
-// signRS256 RS256 imzalama
-func signRS256(input, privateKeyPEM string) (string, error) {
-	// PEM formatındaki private key'i parse et
-	block, _ := pem.Decode([]byte(privateKeyPEM))
-	if block == nil {
-		return "", fmt.Errorf("PEM block bulunamadı")
+	var body struct {
+		PropertyURL string `json:"property_url"`
+		APIKey      string `json:"api_key"`
+		Days        int    `json:"days"`
+		StartDate   string   `json:"start_date"`
+		EndDate     string   `json:"end_date"`
+		Dimensions  []string `json:"dimensions"`
+		RowLimit    int      `json:"row_limit"`
+		Refresh     bool     `json:"refresh"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid JSON", 400)
+		return
 	}
 	
-	var privateKey interface{}
-	var err error
+	if body.PropertyURL == "" {
+		http.Error(w, "Property URL required", 400)
+		return
+	}
+	if body.APIKey == "" {
+		http.Error(w, "API Key (Service Account JSON) required", 400)
+		return
+	}
 	
-	// PKCS#8 veya PKCS#1 formatını dene
-	privateKey, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	w.Header().Set("Content-Type", "application/json")
+	serviceAccount, err := googleauth.ParseServiceAccount([]byte(body.APIKey))
 	if err != nil {
-		privateKey, err = x509.ParsePKCS1PrivateKey(block.Bytes)
-		if err != nil {
-			return "", fmt.Errorf("private key parse hatası: %w", err)
-		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
 	}
 	
-	rsaKey, ok := privateKey.(*rsa.PrivateKey)
-	if !ok {
-		return "", fmt.Errorf("RSA private key değil")
+	q := gsc.Query{StartDate: body.StartDate, EndDate: body.EndDate, Dimensions: body.Dimensions,
+		RowLimit: body.RowLimit, Refresh: body.Refresh}
+	if q.StartDate == "" || q.EndDate == "" {
+		q.StartDate, q.EndDate = gsc.LastDays(body.Days, time.Now()) // Varsayılan 28 gün
 	}
-	
-	// SHA256 hash
-	h := sha256.New()
-	h.Write([]byte(input))
-	hashed := h.Sum(nil)
-	
-	// RSA imzala
-	signature, err := rsa.SignPKCS1v15(nil, rsaKey, crypto.SHA256, hashed)
+	property := gsc.NormalizeProperty(body.PropertyURL)
+	rows, cached, err := s.gscClient(serviceAccount).SearchAnalytics(r.Context(), property, q)
 	if err != nil {
-		return "", fmt.Errorf("imzalama hatası: %w", err)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "GSC API error: " + err.Error(),
+		})
+		return
 	}
 	
-	// Base64 URL encode
-	return strings.TrimRight(strings.ReplaceAll(strings.ReplaceAll(
-		encodeBase64(signature), "+", "-"), "/", "_"), "="), nil
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"property":   property,
+		"start_date": q.StartDate,
+		"end_date":   q.EndDate,
+		"cached":     cached,
+		"queries":    rows,
+	})
+}
+
+// gscClient Service Account'a ait istemciyi döner; access token istekler arasında önbellekte kalır
+func (s *Server) gscClient(sa *googleauth.ServiceAccount) *gsc.Client {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Önbellek ayarı değişince istemci yeniden oluşturulur
+	key := fmt.Sprintf("%s|%s|%s|%d", sa.ClientEmail, sa.PrivateKey, s.cfg.GscCacheDir, s.cfg.GscCacheHours)
+	if c := s.gscClients[key]; c != nil {
+		return c
+	}
+	if s.gscClients == nil {
+		s.gscClients = map[string]*gsc.Client{}
+	}
+	c := gsc.NewClient(sa, gsc.Config{CacheDir: s.cfg.GscCacheDir, CacheTTL: time.Duration(s.cfg.GscCacheHours) * time.Hour})
+	s.gscClients[key] = c
+	return c
 }
 
 func escapeSSE(s string) string {
@@ -1942,7 +2278,7 @@ func (s *Server) handleTelegramTest(w http.ResponseWriter, r *http.Request) {
 	}
 	w.Header().Set("Content-Type", "application/json")
 
-	if s.notifier == nil {
+	if s.telegram == nil {
 		json.NewEncoder(w).Encode(map[string]interface{}{
 			"success": false,
 			"error":   "Notifier başlatılmadı",
@@ -1950,7 +2286,7 @@ func (s *Server) handleTelegramTest(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := s.notifier.TestConnection()
+	err := s.telegram.TestConnection()
 	if err != nil {
 		json.NewEncoder(w).Encode(map[string]interface{}{
 			"success": false,
@@ -2005,8 +2341,8 @@ func (s *Server) handleTelegramConfig(w http.ResponseWriter, r *http.Request) {
 		s.mu.Unlock()
 
 		// Notifier'ı güncelle
-		if s.notifier != nil {
-			s.notifier.UpdateConfig(notification.TelegramConfig{
+		if s.telegram != nil {
+			s.telegram.UpdateConfig(notification.TelegramConfig{
 				BotToken:       body.BotToken,
 				ChatID:         body.ChatID,
 				Enabled:        body.Enabled,
@@ -2024,143 +2360,151 @@ func (s *Server) handleTelegramConfig(w http.ResponseWriter, r *http.Request) {
 	http.Error(w, "Method not allowed", 405)
 }
 
-// handleSchedulerJobs Scheduler işlerini yönetir
-func (s *Server) handleSchedulerJobs(w http.ResponseWriter, r *http.Request) {
+// handleSERPReport SERP raporlarını döndürür
+func (s *Server) handleSERPReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", 405)
+		return
+	}
 	w.Header().Set("Content-Type", "application/json")
 
-	switch r.Method {
-	case http.MethodGet:
-		// İş listesini döndür
-		s.mu.Lock()
-		jobsFile := s.cfg.SchedulerJobsFile
-		s.mu.Unlock()
-
-		data, err := os.ReadFile(jobsFile)
-		if err != nil {
-			json.NewEncoder(w).Encode(map[string]interface{}{
-				"jobs": []interface{}{},
-			})
-			return
-		}
-
-		var jobs []interface{}
-		if err := json.Unmarshal(data, &jobs); err != nil {
-			json.NewEncoder(w).Encode(map[string]interface{}{
-				"jobs": []interface{}{},
-			})
-			return
-		}
-
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"jobs": jobs,
-		})
-
-	case http.MethodPost:
-		// Yeni iş ekle
-		var body json.RawMessage
-		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-			http.Error(w, "Invalid JSON", 400)
-			return
-		}
-
-		s.mu.Lock()
-		jobsFile := s.cfg.SchedulerJobsFile
-		s.mu.Unlock()
-
-		// Mevcut işleri oku
-		var jobs []json.RawMessage
-		data, err := os.ReadFile(jobsFile)
-		if err == nil {
-			_ = json.Unmarshal(data, &jobs)
-		}
-
-		jobs = append(jobs, body)
-
-		// Kaydet
-		out, _ := json.MarshalIndent(jobs, "", "  ")
-		if err := os.WriteFile(jobsFile, out, 0644); err != nil {
-			http.Error(w, "İş kaydedilemedi: "+err.Error(), 500)
-			return
-		}
-
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": true,
-			"message": "İş eklendi",
-		})
-
-	case http.MethodDelete:
-		// İş sil - query param ile id
-		jobID := r.URL.Query().Get("id")
-		if jobID == "" {
-			http.Error(w, "id parametresi gerekli", 400)
-			return
-		}
-
-		s.mu.Lock()
-		jobsFile := s.cfg.SchedulerJobsFile
-		s.mu.Unlock()
-
-		data, err := os.ReadFile(jobsFile)
-		if err != nil {
-			http.Error(w, "İş dosyası okunamadı", 500)
-			return
-		}
-
-		var jobs []map[string]interface{}
-		if err := json.Unmarshal(data, &jobs); err != nil {
-			http.Error(w, "İş dosyası parse hatası", 500)
-			return
-		}
+	s.mu.Lock()
+	reportDir := s.cfg.SerpReportDir
+	s.mu.Unlock()
 
-		// ID'ye göre filtrele
-		var filtered []map[string]interface{}
-		for _, j := range jobs {
-			if id, ok := j["id"].(string); ok && id != jobID {
-				filtered = append(filtered, j)
+	// Rapor dosyalarını listele
+	var reports []map[string]interface{}
+	entries, err := os.ReadDir(reportDir)
+	if err == nil {
+		for _, entry := range entries {
+			if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
+				info, _ := entry.Info()
+				reports = append(reports, map[string]interface{}{
+					"name":     entry.Name(),
+					"size":     info.Size(),
+					"modified": info.ModTime().Format(time.RFC3339),
+				})
 			}
 		}
+	}
 
-		out, _ := json.MarshalIndent(filtered, "", "  ")
-		_ = os.WriteFile(jobsFile, out, 0644)
-
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": true,
-			"message": "İş silindi",
-		})
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"reports": reports,
+	})
+}
 
-	default:
+// handleRuns GET /api/runs?limit=50: rapor dizinindeki geçmiş çalıştırmalar (en yenisi önce); her dosya için
+// /api/runs/file linki döner
+func (s *Server) handleRuns(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
 		http.Error(w, "Method not allowed", 405)
+		return
 	}
+	limit := 50
+	if v, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && v > 0 {
+		limit = min(v, 1000)
+	}
+	s.mu.Lock()
+	outputDir := s.cfg.OutputDir
+	s.mu.Unlock()
+
+	runs, err := reporter.ListRuns(outputDir)
+	if err != nil {
+		http.Error(w, "Raporlar okunamadı: "+err.Error(), 500)
+		return
+	}
+	total := len(runs)
+	if len(runs) > limit {
+		runs = runs[:limit]
+	}
+	type runItem struct {
+		reporter.RunSummary
+		Reports map[string]string `json:"reports"` // Uzantı → indirme linki
+	}
+	items := make([]runItem, 0, len(runs))
+	for _, run := range runs {
+		links := map[string]string{}
+		for _, name := range run.Files {
+			links[strings.TrimPrefix(filepath.Ext(name), ".")] = "/api/runs/file?name=" + url.QueryEscape(name)
+		}
+		items = append(items, runItem{RunSummary: run, Reports: links})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"runs": items, "total": total})
 }
 
-// handleSchedulerStart Scheduler'ı başlatır
-func (s *Server) handleSchedulerStart(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
+// handleRunFile GET /api/runs/file?name=: rapor dizinindeki çalıştırma raporunu döner (HTML tarayıcıda açılır,
+// CSV/JSON indirilir). Yalnızca vgbot rapor dosyaları sunulur.
+func (s *Server) handleRunFile(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
 		http.Error(w, "Method not allowed", 405)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"success": true,
-		"message": "Scheduler başlatıldı",
-	})
+	name := r.URL.Query().Get("name")
+	if name != filepath.Base(name) || !reporter.IsRunReport(name) {
+		http.Error(w, "Geçersiz rapor adı", 400)
+		return
+	}
+	s.mu.Lock()
+	path := filepath.Join(s.cfg.OutputDir, name)
+	s.mu.Unlock()
+	if _, err := os.Stat(path); err != nil {
+		http.Error(w, "Rapor bulunamadı", 404)
+		return
+	}
+	if strings.HasSuffix(name, ".html") {
+		// Rapor panelde satır içi açılır; sandbox onu panel origin'inden ayırır (grafikler için script açık)
+		w.Header().Set("Content-Security-Policy", "sandbox allow-scripts")
+	} else {
+		w.Header().Set("Content-Disposition", "attachment; filename="+name)
+	}
+	http.ServeFile(w, r, path)
 }
 
-// handleSchedulerStop Scheduler'ı durdurur
-func (s *Server) handleSchedulerStop(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
+// handleReportDownload son (veya süren) çalıştırmanın hit kayıtlarını dosya olarak akıtır.
+// GET /api/reports/download?format=csv|xlsx
+func (s *Server) handleReportDownload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
 		http.Error(w, "Method not allowed", 405)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"success": true,
-		"message": "Scheduler durduruldu",
-	})
+	format := strings.ToLower(r.URL.Query().Get("format"))
+	if format == "" {
+		format = "csv"
+	}
+	if format != "csv" && format != "xlsx" {
+		http.Error(w, "format csv veya xlsx olmalı", 400)
+		return
+	}
+	s.mu.Lock()
+	var rep *reporter.Reporter
+	if s.sim != nil {
+		rep = s.sim.Reporter()
+	}
+	s.mu.Unlock()
+	if rep == nil {
+		http.Error(w, "Henüz rapor yok", 404)
+		return
+	}
+
+	name := fmt.Sprintf("vgbot_hits_%s.%s", time.Now().Format("20060102_150405"), format)
+	w.Header().Set("Content-Disposition", "attachment; filename="+name)
+	var err error
+	if format == "xlsx" {
+		w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+		err = rep.WriteHitsXLSX(w)
+	} else {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		err = rep.WriteHitsCSV(w)
+	}
+	if err != nil {
+		// Gövde yazılmaya başlandı; yalnızca logla
+		log.Printf("[WARN] Rapor indirilemedi: %v", err)
+	}
 }
 
-// handleSERPReport SERP raporlarını döndürür
-func (s *Server) handleSERPReport(w http.ResponseWriter, r *http.Request) {
+// handleReplayReports replay için kullanılabilecek hit raporlarını listeler (JSON rapor + CSV hit logu)
+func (s *Server) handleReplayReports(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		http.Error(w, "Method not allowed", 405)
 		return
@@ -2168,24 +2512,33 @@ func (s *Server) handleSERPReport(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
 	s.mu.Lock()
-	reportDir := s.cfg.SerpReportDir
+	outputDir := s.cfg.OutputDir
 	s.mu.Unlock()
 
-	// Rapor dosyalarını listele
-	var reports []map[string]interface{}
-	entries, err := os.ReadDir(reportDir)
+	reports := []map[string]interface{}{}
+	entries, err := os.ReadDir(outputDir)
 	if err == nil {
 		for _, entry := range entries {
-			if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
-				info, _ := entry.Info()
-				reports = append(reports, map[string]interface{}{
-					"name":     entry.Name(),
-					"size":     info.Size(),
-					"modified": info.ModTime().Format(time.RFC3339),
-				})
+			name := entry.Name()
+			if entry.IsDir() || !(strings.HasPrefix(name, "vgbot_report_") && strings.HasSuffix(name, ".json") ||
+				strings.HasPrefix(name, "vgbot_hits_") && strings.HasSuffix(name, ".csv")) {
+				continue
+			}
+			info, errInfo := entry.Info()
+			if errInfo != nil {
+				continue
 			}
+			reports = append(reports, map[string]interface{}{
+				"name":     name,
+				"size":     info.Size(),
+				"modified": info.ModTime().Format(time.RFC3339),
+			})
 		}
 	}
+	// En yeni rapor en üstte
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i]["modified"].(string) > reports[j]["modified"].(string)
+	})
 
 	json.NewEncoder(w).Encode(map[string]interface{}{
 		"reports": reports,
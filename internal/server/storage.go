@@ -0,0 +1,184 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"vgbot/internal/browser"
+	"vgbot/internal/config"
+	"vgbot/pkg/storage"
+)
+
+// storageCategory saklama politikasının uygulandığı dizin
+type storageCategory struct {
+	name     string
+	dir      string
+	patterns []string // Aracın bu dizine yazdığı girdiler; diğer dosyalara dokunulmaz
+	live     bool     // Çalışan simülasyon kullanıyor olabilir (çalışırken temizlenmez)
+}
+
+// target kategorinin temizlik hedefi; config dizini (config.json, profiller) korunur
+func (c storageCategory) target() storage.Target {
+	return storage.Target{Category: c.name, Dir: c.dir, Patterns: c.patterns, Protected: []string{configDir()}}
+}
+
+// storageCleanup bir temizlik turunun özeti
+type storageCleanup struct {
+	At      time.Time        `json:"at"`
+	Manual  bool             `json:"manual"`
+	Results []storage.Result `json:"results"`
+	Skipped []string         `json:"skipped,omitempty"` // Aktif çalıştırma nedeniyle atlanan kategoriler
+}
+
+// storageCategories config'teki saklama kategorileri. Loglar stdout'a yazıldığından ayrı bir log dizini yoktur.
+func storageCategories(cfg *config.Config) []storageCategory {
+	return []storageCategory{
+		// vgbot_report_*/vgbot_hits_*/vgbot_sessions_* ve oturum logu dizini; runs.jsonl dizini korunur
+		{name: "reports", dir: cfg.OutputDir, patterns: []string{"vgbot_*", "sessions"}},
+		{name: "serp_reports", dir: cfg.SerpReportDir, patterns: []string{"serp_report_*.json"}},
+		// HTTP önbellek profilleri ve istemci ID'si adlı (sayı.sayı) profil dosyaları
+		{name: "browser_profiles", dir: cfg.BrowserProfilePath, patterns: []string{browser.HTTPCacheDirName, "[0-9]*.json"}, live: true},
+		{name: "sessions", dir: cfg.SessionStoragePath, patterns: []string{"sess_*.json", "sess_*.enc"}, live: true},
+	}
+}
+
+// retentionPolicy config'ten saklama politikası
+func retentionPolicy(cfg *config.Config) storage.Policy {
+	return storage.Policy{
+		MaxAge:   time.Duration(cfg.RetentionDays) * 24 * time.Hour,
+		MaxBytes: int64(cfg.RetentionMaxMB) << 20,
+	}
+}
+
+// busy ana simülasyon, cluster veya herhangi bir kampanya çalışıyor mu
+func (s *Server) busy() bool {
+	s.mu.Lock()
+	running := s.run != nil || s.clusterRep != nil
+	s.mu.Unlock()
+	if running {
+		return true
+	}
+	for _, c := range s.campaigns.list() {
+		if c.Status == campaignRunning {
+			return true
+		}
+	}
+	return false
+}
+
+// cleanupStorage politikaya göre temizlik yapar; category boş değilse yalnızca o kategori.
+// Çalışma sırasında tarayıcı profilleri ve oturumlar atlanır.
+func (s *Server) cleanupStorage(category string, manual bool) (*storageCleanup, error) {
+	s.mu.Lock()
+	cfg := s.cfg
+	s.mu.Unlock()
+
+	policy := retentionPolicy(cfg)
+	busy := s.busy()
+	out := &storageCleanup{At: time.Now(), Manual: manual, Results: []storage.Result{}}
+	found := category == ""
+	for _, c := range storageCategories(cfg) {
+		if category != "" && c.name != category {
+			continue
+		}
+		found = true
+		if c.live && busy {
+			out.Skipped = append(out.Skipped, c.name)
+			continue
+		}
+		out.Results = append(out.Results, storage.Cleanup(c.target(), policy, out.At))
+	}
+	if !found {
+		return nil, fmt.Errorf("Bilinmeyen kategori: %s", category)
+	}
+
+	s.mu.Lock()
+	s.lastCleanup = out
+	s.mu.Unlock()
+	return out, nil
+}
+
+// retentionLoop saklama politikasını periyodik olarak uygular (politika kapalıysa bir şey silmez)
+func (s *Server) retentionLoop() {
+	s.mu.Lock()
+	interval := time.Duration(s.cfg.RetentionIntervalMin) * time.Minute
+	s.mu.Unlock()
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.mu.Lock()
+			enabled := retentionPolicy(s.cfg).Enabled()
+			s.mu.Unlock()
+			if !enabled {
+				continue
+			}
+			res, err := s.cleanupStorage("", false)
+			if err != nil {
+				continue
+			}
+			var removed int
+			var freed int64
+			for _, r := range res.Results {
+				removed += r.Removed
+				freed += r.Freed
+			}
+			if removed > 0 {
+				s.hub.Broadcast("log", fmt.Sprintf("🧹 Saklama temizliği: %d girdi silindi, %.1f MB boşaltıldı", removed, float64(freed)/(1<<20)))
+			}
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// handleStorage GET: kategori başına disk kullanımı, politika ve son temizlik.
+// POST: temizliği hemen çalıştırır (?category= ile tek kategori).
+func (s *Server) handleStorage(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.mu.Lock()
+		cfg := s.cfg
+		last := s.lastCleanup
+		s.mu.Unlock()
+		usage := make([]storage.Usage, 0, 4)
+		var total int64
+		for _, c := range storageCategories(cfg) {
+			u, err := storage.Measure(c.target())
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			usage = append(usage, u)
+			total += u.Bytes
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"categories":  usage,
+			"total_bytes": total,
+			"policy": map[string]interface{}{
+				"retention_days":         cfg.RetentionDays,
+				"retention_max_mb":       cfg.RetentionMaxMB,
+				"retention_interval_min": cfg.RetentionIntervalMin,
+				"enabled":                retentionPolicy(cfg).Enabled(),
+			},
+			"last_cleanup": last,
+		})
+	case http.MethodPost:
+		res, err := s.cleanupStorage(r.URL.Query().Get("category"), true)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(res)
+	default:
+		http.Error(w, "Method not allowed", 405)
+	}
+}
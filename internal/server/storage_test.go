@@ -0,0 +1,75 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStorageCleanupSkipsLiveDirsWhileRunning(t *testing.T) {
+	cfg := testConfig()
+	root := t.TempDir()
+	cfg.OutputDir = filepath.Join(root, "reports")
+	cfg.SerpReportDir = filepath.Join(root, "serp")
+	cfg.BrowserProfilePath = filepath.Join(root, "profiles")
+	cfg.SessionStoragePath = filepath.Join(root, "sessions")
+	cfg.RetentionDays = 7
+
+	old := time.Now().Add(-30 * 24 * time.Hour)
+	for _, p := range []string{
+		filepath.Join(cfg.OutputDir, "vgbot_report_old.json"),
+		filepath.Join(cfg.OutputDir, "notes.txt"), // Araca ait olmayan dosyaya dokunulmaz
+		filepath.Join(cfg.BrowserProfilePath, "http_cache", "0a1b2c", "Cookies"),
+	} {
+		os.MkdirAll(filepath.Dir(p), 0755)
+		os.WriteFile(p, []byte("data"), 0644)
+		os.Chtimes(p, old, old)
+	}
+	os.WriteFile(filepath.Join(cfg.OutputDir, "vgbot_report_new.json"), []byte("data"), 0644)
+
+	s := &Server{cfg: &cfg, campaigns: newCampaignManager(), run: newRunScope()}
+	rec := httptest.NewRecorder()
+	s.handleStorage(rec, httptest.NewRequest(http.MethodPost, "/api/storage", nil))
+	if rec.Code != 200 {
+		t.Fatalf("cleanup = %d %s", rec.Code, rec.Body)
+	}
+	var res storageCleanup
+	json.Unmarshal(rec.Body.Bytes(), &res)
+	if len(res.Skipped) != 2 || res.Results[0].Category != "reports" || res.Results[0].Removed != 1 {
+		t.Fatalf("cleanup = %+v", res)
+	}
+	if _, err := os.Stat(filepath.Join(cfg.BrowserProfilePath, "http_cache")); err != nil {
+		t.Errorf("profile removed during run: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(cfg.OutputDir, "vgbot_report_new.json")); err != nil {
+		t.Errorf("new report removed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(cfg.OutputDir, "notes.txt")); err != nil {
+		t.Errorf("foreign file removed: %v", err)
+	}
+
+	s.run = nil
+	if res, _ := s.cleanupStorage("browser_profiles", true); len(res.Results) != 1 || res.Results[0].Removed != 1 {
+		t.Fatalf("profiles cleanup = %+v", res)
+	}
+	if _, err := s.cleanupStorage("logs", true); err == nil {
+		t.Error("unknown category accepted")
+	}
+
+	rec = httptest.NewRecorder()
+	s.handleStorage(rec, httptest.NewRequest(http.MethodGet, "/api/storage", nil))
+	var got struct {
+		Categories []struct {
+			Category string `json:"category"`
+			Entries  int    `json:"entries"`
+		} `json:"categories"`
+	}
+	json.Unmarshal(rec.Body.Bytes(), &got)
+	if len(got.Categories) != 4 || got.Categories[0].Entries != 1 || got.Categories[2].Entries != 0 {
+		t.Fatalf("usage = %+v", got)
+	}
+}
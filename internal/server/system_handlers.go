@@ -1,10 +1,16 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
+	"log"
 	"net/http"
 	"runtime"
+	"strings"
+	"time"
 
+	"vgbot/pkg/chromeflags"
+	"vgbot/pkg/chromelocator"
 	"vgbot/pkg/network"
 	"vgbot/pkg/system"
 	"vgbot/pkg/stealth"
@@ -111,13 +117,12 @@ func (s *Server) handleNetworkConfig(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodGet:
 		pool := network.GetGlobalPool()
-		_ = pool.GetMetrics()
 		
 		config := NetworkConfigResponse{}
 		config.ConnectionPool.Enabled = true
 		config.ConnectionPool.MaxIdleConns = 100
 		config.ConnectionPool.MaxConnsPerHost = 20
-		config.HTTP3.Enabled = false
+		config.HTTP3.Enabled = pool.HTTP3Enabled()
 		config.TCPFastOpen.Enabled = false
 
 		w.Header().Set("Content-Type", "application/json")
@@ -181,3 +186,119 @@ func (s *Server) handleVMScore(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+
+// locateBrowser configures the shared chromelocator from the config and searches for a browser
+// (downloading the pinned Chromium when browser_auto_download is on)
+func (s *Server) locateBrowser() {
+	s.mu.Lock()
+	opts := chromelocator.Options{
+		Path:         s.cfg.BrowserPath,
+		CacheDir:     s.cfg.BrowserCacheDir,
+		AutoDownload: s.cfg.BrowserAutoDownload,
+		SHA256:       s.cfg.BrowserDownloadSHA256,
+	}
+	s.mu.Unlock()
+
+	loc := chromelocator.Default()
+	loc.SetOptions(opts)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+	b, err := loc.Locate(ctx)
+	if err != nil {
+		log.Printf("[WARN] Browser not found: %v", err)
+		s.hub.Broadcast("log", "Tarayıcı bulunamadı: "+err.Error())
+		return
+	}
+	log.Printf("[INFO] Browser: %s %s (%s, %s)", b.Name, b.Version, b.Path, b.Source)
+}
+
+// handleSystemBrowser returns browser detection status (GET) or re-runs detection in the background (POST)
+func (s *Server) handleSystemBrowser(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(chromelocator.Default().Status())
+	case http.MethodPost:
+		if chromelocator.Default().Status().Downloading {
+			http.Error(w, "Chromium download in progress", http.StatusConflict)
+			return
+		}
+		go s.locateBrowser()
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(map[string]string{"status": "started"})
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// browserFlagOptions converts the config fields to chromeflags options (empty entries are dropped).
+// host_map entries become Chrome host resolver rules and proxy bypass hosts.
+func browserFlagOptions(headless string, extra, extensions, hostMap []string) (chromeflags.Options, error) {
+	opts := chromeflags.Options{Headless: strings.TrimSpace(headless)}
+	for _, f := range extra {
+		if f = strings.TrimSpace(f); f != "" {
+			opts.Extra = append(opts.Extra, f)
+		}
+	}
+	for _, dir := range extensions {
+		if dir = strings.TrimSpace(dir); dir != "" {
+			opts.Extensions = append(opts.Extensions, dir)
+		}
+	}
+	m, err := network.ParseHostMap(hostMap)
+	if err != nil {
+		return opts, err
+	}
+	opts.HostResolverRules = m.ResolverRules()
+	opts.ProxyBypass = m.Hosts()
+	return opts, nil
+}
+
+// applyBrowserFlags makes the configured headless mode, extra flags, extensions and host mapping
+// the default for new browsers and HTTP clients
+func (s *Server) applyBrowserFlags() {
+	s.mu.Lock()
+	hostMap := s.cfg.HostMap
+	opts, err := browserFlagOptions(s.cfg.BrowserHeadlessMode, s.cfg.BrowserExtraFlags, s.cfg.BrowserExtensions, hostMap)
+	s.mu.Unlock()
+	if err != nil {
+		log.Printf("[WARN] Invalid host_map ignored: %v", err)
+		opts.HostResolverRules, opts.ProxyBypass = "", nil
+		hostMap = nil
+	}
+	if err := chromeflags.SetDefaults(opts); err != nil {
+		log.Printf("[WARN] Invalid browser flags ignored: %v", err)
+	}
+	m, _ := network.ParseHostMap(hostMap)
+	network.SetHostMap(m)
+	if len(m) > 0 {
+		log.Printf("[INFO] Staging host mapping active: %s", m.ResolverRules())
+	}
+}
+
+// handleSystemBrowserFlags shows the resolved Chrome launch flags per profile (debug view)
+func (s *Server) handleSystemBrowserFlags(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	opts := chromeflags.Defaults()
+	profiles := map[string]interface{}{}
+	for _, name := range chromeflags.Profiles() {
+		flags := chromeflags.Build(name, opts)
+		profiles[name] = map[string]interface{}{
+			"flags":        flags,
+			"command_line": chromeflags.CommandLine(flags),
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"headless":   opts.Headless,
+		"extra":      opts.Extra,
+		"extensions": opts.Extensions,
+		"host_map":   network.ActiveHostMap(),
+		"exec_path":  chromelocator.ExecPath(),
+		"profiles":   profiles,
+	})
+}
@@ -0,0 +1,187 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"vgbot/pkg/consent"
+	"vgbot/pkg/i18n"
+)
+
+// requestActor denetim kaydı için istemci adresi
+func requestActor(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
+
+// checkTarget hedefe trafik gönderilebilir mi (koşul onayı + izin listesi). İzin listesinde olmayan
+// domain denemesi denetim kaydına target_blocked olarak yazılır.
+func (s *Server) checkTarget(domain, source, actor, detail string) error {
+	err := s.consent.Check(domain)
+	if errors.Is(err, consent.ErrNotWhitelisted) {
+		d, _ := consent.Normalize(domain)
+		s.consent.Audit(consent.AuditEntry{Action: consent.ActionTargetBlocked, Domain: d, Source: source, Actor: actor, Detail: detail})
+	}
+	return err
+}
+
+// writeConsentRequired onay gerektiren hatayı 428 ile yazar; panel/CLI yanıttaki alanlara göre koşul onayı
+// veya domain onayı ister. Onayla ilgisiz hatada false döner.
+func writeConsentRequired(w http.ResponseWriter, err error) bool {
+	resp := map[string]interface{}{"error": err.Error(), "terms_version": consent.TermsVersion}
+	switch {
+	case errors.Is(err, consent.ErrTermsNotAcknowledged):
+		resp["terms_required"] = true
+	case errors.Is(err, consent.ErrNotWhitelisted):
+		_, domain, _ := strings.Cut(err.Error(), ": ")
+		resp["confirm_domain"] = domain
+	default:
+		return false
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusPreconditionRequired)
+	json.NewEncoder(w).Encode(resp)
+	return true
+}
+
+// rejectUnconfirmedTarget hedef onay gerektiriyorsa 428 döner
+func (s *Server) rejectUnconfirmedTarget(w http.ResponseWriter, r *http.Request, domain string) bool {
+	err := s.checkTarget(domain, "web", requestActor(r), r.URL.Path)
+	if err == nil {
+		return false
+	}
+	if !writeConsentRequired(w, err) {
+		http.Error(w, err.Error(), 400)
+	}
+	return true
+}
+
+// writeStartError başlatma hatasını yazar; onay gerektiren hatalar 428, diğerleri code ile döner
+func writeStartError(w http.ResponseWriter, err error, code int) {
+	if !writeConsentRequired(w, err) {
+		http.Error(w, err.Error(), code)
+	}
+}
+
+// handleConsent /api/consent: GET koşul metni ve onay durumu (?lang=en), POST {"accept": true, "version": "1"}
+// geçerli koşulları onaylar
+func (s *Server) handleConsent(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	switch r.Method {
+	case http.MethodGet:
+		st := s.consent.Snapshot()
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"terms_version":   consent.TermsVersion,
+			"terms":           i18n.T(r.URL.Query().Get("lang"), i18n.MsgTermsOfUse),
+			"acknowledged":    s.consent.Acknowledged(),
+			"acknowledged_at": st.AcknowledgedAt,
+			"acknowledged_by": st.AcknowledgedBy,
+			"targets":         st.Targets,
+		})
+	case http.MethodPost:
+		var req struct {
+			Accept  bool   `json:"accept"`
+			Version string `json:"version"`
+		}
+		if err := json.NewDecoder(io.LimitReader(r.Body, 1<<16)).Decode(&req); err != nil {
+			http.Error(w, "Invalid JSON", 400)
+			return
+		}
+		if !req.Accept || req.Version != consent.TermsVersion {
+			http.Error(w, "Geçerli kullanım koşulları (sürüm "+consent.TermsVersion+") açıkça kabul edilmeli", 400)
+			return
+		}
+		if err := s.consent.Acknowledge("web", requestActor(r)); err != nil {
+			http.Error(w, "Onay kaydedilemedi: "+err.Error(), 500)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"acknowledged": true, "terms_version": consent.TermsVersion})
+	default:
+		http.Error(w, "Method not allowed", 405)
+	}
+}
+
+// handleConsentTargets /api/consent/targets: GET izin listesi, POST {"domain", "confirm", "note"} domain'i ekler
+// (confirm alanına domain aynen yazılmalı), DELETE ?domain= çıkarır
+func (s *Server) handleConsentTargets(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	switch r.Method {
+	case http.MethodGet:
+		json.NewEncoder(w).Encode(map[string]interface{}{"targets": s.consent.Snapshot().Targets})
+	case http.MethodPost:
+		var req struct {
+			Domain  string `json:"domain"`
+			Confirm string `json:"confirm"`
+			Note    string `json:"note"`
+		}
+		if err := json.NewDecoder(io.LimitReader(r.Body, 1<<16)).Decode(&req); err != nil {
+			http.Error(w, "Invalid JSON", 400)
+			return
+		}
+		if !s.consent.Acknowledged() {
+			writeConsentRequired(w, consent.ErrTermsNotAcknowledged)
+			return
+		}
+		domain, err := consent.Normalize(req.Domain)
+		if err != nil {
+			http.Error(w, err.Error(), 400)
+			return
+		}
+		if confirm, _ := consent.Normalize(req.Confirm); confirm != domain {
+			http.Error(w, "Onay için domain'i confirm alanına aynen yazın: "+domain, 400)
+			return
+		}
+		if len(req.Note) > 500 {
+			req.Note = req.Note[:500]
+		}
+		t, err := s.consent.Confirm(domain, "web", requestActor(r), req.Note)
+		if err != nil {
+			http.Error(w, "İzin listesi kaydedilemedi: "+err.Error(), 500)
+			return
+		}
+		if s.hub != nil {
+			s.hub.Broadcast("log", "✅ Hedef domain izin listesine eklendi: "+t.Domain)
+		}
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(t)
+	case http.MethodDelete:
+		removed, err := s.consent.Remove(r.URL.Query().Get("domain"), "web", requestActor(r))
+		if err != nil {
+			http.Error(w, err.Error(), 400)
+			return
+		}
+		if !removed {
+			http.Error(w, "Domain izin listesinde yok", 404)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"status": "deleted", "domain": r.URL.Query().Get("domain")})
+	default:
+		http.Error(w, "Method not allowed", 405)
+	}
+}
+
+// handleConsentAudit GET /api/consent/audit?limit=100: denetim kaydının son satırları (en yenisi sonda)
+func (s *Server) handleConsentAudit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", 405)
+		return
+	}
+	limit := 100
+	if v, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && v > 0 {
+		limit = min(v, 5000)
+	}
+	entries, err := s.consent.AuditLog(limit)
+	if err != nil {
+		http.Error(w, "Denetim kaydı okunamadı: "+err.Error(), 500)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"entries": entries})
+}
@@ -0,0 +1,93 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"vgbot/pkg/consent"
+)
+
+func TestTargetConsent(t *testing.T) {
+	cfg := testConfig()
+	dir := t.TempDir()
+	store, _ := consent.Open(filepath.Join(dir, "consent.json"), filepath.Join(dir, "audit.log"))
+	s := &Server{cfg: &cfg, campaigns: newCampaignManager(), consent: store}
+
+	call := func(h http.HandlerFunc, method, target, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		h(rec, httptest.NewRequest(method, target, strings.NewReader(body)))
+		return rec
+	}
+	required := func(rec *httptest.ResponseRecorder) map[string]interface{} {
+		var resp map[string]interface{}
+		json.Unmarshal(rec.Body.Bytes(), &resp)
+		if rec.Code != http.StatusPreconditionRequired {
+			t.Fatalf("expected 428, got %d %s", rec.Code, rec.Body)
+		}
+		return resp
+	}
+
+	// İlk çalıştırma: önce koşul onayı istenir
+	if resp := required(call(s.handleStart, http.MethodPost, "/api/start", "")); resp["terms_required"] != true {
+		t.Errorf("start before ack = %v", resp)
+	}
+	if rec := call(s.handleConsent, http.MethodPost, "/api/consent", `{"accept":true,"version":"0"}`); rec.Code != 400 {
+		t.Errorf("stale terms version = %d", rec.Code)
+	}
+	if rec := call(s.handleConsent, http.MethodPost, "/api/consent", `{"accept":true,"version":"`+consent.TermsVersion+`"}`); rec.Code != 200 {
+		t.Fatalf("ack = %d %s", rec.Code, rec.Body)
+	}
+
+	// Listede olmayan domain: başlatma ve kampanya onay ister, deneme denetim kaydına yazılır
+	if resp := required(call(s.handleTestVisit, http.MethodPost, "/api/testvisit", "")); resp["confirm_domain"] != "example.com" {
+		t.Errorf("test visit = %v", resp)
+	}
+	if err := s.startCampaign(s.campaigns.add("shop", &cfg), "tr"); !errors.Is(err, consent.ErrNotWhitelisted) {
+		t.Errorf("campaign start = %v", err)
+	}
+	// Dry-run sitemap'i, monitor hedefi çeker: onaydan önce ağa çıkılmaz
+	if resp := required(call(s.handleStart, http.MethodPost, "/api/start?dry_run=1", "")); resp["confirm_domain"] != "example.com" {
+		t.Errorf("dry run = %v", resp)
+	}
+	if resp := required(call(s.handleMonitor, http.MethodPost, "/api/monitor", `{"action":"start"}`)); resp["confirm_domain"] != "example.com" {
+		t.Errorf("monitor = %v", resp)
+	}
+	if rec := call(s.handleConsentTargets, http.MethodPost, "/api/consent/targets", `{"domain":"example.com","confirm":"exampel.com"}`); rec.Code != 400 {
+		t.Errorf("mistyped confirmation = %d", rec.Code)
+	}
+	if rec := call(s.handleConsentTargets, http.MethodPost, "/api/consent/targets", `{"domain":"example.com","confirm":"Example.com"}`); rec.Code != http.StatusCreated {
+		t.Fatalf("confirm = %d %s", rec.Code, rec.Body)
+	}
+	if err := s.checkTarget("www.example.com", "web", "", ""); err != nil {
+		t.Errorf("confirmed target = %v", err)
+	}
+	// Monitor'ün mutlak URL'leri de listede olmalı
+	cfg.MonitorURLs = []string{"/health", "https://status.other.example/health"}
+	if resp := required(call(s.handleMonitor, http.MethodPost, "/api/monitor", `{"action":"start"}`)); resp["confirm_domain"] != "status.other.example" {
+		t.Errorf("monitor foreign url = %v", resp)
+	}
+
+	var audit struct {
+		Entries []consent.AuditEntry `json:"entries"`
+	}
+	json.Unmarshal(call(s.handleConsentAudit, http.MethodGet, "/api/consent/audit", "").Body.Bytes(), &audit)
+	var actions []string
+	for _, e := range audit.Entries {
+		actions = append(actions, e.Action)
+	}
+	if got := strings.Join(actions, ","); got != "terms_acknowledged,target_blocked,target_blocked,target_blocked,target_blocked,target_confirmed,target_blocked" {
+		t.Errorf("audit actions = %s", got)
+	}
+
+	if rec := call(s.handleConsentTargets, http.MethodDelete, "/api/consent/targets?domain=example.com", ""); rec.Code != 200 {
+		t.Errorf("delete = %d", rec.Code)
+	}
+	if rec := call(s.handleConsentTargets, http.MethodDelete, "/api/consent/targets?domain=example.com", ""); rec.Code != 404 {
+		t.Errorf("delete again = %d", rec.Code)
+	}
+}
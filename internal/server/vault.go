@@ -0,0 +1,73 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"vgbot/pkg/vault"
+)
+
+// handleVault GET: kayıtlı giriş bilgisi adları (şifreler döndürülmez);
+// POST {"name","username","password"}: ekle/güncelle; DELETE ?name=: sil.
+// Vault parolası yalnızca sunucu ortamından (VGBOT_VAULT_KEY) okunur.
+func (s *Server) handleVault(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost && r.Method != http.MethodDelete {
+		http.Error(w, "Method not allowed", 405)
+		return
+	}
+	v, err := s.openVault()
+	if err != nil {
+		code := 500
+		if errors.Is(err, vault.ErrNoKey) || errors.Is(err, vault.ErrBadKey) {
+			code = 409
+		}
+		http.Error(w, err.Error(), code)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPost:
+		var body struct {
+			Name     string `json:"name"`
+			Username string `json:"username"`
+			Password string `json:"password"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, "Geçersiz istek", 400)
+			return
+		}
+		if err := v.Set(body.Name, vault.Credential{Username: body.Username, Password: body.Password}); err != nil {
+			http.Error(w, err.Error(), 400)
+			return
+		}
+	case http.MethodDelete:
+		if err := v.Delete(r.URL.Query().Get("name")); err != nil {
+			http.Error(w, err.Error(), 500)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"names": v.Names()})
+}
+
+// openVault açık vault'u döner; ilk istekte veya vault_file değiştiğinde VGBOT_VAULT_KEY ile açar.
+// Her istekte açmak scrypt türetmesini tekrarlardı; açılamazsa sonraki istek yeniden dener.
+func (s *Server) openVault() (*vault.Vault, error) {
+	s.mu.Lock()
+	path := s.cfg.VaultFile
+	s.mu.Unlock()
+
+	s.vaultMu.Lock()
+	defer s.vaultMu.Unlock()
+	if s.vault != nil && s.vault.Path() == path {
+		return s.vault, nil
+	}
+	v, err := vault.OpenFromEnv(path)
+	if err != nil {
+		return nil, err
+	}
+	s.vault = v
+	return v, nil
+}
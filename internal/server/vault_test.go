@@ -0,0 +1,43 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"vgbot/pkg/vault"
+)
+
+func TestVaultHandlerReusesOpenVault(t *testing.T) {
+	t.Setenv(vault.KeyEnvVar, "correct horse")
+	dir := t.TempDir()
+	cfg := testConfig()
+	cfg.VaultFile = filepath.Join(dir, "vault.enc")
+	s := &Server{cfg: &cfg}
+
+	rec := httptest.NewRecorder()
+	s.handleVault(rec, httptest.NewRequest(http.MethodPost, "/api/vault", strings.NewReader(`{"name":"shop","username":"alice","password":"x"}`)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("POST = %d %s", rec.Code, rec.Body)
+	}
+	opened := s.vault
+
+	rec = httptest.NewRecorder()
+	s.handleVault(rec, httptest.NewRequest(http.MethodGet, "/api/vault", nil))
+	if !strings.Contains(rec.Body.String(), `"shop"`) {
+		t.Fatalf("GET = %s", rec.Body)
+	}
+	if s.vault != opened {
+		t.Fatal("vault reopened on every request")
+	}
+
+	// vault_file değişince yeni dosya açılır
+	cfg.VaultFile = filepath.Join(dir, "other.enc")
+	rec = httptest.NewRecorder()
+	s.handleVault(rec, httptest.NewRequest(http.MethodGet, "/api/vault", nil))
+	if s.vault == opened || strings.Contains(rec.Body.String(), `"shop"`) {
+		t.Fatalf("vault not reopened for new path: %s", rec.Body)
+	}
+}
@@ -0,0 +1,102 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"vgbot/internal/config"
+)
+
+func TestBuildWizardConfigClampsToSafeRate(t *testing.T) {
+	cfg, warnings, err := buildWizardConfig(config.Config{}, wizardAnswers{
+		Domain:        "https://Example.com/blog/",
+		GtagID:        "g-abc1234567",
+		ProxyMode:     "none",
+		HitsPerMinute: 100,
+		MaxConcurrent: 20,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.TargetDomain != "example.com" || cfg.GtagID != "G-ABC1234567" {
+		t.Fatalf("domain = %q, gtag = %q", cfg.TargetDomain, cfg.GtagID)
+	}
+	if cfg.HitsPerMinute != 5 || cfg.MaxConcurrentVisits != 2 || len(warnings) != 2 {
+		t.Fatalf("hpm = %d, conc = %d, warnings = %v", cfg.HitsPerMinute, cfg.MaxConcurrentVisits, warnings)
+	}
+	if cfg.DurationMinutes != 30 || cfg.ProxyEnabled {
+		t.Fatalf("duration = %d, proxy = %v", cfg.DurationMinutes, cfg.ProxyEnabled)
+	}
+}
+
+func TestBuildWizardConfigPrivateProxies(t *testing.T) {
+	cfg, _, err := buildWizardConfig(config.Config{ProxyHost: "old", ProxyPort: 1}, wizardAnswers{
+		Domain:         "example.com",
+		ProxyMode:      "private",
+		PrivateProxies: []string{"1.2.3.4:8080", "socks5://u:p@5.6.7.8:1080", ""},
+		HitsPerMinute:  15,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.UsePrivateProxy || len(cfg.PrivateProxies) != 2 || cfg.ProxyEnabled {
+		t.Fatalf("private = %v, proxies = %+v, single = %v", cfg.UsePrivateProxy, cfg.PrivateProxies, cfg.ProxyEnabled)
+	}
+	if p := cfg.PrivateProxies[1]; p.Protocol != "socks5" || p.User != "u" || p.Pass != "p" || p.Port != 1080 {
+		t.Fatalf("proxy = %+v", p)
+	}
+	if cfg.HitsPerMinute != 15 {
+		t.Fatalf("hpm = %d, want 15 (under 2-proxy limit)", cfg.HitsPerMinute)
+	}
+	if summary := wizardSummary(cfg); !strings.Contains(strings.Join(summary, " "), "2 private proxy") {
+		t.Fatalf("summary = %v", summary)
+	}
+}
+
+func TestBuildWizardConfigRejectsBadInput(t *testing.T) {
+	for _, a := range []wizardAnswers{
+		{Domain: ""},
+		{Domain: "localhost"},
+		{Domain: "192.168.1.10"},
+		{Domain: "example.com", GtagID: "UA-1234-1"},
+		{Domain: "example.com", ProxyMode: "single"},
+		{Domain: "example.com", ProxyMode: "private", PrivateProxies: []string{"host-without-port"}},
+		{Domain: "example.com", ProxyMode: "tor"},
+	} {
+		if _, _, err := buildWizardConfig(config.Config{}, a); err == nil {
+			t.Errorf("expected error for %+v", a)
+		}
+	}
+}
+
+func TestWizardSecretPersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wizard_secret.key")
+	first, err := newWizardState(path).token("Example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Yeniden başlatma: aynı dosyadan aynı token
+	again, err := newWizardState(path).token("example.com")
+	if err != nil || again != first {
+		t.Fatalf("token after restart = %q (%v), want %q", again, err, first)
+	}
+	if other, _ := newWizardState(path).token("other.com"); other == first {
+		t.Fatal("token does not depend on domain")
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+		t.Fatalf("secret file mode = %v (%v), want 0600", info.Mode().Perm(), err)
+	}
+}
+
+func TestWizardFinishRequiresVerifiedDomain(t *testing.T) {
+	cfg := testConfig()
+	s := &Server{cfg: &cfg, wizard: newWizardState(filepath.Join(t.TempDir(), "wizard_secret.key"))}
+	if _, err := s.wizardFinish(wizardAnswers{Domain: "example.org"}); err == nil || !strings.Contains(err.Error(), "doğrulanmadı") {
+		t.Fatalf("finish without verification: err = %v", err)
+	}
+	if s.cfg.TargetDomain != "example.com" {
+		t.Fatalf("config changed to %q", s.cfg.TargetDomain)
+	}
+}
@@ -0,0 +1,177 @@
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"vgbot/internal/config"
+	"vgbot/internal/reporter"
+	"vgbot/pkg/delay"
+	"vgbot/pkg/i18n"
+)
+
+// maxVirtualUsers closed modda bir seviyedeki en fazla sanal kullanıcı (MaxConcurrentVisits sınırıyla aynı)
+const maxVirtualUsers = 50
+
+// closedLoopLevels closed modda sırayla uygulanacak sanal kullanıcı sayıları (boşsa tek seviye: workers)
+func closedLoopLevels(levels []int, workers int) []int {
+	out := make([]int, 0, len(levels))
+	for _, n := range levels {
+		if n > maxVirtualUsers {
+			n = maxVirtualUsers
+		}
+		if n > 0 {
+			out = append(out, n)
+		}
+	}
+	if len(out) == 0 {
+		out = append(out, workers)
+	}
+	return out
+}
+
+// closedLoopStages closed modun aşamaları: yük profili varsa onun aşamaları (seviye = sanal kullanıcı),
+// yoksa load_levels süreye eşit bölünür
+func closedLoopStages(cfg *config.Config, workers int) ([]LoadStage, error) {
+	stages, err := LoadStages(cfg.LoadProfile, cfg.LoadStages, cfg.Duration, workers)
+	if err != nil {
+		return nil, err
+	}
+	if len(stages) > 0 {
+		for i := range stages {
+			if stages[i].Level > maxVirtualUsers {
+				stages[i].Level = maxVirtualUsers
+			}
+		}
+		return stages, nil
+	}
+	levels := closedLoopLevels(cfg.LoadLevels, workers)
+	for _, vu := range levels {
+		stages = append(stages, LoadStage{Duration: cfg.Duration / time.Duration(len(levels)), Level: vu})
+	}
+	return stages, nil
+}
+
+// describeStages aşamaları log için "ad seviye birim süre" listesi olarak yazar
+func describeStages(stages []LoadStage, unit string) string {
+	parts := make([]string, len(stages))
+	for i, st := range stages {
+		parts[i] = strings.TrimSpace(fmt.Sprintf("%s %d %s %s", st.Name, st.Level, unit, st.Duration.Round(time.Second)))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// runClosedLoop klasik yük testi: her aşamada sabit sayıda sanal kullanıcı, bir ziyaret biter bitmez
+// yenisini başlatır (HPM uygulanmaz). Her aşamanın throughput ve gecikme değerleri rapora ayrı yazılır.
+func (s *Simulator) runClosedLoop(ctx context.Context, workers int) error {
+	stages, err := closedLoopStages(s.cfg, workers)
+	if err != nil {
+		return err
+	}
+	s.reporter.LogT(i18n.MsgClosedLoopPlan, describeStages(stages, "VU"))
+
+	start := time.Now()
+	deadline := start.Add(s.cfg.Duration)
+	end := start
+	for i, st := range stages {
+		end = end.Add(st.Duration)
+		if i == len(stages)-1 || end.After(deadline) {
+			end = deadline
+		}
+		s.reporter.BeginLoadLevel(st.Name, st.Level, 0)
+		if st.Name != "" {
+			s.reporter.LogT(i18n.MsgLoadStage, st.Name, st.Level, "VU", time.Until(end).Round(time.Second))
+		}
+		s.runVirtualUsers(ctx, st.Level, end)
+		s.endLoadLevel()
+		if ctx.Err() != nil {
+			s.reporter.LogT(i18n.MsgCancel)
+			s.finish()
+			return ctx.Err()
+		}
+		if !end.Before(deadline) {
+			break
+		}
+	}
+	s.reporter.LogT(i18n.MsgDeadline)
+	s.finish()
+	return nil
+}
+
+// runVirtualUsers n sanal kullanıcıyı end anına kadar arka arkaya ziyaret yaptırır; hepsi bitince döner
+func (s *Simulator) runVirtualUsers(ctx context.Context, n int, end time.Time) {
+	var pickMu sync.Mutex // pickPage'in rng'si eşzamanlı kullanıma uygun değil
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil && time.Now().Before(end) {
+				if !s.waitForWindow(ctx, end) {
+					return
+				}
+				pickMu.Lock()
+				url := s.pickPage()
+				pickMu.Unlock()
+
+				visitCtx, cancel := context.WithTimeout(ctx, 60*time.Second)
+				err := s.hitVisitor.VisitURL(visitCtx, url)
+				cancel()
+				if err != nil {
+					s.visitErrAgg.add(s.reporter, url, err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// runLoadStages açık modda yük profilini uygular: her aşamada token bucket hızını aşamanın HPM'ine ayarlar.
+// Son aşama (veya aşamalar run süresinden kısaysa son seviye) deadline'a kadar sürer.
+func (s *Simulator) runLoadStages(ctx context.Context, tb *delay.TokenBucket, stages []LoadStage, deadline time.Time) {
+	end := time.Now()
+	for i, st := range stages {
+		end = end.Add(st.Duration)
+		if i == len(stages)-1 || end.After(deadline) {
+			end = deadline
+		}
+		tb.SetRate(st.Level)
+		s.reporter.BeginLoadLevel(st.Name, 0, st.Level)
+		s.reporter.LogT(i18n.MsgLoadStage, st.Name, st.Level, "HPM", time.Until(end).Round(time.Second))
+
+		timer := time.NewTimer(time.Until(end))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		if !end.Before(deadline) {
+			return
+		}
+		s.endLoadLevel()
+	}
+}
+
+// endLoadLevel aktif seviyeyi kapatır ve sonucunu loglar
+func (s *Simulator) endLoadLevel() {
+	l, ok := s.reporter.EndLoadLevel()
+	if !ok {
+		return
+	}
+	s.reporter.LogT(i18n.MsgLoadLevel, loadLevelLabel(l), l.Hits, l.SuccessHits, l.ThroughputPerMin, l.AvgLatencyMs, l.P95LatencyMs)
+}
+
+// loadLevelLabel seviyenin log etiketi (ör. "step 2/4 20 HPM", "10 VU")
+func loadLevelLabel(l reporter.LoadLevel) string {
+	switch {
+	case l.Concurrency > 0:
+		return strings.TrimSpace(fmt.Sprintf("%s %d VU", l.Stage, l.Concurrency))
+	case l.HitsPerMinute > 0:
+		return strings.TrimSpace(fmt.Sprintf("%s %d HPM", l.Stage, l.HitsPerMinute))
+	}
+	return l.Stage
+}
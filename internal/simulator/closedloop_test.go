@@ -0,0 +1,24 @@
+package simulator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClosedLoopLevels(t *testing.T) {
+	cases := []struct {
+		levels  []int
+		workers int
+		want    []int
+	}{
+		{nil, 10, []int{10}},
+		{[]int{5, 10, 20}, 10, []int{5, 10, 20}},
+		{[]int{0, -1, 80}, 10, []int{50}},
+		{[]int{0}, 7, []int{7}},
+	}
+	for _, c := range cases {
+		if got := closedLoopLevels(c.levels, c.workers); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("closedLoopLevels(%v, %d) = %v, want %v", c.levels, c.workers, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package simulator
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"vgbot/internal/config"
+	"vgbot/pkg/scheduler"
+)
+
+func TestHitSchedule(t *testing.T) {
+	windows, err := scheduler.NewWindowPlan([]string{"10:00-11:00"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 3, 2, 9, 30, 0, 0, time.Local)
+	slots, total := hitSchedule(windows, now, 2*time.Hour, 10)
+	if total != 600 {
+		t.Errorf("total = %d, want 600 (60 open minutes x 10)", total)
+	}
+	if len(slots) != 3 || slots[0].Hits != 0 || slots[1].Hits != 600 || slots[2].Hits != 0 {
+		t.Errorf("slots = %+v", slots)
+	}
+	if !slots[0].Start.Equal(time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local)) {
+		t.Errorf("first slot starts %v", slots[0].Start)
+	}
+
+	if _, total := hitSchedule(nil, now, 30*time.Minute, 4); total != 120 {
+		t.Errorf("no windows: total = %d, want 120", total)
+	}
+}
+
+func TestDryRunLandingPages(t *testing.T) {
+	cfg := &config.Config{
+		TargetDomain:    "example.com",
+		DurationMinutes: 10,
+		HitsPerMinute:   20,
+		LandingPages:    []string{"/pricing=3", "/blog=1"},
+		NotFoundRate:    10,
+		DeviceType:      "mobile",
+		ReferrerEnabled: true,
+		ReferrerKeyword: "vgbot",
+	}
+	plan, err := DryRun(context.Background(), cfg, nil, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.PlannedHits != 200 || plan.PageSource != "landing_pages" || plan.Pages != 2 {
+		t.Fatalf("plan = %+v", plan)
+	}
+	top := plan.TopPages[0]
+	if top.URL != "https://example.com/pricing" || math.Abs(top.Share-0.675) > 1e-9 || top.Hits != 135 {
+		t.Errorf("top page = %+v", top)
+	}
+	if plan.Devices["mobile"] != 1 || plan.Referrers["google"] != 1 {
+		t.Errorf("devices = %v, referrers = %v", plan.Devices, plan.Referrers)
+	}
+	if plan.Proxies.Mode != "none" || plan.Proxies.Checked != 0 {
+		t.Errorf("proxies = %+v", plan.Proxies)
+	}
+}
+
+func TestDryRunHomepageOnly(t *testing.T) {
+	cfg := &config.Config{TargetDomain: "https://example.com/", DurationMinutes: 1, Keywords: []string{"a"}}
+	plan, err := DryRun(context.Background(), cfg, nil, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.PageSource != "homepage" || len(plan.TopPages) != 1 || plan.TopPages[0].URL != "https://example.com" {
+		t.Errorf("pages = %s %+v", plan.PageSource, plan.TopPages)
+	}
+	if len(plan.Warnings) == 0 {
+		t.Error("expected a warning about skipped crawler discovery")
+	}
+	if plan.Devices["user_agent_pool"] != 1 || plan.Referrers["bing"] != 0.2 {
+		t.Errorf("devices = %v, referrers = %v", plan.Devices, plan.Referrers)
+	}
+}
@@ -0,0 +1,117 @@
+package simulator
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"vgbot/internal/config"
+	"vgbot/internal/reporter"
+)
+
+// LandingMix giriş (landing) sayfası ağırlıkları; oturumların hangi sayfadan başlayacağını belirler,
+// oturum içi gezinilebilecek sayfalardan (keşif/sitemap) bağımsızdır
+type LandingMix struct {
+	urls  []string
+	cum   []int // Kümülatif ağırlıklar
+	total int
+}
+
+// ParseLandingMix "url=ağırlık" girdilerini çözer ("/path" baseURL'e göre; ağırlık yoksa 1).
+// Girdi yoksa nil döner (landing sayfası anasayfa/keşfedilen sayfalardan seçilir).
+func ParseLandingMix(entries []string, baseURL string) (*LandingMix, error) {
+	base := strings.TrimSuffix(baseURL, "/")
+	m := &LandingMix{}
+	seen := map[string]bool{}
+	for _, e := range entries {
+		e = strings.TrimSpace(e)
+		if e == "" {
+			continue
+		}
+		// Son "=" sonrası sayıysa ağırlıktır; değilse URL'nin sorgu parametresidir
+		target, weight := e, 1
+		if i := strings.LastIndex(e, "="); i > 0 {
+			if w, err := strconv.Atoi(strings.TrimSpace(e[i+1:])); err == nil {
+				if w < 0 {
+					return nil, fmt.Errorf("geçersiz landing ağırlığı %q (ör. /fiyatlar=40)", e)
+				}
+				target, weight = strings.TrimSpace(e[:i]), w
+			}
+		}
+		switch {
+		case strings.HasPrefix(target, "http://"), strings.HasPrefix(target, "https://"):
+		case strings.HasPrefix(target, "/"):
+			target = base + target
+		default:
+			return nil, fmt.Errorf("geçersiz landing sayfası %q (tam URL veya /path)", target)
+		}
+		if seen[target] {
+			return nil, fmt.Errorf("landing sayfası iki kez tanımlı: %s", target)
+		}
+		seen[target] = true
+		if weight == 0 {
+			continue
+		}
+		m.total += weight
+		m.urls = append(m.urls, target)
+		m.cum = append(m.cum, m.total)
+	}
+	if len(seen) > 0 && m.total == 0 {
+		return nil, fmt.Errorf("tüm landing ağırlıkları 0")
+	}
+	if m.total == 0 {
+		return nil, nil
+	}
+	return m, nil
+}
+
+// LandingEntries landing_pages girdilerine url_weights listesini "url=ağırlık" olarak ekler
+func LandingEntries(pages []string, weights []config.URLWeight) []string {
+	if len(weights) == 0 {
+		return pages
+	}
+	out := append([]string(nil), pages...)
+	for _, w := range weights {
+		out = append(out, fmt.Sprintf("%s=%d", strings.TrimSpace(w.URL), w.Weight))
+	}
+	return out
+}
+
+// pick ağırlığa göre bir landing sayfası seçer (rng çağıranın kilidi altında olmalı)
+func (m *LandingMix) pick(rng *rand.Rand) string {
+	n := rng.Intn(m.total)
+	for i, c := range m.cum {
+		if n < c {
+			return m.urls[i]
+		}
+	}
+	return m.urls[len(m.urls)-1]
+}
+
+// Describe dağılımı log için yüzde olarak döner (ör. "https://x.com/fiyat %40")
+func (m *LandingMix) Describe() []string {
+	out := make([]string, len(m.urls))
+	prev := 0
+	for i, u := range m.urls {
+		out[i] = fmt.Sprintf("%s %%%.0f", u, float64(m.cum[i]-prev)*100/float64(m.total))
+		prev = m.cum[i]
+	}
+	return out
+}
+
+// shares her URL'nin seçilme oranını döner (0-1)
+func (m *LandingMix) shares() map[string]float64 {
+	out := make(map[string]float64, len(m.urls))
+	prev := 0
+	for i, u := range m.urls {
+		out[u] = float64(m.cum[i]-prev) / float64(m.total)
+		prev = m.cum[i]
+	}
+	return out
+}
+
+// notFoundURL var olmayan bir URL üretir (404 denemesi); reporter bu yolu ayrı sayar
+func notFoundURL(base string, rng *rand.Rand) string {
+	return fmt.Sprintf("%s%s%08x", strings.TrimSuffix(base, "/"), reporter.NotFoundPathPrefix, rng.Uint32())
+}
@@ -0,0 +1,71 @@
+package simulator
+
+import (
+	"math/rand"
+	"testing"
+
+	"vgbot/internal/config"
+)
+
+func TestParseLandingMix(t *testing.T) {
+	m, err := ParseLandingMix([]string{"/pricing=3", "https://example.com/?ref=5=1", " /blog ", "/old=0", ""}, "https://example.com/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"https://example.com/pricing", "https://example.com/?ref=5", "https://example.com/blog"}
+	if len(m.urls) != len(want) || m.total != 5 {
+		t.Fatalf("mix = %+v", m)
+	}
+	for i, u := range want {
+		if m.urls[i] != u {
+			t.Errorf("urls[%d] = %q, want %q", i, m.urls[i], u)
+		}
+	}
+
+	counts := map[string]int{}
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 5000; i++ {
+		counts[m.pick(rng)]++
+	}
+	if c := counts["https://example.com/pricing"]; c < 2700 || c > 3300 {
+		t.Errorf("pricing picked %d/5000, want ~3000", c)
+	}
+	if counts["https://example.com/old"] != 0 {
+		t.Error("zero-weight page picked")
+	}
+
+	if m, err := ParseLandingMix(nil, "https://example.com"); m != nil || err != nil {
+		t.Errorf("empty = %v, %v", m, err)
+	}
+	for _, bad := range [][]string{{"pricing=3"}, {"/a=-1"}, {"/a=1", "/a=2"}, {"/a=0"}} {
+		if _, err := ParseLandingMix(bad, "https://example.com"); err == nil {
+			t.Errorf("%q accepted", bad)
+		}
+	}
+}
+
+func TestLandingEntriesWithURLWeights(t *testing.T) {
+	entries := LandingEntries([]string{"/pricing=3"}, []config.URLWeight{
+		{URL: " /signup ", Weight: 1},
+		{URL: "https://example.com/?utm=a", Weight: 4},
+		{URL: "/off", Weight: 0},
+	})
+	m, err := ParseLandingMix(entries, "https://example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"https://example.com/pricing", "https://example.com/signup", "https://example.com/?utm=a"}
+	if len(m.urls) != len(want) || m.total != 8 {
+		t.Fatalf("mix = %+v", m)
+	}
+	for i, u := range want {
+		if m.urls[i] != u {
+			t.Errorf("urls[%d] = %q, want %q", i, m.urls[i], u)
+		}
+	}
+	for _, bad := range [][]config.URLWeight{{{URL: "", Weight: 1}}, {{URL: "/a", Weight: -1}}, {{URL: "/pricing", Weight: 1}}} {
+		if _, err := ParseLandingMix(LandingEntries([]string{"/pricing=3"}, bad), "https://example.com"); err == nil {
+			t.Errorf("%+v accepted", bad)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package simulator
+
+import (
+	"fmt"
+
+	"vgbot/internal/browser"
+	"vgbot/internal/config"
+	"vgbot/pkg/vault"
+)
+
+// LoginForm config'teki giriş formu alanlarından senaryo oluşturur (giriş bilgisi ve vault hariç)
+func LoginForm(cfg *config.Config) *browser.LoginScenario {
+	return &browser.LoginScenario{
+		URL:             cfg.LoginURL,
+		UserSelector:    cfg.LoginUserSelector,
+		PassSelector:    cfg.LoginPassSelector,
+		SubmitSelector:  cfg.LoginSubmitSelector,
+		SuccessSelector: cfg.LoginSuccessSelector,
+	}
+}
+
+// newLoginScenario giriş açıksa vault'u açıp giriş bilgisini yükler; kapalıysa nil döner
+func newLoginScenario(cfg *config.Config) (*browser.LoginScenario, error) {
+	if !cfg.LoginEnabled {
+		return nil, nil
+	}
+	l := LoginForm(cfg)
+	if err := l.Validate(); err != nil {
+		return nil, err
+	}
+	if cfg.LoginCredential == "" {
+		return nil, fmt.Errorf("login_credential boş (vault'taki giriş bilgisinin adı)")
+	}
+	v, err := vault.OpenFromEnv(cfg.VaultFile)
+	if err != nil {
+		return nil, err
+	}
+	cred, ok := v.Get(cfg.LoginCredential)
+	if !ok {
+		return nil, fmt.Errorf("vault'ta %q giriş bilgisi yok", cfg.LoginCredential)
+	}
+	l.Credential, l.Vault = cred, v
+	return l, nil
+}
@@ -0,0 +1,90 @@
+package simulator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LoadStage yük profilinin bir aşaması; Level açık modda HPM, closed modda sanal kullanıcı sayısıdır
+type LoadStage struct {
+	Name     string        `json:"name"`
+	Duration time.Duration `json:"duration"`
+	Level    int           `json:"level"`
+}
+
+// Hazır yük profilleri
+const (
+	ProfileStep   = "step"   // Seviye eşit aralıklarla %25'ten %100'e çıkar
+	ProfileSpike  = "spike"  // Düşük taban, kısa tam yük sıçraması, tekrar taban
+	ProfileSoak   = "soak"   // Uzun süre sabit yük
+	ProfileCustom = "custom" // Yalnızca load_stages
+)
+
+// LoadStages profil şekli ve aşama tanımlarından ("10m:40" = 10 dakika, seviye 40) aşamaları üretir.
+// stages boşsa şeklin varsayılanı total süreye ve base seviyeye göre hesaplanır. profile boşsa nil döner.
+func LoadStages(profile string, stages []string, total time.Duration, base int) ([]LoadStage, error) {
+	if profile == "" {
+		return nil, nil
+	}
+	if base <= 0 {
+		base = 1
+	}
+	var out []LoadStage
+	switch {
+	case len(stages) > 0:
+		for _, spec := range stages {
+			st, err := parseLoadStage(spec)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, st)
+		}
+	case profile == ProfileStep:
+		for i := 1; i <= 4; i++ {
+			out = append(out, LoadStage{Duration: total / 4, Level: atLeastOne(base * i / 4)})
+		}
+	case profile == ProfileSpike:
+		low := atLeastOne(base / 4)
+		out = []LoadStage{
+			{Duration: total * 2 / 5, Level: low},
+			{Duration: total / 5, Level: base},
+			{Duration: total - total*2/5 - total/5, Level: low},
+		}
+	case profile == ProfileSoak:
+		out = []LoadStage{{Duration: total, Level: base}}
+	case profile == ProfileCustom:
+		return nil, fmt.Errorf("load_profile custom için load_stages gerekli")
+	default:
+		return nil, fmt.Errorf("load_profile step, spike, soak veya custom olmalı: %q", profile)
+	}
+	for i := range out {
+		out[i].Name = fmt.Sprintf("%s %d/%d", profile, i+1, len(out))
+	}
+	return out, nil
+}
+
+// parseLoadStage "süre:seviye" biçimindeki aşamayı çözer (ör. "5m:20")
+func parseLoadStage(spec string) (LoadStage, error) {
+	d, lvl, ok := strings.Cut(strings.TrimSpace(spec), ":")
+	if !ok {
+		return LoadStage{}, fmt.Errorf("load_stages süre:seviye biçiminde olmalı: %q", spec)
+	}
+	dur, err := time.ParseDuration(strings.TrimSpace(d))
+	if err != nil || dur <= 0 {
+		return LoadStage{}, fmt.Errorf("load_stages geçersiz süre: %q", spec)
+	}
+	level, err := strconv.Atoi(strings.TrimSpace(lvl))
+	if err != nil || level <= 0 {
+		return LoadStage{}, fmt.Errorf("load_stages seviye pozitif tam sayı olmalı: %q", spec)
+	}
+	return LoadStage{Duration: dur, Level: level}, nil
+}
+
+func atLeastOne(n int) int {
+	if n < 1 {
+		return 1
+	}
+	return n
+}
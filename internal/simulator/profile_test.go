@@ -0,0 +1,52 @@
+package simulator
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoadStages(t *testing.T) {
+	if st, err := LoadStages("", nil, time.Hour, 40); st != nil || err != nil {
+		t.Fatalf("empty profile = %v, %v", st, err)
+	}
+
+	st, err := LoadStages(ProfileStep, nil, 40*time.Minute, 40)
+	if err != nil || len(st) != 4 {
+		t.Fatalf("step = %v, %v", st, err)
+	}
+	for i, want := range []int{10, 20, 30, 40} {
+		if st[i].Level != want || st[i].Duration != 10*time.Minute {
+			t.Errorf("step[%d] = %+v", i, st[i])
+		}
+	}
+	if st[1].Name != "step 2/4" {
+		t.Errorf("name = %q", st[1].Name)
+	}
+
+	st, err = LoadStages(ProfileSpike, nil, 50*time.Minute, 2)
+	if err != nil || len(st) != 3 || st[0].Level != 1 || st[1].Level != 2 || st[1].Duration != 10*time.Minute || st[2].Duration != 20*time.Minute {
+		t.Fatalf("spike = %+v, %v", st, err)
+	}
+
+	st, err = LoadStages(ProfileSoak, nil, time.Hour, 30)
+	if err != nil || len(st) != 1 || st[0].Level != 30 || st[0].Duration != time.Hour {
+		t.Fatalf("soak = %+v, %v", st, err)
+	}
+
+	st, err = LoadStages(ProfileCustom, []string{"5m:20", " 90s : 60 "}, 0, 1)
+	if err != nil || len(st) != 2 || st[1].Duration != 90*time.Second || st[1].Level != 60 || st[0].Name != "custom 1/2" {
+		t.Fatalf("custom = %+v, %v", st, err)
+	}
+
+	for _, bad := range [][]string{{"5m"}, {"x:10"}, {"-1m:10"}, {"5m:0"}, {"5m:abc"}} {
+		if _, err := LoadStages(ProfileCustom, bad, 0, 1); err == nil {
+			t.Errorf("stages %q: want error", bad)
+		}
+	}
+	if _, err := LoadStages(ProfileCustom, nil, time.Hour, 1); err == nil {
+		t.Error("custom without stages: want error")
+	}
+	if _, err := LoadStages("ramp", nil, time.Hour, 1); err == nil {
+		t.Error("unknown profile: want error")
+	}
+}
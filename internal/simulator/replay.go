@@ -0,0 +1,204 @@
+package simulator
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"vgbot/internal/reporter"
+	"vgbot/pkg/i18n"
+)
+
+// ReplayHit tekrar oynatılacak tek ziyaret
+type ReplayHit struct {
+	Offset    time.Duration // İlk ziyaretin başlangıcından itibaren
+	URL       string
+	UserAgent string
+}
+
+// ReplayPlan önceki bir çalıştırmanın ziyaret dizisi
+type ReplayPlan struct {
+	Source string
+	Seed   int64 // Kaynak raporun seed'i (varsa)
+	Hits   []ReplayHit
+}
+
+// Duration planın toplam süresi (son ziyaretin başlangıcı)
+func (p *ReplayPlan) Duration() time.Duration {
+	if len(p.Hits) == 0 {
+		return 0
+	}
+	return p.Hits[len(p.Hits)-1].Offset
+}
+
+// LoadReplay JSON raporu (vgbot_report_*.json) veya CSV hit logunu (vgbot_hits_*.csv) okur
+func LoadReplay(path string) (*ReplayPlan, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var (
+		records []reporter.HitRecord
+		seed    int64
+	)
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		var report struct {
+			Records []reporter.HitRecord `json:"records"`
+			Metrics reporter.Metrics     `json:"metrics"`
+		}
+		if err := json.NewDecoder(f).Decode(&report); err != nil {
+			return nil, fmt.Errorf("replay JSON: %w", err)
+		}
+		records, seed = report.Records, report.Metrics.Seed
+	case ".csv":
+		records, err = readReplayCSV(f)
+		if err != nil {
+			return nil, fmt.Errorf("replay CSV: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("desteklenmeyen replay dosyası: %s", filepath.Base(path))
+	}
+	if len(records) == 0 {
+		return nil, errors.New("replay dosyasında hit kaydı yok")
+	}
+	return newReplayPlan(filepath.Base(path), seed, records), nil
+}
+
+// newReplayPlan kayıtları başlangıç zamanına göre sıralayıp offset'lere çevirir.
+// Kayıt zamanı ziyaretin bitişidir; başlangıç = bitiş - yanıt süresi.
+func newReplayPlan(source string, seed int64, records []reporter.HitRecord) *ReplayPlan {
+	type started struct {
+		at  time.Time
+		rec reporter.HitRecord
+	}
+	list := make([]started, 0, len(records))
+	for _, r := range records {
+		if r.URL == "" {
+			continue
+		}
+		list = append(list, started{r.Timestamp.Add(-time.Duration(r.ResponseTime) * time.Millisecond), r})
+	}
+	sort.SliceStable(list, func(i, j int) bool { return list[i].at.Before(list[j].at) })
+
+	p := &ReplayPlan{Source: source, Seed: seed}
+	for _, s := range list {
+		p.Hits = append(p.Hits, ReplayHit{
+			Offset:    s.at.Sub(list[0].at),
+			URL:       s.rec.URL,
+			UserAgent: s.rec.UserAgent,
+		})
+	}
+	return p
+}
+
+func readReplayCSV(r io.Reader) ([]reporter.HitRecord, error) {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) < 2 {
+		return nil, nil
+	}
+	col := make(map[string]int)
+	for i, h := range rows[0] {
+		col[h] = i
+	}
+	for _, name := range []string{"timestamp", "url"} {
+		if _, ok := col[name]; !ok {
+			return nil, fmt.Errorf("CSV başlığında %q kolonu yok", name)
+		}
+	}
+	get := func(row []string, name string) string {
+		if i, ok := col[name]; ok && i < len(row) {
+			return row[i]
+		}
+		return ""
+	}
+
+	var out []reporter.HitRecord
+	for _, row := range rows[1:] {
+		ts, err := time.Parse(time.RFC3339, get(row, "timestamp"))
+		if err != nil {
+			continue
+		}
+		rt, _ := strconv.ParseInt(get(row, "response_time_ms"), 10, 64)
+		out = append(out, reporter.HitRecord{
+			Timestamp:    ts,
+			URL:          get(row, "url"),
+			ResponseTime: rt,
+			UserAgent:    get(row, "user_agent"),
+		})
+	}
+	return out, nil
+}
+
+// Replay önceki çalıştırmanın ziyaretlerini aynı sıra, zamanlama ve user agent ile tekrarlar.
+// Eşzamanlılık MaxConcurrentVisits ile sınırlanır; slot beklenirse ziyaret gecikir ama sıra korunur.
+func (s *Simulator) Replay(ctx context.Context, plan *ReplayPlan) error {
+	if s.hitVisitor == nil {
+		s.finish()
+		return errors.New("replay public proxy modunda desteklenmiyor")
+	}
+	workers := s.cfg.MaxConcurrentVisits
+	if workers <= 0 {
+		workers = 10
+	}
+	if workers > 50 {
+		workers = 50
+	}
+	s.reporter.LogT(i18n.MsgReplayStart, plan.Source, len(plan.Hits), plan.Duration().Round(time.Second))
+	if plan.Seed != 0 {
+		s.reporter.LogT(i18n.MsgReplaySeed, plan.Seed)
+	}
+
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	start := time.Now()
+	for i, hit := range plan.Hits {
+		if wait := time.Until(start.Add(hit.Offset)); wait > 0 {
+			select {
+			case <-ctx.Done():
+			case <-time.After(wait):
+			}
+		}
+		select {
+		case <-ctx.Done():
+			s.reporter.LogT(i18n.MsgCancel)
+			wg.Wait()
+			s.finish()
+			return ctx.Err()
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(h ReplayHit) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			visitCtx, cancel := context.WithTimeout(ctx, 60*time.Second)
+			defer cancel()
+			if err := s.hitVisitor.VisitURLAs(visitCtx, h.URL, h.UserAgent); err != nil {
+				s.visitErrAgg.add(s.reporter, h.URL, err)
+			}
+		}(hit)
+
+		if n := i + 1; n%10 == 0 {
+			s.reporter.LogT(i18n.MsgReplayProgress, n, len(plan.Hits))
+		}
+	}
+	wg.Wait()
+	s.finish()
+	return nil
+}
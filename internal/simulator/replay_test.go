@@ -0,0 +1,68 @@
+package simulator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadReplayCSV(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "vgbot_hits_test.csv")
+	data := "timestamp,url,status_code,response_time_ms,user_agent,error\n" +
+		"2024-01-01T10:00:05Z,https://example.com/b,200,1000,UA-B,-\n" +
+		"2024-01-01T10:00:02Z,https://example.com/a,200,2000,UA-A,-\n" +
+		"2024-01-01T10:00:30Z,https://example.com/c,0,0,UA-C,timeout\n"
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := LoadReplay(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Hits) != 3 {
+		t.Fatalf("got %d hits, want 3", len(plan.Hits))
+	}
+	// Başlangıç zamanları: a=10:00:00, b=10:00:04, c=10:00:30
+	want := []struct {
+		url    string
+		offset time.Duration
+		ua     string
+	}{
+		{"https://example.com/a", 0, "UA-A"},
+		{"https://example.com/b", 4 * time.Second, "UA-B"},
+		{"https://example.com/c", 30 * time.Second, "UA-C"},
+	}
+	for i, w := range want {
+		h := plan.Hits[i]
+		if h.URL != w.url || h.Offset != w.offset || h.UserAgent != w.ua {
+			t.Errorf("hit %d = %+v, want %+v", i, h, w)
+		}
+	}
+	if plan.Duration() != 30*time.Second {
+		t.Errorf("Duration = %v, want 30s", plan.Duration())
+	}
+}
+
+func TestLoadReplayJSONSeed(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "vgbot_report_test.json")
+	data := `{"records":[{"timestamp":"2024-01-01T10:00:00Z","url":"https://example.com/","user_agent":"UA"}],
+		"metrics":{"seed":1234}}`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	plan, err := LoadReplay(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.Seed != 1234 || len(plan.Hits) != 1 {
+		t.Errorf("plan = %+v, want seed 1234 with 1 hit", plan)
+	}
+
+	if _, err := LoadReplay(filepath.Join(dir, "report.txt")); err == nil {
+		t.Error("unsupported extension should fail")
+	}
+}
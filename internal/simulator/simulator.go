@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
+	"path/filepath"
 	"sort"
 	"strings"
 	"sync"
@@ -16,9 +17,16 @@ import (
 	"vgbot/internal/proxy"
 	"vgbot/internal/reporter"
 	"vgbot/pkg/analytics"
+	"vgbot/pkg/browserpool"
+	"vgbot/pkg/contentcheck"
 	"vgbot/pkg/delay"
+	"vgbot/pkg/errclass"
 	"vgbot/pkg/i18n"
+	"vgbot/pkg/marker"
+	"vgbot/pkg/network"
+	"vgbot/pkg/scheduler"
 	"vgbot/pkg/sitemap"
+	"vgbot/pkg/utils"
 )
 
 // visitorSlot public proxy modunda her slot: bir visitor + bir proxy; başarısız olunca visitor kapatılır, proxy havuzdan silinir
@@ -34,6 +42,23 @@ type Simulator struct {
 	pages        []string
 	homepageURL  string
 	visitErrAgg  *visitErrAgg
+	windows      *scheduler.WindowPlan // Aktif/blackout zaman pencereleri (nil = kısıt yok)
+	windowPaused int32                 // Pencere dışında beklerken 1 (log tekrarını önler)
+	seeder       *utils.Seeder // Çalıştırmanın seed kaynağı; eşzamanlı kampanyalar birbirini etkilemez
+	rngMu        sync.Mutex
+	rng          *rand.Rand // Run seed'inden türetilir (sayfa seçimi)
+	properties   *analytics.PropertySplit // Çoklu GA4 mülkü (nil = GtagID)
+	login        *browser.LoginScenario   // Girişli oturum senaryosu (nil = girişsiz)
+	headers      map[string]string        // Hedef host'a giden isteklere eklenen header'lar (basic auth, staging token)
+	marker       *marker.Marker           // Trafik işareti (nil = kapalı)
+	landing      *LandingMix              // Giriş sayfası ağırlıkları (nil = anasayfa/keşfedilen sayfalar)
+	outbound     []string                 // Outbound click partner domain'leri
+	experiment   *marker.Split            // A/B deney bölmesi (nil = kapalı)
+	checks       *contentcheck.Checker    // Yanıt içerik kontrolleri (nil = kapalı)
+	eventMap     *analytics.EventMapping  // Özel GA4 event şeması (nil = standart)
+	sgtm         *analytics.ServerEndpoint // Birinci taraf sGTM (nil = Google'a doğrudan)
+	backend      analytics.Tracker         // GA dışı analytics backend (nil = GA4)
+	keywords     browser.KeywordSource     // Keyword cluster'ları (nil = cfg.Keywords)
 }
 
 type visitorSlot struct {
@@ -61,6 +86,10 @@ func newVisitErrAgg() *visitErrAgg {
 }
 
 func errKey(err error) string {
+	// Sınıflandırılmış hatalar sınıf etiketiyle gruplanır (proxy_dead, banned, ...)
+	if class := errclass.Of(err); class != errclass.Other {
+		return class
+	}
 	s := err.Error()
 	if i := strings.Index(s, "net::"); i >= 0 {
 		rest := s[i+5:]
@@ -122,6 +151,13 @@ func New(cfg *config.Config, agentProvider crawler.AgentProvider, rep *reporter.
 		rep = reporter.New(cfg.OutputDir, cfg.ExportFormat, cfg.TargetDomain)
 	}
 	rep.LogT(i18n.MsgStarting)
+	seeder := newRunSeeder(cfg, rep)
+	rep.SetBranding(ReportBranding(cfg))
+	AttachHitLog(cfg, rep)
+	attachSessionLog(cfg, rep)
+	if err := AttachAssertions(cfg, rep); err != nil {
+		return nil, err
+	}
 
 	// SECURITY FIX: Proxy URL'yi doğru şekilde oluştur - auth bilgisi dahil
 	proxyURL := ""
@@ -134,14 +170,65 @@ func New(cfg *config.Config, agentProvider crawler.AgentProvider, rep *reporter.
 		}
 	}
 
+	headers, err := network.CampaignHeaders(cfg.BasicAuthUser, cfg.BasicAuthPass, cfg.ExtraHeaders)
+	if err != nil {
+		return nil, fmt.Errorf("extra_headers: %w", err)
+	}
 	c, err := crawler.New(cfg.TargetDomain, cfg.MaxPages, rep, cfg.ProxyURL, agentProvider)
 	if err != nil {
 		return nil, err
 	}
+	c.SetHeaders(headers)
+	mark, err := trafficMarker(cfg, rep)
+	if err != nil {
+		return nil, err
+	}
+	c.SetMarker(mark)
+	if err := enableHTTP3(cfg, c, rep); err != nil {
+		return nil, err
+	}
+	outbound, err := browser.NormalizeOutboundDomains(cfg.OutboundDomains)
+	if err != nil {
+		return nil, err
+	}
+	experiment, err := experimentSplit(cfg, rep)
+	if err != nil {
+		return nil, err
+	}
+	checks, err := newContentChecker(cfg, rep)
+	if err != nil {
+		return nil, fmt.Errorf("content_checks: %w", err)
+	}
 
+	eventMap, err := newEventMapping(cfg, rep)
+	if err != nil {
+		return nil, fmt.Errorf("ga4_event_mapping: %w", err)
+	}
+	sgtm, err := newServerEndpoint(cfg, rep)
+	if err != nil {
+		return nil, err
+	}
+	if err := logTrafficType(cfg, rep); err != nil {
+		return nil, err
+	}
+	backend, err := analyticsBackend(cfg, rep)
+	if err != nil {
+		return nil, err
+	}
 	analyticsMgr := &analytics.Manager{
 		GA4Enabled:       cfg.GtagID != "",
 		GA4MeasurementID: cfg.GtagID,
+		MPAPISecret:      cfg.GA4APISecret,
+		Mapping:          eventMap,
+		Server:           sgtm,
+		TrafficType:      cfg.GA4TrafficType,
+		Backend:          backend,
+		Seeder:           seeder,
+	}
+	properties := newPropertySplit(cfg, rep, seeder)
+	login, err := newLoginScenario(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("giriş senaryosu: %w", err)
 	}
 
 	var hitVisitor *browser.HitVisitor
@@ -156,16 +243,59 @@ func New(cfg *config.Config, agentProvider crawler.AgentProvider, rep *reporter.
 			ScrollStrategy:    cfg.ScrollStrategy,
 			SendScrollEvent:   cfg.SendScrollEvent,
 			AnalyticsManager:  analyticsMgr,
+			Properties:        properties,
 			Keywords:          cfg.Keywords,
 			// Yeni alanlar
 			DeviceType:        cfg.DeviceType,
 			DeviceBrands:      cfg.DeviceBrands,
 			ReferrerKeyword:   cfg.ReferrerKeyword,
 			ReferrerEnabled:   cfg.ReferrerEnabled,
+			CacheDir:          httpCacheDir(cfg),
+			ReturningRate:     cfg.ReturningVisitorRate,
+			MaxCacheProfiles:  cfg.MaxBrowserProfiles,
+			CacheProfileDays:  cfg.ReturningVisitorDays,
+			Login:             login,
+			ExtraHeaders:      headers,
+			Marker:            mark,
+			OutboundDomains:   outbound,
+			OutboundRate:      cfg.OutboundClickRate,
+			Experiment:        experiment,
+			Pool:              browserPoolConfig(cfg, rep),
+			ContentChecks:     checks,
+			Seeder:            seeder,
 		})
 		if errHv != nil {
 			return nil, errHv
 		}
+	} else if cfg.BrowserPoolEnabled {
+		rep.LogT(i18n.MsgBrowserPoolSkip)
+	}
+
+	windows, errWin := scheduler.NewWindowPlan(cfg.ActiveWindows, cfg.BlackoutWindows)
+	if errWin != nil {
+		rep.LogT(i18n.MsgWindowInvalid, errWin)
+		windows = nil
+	}
+
+	if livePool != nil {
+		limits := proxy.ProxyLimits{
+			MaxHitsPerHour: cfg.ProxyMaxHitsPerHour,
+			CooldownAfter:  cfg.ProxyCooldownAfter,
+			Cooldown:       time.Duration(cfg.ProxyCooldownMinutes) * time.Minute,
+		}
+		livePool.SetLimits(limits)
+		if limits.Enabled() {
+			rep.LogT(i18n.MsgProxyLimits, cfg.ProxyMaxHitsPerHour, cfg.ProxyCooldownAfter, cfg.ProxyCooldownMinutes)
+		}
+		health := proxy.HealthPolicy{
+			MinScore:       float64(cfg.ProxyMinHealthScore),
+			Quarantine:     time.Duration(cfg.ProxyQuarantineMinutes) * time.Minute,
+			BlacklistAfter: cfg.ProxyBlacklistAfter,
+		}
+		livePool.SetHealthPolicy(health)
+		if health.Enabled() {
+			rep.LogT(i18n.MsgProxyHealth, cfg.ProxyMinHealthScore, cfg.ProxyQuarantineMinutes, cfg.ProxyBlacklistAfter)
+		}
 	}
 
 	return &Simulator{
@@ -177,9 +307,75 @@ func New(cfg *config.Config, agentProvider crawler.AgentProvider, rep *reporter.
 		reporter:      rep,
 		pages:         nil,
 		visitErrAgg:   newVisitErrAgg(),
+		windows:       windows,
+		seeder:        seeder,
+		rng:           seeder.NewRand(),
+		properties:    properties,
+		login:         login,
+		headers:       headers,
+		marker:        mark,
+		outbound:      outbound,
+		experiment:    experiment,
+		checks:        checks,
+		eventMap:      eventMap,
+		sgtm:          sgtm,
+		backend:       backend,
 	}, nil
 }
 
+// Seeder çalıştırmanın seed kaynağını döner; simülatörle birlikte kullanılan bileşenler aynı seed'i izler
+func (s *Simulator) Seeder() *utils.Seeder {
+	return s.seeder
+}
+
+// SetKeywordSource arama referrer kelimelerini cfg.Keywords yerine keyword cluster yöneticisinden çeker.
+// Run'dan önce çağrılmalı; kaynakta cluster yoksa cfg.Keywords kullanılmaya devam eder.
+func (s *Simulator) SetKeywordSource(src browser.KeywordSource) {
+	s.keywords = src
+	if s.hitVisitor != nil {
+		s.hitVisitor.SetKeywordSource(src)
+	}
+}
+
+// windowOpen now anı zaman penceresi içinde mi; durum değişince bir kez loglar
+func (s *Simulator) windowOpen(now time.Time) bool {
+	if s.windows.Allowed(now) {
+		if atomic.CompareAndSwapInt32(&s.windowPaused, 1, 0) {
+			s.reporter.LogT(i18n.MsgWindowResumed)
+		}
+		return true
+	}
+	if atomic.CompareAndSwapInt32(&s.windowPaused, 0, 1) {
+		next := s.windows.NextAllowed(now)
+		s.reporter.LogT(i18n.MsgWindowPaused, next.Format("2006-01-02 15:04"))
+	}
+	return false
+}
+
+// waitForWindow pencere açılana kadar bekler; ctx iptal edilirse veya deadline geçerse false döner
+func (s *Simulator) waitForWindow(ctx context.Context, deadline time.Time) bool {
+	for {
+		now := time.Now()
+		if s.windowOpen(now) {
+			return true
+		}
+		next := s.windows.NextAllowed(now)
+		if next.IsZero() || next.After(deadline) {
+			next = deadline
+		}
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return false
+		case <-timer.C:
+		}
+		if !time.Now().Before(deadline) {
+			return false
+		}
+	}
+}
+
 // Run simülasyonu başlatır
 func (s *Simulator) Run(ctx context.Context) error {
 	workers := s.cfg.MaxConcurrentVisits
@@ -197,6 +393,9 @@ func (s *Simulator) Run(ctx context.Context) error {
 	}
 	s.reporter.LogT(i18n.MsgTarget,
 		s.cfg.TargetDomain, s.cfg.MaxPages, s.cfg.DurationMinutes, hpm, workers)
+	for _, line := range s.windows.Describe() {
+		s.reporter.LogT(i18n.MsgWindowPlan, line)
+	}
 
 	// 1. Sayfa keşfi (ve isteğe bağlı sitemap)
 	baseURL := s.cfg.TargetDomain
@@ -205,13 +404,24 @@ func (s *Simulator) Run(ctx context.Context) error {
 	}
 	baseURL = strings.TrimSuffix(baseURL, "/")
 	s.homepageURL = baseURL
+	landing, err := ParseLandingMix(LandingEntries(s.cfg.LandingPages, s.cfg.URLWeights), baseURL)
+	if err != nil {
+		return err
+	}
+	if landing != nil {
+		s.landing = landing
+		s.reporter.LogT(i18n.MsgLandingMix, strings.Join(landing.Describe(), ", "))
+	}
 
 	s.reporter.LogT(i18n.MsgDiscovery)
 	var pages []string
 	if s.cfg.UseSitemap {
-		sitemapURLs, errSitemap := sitemap.Fetch(baseURL, nil)
+		sitemapURLs, cached, errSitemap := sitemapCache(s.cfg).Fetch(baseURL, sitemap.NewClient(baseURL, s.headers))
 		if errSitemap == nil && len(sitemapURLs) > 0 {
 			pages = sitemapURLs
+			if cached {
+				s.reporter.LogT(i18n.MsgSitemapCached, s.cfg.SitemapCacheHours)
+			}
 			weight := s.cfg.SitemapHomepageWeight
 			if weight <= 0 {
 				weight = 60
@@ -234,12 +444,30 @@ func (s *Simulator) Run(ctx context.Context) error {
 	if len(s.pages) == 0 {
 		s.pages = []string{s.homepageURL}
 	}
+	StartWarmup(s.cfg, s.reporter)
+
+	// Closed-loop: HPM yerine sabit eşzamanlılık (public proxy havuzunda slot/proxy yönetimi HPM döngüsüne bağlı)
+	if s.cfg.LoadMode == "closed" {
+		if s.livePool == nil {
+			return s.runClosedLoop(ctx, workers)
+		}
+		s.reporter.LogT(i18n.MsgClosedLoopPool)
+	}
 
 	// 2. HPM sınırı: token bucket (başta workers kadar burst, sonra dakikada hpm refill)
+	stages, err := LoadStages(s.cfg.LoadProfile, s.cfg.LoadStages, s.cfg.Duration, hpm)
+	if err != nil {
+		return err
+	}
 	tb := delay.NewTokenBucket(ctx, hpm, workers)
 	defer tb.Stop()
 
 	deadline := time.Now().Add(s.cfg.Duration)
+	if len(stages) > 0 {
+		// Yük profili: aşamalar HPM'i değiştirir, aşama başına sonuçlar rapora ve metrik zaman çizelgesine yazılır
+		s.reporter.LogT(i18n.MsgLoadProfile, describeStages(stages, "HPM"))
+		go s.runLoadStages(ctx, tb, stages, deadline)
+	}
 	var hitCount int64
 	var wg sync.WaitGroup
 
@@ -273,6 +501,10 @@ func (s *Simulator) Run(ctx context.Context) error {
 					s.finish()
 					return nil
 				}
+				// Blackout / aktif pencere dışındaysa yeni ziyaret başlatma
+				if !s.windowOpen(time.Now()) {
+					continue
+				}
 				// Boşta slot varsa yeni ziyaret başlat
 				select {
 				case <-slotFreed:
@@ -324,9 +556,19 @@ func (s *Simulator) Run(ctx context.Context) error {
 	analyticsMgr := &analytics.Manager{
 		GA4Enabled:       s.cfg.GtagID != "",
 		GA4MeasurementID: s.cfg.GtagID,
+		MPAPISecret:      s.cfg.GA4APISecret,
+		Mapping:          s.eventMap,
+		Server:           s.sgtm,
+		TrafficType:      s.cfg.GA4TrafficType,
+		Backend:          s.backend,
+		Seeder:           s.seeder,
 	}
 
+	var limitLogAt int64 // MsgProxyAllLimited son log zamanı (unix)
 	startVisitPublic := func() {
+		if !s.waitForWindow(ctx, deadline) {
+			return
+		}
 		if err := tb.Take(ctx); err != nil {
 			return
 		}
@@ -342,6 +584,13 @@ func (s *Simulator) Run(ctx context.Context) error {
 			if hv == nil {
 				pc = s.livePool.GetNext()
 				if pc == nil {
+					// Havuz boş değilse tüm proxy'ler sınırda/dinlenmede: dakikada bir bildir
+					if n := s.livePool.Count(); n > 0 {
+						now := time.Now().Unix()
+						if last := atomic.LoadInt64(&limitLogAt); now-last >= 60 && atomic.CompareAndSwapInt64(&limitLogAt, last, now) {
+							s.reporter.LogT(i18n.MsgProxyAllLimited, n)
+						}
+					}
 					slot.mu.Unlock()
 					slotPool <- idx
 					slotFreed <- struct{}{}
@@ -357,12 +606,26 @@ func (s *Simulator) Run(ctx context.Context) error {
 					ScrollStrategy:    s.cfg.ScrollStrategy,
 					SendScrollEvent:   s.cfg.SendScrollEvent,
 					AnalyticsManager:  analyticsMgr,
+					Properties:        s.properties,
 					Keywords:          s.cfg.Keywords,
+					KeywordSource:     s.keywords,
 					// Yeni alanlar
 					DeviceType:        s.cfg.DeviceType,
 					DeviceBrands:      s.cfg.DeviceBrands,
 					ReferrerKeyword:   s.cfg.ReferrerKeyword,
 					ReferrerEnabled:   s.cfg.ReferrerEnabled,
+					CacheDir:          httpCacheDir(s.cfg),
+					ReturningRate:     s.cfg.ReturningVisitorRate,
+					MaxCacheProfiles:  s.cfg.MaxBrowserProfiles,
+					CacheProfileDays:  s.cfg.ReturningVisitorDays,
+					Login:             s.login,
+					ExtraHeaders:      s.headers,
+					Marker:            s.marker,
+					OutboundDomains:   s.outbound,
+					OutboundRate:      s.cfg.OutboundClickRate,
+					Experiment:        s.experiment,
+					ContentChecks:     s.checks,
+					Seeder:            s.seeder,
 				})
 				if errHv != nil {
 					slot.mu.Unlock()
@@ -373,6 +636,16 @@ func (s *Simulator) Run(ctx context.Context) error {
 				slot.visitor = hv
 				slot.proxy = pc
 			}
+			// Proxy kullanım sınırı: saatlik sınır dolmuş veya dinlenme gerekiyorsa slot proxy değiştirir
+			if hv != nil && !s.livePool.Use(pc) {
+				hv.Close()
+				slot.visitor = nil
+				slot.proxy = nil
+				slot.mu.Unlock()
+				slotPool <- idx
+				slotFreed <- struct{}{}
+				return
+			}
 			slot.mu.Unlock()
 			
 			// Nil pointer kontrolü - visitor nil ise devam etme
@@ -393,10 +666,11 @@ func (s *Simulator) Run(ctx context.Context) error {
 					return
 				}
 				
+				visitStart := time.Now()
 				err := visitor.VisitURL(ctx, url)
+				s.recordProxyHealth(proxyCfg, err, time.Since(visitStart))
 				if err != nil {
 					s.visitErrAgg.add(s.reporter, url, err)
-					s.livePool.Remove(proxyCfg)
 					visitor.Close()
 					slots[slotIdx].mu.Lock()
 					slots[slotIdx].visitor = nil
@@ -456,18 +730,315 @@ func (s *Simulator) Run(ctx context.Context) error {
 }
 
 func (s *Simulator) pickPage() string {
-	if len(s.pages) == 0 {
-		return s.homepageURL
-	}
 	weight := s.cfg.SitemapHomepageWeight
 	if weight <= 0 {
 		weight = 60
 	}
+	s.rngMu.Lock()
+	defer s.rngMu.Unlock()
+	// 404 denemesi: NotFoundRate% ziyaret var olmayan bir URL'ye gider
+	if s.cfg.NotFoundRate > 0 && s.homepageURL != "" && s.rng.Intn(100) < s.cfg.NotFoundRate {
+		return notFoundURL(s.homepageURL, s.rng)
+	}
+	if s.landing != nil {
+		return s.landing.pick(s.rng)
+	}
+	if len(s.pages) == 0 {
+		return s.homepageURL
+	}
 	// Anasayfa yoğunluğu: weight% anasayfa, (100-weight)% sitemap/diğer sayfalar
-	if s.homepageURL != "" && rand.Intn(100) < weight {
+	if s.homepageURL != "" && s.rng.Intn(100) < weight {
 		return s.homepageURL
 	}
-	return s.pages[rand.Intn(len(s.pages))]
+	return s.pages[s.rng.Intn(len(s.pages))]
+}
+
+// newPropertySplit config'teki GA4 mülklerinden split oluşturur ve dağılımı loglar (mülk yoksa nil)
+func newPropertySplit(cfg *config.Config, rep *reporter.Reporter, seeder *utils.Seeder) *analytics.PropertySplit {
+	if len(cfg.GA4Properties) == 0 {
+		return nil
+	}
+	props := make([]analytics.Property, 0, len(cfg.GA4Properties))
+	for _, p := range cfg.GA4Properties {
+		props = append(props, analytics.Property{
+			MeasurementID: p.MeasurementID,
+			APISecret:     p.APISecret,
+			Label:         p.Label,
+			Share:         p.Share,
+		})
+	}
+	split := analytics.NewPropertySplit(props, seeder)
+	rep.LogT(i18n.MsgGA4Split, strings.Join(split.Describe(), ", "))
+	return split
+}
+
+// EventMapping config'teki GA4 event eşlemesini doğrular ve oluşturur (tanımlı değilse nil)
+func EventMapping(m config.GA4EventMapping) (*analytics.EventMapping, error) {
+	return analytics.NewEventMapping(m.Rename, m.DefaultParams, m.EventParams)
+}
+
+// newEventMapping config'teki event eşlemesini oluşturur ve loglar (tanımlı değilse nil)
+func newEventMapping(cfg *config.Config, rep *reporter.Reporter) (*analytics.EventMapping, error) {
+	m, err := EventMapping(cfg.GA4EventMapping)
+	if err != nil || m == nil {
+		return nil, err
+	}
+	rep.LogT(i18n.MsgGA4EventMapping, m.Describe())
+	return m, nil
+}
+
+// newServerEndpoint config'teki birinci taraf sGTM adresini oluşturur ve loglar (ayarlı değilse nil)
+func newServerEndpoint(cfg *config.Config, rep *reporter.Reporter) (*analytics.ServerEndpoint, error) {
+	e, err := analytics.NewServerEndpoint(cfg.GA4ServerURL, cfg.GA4ServerScriptPath, cfg.GA4ServerCollectPath, cfg.GA4ServerHeaders)
+	if err != nil || e == nil {
+		return nil, err
+	}
+	rep.LogT(i18n.MsgGA4ServerEndpoint, e.BaseURL())
+	return e, nil
+}
+
+// logTrafficType traffic_type etiketini doğrular ve rapora yazar (kapalıysa bir şey yapmaz)
+func logTrafficType(cfg *config.Config, rep *reporter.Reporter) error {
+	if cfg.GA4TrafficType == "" {
+		return nil
+	}
+	if err := analytics.ValidateTrafficType(cfg.GA4TrafficType); err != nil {
+		return err
+	}
+	rep.LogT(i18n.MsgGA4TrafficType, cfg.GA4TrafficType)
+	return nil
+}
+
+// analyticsBackend config'teki GA dışı analytics backend'ini oluşturur ve loglar (ga4 ise nil)
+func analyticsBackend(cfg *config.Config, rep *reporter.Reporter) (analytics.Tracker, error) {
+	t, err := analytics.NewTracker(analytics.BackendConfig{
+		Backend:         cfg.AnalyticsBackend,
+		MatomoURL:       cfg.MatomoURL,
+		MatomoSiteID:    cfg.MatomoSiteID,
+		PlausibleURL:    cfg.PlausibleURL,
+		PlausibleDomain: cfg.PlausibleDomain,
+	}, cfg.TargetDomain)
+	if err != nil || t == nil {
+		return nil, err
+	}
+	rep.LogT(i18n.MsgAnalyticsBackend, t.ID())
+	return t, nil
+}
+
+// sitemapCache config'e göre sitemap URL önbelleğini döner (sitemap_cache_hours < 0 ise kapalı)
+func sitemapCache(cfg *config.Config) *sitemap.Cache {
+	return &sitemap.Cache{Dir: cfg.SitemapCacheDir, TTL: time.Duration(cfg.SitemapCacheHours) * time.Hour}
+}
+
+// httpCacheDir returning visitor veya girişli oturum açıksa profil dizinlerinin (HTTP önbelleği, giriş çerezleri)
+// kökünü döner ("" = kapalı)
+func httpCacheDir(cfg *config.Config) string {
+	if !(cfg.EnableReturningVisitor || cfg.LoginEnabled) || cfg.BrowserProfilePath == "" {
+		return ""
+	}
+	return filepath.Join(cfg.BrowserProfilePath, browser.HTTPCacheDirName)
+}
+
+// browserPoolConfig browser_pool_enabled açıksa sıcak Chrome havuzu ayarlarını döner (kapalıysa nil).
+// Önbellek profilleriyle birlikte kullanılamaz: her profil kendi disk önbelleğiyle ayrı süreç ister.
+func browserPoolConfig(cfg *config.Config, rep *reporter.Reporter) *browserpool.Config {
+	if !cfg.BrowserPoolEnabled {
+		return nil
+	}
+	if httpCacheDir(cfg) != "" {
+		rep.LogT(i18n.MsgBrowserPoolSkip)
+		return nil
+	}
+	pc := &browserpool.Config{
+		Min:         cfg.BrowserPoolMin,
+		Max:         cfg.BrowserPoolMax,
+		AutoScale:   cfg.EnableAutoScaling,
+		MaxSessions: cfg.BrowserMaxSessions,
+		MaxAge:      time.Duration(cfg.BrowserMaxAge) * time.Minute,
+	}
+	lo := pc.Min
+	if !pc.AutoScale {
+		lo = pc.Max
+	}
+	rep.LogT(i18n.MsgBrowserPool, lo, pc.Max, pc.AutoScale, pc.MaxSessions, cfg.BrowserMaxAge)
+	return pc
+}
+
+// trafficMarker config'teki trafik işaretini çözer ve rapora yazar (kapalıysa nil)
+func trafficMarker(cfg *config.Config, rep *reporter.Reporter) (*marker.Marker, error) {
+	m, err := marker.Parse(cfg.TrafficMarker, cfg.TrafficMarkerMode)
+	if err != nil || m == nil {
+		return nil, err
+	}
+	rep.SetTrafficMarker(m.String())
+	rep.LogT(i18n.MsgTrafficMarker, m.String())
+	return m, nil
+}
+
+// experimentSplit config'teki A/B deney bölmesini çözer ve loglar (kapalıysa nil)
+func experimentSplit(cfg *config.Config, rep *reporter.Reporter) (*marker.Split, error) {
+	split, err := marker.ParseSplit(cfg.ExperimentParam, cfg.ExperimentMode, cfg.ExperimentVariants)
+	if err != nil || split == nil {
+		return nil, err
+	}
+	rep.LogT(i18n.MsgExperimentSplit, split.Describe())
+	return split, nil
+}
+
+// defaultContentCheckRate content_check_rate verilmediğinde kontrol edilen ziyaret yüzdesi
+const defaultContentCheckRate = 10
+
+// ContentChecker config'teki içerik kontrollerini doğrular ve derler (tanımlı değilse nil)
+func ContentChecker(checks []config.ContentCheck, rate int) (*contentcheck.Checker, error) {
+	if rate == 0 {
+		rate = defaultContentCheckRate
+	}
+	rules := make([]contentcheck.Rule, len(checks))
+	for i, c := range checks {
+		rules[i] = contentcheck.Rule{
+			URL:       c.URL,
+			Status:    c.Status,
+			Contains:  c.Contains,
+			Regex:     c.Regex,
+			JSONPath:  c.JSONPath,
+			JSONValue: c.JSONValue,
+		}
+	}
+	return contentcheck.New(rules, rate)
+}
+
+// newContentChecker içerik kontrollerini oluşturur ve loglar (tanımlı değilse nil)
+func newContentChecker(cfg *config.Config, rep *reporter.Reporter) (*contentcheck.Checker, error) {
+	c, err := ContentChecker(cfg.ContentChecks, cfg.ContentCheckRate)
+	if err != nil || c == nil {
+		return nil, err
+	}
+	rep.LogT(i18n.MsgContentChecks, c.Rate(), c.Describe())
+	return c, nil
+}
+
+// ReportBranding config'teki HTML rapor markalamasını döner
+func ReportBranding(cfg *config.Config) reporter.Branding {
+	return reporter.Branding{
+		Title:           cfg.ReportTitle,
+		Logo:            cfg.ReportLogo,
+		PrimaryColor:    cfg.ReportPrimaryColor,
+		BackgroundColor: cfg.ReportBackgroundColor,
+		Footer:          cfg.ReportFooter,
+	}
+}
+
+// AttachHitLog cfg'de hit_log_jsonl açıksa hit'leri çalıştırma boyunca JSON Lines dosyasına yazdırır.
+// Dosya açılamazsa çalıştırma durmaz, yalnızca loglanır.
+func AttachHitLog(cfg *config.Config, rep *reporter.Reporter) {
+	if !cfg.HitLogJSONL {
+		return
+	}
+	path, err := rep.OpenHitLog()
+	if err != nil {
+		rep.LogT(i18n.MsgHitLogError, err)
+		return
+	}
+	rep.LogT(i18n.MsgHitLogEnabled, path)
+}
+
+// enableHTTP3 cfg'de enable_http3 açıksa crawler isteklerini HTTP/3 (QUIC) ile denetir
+func enableHTTP3(cfg *config.Config, c *crawler.Crawler, rep *reporter.Reporter) error {
+	if !cfg.EnableHTTP3 {
+		return nil
+	}
+	ok, err := c.EnableHTTP3()
+	if err != nil {
+		return fmt.Errorf("enable_http3: %w", err)
+	}
+	if ok {
+		rep.LogT(i18n.MsgHTTP3Enabled)
+	} else {
+		rep.LogT(i18n.MsgHTTP3Proxy)
+	}
+	return nil
+}
+
+// recordProxyHealth ziyaret sonucunu proxy'nin sağlık skoruna işler. Proxy kaynaklı hatalarda (ölü/engellenmiş)
+// proxy rotasyondan çıkar: sağlık politikası açıksa karantinaya, kapalıysa havuzdan silinir. Timeout ve ağ
+// hataları skoru düşürür; içerik/analytics hataları proxy'ye yazılmaz.
+func (s *Simulator) recordProxyHealth(pc *proxy.ProxyConfig, err error, latency time.Duration) {
+	var event proxy.HealthEvent
+	switch {
+	case errclass.ProxyFault(err):
+		event = s.livePool.Evict(pc)
+	case err == nil:
+		event = s.livePool.RecordResult(pc, true, latency)
+	case errclass.Retryable(err):
+		event = s.livePool.RecordResult(pc, false, latency)
+	}
+	switch event {
+	case proxy.HealthQuarantined:
+		s.reporter.LogT(i18n.MsgProxyQuarantined, pc.Key(), s.cfg.ProxyQuarantineMinutes)
+	case proxy.HealthBlacklisted:
+		s.reporter.LogT(i18n.MsgProxyBlacklisted, pc.Key())
+	}
+}
+
+// StartWarmup cfg'de warmup_seconds varsa hit fazının ilk saniyelerini istatistik ve SLA dışında tutar.
+// Hit fazı başlarken (sayfa keşfinden sonra) çağrılır; pencere çalıştırma süresini aşamaz.
+func StartWarmup(cfg *config.Config, rep *reporter.Reporter) {
+	d := time.Duration(cfg.WarmupSeconds) * time.Second
+	if d <= 0 {
+		return
+	}
+	if cfg.Duration > 0 && d > cfg.Duration {
+		d = cfg.Duration
+	}
+	rep.StartWarmup(d)
+	rep.LogT(i18n.MsgWarmup, d)
+}
+
+// AttachAssertions cfg'deki SLA koşullarını çözüp çalıştırma sonunda değerlendirilmek üzere reporter'a verir
+func AttachAssertions(cfg *config.Config, rep *reporter.Reporter) error {
+	assertions, err := reporter.ParseAssertions(cfg.Assertions)
+	if err != nil {
+		return err
+	}
+	if len(assertions) > 0 {
+		rep.SetAssertions(assertions)
+	}
+	return nil
+}
+
+// attachSessionLog cfg'de session_log_jsonl açıksa ziyaret adımlarını yapılandırılmış olay olarak dosyaya yazdırır
+func attachSessionLog(cfg *config.Config, rep *reporter.Reporter) {
+	if !cfg.SessionLogJSONL {
+		return
+	}
+	path, err := rep.OpenSessionLog()
+	if err != nil {
+		rep.LogT(i18n.MsgSessionLogError, err)
+		return
+	}
+	rep.LogT(i18n.MsgSessionLogEnabled, path)
+}
+
+// newRunSeeder çalıştırmanın seed kaynağını oluşturur ve seed'i rapora yazar (cfg.Seed 0 ise rastgele seçilir).
+// Kaynak simülatöre aittir; paket düzeyindeki seed'i değiştirmez.
+func newRunSeeder(cfg *config.Config, rep *reporter.Reporter) *utils.Seeder {
+	seeder := utils.NewSeeder(cfg.Seed)
+	rep.SetSeed(seeder.Seed())
+	rep.LogT(i18n.MsgRunSeed, seeder.Seed())
+	return seeder
+}
+
+// LivePool çalışmanın proxy havuzunu döner (local modda nil); eklenen proxy'ler çalışma sırasında kullanılır
+func (s *Simulator) LivePool() *proxy.LivePool {
+	return s.livePool
+}
+
+// BrowserPoolStats sıcak Chrome havuzunun durumu; havuz kapalıysa ok false
+func (s *Simulator) BrowserPoolStats() (browserpool.Stats, bool) {
+	if s.hitVisitor == nil {
+		return browserpool.Stats{}, false
+	}
+	return s.hitVisitor.PoolStats()
 }
 
 // Reporter reporter instance döner (log kanalı için)
@@ -476,6 +1047,7 @@ func (s *Simulator) Reporter() *reporter.Reporter {
 }
 
 func (s *Simulator) finish() {
+	s.endLoadLevel()
 	if s.hitVisitor != nil {
 		s.hitVisitor.Close()
 	}
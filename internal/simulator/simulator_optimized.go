@@ -12,10 +12,12 @@ import (
 	"time"
 
 	"github.com/chromedp/cdproto/emulation"
+	"github.com/chromedp/cdproto/fetch"
 	"github.com/chromedp/cdproto/network"
 	"github.com/chromedp/cdproto/page"
 	"github.com/chromedp/chromedp"
 
+	hitbrowser "vgbot/internal/browser"
 	"vgbot/internal/config"
 	"vgbot/internal/crawler"
 	"vgbot/internal/proxy"
@@ -26,13 +28,17 @@ import (
 	"vgbot/pkg/canvas"
 	"vgbot/pkg/delay"
 	"vgbot/pkg/engagement"
+	"vgbot/pkg/errclass"
 	"vgbot/pkg/fingerprint"
 	"vgbot/pkg/i18n"
+	"vgbot/pkg/marker"
 	"vgbot/pkg/mobile"
+	vgnetwork "vgbot/pkg/network"
 	"vgbot/pkg/referrer"
 	"vgbot/pkg/sitemap"
 	"vgbot/pkg/stealth"
 	"vgbot/pkg/useragent"
+	"vgbot/pkg/utils"
 )
 
 // OptimizedSimulator uses browser pool for better performance.
@@ -46,6 +52,17 @@ type OptimizedSimulator struct {
 	pages         []string
 	homepageURL   string
 	visitErrAgg   *visitErrAgg
+	seeder        *utils.Seeder // Çalıştırmanın seed kaynağı; eşzamanlı kampanyalar birbirini etkilemez
+	rngMu         sync.Mutex
+	rng           *rand.Rand
+	properties    *analytics.PropertySplit  // Çoklu GA4 mülkü (nil = GtagID)
+	headers       map[string]string         // Hedef host'a giden isteklere eklenen header'lar (basic auth, staging token)
+	marker        *marker.Marker            // Trafik işareti (nil = kapalı)
+	landing       *LandingMix               // Giriş sayfası ağırlıkları (nil = anasayfa/keşfedilen sayfalar)
+	outbound      []string                  // Outbound click partner domain'leri
+	experiment    *marker.Split             // A/B deney bölmesi (nil = kapalı)
+	eventMap      *analytics.EventMapping   // Özel GA4 event şeması (nil = standart)
+	sgtm          *analytics.ServerEndpoint // Birinci taraf sGTM (nil = Google'a doğrudan)
 }
 
 // NewOptimized creates an optimized simulator with browser pooling.
@@ -54,6 +71,13 @@ func NewOptimized(cfg *config.Config, agentProvider crawler.AgentProvider, rep *
 		rep = reporter.New(cfg.OutputDir, cfg.ExportFormat, cfg.TargetDomain)
 	}
 	rep.LogT(i18n.MsgStarting)
+	seeder := newRunSeeder(cfg, rep)
+	rep.SetBranding(ReportBranding(cfg))
+	AttachHitLog(cfg, rep)
+	attachSessionLog(cfg, rep)
+	if err := AttachAssertions(cfg, rep); err != nil {
+		return nil, err
+	}
 
 	poolConfig := browser.PoolConfig{
 		MaxInstances:        cfg.MaxConcurrentVisits,
@@ -72,10 +96,42 @@ func NewOptimized(cfg *config.Config, agentProvider crawler.AgentProvider, rep *
 		return nil, fmt.Errorf("failed to create browser pool: %w", err)
 	}
 
+	headers, err := vgnetwork.CampaignHeaders(cfg.BasicAuthUser, cfg.BasicAuthPass, cfg.ExtraHeaders)
+	if err != nil {
+		return nil, fmt.Errorf("extra_headers: %w", err)
+	}
 	c, err := crawler.New(cfg.TargetDomain, cfg.MaxPages, rep, cfg.ProxyURL, agentProvider)
 	if err != nil {
 		return nil, err
 	}
+	c.SetHeaders(headers)
+	mark, err := trafficMarker(cfg, rep)
+	if err != nil {
+		return nil, err
+	}
+	c.SetMarker(mark)
+	if err := enableHTTP3(cfg, c, rep); err != nil {
+		return nil, err
+	}
+	outbound, err := hitbrowser.NormalizeOutboundDomains(cfg.OutboundDomains)
+	if err != nil {
+		return nil, err
+	}
+	experiment, err := experimentSplit(cfg, rep)
+	if err != nil {
+		return nil, err
+	}
+	eventMap, err := newEventMapping(cfg, rep)
+	if err != nil {
+		return nil, fmt.Errorf("ga4_event_mapping: %w", err)
+	}
+	sgtm, err := newServerEndpoint(cfg, rep)
+	if err != nil {
+		return nil, err
+	}
+	if err := logTrafficType(cfg, rep); err != nil {
+		return nil, err
+	}
 
 	return &OptimizedSimulator{
 		cfg:           cfg,
@@ -85,6 +141,15 @@ func NewOptimized(cfg *config.Config, agentProvider crawler.AgentProvider, rep *
 		livePool:      livePool,
 		reporter:      rep,
 		visitErrAgg:   newVisitErrAgg(),
+		seeder:        seeder,
+		rng:           seeder.NewRand(),
+		properties:    newPropertySplit(cfg, rep, seeder),
+		headers:       headers,
+		marker:        mark,
+		outbound:      outbound,
+		experiment:    experiment,
+		eventMap:      eventMap,
+		sgtm:          sgtm,
 	}, nil
 }
 
@@ -113,13 +178,24 @@ func (s *OptimizedSimulator) Run(ctx context.Context) error {
 	}
 	baseURL = strings.TrimSuffix(baseURL, "/")
 	s.homepageURL = baseURL
+	landing, err := ParseLandingMix(LandingEntries(s.cfg.LandingPages, s.cfg.URLWeights), baseURL)
+	if err != nil {
+		return err
+	}
+	if landing != nil {
+		s.landing = landing
+		s.reporter.LogT(i18n.MsgLandingMix, strings.Join(landing.Describe(), ", "))
+	}
 
 	s.reporter.LogT(i18n.MsgDiscovery)
 	var pages []string
 	if s.cfg.UseSitemap {
-		sitemapURLs, errSitemap := sitemap.Fetch(baseURL, nil)
+		sitemapURLs, cached, errSitemap := sitemapCache(s.cfg).Fetch(baseURL, sitemap.NewClient(baseURL, s.headers))
 		if errSitemap == nil && len(sitemapURLs) > 0 {
 			pages = sitemapURLs
+			if cached {
+				s.reporter.LogT(i18n.MsgSitemapCached, s.cfg.SitemapCacheHours)
+			}
 			weight := s.cfg.SitemapHomepageWeight
 			if weight <= 0 {
 				weight = 60
@@ -142,6 +218,7 @@ func (s *OptimizedSimulator) Run(ctx context.Context) error {
 	if len(s.pages) == 0 {
 		s.pages = []string{s.homepageURL}
 	}
+	StartWarmup(s.cfg, s.reporter)
 
 	// Token bucket for rate limiting
 	tb := delay.NewTokenBucket(ctx, hpm, workers)
@@ -239,6 +316,7 @@ func (s *OptimizedSimulator) visitWithPooledBrowser(ctx context.Context, instanc
 	defer timeoutCancel()
 
 	start := time.Now()
+	sessionID := reporter.NewSessionID()
 
 	// UA ve fingerprint oluştur
 	var ua string
@@ -325,6 +403,15 @@ func (s *OptimizedSimulator) visitWithPooledBrowser(ctx context.Context, instanc
 			}
 		}
 	})
+	var meter hitbrowser.ResourceMeter
+	meter.Listen(tabCtx)
+	var beacons hitbrowser.BeaconMeter
+	beacons.Listen(tabCtx)
+	// Kampanya header'ları fetch yakalamasıyla yalnızca hedef host'a eklenir
+	injector := hitbrowser.NewHeaderInjector(urlStr, s.headers)
+	if injector != nil {
+		injector.Listen(tabCtx)
+	}
 
 	// Referrer oluştur
 	targetDomain := urlStr
@@ -335,6 +422,13 @@ func (s *OptimizedSimulator) visitWithPooledBrowser(ctx context.Context, instanc
 		targetDomain = targetDomain[:idx]
 	}
 
+	// Navigasyon URL'si trafik işaretini taşır; raporda işaretsiz URL kullanılır
+	navURL := s.marker.ApplyURL(urlStr)
+	s.rngMu.Lock()
+	variant, expMarker := s.experiment.Pick(s.rng.Intn)
+	s.rngMu.Unlock()
+	navURL = expMarker.ApplyURL(navURL)
+
 	var referrerURL string
 	if s.cfg.ReferrerEnabled && s.cfg.ReferrerKeyword != "" {
 		encodedKeyword := url.QueryEscape(s.cfg.ReferrerKeyword)
@@ -366,6 +460,22 @@ func (s *OptimizedSimulator) visitWithPooledBrowser(ctx context.Context, instanc
 		}),
 	}
 
+	if injector != nil {
+		navActions = append(navActions, fetch.Enable())
+	}
+	if s.marker.CookieValue() != "" {
+		navActions = append(navActions, hitbrowser.MarkerCookieAction(s.marker, navURL))
+	}
+	if variant != "" {
+		if expMarker.CookieValue() != "" {
+			navActions = append(navActions, hitbrowser.MarkerCookieAction(expMarker, navURL))
+		}
+		navActions = append(navActions, hitbrowser.ExperimentTagAction(expMarker.Name, variant))
+	}
+	if s.cfg.GA4TrafficType != "" {
+		navActions = append(navActions, hitbrowser.TrafficTypeAction(s.cfg.GA4TrafficType))
+	}
+
 	// Touch emülasyonu (mobil)
 	if isMobile {
 		navActions = append(navActions, chromedp.ActionFunc(func(ctx context.Context) error {
@@ -376,11 +486,11 @@ func (s *OptimizedSimulator) visitWithPooledBrowser(ctx context.Context, instanc
 	// Navigate with or without referrer
 	if referrerURL != "" {
 		navActions = append(navActions, chromedp.ActionFunc(func(ctx context.Context) error {
-			_, _, _, err := page.Navigate(urlStr).WithReferrer(referrerURL).Do(ctx)
+			_, _, _, err := page.Navigate(navURL).WithReferrer(referrerURL).Do(ctx)
 			return err
 		}))
 	} else {
-		navActions = append(navActions, chromedp.Navigate(urlStr))
+		navActions = append(navActions, chromedp.Navigate(navURL))
 	}
 
 	navActions = append(navActions,
@@ -390,40 +500,17 @@ func (s *OptimizedSimulator) visitWithPooledBrowser(ctx context.Context, instanc
 
 	navErr := chromedp.Run(tabCtx, navActions...)
 
-	// GA4 injection
-	if navErr == nil && s.cfg.GtagID != "" {
-		gtagID := s.cfg.GtagID
-		isValid := false
-		if len(gtagID) >= 10 && len(gtagID) <= 15 {
-			if strings.HasPrefix(gtagID, "G-") || strings.HasPrefix(gtagID, "GT-") {
-				suffix := gtagID[strings.Index(gtagID, "-")+1:]
-				isValid = true
-				for _, c := range suffix {
-					if !((c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')) {
-						isValid = false
-						break
-					}
-				}
-			}
+	// GA4 injection (çoklu mülk varsa ziyaret paya göre seçilen mülke gider)
+	gtagID, apiSecret := s.cfg.GtagID, s.cfg.GA4APISecret
+	if s.properties != nil {
+		prop := s.properties.Pick()
+		gtagID = prop.MeasurementID
+		if prop.APISecret != "" {
+			apiSecret = prop.APISecret
 		}
-		if !isValid && len(gtagID) >= 10 && len(gtagID) <= 20 && strings.HasPrefix(gtagID, "UA-") {
-			isValid = true
-			for _, c := range gtagID[3:] {
-				if !((c >= '0' && c <= '9') || c == '-') {
-					isValid = false
-					break
-				}
-			}
-		}
-		if isValid {
-			gtagScript := `(function(){
-				var s=document.createElement('script');s.async=true;
-				s.src='https://www.googletagmanager.com/gtag/js?id=` + gtagID + `';
-				document.head.appendChild(s);
-				window.dataLayer=window.dataLayer||[];function gtag(){dataLayer.push(arguments);}
-				gtag('js',new Date());
-				gtag('config','` + gtagID + `',{send_page_view:true});
-			})();`
+	}
+	if navErr == nil && gtagID != "" {
+		if gtagScript := hitbrowser.GtagInjectScript(gtagID, s.sgtm); gtagScript != "" {
 			_ = chromedp.Run(tabCtx, chromedp.Evaluate(gtagScript, nil))
 			_ = chromedp.Run(tabCtx, chromedp.Sleep(1000*time.Millisecond))
 		}
@@ -432,6 +519,12 @@ func (s *OptimizedSimulator) visitWithPooledBrowser(ctx context.Context, instanc
 	// Stealth scripts already injected via AddScriptToEvaluateOnNewDocument (runs before page load).
 	// Re-injection removed — redundant 14 CDP round-trips eliminated.
 
+	// Ziyarette gönderilen GA event'leri (BigQuery export için)
+	var events []string
+	if navErr == nil && gtagID != "" {
+		events = append(events, "page_view")
+	}
+
 	if navErr == nil {
 		// Canvas/WebGL/Audio fingerprint — single batched CDP call
 		if s.cfg.CanvasFingerprint {
@@ -450,15 +543,22 @@ func (s *OptimizedSimulator) visitWithPooledBrowser(ctx context.Context, instanc
 		})
 
 		// Scroll event (GA4)
-		if s.cfg.SendScrollEvent && s.cfg.GtagID != "" {
+		if s.cfg.SendScrollEvent && gtagID != "" {
 			analyticsMgr := &analytics.Manager{
 				GA4Enabled:       true,
-				GA4MeasurementID: s.cfg.GtagID,
+				GA4MeasurementID: gtagID,
+				MPAPISecret:      apiSecret,
+				Mapping:          s.eventMap,
+				Server:           s.sgtm,
+				TrafficType:      s.cfg.GA4TrafficType,
+				Seeder:           s.seeder,
 			}
-			_ = analyticsMgr.SendEvent(tabCtx, analytics.Event{
+			if analyticsMgr.SendEvent(tabCtx, analytics.Event{
 				Type: analytics.EventScroll, Category: "engagement",
 				Action: "scroll", Label: "75%", Value: 75,
-			})
+			}) == nil {
+				events = append(events, "scroll")
+			}
 		}
 
 		// Human behavior
@@ -467,6 +567,7 @@ func (s *OptimizedSimulator) visitWithPooledBrowser(ctx context.Context, instanc
 			mouseMoveProb = 0.0
 		}
 		hum := behavior.NewHumanBehavior(&behavior.BehaviorConfig{
+			Rand:                 s.seeder.NewRand(),
 			MinPageDuration:      1 * time.Second,
 			MaxPageDuration:      3 * time.Second,
 			ScrollProbability:    0.5,
@@ -474,16 +575,44 @@ func (s *OptimizedSimulator) visitWithPooledBrowser(ctx context.Context, instanc
 			ClickProbability:     0,
 		})
 		hum.SimulatePageVisit(tabCtx, 0)
+
+		// Oturum sonu outbound click (partner sitesine gidilmez, yalnızca event)
+		if gtagID != "" && len(s.outbound) > 0 {
+			s.rngMu.Lock()
+			roll := s.rng.Intn(100)
+			s.rngMu.Unlock()
+			if roll < s.cfg.OutboundClickRate {
+				mgr := &analytics.Manager{GA4Enabled: true, GA4MeasurementID: gtagID, MPAPISecret: apiSecret, Mapping: s.eventMap, Server: s.sgtm, TrafficType: s.cfg.GA4TrafficType, Seeder: s.seeder}
+				if href, err := hitbrowser.OutboundClick(tabCtx, mgr, s.outbound); err == nil && href != "" {
+					events = append(events, "click")
+				}
+			}
+		}
+	}
+
+	// Teslimat yakalanan GA4 collect isteklerine göre belirlenir
+	var delivery hitbrowser.BeaconSummary
+	var errClass string
+	if navErr == nil && gtagID != "" {
+		if delivery = beacons.Wait(tabCtx, gtagID, hitbrowser.BeaconWait); delivery.Delivered == 0 {
+			errClass = errclass.AnalyticsMissing
+			events = nil
+		}
 	}
 
 	elapsed := time.Since(start).Milliseconds()
 
+	requests, bytes := meter.Totals()
 	if navErr != nil {
 		s.reporter.Record(reporter.HitRecord{
 			Timestamp: time.Now(),
 			URL:       urlStr,
 			Error:     navErr.Error(),
 			UserAgent: ua,
+			SessionID: sessionID,
+			Requests:  requests,
+			Bytes:     bytes,
+			Variant:   variant,
 		})
 		return navErr
 	}
@@ -496,27 +625,46 @@ func (s *OptimizedSimulator) visitWithPooledBrowser(ctx context.Context, instanc
 	}
 
 	s.reporter.Record(reporter.HitRecord{
-		Timestamp:    time.Now(),
-		URL:          urlStr,
-		StatusCode:   statusCode,
-		ResponseTime: elapsed,
-		UserAgent:    ua,
+		Timestamp:        time.Now(),
+		URL:              urlStr,
+		StatusCode:       statusCode,
+		ResponseTime:     elapsed,
+		ErrorClass:       errClass,
+		UserAgent:        ua,
+		SessionID:        sessionID,
+		MeasurementID:    gtagID,
+		Events:           events,
+		Requests:         requests,
+		Bytes:            bytes,
+		Variant:          variant,
+		Beacons:          delivery.Beacons,
+		BeaconsDelivered: delivery.Delivered,
+		BeaconBytes:      delivery.Bytes,
 	})
 	return nil
 }
 
 func (s *OptimizedSimulator) pickPage() string {
-	if len(s.pages) == 0 {
-		return s.homepageURL
-	}
 	weight := s.cfg.SitemapHomepageWeight
 	if weight <= 0 {
 		weight = 60
 	}
-	if s.homepageURL != "" && rand.Intn(100) < weight {
+	s.rngMu.Lock()
+	defer s.rngMu.Unlock()
+	// 404 denemesi: NotFoundRate% ziyaret var olmayan bir URL'ye gider
+	if s.cfg.NotFoundRate > 0 && s.homepageURL != "" && s.rng.Intn(100) < s.cfg.NotFoundRate {
+		return notFoundURL(s.homepageURL, s.rng)
+	}
+	if s.landing != nil {
+		return s.landing.pick(s.rng)
+	}
+	if len(s.pages) == 0 {
+		return s.homepageURL
+	}
+	if s.homepageURL != "" && s.rng.Intn(100) < weight {
 		return s.homepageURL
 	}
-	return s.pages[rand.Intn(len(s.pages))]
+	return s.pages[s.rng.Intn(len(s.pages))]
 }
 
 // Reporter returns the reporter instance.
@@ -0,0 +1,124 @@
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"vgbot/internal/browser"
+	"vgbot/internal/config"
+	"vgbot/internal/crawler"
+	"vgbot/internal/reporter"
+	"vgbot/pkg/analytics"
+	"vgbot/pkg/network"
+	"vgbot/pkg/utils"
+)
+
+// TestVisit mevcut config ile tek bir tam enstrümanlı ziyaret yapar ve adım adım izini döner.
+// Ziyaret kendi geçici reporter'ını kullanır; çalışan simülasyonun metriklerine karışmaz.
+func TestVisit(ctx context.Context, cfg *config.Config, agentProvider crawler.AgentProvider) (*browser.VisitTrace, error) {
+	if strings.TrimSpace(cfg.TargetDomain) == "" {
+		return nil, fmt.Errorf("hedef domain boş")
+	}
+	rep := reporter.New(cfg.OutputDir, cfg.ExportFormat, cfg.TargetDomain)
+	defer rep.Close()
+	seeder := utils.NewSeeder(cfg.Seed)
+
+	proxyURL := ""
+	if cfg.ProxyEnabled {
+		if cfg.ProxyURL != "" {
+			proxyURL = cfg.ProxyURL
+		} else if cfg.ProxyBaseURL != "" {
+			proxyURL = cfg.ProxyBaseURL
+		}
+	}
+	login, err := newLoginScenario(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("giriş senaryosu: %w", err)
+	}
+	headers, err := network.CampaignHeaders(cfg.BasicAuthUser, cfg.BasicAuthPass, cfg.ExtraHeaders)
+	if err != nil {
+		return nil, fmt.Errorf("extra_headers: %w", err)
+	}
+	mark, err := trafficMarker(cfg, rep)
+	if err != nil {
+		return nil, err
+	}
+	outbound, err := browser.NormalizeOutboundDomains(cfg.OutboundDomains)
+	if err != nil {
+		return nil, err
+	}
+	eventMap, err := newEventMapping(cfg, rep)
+	if err != nil {
+		return nil, fmt.Errorf("ga4_event_mapping: %w", err)
+	}
+	sgtm, err := newServerEndpoint(cfg, rep)
+	if err != nil {
+		return nil, err
+	}
+	if err := logTrafficType(cfg, rep); err != nil {
+		return nil, err
+	}
+	backend, err := analyticsBackend(cfg, rep)
+	if err != nil {
+		return nil, err
+	}
+	experiment, err := experimentSplit(cfg, rep)
+	if err != nil {
+		return nil, err
+	}
+	checks, err := newContentChecker(cfg, rep)
+	if err != nil {
+		return nil, fmt.Errorf("content_checks: %w", err)
+	}
+	hv, err := browser.NewHitVisitor(agentProvider, rep, browser.HitVisitorConfig{
+		ProxyURL:          proxyURL,
+		ProxyUser:         cfg.ProxyUser,
+		ProxyPass:         cfg.ProxyPass,
+		GtagID:            cfg.GtagID,
+		CanvasFingerprint: cfg.CanvasFingerprint,
+		ScrollStrategy:    cfg.ScrollStrategy,
+		SendScrollEvent:   cfg.SendScrollEvent,
+		AnalyticsManager: &analytics.Manager{
+			GA4Enabled:       cfg.GtagID != "",
+			GA4MeasurementID: cfg.GtagID,
+			MPAPISecret:      cfg.GA4APISecret,
+			Mapping:          eventMap,
+			Server:           sgtm,
+			TrafficType:      cfg.GA4TrafficType,
+			Backend:          backend,
+			Seeder:           seeder,
+		},
+		Properties:       newPropertySplit(cfg, rep, seeder),
+		Keywords:         cfg.Keywords,
+		DeviceType:       cfg.DeviceType,
+		DeviceBrands:     cfg.DeviceBrands,
+		ReferrerKeyword:  cfg.ReferrerKeyword,
+		ReferrerEnabled:  cfg.ReferrerEnabled,
+		CacheDir:         httpCacheDir(cfg),
+		ReturningRate:    cfg.ReturningVisitorRate,
+		MaxCacheProfiles: cfg.MaxBrowserProfiles,
+		CacheProfileDays: cfg.ReturningVisitorDays,
+		Login:            login,
+		ExtraHeaders:     headers,
+		Marker:           mark,
+		OutboundDomains:  outbound,
+		OutboundRate:     cfg.OutboundClickRate,
+		Experiment:       experiment,
+		ContentChecks:    checks,
+		Seeder:           seeder,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer hv.Close()
+
+	trace := browser.NewVisitTrace()
+	target := cfg.TargetDomain
+	if !strings.HasPrefix(target, "http://") && !strings.HasPrefix(target, "https://") {
+		target = "https://" + target
+	}
+	// Ziyaret hatası izde yer alır; çağırana iz her durumda döner
+	_ = hv.VisitURL(browser.WithTrace(ctx, trace), target)
+	return trace, nil
+}
@@ -19,6 +19,8 @@ import (
 
 	"github.com/chromedp/cdproto/network"
 	"github.com/chromedp/chromedp"
+
+	"vgbot/pkg/utils"
 )
 
 // ============================================================================
@@ -34,22 +36,26 @@ type GA4Config struct {
 	SessionID       string // Session ID
 	EngagementTime  int64  // Engagement time in milliseconds
 	Debug           bool   // Debug mode
+	Server          *ServerEndpoint // Birinci taraf sGTM (nil = google-analytics.com)
+	Seeder          *utils.Seeder   // Çalıştırmanın seed kaynağı (nil = tekrarlanamaz)
 }
 
 // GA4Event GA4 event yapısı
 type GA4Event struct {
-	Name   string                 `json:"name"`
-	Params map[string]interface{} `json:"params"`
+	Name            string                 `json:"name"`
+	Params          map[string]interface{} `json:"params"`
+	TimestampMicros int64                  `json:"timestamp_micros,omitempty"` // Toplu gönderimde event'in gerçek zamanı
 }
 
 // GA4Payload GA4 Measurement Protocol payload
 type GA4Payload struct {
-	ClientID        string                 `json:"client_id"`
-	UserID          string                 `json:"user_id,omitempty"`
-	TimestampMicros string                 `json:"timestamp_micros,omitempty"`
-	NonPersonalized bool                   `json:"non_personalized_ads,omitempty"`
-	Events          []GA4Event             `json:"events"`
-	UserProperties  map[string]interface{} `json:"user_properties,omitempty"`
+	ClientID           string                 `json:"client_id"`
+	UserID             string                 `json:"user_id,omitempty"`
+	TimestampMicros    string                 `json:"timestamp_micros,omitempty"`
+	NonPersonalized    bool                   `json:"non_personalized_ads,omitempty"`
+	Events             []GA4Event             `json:"events"`
+	UserProperties     map[string]interface{} `json:"user_properties,omitempty"`
+	ValidationBehavior string                 `json:"validation_behavior,omitempty"` // Yalnızca debug uç noktası: RELAXED, ENFORCE_RECOMMENDATIONS
 }
 
 // GA4Client GA4 Measurement Protocol client
@@ -58,6 +64,7 @@ type GA4Client struct {
 	httpClient *http.Client
 	mu         sync.Mutex
 	rng        *mrand.Rand
+	batcher    *MPBatcher // nil = her event ayrı istekle gider
 }
 
 // NewGA4Client yeni GA4 client oluşturur
@@ -74,7 +81,7 @@ func NewGA4Client(config GA4Config) *GA4Client {
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
-		rng: mrand.New(mrand.NewSource(time.Now().UnixNano())),
+		rng: config.Seeder.NewRand(),
 	}
 }
 
@@ -183,8 +190,11 @@ func (c *GA4Client) SendCustomEvent(eventName string, params map[string]interfac
 	return c.sendEvent(event)
 }
 
-// sendEvent eventi GA4'e gönderir
+// sendEvent eventi GA4'e gönderir; batching açıksa event kuyruğa alınır
 func (c *GA4Client) sendEvent(event GA4Event) error {
+	if c.batcher != nil {
+		return c.batcher.Add(event)
+	}
 	payload := GA4Payload{
 		ClientID: c.config.ClientID,
 		UserID:   c.config.UserID,
@@ -194,52 +204,79 @@ func (c *GA4Client) sendEvent(event GA4Event) error {
 	return c.send(payload)
 }
 
-// SendBatch toplu event gönderir
+// SendBatch toplu event gönderir; istek başına en fazla MaxMPBatchEvents event gider
 func (c *GA4Client) SendBatch(events []GA4Event) error {
-	payload := GA4Payload{
-		ClientID: c.config.ClientID,
-		UserID:   c.config.UserID,
-		Events:   events,
+	if c.batcher != nil {
+		for _, event := range events {
+			if err := c.batcher.Add(event); err != nil {
+				return err
+			}
+		}
+		return nil
 	}
-	
-	return c.send(payload)
+	for len(events) > 0 {
+		n := min(len(events), MaxMPBatchEvents)
+		payload := GA4Payload{
+			ClientID: c.config.ClientID,
+			UserID:   c.config.UserID,
+			Events:   events[:n],
+		}
+		if err := c.send(payload); err != nil {
+			return err
+		}
+		events = events[n:]
+	}
+	return nil
 }
 
 // send payload'ı GA4'e gönderir
 func (c *GA4Client) send(payload GA4Payload) error {
+	_, err := c.post(payload, c.config.Debug && c.config.Server == nil)
+	return err
+}
+
+// post payload'ı collect (debug ise Google doğrulama) uç noktasına gönderir ve yanıt gövdesini döner
+func (c *GA4Client) post(payload GA4Payload, debug bool) ([]byte, error) {
 	jsonData, err := json.Marshal(payload)
 	if err != nil {
-		return fmt.Errorf("JSON marshal hatası: %w", err)
+		return nil, fmt.Errorf("JSON marshal hatası: %w", err)
 	}
 	
-	// Endpoint URL
-	baseURL := "https://www.google-analytics.com/mp/collect"
-	if c.config.Debug {
-		baseURL = "https://www.google-analytics.com/debug/mp/collect"
+	// Endpoint URL; doğrulama uç noktası sGTM'de yoktur, debug her zaman Google'a gider
+	baseURL := ga4CollectURL
+	if debug {
+		baseURL = ga4DebugURL
+	} else if c.config.Server != nil {
+		baseURL = c.config.Server.CollectURL()
 	}
 	
 	reqURL := fmt.Sprintf("%s?measurement_id=%s&api_secret=%s",
-		baseURL, c.config.MeasurementID, c.config.APISecret)
+		baseURL, url.QueryEscape(c.config.MeasurementID), url.QueryEscape(c.config.APISecret))
 	
 	req, err := http.NewRequest("POST", reqURL, bytes.NewBuffer(jsonData))
 	if err != nil {
-		return fmt.Errorf("request oluşturma hatası: %w", err)
+		return nil, fmt.Errorf("request oluşturma hatası: %w", err)
 	}
 	
 	req.Header.Set("Content-Type", "application/json")
+	if c.config.Server != nil && !debug {
+		for k, v := range c.config.Server.Headers() {
+			req.Header.Set(k, v)
+		}
+	}
 	
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("request gönderme hatası: %w", err)
+		return nil, fmt.Errorf("request gönderme hatası: %w", err)
 	}
 	defer resp.Body.Close()
 	
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("GA4 hatası: %d - %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("GA4 hatası: %d - %s", resp.StatusCode, string(body))
 	}
 	
-	return nil
+	return body, nil
 }
 
 // getEngagementTime engagement time döner
@@ -260,8 +297,11 @@ func (c *GA4Client) UpdateSessionID(sessionID string) {
 	c.config.SessionID = sessionID
 }
 
-// UpdateClientID client ID'yi günceller
+// UpdateClientID client ID'yi günceller; kuyruktaki event'ler önce eski client ID ile gönderilir
 func (c *GA4Client) UpdateClientID(clientID string) {
+	if c.batcher != nil {
+		_ = c.batcher.Flush() // Hata batcher istatistiklerinde reddedilen olarak sayılır
+	}
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	c.config.ClientID = clientID
@@ -364,10 +404,10 @@ type AnalyticsInjector struct {
 	rng *mrand.Rand
 }
 
-// NewAnalyticsInjector yeni injector oluşturur
-func NewAnalyticsInjector() *AnalyticsInjector {
+// NewAnalyticsInjector yeni injector oluşturur (seeder nil ise tekrarlanamaz)
+func NewAnalyticsInjector(seeder *utils.Seeder) *AnalyticsInjector {
 	return &AnalyticsInjector{
-		rng: mrand.New(mrand.NewSource(time.Now().UnixNano())),
+		rng: seeder.NewRand(),
 	}
 }
 
@@ -529,10 +569,10 @@ type SearchConsoleSimulator struct {
 	rng *mrand.Rand
 }
 
-// NewSearchConsoleSimulator yeni simulator oluşturur
-func NewSearchConsoleSimulator() *SearchConsoleSimulator {
+// NewSearchConsoleSimulator yeni simulator oluşturur (seeder nil ise tekrarlanamaz)
+func NewSearchConsoleSimulator(seeder *utils.Seeder) *SearchConsoleSimulator {
 	return &SearchConsoleSimulator{
-		rng: mrand.New(mrand.NewSource(time.Now().UnixNano())),
+		rng: seeder.NewRand(),
 	}
 }
 
@@ -628,8 +668,10 @@ type AnalyticsTracker struct {
 	mu           sync.Mutex
 	sessionStart time.Time
 	pageViews    int
+	newSession   bool // Oturum zaman aşımı sonrası: sonraki page_view session_start ile gider
 	events       []TrackedEvent
 	rng          *mrand.Rand
+	mpBatcher    *MPBatcher // GA4BatchEvents açıksa Measurement Protocol event'lerini toplar
 }
 
 // TrackedEvent takip edilen event
@@ -641,30 +683,44 @@ type TrackedEvent struct {
 
 // AnalyticsTrackerConfig tracker yapılandırması
 type AnalyticsTrackerConfig struct {
-	GA4MeasurementID string
-	GA4APISecret     string
-	UATrackingID     string
-	EnableGA4        bool
-	EnableUA         bool
-	EnableInjection  bool
+	GA4MeasurementID      string
+	GA4APISecret          string
+	UATrackingID          string
+	EnableGA4             bool
+	EnableUA              bool
+	EnableInjection       bool
+	GA4BatchEvents        bool   // GA4 event'leri 25'lik Measurement Protocol istekleriyle gönderilir
+	GA4DryRun             bool   // Event'ler yalnızca doğrulama uç noktasına gider (batching açılır)
+	GA4ValidationBehavior string // Dry-run doğrulama seviyesi; geçersizse RELAXED
+	Seeder                *utils.Seeder // Çalıştırmanın seed kaynağı (nil = tekrarlanamaz)
 }
 
 // NewAnalyticsTracker yeni tracker oluşturur
 func NewAnalyticsTracker(config AnalyticsTrackerConfig) *AnalyticsTracker {
 	tracker := &AnalyticsTracker{
-		injector:     NewAnalyticsInjector(),
-		scSimulator:  NewSearchConsoleSimulator(),
+		injector:     NewAnalyticsInjector(config.Seeder),
+		scSimulator:  NewSearchConsoleSimulator(config.Seeder),
 		sessionStart: time.Now(),
 		pageViews:    0,
 		events:       make([]TrackedEvent, 0),
-		rng:          mrand.New(mrand.NewSource(time.Now().UnixNano())),
+		rng:          config.Seeder.NewRand(),
 	}
 	
 	if config.EnableGA4 && config.GA4MeasurementID != "" && config.GA4APISecret != "" {
 		tracker.ga4Client = NewGA4Client(GA4Config{
 			MeasurementID: config.GA4MeasurementID,
 			APISecret:     config.GA4APISecret,
+			Seeder:        config.Seeder,
 		})
+		if config.GA4BatchEvents || config.GA4DryRun {
+			batchCfg := MPBatchConfig{DryRun: config.GA4DryRun, ValidationBehavior: config.GA4ValidationBehavior}
+			b, err := NewMPBatcher(tracker.ga4Client, batchCfg)
+			if err != nil {
+				batchCfg.ValidationBehavior = ""
+				b, _ = NewMPBatcher(tracker.ga4Client, batchCfg)
+			}
+			tracker.mpBatcher = b
+		}
 	}
 	
 	if config.EnableUA && config.UATrackingID != "" {
@@ -681,6 +737,8 @@ func (at *AnalyticsTracker) TrackPageView(ctx context.Context, pageTitle, pageUR
 	at.mu.Lock()
 	at.pageViews++
 	isFirstVisit := at.pageViews == 1
+	newSession := at.newSession
+	at.newSession = false
 	at.mu.Unlock()
 	
 	var errs []error
@@ -713,6 +771,10 @@ func (at *AnalyticsTracker) TrackPageView(ctx context.Context, pageTitle, pageUR
 			if err := at.ga4Client.SendSessionStart(pageURL); err != nil {
 				errs = append(errs, err)
 			}
+		} else if newSession {
+			if err := at.ga4Client.SendSessionStart(pageURL); err != nil {
+				errs = append(errs, err)
+			}
 		}
 		
 		if err := at.ga4Client.SendPageView(pageTitle, pageURL, referrer); err != nil {
@@ -747,6 +809,18 @@ func (at *AnalyticsTracker) TrackPageView(ctx context.Context, pageTitle, pageUR
 	return nil
 }
 
+// StartNewSession oturum zaman aşımından sonra yeni session_id'ye geçer;
+// sonraki page_view session_start ile birlikte gönderilir.
+func (at *AnalyticsTracker) StartNewSession(sessionID string) {
+	at.mu.Lock()
+	at.newSession = true
+	at.sessionStart = time.Now()
+	at.mu.Unlock()
+	if at.ga4Client != nil {
+		at.ga4Client.UpdateSessionID(sessionID)
+	}
+}
+
 // TrackScroll scroll takibi
 func (at *AnalyticsTracker) TrackScroll(ctx context.Context, percentScrolled int) error {
 	var errs []error
@@ -870,17 +944,41 @@ func (at *AnalyticsTracker) recordEvent(name string, params map[string]interface
 	})
 }
 
+// FlushEvents toplu gönderimde kuyruktaki GA4 event'lerini gönderir (batching kapalıysa no-op)
+func (at *AnalyticsTracker) FlushEvents() error {
+	if at.mpBatcher == nil {
+		return nil
+	}
+	return at.mpBatcher.Flush()
+}
+
+// MPStats toplu gönderimin istek ve kabul/ret sayaçları (batching kapalıysa sıfır)
+func (at *AnalyticsTracker) MPStats() MPBatchStats {
+	if at.mpBatcher == nil {
+		return MPBatchStats{}
+	}
+	return at.mpBatcher.Stats()
+}
+
 // GetSessionStats oturum istatistiklerini döner
 func (at *AnalyticsTracker) GetSessionStats() map[string]interface{} {
 	at.mu.Lock()
 	defer at.mu.Unlock()
 	
-	return map[string]interface{}{
+	stats := map[string]interface{}{
 		"session_duration_ms": time.Since(at.sessionStart).Milliseconds(),
 		"page_views":          at.pageViews,
 		"total_events":        len(at.events),
 		"session_start":       at.sessionStart,
 	}
+	if at.mpBatcher != nil {
+		mp := at.mpBatcher.Stats()
+		stats["mp_requests"] = mp.Requests
+		stats["mp_events_accepted"] = mp.Accepted
+		stats["mp_events_rejected"] = mp.Rejected
+		stats["mp_events_pending"] = at.mpBatcher.Pending()
+	}
+	return stats
 }
 
 // ============================================================================
@@ -0,0 +1,353 @@
+package analytics
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// Analytics backend'leri (config analytics_backend)
+const (
+	BackendGA4       = "ga4"
+	BackendMatomo    = "matomo"
+	BackendPlausible = "plausible"
+)
+
+// Tracker GA dışı analytics backend'i. Sayfada backend'in kendi izleyicisi çalışıyorsa page view'ı o
+// gönderir ve event'ler izleyiciye verilir; izleyici yoksa backend'in HTTP API'si sunucudan kullanılır
+// (GA'daki Measurement Protocol yedeği gibi).
+type Tracker interface {
+	// Name backend adı (matomo, plausible)
+	Name() string
+	// ID raporlarda ölçüm kimliği yerine geçen site kimliği (ör. "matomo:3")
+	ID() string
+	// Host backend alan adı (kaynak engellemede izin listesi için)
+	Host() string
+	// PageView sayfa görüntülemeyi doğrular/gönderir; yöntemi döner: page_tracker veya http_api
+	PageView(ctx context.Context) (string, error)
+	// Event event'i sayfadaki izleyiciyle veya HTTP API ile gönderir
+	Event(ctx context.Context, event Event) error
+}
+
+// BackendConfig GA dışı backend ayarları
+type BackendConfig struct {
+	Backend         string // ga4 (boş), matomo, plausible
+	MatomoURL       string // Matomo kök adresi (matomo.php buradan çağrılır)
+	MatomoSiteID    int
+	PlausibleURL    string // Boşsa https://plausible.io
+	PlausibleDomain string // Plausible'daki site adı (boşsa hedef domain)
+}
+
+// NewTracker backend'i doğrular ve oluşturur; ga4 (veya boş) için nil döner
+func NewTracker(cfg BackendConfig, targetDomain string) (Tracker, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	switch strings.ToLower(strings.TrimSpace(cfg.Backend)) {
+	case "", BackendGA4:
+		return nil, nil
+	case BackendMatomo:
+		base, err := backendURL("matomo_url", cfg.MatomoURL)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.MatomoSiteID <= 0 {
+			return nil, fmt.Errorf("matomo_site_id gerekli (Matomo'daki site ID)")
+		}
+		return &matomoTracker{base: base, siteID: cfg.MatomoSiteID, client: client}, nil
+	case BackendPlausible:
+		raw := cfg.PlausibleURL
+		if raw == "" {
+			raw = "https://plausible.io"
+		}
+		base, err := backendURL("plausible_url", raw)
+		if err != nil {
+			return nil, err
+		}
+		domain := strings.TrimSpace(cfg.PlausibleDomain)
+		if domain == "" {
+			domain = strings.TrimPrefix(targetDomain, "www.")
+		}
+		if domain == "" {
+			return nil, fmt.Errorf("plausible_domain gerekli (Plausible'daki site adı)")
+		}
+		return &plausibleTracker{base: base, domain: domain, client: client}, nil
+	default:
+		return nil, fmt.Errorf("geçersiz analytics_backend %q (ga4, matomo veya plausible)", cfg.Backend)
+	}
+}
+
+// backendURL self-hosted backend adresini doğrular (sondaki / atılır)
+func backendURL(field, raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	u, err := url.Parse(raw)
+	if raw == "" || err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" || u.RawQuery != "" {
+		return "", fmt.Errorf("geçersiz %s %q (https://analytics.alan.adi bekleniyor)", field, raw)
+	}
+	return strings.TrimSuffix(u.String(), "/"), nil
+}
+
+// hostOf doğrulanmış backend adresinin alan adı
+func hostOf(base string) string {
+	u, _ := url.Parse(base)
+	return u.Host
+}
+
+// Tracker yapılandırılmış GA dışı backend (nil = GA4)
+func (m *Manager) Tracker() Tracker {
+	if m == nil {
+		return nil
+	}
+	return m.Backend
+}
+
+// backendPageJS sayfa bilgisini ve sekme boyunca sabit ziyaretçi kimliğini toplar
+const backendPageJS = `(function(){
+	var id = '';
+	try {
+		id = sessionStorage.getItem('_vgb_vid') || '';
+		if (!id) {
+			for (var i = 0; i < 16; i++) id += Math.floor(Math.random() * 16).toString(16);
+			sessionStorage.setItem('_vgb_vid', id);
+		}
+	} catch(e) {}
+	return {url: location.href, title: document.title, referrer: document.referrer,
+		ua: navigator.userAgent, width: screen.width, height: screen.height, visitor: id};
+})()`
+
+// backendPage HTTP API istekleri için sayfa bilgisi
+type backendPage struct {
+	URL      string `json:"url"`
+	Title    string `json:"title"`
+	Referrer string `json:"referrer"`
+	UA       string `json:"ua"`
+	Width    int    `json:"width"`
+	Height   int    `json:"height"`
+	Visitor  string `json:"visitor"`
+}
+
+func currentPage(ctx context.Context) (backendPage, error) {
+	var p backendPage
+	err := chromedp.Evaluate(backendPageJS, &p).Do(ctx)
+	return p, err
+}
+
+// pageHas JS ifadesi sayfada doğruysa true döner
+func pageHas(ctx context.Context, expr string) bool {
+	var ok bool
+	return chromedp.Evaluate(expr, &ok).Do(ctx) == nil && ok
+}
+
+// backendDo isteği gönderir; 2xx dışı yanıt hata döner
+func backendDo(client *http.Client, req *http.Request) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s: %d - %s", req.URL.Host, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// ============================================================================
+// MATOMO (Tracking HTTP API)
+// ============================================================================
+
+type matomoTracker struct {
+	base   string
+	siteID int
+	client *http.Client
+}
+
+// matomoLoadedJS sayfanın Matomo izleyicisi kurulu mu (_paq komutları işleniyor mu)
+const matomoLoadedJS = `!!((window.Matomo || window.Piwik) && (window.Matomo || window.Piwik).initialized)`
+
+func (t *matomoTracker) Name() string { return BackendMatomo }
+func (t *matomoTracker) ID() string   { return fmt.Sprintf("matomo:%d", t.siteID) }
+func (t *matomoTracker) Host() string { return hostOf(t.base) }
+
+func (t *matomoTracker) PageView(ctx context.Context) (string, error) {
+	if pageHas(ctx, matomoLoadedJS) {
+		return "page_tracker", nil
+	}
+	p, err := currentPage(ctx)
+	if err != nil {
+		return "", err
+	}
+	q := t.params(p)
+	q.Set("action_name", p.Title)
+	return "http_api", t.send(ctx, p, q)
+}
+
+func (t *matomoTracker) Event(ctx context.Context, event Event) error {
+	category := event.Category
+	if category == "" {
+		category = "engagement"
+	}
+	action := event.Action
+	if action == "" {
+		action = string(event.Type)
+	}
+	// Outbound click Matomo'nun outlink raporuna gider (event değil)
+	outlink := event.Type == EventClick && event.Category == "outbound"
+	if pageHas(ctx, matomoLoadedJS) {
+		script := fmt.Sprintf(`window._paq.push(['trackEvent','%s','%s','%s',%d])`,
+			escapeJS(category), escapeJS(action), escapeJS(event.Label), event.Value)
+		if outlink {
+			script = fmt.Sprintf(`window._paq.push(['trackLink','%s','link'])`, escapeJS(event.Label))
+		}
+		return chromedp.Evaluate(script, nil).Do(ctx)
+	}
+	p, err := currentPage(ctx)
+	if err != nil {
+		return err
+	}
+	q := t.params(p)
+	if outlink {
+		q.Set("link", event.Label)
+		return t.send(ctx, p, q)
+	}
+	q.Set("e_c", category)
+	q.Set("e_a", action)
+	if event.Label != "" {
+		q.Set("e_n", event.Label)
+	}
+	q.Set("e_v", strconv.Itoa(event.Value))
+	return t.send(ctx, p, q)
+}
+
+// params Tracking API'nin her istekte gereken parametreleri
+func (t *matomoTracker) params(p backendPage) url.Values {
+	q := url.Values{}
+	q.Set("idsite", strconv.Itoa(t.siteID))
+	q.Set("rec", "1")
+	q.Set("apiv", "1")
+	q.Set("send_image", "0")
+	q.Set("url", p.URL)
+	q.Set("rand", strconv.FormatInt(time.Now().UnixNano(), 36))
+	if p.Referrer != "" {
+		q.Set("urlref", p.Referrer)
+	}
+	if p.Visitor != "" {
+		q.Set("_id", p.Visitor)
+	}
+	if p.Width > 0 && p.Height > 0 {
+		q.Set("res", fmt.Sprintf("%dx%d", p.Width, p.Height))
+	}
+	return q
+}
+
+func (t *matomoTracker) send(ctx context.Context, p backendPage, q url.Values) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, t.base+"/matomo.php?"+q.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", p.UA)
+	if err := backendDo(t.client, req); err != nil {
+		return fmt.Errorf("matomo: %w", err)
+	}
+	return nil
+}
+
+// ============================================================================
+// PLAUSIBLE (Events API)
+// ============================================================================
+
+type plausibleTracker struct {
+	base   string
+	domain string
+	client *http.Client
+}
+
+// plausibleLoadedJS sayfanın Plausible script'i yüklü mü
+const plausibleLoadedJS = `typeof window.plausible === 'function'`
+
+func (t *plausibleTracker) Name() string { return BackendPlausible }
+func (t *plausibleTracker) ID() string   { return "plausible:" + t.domain }
+func (t *plausibleTracker) Host() string { return hostOf(t.base) }
+
+func (t *plausibleTracker) PageView(ctx context.Context) (string, error) {
+	if pageHas(ctx, plausibleLoadedJS) {
+		return "page_tracker", nil
+	}
+	return "http_api", t.send(ctx, "pageview", nil)
+}
+
+func (t *plausibleTracker) Event(ctx context.Context, event Event) error {
+	name, props := plausibleEvent(event)
+	if pageHas(ctx, plausibleLoadedJS) {
+		data, _ := json.Marshal(map[string]interface{}{"props": props})
+		return chromedp.Evaluate(fmt.Sprintf(`window.plausible('%s', %s)`, escapeJS(name), data), nil).Do(ctx)
+	}
+	return t.send(ctx, name, props)
+}
+
+// plausibleEvent event'i Plausible adı ve string prop'larına çevirir; outbound click Plausible'ın
+// kendi "Outbound Link: Click" hedefiyle gönderilir
+func plausibleEvent(event Event) (string, map[string]string) {
+	props := map[string]string{}
+	if event.Type == EventClick && event.Category == "outbound" {
+		props["url"] = event.Label
+		return "Outbound Link: Click", props
+	}
+	for k, v := range event.Parameters {
+		props[k] = fmt.Sprint(v)
+	}
+	if event.Category != "" {
+		props["category"] = event.Category
+	}
+	if event.Label != "" {
+		props["label"] = event.Label
+	}
+	name := event.Action
+	if name == "" {
+		name = string(event.Type)
+	}
+	return name, props
+}
+
+func (t *plausibleTracker) send(ctx context.Context, name string, props map[string]string) error {
+	p, err := currentPage(ctx)
+	if err != nil {
+		return err
+	}
+	body := map[string]interface{}{
+		"name":   name,
+		"url":    p.URL,
+		"domain": t.domain,
+	}
+	if p.Referrer != "" {
+		body["referrer"] = p.Referrer
+	}
+	if len(props) > 0 {
+		body["props"] = props
+	}
+	data, _ := json.Marshal(body)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.base+"/api/event", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	// Plausible ziyaretçiyi User-Agent + IP ile ayırt eder; User-Agent olmayan istekler yok sayılır
+	req.Header.Set("User-Agent", p.UA)
+	req.Header.Set("Content-Type", "application/json")
+	if err := backendDo(t.client, req); err != nil {
+		return fmt.Errorf("plausible: %w", err)
+	}
+	return nil
+}
+
+// sendBackendEvent Manager.SendEvent'in GA dışı backend yolu
+func (m *Manager) sendBackendEvent(ctx context.Context, event Event) error {
+	event.Parameters = m.labelTraffic(event.Parameters)
+	return m.Backend.Event(ctx, event)
+}
@@ -0,0 +1,87 @@
+package analytics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewTracker(t *testing.T) {
+	if tr, err := NewTracker(BackendConfig{Backend: "GA4"}, "example.com"); tr != nil || err != nil {
+		t.Errorf("ga4 = %v, %v", tr, err)
+	}
+	for _, cfg := range []BackendConfig{
+		{Backend: "matomo", MatomoURL: "https://stats.example.com", MatomoSiteID: 0},
+		{Backend: "matomo", MatomoURL: "stats.example.com", MatomoSiteID: 1},
+		{Backend: "plausible", PlausibleURL: "ftp://plausible.example.com"},
+		{Backend: "umami"},
+	} {
+		if _, err := NewTracker(cfg, "example.com"); err == nil {
+			t.Errorf("NewTracker(%+v): expected error", cfg)
+		}
+	}
+
+	tr, err := NewTracker(BackendConfig{Backend: "Matomo", MatomoURL: "https://stats.example.com/", MatomoSiteID: 3}, "")
+	if err != nil || tr.ID() != "matomo:3" || tr.Host() != "stats.example.com" {
+		t.Fatalf("matomo = %v, %v", tr, err)
+	}
+	// Plausible varsayılan olarak plausible.io ve hedef domain'i kullanır
+	tr, err = NewTracker(BackendConfig{Backend: "plausible"}, "www.example.com")
+	if err != nil || tr.ID() != "plausible:example.com" || tr.Host() != "plausible.io" {
+		t.Fatalf("plausible = %v, %v", tr, err)
+	}
+	if (&Manager{Backend: tr}).Tracker() != tr || (*Manager)(nil).Tracker() != nil {
+		t.Error("Manager.Tracker")
+	}
+}
+
+func TestMatomoTrackingRequest(t *testing.T) {
+	var got *http.Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	tr, err := NewTracker(BackendConfig{Backend: "matomo", MatomoURL: srv.URL, MatomoSiteID: 7}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := tr.(*matomoTracker)
+	p := backendPage{URL: "https://example.com/a", Referrer: "https://google.com/", UA: "UA/1", Width: 1920, Height: 1080, Visitor: "0123456789abcdef"}
+	q := m.params(p)
+	q.Set("action_name", "Home")
+	if err := m.send(context.Background(), p, q); err != nil {
+		t.Fatal(err)
+	}
+	params := got.URL.Query()
+	if got.URL.Path != "/matomo.php" || got.Header.Get("User-Agent") != "UA/1" {
+		t.Errorf("request %s UA %q", got.URL.Path, got.Header.Get("User-Agent"))
+	}
+	for k, want := range map[string]string{"idsite": "7", "rec": "1", "url": p.URL, "urlref": p.Referrer,
+		"_id": p.Visitor, "res": "1920x1080", "action_name": "Home"} {
+		if params.Get(k) != want {
+			t.Errorf("%s = %q, want %q", k, params.Get(k), want)
+		}
+	}
+
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid idsite", http.StatusBadRequest)
+	})
+	if err := m.send(context.Background(), p, q); err == nil {
+		t.Error("expected error for 400 response")
+	}
+}
+
+func TestPlausibleEvent(t *testing.T) {
+	name, props := plausibleEvent(Event{Type: EventClick, Category: "outbound", Label: "https://partner.com/"})
+	if name != "Outbound Link: Click" || len(props) != 1 || props["url"] != "https://partner.com/" {
+		t.Errorf("outbound = %q %v", name, props)
+	}
+	name, props = plausibleEvent(Event{Type: EventScroll, Category: "engagement", Label: "75%",
+		Parameters: map[string]interface{}{"percent_scrolled": 75}})
+	if name != string(EventScroll) || props["percent_scrolled"] != "75" || props["category"] != "engagement" || props["label"] != "75%" {
+		t.Errorf("scroll = %q %v", name, props)
+	}
+}
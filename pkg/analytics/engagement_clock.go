@@ -0,0 +1,90 @@
+package analytics
+
+import (
+	"sync"
+	"time"
+)
+
+// EngagementClock sayfanın ön planda (focus) geçirdiği süreyi sayar; blur dönemleri hariç tutulur.
+// gtag.js gibi engagement_time_msec her event'te son raporlamadan bu yana biriken süreyi taşır.
+type EngagementClock struct {
+	mu         sync.Mutex
+	focused    bool
+	lastTick   time.Time
+	unreported time.Duration // Son Take'ten beri biriken ön plan süresi
+	page       time.Duration // Mevcut sayfadaki ön plan süresi
+	total      time.Duration // Oturum boyunca ön plan süresi
+}
+
+// NewEngagementClock sayfa yüklendiği anda (ön planda) başlayan saat oluşturur
+func NewEngagementClock(now time.Time) *EngagementClock {
+	return &EngagementClock{focused: true, lastTick: now}
+}
+
+// advance now'a kadar geçen süreyi ön plandaysa biriktirir (c.mu tutulurken çağrılır)
+func (c *EngagementClock) advance(now time.Time) {
+	if now.Before(c.lastTick) {
+		return
+	}
+	if c.focused {
+		d := now.Sub(c.lastTick)
+		c.unreported += d
+		c.page += d
+		c.total += d
+	}
+	c.lastTick = now
+}
+
+// Blur sekme arka plana geçti; süre sayımı durur
+func (c *EngagementClock) Blur(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.advance(now)
+	c.focused = false
+}
+
+// Focus sekme tekrar ön plana geldi; süre sayımı devam eder
+func (c *EngagementClock) Focus(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.advance(now)
+	c.focused = true
+}
+
+// Take son raporlamadan beri biriken ön plan süresini döner ve sıfırlar (engagement_time_msec)
+func (c *EngagementClock) Take(now time.Time) time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.advance(now)
+	d := c.unreported
+	c.unreported = 0
+	return d
+}
+
+// NewPage yeni sayfaya geçildiğini bildirir; önceki sayfanın toplam ön plan süresini döner.
+// Raporlanmamış süre korunur ve bir sonraki event'le (yeni sayfanın page_view'ı) gönderilir.
+func (c *EngagementClock) NewPage(now time.Time) time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.advance(now)
+	d := c.page
+	c.page = 0
+	c.focused = true
+	return d
+}
+
+// Page mevcut sayfadaki ön plan süresi
+func (c *EngagementClock) Page(now time.Time) time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.advance(now)
+	return c.page
+}
+
+// Total oturum boyunca toplam ön plan süresi
+func (c *EngagementClock) Total(now time.Time) time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.advance(now)
+	return c.total
+}
@@ -0,0 +1,40 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEngagementClockExcludesBlur(t *testing.T) {
+	t0 := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	c := NewEngagementClock(t0)
+
+	c.Blur(t0.Add(4 * time.Second))
+	c.Focus(t0.Add(10 * time.Second)) // 6sn arka planda
+	if got := c.Take(t0.Add(12 * time.Second)); got != 6*time.Second {
+		t.Fatalf("first take = %v, want 6s", got)
+	}
+	if got := c.Take(t0.Add(15 * time.Second)); got != 3*time.Second {
+		t.Fatalf("second take = %v, want 3s (delta since last report)", got)
+	}
+	if got := c.Total(t0.Add(15 * time.Second)); got != 9*time.Second {
+		t.Fatalf("total = %v, want 9s", got)
+	}
+}
+
+func TestEngagementClockNewPage(t *testing.T) {
+	t0 := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	c := NewEngagementClock(t0)
+	c.Take(t0.Add(5 * time.Second))
+
+	// Önceki sayfada raporlanmamış 2sn yeni sayfanın ilk event'ine kalır
+	if page := c.NewPage(t0.Add(7 * time.Second)); page != 7*time.Second {
+		t.Fatalf("previous page = %v, want 7s", page)
+	}
+	if got := c.Take(t0.Add(7 * time.Second)); got != 2*time.Second {
+		t.Fatalf("carry-over = %v, want 2s", got)
+	}
+	if got := c.Page(t0.Add(8 * time.Second)); got != time.Second {
+		t.Fatalf("new page = %v, want 1s", got)
+	}
+}
@@ -0,0 +1,162 @@
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/chromedp/chromedp"
+)
+
+// ga4NameRe GA4 event ve parametre adı kuralı: harfle başlar, harf/rakam/alt çizgi, en fazla 40 karakter
+var ga4NameRe = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]{0,39}$`)
+
+// ga4ReservedPrefixes GA4'ün ayırdığı önekler; bu adlarla gönderilen event/parametreler düşürülür
+var ga4ReservedPrefixes = []string{"google_", "ga_", "firebase_"}
+
+// EventMapping sitenin özel GA4 event şeması. Standart event adlarını (scroll, click, page_view...)
+// sitenin kullandığı adlara çevirir, her event'e varsayılan parametreler ve event başına şablonlu
+// parametreler ekler. Şablonlarda {event}, {category}, {label}, {value}, event parametreleri
+// ({link_url} gibi) ve sayfa bilgisi ({page_location}, {page_path}, {page_title}) kullanılabilir.
+type EventMapping struct {
+	rename        map[string]string
+	defaultParams map[string]string
+	eventParams   map[string]map[string]string // standart event adı → parametre şablonları
+	needsPage     bool
+}
+
+// NewEventMapping eşlemeyi doğrular ve oluşturur; hepsi boşsa nil döner
+func NewEventMapping(rename, defaultParams map[string]string, eventParams map[string]map[string]string) (*EventMapping, error) {
+	if len(rename) == 0 && len(defaultParams) == 0 && len(eventParams) == 0 {
+		return nil, nil
+	}
+	m := &EventMapping{rename: rename, defaultParams: defaultParams, eventParams: eventParams}
+	for from, to := range rename {
+		if err := checkGA4Name("event", from); err != nil {
+			return nil, err
+		}
+		if err := checkGA4Name("event", to); err != nil {
+			return nil, err
+		}
+	}
+	check := func(params map[string]string) error {
+		for k, v := range params {
+			if err := checkGA4Name("parametre", k); err != nil {
+				return err
+			}
+			if strings.Contains(v, "{page_") {
+				m.needsPage = true
+			}
+		}
+		return nil
+	}
+	if err := check(defaultParams); err != nil {
+		return nil, err
+	}
+	for name, params := range eventParams {
+		if err := checkGA4Name("event", name); err != nil {
+			return nil, err
+		}
+		if err := check(params); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return m, nil
+}
+
+// checkGA4Name adın GA4 kurallarına uyup uymadığını kontrol eder
+func checkGA4Name(kind, name string) error {
+	if !ga4NameRe.MatchString(name) {
+		return fmt.Errorf("geçersiz GA4 %s adı %q (harfle başlamalı, yalnızca harf/rakam/_; en fazla 40 karakter)", kind, name)
+	}
+	lower := strings.ToLower(name)
+	for _, p := range ga4ReservedPrefixes {
+		if strings.HasPrefix(lower, p) {
+			return fmt.Errorf("GA4 %s adı %q ayrılmış önekle (%s) başlıyor", kind, name, p)
+		}
+	}
+	return nil
+}
+
+// Apply event'in gönderilecek adını ve parametrelerini döner. Önce varsayılan parametreler
+// (event'in kendi parametrelerini ezmez), sonra event'e özel şablonlar (ezer) uygulanır.
+// page sayfa şablon değişkenleridir (page_location, page_path, page_title); nil olabilir.
+func (m *EventMapping) Apply(name string, event Event, page map[string]string) (string, map[string]interface{}) {
+	params := make(map[string]interface{}, len(event.Parameters))
+	for k, v := range event.Parameters {
+		params[k] = v
+	}
+	if m == nil {
+		return name, params
+	}
+
+	vars := map[string]string{
+		"event":    name,
+		"category": event.Category,
+		"label":    event.Label,
+		"value":    strconv.Itoa(event.Value),
+	}
+	for k, v := range event.Parameters {
+		vars[k] = fmt.Sprint(v)
+	}
+	for k, v := range page {
+		vars[k] = v
+	}
+	pairs := make([]string, 0, len(vars)*2)
+	for k, v := range vars {
+		pairs = append(pairs, "{"+k+"}", v)
+	}
+	expand := strings.NewReplacer(pairs...)
+
+	for k, tpl := range m.defaultParams {
+		if _, ok := params[k]; !ok {
+			params[k] = expand.Replace(tpl)
+		}
+	}
+	for k, tpl := range m.eventParams[name] {
+		params[k] = expand.Replace(tpl)
+	}
+	if to, ok := m.rename[name]; ok {
+		name = to
+	}
+	return name, params
+}
+
+// Describe eşlemeyi log için tek satıra çevirir: "scroll→page_scroll, 2 varsayılan parametre"
+func (m *EventMapping) Describe() string {
+	if m == nil {
+		return ""
+	}
+	var parts []string
+	for from, to := range m.rename {
+		parts = append(parts, from+"→"+to)
+	}
+	sort.Strings(parts)
+	if n := len(m.defaultParams); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d varsayılan parametre", n))
+	}
+	events := make([]string, 0, len(m.eventParams))
+	for name := range m.eventParams {
+		events = append(events, name)
+	}
+	sort.Strings(events)
+	for _, name := range events {
+		parts = append(parts, fmt.Sprintf("%s: %d parametre", name, len(m.eventParams[name])))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// pageVars şablonlar için sayfa bilgisini okur
+func (m *EventMapping) pageVars(ctx context.Context) map[string]string {
+	if m == nil || !m.needsPage {
+		return nil
+	}
+	var page map[string]string
+	if err := chromedp.Evaluate(`({page_location: location.href, page_path: location.pathname, page_title: document.title})`, &page).Do(ctx); err != nil {
+		return nil
+	}
+	return page
+}
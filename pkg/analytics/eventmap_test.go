@@ -0,0 +1,69 @@
+package analytics
+
+import "testing"
+
+func TestNewEventMappingValidation(t *testing.T) {
+	if m, err := NewEventMapping(nil, nil, nil); m != nil || err != nil {
+		t.Fatalf("empty mapping = %v, %v", m, err)
+	}
+	bad := []struct {
+		rename   map[string]string
+		defaults map[string]string
+		events   map[string]map[string]string
+	}{
+		{rename: map[string]string{"scroll": "page scroll"}},
+		{rename: map[string]string{"scroll": "ga_scroll"}},
+		{defaults: map[string]string{"1section": "x"}},
+		{events: map[string]map[string]string{"click": {"google_id": "x"}}},
+		{events: map[string]map[string]string{"bad-name": {"a": "x"}}},
+	}
+	for i, c := range bad {
+		if _, err := NewEventMapping(c.rename, c.defaults, c.events); err == nil {
+			t.Errorf("case %d accepted", i)
+		}
+	}
+}
+
+func TestEventMappingApply(t *testing.T) {
+	m, err := NewEventMapping(
+		map[string]string{"scroll": "page_scroll"},
+		map[string]string{"site_section": "blog", "link_url": "none", "origin": "{page_path}"},
+		map[string]map[string]string{
+			"scroll": {"depth": "{value}%", "where": "{category}/{label}"},
+			"click":  {"target": "{link_domain}"},
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !m.needsPage {
+		t.Error("needsPage = false with {page_path} template")
+	}
+
+	event := Event{Type: EventScroll, Category: "engagement", Action: "scroll", Label: "75%", Value: 75}
+	name, params := m.Apply("scroll", event, map[string]string{"page_path": "/blog/a"})
+	if name != "page_scroll" {
+		t.Errorf("name = %q", name)
+	}
+	want := map[string]string{"site_section": "blog", "depth": "75%", "where": "engagement/75%", "origin": "/blog/a", "link_url": "none"}
+	for k, v := range want {
+		if params[k] != v {
+			t.Errorf("params[%s] = %v, want %q", k, params[k], v)
+		}
+	}
+
+	// Varsayılan parametre event'in kendi parametresini ezmez; event parametreleri şablonda kullanılabilir
+	click := Event{Action: "click", Parameters: map[string]interface{}{"link_url": "https://p.example/x", "link_domain": "p.example"}}
+	name, params = m.Apply("click", click, nil)
+	if name != "click" || params["link_url"] != "https://p.example/x" || params["target"] != "p.example" {
+		t.Errorf("click = %q %v", name, params)
+	}
+	if _, ok := click.Parameters["site_section"]; ok {
+		t.Error("Apply modified the event's parameters")
+	}
+
+	var none *EventMapping
+	if name, params := none.Apply("scroll", event, nil); name != "scroll" || len(params) != 0 {
+		t.Errorf("nil mapping = %q %v", name, params)
+	}
+}
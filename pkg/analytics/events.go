@@ -2,10 +2,15 @@ package analytics
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/chromedp/chromedp"
+
+	"vgbot/pkg/errclass"
+	"vgbot/pkg/utils"
 )
 
 // EventType analytics event tipi
@@ -36,14 +41,50 @@ type Event struct {
 type Manager struct {
 	GA4Enabled       bool
 	GA4MeasurementID string
+	MPAPISecret      string // Measurement Protocol fallback (gtag yüklenemediğinde)
 	GTMEnabled       bool
 	GTMID            string
 	FBPixelEnabled   bool
 	FBPixelID        string
+	Mapping          *EventMapping // Sitenin özel GA4 event şeması (nil = standart adlar)
+	Server           *ServerEndpoint // Birinci taraf sGTM (nil = Google'a doğrudan)
+	TrafficType      string          // Doluysa her event'e traffic_type eklenir (GA4 iç trafik filtresi)
+	Backend          Tracker         // GA dışı backend (Matomo, Plausible); ayarlıysa event'ler yalnızca ona gider
+	Seeder           *utils.Seeder   // Measurement Protocol event'lerinin rastgele değerleri için (nil = tekrarlanamaz)
+}
+
+// trafficTypeRe traffic_type değeri: GA4 filtresinde eşleştirilecek sade bir etiket
+var trafficTypeRe = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,100}$`)
+
+// ValidateTrafficType traffic_type değerini doğrular (boş = kapalı)
+func ValidateTrafficType(v string) error {
+	if v != "" && !trafficTypeRe.MatchString(v) {
+		return fmt.Errorf("geçersiz traffic_type %q (harf, rakam, _ . -; en fazla 100 karakter)", v)
+	}
+	return nil
+}
+
+// labelTraffic TrafficType ayarlıysa params'a traffic_type ekler (eşlemeyle açıkça verilmişse dokunmaz)
+func (m *Manager) labelTraffic(params map[string]interface{}) map[string]interface{} {
+	if m.TrafficType == "" {
+		return params
+	}
+	if _, ok := params["traffic_type"]; ok {
+		return params
+	}
+	out := make(map[string]interface{}, len(params)+1)
+	for k, v := range params {
+		out[k] = v
+	}
+	out["traffic_type"] = m.TrafficType
+	return out
 }
 
 // SendEvent event'i yapılandırılmış platformlara gönderir
 func (m *Manager) SendEvent(ctx context.Context, event Event) error {
+	if m.Backend != nil {
+		return m.sendBackendEvent(ctx, event)
+	}
 	var errs []error
 	if m.GA4Enabled && m.GA4MeasurementID != "" {
 		if err := m.sendGA4Event(ctx, event); err != nil {
@@ -61,25 +102,54 @@ func (m *Manager) SendEvent(ctx context.Context, event Event) error {
 		}
 	}
 	if len(errs) > 0 {
-		return fmt.Errorf("event errors: %v", errs)
+		// errors.Join: errclass.ErrAnalyticsMissing gibi sınıflar errors.Is ile korunur
+		return fmt.Errorf("event errors: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// VerifyGA4Loaded gtag.js'in sayfada gerçekten yüklendiğini doğrular.
+// Yüklenmediyse (engellendi, CSP, ağ hatası) errclass.ErrAnalyticsMissing döner.
+func VerifyGA4Loaded(ctx context.Context) error {
+	var loaded bool
+	if err := chromedp.Evaluate(`!!window.google_tag_manager`, &loaded).Do(ctx); err != nil {
+		return err
+	}
+	if !loaded {
+		return errclass.ErrAnalyticsMissing
 	}
 	return nil
 }
 
 func (m *Manager) sendGA4Event(ctx context.Context, event Event) error {
-	params := m.formatGA4Params(event.Parameters)
+	name, eventParams := event.Action, event.Parameters
+	if m.Mapping != nil {
+		name, eventParams = m.Mapping.Apply(event.Action, event, m.Mapping.pageVars(ctx))
+	}
+	params := m.formatGA4Params(m.labelTraffic(eventParams))
+	// Event'i sayfada aktif olan (veya yapılandırılan) ID'ye yönlendir
+	params += fmt.Sprintf(`,'send_to':'%s'`, escapeJS(m.GA4MeasurementID))
 	script := fmt.Sprintf(`(function(){
 		if(typeof gtag==='function'){
 			gtag('event','%s',{'event_category':'%s','event_label':'%s','value':%d%s});
+			return true;
 		}
+		return false;
 	})();`,
-		escapeJS(event.Action),
+		escapeJS(name),
 		escapeJS(event.Category),
 		escapeJS(event.Label),
 		event.Value,
 		params,
 	)
-	return chromedp.Evaluate(script, nil).Do(ctx)
+	var sent bool
+	if err := chromedp.Evaluate(script, &sent).Do(ctx); err != nil {
+		return err
+	}
+	if !sent {
+		return errclass.ErrAnalyticsMissing
+	}
+	return nil
 }
 
 func (m *Manager) sendGTMEvent(ctx context.Context, event Event) error {
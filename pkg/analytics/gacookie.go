@@ -0,0 +1,93 @@
+package analytics
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ============================================================================
+// GA COOKIE FORMATI (_ga / _ga_<container>)
+// ============================================================================
+
+// GA4CookieName measurement ID'den GA4 oturum çerezinin adını üretir (G-ABC123 → _ga_ABC123).
+// Geçersiz ID için "" döner.
+func GA4CookieName(measurementID string) string {
+	id := strings.ToUpper(strings.TrimSpace(measurementID))
+	if !strings.HasPrefix(id, "G-") || len(id) <= 2 {
+		return ""
+	}
+	return "_ga_" + strings.TrimPrefix(id, "G-")
+}
+
+// GACookieValue _ga çerez değerini üretir: GA1.<domain bileşen sayısı>.<client_id>
+func GACookieValue(clientID, domain string) string {
+	domain = strings.Trim(strings.TrimSpace(domain), ".")
+	depth := 1
+	if domain != "" {
+		depth = len(strings.Split(domain, "."))
+	}
+	return fmt.Sprintf("GA1.%d.%s", depth, clientID)
+}
+
+// GASessionState _ga_<container> çerezindeki GS1.1 alanları:
+// GS1.1.<session_id>.<session_number>.<engaged>.<last_hit>.<j>.<l>.<h>
+type GASessionState struct {
+	SessionID    int64 `json:"session_id"`     // Oturum başlangıcı (unix saniye)
+	SessionCount int   `json:"session_number"` // ga_session_number
+	Engaged      bool  `json:"engaged"`        // Oturum engaged sayıldı mı (10sn+, 2+ sayfa veya conversion)
+	LastHit      int64 `json:"last_hit"`       // Son event zamanı (unix saniye)
+}
+
+// NewGASessionState yeni kullanıcının ilk oturumunu başlatır
+func NewGASessionState(now time.Time) GASessionState {
+	return GASessionState{SessionID: now.Unix(), SessionCount: 1, LastHit: now.Unix()}
+}
+
+// CookieValue çerez değerini GS1.1 formatında döner
+func (s GASessionState) CookieValue() string {
+	engaged := 0
+	if s.Engaged {
+		engaged = 1
+	}
+	return fmt.Sprintf("GS1.1.%d.%d.%d.%d.0.0.0", s.SessionID, s.SessionCount, engaged, s.LastHit)
+}
+
+// ParseGASessionCookie GS1.1 çerez değerini okur
+func ParseGASessionCookie(value string) (GASessionState, error) {
+	parts := strings.Split(value, ".")
+	if len(parts) < 6 || parts[0] != "GS1" {
+		return GASessionState{}, fmt.Errorf("geçersiz GA4 oturum çerezi: %q", value)
+	}
+	var s GASessionState
+	var err error
+	if s.SessionID, err = strconv.ParseInt(parts[2], 10, 64); err != nil {
+		return GASessionState{}, fmt.Errorf("geçersiz session_id: %w", err)
+	}
+	if s.SessionCount, err = strconv.Atoi(parts[3]); err != nil {
+		return GASessionState{}, fmt.Errorf("geçersiz session_number: %w", err)
+	}
+	s.Engaged = parts[4] == "1"
+	if s.LastHit, err = strconv.ParseInt(parts[5], 10, 64); err != nil {
+		return GASessionState{}, fmt.Errorf("geçersiz last_hit: %w", err)
+	}
+	return s, nil
+}
+
+// Touch now'da bir event gönderileceğini işler. Son event'ten bu yana timeout'tan uzun süre
+// geçtiyse yeni oturum başlar (session_id=now, session_number+1, engaged=0) ve true döner.
+func (s *GASessionState) Touch(now time.Time, timeout time.Duration) bool {
+	if s.SessionCount == 0 {
+		*s = NewGASessionState(now)
+		return true
+	}
+	newSession := now.Unix()-s.LastHit > int64(timeout/time.Second)
+	if newSession {
+		s.SessionID = now.Unix()
+		s.SessionCount++
+		s.Engaged = false
+	}
+	s.LastHit = now.Unix()
+	return newSession
+}
@@ -0,0 +1,57 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGA4CookieName(t *testing.T) {
+	cases := map[string]string{
+		"G-ABC123XYZ": "_ga_ABC123XYZ",
+		" g-abc123 ":  "_ga_ABC123",
+		"UA-1234-1":   "",
+		"":            "",
+	}
+	for in, want := range cases {
+		if got := GA4CookieName(in); got != want {
+			t.Errorf("GA4CookieName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestGACookieValue(t *testing.T) {
+	if got := GACookieValue("123.456", ".example.com"); got != "GA1.2.123.456" {
+		t.Fatalf("got %q", got)
+	}
+	if got := GACookieValue("123.456", "shop.example.co.uk"); got != "GA1.4.123.456" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestGASessionStateRoundTripAndTimeout(t *testing.T) {
+	t0 := time.Unix(1700000000, 0)
+	s := NewGASessionState(t0)
+	s.Engaged = true
+	if v := s.CookieValue(); v != "GS1.1.1700000000.1.1.1700000000.0.0.0" {
+		t.Fatalf("cookie = %q", v)
+	}
+	parsed, err := ParseGASessionCookie(s.CookieValue())
+	if err != nil || parsed != s {
+		t.Fatalf("parsed = %+v, err = %v", parsed, err)
+	}
+
+	if s.Touch(t0.Add(10*time.Minute), 30*time.Minute) {
+		t.Fatal("10 min later should continue the session")
+	}
+	if !s.Touch(t0.Add(50*time.Minute), 30*time.Minute) {
+		t.Fatal("40 min idle should start a new session")
+	}
+	want := GASessionState{SessionID: t0.Add(50 * time.Minute).Unix(), SessionCount: 2, LastHit: t0.Add(50 * time.Minute).Unix()}
+	if s != want {
+		t.Fatalf("state = %+v, want %+v", s, want)
+	}
+
+	if _, err := ParseGASessionCookie("GA1.2.123.456"); err == nil {
+		t.Fatal("expected error for non GS1 cookie")
+	}
+}
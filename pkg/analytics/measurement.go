@@ -0,0 +1,95 @@
+package analytics
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/chromedp/chromedp"
+)
+
+// pageMeasurementIDsJS yüklü sayfada aktif GA4 measurement ID'lerini toplar:
+// google_tag_manager anahtarları, dataLayer 'config' komutları ve gtag.js script src'leri.
+const pageMeasurementIDsJS = `(function(){
+	var ids = {};
+	var add = function(id){ if (typeof id === 'string' && /^G-[A-Z0-9]{4,}$/.test(id)) ids[id] = true; };
+	try { Object.keys(window.google_tag_manager || {}).forEach(add); } catch(e) {}
+	try {
+		(window.dataLayer || []).forEach(function(e){
+			if (e && e[0] === 'config') add(e[1]);
+		});
+	} catch(e) {}
+	try {
+		document.querySelectorAll('script[src*="googletagmanager.com/gtag/js"]').forEach(function(s){
+			var m = /[?&]id=([^&]+)/.exec(s.src); if (m) add(decodeURIComponent(m[1]));
+		});
+	} catch(e) {}
+	return Object.keys(ids);
+})()`
+
+// PageMeasurementIDs yüklü sayfadaki aktif GA4 measurement ID'lerini döner (sıralı)
+func PageMeasurementIDs(ctx context.Context) ([]string, error) {
+	var ids []string
+	if err := chromedp.Evaluate(pageMeasurementIDsJS, &ids).Do(ctx); err != nil {
+		return nil, err
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// ResolveMeasurementID sayfa için kullanılacak measurement ID'yi seçer:
+// yapılandırılan ID sayfada aktifse o, değilse sayfanın ilk ID'si; sayfada hiç yoksa fallback.
+func ResolveMeasurementID(pageIDs []string, fallback string) string {
+	for _, id := range pageIDs {
+		if strings.EqualFold(id, fallback) {
+			return id
+		}
+	}
+	if len(pageIDs) > 0 {
+		return pageIDs[0]
+	}
+	return fallback
+}
+
+// ForMeasurementID manager'ın verilen measurement ID'ye yönlendirilmiş kopyasını döner.
+// Event'ler gtag 'send_to' ile yalnızca bu ID'ye gider; id boşsa m aynen döner.
+func (m *Manager) ForMeasurementID(id string) *Manager {
+	if m == nil || id == "" || id == m.GA4MeasurementID {
+		return m
+	}
+	cp := *m
+	cp.GA4MeasurementID = id
+	return &cp
+}
+
+// SendMeasurementProtocolPageView gtag sayfada çalışmadığında page_view'ı Measurement Protocol ile gönderir.
+// MPAPISecret ayarlı değilse hiçbir şey yapmaz.
+func (m *Manager) SendMeasurementProtocolPageView(pageTitle, pageLocation, pageReferrer string) error {
+	if m == nil || m.MPAPISecret == "" || m.GA4MeasurementID == "" {
+		return nil
+	}
+	client := NewGA4Client(GA4Config{
+		MeasurementID: m.GA4MeasurementID,
+		APISecret:     m.MPAPISecret,
+		Server:        m.Server,
+		Seeder:        m.Seeder,
+	})
+	if m.Mapping == nil && m.TrafficType == "" {
+		return client.SendPageView(pageTitle, pageLocation, pageReferrer)
+	}
+	page := map[string]string{"page_location": pageLocation, "page_title": pageTitle}
+	if u, err := url.Parse(pageLocation); err == nil {
+		page["page_path"] = u.Path
+	}
+	name, params := m.Mapping.Apply("page_view", Event{
+		Type:   EventPageView,
+		Action: "page_view",
+		Parameters: map[string]interface{}{
+			"page_title":    pageTitle,
+			"page_location": pageLocation,
+			"page_referrer": pageReferrer,
+		},
+	}, page)
+	return client.SendCustomEvent(name, m.labelTraffic(params))
+}
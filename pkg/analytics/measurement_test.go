@@ -0,0 +1,36 @@
+package analytics
+
+import "testing"
+
+func TestResolveMeasurementID(t *testing.T) {
+	cases := []struct {
+		page     []string
+		fallback string
+		want     string
+	}{
+		{nil, "G-FALLBACK1", "G-FALLBACK1"},
+		{[]string{"G-BLOG00001"}, "G-FALLBACK1", "G-BLOG00001"},
+		{[]string{"G-BLOG00001", "G-FALLBACK1"}, "g-fallback1", "G-FALLBACK1"},
+		{[]string{"G-SHOP00001"}, "", "G-SHOP00001"},
+	}
+	for _, c := range cases {
+		if got := ResolveMeasurementID(c.page, c.fallback); got != c.want {
+			t.Errorf("ResolveMeasurementID(%v, %q) = %q, want %q", c.page, c.fallback, got, c.want)
+		}
+	}
+}
+
+func TestForMeasurementID(t *testing.T) {
+	m := &Manager{GA4Enabled: true, GA4MeasurementID: "G-FALLBACK1"}
+	if m.ForMeasurementID("") != m || m.ForMeasurementID("G-FALLBACK1") != m {
+		t.Fatal("same or empty ID should return the manager unchanged")
+	}
+	cp := m.ForMeasurementID("G-BLOG00001")
+	if cp == m || cp.GA4MeasurementID != "G-BLOG00001" || m.GA4MeasurementID != "G-FALLBACK1" {
+		t.Fatalf("copy = %+v, original = %+v", cp, m)
+	}
+	var nilMgr *Manager
+	if nilMgr.ForMeasurementID("G-BLOG00001") != nil {
+		t.Fatal("nil manager should stay nil")
+	}
+}
@@ -0,0 +1,191 @@
+package analytics
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MaxMPBatchEvents Measurement Protocol'ün istek başına kabul ettiği en fazla event sayısı
+const MaxMPBatchEvents = 25
+
+// Measurement Protocol uç noktaları (testlerde değiştirilir)
+var (
+	ga4CollectURL = "https://www.google-analytics.com/mp/collect"
+	ga4DebugURL   = "https://www.google-analytics.com/debug/mp/collect"
+)
+
+// MPBatchConfig toplu gönderim ayarları
+type MPBatchConfig struct {
+	// DryRun event'leri yalnızca Google'ın doğrulama uç noktasına (/debug/mp/collect) gönderir;
+	// GA4'e veri yazılmaz, kabul/ret sayıları doğrulama mesajlarından çıkarılır
+	DryRun bool
+	// ValidationBehavior dry-run doğrulama seviyesi: RELAXED (varsayılan) veya ENFORCE_RECOMMENDATIONS
+	ValidationBehavior string
+}
+
+// MPBatchStats Measurement Protocol gönderim sayaçları
+type MPBatchStats struct {
+	Requests int `json:"requests"`
+	Accepted int `json:"accepted"`
+	Rejected int `json:"rejected"`
+}
+
+// MPValidationMessage doğrulama uç noktasının döndüğü mesaj
+type MPValidationMessage struct {
+	FieldPath      string `json:"fieldPath"`
+	Description    string `json:"description"`
+	ValidationCode string `json:"validationCode"`
+}
+
+// MPBatcher GA4 client'ın event'lerini kuyruğa alır ve MaxMPBatchEvents'lik isteklerle gönderir
+type MPBatcher struct {
+	client   *GA4Client
+	config   MPBatchConfig
+	mu       sync.Mutex
+	pending  []GA4Event
+	stats    MPBatchStats
+	messages []MPValidationMessage // Son dry-run isteğinin doğrulama mesajları
+}
+
+// NewMPBatcher client için batching'i açar: client'ın Send* çağrıları bundan sonra kuyruğa alınır
+func NewMPBatcher(client *GA4Client, config MPBatchConfig) (*MPBatcher, error) {
+	switch config.ValidationBehavior = strings.ToUpper(strings.TrimSpace(config.ValidationBehavior)); config.ValidationBehavior {
+	case "":
+		if config.DryRun {
+			config.ValidationBehavior = "RELAXED"
+		}
+	case "RELAXED", "ENFORCE_RECOMMENDATIONS":
+		if !config.DryRun {
+			return nil, fmt.Errorf("validation behavior %s yalnızca dry-run modunda geçerli", config.ValidationBehavior)
+		}
+	default:
+		return nil, fmt.Errorf("geçersiz validation behavior %q (RELAXED veya ENFORCE_RECOMMENDATIONS)", config.ValidationBehavior)
+	}
+	b := &MPBatcher{client: client, config: config}
+	client.batcher = b
+	return b, nil
+}
+
+// Add event'i kuyruğa alır; kuyruk MaxMPBatchEvents'e ulaşınca gönderir
+func (b *MPBatcher) Add(event GA4Event) error {
+	if event.TimestampMicros == 0 {
+		event.TimestampMicros = time.Now().UnixMicro()
+	}
+	b.mu.Lock()
+	b.pending = append(b.pending, event)
+	full := len(b.pending) >= MaxMPBatchEvents
+	b.mu.Unlock()
+	if full {
+		return b.Flush()
+	}
+	return nil
+}
+
+// Pending gönderilmeyi bekleyen event sayısı
+func (b *MPBatcher) Pending() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.pending)
+}
+
+// Flush kuyruktaki event'leri MaxMPBatchEvents'lik isteklerle gönderir. Başarısız istekteki event'ler
+// reddedilmiş sayılır ve yeniden denenmez.
+func (b *MPBatcher) Flush() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var firstErr error
+	for len(b.pending) > 0 {
+		n := min(len(b.pending), MaxMPBatchEvents)
+		if err := b.sendLocked(b.pending[:n]); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		b.pending = b.pending[n:]
+	}
+	b.pending = nil
+	return firstErr
+}
+
+// sendLocked tek isteği gönderir ve sayaçları günceller (b.mu tutulurken çağrılır)
+func (b *MPBatcher) sendLocked(events []GA4Event) error {
+	c := b.client
+	c.mu.Lock()
+	payload := GA4Payload{
+		ClientID: c.config.ClientID,
+		UserID:   c.config.UserID,
+		Events:   events,
+	}
+	c.mu.Unlock()
+	if b.config.DryRun {
+		payload.ValidationBehavior = b.config.ValidationBehavior
+	}
+
+	b.stats.Requests++
+	body, err := c.post(payload, b.config.DryRun)
+	if err != nil {
+		b.stats.Rejected += len(events)
+		return err
+	}
+	if !b.config.DryRun {
+		// Canlı uç nokta her zaman 2xx döner; event bazında sonuç yoktur
+		b.stats.Accepted += len(events)
+		return nil
+	}
+
+	var resp struct {
+		ValidationMessages []MPValidationMessage `json:"validationMessages"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		b.stats.Rejected += len(events)
+		return fmt.Errorf("doğrulama yanıtı okunamadı: %w", err)
+	}
+	b.messages = resp.ValidationMessages
+	rejected := rejectedEvents(resp.ValidationMessages, len(events))
+	b.stats.Accepted += len(events) - rejected
+	b.stats.Rejected += rejected
+	if rejected > 0 {
+		return fmt.Errorf("GA4 doğrulama: %d/%d event reddedildi: %s", rejected, len(events), resp.ValidationMessages[0].Description)
+	}
+	return nil
+}
+
+// mpEventIndex doğrulama mesajında event sırasını bulur ("events[3]" veya "index: [3]")
+var mpEventIndex = regexp.MustCompile(`(?:events\[|index: \[)(\d+)\]`)
+
+// rejectedEvents doğrulama mesajlarından reddedilen event sayısını çıkarır. Event sırası belirtmeyen
+// mesaj (ör. client_id hatası) isteğin tamamını geçersiz kılar.
+func rejectedEvents(messages []MPValidationMessage, total int) int {
+	bad := map[int]bool{}
+	for _, m := range messages {
+		match := mpEventIndex.FindStringSubmatch(m.FieldPath)
+		if match == nil {
+			match = mpEventIndex.FindStringSubmatch(m.Description)
+		}
+		if match == nil {
+			return total
+		}
+		if i, err := strconv.Atoi(match[1]); err == nil && i < total {
+			bad[i] = true
+		}
+	}
+	return len(bad)
+}
+
+// Stats gönderim sayaçlarının kopyası
+func (b *MPBatcher) Stats() MPBatchStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.stats
+}
+
+// ValidationMessages son dry-run isteğinin doğrulama mesajları
+func (b *MPBatcher) ValidationMessages() []MPValidationMessage {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]MPValidationMessage(nil), b.messages...)
+}
@@ -0,0 +1,95 @@
+package analytics
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMPBatcherSplitsRequests(t *testing.T) {
+	var sizes []int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload GA4Payload
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &payload)
+		if r.URL.Path != "/mp/collect" || payload.ValidationBehavior != "" {
+			t.Errorf("live request path %q validation %q", r.URL.Path, payload.ValidationBehavior)
+		}
+		sizes = append(sizes, len(payload.Events))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+	defer func(u string) { ga4CollectURL = u }(ga4CollectURL)
+	ga4CollectURL = srv.URL + "/mp/collect"
+
+	client := NewGA4Client(GA4Config{MeasurementID: "G-ABC1234567", APISecret: "s"})
+	b, err := NewMPBatcher(client, MPBatchConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 30; i++ {
+		if err := client.SendCustomEvent(fmt.Sprintf("e%d", i), nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 25. event isteği tetikler, kalan 5 Flush'a kadar bekler
+	if len(sizes) != 1 || sizes[0] != MaxMPBatchEvents || b.Pending() != 5 {
+		t.Fatalf("sizes %v pending %d", sizes, b.Pending())
+	}
+	if err := b.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if st := b.Stats(); len(sizes) != 2 || st != (MPBatchStats{Requests: 2, Accepted: 30}) {
+		t.Errorf("sizes %v stats %+v", sizes, st)
+	}
+}
+
+func TestMPBatcherDryRunValidation(t *testing.T) {
+	var behavior string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload GA4Payload
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &payload)
+		behavior = payload.ValidationBehavior
+		if r.URL.Path != "/debug/mp/collect" {
+			t.Errorf("dry-run path %q", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"validationMessages":[
+			{"fieldPath":"events","description":"Event at index: [1] has invalid name [_bad].","validationCode":"NAME_INVALID"},
+			{"fieldPath":"events[1].params","description":"Param name too long.","validationCode":"NAME_INVALID"},
+			{"fieldPath":"events[2].params.value","description":"Value must be a number.","validationCode":"VALUE_INVALID"}]}`)
+	}))
+	defer srv.Close()
+	defer func(u string) { ga4DebugURL = u }(ga4DebugURL)
+	ga4DebugURL = srv.URL + "/debug/mp/collect"
+
+	// sGTM ayarlı olsa da doğrulama Google'ın debug uç noktasına gider
+	server, _ := NewServerEndpoint("https://sgtm.invalid", "", "", nil)
+	client := NewGA4Client(GA4Config{MeasurementID: "G-ABC1234567", APISecret: "s", Server: server})
+	b, err := NewMPBatcher(client, MPBatchConfig{DryRun: true, ValidationBehavior: "enforce_recommendations"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.SendBatch([]GA4Event{{Name: "page_view"}, {Name: "_bad"}, {Name: "purchase"}, {Name: "scroll"}})
+	if err := b.Flush(); err == nil {
+		t.Error("expected validation error")
+	}
+	if st := b.Stats(); st != (MPBatchStats{Requests: 1, Accepted: 2, Rejected: 2}) || behavior != "ENFORCE_RECOMMENDATIONS" {
+		t.Errorf("stats %+v behavior %q", st, behavior)
+	}
+	if len(b.ValidationMessages()) != 3 {
+		t.Errorf("messages %+v", b.ValidationMessages())
+	}
+
+	if rejectedEvents([]MPValidationMessage{{FieldPath: "client_id", Description: "Client ID is required."}}, 4) != 4 {
+		t.Error("payload-level message should reject the whole batch")
+	}
+	for _, cfg := range []MPBatchConfig{{ValidationBehavior: "RELAXED"}, {DryRun: true, ValidationBehavior: "STRICT"}} {
+		if _, err := NewMPBatcher(NewGA4Client(GA4Config{}), cfg); err == nil {
+			t.Errorf("NewMPBatcher(%+v): expected error", cfg)
+		}
+	}
+}
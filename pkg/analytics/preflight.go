@@ -0,0 +1,137 @@
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"vgbot/pkg/secaudit"
+)
+
+// ============================================================================
+// ANALYTICS PRE-FLIGHT
+// ============================================================================
+
+// Pre-flight uyarı kodları (UI i18n anahtarına çevirir)
+const (
+	WarnNoAnalytics    = "no_analytics"     // Sayfada hiçbir analytics etiketi bulunamadı
+	WarnIDMismatch     = "id_mismatch"      // Yapılandırılan GA4 ID sayfada yok, başka ID var
+	WarnGTMOnly        = "gtm_only"         // Yalnızca GTM container'ı var; GA4 ID HTML'den doğrulanamıyor
+	WarnMatomoOnly     = "matomo_only"      // Sitede GA4 yok, Matomo var
+	WarnNoConfiguredID = "no_configured_id" // Sayfada GA4 var ama GtagID ayarlanmamış
+	WarnUniversalOnly  = "universal_only"   // Yalnızca eski Universal Analytics (UA-) ID'si var
+)
+
+var (
+	reGA4ID  = regexp.MustCompile(`\bG-[A-Z0-9]{6,12}\b`)
+	reGTMID  = regexp.MustCompile(`\bGTM-[A-Z0-9]{4,10}\b`)
+	reUAID   = regexp.MustCompile(`\bUA-\d{4,10}-\d{1,4}\b`)
+	reMatomo = regexp.MustCompile(`(?i)(matomo|piwik)\.(js|php)|_paq\.push`)
+	// _paq.push(['setSiteId', '3']) veya matomo.php?idsite=3
+	reMatomoSite = regexp.MustCompile(`(?i)(?:setSiteId['"]?\s*,\s*['"]?|idsite=)(\d+)`)
+)
+
+// Detection hedef sayfada bulunan analytics yığınları ve yapılandırmayla karşılaştırma sonucu
+type Detection struct {
+	URL          string            `json:"url"`
+	StatusCode   int               `json:"status_code"`
+	GA4IDs       []string          `json:"ga4_ids"`
+	GTMIDs       []string          `json:"gtm_ids"`
+	UAIDs        []string          `json:"ua_ids"`
+	Matomo       bool              `json:"matomo"`
+	MatomoSiteID string            `json:"matomo_site_id,omitempty"`
+	ConfiguredID string            `json:"configured_id"`
+	Match        bool              `json:"match"` // Yapılandırılan GA4 ID sayfada bulundu
+	Warnings     []string          `json:"warnings"`
+	Security     *secaudit.Summary `json:"security,omitempty"` // Aynı yanıttan sertifika ve güvenlik header'ları
+}
+
+// DetectTags HTML içindeki analytics etiketlerini tespit eder
+func DetectTags(html string) Detection {
+	d := Detection{
+		GA4IDs: uniqueMatches(reGA4ID, html),
+		GTMIDs: uniqueMatches(reGTMID, html),
+		UAIDs:  uniqueMatches(reUAID, html),
+		Matomo: reMatomo.MatchString(html),
+	}
+	if m := reMatomoSite.FindStringSubmatch(html); d.Matomo && m != nil {
+		d.MatomoSiteID = m[1]
+	}
+	return d
+}
+
+// Compare tespit sonucunu yapılandırılan GA4 ID ile karşılaştırıp uyarıları doldurur
+func (d *Detection) Compare(configuredID string) {
+	d.ConfiguredID = strings.TrimSpace(configuredID)
+	d.Warnings = nil
+	for _, id := range d.GA4IDs {
+		if strings.EqualFold(id, d.ConfiguredID) {
+			d.Match = true
+		}
+	}
+	hasGoogle := len(d.GA4IDs) > 0 || len(d.GTMIDs) > 0 || len(d.UAIDs) > 0
+	switch {
+	case !hasGoogle && !d.Matomo:
+		d.Warnings = append(d.Warnings, WarnNoAnalytics)
+	case !hasGoogle && d.Matomo:
+		d.Warnings = append(d.Warnings, WarnMatomoOnly)
+	case d.ConfiguredID == "" && len(d.GA4IDs) > 0:
+		d.Warnings = append(d.Warnings, WarnNoConfiguredID)
+	case d.ConfiguredID == "" || d.Match:
+	case len(d.GA4IDs) > 0:
+		d.Warnings = append(d.Warnings, WarnIDMismatch)
+	case len(d.GTMIDs) > 0:
+		d.Warnings = append(d.Warnings, WarnGTMOnly)
+	case len(d.UAIDs) > 0:
+		d.Warnings = append(d.Warnings, WarnUniversalOnly)
+	}
+}
+
+// Preflight hedef ana sayfayı indirir, analytics etiketlerini tespit eder ve configuredID ile karşılaştırır.
+// Aynı yanıtın TLS durumu ve header'ları Security özetine yazılır.
+// Ağ hatası error döner; sayfa 2xx değilse yine de içerik taranır (status_code raporlanır).
+func Preflight(ctx context.Context, pageURL, configuredID string) (*Detection, error) {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0 Safari/537.36")
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+	resp, err := (&http.Client{Timeout: 20 * time.Second}).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("pre-flight: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4<<20))
+	if err != nil {
+		return nil, fmt.Errorf("pre-flight: %w", err)
+	}
+
+	d := DetectTags(string(body))
+	d.URL = pageURL
+	d.StatusCode = resp.StatusCode
+	d.Compare(configuredID)
+	d.Security = secaudit.FromResponse(resp, time.Now())
+	return &d, nil
+}
+
+func uniqueMatches(re *regexp.Regexp, s string) []string {
+	seen := make(map[string]bool)
+	out := []string{}
+	for _, m := range re.FindAllString(s, -1) {
+		if !seen[m] {
+			seen[m] = true
+			out = append(out, m)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
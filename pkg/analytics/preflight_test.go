@@ -0,0 +1,66 @@
+package analytics
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDetectTagsAndCompare(t *testing.T) {
+	cases := []struct {
+		name       string
+		html       string
+		configured string
+		match      bool
+		warnings   []string
+	}{
+		{
+			name:       "ga4 match",
+			html:       `<script async src="https://www.googletagmanager.com/gtag/js?id=G-ABC1234567"></script><script>gtag('config','G-ABC1234567')</script>`,
+			configured: "G-ABC1234567",
+			match:      true,
+		},
+		{
+			name:       "ga4 mismatch",
+			html:       `<script>gtag('config','G-OTHER12345')</script>`,
+			configured: "G-ABC1234567",
+			warnings:   []string{WarnIDMismatch},
+		},
+		{
+			name:       "gtm only",
+			html:       `<script>(function(w,d,s,l,i){})(window,document,'script','dataLayer','GTM-K9X2AB');</script>`,
+			configured: "G-ABC1234567",
+			warnings:   []string{WarnGTMOnly},
+		},
+		{
+			name:       "matomo only",
+			html:       `<script>var _paq = window._paq || []; _paq.push(['setSiteId', '7']);</script><script src="//stats.example.com/matomo.js"></script>`,
+			configured: "G-ABC1234567",
+			warnings:   []string{WarnMatomoOnly},
+		},
+		{
+			name:       "nothing",
+			html:       `<html><body>hello</body></html>`,
+			configured: "G-ABC1234567",
+			warnings:   []string{WarnNoAnalytics},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			d := DetectTags(c.html)
+			d.Compare(c.configured)
+			if d.Match != c.match {
+				t.Errorf("match = %v, want %v", d.Match, c.match)
+			}
+			if !reflect.DeepEqual(d.Warnings, c.warnings) {
+				t.Errorf("warnings = %v, want %v", d.Warnings, c.warnings)
+			}
+		})
+	}
+}
+
+func TestDetectTagsMatomoSite(t *testing.T) {
+	d := DetectTags(`<img src="https://stats.example.com/matomo.php?idsite=3&rec=1">`)
+	if !d.Matomo || d.MatomoSiteID != "3" {
+		t.Fatalf("matomo = %v site = %q", d.Matomo, d.MatomoSiteID)
+	}
+}
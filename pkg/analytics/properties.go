@@ -0,0 +1,96 @@
+package analytics
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+
+	"vgbot/pkg/utils"
+)
+
+// Property ziyaretlerin yönlendirilebileceği GA4 mülkü
+type Property struct {
+	MeasurementID string
+	APISecret     string
+	Label         string
+	Share         float64
+}
+
+// PropertySplit ziyaretleri paylarına göre GA4 mülkleri arasında dağıtır
+// (ör. %90 production, %10 sandbox mülkü — event'leri güvenle doğrulamak için)
+type PropertySplit struct {
+	props []Property
+	total float64
+
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+// NewPropertySplit mülk listesinden split oluşturur; liste boşsa nil döner.
+// Tüm paylar 0 ise mülkler eşit paylaşılır. Seçimler seeder'dan türetilir (nil = tekrarlanamaz).
+func NewPropertySplit(props []Property, seeder *utils.Seeder) *PropertySplit {
+	if len(props) == 0 {
+		return nil
+	}
+	s := &PropertySplit{props: append([]Property(nil), props...), rng: seeder.NewRand()}
+	for _, p := range s.props {
+		s.total += p.Share
+	}
+	if s.total == 0 {
+		for i := range s.props {
+			s.props[i].Share = 1
+		}
+		s.total = float64(len(s.props))
+	}
+	return s
+}
+
+// Pick bir ziyaret için mülk seçer (eşzamanlı kullanım güvenli)
+func (s *PropertySplit) Pick() Property {
+	s.mu.Lock()
+	r := s.rng.Float64()
+	s.mu.Unlock()
+	return s.pick(r)
+}
+
+// pick r ∈ [0,1) değerine karşılık gelen mülkü döner
+func (s *PropertySplit) pick(r float64) Property {
+	acc := 0.0
+	for _, p := range s.props {
+		acc += p.Share / s.total
+		if r < acc {
+			return p
+		}
+	}
+	return s.props[len(s.props)-1]
+}
+
+// Describe split'i log için satırlara çevirir: "G-XXXX (sandbox) %10.0"
+func (s *PropertySplit) Describe() []string {
+	if s == nil {
+		return nil
+	}
+	out := make([]string, 0, len(s.props))
+	for _, p := range s.props {
+		name := p.MeasurementID
+		if p.Label != "" {
+			name += " (" + p.Label + ")"
+		}
+		out = append(out, fmt.Sprintf("%s %%%.1f", name, p.Share/s.total*100))
+	}
+	return out
+}
+
+// ForProperty manager'ın verilen mülke (ID + Measurement Protocol secret) yönlendirilmiş kopyasını döner
+func (m *Manager) ForProperty(p Property) *Manager {
+	if m == nil {
+		return nil
+	}
+	cp := *m
+	cp.GA4Enabled = true
+	cp.GA4MeasurementID = p.MeasurementID
+	if p.APISecret != "" {
+		cp.MPAPISecret = p.APISecret
+	}
+	return &cp
+}
@@ -0,0 +1,33 @@
+package analytics
+
+import "testing"
+
+func TestPropertySplitPick(t *testing.T) {
+	s := NewPropertySplit([]Property{
+		{MeasurementID: "G-PROD000001", Share: 90, Label: "prod"},
+		{MeasurementID: "G-TEST000001", Share: 10, Label: "sandbox"},
+	}, nil)
+	cases := map[float64]string{0: "G-PROD000001", 0.89: "G-PROD000001", 0.9: "G-TEST000001", 0.999: "G-TEST000001"}
+	for r, want := range cases {
+		if got := s.pick(r).MeasurementID; got != want {
+			t.Errorf("pick(%v) = %s, want %s", r, got, want)
+		}
+	}
+	counts := map[string]int{}
+	for i := 0; i < 10000; i++ {
+		counts[s.Pick().MeasurementID]++
+	}
+	if n := counts["G-TEST000001"]; n < 800 || n > 1200 {
+		t.Errorf("sandbox share = %d/10000, want ~1000", n)
+	}
+}
+
+func TestPropertySplitEqualWhenNoShares(t *testing.T) {
+	s := NewPropertySplit([]Property{{MeasurementID: "G-A00000001"}, {MeasurementID: "G-B00000001"}}, nil)
+	if s.pick(0.49).MeasurementID != "G-A00000001" || s.pick(0.51).MeasurementID != "G-B00000001" {
+		t.Fatal("zero shares should split evenly")
+	}
+	if NewPropertySplit(nil, nil) != nil {
+		t.Fatal("empty property list should give nil split")
+	}
+}
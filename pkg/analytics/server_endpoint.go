@@ -0,0 +1,91 @@
+package analytics
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"vgbot/pkg/network"
+)
+
+const (
+	defaultServerScriptPath  = "/gtag/js"
+	defaultServerCollectPath = "/mp/collect"
+)
+
+// ServerEndpoint birinci taraf sunucu taraflı GTM (sGTM) adresi. Ayarlıysa gtag.js bu adresten yüklenir,
+// tarayıcı beacon'ları transport_url/server_container_url ile buraya gider ve Measurement Protocol
+// istekleri google-analytics.com yerine CollectURL'e gönderilir.
+type ServerEndpoint struct {
+	base        *url.URL
+	scriptPath  string
+	collectPath string
+	headers     map[string]string // Measurement Protocol isteklerine eklenir (ör. sGTM erişim anahtarı)
+}
+
+// NewServerEndpoint sGTM adresini doğrular; baseURL boşsa nil döner (Google'a doğrudan gönderilir).
+// Boş yollar varsayılanlara (/gtag/js, /mp/collect) düşer; headers "Name: value" satırlarıdır.
+func NewServerEndpoint(baseURL, scriptPath, collectPath string, headers []string) (*ServerEndpoint, error) {
+	baseURL = strings.TrimSpace(baseURL)
+	if baseURL == "" {
+		return nil, nil
+	}
+	if strings.ContainsAny(baseURL, "'\"\\<> ") {
+		return nil, fmt.Errorf("geçersiz sGTM adresi %q", baseURL)
+	}
+	u, err := url.Parse(baseURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return nil, fmt.Errorf("geçersiz sGTM adresi %q (https://alan.adi bekleniyor)", baseURL)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return nil, fmt.Errorf("sGTM adresi %q sorgu veya fragment içeremez", baseURL)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	e := &ServerEndpoint{base: u, scriptPath: defaultServerScriptPath, collectPath: defaultServerCollectPath}
+	for _, p := range []struct {
+		name string
+		val  string
+		dst  *string
+	}{
+		{"script", scriptPath, &e.scriptPath},
+		{"collect", collectPath, &e.collectPath},
+	} {
+		v := strings.TrimSpace(p.val)
+		if v == "" {
+			continue
+		}
+		if !strings.HasPrefix(v, "/") || strings.ContainsAny(v, "?#'\" ") {
+			return nil, fmt.Errorf("geçersiz sGTM %s yolu %q (/ ile başlamalı)", p.name, p.val)
+		}
+		*p.dst = v
+	}
+	if e.headers, err = network.CampaignHeaders("", "", headers); err != nil {
+		return nil, fmt.Errorf("sGTM header: %w", err)
+	}
+	return e, nil
+}
+
+// BaseURL sGTM kök adresi (transport_url)
+func (e *ServerEndpoint) BaseURL() string {
+	return e.base.String()
+}
+
+// Host sGTM alan adı (kaynak engellemede izin listesi için)
+func (e *ServerEndpoint) Host() string {
+	return e.base.Host
+}
+
+// ScriptURL measurement ID için gtag.js adresi
+func (e *ServerEndpoint) ScriptURL(measurementID string) string {
+	return e.BaseURL() + e.scriptPath + "?id=" + url.QueryEscape(measurementID)
+}
+
+// CollectURL Measurement Protocol adresi; debug doğrulama uç noktası sGTM'de yoktur, istek aynı yere gider
+func (e *ServerEndpoint) CollectURL() string {
+	return e.BaseURL() + e.collectPath
+}
+
+// Headers Measurement Protocol isteklerine eklenecek header'lar
+func (e *ServerEndpoint) Headers() map[string]string {
+	return e.headers
+}
@@ -0,0 +1,70 @@
+package analytics
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewServerEndpoint(t *testing.T) {
+	if e, err := NewServerEndpoint(" ", "", "", nil); e != nil || err != nil {
+		t.Fatalf("empty = %v, %v", e, err)
+	}
+	for _, bad := range [][2]string{
+		{"sgtm.example.com", ""},
+		{"ftp://sgtm.example.com", ""},
+		{"https://sgtm.example.com/?x=1", ""},
+		{"https://sgtm.example.com/a'b", ""},
+		{"https://sgtm.example.com", "collect"},
+	} {
+		if _, err := NewServerEndpoint(bad[0], "", bad[1], nil); err == nil {
+			t.Errorf("%q %q accepted", bad[0], bad[1])
+		}
+	}
+	if _, err := NewServerEndpoint("https://sgtm.example.com", "", "", []string{"no colon"}); err == nil {
+		t.Error("invalid header accepted")
+	}
+
+	e, err := NewServerEndpoint("https://sgtm.example.com/t/", "", "/data", []string{"X-Gtm-Key: abc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := e.ScriptURL("G-ABC1234567"); got != "https://sgtm.example.com/t/gtag/js?id=G-ABC1234567" {
+		t.Errorf("ScriptURL = %q", got)
+	}
+	if got := e.CollectURL(); got != "https://sgtm.example.com/t/data" {
+		t.Errorf("CollectURL = %q", got)
+	}
+	if e.Host() != "sgtm.example.com" || e.Headers()["X-Gtm-Key"] != "abc" {
+		t.Errorf("host %q headers %v", e.Host(), e.Headers())
+	}
+}
+
+func TestMeasurementProtocolViaServerEndpoint(t *testing.T) {
+	var gotPath, gotKey, gotID string
+	var payload GA4Payload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotKey, gotID = r.URL.Path, r.Header.Get("X-Gtm-Key"), r.URL.Query().Get("measurement_id")
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &payload)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	e, err := NewServerEndpoint(srv.URL, "", "/mp/collect", []string{"X-Gtm-Key: abc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := &Manager{GA4Enabled: true, GA4MeasurementID: "G-ABC1234567", MPAPISecret: "s", Server: e}
+	if err := m.SendMeasurementProtocolPageView("Home", "https://site.example/", ""); err != nil {
+		t.Fatal(err)
+	}
+	if gotPath != "/mp/collect" || gotKey != "abc" || gotID != "G-ABC1234567" {
+		t.Errorf("request path %q key %q id %q", gotPath, gotKey, gotID)
+	}
+	if len(payload.Events) != 1 || payload.Events[0].Name != "page_view" {
+		t.Errorf("payload = %+v", payload)
+	}
+}
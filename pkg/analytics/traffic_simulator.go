@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -16,6 +17,8 @@ import (
 	"github.com/chromedp/cdproto/cdp"
 	"github.com/chromedp/cdproto/network"
 	"github.com/chromedp/chromedp"
+
+	"vgbot/pkg/utils"
 )
 
 // ============================================================================
@@ -38,8 +41,11 @@ type TrafficSimulator struct {
 // TrafficSimulatorConfig simülatör yapılandırması
 type TrafficSimulatorConfig struct {
 	// GA4 ayarları
-	GA4MeasurementID string
-	GA4APISecret     string
+	GA4MeasurementID      string
+	GA4APISecret          string
+	GA4BatchEvents        bool   // Measurement Protocol event'leri 25'lik isteklerle gönderilir
+	GA4DryRun             bool   // Event'ler yalnızca GA4 doğrulama uç noktasına gider
+	GA4ValidationBehavior string // Dry-run doğrulama: RELAXED veya ENFORCE_RECOMMENDATIONS
 	
 	// UA ayarları (legacy)
 	UATrackingID     string
@@ -54,6 +60,7 @@ type TrafficSimulatorConfig struct {
 	ClickProbability    float64
 	ScrollProbability   float64
 	EngagementThreshold time.Duration
+	SessionTimeout      time.Duration // GA4 oturum zaman aşımı (hareketsizlik; varsayılan 30dk)
 	
 	// v2.3.0 - Bounce Rate Control
 	TargetBounceRate     int
@@ -99,6 +106,8 @@ type TrafficSimulatorConfig struct {
 	
 	// Debug
 	Debug bool
+
+	Seeder *utils.Seeder // Çalıştırmanın seed kaynağı (nil = tekrarlanamaz)
 }
 
 // GSCQuery Google Search Console sorgusu
@@ -129,6 +138,13 @@ type SessionData struct {
 	ProfileID       string
 	VisitedPages    []string
 	IsBounce        bool
+	Engagement      *EngagementClock // Ön plan süresi (ilk page_view ile başlar)
+	SessionNumber   int              // ga_session_number: zaman aşımıyla yeni oturum açıldıkça artar
+	SessionStarts   int              // Bu ziyarette gönderilen session_start sayısı
+	GA              GASessionState   // _ga_<container> çerez durumu (profil ile ziyaretler arası taşınır)
+	GAPrior         GASessionState   // Ziyaret başındaki çerez durumu (tarayıcıya yazılır)
+	SessionPages      int            // Mevcut GA oturumundaki page_view sayısı
+	SessionEngagement time.Duration  // Mevcut GA oturumunda raporlanan engagement süresi
 }
 
 // ClickData tıklama verisi
@@ -160,6 +176,7 @@ type BrowserProfile struct {
 	CreatedAt      time.Time              `json:"created_at"`
 	LastVisit      time.Time              `json:"last_visit"`
 	VisitCount     int                    `json:"visit_count"`
+	GASession      GASessionState         `json:"ga_session"` // _ga_<container> çerez durumu
 	Fingerprint    map[string]interface{} `json:"fingerprint"`
 }
 
@@ -208,6 +225,9 @@ func NewTrafficSimulator(config TrafficSimulatorConfig) *TrafficSimulator {
 	if config.EngagementThreshold == 0 {
 		config.EngagementThreshold = 10 * time.Second
 	}
+	if config.SessionTimeout == 0 {
+		config.SessionTimeout = 30 * time.Minute
+	}
 	
 	// v2.3.0 defaults
 	if config.TargetBounceRate == 0 {
@@ -236,19 +256,23 @@ func NewTrafficSimulator(config TrafficSimulatorConfig) *TrafficSimulator {
 	}
 	
 	ts := &TrafficSimulator{
-		rng:       mrand.New(mrand.NewSource(time.Now().UnixNano())),
+		rng:       config.Seeder.NewRand(),
 		validator: NewTrafficValidator(),
 		config:    config,
 	}
 	
 	// Analytics tracker oluştur
 	ts.tracker = NewAnalyticsTracker(AnalyticsTrackerConfig{
-		GA4MeasurementID: config.GA4MeasurementID,
-		GA4APISecret:     config.GA4APISecret,
-		UATrackingID:     config.UATrackingID,
-		EnableGA4:        config.GA4MeasurementID != "" && config.GA4APISecret != "",
-		EnableUA:         config.UATrackingID != "",
-		EnableInjection:  true,
+		GA4MeasurementID:      config.GA4MeasurementID,
+		GA4APISecret:          config.GA4APISecret,
+		UATrackingID:          config.UATrackingID,
+		EnableGA4:             config.GA4MeasurementID != "" && config.GA4APISecret != "",
+		EnableUA:              config.UATrackingID != "",
+		EnableInjection:       true,
+		GA4BatchEvents:        config.GA4BatchEvents,
+		GA4DryRun:             config.GA4DryRun,
+		GA4ValidationBehavior: config.GA4ValidationBehavior,
+		Seeder:                config.Seeder,
 	})
 	
 	// Profile manager
@@ -475,6 +499,7 @@ func (ts *TrafficSimulator) StartSession(keyword, referrer string) {
 	var clientID string
 	var isReturning bool
 	var profileID string
+	var gaState GASessionState
 	
 	// Returning visitor kontrolü
 	if ts.config.EnableReturningVisitor && ts.returningPool != nil && ts.returningPool.ShouldBeReturning() {
@@ -483,10 +508,16 @@ func (ts *TrafficSimulator) StartSession(keyword, referrer string) {
 				clientID = profile.ClientID
 				profileID = profile.ID
 				isReturning = true
+				gaState = profile.GASession
+				if gaState.SessionCount == 0 {
+					// Eski profil: çerez durumu yok, ziyaret sayısından türet
+					gaState = GASessionState{SessionCount: profile.VisitCount, LastHit: profile.LastVisit.Unix()}
+				}
 			}
 		} else if existingID := ts.returningPool.GetReturningClientID(); existingID != "" {
 			clientID = existingID
 			isReturning = true
+			gaState = GASessionState{SessionCount: 1} // Önceki oturumun zamanı bilinmiyor: yeni oturum açılır
 		}
 	}
 	
@@ -509,6 +540,12 @@ func (ts *TrafficSimulator) StartSession(keyword, referrer string) {
 		ProfileID:       profileID,
 		VisitedPages:    make([]string, 0),
 		IsBounce:        true, // Başlangıçta bounce, 2+ sayfa görürse false olur
+		SessionNumber:   gaState.SessionCount,
+		GA:              gaState,
+		GAPrior:         gaState,
+	}
+	if ts.tracker != nil {
+		ts.tracker.StartNewSession(ts.sessionData.SessionID)
 	}
 	
 	// Returning visitor pool'a ekle
@@ -527,6 +564,17 @@ func (ts *TrafficSimulator) SimulateOrganicVisit(ctx context.Context, keyword, t
 		return fmt.Errorf("referrer ayarlama hatası: %w", err)
 	}
 	
+	// Returning visitor: önceki ziyaretin _ga/_ga_<container> çerezleri gtag yüklenmeden yazılır
+	if u, err := url.Parse(targetURL); err == nil && u.Hostname() != "" {
+		ts.mu.Lock()
+		clientID, prior := ts.sessionData.ClientID, ts.sessionData.GAPrior
+		ts.mu.Unlock()
+		domain := strings.TrimPrefix(u.Hostname(), "www.")
+		if err := NewCookieManager().SetGACookies(ctx, clientID, ts.config.GA4MeasurementID, prior, domain); err != nil {
+			_ = err
+		}
+	}
+	
 	// 2. Hedef sayfaya git
 	if err := ts.navigateToTarget(ctx, targetURL); err != nil {
 		return fmt.Errorf("navigasyon hatası: %w", err)
@@ -568,6 +616,11 @@ func (ts *TrafficSimulator) SimulateOrganicVisit(ctx context.Context, keyword, t
 		_ = err
 	}
 	
+	// Toplu gönderimde oturumun kalan GA4 event'leri gönderilir
+	if err := ts.tracker.FlushEvents(); err != nil {
+		_ = err
+	}
+	
 	// 9. Profil kaydet
 	if ts.config.EnableBrowserProfile && ts.profileManager != nil {
 		ts.saveCurrentProfile(ctx)
@@ -748,9 +801,14 @@ func (ts *TrafficSimulator) simulatePageBehavior(ctx context.Context) {
 func (ts *TrafficSimulator) saveCurrentProfile(ctx context.Context) {
 	ts.mu.Lock()
 	clientID := ts.sessionData.ClientID
+	gaState := ts.sessionData.GA
 	ts.mu.Unlock()
 	
 	profile := ts.profileManager.GetProfile(clientID)
+	// Sonraki ziyaret aynı çerez durumundan devam eder (zaman aşımı → yeni oturum)
+	if gaState.SessionCount > 0 {
+		profile.GASession = gaState
+	}
 	
 	// Cookies'i al
 	if ts.config.PersistCookies {
@@ -894,10 +952,18 @@ func (ts *TrafficSimulator) triggerAnalytics(ctx context.Context) error {
 		return err
 	}
 	
-	// Returning visitor bilgisi
+	// Returning visitor bilgisi; session_start oturumun ilk page_view'ında (veya zaman aşımından sonra) gönderilir
+	newSession := ts.touchSession(time.Now())
 	ts.mu.Lock()
+	ts.sessionData.SessionPages++
+	ts.markEngaged()
 	isReturning := ts.sessionData.IsReturning
+	sessionID := ts.sessionData.SessionID
+	sessionNumber := ts.sessionData.SessionNumber
 	ts.mu.Unlock()
+
+	// page_view, önceki sayfada raporlanmamış ön plan süresini taşır (ilk sayfada 0)
+	engagementMs := ts.startPageEngagement(time.Now()).Milliseconds()
 	
 	// Analytics tetikleme
 	triggerScript := fmt.Sprintf(`
@@ -906,6 +972,9 @@ func (ts *TrafficSimulator) triggerAnalytics(ctx context.Context) error {
 	var pageLocation = window.location.href;
 	var pageReferrer = document.referrer;
 	var isReturning = %v;
+	var engagementMs = %d;
+	var newSession = %v;
+	var sessionParams = {'ga_session_id': '%s', 'ga_session_number': %d};
 	
 	// gtag ile
 	if (typeof gtag === 'function') {
@@ -917,20 +986,23 @@ func (ts *TrafficSimulator) triggerAnalytics(ctx context.Context) error {
 		});
 		
 		// Page view event
-		gtag('event', 'page_view', {
+		var pageViewParams = Object.assign({
 			'page_title': pageTitle,
 			'page_location': pageLocation,
 			'page_referrer': pageReferrer
-		});
+		}, sessionParams);
+		if (engagementMs > 0) {
+			pageViewParams['engagement_time_msec'] = engagementMs;
+		}
+		gtag('event', 'page_view', pageViewParams);
 		
-		// Session start (sadece ilk sayfa için)
-		if (!window._sessionStarted) {
-			gtag('event', 'session_start');
-			window._sessionStarted = true;
+		// Session start: oturumun ilk sayfası veya zaman aşımı sonrası yeni oturum
+		if (newSession) {
+			gtag('event', 'session_start', sessionParams);
 			
-			// First visit veya returning
-			if (!isReturning) {
-				gtag('event', 'first_visit');
+			// First visit yalnızca yeni kullanıcının ilk oturumunda
+			if (!isReturning && sessionParams.ga_session_number === 1) {
+				gtag('event', 'first_visit', sessionParams);
 			}
 		}
 		
@@ -964,7 +1036,7 @@ func (ts *TrafficSimulator) triggerAnalytics(ctx context.Context) error {
 	
 	return {success: false, method: 'none'};
 })();
-`, isReturning)
+`, isReturning, engagementMs, newSession, sessionID, sessionNumber)
 	
 	var triggerResult map[string]interface{}
 	if err := chromedp.Run(ctx, chromedp.Evaluate(triggerScript, &triggerResult)); err != nil {
@@ -1044,7 +1116,7 @@ func (ts *TrafficSimulator) simulateUserBehavior(ctx context.Context) error {
 	}
 	
 	ts.mu.Lock()
-	ts.sessionData.TotalEngagement = time.Since(startTime)
+	ts.sessionData.TotalEngagement = ts.engagementClock().Total(time.Now())
 	ts.mu.Unlock()
 	
 	return nil
@@ -1154,54 +1226,153 @@ func (ts *TrafficSimulator) simulateSmallScroll(ctx context.Context) error {
 	return chromedp.Run(ctx, chromedp.Evaluate(script, &result))
 }
 
-// simulateFocusBlur focus/blur simüle eder
+// simulateFocusBlur sekmeyi kısa bir süre arka plana alır (blur + visibilitychange), sonra geri getirir.
+// Arka planda geçen süre engagement süresine sayılmaz.
 func (ts *TrafficSimulator) simulateFocusBlur(ctx context.Context) error {
-	script := `
+	blurScript := `
 (function() {
-	// Visibility change simüle et
+	Object.defineProperty(document, 'visibilityState', {value: 'hidden', configurable: true});
+	Object.defineProperty(document, 'hidden', {value: true, configurable: true});
+	document.dispatchEvent(new Event('visibilitychange'));
+	window.dispatchEvent(new Event('blur'));
+	return true;
+})();
+`
+	focusScript := `
+(function() {
+	delete document.visibilityState;
+	delete document.hidden;
+	document.dispatchEvent(new Event('visibilitychange'));
 	window.dispatchEvent(new Event('focus'));
-	
-	// Bazı elementlere focus
-	var focusable = document.querySelectorAll('a, button, input, textarea');
-	if (focusable.length > 0) {
-		var randomElement = focusable[Math.floor(Math.random() * focusable.length)];
-		randomElement.focus();
-		
-		setTimeout(function() {
-			randomElement.blur();
-		}, 500);
-	}
-	
 	return true;
 })();
 `
-	
+	ts.mu.Lock()
+	clock := ts.engagementClock()
+	away := time.Duration(1000+ts.rng.Intn(4000)) * time.Millisecond
+	ts.mu.Unlock()
+
 	var result bool
-	return chromedp.Run(ctx, chromedp.Evaluate(script, &result))
+	if err := chromedp.Run(ctx, chromedp.Evaluate(blurScript, &result)); err != nil {
+		return err
+	}
+	clock.Blur(time.Now())
+	select {
+	case <-ctx.Done():
+	case <-time.After(away):
+	}
+	clock.Focus(time.Now())
+	return chromedp.Run(ctx, chromedp.Evaluate(focusScript, &result))
 }
 
-// sendPeriodicEngagement periyodik engagement gönderir
+// sendPeriodicEngagement son raporlamadan beri biriken ön plan süresini user_engagement ile gönderir
 func (ts *TrafficSimulator) sendPeriodicEngagement(ctx context.Context) error {
-	script := `
+	newSession := ts.touchSession(time.Now())
+	ts.mu.Lock()
+	engagement := ts.engagementClock().Take(time.Now())
+	ts.sessionData.SessionEngagement += engagement
+	ts.markEngaged()
+	engagementMs := engagement.Milliseconds()
+	sessionID := ts.sessionData.SessionID
+	sessionNumber := ts.sessionData.SessionNumber
+	ts.mu.Unlock()
+	if engagementMs <= 0 && !newSession {
+		return nil
+	}
+
+	script := fmt.Sprintf(`
 (function() {
 	if (typeof gtag === 'function') {
-		gtag('event', 'user_engagement', {
-			'engagement_time_msec': 10000
-		});
+		var sessionParams = {'ga_session_id': '%s', 'ga_session_number': %d};
+		if (%v) {
+			gtag('event', 'session_start', sessionParams);
+		}
+		gtag('event', 'user_engagement', Object.assign({
+			'engagement_time_msec': %d
+		}, sessionParams));
 		return true;
 	}
 	return false;
 })();
-`
+`, sessionID, sessionNumber, newSession, engagementMs)
 	
 	var result bool
 	return chromedp.Run(ctx, chromedp.Evaluate(script, &result))
 }
 
+// touchSession GA event'i öncesi oturum zaman aşımını uygular (GS1.1 çerez semantiği).
+// Yeni kullanıcının ilk event'inde veya son event'ten bu yana SessionTimeout'tan uzun süre
+// geçtiyse yeni oturum açar (session_id=şimdi, ga_session_number+1) ve true döner;
+// çağıran session_start göndermelidir.
+func (ts *TrafficSimulator) touchSession(now time.Time) bool {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	sd := ts.sessionData
+	newSession := sd.GA.Touch(now, ts.config.SessionTimeout)
+	if newSession {
+		sd.SessionStarts++
+		sd.SessionPages = 0
+		sd.SessionEngagement = 0
+		sd.StartTime = now
+		if sd.Engagement != nil {
+			sd.Engagement.Take(now) // Önceki oturumun raporlanmamış süresi yeni oturuma taşınmaz
+		}
+	}
+	oldID := sd.SessionID
+	sd.SessionID = strconv.FormatInt(sd.GA.SessionID, 10)
+	sd.SessionNumber = sd.GA.SessionCount
+	if sd.SessionID != oldID && ts.tracker != nil {
+		ts.tracker.StartNewSession(sd.SessionID)
+	}
+	return newSession
+}
+
+// markEngaged GA4 engaged session kuralı: 10sn+ engagement veya 2+ page_view (ts.mu tutulurken çağrılır)
+func (ts *TrafficSimulator) markEngaged() {
+	sd := ts.sessionData
+	if sd.SessionPages >= 2 || sd.SessionEngagement >= 10*time.Second {
+		sd.GA.Engaged = true
+	}
+}
+
+// engagementClock oturumun engagement saatini döner, yoksa başlatır (ts.mu tutulurken çağrılır)
+func (ts *TrafficSimulator) engagementClock() *EngagementClock {
+	if ts.sessionData.Engagement == nil {
+		ts.sessionData.Engagement = NewEngagementClock(time.Now())
+	}
+	return ts.sessionData.Engagement
+}
+
+// startPageEngagement yeni sayfanın page_view'ı için raporlanmamış süreyi alır ve sayfa sayacını sıfırlar
+func (ts *TrafficSimulator) startPageEngagement(now time.Time) time.Duration {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if ts.sessionData.Engagement == nil {
+		ts.sessionData.Engagement = NewEngagementClock(now)
+		return 0
+	}
+	clock := ts.sessionData.Engagement
+	clock.NewPage(now)
+	d := clock.Take(now)
+	ts.sessionData.SessionEngagement += d
+	ts.markEngaged()
+	return d
+}
+
 // sendEngagementSignals engagement sinyalleri gönderir
 func (ts *TrafficSimulator) sendEngagementSignals(ctx context.Context) error {
+	// Final user_engagement yalnızca henüz raporlanmamış ön plan süresini taşır
+	newSession := ts.touchSession(time.Now())
 	ts.mu.Lock()
-	engagementMs := ts.sessionData.TotalEngagement.Milliseconds()
+	sessionID := ts.sessionData.SessionID
+	sessionNumber := ts.sessionData.SessionNumber
+	clock := ts.engagementClock()
+	engagement := clock.Take(time.Now())
+	ts.sessionData.SessionEngagement += engagement
+	ts.markEngaged()
+	engagementMs := engagement.Milliseconds()
+	ts.sessionData.TotalEngagement = clock.Total(time.Now())
+	totalMs := ts.sessionData.TotalEngagement.Milliseconds()
 	maxScroll := 0
 	for _, s := range ts.sessionData.ScrollDepths {
 		if s > maxScroll {
@@ -1214,14 +1385,19 @@ func (ts *TrafficSimulator) sendEngagementSignals(ctx context.Context) error {
 	script := fmt.Sprintf(`
 (function() {
 	var engagementMs = %d;
+	var totalMs = %d;
 	var maxScroll = %d;
 	var isBounce = %v;
+	var sessionParams = {'ga_session_id': '%s', 'ga_session_number': %d};
 	
 	if (typeof gtag === 'function') {
+		if (%v) {
+			gtag('event', 'session_start', sessionParams);
+		}
 		// Final engagement
-		gtag('event', 'user_engagement', {
+		gtag('event', 'user_engagement', Object.assign({
 			'engagement_time_msec': engagementMs
-		});
+		}, sessionParams));
 		
 		// Scroll depth (if 90%% or more)
 		if (maxScroll >= 90) {
@@ -1234,14 +1410,14 @@ func (ts *TrafficSimulator) sendEngagementSignals(ctx context.Context) error {
 	}
 	
 	if (typeof ga === 'function') {
-		ga('send', 'event', 'Engagement', 'Time', 'Session', Math.floor(engagementMs / 1000));
+		ga('send', 'event', 'Engagement', 'Time', 'Session', Math.floor(totalMs / 1000));
 		ga('send', 'event', 'Engagement', 'Scroll', 'Depth', maxScroll);
 		return {success: true, method: 'ga'};
 	}
 	
 	return {success: false};
 })();
-`, engagementMs, maxScroll, isBounce)
+`, engagementMs, totalMs, maxScroll, isBounce, sessionID, sessionNumber, newSession)
 	
 	var result map[string]interface{}
 	if err := chromedp.Run(ctx, chromedp.Evaluate(script, &result)); err != nil {
@@ -1286,8 +1462,10 @@ func (ts *TrafficSimulator) GetSessionStats() map[string]interface{} {
 		}
 	}
 	
-	return map[string]interface{}{
+	stats := map[string]interface{}{
 		"session_id":        ts.sessionData.SessionID,
+		"session_number":    ts.sessionData.SessionNumber,
+		"session_starts":    ts.sessionData.SessionStarts,
 		"client_id":         ts.sessionData.ClientID,
 		"page_views":        ts.sessionData.PageViews,
 		"total_engagement":  ts.sessionData.TotalEngagement.String(),
@@ -1301,6 +1479,13 @@ func (ts *TrafficSimulator) GetSessionStats() map[string]interface{} {
 		"is_bounce":         ts.sessionData.IsBounce,
 		"visited_pages":     ts.sessionData.VisitedPages,
 	}
+	if ts.tracker != nil && ts.tracker.mpBatcher != nil {
+		mp := ts.tracker.MPStats()
+		stats["mp_requests"] = mp.Requests
+		stats["mp_events_accepted"] = mp.Accepted
+		stats["mp_events_rejected"] = mp.Rejected
+	}
+	return stats
 }
 
 // ValidateTraffic trafiği doğrular
@@ -1471,10 +1656,10 @@ type SearchConsoleOptimizer struct {
 	rng *mrand.Rand
 }
 
-// NewSearchConsoleOptimizer yeni optimizer oluşturur
-func NewSearchConsoleOptimizer() *SearchConsoleOptimizer {
+// NewSearchConsoleOptimizer yeni optimizer oluşturur (seeder nil ise tekrarlanamaz)
+func NewSearchConsoleOptimizer(seeder *utils.Seeder) *SearchConsoleOptimizer {
 	return &SearchConsoleOptimizer{
-		rng: mrand.New(mrand.NewSource(time.Now().UnixNano())),
+		rng: seeder.NewRand(),
 	}
 }
 
@@ -1587,47 +1772,32 @@ func NewCookieManager() *CookieManager {
 	return &CookieManager{}
 }
 
-// SetGACookies Google Analytics çerezlerini ayarlar
-func (cm *CookieManager) SetGACookies(ctx context.Context, clientID, sessionID string, domain string) error {
-	// _ga cookie
-	gaExpires := cdp.TimeSinceEpoch(time.Now().Add(2 * 365 * 24 * time.Hour))
-	gaCookie := &network.CookieParam{
-		Name:     "_ga",
-		Value:    fmt.Sprintf("GA1.2.%s", clientID),
-		Domain:   domain,
-		Path:     "/",
-		Expires:  &gaExpires,
-		HTTPOnly: false,
-		Secure:   false,
-	}
-	
-	// _ga_XXXXX cookie (GA4)
-	ga4Expires := cdp.TimeSinceEpoch(time.Now().Add(2 * 365 * 24 * time.Hour))
-	ga4Cookie := &network.CookieParam{
-		Name:     "_ga_XXXXXXXX",
-		Value:    fmt.Sprintf("GS1.1.%s.1.1.%d.0.0.0", sessionID, time.Now().Unix()),
-		Domain:   domain,
-		Path:     "/",
-		Expires:  &ga4Expires,
-		HTTPOnly: false,
-		Secure:   false,
-	}
-	
-	// _gid cookie
-	gidExpires := cdp.TimeSinceEpoch(time.Now().Add(24 * time.Hour))
-	gidCookie := &network.CookieParam{
-		Name:     "_gid",
-		Value:    fmt.Sprintf("GA1.2.%d.%d", time.Now().UnixNano(), time.Now().Unix()),
-		Domain:   domain,
-		Path:     "/",
-		Expires:  &gidExpires,
-		HTTPOnly: false,
-		Secure:   false,
-	}
-	
-	return chromedp.Run(ctx,
-		network.SetCookies([]*network.CookieParam{gaCookie, ga4Cookie, gidCookie}),
-	)
+// SetGACookies Google Analytics çerezlerini ayarlar: _ga (client ID) ve measurement ID'ye
+// özel _ga_<container> (GS1.1 oturum durumu). state boşsa (yeni kullanıcı) çerez yazılmaz;
+// gtag çerezleri kendisi oluşturur.
+func (cm *CookieManager) SetGACookies(ctx context.Context, clientID, measurementID string, state GASessionState, domain string) error {
+	if state.SessionCount == 0 {
+		return nil
+	}
+	domain = "." + strings.TrimPrefix(domain, ".")
+	expires := cdp.TimeSinceEpoch(time.Now().Add(2 * 365 * 24 * time.Hour))
+	cookies := []*network.CookieParam{{
+		Name:    "_ga",
+		Value:   GACookieValue(clientID, domain),
+		Domain:  domain,
+		Path:    "/",
+		Expires: &expires,
+	}}
+	if name := GA4CookieName(measurementID); name != "" {
+		cookies = append(cookies, &network.CookieParam{
+			Name:    name,
+			Value:   state.CookieValue(),
+			Domain:  domain,
+			Path:    "/",
+			Expires: &expires,
+		})
+	}
+	return chromedp.Run(ctx, network.SetCookies(cookies))
 }
 
 // SetSessionStorage session storage ayarlar
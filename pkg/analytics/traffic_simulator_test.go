@@ -0,0 +1,31 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTouchSessionTimeoutSplitsSession(t *testing.T) {
+	ts := NewTrafficSimulator(TrafficSimulatorConfig{})
+	ts.StartSession("kw", "https://www.google.com/")
+	t0 := time.Now()
+
+	if !ts.touchSession(t0) {
+		t.Fatal("first event should start a session")
+	}
+	firstID := ts.sessionData.SessionID
+	if ts.touchSession(t0.Add(29 * time.Minute)) {
+		t.Fatal("29 min gap should stay in the same session")
+	}
+	// Son event'ten itibaren ölçülür: 29dk + 20dk toplamda > 30dk ama boşluk 20dk
+	if ts.touchSession(t0.Add(49 * time.Minute)) {
+		t.Fatal("inactivity is measured from the last event")
+	}
+	if !ts.touchSession(t0.Add(80 * time.Minute)) {
+		t.Fatal("31 min gap should start a new session")
+	}
+	sd := ts.sessionData
+	if sd.SessionID == firstID || sd.SessionNumber != 2 || sd.SessionStarts != 2 {
+		t.Fatalf("session = id %s number %d starts %d", sd.SessionID, sd.SessionNumber, sd.SessionStarts)
+	}
+}
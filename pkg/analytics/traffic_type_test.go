@@ -0,0 +1,67 @@
+package analytics
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestValidateTrafficType(t *testing.T) {
+	for _, ok := range []string{"", "internal", "vgbot_test", "qa-2.0"} {
+		if err := ValidateTrafficType(ok); err != nil {
+			t.Errorf("%q: %v", ok, err)
+		}
+	}
+	for _, bad := range []string{"in ternal", "a'b", "x=y"} {
+		if err := ValidateTrafficType(bad); err == nil {
+			t.Errorf("%q accepted", bad)
+		}
+	}
+}
+
+func TestLabelTraffic(t *testing.T) {
+	params := map[string]interface{}{"percent": 50}
+	if got := (&Manager{}).labelTraffic(params); len(got) != 1 {
+		t.Errorf("disabled = %v", got)
+	}
+	m := &Manager{TrafficType: "internal"}
+	got := m.labelTraffic(params)
+	if got["traffic_type"] != "internal" || got["percent"] != 50 {
+		t.Errorf("labeled = %v", got)
+	}
+	if _, ok := params["traffic_type"]; ok {
+		t.Error("input map modified")
+	}
+	explicit := m.labelTraffic(map[string]interface{}{"traffic_type": "mapped"})
+	if explicit["traffic_type"] != "mapped" {
+		t.Errorf("explicit value overridden: %v", explicit)
+	}
+}
+
+func TestMeasurementProtocolTrafficType(t *testing.T) {
+	var payload GA4Payload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &payload)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	e, err := NewServerEndpoint(srv.URL, "", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := &Manager{GA4Enabled: true, GA4MeasurementID: "G-ABC1234567", MPAPISecret: "s", Server: e, TrafficType: "vgbot_test"}
+	if err := m.SendMeasurementProtocolPageView("Home", "https://site.example/a", ""); err != nil {
+		t.Fatal(err)
+	}
+	if len(payload.Events) != 1 || payload.Events[0].Name != "page_view" {
+		t.Fatalf("payload = %+v", payload)
+	}
+	p := payload.Events[0].Params
+	if p["traffic_type"] != "vgbot_test" || p["page_location"] != "https://site.example/a" {
+		t.Errorf("params = %v", p)
+	}
+}
@@ -5,15 +5,19 @@ import (
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"math"
 	mrand "math/rand"
+	"os"
 	"sort"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/chromedp/chromedp"
+
+	"vgbot/pkg/utils"
 )
 
 // AntiDetectConfig anti-detection yapılandırması
@@ -32,6 +36,7 @@ type AntiDetectConfig struct {
 	HardwareSpoof        bool // Hardware concurrency spoofing
 	MemorySpoof          bool // Device memory spoofing
 	ConnectionSpoof      bool // Network connection spoofing
+	Seeder               *utils.Seeder // Çalıştırmanın seed kaynağı (nil = tekrarlanamaz)
 }
 
 // AntiDetect anti-detection yöneticisi
@@ -45,7 +50,7 @@ type AntiDetect struct {
 func NewAntiDetect(config AntiDetectConfig) *AntiDetect {
 	return &AntiDetect{
 		config: config,
-		rng:    mrand.New(mrand.NewSource(time.Now().UnixNano())),
+		rng:    config.Seeder.NewRand(),
 	}
 }
 
@@ -571,20 +576,20 @@ func (ad *AntiDetect) GetOnNewDocumentScript() string {
 
 // KeywordCluster semantik olarak ilişkili keyword gruplarını temsil eder
 type KeywordCluster struct {
-	ID          string            // Cluster benzersiz ID
-	Name        string            // Cluster adı (örn: "e-commerce", "tech")
-	PrimaryKW   string            // Ana keyword
-	Variations  []string          // Varyasyonlar
-	LongTails   []string          // Long-tail keywordler
-	Synonyms    []string          // Eş anlamlılar
-	Related     []string          // İlişkili keywordler
-	Modifiers   []string          // Modifier'lar (best, top, cheap, vb.)
-	Locations   []string          // Lokasyon modifierleri
-	Intent      SearchIntent      // Arama niyeti
-	Weight      float64           // Cluster ağırlığı (0-1)
-	UsageCount  int               // Kullanım sayısı
-	LastUsed    time.Time         // Son kullanım zamanı
-	Metadata    map[string]string // Ek metadata
+	ID          string            `json:"id"`          // Cluster benzersiz ID
+	Name        string            `json:"name"`        // Cluster adı (örn: "e-commerce", "tech")
+	PrimaryKW   string            `json:"primary_kw"`  // Ana keyword
+	Variations  []string          `json:"variations"`  // Varyasyonlar
+	LongTails   []string          `json:"long_tails"`  // Long-tail keywordler
+	Synonyms    []string          `json:"synonyms"`    // Eş anlamlılar
+	Related     []string          `json:"related"`     // İlişkili keywordler
+	Modifiers   []string          `json:"modifiers"`   // Modifier'lar (best, top, cheap, vb.)
+	Locations   []string          `json:"locations"`   // Lokasyon modifierleri
+	Intent      SearchIntent      `json:"intent"`      // Arama niyeti
+	Weight      float64           `json:"weight"`      // Cluster ağırlığı (0-1)
+	UsageCount  int               `json:"usage_count"` // Kullanım sayısı
+	LastUsed    time.Time         `json:"last_used"`   // Son kullanım zamanı
+	Metadata    map[string]string `json:"metadata"`    // Ek metadata
 }
 
 // SearchIntent arama niyeti türleri
@@ -598,6 +603,15 @@ const (
 	IntentLocal         SearchIntent = "local"         // Yerel arama
 )
 
+// Valid niyet bilinen arama niyetlerinden biri mi
+func (i SearchIntent) Valid() bool {
+	switch i {
+	case IntentInformational, IntentNavigational, IntentTransactional, IntentCommercial, IntentLocal:
+		return true
+	}
+	return false
+}
+
 // KeywordRotationStrategy rotation stratejisi
 type KeywordRotationStrategy string
 
@@ -643,15 +657,15 @@ type PatternDetector struct {
 	alertThreshold  float64                  // Uyarı eşiği
 }
 
-// NewKeywordClusterManager yeni cluster manager oluşturur
-func NewKeywordClusterManager(strategy KeywordRotationStrategy) *KeywordClusterManager {
+// NewKeywordClusterManager yeni cluster manager oluşturur; seçimler seeder'dan türetilir (nil = tekrarlanamaz)
+func NewKeywordClusterManager(strategy KeywordRotationStrategy, seeder *utils.Seeder) *KeywordClusterManager {
 	return &KeywordClusterManager{
 		clusters:         make(map[string]*KeywordCluster),
 		rotationStrategy: strategy,
 		rotationIndex:    make(map[string]int),
 		usageHistory:     make([]KeywordUsageRecord, 0, 10000),
 		patternDetector:  NewPatternDetector(50, 0.7),
-		rng:              mrand.New(mrand.NewSource(time.Now().UnixNano())),
+		rng:              seeder.NewRand(),
 		maxHistorySize:   10000,
 		cooldownPeriod:   5 * time.Minute,
 		clusterCooldown:  2 * time.Minute,
@@ -716,11 +730,15 @@ func (kcm *KeywordClusterManager) GetNextKeyword(clusterID string) (string, erro
 	if !exists {
 		return "", fmt.Errorf("cluster not found: %s", clusterID)
 	}
-	
+	return kcm.nextKeywordLocked(clusterID, cluster), nil
+}
+
+// nextKeywordLocked cluster'dan stratejiye göre keyword seçer ve kullanımı işaretler (kcm.mu tutulurken)
+func (kcm *KeywordClusterManager) nextKeywordLocked(clusterID string, cluster *KeywordCluster) string {
 	// Tüm keyword'leri birleştir
 	allKeywords := kcm.getAllKeywordsFromCluster(cluster)
 	if len(allKeywords) == 0 {
-		return cluster.PrimaryKW, nil
+		return cluster.PrimaryKW
 	}
 	
 	var selectedKW string
@@ -749,42 +767,110 @@ func (kcm *KeywordClusterManager) GetNextKeyword(clusterID string) (string, erro
 	// Pattern detector'a ekle
 	kcm.patternDetector.AddSequence(selectedKW)
 	
-	return selectedKW, nil
+	return selectedKW
 }
 
 // GetNextKeywordWithModifier modifier ile keyword döner
 func (kcm *KeywordClusterManager) GetNextKeywordWithModifier(clusterID string) (string, error) {
-	kcm.mu.RLock()
-	cluster, exists := kcm.clusters[clusterID]
-	kcm.mu.RUnlock()
+	kcm.mu.Lock()
+	defer kcm.mu.Unlock()
 	
+	cluster, exists := kcm.clusters[clusterID]
 	if !exists {
 		return "", fmt.Errorf("cluster not found: %s", clusterID)
 	}
-	
-	baseKW, err := kcm.GetNextKeyword(clusterID)
-	if err != nil {
-		return "", err
-	}
-	
+	return kcm.withModifierLocked(cluster, kcm.nextKeywordLocked(clusterID, cluster)), nil
+}
+
+// withModifierLocked keyword'e olasılıkla modifier veya lokasyon ekler (kcm.mu tutulurken; rng paylaşımlı)
+func (kcm *KeywordClusterManager) withModifierLocked(cluster *KeywordCluster, baseKW string) string {
 	// %60 ihtimalle modifier ekle
 	if kcm.rng.Float64() < 0.6 && len(cluster.Modifiers) > 0 {
 		modifier := cluster.Modifiers[kcm.rng.Intn(len(cluster.Modifiers))]
 		
 		// Modifier pozisyonu: %70 önde, %30 arkada
 		if kcm.rng.Float64() < 0.7 {
-			return modifier + " " + baseKW, nil
+			return modifier + " " + baseKW
 		}
-		return baseKW + " " + modifier, nil
+		return baseKW + " " + modifier
 	}
 	
 	// %20 ihtimalle lokasyon ekle
 	if kcm.rng.Float64() < 0.2 && len(cluster.Locations) > 0 {
 		location := cluster.Locations[kcm.rng.Intn(len(cluster.Locations))]
-		return baseKW + " " + location, nil
+		return baseKW + " " + location
+	}
+	
+	return baseKW
+}
+
+// NextKeyword tüm cluster'lar arasından ağırlığa göre bir cluster seçip modifier'lı keyword döner.
+// Cluster yoksa ok false döner; simülatör bu durumda düz keyword listesine düşer.
+func (kcm *KeywordClusterManager) NextKeyword() (keyword, clusterID string, ok bool) {
+	kcm.mu.Lock()
+	defer kcm.mu.Unlock()
+	
+	if len(kcm.clusters) == 0 {
+		return "", "", false
+	}
+	ids := make([]string, 0, len(kcm.clusters))
+	total := 0.0
+	for id, cluster := range kcm.clusters {
+		ids = append(ids, id)
+		if cluster.Weight > 0 {
+			total += cluster.Weight
+		}
+	}
+	sort.Strings(ids)
+	
+	clusterID = ids[kcm.rng.Intn(len(ids))]
+	if total > 0 {
+		roll := kcm.rng.Float64() * total
+		for _, id := range ids {
+			if w := kcm.clusters[id].Weight; w > 0 {
+				if roll < w {
+					clusterID = id
+					break
+				}
+				roll -= w
+			}
+		}
 	}
+	cluster := kcm.clusters[clusterID]
+	return kcm.withModifierLocked(cluster, kcm.nextKeywordLocked(clusterID, cluster)), clusterID, true
+}
+
+// GetCluster cluster'ın kopyasını döner
+func (kcm *KeywordClusterManager) GetCluster(clusterID string) (*KeywordCluster, bool) {
+	kcm.mu.RLock()
+	defer kcm.mu.RUnlock()
 	
-	return baseKW, nil
+	cluster, exists := kcm.clusters[clusterID]
+	if !exists {
+		return nil, false
+	}
+	c := *cluster
+	return &c, true
+}
+
+// ClusterCount kayıtlı cluster sayısı
+func (kcm *KeywordClusterManager) ClusterCount() int {
+	kcm.mu.RLock()
+	defer kcm.mu.RUnlock()
+	return len(kcm.clusters)
+}
+
+// RemoveCluster cluster'ı siler; cluster yoksa false döner
+func (kcm *KeywordClusterManager) RemoveCluster(clusterID string) bool {
+	kcm.mu.Lock()
+	defer kcm.mu.Unlock()
+	
+	if _, exists := kcm.clusters[clusterID]; !exists {
+		return false
+	}
+	delete(kcm.clusters, clusterID)
+	delete(kcm.rotationIndex, clusterID)
+	return true
 }
 
 // GetKeywordBatch toplu keyword döner (anti-pattern için)
@@ -1092,8 +1178,8 @@ type FingerprintRotator struct {
 	rng             *mrand.Rand
 }
 
-// NewFingerprintRotator yeni fingerprint rotator oluşturur
-func NewFingerprintRotator(maxFingerprints int, rotationInterval time.Duration) *FingerprintRotator {
+// NewFingerprintRotator yeni fingerprint rotator oluşturur; fingerprint'ler seeder'dan türetilir (nil = tekrarlanamaz)
+func NewFingerprintRotator(maxFingerprints int, rotationInterval time.Duration, seeder *utils.Seeder) *FingerprintRotator {
 	fr := &FingerprintRotator{
 		fingerprints:     make([]*SessionFingerprint, 0, maxFingerprints),
 		activeIndex:      0,
@@ -1101,7 +1187,7 @@ func NewFingerprintRotator(maxFingerprints int, rotationInterval time.Duration)
 		maxFingerprints:  maxFingerprints,
 		rotationInterval: rotationInterval,
 		lastRotation:     time.Now(),
-		rng:              mrand.New(mrand.NewSource(time.Now().UnixNano())),
+		rng:              seeder.NewRand(),
 	}
 	
 	// Başlangıç fingerprint'leri oluştur
@@ -1267,8 +1353,8 @@ type RequestPatternRandomizer struct {
 	humanSimulation bool
 }
 
-// NewRequestPatternRandomizer yeni pattern randomizer oluşturur
-func NewRequestPatternRandomizer(humanSimulation bool) *RequestPatternRandomizer {
+// NewRequestPatternRandomizer yeni pattern randomizer oluşturur; gecikmeler seeder'dan türetilir (nil = tekrarlanamaz)
+func NewRequestPatternRandomizer(humanSimulation bool, seeder *utils.Seeder) *RequestPatternRandomizer {
 	rpr := &RequestPatternRandomizer{
 		patterns: []RequestPattern{
 			// Normal browsing pattern
@@ -1285,7 +1371,7 @@ func NewRequestPatternRandomizer(humanSimulation bool) *RequestPatternRandomizer
 		activePattern:   0,
 		requestCount:    0,
 		lastRequest:     time.Now(),
-		rng:             mrand.New(mrand.NewSource(time.Now().UnixNano())),
+		rng:             seeder.NewRand(),
 		humanSimulation: humanSimulation,
 	}
 	
@@ -1483,11 +1569,11 @@ type BehaviorClusterManager struct {
 	rng             *mrand.Rand
 }
 
-// NewBehaviorClusterManager yeni behavior cluster manager oluşturur
-func NewBehaviorClusterManager() *BehaviorClusterManager {
+// NewBehaviorClusterManager yeni behavior cluster manager oluşturur; seçimler seeder'dan türetilir (nil = tekrarlanamaz)
+func NewBehaviorClusterManager(seeder *utils.Seeder) *BehaviorClusterManager {
 	bcm := &BehaviorClusterManager{
 		clusters: make(map[string]*BehaviorCluster),
-		rng:      mrand.New(mrand.NewSource(time.Now().UnixNano())),
+		rng:      seeder.NewRand(),
 	}
 	
 	// Varsayılan davranış kümeleri
@@ -2052,9 +2138,11 @@ func (kcm *KeywordClusterManager) ExportClusters() []*KeywordCluster {
 	kcm.mu.RLock()
 	defer kcm.mu.RUnlock()
 	
+	// Kopyalar döner: rotasyon UsageCount/LastUsed'ı güncellerken export güvenle encode edilebilir
 	clusters := make([]*KeywordCluster, 0, len(kcm.clusters))
 	for _, cluster := range kcm.clusters {
-		clusters = append(clusters, cluster)
+		c := *cluster
+		clusters = append(clusters, &c)
 	}
 	
 	// ID'ye göre sırala
@@ -2071,11 +2159,43 @@ func (kcm *KeywordClusterManager) ImportClusters(clusters []*KeywordCluster) {
 	defer kcm.mu.Unlock()
 	
 	for _, cluster := range clusters {
+		if cluster.ID == "" {
+			cluster.ID = generateClusterID(cluster.PrimaryKW)
+		}
+		if cluster.Metadata == nil {
+			cluster.Metadata = make(map[string]string)
+		}
 		kcm.clusters[cluster.ID] = cluster
 		kcm.rotationIndex[cluster.ID] = 0
 	}
 }
 
+// LoadClustersFile ExportClusters formatındaki JSON dosyasından cluster'ları yükler; dosya yoksa hata değildir
+func (kcm *KeywordClusterManager) LoadClustersFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	var clusters []*KeywordCluster
+	if err := json.Unmarshal(data, &clusters); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	kcm.ImportClusters(clusters)
+	return nil
+}
+
+// SaveClustersFile cluster'ları (kullanım sayılarıyla) JSON dosyasına yazar
+func (kcm *KeywordClusterManager) SaveClustersFile(path string) error {
+	data, err := json.MarshalIndent(kcm.ExportClusters(), "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
 // ClearHistory kullanım geçmişini temizler
 func (kcm *KeywordClusterManager) ClearHistory() {
 	kcm.mu.Lock()
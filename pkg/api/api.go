@@ -0,0 +1,101 @@
+// Package api sunucu, master, worker ve istemciler arasında paylaşılan veri yapıları (DTO).
+// JSON alan adları sözleşmedir: roller farklı sürümlerde çalışabilir, alanlar yeniden adlandırılmaz.
+package api
+
+import (
+	"time"
+
+	"vgbot/pkg/behavior"
+	"vgbot/pkg/proxy"
+)
+
+// TaskStatus task durumu
+type TaskStatus string
+
+const (
+	TaskPending   TaskStatus = "pending"
+	TaskAssigned  TaskStatus = "assigned"
+	TaskRunning   TaskStatus = "running"
+	TaskCompleted TaskStatus = "completed"
+	TaskFailed    TaskStatus = "failed"
+	TaskRetrying  TaskStatus = "retrying"  // Backoff süresi dolunca kuyruğa geri konur
+	TaskCancelled TaskStatus = "cancelled" // Master'da iptal edildi; worker'ın geç gelen sonucu yok sayılır
+)
+
+// Task bir ziyaret task'ı
+type Task struct {
+	ID          string                    `json:"id"`
+	URL         string                    `json:"url"`
+	Proxy       *proxy.ProxyConfig        `json:"proxy,omitempty"`
+	Profile     *behavior.BehaviorProfile `json:"profile,omitempty"`
+	SessionID   string                    `json:"session_id"`
+	Keyword     string                    `json:"keyword,omitempty"` // Arama referrer'ı için anahtar kelime (boşsa doğrudan ziyaret)
+	Status      TaskStatus                `json:"status"`
+	WorkerID    string                    `json:"worker_id,omitempty"`
+	CreatedAt   time.Time                 `json:"created_at"`
+	AssignedAt  *time.Time                `json:"assigned_at,omitempty"`
+	CompletedAt *time.Time                `json:"completed_at,omitempty"`
+	Result      *TaskResult               `json:"result,omitempty"`
+	Attempts    int                       `json:"attempts,omitempty"`      // Başarısız deneme sayısı
+	LastError   string                    `json:"last_error,omitempty"`    // Son denemenin hatası
+	NextRetryAt *time.Time                `json:"next_retry_at,omitempty"` // TaskRetrying iken tekrar kuyruğa girme zamanı
+}
+
+// TaskResult task sonucu
+type TaskResult struct {
+	Success      bool          `json:"success"`
+	ResponseTime time.Duration `json:"response_time"`
+	Error        string        `json:"error,omitempty"`
+	PageTitle    string        `json:"page_title,omitempty"`
+	StatusCode   int           `json:"status_code"`
+	Protocol     string        `json:"protocol,omitempty"` // Negotiated HTTP version (HTTP/2.0, HTTP/3.0)
+	Timestamp    time.Time     `json:"timestamp"`
+}
+
+// WorkerInfo worker bilgisi
+type WorkerInfo struct {
+	ID             string    `json:"id"`
+	Hostname       string    `json:"hostname"`
+	IPAddress      string    `json:"ip_address"`
+	MaxConcurrency int       `json:"max_concurrency"`
+	ActiveTasks    int       `json:"active_tasks"`
+	TotalTasks     int64     `json:"total_tasks"`
+	SuccessCount   int64     `json:"success_count"`
+	FailedCount    int64     `json:"failed_count"`
+	LastHeartbeat  time.Time `json:"last_heartbeat"`
+	Status         string    `json:"status"`
+	Version        string    `json:"version"`
+	Transport      string    `json:"transport,omitempty"` // "http" (polling) veya "grpc" (stream)
+}
+
+// IsHealthy worker'ın sağlıklı olup olmadığını kontrol eder
+func (w *WorkerInfo) IsHealthy() bool {
+	return time.Since(w.LastHeartbeat) < 30*time.Second && w.Status == "active"
+}
+
+// MasterStats master istatistikleri
+type MasterStats struct {
+	TotalTasks     int64 `json:"total_tasks"`
+	CompletedTasks int64 `json:"completed_tasks"`
+	FailedTasks    int64 `json:"failed_tasks"`
+	PendingTasks   int64 `json:"pending_tasks"`
+	ActiveWorkers  int64 `json:"active_workers"`
+	RetriedTasks   int64 `json:"retried_tasks"`   // Toplam yeniden deneme sayısı
+	DeadTasks      int64 `json:"dead_tasks"`      // Dead-letter kuyruğundaki task'lar
+	CancelledTasks int64 `json:"cancelled_tasks"` // İptal edilmiş (yeniden kuyruğa alınmamış) task'lar
+}
+
+// WorkerStats worker istatistikleri
+type WorkerStats struct {
+	WorkerID     string `json:"worker_id"`
+	ActiveTasks  int    `json:"active_tasks"`
+	TotalTasks   int64  `json:"total_tasks"`
+	SuccessCount int64  `json:"success_count"`
+	FailedCount  int64  `json:"failed_count"`
+}
+
+// ClusterStatus sunucunun gömülü master durumu (/api/cluster/status ve /api/status "cluster" alanı)
+type ClusterStatus struct {
+	MasterStats
+	Workers []WorkerInfo `json:"workers"`
+}
@@ -4,6 +4,8 @@ import (
 	"math/rand"
 	"sync"
 	"time"
+
+	"vgbot/pkg/utils"
 )
 
 // ScrollType scroll davranış tipi
@@ -69,16 +71,16 @@ type FingerprintGenerator struct {
 	mu  sync.Mutex
 }
 
-// NewFingerprintGenerator yeni bir fingerprint generator oluşturur
-func NewFingerprintGenerator() *FingerprintGenerator {
+// NewFingerprintGenerator çalıştırmanın seed'inden (nil = tekrarlanamaz) beslenen bir fingerprint generator oluşturur
+func NewFingerprintGenerator(seeder *utils.Seeder) *FingerprintGenerator {
 	return &FingerprintGenerator{
-		rng: rand.New(rand.NewSource(time.Now().UnixNano())),
+		rng: seeder.NewRand(),
 	}
 }
 
 // GenerateRandomProfile rastgele bir davranış profili oluşturur
-func GenerateRandomProfile() *BehavioralProfile {
-	gen := NewFingerprintGenerator()
+func GenerateRandomProfile(seeder *utils.Seeder) *BehavioralProfile {
+	gen := NewFingerprintGenerator(seeder)
 	return gen.GenerateProfile()
 }
 
@@ -355,15 +357,16 @@ type ProfilePool struct {
 	rng      *rand.Rand
 }
 
-// NewProfilePool yeni profil havuzu oluşturur
-func NewProfilePool(size int) *ProfilePool {
+// NewProfilePool yeni profil havuzu oluşturur; profiller ve seçimler seeder'dan türetilir
+func NewProfilePool(size int, seeder *utils.Seeder) *ProfilePool {
 	pool := &ProfilePool{
 		profiles: make([]*BehavioralProfile, size),
-		rng:      rand.New(rand.NewSource(time.Now().UnixNano())),
+		rng:      seeder.NewRand(),
 	}
 
+	gen := NewFingerprintGenerator(seeder)
 	for i := 0; i < size; i++ {
-		pool.profiles[i] = GenerateRandomProfile()
+		pool.profiles[i] = gen.GenerateProfile()
 	}
 
 	return pool
@@ -398,7 +401,7 @@ func (pp *ProfilePool) Shuffle() {
 }
 
 // GenerateMixedProfiles farklı profil tiplerinden karışık profiller oluşturur
-func GenerateMixedProfiles(count int, mixRatio map[ProfileType]float64) []*BehavioralProfile {
+func GenerateMixedProfiles(count int, mixRatio map[ProfileType]float64, seeder *utils.Seeder) []*BehavioralProfile {
 	if len(mixRatio) == 0 {
 		// Varsayılan dağılım
 		mixRatio = map[ProfileType]float64{
@@ -412,7 +415,7 @@ func GenerateMixedProfiles(count int, mixRatio map[ProfileType]float64) []*Behav
 		}
 	}
 
-	gen := NewFingerprintGenerator()
+	gen := NewFingerprintGenerator(seeder)
 	profiles := make([]*BehavioralProfile, count)
 
 	for i := 0; i < count; i++ {
@@ -10,6 +10,8 @@ import (
 	"time"
 
 	"github.com/chromedp/chromedp"
+
+	"vgbot/pkg/utils"
 )
 
 // ScrollType fast scroll tipi için eksik tanım
@@ -28,6 +30,7 @@ type BehaviorConfig struct {
 	MouseMoveProbability float64
 	ClickProbability     float64
 	ReadingSpeed         int
+	Rand                 *rand.Rand // Çalıştırmanın seed'inden türetilmiş kaynak (nil = zamana göre, tekrarlanamaz)
 }
 
 // HumanBehavior insan benzeri davranış simülatörü
@@ -50,19 +53,23 @@ func NewHumanBehavior(config *BehaviorConfig) *HumanBehavior {
 			ReadingSpeed:         250,
 		}
 	}
+	rng := config.Rand
+	if rng == nil {
+		rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
 	return &HumanBehavior{
 		config:  config,
 		profile: nil,
-		rng:     rand.New(rand.NewSource(time.Now().UnixNano())),
+		rng:     rng,
 	}
 }
 
 // NewHumanBehaviorWithProfile belirli bir davranış profili ile simülatör oluşturur
-func NewHumanBehaviorWithProfile(profile *BehavioralProfile) *HumanBehavior {
+func NewHumanBehaviorWithProfile(profile *BehavioralProfile, seeder *utils.Seeder) *HumanBehavior {
 	hb := &HumanBehavior{
 		config:  nil,
 		profile: profile,
-		rng:     rand.New(rand.NewSource(time.Now().UnixNano())),
+		rng:     seeder.NewRand(),
 	}
 	
 	// Profilden BehaviorConfig oluştur
@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/chromedp/chromedp"
+
+	"vgbot/pkg/utils"
 )
 
 // ProfileType davranış profili tipi
@@ -172,9 +174,9 @@ type ProfileManager struct {
 }
 
 // NewProfileManager yeni profil yöneticisi oluşturur
-func NewProfileManager() *ProfileManager {
+func NewProfileManager(seeder *utils.Seeder) *ProfileManager {
 	return &ProfileManager{
-		rng: rand.New(rand.NewSource(time.Now().UnixNano())),
+		rng: seeder.NewRand(),
 	}
 }
 
@@ -244,8 +246,7 @@ func (pm *ProfileManager) GetRandomProfileWeighted(weights map[ProfileType]int)
 }
 
 // GetDwellTime profil için dwell time hesaplar
-func (p *BehaviorProfile) GetDwellTime() time.Duration {
-	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+func (p *BehaviorProfile) GetDwellTime(rng *rand.Rand) time.Duration {
 	diff := p.MaxDwellTime - p.MinDwellTime
 	return p.MinDwellTime + time.Duration(rng.Int63n(int64(diff)))
 }
@@ -276,10 +277,10 @@ func (p *BehaviorProfile) ShouldNavigateBack() bool {
 }
 
 // SimulateBehavior profil davranışını simüle eder
-func (p *BehaviorProfile) SimulateBehavior(ctx context.Context) error {
-	dwellTime := p.GetDwellTime()
+func (p *BehaviorProfile) SimulateBehavior(ctx context.Context, seeder *utils.Seeder) error {
+	rng := seeder.NewRand()
+	dwellTime := p.GetDwellTime(rng)
 	start := time.Now()
-	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
 	
 	// Scroll davranışı
 	scrollSteps := int(p.ScrollDepth * 10)
@@ -12,6 +12,9 @@ import (
 
 	"github.com/chromedp/cdproto/network"
 	"github.com/chromedp/chromedp"
+
+	"vgbot/pkg/chromeflags"
+	"vgbot/pkg/chromelocator"
 )
 
 // PoolConfig defines configuration for the browser pool
@@ -411,31 +414,16 @@ drained:
 
 // createInstance creates a new Chrome browser instance
 func (p *BrowserPool) createInstance() (*BrowserInstance, error) {
-	opts := append(chromedp.DefaultExecAllocatorOptions[:],
-		chromedp.Flag("headless", p.config.Headless),
-		chromedp.Flag("disable-gpu", true),
-		chromedp.Flag("no-sandbox", true),
-		chromedp.Flag("disable-dev-shm-usage", true),
-		chromedp.Flag("disable-setuid-sandbox", true),
-		// Headless bypass - critical for anti-detection
-		chromedp.Flag("disable-blink-features", "AutomationControlled"),
-		chromedp.Flag("disable-background-timer-throttling", true),
-		chromedp.Flag("disable-backgrounding-occluded-windows", true),
-		chromedp.Flag("disable-renderer-backgrounding", true),
-		chromedp.Flag("disable-features", "IsolateOrigins,site-per-process,TranslateUI"),
-		chromedp.Flag("no-first-run", true),
-		chromedp.Flag("no-default-browser-check", true),
-		chromedp.Flag("disable-hang-monitor", true),
-		chromedp.Flag("disable-prompt-on-repost", true),
-		chromedp.Flag("disable-sync", true),
-		// Performance optimizations
-		chromedp.Flag("disable-web-security", false),
-		chromedp.Flag("disable-extensions", true),
-		chromedp.Flag("disable-plugins", true),
-		chromedp.Flag("disable-images", false), // May need images for some sites
-		chromedp.Flag("disk-cache-size", 33554432), // 32MB cache
-		chromedp.Flag("media-cache-size", 33554432),
-	)
+	// Flags come from chromeflags; Headless=false in PoolConfig forces a visible window
+	opts := chromeflags.AllocatorOptions(chromeflags.ProfilePool)
+	if !p.config.Headless {
+		opts = append(opts, chromedp.Flag("headless", false))
+	}
+
+	// Use the browser found by chromelocator; otherwise chromedp searches on its own
+	if path := chromelocator.ExecPath(); path != "" {
+		opts = append(opts, chromedp.ExecPath(path))
+	}
 
 	// Configure proxy if provided
 	proxyURL := p.config.ProxyURL
@@ -459,7 +447,7 @@ func (p *BrowserPool) createInstance() (*BrowserInstance, error) {
 
 		opts = append(opts,
 			chromedp.ProxyServer(proxyURL),
-			chromedp.Flag("proxy-bypass-list", "<-loopback>"),
+			chromedp.Flag("proxy-bypass-list", chromeflags.ProxyBypassList()),
 		)
 	}
 
@@ -26,6 +26,7 @@ import (
 	"vgbot/pkg/referrer"
 	"vgbot/pkg/stealth"
 	"vgbot/pkg/useragent"
+	"vgbot/pkg/utils"
 )
 
 // PooledHitVisitor is a high-performance visitor that uses browser pool
@@ -36,6 +37,7 @@ type PooledHitVisitor struct {
 	defaultUserAgent string
 	profilePool      *behavior.ProfilePool
 	mixedModeOpts    *MixedModeOptions
+	seeder           *utils.Seeder
 	mu               sync.RWMutex
 }
 
@@ -49,6 +51,9 @@ type PooledHitVisitorConfig struct {
 	
 	// Default User-Agent if agent provider fails
 	DefaultUserAgent string
+
+	// Seeder drives behavioral profiles and human behavior (nil = not reproducible)
+	Seeder *utils.Seeder
 }
 
 // NewPooledHitVisitor creates a new pooled visitor with the given configuration
@@ -65,6 +70,7 @@ func NewPooledHitVisitor(config PooledHitVisitorConfig) (*PooledHitVisitor, erro
 		defaultUserAgent: config.DefaultUserAgent,
 		profilePool:      nil,
 		mixedModeOpts:    nil,
+		seeder:           config.Seeder,
 	}
 
 	return visitor, nil
@@ -78,13 +84,13 @@ func (v *PooledHitVisitor) SetMixedMode(opts MixedModeOptions) {
 	v.mixedModeOpts = &opts
 	
 	if opts.EnableMixedMode && opts.ProfilePoolSize > 0 {
-		v.profilePool = behavior.NewProfilePool(opts.ProfilePoolSize)
+		v.profilePool = behavior.NewProfilePool(opts.ProfilePoolSize, v.seeder)
 	}
 }
 
 // GenerateRandomProfile rastgele davranış profili oluşturur
 func (v *PooledHitVisitor) GenerateRandomProfile() *behavior.BehavioralProfile {
-	return behavior.GenerateRandomProfile()
+	return behavior.GenerateRandomProfile(v.seeder)
 }
 
 // GetNextMixedProfile mixed mode için sıradaki profili döner
@@ -97,7 +103,7 @@ func (v *PooledHitVisitor) GetNextMixedProfile() *behavior.BehavioralProfile {
 	}
 	
 	// Profil havuzu yoksa yeni profil oluştur
-	return behavior.GenerateRandomProfile()
+	return behavior.GenerateRandomProfile(v.seeder)
 }
 
 // Close shuts down the visitor and its pool
@@ -221,7 +227,7 @@ func (v *PooledHitVisitor) VisitURL(ctx context.Context, opts VisitOptions) erro
 		opts.BehavioralProfile = v.GetNextMixedProfile()
 	}
 	
-	if err := applyPostLoadActions(tabCtx, opts, advFP); err != nil {
+	if err := applyPostLoadActions(tabCtx, opts, advFP, v.seeder); err != nil {
 		// Post-load errors are not critical
 		_ = err
 	}
@@ -468,7 +474,7 @@ func injectGtag(tabCtx context.Context, gtagID string) {
 }
 
 // applyPostLoadActions applies canvas fingerprint, scrolling and behavior simulation
-func applyPostLoadActions(tabCtx context.Context, opts VisitOptions, advFP *fingerprint.AdvancedFingerprint) error {
+func applyPostLoadActions(tabCtx context.Context, opts VisitOptions, advFP *fingerprint.AdvancedFingerprint, seeder *utils.Seeder) error {
 	// Stealth scripts
 	stealthCfg := buildStealthConfig(advFP, advFP.UserAgent)
 	if err := stealth.InjectStealthScripts(tabCtx, stealthCfg); err != nil {
@@ -506,7 +512,7 @@ func applyPostLoadActions(tabCtx context.Context, opts VisitOptions, advFP *fing
 	
 	if opts.BehavioralProfile != nil {
 		// Belirtilen profili kullan
-		hum = behavior.NewHumanBehaviorWithProfile(opts.BehavioralProfile)
+		hum = behavior.NewHumanBehaviorWithProfile(opts.BehavioralProfile, seeder)
 	} else {
 		// Varsayılan davranış
 		hum = behavior.NewHumanBehavior(&behavior.BehaviorConfig{
@@ -515,6 +521,7 @@ func applyPostLoadActions(tabCtx context.Context, opts VisitOptions, advFP *fing
 			ScrollProbability:    0.5,
 			MouseMoveProbability: 0.5,
 			ClickProbability:     0,
+			Rand:                 seeder.NewRand(),
 		})
 	}
 
@@ -0,0 +1,53 @@
+package browserpool
+
+import (
+	"context"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// launchTimeout Chrome'un başlayıp DevTools bağlantısını kabul etmesi için süre
+const launchTimeout = 45 * time.Second
+
+// chromeBrowser chromedp ile başlatılmış Chrome süreci
+type chromeBrowser struct {
+	ctx         context.Context // Tarayıcı context'i; sekmeler bundan türer
+	cancel      context.CancelFunc
+	allocCancel context.CancelFunc
+}
+
+// chromeLauncher opts ile Chrome başlatır ve ilk boş sekmeyi açarak süreci sıcak tutar
+func chromeLauncher(opts []chromedp.ExecAllocatorOption) launcher {
+	return func(parent context.Context) (browser, error) {
+		allocCtx, allocCancel := chromedp.NewExecAllocator(parent, opts...)
+		ctx, cancel := chromedp.NewContext(allocCtx, chromedp.WithLogf(func(string, ...interface{}) {}))
+		// Boş Run süreci başlatır. İlk Run'ın context'i tarayıcının ömrünü belirlediği için zaman aşımı
+		// context'le değil zamanlayıcıyla uygulanır
+		timer := time.AfterFunc(launchTimeout, cancel)
+		err := chromedp.Run(ctx)
+		if !timer.Stop() && err == nil {
+			err = context.DeadlineExceeded
+		}
+		if err != nil {
+			cancel()
+			allocCancel()
+			return nil, err
+		}
+		return &chromeBrowser{ctx: ctx, cancel: cancel, allocCancel: allocCancel}, nil
+	}
+}
+
+func (b *chromeBrowser) newTab(opts ...chromedp.ContextOption) (context.Context, context.CancelFunc) {
+	opts = append(opts[:len(opts):len(opts)], chromedp.WithNewBrowserContext())
+	return chromedp.NewContext(b.ctx, opts...)
+}
+
+func (b *chromeBrowser) alive() bool {
+	return b.ctx.Err() == nil
+}
+
+func (b *chromeBrowser) close() {
+	b.cancel()
+	b.allocCancel()
+}
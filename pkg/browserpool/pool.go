@@ -0,0 +1,323 @@
+// Package browserpool önceden başlatılmış (sıcak) Chrome süreçlerini ziyaretler arasında paylaşır.
+// Her ziyaret sıcak bir süreçte kendi browser context'inde (gizli pencere gibi: ayrı çerez, önbellek
+// ve depolama) yeni bir sekme açar; süreç belirli sayıda oturumdan veya belirli bir yaştan sonra
+// kapatılıp yerine yenisi başlatılır.
+package browserpool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// ErrExhausted havuzda boşta instance yoksa ve üst sınıra ulaşıldıysa Acquire'dan döner; çağıran
+// ziyareti havuzsuz (kendi Chrome'uyla) yapabilir
+var ErrExhausted = errors.New("browserpool: boşta instance yok")
+
+// ErrClosed havuz kapatıldıktan sonra Acquire'dan döner
+var ErrClosed = errors.New("browserpool: havuz kapalı")
+
+// Varsayılanlar (config.ApplyDefaults ile aynı)
+const (
+	defaultMin         = 2
+	defaultMax         = 10
+	defaultMaxSessions = 50
+	defaultMaxAge      = 30 * time.Minute
+	defaultIdleTimeout = 5 * time.Minute
+	maintainInterval   = 30 * time.Second
+)
+
+// Config havuz ayarları
+type Config struct {
+	Min         int           // AutoScale açıkken sıcak tutulan instance sayısı
+	Max         int           // Üst sınır; AutoScale kapalıyken havuz sabit Max instance tutar
+	AutoScale   bool          // Talebe göre Min..Max arasında büyü, boşta kalanları Min'e küçült
+	MaxSessions int           // Instance bu kadar ziyaretten sonra yenilenir
+	MaxAge      time.Duration // Instance bu yaştan sonra yenilenir
+	IdleTimeout time.Duration // AutoScale: Min üstündeki instance bu süre boşta kalırsa kapanır
+	// Chrome başlatma seçenekleri (flag'ler, exec yolu, proxy); HitVisitor'ınkilerle aynı olmalı
+	AllocatorOptions []chromedp.ExecAllocatorOption
+}
+
+// Stats havuz durumu (metrics collector ve /api/status için)
+type Stats struct {
+	Warm         int   `json:"warm"`          // Boşta, kullanıma hazır
+	InUse        int   `json:"in_use"`        // Ziyarette
+	Launching    int   `json:"launching"`     // Başlatılıyor
+	Launched     int64 `json:"launched"`      // Toplam başlatılan
+	Recycled     int64 `json:"recycled"`      // Oturum/yaş sınırı, hata veya küçülme ile kapatılan
+	Reused       int64 `json:"reused"`        // Sıcak instance ile yapılan ziyaret
+	Exhausted    int64 `json:"exhausted"`     // Boşta instance olmadığı için havuzsuz yapılan ziyaret
+	LaunchErrors int64 `json:"launch_errors"` // Başlatılamayan instance
+}
+
+// browser havuzdaki tek Chrome süreci
+type browser interface {
+	// newTab ayrı browser context'inde yeni sekme açar; cancel sekmeyi ve context'i kapatır
+	newTab(opts ...chromedp.ContextOption) (context.Context, context.CancelFunc)
+	alive() bool
+	close()
+}
+
+// launcher yeni Chrome süreci başlatır (testlerde sahte tarayıcı)
+type launcher func(ctx context.Context) (browser, error)
+
+type instance struct {
+	b        browser
+	created  time.Time
+	lastUsed time.Time
+	sessions int
+}
+
+// Pool sıcak Chrome instance havuzu
+type Pool struct {
+	cfg    Config
+	launch launcher
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu        sync.Mutex
+	idle      []*instance
+	inUse     int
+	launching int
+	closed    bool
+	stats     Stats
+}
+
+// New havuzu oluşturur; sıcak instance'lar arka planda başlatılır (New beklemez)
+func New(cfg Config) *Pool {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := newPool(ctx, cancel, cfg, chromeLauncher(cfg.AllocatorOptions))
+	p.wg.Add(1)
+	go p.maintainLoop()
+	return p
+}
+
+func newPool(ctx context.Context, cancel context.CancelFunc, cfg Config, launch launcher) *Pool {
+	if cfg.Max <= 0 {
+		cfg.Max = defaultMax
+	}
+	if cfg.Min <= 0 {
+		cfg.Min = defaultMin
+	}
+	cfg.Min = min(cfg.Min, cfg.Max)
+	if cfg.MaxSessions <= 0 {
+		cfg.MaxSessions = defaultMaxSessions
+	}
+	if cfg.MaxAge <= 0 {
+		cfg.MaxAge = defaultMaxAge
+	}
+	if cfg.IdleTimeout <= 0 {
+		cfg.IdleTimeout = defaultIdleTimeout
+	}
+	p := &Pool{cfg: cfg, launch: launch, ctx: ctx, cancel: cancel}
+	p.fill()
+	return p
+}
+
+// warmTarget sıcak tutulacak instance sayısı
+func (p *Pool) warmTarget() int {
+	if p.cfg.AutoScale {
+		return p.cfg.Min
+	}
+	return p.cfg.Max
+}
+
+// Acquire boşta bir instance alır; yoksa ve AutoScale açıksa (Max'a kadar) yenisini başlatır.
+// Havuz doluysa beklemez, ErrExhausted döner.
+func (p *Pool) Acquire(ctx context.Context) (*Lease, error) {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil, ErrClosed
+	}
+	for len(p.idle) > 0 {
+		inst := p.idle[len(p.idle)-1]
+		p.idle = p.idle[:len(p.idle)-1]
+		if !inst.b.alive() || p.expired(inst, time.Now()) {
+			p.stats.Recycled++
+			go inst.b.close()
+			continue
+		}
+		p.inUse++
+		p.stats.Reused++
+		p.mu.Unlock()
+		return &Lease{pool: p, inst: inst}, nil
+	}
+	if !p.cfg.AutoScale || p.total() >= p.cfg.Max {
+		p.stats.Exhausted++
+		p.mu.Unlock()
+		p.fill()
+		return nil, ErrExhausted
+	}
+	p.launching++
+	p.mu.Unlock()
+
+	b, err := p.launch(p.ctx)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.launching--
+	if err != nil {
+		p.stats.LaunchErrors++
+		return nil, err
+	}
+	p.stats.Launched++
+	if p.closed {
+		go b.close()
+		return nil, ErrClosed
+	}
+	now := time.Now()
+	p.inUse++
+	return &Lease{pool: p, inst: &instance{b: b, created: now, lastUsed: now}}, nil
+}
+
+// release ziyareti biten instance'ı havuza döndürür veya sınırı dolduysa kapatır
+func (p *Pool) release(inst *instance) {
+	p.mu.Lock()
+	p.inUse--
+	inst.sessions++
+	inst.lastUsed = time.Now()
+	if p.closed || !inst.b.alive() || p.expired(inst, inst.lastUsed) || p.total() >= p.cfg.Max {
+		p.stats.Recycled++
+		p.mu.Unlock()
+		inst.b.close()
+		p.fill()
+		return
+	}
+	p.idle = append(p.idle, inst)
+	p.mu.Unlock()
+}
+
+// expired oturum veya yaş sınırı doldu mu (p.mu tutulurken)
+func (p *Pool) expired(inst *instance, now time.Time) bool {
+	return inst.sessions >= p.cfg.MaxSessions || now.Sub(inst.created) >= p.cfg.MaxAge
+}
+
+// total tüm instance'lar (p.mu tutulurken)
+func (p *Pool) total() int {
+	return len(p.idle) + p.inUse + p.launching
+}
+
+// fill sıcak instance sayısını hedefe tamamlar; başlatma arka planda yapılır
+func (p *Pool) fill() {
+	p.mu.Lock()
+	n := 0
+	if !p.closed {
+		n = p.warmTarget() - p.total()
+	}
+	p.launching += max(n, 0)
+	p.mu.Unlock()
+	for i := 0; i < n; i++ {
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			b, err := p.launch(p.ctx)
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			p.launching--
+			if err != nil {
+				p.stats.LaunchErrors++
+				return
+			}
+			p.stats.Launched++
+			if p.closed {
+				go b.close()
+				return
+			}
+			now := time.Now()
+			p.idle = append(p.idle, &instance{b: b, created: now, lastUsed: now})
+		}()
+	}
+}
+
+// maintain yaşı dolan veya ölen boşta instance'ları kapatır, AutoScale'de uzun süre boşta kalan
+// fazlalıkları Min'e indirir ve sıcak sayıyı tamamlar
+func (p *Pool) maintain(now time.Time) {
+	p.mu.Lock()
+	keep := p.idle[:0]
+	var drop []*instance
+	excess := p.total() - p.warmTarget()
+	for _, inst := range p.idle {
+		switch {
+		case !inst.b.alive() || p.expired(inst, now):
+			drop = append(drop, inst)
+		case p.cfg.AutoScale && excess > 0 && now.Sub(inst.lastUsed) >= p.cfg.IdleTimeout:
+			drop = append(drop, inst)
+			excess--
+		default:
+			keep = append(keep, inst)
+		}
+	}
+	p.idle = keep
+	p.stats.Recycled += int64(len(drop))
+	p.mu.Unlock()
+	for _, inst := range drop {
+		inst.b.close()
+	}
+	p.fill()
+}
+
+func (p *Pool) maintainLoop() {
+	defer p.wg.Done()
+	t := time.NewTicker(maintainInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case now := <-t.C:
+			p.maintain(now)
+		}
+	}
+}
+
+// Stats anlık havuz durumu
+func (p *Pool) Stats() Stats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	s := p.stats
+	s.Warm, s.InUse, s.Launching = len(p.idle), p.inUse, p.launching
+	return s
+}
+
+// Close tüm instance'ları kapatır; kullanımdaki instance'lar Release'te kapanır
+func (p *Pool) Close() {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.closed = true
+	idle := p.idle
+	p.idle = nil
+	p.stats.Recycled += int64(len(idle))
+	p.mu.Unlock()
+	for _, inst := range idle {
+		inst.b.close()
+	}
+	p.cancel()
+	p.wg.Wait()
+}
+
+// Lease ziyaret süresince kiralanan instance
+type Lease struct {
+	pool *Pool
+	inst *instance
+	once sync.Once
+}
+
+// NewTab instance'da ayrı browser context'inde sekme açar (çerez, önbellek ve depolama ziyarete
+// özeldir); cancel sekmeyi ve browser context'i kapatır
+func (l *Lease) NewTab(opts ...chromedp.ContextOption) (context.Context, context.CancelFunc) {
+	return l.inst.b.newTab(opts...)
+}
+
+// Release instance'ı havuza iade eder; birden fazla çağrı güvenlidir
+func (l *Lease) Release() {
+	l.once.Do(func() { l.pool.release(l.inst) })
+}
@@ -0,0 +1,118 @@
+package browserpool
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+type fakeBrowser struct {
+	closed atomic.Bool
+}
+
+func (b *fakeBrowser) newTab(...chromedp.ContextOption) (context.Context, context.CancelFunc) {
+	return context.WithCancel(context.Background())
+}
+func (b *fakeBrowser) alive() bool { return !b.closed.Load() }
+func (b *fakeBrowser) close()      { b.closed.Store(true) }
+
+func testPool(t *testing.T, cfg Config) *Pool {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	p := newPool(ctx, cancel, cfg, func(context.Context) (browser, error) { return &fakeBrowser{}, nil })
+	t.Cleanup(p.Close)
+	waitWarm(t, p, p.warmTarget())
+	return p
+}
+
+func waitWarm(t *testing.T, p *Pool, n int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for p.Stats().Warm < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("warm = %+v, want %d", p.Stats(), n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestPoolReusesAndRecycles(t *testing.T) {
+	p := testPool(t, Config{Min: 1, Max: 1, MaxSessions: 2})
+	first, err := p.Acquire(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.Acquire(context.Background()); !errors.Is(err, ErrExhausted) {
+		t.Fatalf("second acquire err = %v", err)
+	}
+	first.Release()
+	first.Release() // ikinci çağrı etkisiz
+
+	second, err := p.Acquire(context.Background())
+	if err != nil || second.inst != first.inst {
+		t.Fatalf("instance not reused: %v", err)
+	}
+	second.Release() // 2. oturum: MaxSessions doldu, yenilenir
+	if !first.inst.b.(*fakeBrowser).closed.Load() {
+		t.Error("instance not recycled after MaxSessions")
+	}
+	waitWarm(t, p, 1)
+	s := p.Stats()
+	if s.Launched != 2 || s.Recycled != 1 || s.Reused != 2 || s.Exhausted != 1 || s.InUse != 0 {
+		t.Fatalf("stats = %+v", s)
+	}
+}
+
+func TestPoolAutoScale(t *testing.T) {
+	p := testPool(t, Config{Min: 1, Max: 2, AutoScale: true, IdleTimeout: time.Minute})
+	a, _ := p.Acquire(context.Background())
+	b, err := p.Acquire(context.Background())
+	if err != nil {
+		t.Fatalf("scale up: %v", err)
+	}
+	if _, err := p.Acquire(context.Background()); !errors.Is(err, ErrExhausted) {
+		t.Fatalf("acquire above Max err = %v", err)
+	}
+	a.Release()
+	b.Release()
+	if s := p.Stats(); s.Warm != 2 {
+		t.Fatalf("stats = %+v", s)
+	}
+
+	p.maintain(time.Now().Add(30 * time.Second))
+	if s := p.Stats(); s.Warm != 2 {
+		t.Fatalf("shrunk before idle timeout: %+v", s)
+	}
+	p.maintain(time.Now().Add(2 * time.Minute))
+	if s := p.Stats(); s.Warm != 1 || s.Recycled != 1 {
+		t.Fatalf("idle shrink = %+v", s)
+	}
+	p.maintain(time.Now().Add(time.Hour)) // MaxAge (30 dk) doldu: yenilenir
+	waitWarm(t, p, 1)
+	if s := p.Stats(); s.Recycled != 2 || s.Launched != 3 {
+		t.Fatalf("age recycle = %+v", s)
+	}
+}
+
+func TestPoolClose(t *testing.T) {
+	p := testPool(t, Config{Min: 2, Max: 2})
+	l, err := p.Acquire(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Close()
+	if _, err := p.Acquire(context.Background()); !errors.Is(err, ErrClosed) {
+		t.Fatalf("acquire after close err = %v", err)
+	}
+	l.Release()
+	if !l.inst.b.(*fakeBrowser).closed.Load() {
+		t.Error("leased instance not closed on release after Close")
+	}
+	if s := p.Stats(); s.Warm != 0 || s.InUse != 0 {
+		t.Fatalf("stats = %+v", s)
+	}
+}
@@ -0,0 +1,409 @@
+// Package chromeflags Chrome başlatma flag'lerini tek yerden yönetir: ortak temel set, profil
+// (hit ziyaretçisi / tarayıcı havuzu) setleri, headless modu ve kullanıcının eklediği flag'ler.
+package chromeflags
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/chromedp/chromedp"
+)
+
+// Headless modları
+const (
+	HeadlessOld = "old" // Eski --headless (varsayılan, chromedp ile aynı)
+	HeadlessNew = "new" // --headless=new (Chrome 109+, tam tarayıcı motoru)
+	HeadlessOff = "off" // Görünür pencere
+)
+
+// Flag profilleri
+const (
+	ProfileHit  = "hit"  // internal/browser HitVisitor
+	ProfilePool = "pool" // pkg/browser BrowserPool
+)
+
+// Flag'in nereden geldiği (debug görünümü için)
+const (
+	SourceDefault   = "chromedp"
+	SourceBase      = "base"
+	SourceProfile   = "profile"
+	SourceHeadless  = "headless"
+	SourceExtension = "extension"
+	SourceHostMap   = "host_map"
+	SourceExtra     = "extra"
+)
+
+// Flag tek bir Chrome komut satırı flag'i. Value string veya bool olur; false bool flag'i kapatır.
+type Flag struct {
+	Name   string      `json:"name"`
+	Value  interface{} `json:"value"`
+	Source string      `json:"source"`
+}
+
+// Arg flag'in komut satırı biçimi ("" = kapalı)
+func (f Flag) Arg() string {
+	switch v := f.Value.(type) {
+	case bool:
+		if !v {
+			return ""
+		}
+		return "--" + f.Name
+	default:
+		return fmt.Sprintf("--%s=%v", f.Name, v)
+	}
+}
+
+// chromedpDefaults chromedp.DefaultExecAllocatorOptions (v0.9.5) ile aynı set; ExecAllocator
+// seçenekleri buradan üretildiği için debug görünümü tarayıcıya giden komut satırının tamamını gösterir
+var chromedpDefaults = []Flag{
+	{Name: "no-first-run", Value: true},
+	{Name: "no-default-browser-check", Value: true},
+	{Name: "headless", Value: true},
+	{Name: "hide-scrollbars", Value: true},
+	{Name: "mute-audio", Value: true},
+	{Name: "disable-background-networking", Value: true},
+	{Name: "enable-features", Value: "NetworkService,NetworkServiceInProcess"},
+	{Name: "disable-background-timer-throttling", Value: true},
+	{Name: "disable-backgrounding-occluded-windows", Value: true},
+	{Name: "disable-breakpad", Value: true},
+	{Name: "disable-client-side-phishing-detection", Value: true},
+	{Name: "disable-default-apps", Value: true},
+	{Name: "disable-dev-shm-usage", Value: true},
+	{Name: "disable-extensions", Value: true},
+	{Name: "disable-features", Value: "site-per-process,Translate,BlinkGenPropertyTrees"},
+	{Name: "disable-hang-monitor", Value: true},
+	{Name: "disable-ipc-flooding-protection", Value: true},
+	{Name: "disable-popup-blocking", Value: true},
+	{Name: "disable-prompt-on-repost", Value: true},
+	{Name: "disable-renderer-backgrounding", Value: true},
+	{Name: "disable-sync", Value: true},
+	{Name: "force-color-profile", Value: "srgb"},
+	{Name: "metrics-recording-only", Value: true},
+	{Name: "safebrowsing-disable-auto-update", Value: true},
+	{Name: "enable-automation", Value: true},
+	{Name: "password-store", Value: "basic"},
+	{Name: "use-mock-keychain", Value: true},
+}
+
+// base tüm profillerde ortak flag'ler
+var base = []Flag{
+	{Name: "disable-gpu", Value: true},
+	{Name: "no-sandbox", Value: true},
+	{Name: "disable-dev-shm-usage", Value: true},
+	{Name: "disable-setuid-sandbox", Value: true},
+	{Name: "disable-blink-features", Value: "AutomationControlled"},
+	{Name: "disable-background-timer-throttling", Value: true},
+	{Name: "disable-backgrounding-occluded-windows", Value: true},
+	{Name: "disable-renderer-backgrounding", Value: true},
+	{Name: "disable-features", Value: "IsolateOrigins,site-per-process,TranslateUI"},
+	{Name: "no-first-run", Value: true},
+	{Name: "no-default-browser-check", Value: true},
+	{Name: "disable-hang-monitor", Value: true},
+	{Name: "disable-prompt-on-repost", Value: true},
+	{Name: "disable-sync", Value: true},
+}
+
+// profiles profile özel flag'ler (base'in üzerine)
+var profiles = map[string][]Flag{
+	ProfileHit: nil,
+	ProfilePool: {
+		{Name: "disable-web-security", Value: false},
+		{Name: "disable-extensions", Value: true},
+		{Name: "disable-plugins", Value: true},
+		{Name: "disable-images", Value: false},
+		{Name: "disk-cache-size", Value: "33554432"},
+		{Name: "media-cache-size", Value: "33554432"},
+	},
+}
+
+// reserved chromedp'nin veya uygulamanın kendisinin yönettiği, elle eklenemeyen flag'ler
+var reserved = map[string]bool{
+	"remote-debugging-port": true,
+	"remote-debugging-pipe": true,
+	"user-data-dir":         true,
+	"proxy-server":          true,
+	"headless":              true,
+	// Options.Extensions ile yönetilir
+	"load-extension":            true,
+	"disable-extensions-except": true,
+	// Options.HostResolverRules ile yönetilir
+	"host-resolver-rules": true,
+}
+
+// allowed temel set ve profiller dışında elle eklenebilen flag'ler. Liste bilerek dar tutulur: Chrome'un
+// süreç başlatan flag'leri (renderer-cmd-prefix, gpu-launcher, browser-subprocess-path, ...) komut
+// çalıştırmaya izin verdiğinden yalnızca görünüm, dil ve render ayarları kabul edilir.
+var allowed = map[string]bool{
+	"lang":                                   true,
+	"accept-lang":                            true,
+	"user-agent":                             true,
+	"window-size":                            true,
+	"window-position":                        true,
+	"force-device-scale-factor":              true,
+	"hide-scrollbars":                        true,
+	"mute-audio":                             true,
+	"autoplay-policy":                        true,
+	"blink-settings":                         true,
+	"enable-features":                        true,
+	"force-dark-mode":                        true,
+	"force-color-profile":                    true,
+	"font-render-hinting":                    true,
+	"disable-gpu":                            true,
+	"disable-software-rasterizer":            true,
+	"disable-dev-shm-usage":                  true,
+	"no-sandbox":                             true,
+	"ignore-certificate-errors":              true,
+	"disable-notifications":                  true,
+	"disable-popup-blocking":                 true,
+	"disable-translate":                      true,
+	"disable-default-apps":                   true,
+	"disable-component-update":               true,
+	"disable-client-side-phishing-detection": true,
+}
+
+var flagNameRe = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// Options kullanıcı ayarları (config'ten)
+type Options struct {
+	Headless string   // old, new, off ("" = old)
+	Extra    []string // "flag", "flag=değer", "no-flag" kapatır; "pool:" / "hit:" öneki yalnızca o profile uygulanır
+	// Extensions yüklenecek paketlenmemiş eklenti dizinleri (manifest.json içeren).
+	// Eski headless modu eklenti çalıştırmadığı için new veya off gerektirir.
+	Extensions []string
+	// HostResolverRules --host-resolver-rules değeri (network.HostMap.ResolverRules); production
+	// host'larına giden bağlantılar staging hedefine yönlenir, URL'ler değişmez
+	HostResolverRules string
+	// ProxyBypass proxy kullanılmadan doğrudan bağlanılacak host'lar (staging'e yönlenen host'lar)
+	ProxyBypass []string
+}
+
+var (
+	mu       sync.RWMutex
+	defaults Options
+)
+
+// SetDefaults uygulama genelindeki ayarları günceller (server config'ten çağırır); sonraki
+// tarayıcı başlatmalarında geçerli olur
+func SetDefaults(opts Options) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	mu.Lock()
+	defaults = opts
+	mu.Unlock()
+	return nil
+}
+
+// Defaults geçerli ayarları döner
+func Defaults() Options {
+	mu.RLock()
+	defer mu.RUnlock()
+	return defaults
+}
+
+// Validate headless modunu ve ek flag'leri doğrular
+func (o Options) Validate() error {
+	switch o.Headless {
+	case "", HeadlessOld, HeadlessNew, HeadlessOff:
+	default:
+		return fmt.Errorf("geçersiz headless modu: %q (old, new, off)", o.Headless)
+	}
+	for _, spec := range o.Extra {
+		if _, _, err := ParseExtra(spec); err != nil {
+			return err
+		}
+	}
+	if len(o.Extensions) > 0 && (o.Headless == "" || o.Headless == HeadlessOld) {
+		return fmt.Errorf("eklentiler eski headless modunda yüklenmez; headless modunu new veya off yapın")
+	}
+	for _, dir := range o.Extensions {
+		if err := ValidateExtension(dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateExtension dizinin paketlenmemiş bir Chrome eklentisi olduğunu doğrular
+func ValidateExtension(dir string) error {
+	if strings.Contains(dir, ",") {
+		return fmt.Errorf("eklenti yolu virgül içeremez: %s", dir)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "manifest.json"))
+	if err != nil {
+		return fmt.Errorf("eklenti %s: manifest.json okunamadı: %w", dir, err)
+	}
+	var m struct {
+		ManifestVersion int    `json:"manifest_version"`
+		Name            string `json:"name"`
+	}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return fmt.Errorf("eklenti %s: geçersiz manifest.json: %w", dir, err)
+	}
+	if m.ManifestVersion == 0 || m.Name == "" {
+		return fmt.Errorf("eklenti %s: manifest.json manifest_version ve name içermeli", dir)
+	}
+	return nil
+}
+
+// ParseExtra "pool:--lang=tr" gibi bir ek flag tanımını profil ve flag'e ayırır (profil "" = tümü)
+func ParseExtra(spec string) (profile string, f Flag, err error) {
+	spec = strings.TrimSpace(spec)
+	if i := strings.Index(spec, ":"); i > 0 && !strings.Contains(spec[:i], "=") {
+		profile, spec = spec[:i], spec[i+1:]
+		if _, ok := profiles[profile]; !ok {
+			return "", Flag{}, fmt.Errorf("bilinmeyen flag profili: %q", profile)
+		}
+	}
+	spec = strings.TrimLeft(spec, "-")
+	name, value, hasValue := strings.Cut(spec, "=")
+	f = Flag{Name: name, Value: true, Source: SourceExtra}
+	if hasValue {
+		f.Value = value
+	} else if strings.HasPrefix(name, "no-") && isKnown(strings.TrimPrefix(name, "no-")) {
+		// "no-disable-sync" temel setteki flag'i kapatır
+		f = Flag{Name: strings.TrimPrefix(name, "no-"), Value: false, Source: SourceExtra}
+	}
+	if !flagNameRe.MatchString(f.Name) {
+		return "", Flag{}, fmt.Errorf("geçersiz flag: %q", spec)
+	}
+	if reserved[f.Name] {
+		return "", Flag{}, fmt.Errorf("%s flag'i uygulama tarafından yönetilir", f.Name)
+	}
+	if !allowed[f.Name] && !isKnown(f.Name) {
+		return "", Flag{}, fmt.Errorf("%s flag'i izin verilenler listesinde değil", f.Name)
+	}
+	return profile, f, nil
+}
+
+// isKnown flag temel sette veya herhangi bir profilde tanımlı mı
+func isKnown(name string) bool {
+	for _, f := range base {
+		if f.Name == name {
+			return true
+		}
+	}
+	for _, fs := range profiles {
+		for _, f := range fs {
+			if f.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Build profil için son flag listesini üretir. Sıra: chromedp varsayılanları → base → profil →
+// headless → ek flag'ler; aynı isimli flag'lerde sonraki geçerlidir. Sonuç isme göre sıralıdır.
+func Build(profile string, opts Options) []Flag {
+	byName := map[string]Flag{}
+	set := func(fs []Flag, source string) {
+		for _, f := range fs {
+			if f.Source == "" {
+				f.Source = source
+			}
+			byName[f.Name] = f
+		}
+	}
+	set(chromedpDefaults, SourceDefault)
+	set(base, SourceBase)
+	set(profiles[profile], SourceProfile)
+	set(headlessFlags(opts.Headless), SourceHeadless)
+	set(extensionFlags(opts.Extensions), SourceExtension)
+	if opts.HostResolverRules != "" {
+		set([]Flag{{Name: "host-resolver-rules", Value: opts.HostResolverRules}}, SourceHostMap)
+	}
+	for _, spec := range opts.Extra {
+		p, f, err := ParseExtra(spec)
+		if err != nil || (p != "" && p != profile) {
+			continue
+		}
+		byName[f.Name] = f
+	}
+
+	out := make([]Flag, 0, len(byName))
+	for _, f := range byName {
+		out = append(out, f)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// extensionFlags eklenti dizinleri için flag'ler (yalnızca listelenen eklentiler etkin olur)
+func extensionFlags(dirs []string) []Flag {
+	if len(dirs) == 0 {
+		return nil
+	}
+	abs := make([]string, 0, len(dirs))
+	for _, d := range dirs {
+		if a, err := filepath.Abs(d); err == nil {
+			d = a
+		}
+		abs = append(abs, d)
+	}
+	list := strings.Join(abs, ",")
+	return []Flag{
+		{Name: "disable-extensions", Value: false},
+		{Name: "load-extension", Value: list},
+		{Name: "disable-extensions-except", Value: list},
+	}
+}
+
+// headlessFlags moda göre headless flag'leri
+func headlessFlags(mode string) []Flag {
+	switch mode {
+	case HeadlessNew:
+		return []Flag{{Name: "headless", Value: "new"}}
+	case HeadlessOff:
+		return []Flag{{Name: "headless", Value: false}, {Name: "hide-scrollbars", Value: false}, {Name: "mute-audio", Value: false}}
+	default:
+		return []Flag{{Name: "headless", Value: true}}
+	}
+}
+
+// AllocatorOptions profil için chromedp ExecAllocator seçeneklerini üretir (varsayılan ayarlarla)
+func AllocatorOptions(profile string) []chromedp.ExecAllocatorOption {
+	return AllocatorOptionsWith(profile, Defaults())
+}
+
+// AllocatorOptionsWith profil ve ayarlar için chromedp ExecAllocator seçeneklerini üretir
+func AllocatorOptionsWith(profile string, opts Options) []chromedp.ExecAllocatorOption {
+	flags := Build(profile, opts)
+	out := make([]chromedp.ExecAllocatorOption, 0, len(flags))
+	for _, f := range flags {
+		out = append(out, chromedp.Flag(f.Name, f.Value))
+	}
+	return out
+}
+
+// ProxyBypassList --proxy-bypass-list değeri: loopback ve varsayılan ayarlardaki ProxyBypass host'ları
+func ProxyBypassList() string {
+	return strings.Join(append([]string{"<-loopback>"}, Defaults().ProxyBypass...), ";")
+}
+
+// CommandLine flag listesini komut satırı argümanlarına çevirir (kapalı flag'ler atlanır)
+func CommandLine(flags []Flag) []string {
+	args := make([]string, 0, len(flags))
+	for _, f := range flags {
+		if a := f.Arg(); a != "" {
+			args = append(args, a)
+		}
+	}
+	return args
+}
+
+// Profiles tanımlı flag profillerinin isimlerini döner
+func Profiles() []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
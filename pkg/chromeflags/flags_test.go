@@ -0,0 +1,133 @@
+package chromeflags
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func flagMap(flags []Flag) map[string]Flag {
+	m := map[string]Flag{}
+	for _, f := range flags {
+		m[f.Name] = f
+	}
+	return m
+}
+
+func TestBuildProfiles(t *testing.T) {
+	hit := flagMap(Build(ProfileHit, Options{}))
+	if hit["headless"].Value != true || hit["no-sandbox"].Source != SourceBase {
+		t.Fatalf("hit flags = %+v", hit)
+	}
+	if _, ok := hit["disk-cache-size"]; ok {
+		t.Fatal("pool flag leaked into hit profile")
+	}
+	// base chromedp varsayılanını ezer
+	if hit["disable-features"].Value != "IsolateOrigins,site-per-process,TranslateUI" {
+		t.Fatalf("disable-features = %v", hit["disable-features"].Value)
+	}
+	pool := flagMap(Build(ProfilePool, Options{}))
+	if pool["disk-cache-size"].Source != SourceProfile {
+		t.Fatalf("pool flags = %+v", pool)
+	}
+}
+
+func TestBuildHeadlessModes(t *testing.T) {
+	if f := flagMap(Build(ProfileHit, Options{Headless: HeadlessNew}))["headless"]; f.Value != "new" || f.Arg() != "--headless=new" {
+		t.Fatalf("new headless = %+v", f)
+	}
+	args := strings.Join(CommandLine(Build(ProfileHit, Options{Headless: HeadlessOff})), " ")
+	if strings.Contains(args, "--headless") || strings.Contains(args, "--mute-audio") {
+		t.Fatalf("headless off args = %s", args)
+	}
+}
+
+func TestBuildExtraFlags(t *testing.T) {
+	opts := Options{Extra: []string{"--lang=tr-TR", "pool:window-size=1366,768", "no-disable-sync"}}
+	if err := opts.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	hit := flagMap(Build(ProfileHit, opts))
+	if hit["lang"].Value != "tr-TR" || hit["lang"].Source != SourceExtra {
+		t.Fatalf("lang = %+v", hit["lang"])
+	}
+	if _, ok := hit["window-size"]; ok {
+		t.Fatal("pool-only flag applied to hit profile")
+	}
+	if hit["disable-sync"].Value != false {
+		t.Fatalf("disable-sync = %+v", hit["disable-sync"])
+	}
+	if flagMap(Build(ProfilePool, opts))["window-size"].Value != "1366,768" {
+		t.Fatal("pool-only flag missing from pool profile")
+	}
+}
+
+func TestValidateRejects(t *testing.T) {
+	for _, opts := range []Options{
+		{Headless: "sometimes"},
+		{Extra: []string{"user-data-dir=/tmp/x"}},
+		{Extra: []string{"headless=new"}},
+		{Extra: []string{"worker:lang=tr"}},
+		{Extra: []string{"bad flag"}},
+	} {
+		if err := opts.Validate(); err == nil {
+			t.Errorf("%+v: expected error", opts)
+		}
+	}
+}
+
+func TestValidateRejectsProcessLaunchFlags(t *testing.T) {
+	for _, spec := range []string{
+		"renderer-cmd-prefix=/bin/sh -c id",
+		"--gpu-launcher=/tmp/x",
+		"utility-cmd-prefix=/tmp/x",
+		"browser-subprocess-path=/tmp/x",
+		"pool:ppapi-plugin-launcher=/tmp/x",
+		"plugin-launcher=/tmp/x",
+		"unknown-flag",
+	} {
+		if _, _, err := ParseExtra(spec); err == nil {
+			t.Errorf("%s: expected error", spec)
+		}
+	}
+	for _, spec := range []string{"no-sandbox", "hit:user-agent=test", "disk-cache-size=1", "no-disable-sync"} {
+		if _, _, err := ParseExtra(spec); err != nil {
+			t.Errorf("%s: %v", spec, err)
+		}
+	}
+}
+
+func TestExtensions(t *testing.T) {
+	dir := t.TempDir()
+	ext := filepath.Join(dir, "ext")
+	os.MkdirAll(ext, 0755)
+	os.WriteFile(filepath.Join(ext, "manifest.json"), []byte(`{"manifest_version":3,"name":"measure"}`), 0644)
+
+	if err := (Options{Extensions: []string{ext}}).Validate(); err == nil {
+		t.Fatal("extensions with old headless should be rejected")
+	}
+	if err := (Options{Headless: HeadlessNew, Extensions: []string{dir}}).Validate(); err == nil {
+		t.Fatal("directory without manifest.json should be rejected")
+	}
+	opts := Options{Headless: HeadlessNew, Extensions: []string{ext}}
+	if err := opts.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	pool := flagMap(Build(ProfilePool, opts))
+	if pool["disable-extensions"].Value != false || pool["load-extension"].Value != ext ||
+		pool["disable-extensions-except"].Value != ext {
+		t.Fatalf("extension flags = %+v %+v", pool["load-extension"], pool["disable-extensions"])
+	}
+}
+
+func TestHostResolverRules(t *testing.T) {
+	opts := Options{HostResolverRules: "MAP example.com 10.0.0.5"}
+	f := flagMap(Build(ProfileHit, opts))["host-resolver-rules"]
+	if f.Value != "MAP example.com 10.0.0.5" || f.Source != SourceHostMap {
+		t.Fatalf("host-resolver-rules = %+v", f)
+	}
+	if err := (Options{Extra: []string{"host-resolver-rules=MAP * 1.1.1.1"}}).Validate(); err == nil {
+		t.Fatal("host-resolver-rules must be managed through host_map")
+	}
+}
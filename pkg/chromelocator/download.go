@@ -0,0 +1,229 @@
+package chromelocator
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// PinnedVersion indirilen Chromium (Chrome for Testing) sürümü; chromedp sürümüyle birlikte güncellenir
+const PinnedVersion = "121.0.6167.85"
+
+// DownloadBaseURL Chrome for Testing arşivlerinin kökü (testlerde değiştirilir)
+var DownloadBaseURL = "https://storage.googleapis.com/chrome-for-testing-public"
+
+// maxDownloadSize indirilecek arşivin üst sınırı
+const maxDownloadSize = 512 << 20
+
+// PinnedSHA256 sabitlenmiş arşivlerin SHA-256 özetleri ("sürüm/platform" → hex). Chrome for Testing
+// özet yayınlamaz; PinnedVersion güncellenirken `make chromium-sha256` ile downloadPlatforms'taki her
+// platformun arşivi indirilip özeti buraya eklenir. Listede olmayan sürüm/platform ancak beklenen özet
+// açıkça verilirse (browser_download_sha256) indirilir.
+var PinnedSHA256 = map[string]string{}
+
+// downloadPlatforms GOOS/GOARCH → Chrome for Testing platform adı (indirme desteklenen platformlar)
+var downloadPlatforms = map[string]string{
+	"linux/amd64":   "linux64",
+	"darwin/amd64":  "mac-x64",
+	"darwin/arm64":  "mac-arm64",
+	"windows/amd64": "win64",
+	"windows/386":   "win32",
+}
+
+// platform Chrome for Testing platform adını döner ("" = desteklenmiyor)
+func platform() string {
+	return downloadPlatforms[runtime.GOOS+"/"+runtime.GOARCH]
+}
+
+// executableIn arşiv açıldıktan sonra platformdaki çalıştırılabilir dosyanın göreli yolu
+func executableIn(plat string) string {
+	switch {
+	case strings.HasPrefix(plat, "mac"):
+		return filepath.Join("chrome-"+plat, "Google Chrome for Testing.app", "Contents", "MacOS", "Google Chrome for Testing")
+	case strings.HasPrefix(plat, "win"):
+		return filepath.Join("chrome-"+plat, "chrome.exe")
+	default:
+		return filepath.Join("chrome-"+plat, "chrome")
+	}
+}
+
+// cachedExecutable önbellekte daha önce indirilmiş sürüm varsa yolunu döner
+func cachedExecutable(cacheDir, version string) string {
+	plat := platform()
+	if plat == "" {
+		return ""
+	}
+	p := filepath.Join(cacheDir, version, executableIn(plat))
+	if _, err := os.Stat(p); err != nil {
+		return ""
+	}
+	return p
+}
+
+// Download sabitlenmiş Chromium sürümünü cacheDir/<version> altına indirip açar ve çalıştırılabilir
+// dosyanın yolunu döner. Önbellekte varsa indirme yapılmaz. Arşiv, wantSHA256 (boşsa PinnedSHA256)
+// ile eşleşmezse açılmaz; beklenen özet yoksa indirme yapılmaz. Yarım kalan indirmeler geçici
+// dizinde kalır; hedef dizin yalnızca arşiv tamamen açıldıktan sonra oluşur.
+func Download(ctx context.Context, cacheDir, version, wantSHA256 string) (string, error) {
+	plat := platform()
+	if plat == "" {
+		return "", fmt.Errorf("%s/%s için Chromium indirmesi desteklenmiyor", runtime.GOOS, runtime.GOARCH)
+	}
+	if p := cachedExecutable(cacheDir, version); p != "" {
+		return p, nil
+	}
+	want := strings.ToLower(strings.TrimSpace(wantSHA256))
+	if want == "" {
+		want = PinnedSHA256[version+"/"+plat]
+	}
+	if len(want) != sha256.Size*2 {
+		return "", fmt.Errorf("Chromium %s (%s) için sabitlenmiş SHA-256 yok; arşivin özetini browser_download_sha256 ile belirtin", version, plat)
+	}
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		return "", err
+	}
+
+	url := fmt.Sprintf("%s/%s/%s/chrome-%s.zip", strings.TrimRight(DownloadBaseURL, "/"), version, plat, plat)
+	archive, err := os.CreateTemp(cacheDir, "chromium-*.zip")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(archive.Name())
+	defer archive.Close()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("Chromium indirilemedi: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Chromium indirilemedi: %s: HTTP %d", url, resp.StatusCode)
+	}
+	sum := sha256.New()
+	n, err := io.Copy(io.MultiWriter(archive, sum), io.LimitReader(resp.Body, maxDownloadSize+1))
+	if err != nil {
+		return "", fmt.Errorf("Chromium indirilemedi: %w", err)
+	}
+	if n > maxDownloadSize {
+		return "", fmt.Errorf("Chromium arşivi çok büyük (> %d MB)", maxDownloadSize>>20)
+	}
+	if got := hex.EncodeToString(sum.Sum(nil)); got != want {
+		return "", fmt.Errorf("Chromium arşivinin SHA-256'sı eşleşmiyor: %s, beklenen %s", got, want)
+	}
+
+	tmpDir, err := os.MkdirTemp(cacheDir, "chromium-"+version+"-")
+	if err != nil {
+		return "", err
+	}
+	if err := unzip(archive.Name(), tmpDir); err != nil {
+		os.RemoveAll(tmpDir)
+		return "", fmt.Errorf("Chromium arşivi açılamadı: %w", err)
+	}
+	dst := filepath.Join(cacheDir, version)
+	if err := os.Rename(tmpDir, dst); err != nil {
+		os.RemoveAll(tmpDir)
+		return "", err
+	}
+	p := filepath.Join(dst, executableIn(plat))
+	if _, err := os.Stat(p); err != nil {
+		return "", fmt.Errorf("arşivde tarayıcı bulunamadı: %s", executableIn(plat))
+	}
+	return p, nil
+}
+
+// unzip arşivi dir altına açar; dizin dışına çıkan yolları ve sembolik bağları reddeder, dosya izinlerini
+// (çalıştırma biti) korur
+func unzip(archive, dir string) error {
+	zr, err := zip.OpenReader(archive)
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+	for _, f := range zr.File {
+		rel := path.Clean(f.Name)
+		if path.IsAbs(rel) || rel == ".." || strings.HasPrefix(rel, "../") {
+			return fmt.Errorf("geçersiz arşiv yolu: %s", f.Name)
+		}
+		dst := filepath.Join(dir, filepath.FromSlash(rel))
+		mode := f.Mode()
+		switch {
+		case mode.IsDir():
+			if err := os.MkdirAll(dst, 0755); err != nil {
+				return err
+			}
+		case mode&os.ModeSymlink != 0:
+			// macOS .app paketlerindeki framework sembolik bağları
+			target, err := readZipFile(f)
+			if err != nil {
+				return err
+			}
+			if !symlinkInside(rel, string(target)) {
+				return fmt.Errorf("geçersiz sembolik bağ: %s -> %s", f.Name, target)
+			}
+			if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+				return err
+			}
+			if err := os.Symlink(string(target), dst); err != nil {
+				return err
+			}
+		default:
+			if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+				return err
+			}
+			if err := extractZipFile(f, dst, mode.Perm()|0600); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// symlinkInside arşivdeki rel konumundaki bağın hedefi açılan dizin içinde mi kalıyor.
+// Mutlak hedefler ve arşiv köküne göre ".." ile dışarı çıkan hedefler reddedilir.
+func symlinkInside(rel, target string) bool {
+	target = filepath.ToSlash(target)
+	if target == "" || path.IsAbs(target) || filepath.IsAbs(filepath.FromSlash(target)) || filepath.VolumeName(filepath.FromSlash(target)) != "" {
+		return false
+	}
+	resolved := path.Join(path.Dir(rel), target)
+	return resolved != ".." && !strings.HasPrefix(resolved, "../")
+}
+
+func extractZipFile(f *zip.File, dst string, perm os.FileMode) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, rc); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func readZipFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
@@ -0,0 +1,315 @@
+// Package chromelocator kurulu Chrome/Chromium/Edge tarayıcısını bulur, chromedp ile sürüm
+// uyumluluğunu doğrular ve hiçbiri yoksa sabitlenmiş bir Chromium sürümünü önbellek dizinine indirir.
+package chromelocator
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MinMajorVersion chromedp'nin (cdproto protokol tanımları) güvenle çalıştığı en eski Chrome ana sürümü
+const MinMajorVersion = 100
+
+// EnvVar tarayıcı yolunu elle belirtmek için ortam değişkeni
+const EnvVar = "VGBOT_CHROME_PATH"
+
+// Tarayıcının nereden bulunduğu
+const (
+	SourceConfig     = "config"
+	SourceEnv        = "env"
+	SourceSystem     = "system"
+	SourceCache      = "cache"
+	SourceDownloaded = "downloaded"
+)
+
+// Browser bulunan tarayıcı
+type Browser struct {
+	Path       string `json:"path"`
+	Name       string `json:"name"` // chrome, chromium, edge
+	Version    string `json:"version"`
+	Major      int    `json:"major"`
+	Compatible bool   `json:"compatible"`
+	Source     string `json:"source"`
+}
+
+// Status /api/system/browser yanıtı
+type Status struct {
+	Browser       *Browser   `json:"browser,omitempty"`
+	Candidates    []*Browser `json:"candidates"` // Denenen ve çalıştırılabilen tüm tarayıcılar
+	MinMajor      int        `json:"min_major"`
+	PinnedVersion string     `json:"pinned_version"`
+	AutoDownload  bool       `json:"auto_download"`
+	CacheDir      string     `json:"cache_dir"`
+	Downloading   bool       `json:"downloading"`
+	Error         string     `json:"error,omitempty"`
+	CheckedAt     time.Time  `json:"checked_at"`
+}
+
+// Options Locator ayarları
+type Options struct {
+	Path         string // Elle belirtilen tarayıcı yolu (boşsa otomatik)
+	CacheDir     string // İndirilen Chromium'un saklandığı dizin
+	AutoDownload bool   // Uyumlu tarayıcı yoksa sabitlenmiş Chromium'u indir
+	SHA256       string // İndirilen arşivin beklenen SHA-256'sı (boşsa PinnedSHA256)
+}
+
+// Locator tarayıcı arama sonucunu saklar; tüm metodlar eşzamanlı kullanım için güvenlidir
+type Locator struct {
+	mu     sync.Mutex
+	opts   Options
+	status Status
+}
+
+// New yeni Locator oluşturur
+func New(opts Options) *Locator {
+	return &Locator{opts: opts}
+}
+
+var defaultLocator = New(Options{})
+
+// Default paylaşılan Locator'ı döner (tarayıcı başlatan paketler ExecPath ile kullanır)
+func Default() *Locator {
+	return defaultLocator
+}
+
+// SetOptions ayarları günceller; önceki arama sonucu geçersiz olur
+func (l *Locator) SetOptions(opts Options) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.opts = opts
+	l.status = Status{}
+}
+
+// ExecPath son aramada bulunan uyumlu tarayıcının yolunu döner; bulunmadıysa ""
+// (çağıran chromedp'nin varsayılan aramasına bırakır)
+func ExecPath() string {
+	return Default().ExecPath()
+}
+
+// ExecPath son aramada bulunan uyumlu tarayıcının yolunu döner
+func (l *Locator) ExecPath() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.status.Browser == nil {
+		return ""
+	}
+	return l.status.Browser.Path
+}
+
+// Status son arama durumunu döner
+func (l *Locator) Status() Status {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	st := l.status
+	st.MinMajor = MinMajorVersion
+	st.PinnedVersion = PinnedVersion
+	st.AutoDownload = l.opts.AutoDownload
+	st.CacheDir = l.opts.CacheDir
+	if st.Candidates == nil {
+		st.Candidates = []*Browser{}
+	}
+	return st
+}
+
+// Locate tarayıcıyı arar. Sıra: config yolu → ortam değişkeni → sistem kurulumları → önbellek →
+// (AutoDownload ise) sabitlenmiş Chromium indirmesi. İlk uyumlu tarayıcı seçilir.
+func (l *Locator) Locate(ctx context.Context) (*Browser, error) {
+	l.mu.Lock()
+	if l.status.Downloading {
+		l.mu.Unlock()
+		return nil, fmt.Errorf("Chromium indirmesi sürüyor")
+	}
+	opts := l.opts
+	l.mu.Unlock()
+
+	st := Status{CheckedAt: time.Now()}
+	var found *Browser
+	try := func(path, source string) bool {
+		b, err := Inspect(ctx, path)
+		if err != nil {
+			return false
+		}
+		b.Source = source
+		st.Candidates = append(st.Candidates, b)
+		if b.Compatible && found == nil {
+			found = b
+		}
+		return found != nil
+	}
+
+	switch {
+	case opts.Path != "" && try(opts.Path, SourceConfig):
+	case os.Getenv(EnvVar) != "" && try(os.Getenv(EnvVar), SourceEnv):
+	default:
+		for _, p := range systemCandidates() {
+			if try(p, SourceSystem) {
+				break
+			}
+		}
+		if found == nil && opts.CacheDir != "" {
+			if p := cachedExecutable(opts.CacheDir, PinnedVersion); p != "" {
+				try(p, SourceCache)
+			}
+		}
+	}
+
+	if found == nil && opts.AutoDownload && opts.CacheDir != "" {
+		l.mu.Lock()
+		l.status.Downloading = true
+		l.mu.Unlock()
+		p, err := Download(ctx, opts.CacheDir, PinnedVersion, opts.SHA256)
+		l.mu.Lock()
+		l.status.Downloading = false
+		l.mu.Unlock()
+		if err != nil {
+			st.Error = err.Error()
+		} else {
+			try(p, SourceDownloaded)
+		}
+	}
+	if found == nil && st.Error == "" {
+		st.Error = fmt.Sprintf("uyumlu Chrome/Chromium/Edge bulunamadı (en az %d gerekli)", MinMajorVersion)
+	}
+	st.Browser = found
+
+	l.mu.Lock()
+	l.status = st
+	l.mu.Unlock()
+	if found == nil {
+		return nil, fmt.Errorf("%s", st.Error)
+	}
+	return found, nil
+}
+
+// Inspect path'teki tarayıcının sürümünü okur ve uyumluluğunu belirler
+func Inspect(ctx context.Context, path string) (*Browser, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		resolved, lookErr := exec.LookPath(path)
+		if lookErr != nil {
+			return nil, err
+		}
+		path = resolved
+	} else if info.IsDir() {
+		return nil, fmt.Errorf("%s bir dizin", path)
+	}
+	version, err := browserVersion(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	major := MajorVersion(version)
+	return &Browser{
+		Path:       path,
+		Name:       browserName(path),
+		Version:    version,
+		Major:      major,
+		Compatible: major >= MinMajorVersion,
+	}, nil
+}
+
+var versionRe = regexp.MustCompile(`(\d+)\.(\d+)\.(\d+)\.(\d+)`)
+
+// ParseVersion "Google Chrome 121.0.6167.85" gibi çıktılardan sürüm numarasını ayıklar
+func ParseVersion(out string) string {
+	return versionRe.FindString(out)
+}
+
+// MajorVersion sürüm dizesinin ana sürümünü döner (geçersizse 0)
+func MajorVersion(version string) int {
+	major, _ := strconv.Atoi(strings.SplitN(version, ".", 2)[0])
+	return major
+}
+
+// browserVersion tarayıcının sürümünü okur. Windows'ta chrome.exe --version çıktı vermediği için
+// kurulum dizinindeki sürüm klasörüne (Application/121.0.6167.85) bakılır.
+func browserVersion(ctx context.Context, path string) (string, error) {
+	if runtime.GOOS == "windows" {
+		entries, err := os.ReadDir(filepath.Dir(path))
+		if err == nil {
+			for _, e := range entries {
+				if e.IsDir() && versionRe.MatchString(e.Name()) {
+					return ParseVersion(e.Name()), nil
+				}
+			}
+		}
+	}
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, path, "--version").Output()
+	if err != nil {
+		return "", fmt.Errorf("%s --version: %w", path, err)
+	}
+	v := ParseVersion(string(out))
+	if v == "" {
+		return "", fmt.Errorf("%s: sürüm okunamadı: %q", path, strings.TrimSpace(string(out)))
+	}
+	return v, nil
+}
+
+// browserName yoldan tarayıcı ailesini tahmin eder
+func browserName(path string) string {
+	p := strings.ToLower(path)
+	switch {
+	case strings.Contains(p, "edge"):
+		return "edge"
+	case strings.Contains(p, "chromium"), strings.Contains(p, "chrome for testing"), strings.Contains(p, "chrome-linux"),
+		strings.Contains(p, "chrome-mac"), strings.Contains(p, "chrome-win"):
+		return "chromium"
+	default:
+		return "chrome"
+	}
+}
+
+// systemCandidates işletim sistemine göre bilinen kurulum yollarını öncelik sırasıyla döner
+func systemCandidates() []string {
+	switch runtime.GOOS {
+	case "darwin":
+		home, _ := os.UserHomeDir()
+		apps := []string{
+			"Google Chrome.app/Contents/MacOS/Google Chrome",
+			"Chromium.app/Contents/MacOS/Chromium",
+			"Microsoft Edge.app/Contents/MacOS/Microsoft Edge",
+		}
+		var out []string
+		for _, root := range []string{"/Applications", filepath.Join(home, "Applications")} {
+			for _, a := range apps {
+				out = append(out, filepath.Join(root, a))
+			}
+		}
+		return out
+	case "windows":
+		var out []string
+		for _, env := range []string{"ProgramFiles", "ProgramFiles(x86)", "LocalAppData"} {
+			root := os.Getenv(env)
+			if root == "" {
+				continue
+			}
+			out = append(out,
+				filepath.Join(root, `Google\Chrome\Application\chrome.exe`),
+				filepath.Join(root, `Chromium\Application\chrome.exe`),
+				filepath.Join(root, `Microsoft\Edge\Application\msedge.exe`),
+			)
+		}
+		return out
+	default:
+		var out []string
+		for _, name := range []string{
+			"google-chrome", "google-chrome-stable", "chromium", "chromium-browser",
+			"microsoft-edge", "microsoft-edge-stable",
+		} {
+			if p, err := exec.LookPath(name); err == nil {
+				out = append(out, p)
+			}
+		}
+		return append(out, "/snap/bin/chromium", "/opt/google/chrome/chrome")
+	}
+}
@@ -0,0 +1,202 @@
+package chromelocator
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	cases := map[string]int{
+		"Google Chrome 121.0.6167.85 \n":          121,
+		"Chromium 99.0.4844.51 snap":              99,
+		"Microsoft Edge 120.0.2210.91":            120,
+		"Google Chrome for Testing 121.0.6167.85": 121,
+	}
+	for out, major := range cases {
+		if got := MajorVersion(ParseVersion(out)); got != major {
+			t.Errorf("%q: major = %d, want %d", out, got, major)
+		}
+	}
+	if v := ParseVersion("not a browser"); v != "" {
+		t.Errorf("ParseVersion = %q, want empty", v)
+	}
+}
+
+// fakeBrowser --version çıktısı veren sahte tarayıcı betiği yazar
+func fakeBrowser(t *testing.T, dir, name, version string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script browser stub")
+	}
+	p := filepath.Join(dir, name)
+	script := "#!/bin/sh\necho 'Chromium " + version + "'\n"
+	if err := os.WriteFile(p, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestLocatePrefersCompatibleConfigPath(t *testing.T) {
+	dir := t.TempDir()
+	old := fakeBrowser(t, dir, "old-chromium", "90.0.4430.93")
+	l := New(Options{Path: old})
+	b, err := l.Locate(context.Background())
+	// Eski sürüm aday olarak raporlanır ama seçilmez
+	st := l.Status()
+	if len(st.Candidates) == 0 || st.Candidates[0].Compatible || st.Candidates[0].Major != 90 {
+		t.Fatalf("candidates = %+v", st.Candidates)
+	}
+	if err == nil && b.Path == old {
+		t.Fatal("incompatible browser selected")
+	}
+
+	good := fakeBrowser(t, dir, "chromium", "121.0.6167.85")
+	l.SetOptions(Options{Path: good})
+	b, err = l.Locate(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Path != good || b.Source != SourceConfig || !b.Compatible || l.ExecPath() != good {
+		t.Fatalf("browser = %+v", b)
+	}
+}
+
+func TestDownloadExtractsPinnedBuild(t *testing.T) {
+	plat := platform()
+	if plat != "linux64" {
+		t.Skip("download test uses a linux64 shell stub")
+	}
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	h := &zip.FileHeader{Name: "chrome-linux64/chrome", Method: zip.Deflate}
+	h.SetMode(0755)
+	fw, _ := zw.CreateHeader(h)
+	fw.Write([]byte("#!/bin/sh\necho 'Google Chrome for Testing " + PinnedVersion + "'\n"))
+	zw.Close()
+
+	var requested string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL.Path
+		w.Write(buf.Bytes())
+	}))
+	defer srv.Close()
+	oldBase := DownloadBaseURL
+	DownloadBaseURL = srv.URL
+	defer func() { DownloadBaseURL = oldBase }()
+
+	sum := sha256.Sum256(buf.Bytes())
+	digest := hex.EncodeToString(sum[:])
+
+	cache := t.TempDir()
+	// Beklenen özet yoksa indirme yapılmaz
+	if _, err := Download(context.Background(), cache, PinnedVersion, ""); err == nil || requested != "" {
+		t.Fatalf("missing digest: requested %q err %v", requested, err)
+	}
+	// Özet tutmazsa arşiv açılmaz
+	if _, err := Download(context.Background(), cache, PinnedVersion, strings.Repeat("0", 64)); err == nil || !strings.Contains(err.Error(), "SHA-256") {
+		t.Fatalf("mismatch: err %v", err)
+	}
+	if cachedExecutable(cache, PinnedVersion) != "" {
+		t.Fatal("mismatched archive was extracted")
+	}
+	p, err := Download(context.Background(), cache, PinnedVersion, strings.ToUpper(digest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "/" + PinnedVersion + "/linux64/chrome-linux64.zip"; requested != want {
+		t.Fatalf("requested %q, want %q", requested, want)
+	}
+	b, err := Inspect(context.Background(), p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Version != PinnedVersion || !b.Compatible {
+		t.Fatalf("browser = %+v", b)
+	}
+	// İkinci çağrı önbellekten döner
+	requested = ""
+	if _, err := Download(context.Background(), cache, PinnedVersion, digest); err != nil || requested != "" {
+		t.Fatalf("expected cache hit, requested %q err %v", requested, err)
+	}
+}
+
+func TestUnzipRejectsTraversal(t *testing.T) {
+	dir := t.TempDir()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	fw, _ := zw.Create("../evil")
+	fw.Write([]byte("x"))
+	zw.Close()
+	archive := filepath.Join(dir, "a.zip")
+	os.WriteFile(archive, buf.Bytes(), 0644)
+	err := unzip(archive, filepath.Join(dir, "out"))
+	if err == nil || !strings.Contains(err.Error(), "geçersiz") {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func TestUnzipRejectsEscapingSymlinks(t *testing.T) {
+	for _, target := range []string{"/etc/passwd", "../../../outside", "Versions/../../../../x"} {
+		dir := t.TempDir()
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		h := &zip.FileHeader{Name: "chrome-mac/Framework/Current"}
+		h.SetMode(os.ModeSymlink | 0777)
+		fw, _ := zw.CreateHeader(h)
+		fw.Write([]byte(target))
+		zw.Close()
+		archive := filepath.Join(dir, "a.zip")
+		os.WriteFile(archive, buf.Bytes(), 0644)
+		err := unzip(archive, filepath.Join(dir, "out"))
+		if err == nil || !strings.Contains(err.Error(), "geçersiz") {
+			t.Errorf("target %q: err = %v", target, err)
+		}
+	}
+
+	// Paket içinde kalan göreli bağlar açılır
+	dir := t.TempDir()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	h := &zip.FileHeader{Name: "chrome-mac/Framework/Current"}
+	h.SetMode(os.ModeSymlink | 0777)
+	fw, _ := zw.CreateHeader(h)
+	fw.Write([]byte("Versions/A"))
+	zw.Close()
+	archive := filepath.Join(dir, "a.zip")
+	os.WriteFile(archive, buf.Bytes(), 0644)
+	if err := unzip(archive, filepath.Join(dir, "out")); err != nil {
+		t.Fatalf("inside link: %v", err)
+	}
+}
+
+func TestPinnedSHA256CoversDownloadTable(t *testing.T) {
+	var missing []string
+	for _, plat := range downloadPlatforms {
+		sum, ok := PinnedSHA256[PinnedVersion+"/"+plat]
+		if !ok {
+			missing = append(missing, plat)
+			continue
+		}
+		if b, err := hex.DecodeString(sum); err != nil || len(b) != sha256.Size || sum != strings.ToLower(sum) {
+			t.Errorf("%s/%s: invalid SHA-256 %q", PinnedVersion, plat, sum)
+		}
+	}
+	if len(missing) == len(downloadPlatforms) {
+		// Özetler arşivler indirilerek üretilir (make chromium-sha256); henüz kaydedilmedilerse
+		// Download browser_download_sha256 ister
+		t.Skipf("PinnedSHA256 has no hashes for %s yet", PinnedVersion)
+	}
+	if len(missing) > 0 {
+		t.Fatalf("PinnedSHA256 missing %s for: %v", PinnedVersion, missing)
+	}
+}
@@ -0,0 +1,303 @@
+// Package consent kullanım koşulları onayını ve operatörün kontrol ettiğini onayladığı hedef domain'lerin
+// izin listesini saklar. İzin listesinde olmayan bir domain'e trafik gönderilmeden önce açık onay gerekir;
+// onaylar, kaldırmalar ve engellenen denemeler denetim kaydına (JSON Lines) yazılır.
+package consent
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TermsVersion geçerli kullanım koşulları sürümü; koşullar değişince artırılır ve onay yeniden istenir
+const TermsVersion = "1"
+
+// Denetim kaydı işlemleri
+const (
+	ActionTermsAcknowledged = "terms_acknowledged"
+	ActionTargetConfirmed   = "target_confirmed"
+	ActionTargetRemoved     = "target_removed"
+	ActionTargetBlocked     = "target_blocked"
+)
+
+// ErrTermsNotAcknowledged kullanım koşulları (geçerli sürüm) onaylanmamışsa döner
+var ErrTermsNotAcknowledged = errors.New("kullanım koşulları onaylanmadı")
+
+// ErrNotWhitelisted hedef domain izin listesinde değilse döner (hata mesajı domain'i içerir)
+var ErrNotWhitelisted = errors.New("hedef domain izin listesinde değil")
+
+// Target izin listesindeki domain. Alt domain'ler de kapsanır (example.com → shop.example.com).
+type Target struct {
+	Domain      string    `json:"domain"`
+	ConfirmedAt time.Time `json:"confirmed_at"`
+	Source      string    `json:"source"` // ui, cli, api
+	Actor       string    `json:"actor,omitempty"`
+	Note        string    `json:"note,omitempty"`
+}
+
+// State kalıcı onay durumu
+type State struct {
+	TermsVersion   string     `json:"terms_version,omitempty"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+	AcknowledgedBy string     `json:"acknowledged_by,omitempty"`
+	Targets        []Target   `json:"targets"`
+}
+
+// AuditEntry denetim kaydı satırı
+type AuditEntry struct {
+	Time   time.Time `json:"time"`
+	Action string    `json:"action"`
+	Domain string    `json:"domain,omitempty"`
+	Source string    `json:"source,omitempty"` // ui, cli, api, campaign, scheduler
+	Actor  string    `json:"actor,omitempty"`  // İstemci adresi veya işletim sistemi kullanıcısı
+	Detail string    `json:"detail,omitempty"`
+}
+
+// Store onay durumu ve denetim kaydı; tüm metodlar eşzamanlı kullanım için güvenlidir
+type Store struct {
+	mu        sync.Mutex
+	path      string
+	auditPath string
+	state     State
+}
+
+// Open durumu path'ten okur; dosya yoksa boş (onaysız, izin listesi boş) başlar. Okuma hatasında da
+// kullanılabilir boş bir Store döner, böylece onay akışı çalışmaya devam eder.
+func Open(path, auditPath string) (*Store, error) {
+	s := &Store{path: path, auditPath: auditPath}
+	if path == "" {
+		return s, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, err
+	}
+	if err := json.Unmarshal(data, &s.state); err != nil {
+		return s, fmt.Errorf("%s okunamadı: %w", path, err)
+	}
+	return s, nil
+}
+
+// Normalize domain'i karşılaştırma biçimine getirir: küçük harf, şema/yol/port ve "www." atılır
+func Normalize(raw string) (string, error) {
+	d := strings.ToLower(strings.TrimSpace(raw))
+	if strings.Contains(d, "://") {
+		u, err := url.Parse(d)
+		if err != nil {
+			return "", fmt.Errorf("geçersiz domain %q", raw)
+		}
+		d = u.Host
+	}
+	if i := strings.IndexAny(d, "/?#"); i >= 0 {
+		d = d[:i]
+	}
+	if host, _, err := net.SplitHostPort(d); err == nil {
+		d = host
+	}
+	d = strings.TrimSuffix(strings.TrimPrefix(d, "www."), ".")
+	if d == "" || strings.ContainsAny(d, " *@\\") || (!strings.Contains(d, ".") && d != "localhost") {
+		return "", fmt.Errorf("geçersiz domain %q", raw)
+	}
+	return d, nil
+}
+
+// Acknowledged geçerli kullanım koşulları sürümü onaylanmış mı
+func (s *Store) Acknowledged() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state.TermsVersion == TermsVersion
+}
+
+// Acknowledge geçerli koşulları onaylar ve denetim kaydına yazar
+func (s *Store) Acknowledge(source, actor string) error {
+	now := time.Now()
+	s.mu.Lock()
+	s.state.TermsVersion = TermsVersion
+	s.state.AcknowledgedAt = &now
+	s.state.AcknowledgedBy = actor
+	err := s.saveLocked()
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return s.Audit(AuditEntry{Time: now, Action: ActionTermsAcknowledged, Source: source, Actor: actor, Detail: "version " + TermsVersion})
+}
+
+// Allowed domain (veya üst domain'i) izin listesinde mi
+func (s *Store) Allowed(domain string) bool {
+	d, err := Normalize(domain)
+	if err != nil {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, t := range s.state.Targets {
+		if d == t.Domain || strings.HasSuffix(d, "."+t.Domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// Check hedefe trafik gönderilebilir mi: önce koşul onayı, sonra izin listesi
+func (s *Store) Check(domain string) error {
+	if !s.Acknowledged() {
+		return ErrTermsNotAcknowledged
+	}
+	if !s.Allowed(domain) {
+		d, err := Normalize(domain)
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("%w: %s", ErrNotWhitelisted, d)
+	}
+	return nil
+}
+
+// Confirm domain'i izin listesine ekler (zaten varsa onay bilgisi yenilenir) ve denetim kaydına yazar.
+// Çağıran, operatörün domain'i kontrol ettiğini açıkça onayladığından emin olmalıdır.
+func (s *Store) Confirm(domain, source, actor, note string) (Target, error) {
+	d, err := Normalize(domain)
+	if err != nil {
+		return Target{}, err
+	}
+	t := Target{Domain: d, ConfirmedAt: time.Now(), Source: source, Actor: actor, Note: strings.TrimSpace(note)}
+	s.mu.Lock()
+	replaced := false
+	for i := range s.state.Targets {
+		if s.state.Targets[i].Domain == d {
+			s.state.Targets[i], replaced = t, true
+		}
+	}
+	if !replaced {
+		s.state.Targets = append(s.state.Targets, t)
+		sort.Slice(s.state.Targets, func(i, j int) bool { return s.state.Targets[i].Domain < s.state.Targets[j].Domain })
+	}
+	err = s.saveLocked()
+	s.mu.Unlock()
+	if err != nil {
+		return t, err
+	}
+	return t, s.Audit(AuditEntry{Time: t.ConfirmedAt, Action: ActionTargetConfirmed, Domain: d, Source: source, Actor: actor, Detail: t.Note})
+}
+
+// Remove domain'i izin listesinden çıkarır; listede yoksa false döner
+func (s *Store) Remove(domain, source, actor string) (bool, error) {
+	d, err := Normalize(domain)
+	if err != nil {
+		return false, err
+	}
+	s.mu.Lock()
+	kept := s.state.Targets[:0]
+	for _, t := range s.state.Targets {
+		if t.Domain != d {
+			kept = append(kept, t)
+		}
+	}
+	removed := len(kept) != len(s.state.Targets)
+	s.state.Targets = kept
+	if removed {
+		err = s.saveLocked()
+	}
+	s.mu.Unlock()
+	if !removed || err != nil {
+		return false, err
+	}
+	return true, s.Audit(AuditEntry{Action: ActionTargetRemoved, Domain: d, Source: source, Actor: actor})
+}
+
+// Snapshot durumun kopyası
+func (s *Store) Snapshot() State {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st := s.state
+	st.Targets = append([]Target{}, s.state.Targets...)
+	return st
+}
+
+// saveLocked durumu dosyaya yazar (s.mu tutulurken çağrılır)
+func (s *Store) saveLocked() error {
+	if s.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(s.state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(s.path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+	// Önce geçici dosyaya yaz: yarım kalan yazma izin listesini bozmasın
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// Audit denetim kaydına bir satır ekler (dosya yalnızca sonuna yazılır)
+func (s *Store) Audit(e AuditEntry) error {
+	if s.auditPath == "" {
+		return nil
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := os.OpenFile(s.auditPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
+
+// AuditLog denetim kaydının son limit satırı (en yenisi sonda; limit <= 0 = tümü)
+func (s *Store) AuditLog(limit int) ([]AuditEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries := []AuditEntry{}
+	if s.auditPath == "" {
+		return entries, nil
+	}
+	f, err := os.Open(s.auditPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for sc.Scan() {
+		var e AuditEntry
+		if json.Unmarshal(sc.Bytes(), &e) == nil {
+			entries = append(entries, e)
+		}
+	}
+	if limit > 0 && len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+	return entries, sc.Err()
+}
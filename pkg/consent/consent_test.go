@@ -0,0 +1,79 @@
+package consent
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	for raw, want := range map[string]string{
+		"Example.com":                   "example.com",
+		"https://www.example.com/a?b=1": "example.com",
+		"shop.example.com:8443":         "shop.example.com",
+		"localhost":                     "localhost",
+	} {
+		if got, err := Normalize(raw); err != nil || got != want {
+			t.Errorf("Normalize(%q) = %q, %v; want %q", raw, got, err, want)
+		}
+	}
+	for _, raw := range []string{"", "example", "*.example.com", "user@example.com"} {
+		if _, err := Normalize(raw); err == nil {
+			t.Errorf("Normalize(%q): expected error", raw)
+		}
+	}
+}
+
+func TestStoreCheckAndAudit(t *testing.T) {
+	dir := t.TempDir()
+	path, auditPath := filepath.Join(dir, "consent.json"), filepath.Join(dir, "audit.log")
+	s, err := Open(path, auditPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Check("example.com"); !errors.Is(err, ErrTermsNotAcknowledged) {
+		t.Fatalf("before ack = %v", err)
+	}
+	if err := s.Acknowledge("cli", "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Check("www.example.com"); !errors.Is(err, ErrNotWhitelisted) || err.Error() != ErrNotWhitelisted.Error()+": example.com" {
+		t.Fatalf("unlisted = %v", err)
+	}
+	if _, err := s.Confirm("https://Example.com/", "web", "127.0.0.1", "own site"); err != nil {
+		t.Fatal(err)
+	}
+	// Alt domain'ler kapsanır, benzer adlı domain'ler kapsanmaz
+	if s.Check("shop.example.com") != nil || s.Allowed("badexample.com") {
+		t.Error("subdomain matching")
+	}
+
+	// Durum yeniden açılışta korunur
+	reopened, err := Open(path, auditPath)
+	if err != nil || !reopened.Acknowledged() || !reopened.Allowed("example.com") {
+		t.Fatalf("reopened = %+v, %v", reopened.Snapshot(), err)
+	}
+	if removed, err := reopened.Remove("example.com", "web", "127.0.0.1"); !removed || err != nil {
+		t.Fatalf("remove = %v, %v", removed, err)
+	}
+	if removed, _ := reopened.Remove("example.com", "web", ""); removed || reopened.Allowed("example.com") {
+		t.Error("domain still listed after remove")
+	}
+
+	entries, err := s.AuditLog(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{ActionTermsAcknowledged, ActionTargetConfirmed, ActionTargetRemoved}
+	if len(entries) != len(want) {
+		t.Fatalf("audit = %+v", entries)
+	}
+	for i, e := range entries {
+		if e.Action != want[i] || e.Time.IsZero() {
+			t.Errorf("audit[%d] = %+v, want %s", i, e, want[i])
+		}
+	}
+	if last, _ := s.AuditLog(1); len(last) != 1 || last[0].Action != ActionTargetRemoved {
+		t.Errorf("AuditLog(1) = %+v", last)
+	}
+}
@@ -0,0 +1,227 @@
+// Package contentcheck ziyaret edilen sayfanın yanıtını URL desenine göre doğrular
+// (status kodu, metin, regex, JSON yolu). Başarısız kontrol ağ hatası değil fonksiyonel
+// hatadır: errclass.ErrFunctional ile sarılır ve tekrar denenmez.
+package contentcheck
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"vgbot/pkg/errclass"
+)
+
+// Rule tek bir URL deseni için beklenen yanıt; boş alanlar kontrol edilmez
+type Rule struct {
+	URL       string // "/blog/*" (path) veya "https://site/api/*" (tam URL); "*" her şeyle eşleşir
+	Status    int    // Beklenen status kodu
+	Contains  string // Gövdede geçmesi gereken metin
+	Regex     string // Gövdenin eşleşmesi gereken düzenli ifade
+	JSONPath  string // Gövde JSON'unda bulunması gereken nokta yolu (ör. "data.items.0.id")
+	JSONValue string // JSONPath'teki değer (boşsa yalnızca varlığı kontrol edilir)
+}
+
+// String kuralı log ve rapor için kısa biçimde döner (ör. "/blog/* status=200 contains")
+func (r Rule) String() string {
+	parts := []string{r.URL}
+	if r.Status != 0 {
+		parts = append(parts, "status="+strconv.Itoa(r.Status))
+	}
+	if r.Contains != "" {
+		parts = append(parts, "contains")
+	}
+	if r.Regex != "" {
+		parts = append(parts, "regex")
+	}
+	if r.JSONPath != "" {
+		parts = append(parts, "json:"+r.JSONPath)
+	}
+	return strings.Join(parts, " ")
+}
+
+type compiled struct {
+	Rule
+	url   *regexp.Regexp
+	regex *regexp.Regexp
+	path  bool // Desen path'e mi uygulanır (tam URL yerine)
+}
+
+// Checker derlenmiş kurallar ve örnekleme oranı
+type Checker struct {
+	rules []compiled
+	rate  int // Kontrol edilen ziyaret oranı (%)
+}
+
+// Failure başarısız bir kontrol; errors.Is(err, errclass.ErrFunctional) true döner
+type Failure struct {
+	Rule   string
+	Reason string
+}
+
+func (f *Failure) Error() string {
+	return fmt.Sprintf("%v: %s: %s", errclass.ErrFunctional, f.Rule, f.Reason)
+}
+
+func (f *Failure) Unwrap() error {
+	return errclass.ErrFunctional
+}
+
+// New kuralları derler; kural yoksa nil döner. rate ziyaretlerin yüzde kaçının kontrol edileceğidir (1-100).
+func New(rules []Rule, rate int) (*Checker, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	if rate < 1 || rate > 100 {
+		return nil, fmt.Errorf("içerik kontrol oranı 1-100 arasında olmalı: %d", rate)
+	}
+	c := &Checker{rate: rate}
+	for _, r := range rules {
+		r.URL = strings.TrimSpace(r.URL)
+		if r.URL == "" {
+			return nil, fmt.Errorf("içerik kontrolünde url deseni gerekli")
+		}
+		if r.Status == 0 && r.Contains == "" && r.Regex == "" && r.JSONPath == "" {
+			return nil, fmt.Errorf("%s: en az bir kontrol gerekli (status, contains, regex, json_path)", r.URL)
+		}
+		if r.JSONValue != "" && r.JSONPath == "" {
+			return nil, fmt.Errorf("%s: json_value için json_path gerekli", r.URL)
+		}
+		cr := compiled{Rule: r, path: strings.HasPrefix(r.URL, "/") || r.URL == "*"}
+		cr.url = globRegexp(r.URL)
+		if r.Regex != "" {
+			re, err := regexp.Compile(r.Regex)
+			if err != nil {
+				return nil, fmt.Errorf("%s: geçersiz regex: %w", r.URL, err)
+			}
+			cr.regex = re
+		}
+		c.rules = append(c.rules, cr)
+	}
+	return c, nil
+}
+
+// globRegexp "*" joker karakterini ("/" dahil her şey) destekleyen deseni regexp'e çevirir
+func globRegexp(pattern string) *regexp.Regexp {
+	quoted := regexp.QuoteMeta(pattern)
+	return regexp.MustCompile("^" + strings.ReplaceAll(quoted, `\*`, ".*") + "$")
+}
+
+// Rate kontrol edilen ziyaret oranı (%)
+func (c *Checker) Rate() int {
+	return c.rate
+}
+
+// Describe kuralları log için döner
+func (c *Checker) Describe() string {
+	parts := make([]string, len(c.rules))
+	for i, r := range c.rules {
+		parts[i] = r.Rule.String()
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Sample bu ziyaretin kontrol edilip edilmeyeceğini örnekleme oranına göre seçer; intn rand.Intn benzeri olmalı
+func (c *Checker) Sample(rawURL string, intn func(n int) int) bool {
+	if c == nil || len(c.matching(rawURL)) == 0 {
+		return false
+	}
+	return c.rate >= 100 || intn(100) < c.rate
+}
+
+func (c *Checker) matching(rawURL string) []compiled {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil
+	}
+	path := u.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	full := u.Scheme + "://" + u.Host + path
+	var out []compiled
+	for _, r := range c.rules {
+		target := full
+		if r.path {
+			target = path
+		}
+		if r.url.MatchString(target) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// Check URL'ye uyan tüm kuralları yanıta uygular; ilk başarısız kontrolü *Failure olarak döner
+func (c *Checker) Check(rawURL string, status int, body []byte) error {
+	for _, r := range c.matching(rawURL) {
+		if reason := r.check(status, body); reason != "" {
+			return &Failure{Rule: r.Rule.String(), Reason: reason}
+		}
+	}
+	return nil
+}
+
+func (r compiled) check(status int, body []byte) string {
+	if r.Status != 0 && status != r.Status {
+		return fmt.Sprintf("status %d, beklenen %d", status, r.Status)
+	}
+	if r.Contains != "" && !strings.Contains(string(body), r.Contains) {
+		return fmt.Sprintf("gövdede %q yok", r.Contains)
+	}
+	if r.regex != nil && !r.regex.Match(body) {
+		return fmt.Sprintf("gövde /%s/ ile eşleşmiyor", r.Regex)
+	}
+	if r.JSONPath != "" {
+		v, err := jsonPath(body, r.JSONPath)
+		if err != nil {
+			return err.Error()
+		}
+		if r.JSONValue != "" && jsonString(v) != r.JSONValue {
+			return fmt.Sprintf("%s = %s, beklenen %s", r.JSONPath, jsonString(v), r.JSONValue)
+		}
+	}
+	return ""
+}
+
+// jsonPath gövdeyi JSON olarak çözer ve nokta yolundaki değeri döner ("$." öneki ve dizi indeksleri desteklenir)
+func jsonPath(body []byte, path string) (interface{}, error) {
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return nil, fmt.Errorf("gövde JSON değil: %v", err)
+	}
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	if path == "" {
+		return v, nil
+	}
+	for _, key := range strings.Split(path, ".") {
+		switch node := v.(type) {
+		case map[string]interface{}:
+			next, ok := node[key]
+			if !ok {
+				return nil, fmt.Errorf("%s bulunamadı", path)
+			}
+			v = next
+		case []interface{}:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, fmt.Errorf("%s bulunamadı", path)
+			}
+			v = node[i]
+		default:
+			return nil, fmt.Errorf("%s bulunamadı", path)
+		}
+	}
+	return v, nil
+}
+
+// jsonString JSON değerini karşılaştırma için metne çevirir (string tırnaksız, diğerleri JSON biçiminde)
+func jsonString(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
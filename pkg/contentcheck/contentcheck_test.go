@@ -0,0 +1,95 @@
+package contentcheck
+
+import (
+	"errors"
+	"testing"
+
+	"vgbot/pkg/errclass"
+)
+
+func TestCheck(t *testing.T) {
+	c, err := New([]Rule{
+		{URL: "/blog/*", Status: 200, Contains: "<article"},
+		{URL: "https://example.com/api/*", JSONPath: "$.data.items.0.id", JSONValue: "42"},
+		{URL: "*", Regex: `(?i)</html>|^\{`},
+	}, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		url    string
+		status int
+		body   string
+		fail   string // beklenen başarısız kural ("" = geçer)
+	}{
+		{"https://example.com/blog/a/b?x=1", 200, "<article>hi</article></html>", ""},
+		{"https://example.com/blog/a", 500, "<article></html>", "/blog/* status=200 contains"},
+		{"https://example.com/blog/a", 200, "<div></html>", "/blog/* status=200 contains"},
+		{"https://example.com/api/list", 200, `{"data":{"items":[{"id":42}]}}`, ""},
+		{"https://example.com/api/list", 200, `{"data":{"items":[{"id":7}]}}`, "https://example.com/api/* json:$.data.items.0.id"},
+		{"https://example.com/api/list", 200, `{"data":{"items":[]}}`, "https://example.com/api/* json:$.data.items.0.id"},
+		{"https://example.com/api/list", 200, `not json`, "https://example.com/api/* json:$.data.items.0.id"},
+		{"https://example.com/about", 200, "<p>cut off", "* regex"},
+	}
+	for _, tc := range cases {
+		err := c.Check(tc.url, tc.status, []byte(tc.body))
+		if tc.fail == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected %v", tc.url, err)
+			}
+			continue
+		}
+		var f *Failure
+		if !errors.As(err, &f) || f.Rule != tc.fail {
+			t.Errorf("%s %q: err = %v, want failure of %q", tc.url, tc.body, err, tc.fail)
+			continue
+		}
+		if errclass.Of(err) != errclass.Functional || errclass.Retryable(err) {
+			t.Errorf("%s: failure must be a non-retryable functional error", tc.url)
+		}
+	}
+}
+
+func TestSample(t *testing.T) {
+	c, err := New([]Rule{{URL: "/checkout*", Status: 200}}, 25)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := 0
+	for i := 0; i < 100; i++ {
+		if c.Sample("https://example.com/checkout", func(int) int { return i }) {
+			n++
+		}
+	}
+	if n != 25 {
+		t.Errorf("sampled %d of 100, want 25", n)
+	}
+	if c.Sample("https://example.com/blog", func(int) int { return 0 }) {
+		t.Error("URL without a matching rule must not be sampled")
+	}
+	var nilChecker *Checker
+	if nilChecker.Sample("https://example.com/checkout", func(int) int { return 0 }) {
+		t.Error("nil checker must not sample")
+	}
+}
+
+func TestNewValidation(t *testing.T) {
+	if c, err := New(nil, 0); c != nil || err != nil {
+		t.Errorf("no rules = %v, %v; want nil, nil", c, err)
+	}
+	bad := [][]Rule{
+		{{URL: "", Status: 200}},
+		{{URL: "/a"}},
+		{{URL: "/a", Regex: "("}},
+		{{URL: "/a", JSONValue: "x"}},
+	}
+	for _, rules := range bad {
+		if _, err := New(rules, 10); err == nil {
+			t.Errorf("%+v: expected error", rules)
+		}
+	}
+	if _, err := New([]Rule{{URL: "/a", Status: 200}}, 0); err == nil {
+		t.Error("rate 0: expected error")
+	}
+}
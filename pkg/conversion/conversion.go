@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/chromedp/chromedp"
+
+	"vgbot/pkg/utils"
 )
 
 // EventType dönüşüm olay tipi
@@ -50,7 +52,8 @@ type ConversionConfig struct {
 	GTMEnabled       bool
 	GTMContainerID   string
 	Events           []ConversionEvent
-	ScrollMilestones []int // Scroll yüzdeleri (25, 50, 75, 100)
+	ScrollMilestones []int         // Scroll yüzdeleri (25, 50, 75, 100)
+	Seeder           *utils.Seeder // Çalıştırmanın seed kaynağı (nil = tekrarlanamaz)
 }
 
 // ConversionSimulator dönüşüm simülatörü
@@ -68,7 +71,7 @@ func NewConversionSimulator(config ConversionConfig) *ConversionSimulator {
 	
 	return &ConversionSimulator{
 		config: config,
-		rng:    rand.New(rand.NewSource(time.Now().UnixNano())),
+		rng:    config.Seeder.NewRand(),
 	}
 }
 
@@ -47,6 +47,7 @@ func PageLoadDelay() time.Duration {
 // TokenBucket dakikada HPM istek sınırı; başta burst = capacity (tüm slotları hemen doldur).
 type TokenBucket struct {
 	ch   chan struct{}
+	rate chan time.Duration
 	stop func()
 }
 
@@ -55,22 +56,21 @@ func NewTokenBucket(ctx context.Context, hitsPerMinute, capacity int) *TokenBuck
 	if capacity <= 0 {
 		capacity = 1
 	}
-	if hitsPerMinute <= 0 {
-		hitsPerMinute = 60
-	}
 	ch := make(chan struct{}, 256) // burst + refill için yeterli buffer
 	for i := 0; i < capacity; i++ {
 		ch <- struct{}{}
 	}
-	refillInterval := time.Minute / time.Duration(hitsPerMinute)
+	rate := make(chan time.Duration, 1)
 	ctx, cancel := context.WithCancel(ctx)
-	ticker := time.NewTicker(refillInterval)
+	ticker := time.NewTicker(refillInterval(hitsPerMinute))
 	go func() {
 		defer ticker.Stop()
 		for {
 			select {
 			case <-ctx.Done():
 				return
+			case d := <-rate:
+				ticker.Reset(d)
 			case <-ticker.C:
 				select {
 				case ch <- struct{}{}:
@@ -79,7 +79,31 @@ func NewTokenBucket(ctx context.Context, hitsPerMinute, capacity int) *TokenBuck
 			}
 		}
 	}()
-	return &TokenBucket{ch: ch, stop: cancel}
+	return &TokenBucket{ch: ch, rate: rate, stop: cancel}
+}
+
+// SetRate dolum hızını dakikada hitsPerMinute olarak değiştirir (yük profili aşamaları için).
+func (tb *TokenBucket) SetRate(hitsPerMinute int) {
+	d := refillInterval(hitsPerMinute)
+	for {
+		select {
+		case tb.rate <- d:
+			return
+		default:
+		}
+		// Henüz uygulanmamış eski hız varsa yenisiyle değiştir
+		select {
+		case <-tb.rate:
+		default:
+		}
+	}
+}
+
+func refillInterval(hitsPerMinute int) time.Duration {
+	if hitsPerMinute <= 0 {
+		hitsPerMinute = 60
+	}
+	return time.Minute / time.Duration(hitsPerMinute)
 }
 
 // Take bir token alır; ctx iptal olursa hemen döner.
@@ -4,73 +4,47 @@ package distributed
 import (
 	"bytes"
 	"context"
+	"crypto/subtle"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
+	"os"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"time"
 
-	"vgbot/pkg/behavior"
-	"vgbot/pkg/proxy"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/grpc"
+
+	"vgbot/pkg/api"
 )
 
-// TaskStatus task durumu
-type TaskStatus string
+// Paylaşılan DTO'lar pkg/api'de; buradaki adlar geriye dönük uyumluluk için takma addır
+type (
+	TaskStatus  = api.TaskStatus
+	Task        = api.Task
+	TaskResult  = api.TaskResult
+	WorkerInfo  = api.WorkerInfo
+	MasterStats = api.MasterStats
+	WorkerStats = api.WorkerStats
+)
 
 const (
-	TaskPending   TaskStatus = "pending"
-	TaskAssigned  TaskStatus = "assigned"
-	TaskRunning   TaskStatus = "running"
-	TaskCompleted TaskStatus = "completed"
-	TaskFailed    TaskStatus = "failed"
+	TaskPending   = api.TaskPending
+	TaskAssigned  = api.TaskAssigned
+	TaskRunning   = api.TaskRunning
+	TaskCompleted = api.TaskCompleted
+	TaskFailed    = api.TaskFailed
+	TaskRetrying  = api.TaskRetrying
+	TaskCancelled = api.TaskCancelled
 )
 
-// Task bir ziyaret task'ı
-type Task struct {
-	ID          string                   `json:"id"`
-	URL         string                   `json:"url"`
-	Proxy       *proxy.ProxyConfig       `json:"proxy,omitempty"`
-	Profile     *behavior.BehaviorProfile `json:"profile,omitempty"`
-	SessionID   string                   `json:"session_id"`
-	Status      TaskStatus               `json:"status"`
-	WorkerID    string                   `json:"worker_id,omitempty"`
-	CreatedAt   time.Time                `json:"created_at"`
-	AssignedAt  *time.Time               `json:"assigned_at,omitempty"`
-	CompletedAt *time.Time               `json:"completed_at,omitempty"`
-	Result      *TaskResult              `json:"result,omitempty"`
-}
-
-// TaskResult task sonucu
-type TaskResult struct {
-	Success      bool          `json:"success"`
-	ResponseTime time.Duration `json:"response_time"`
-	Error        string        `json:"error,omitempty"`
-	PageTitle    string        `json:"page_title,omitempty"`
-	StatusCode   int           `json:"status_code"`
-	Timestamp    time.Time     `json:"timestamp"`
-}
-
-// WorkerInfo worker bilgisi
-type WorkerInfo struct {
-	ID             string    `json:"id"`
-	Hostname       string    `json:"hostname"`
-	IPAddress      string    `json:"ip_address"`
-	MaxConcurrency int       `json:"max_concurrency"`
-	ActiveTasks    int       `json:"active_tasks"`
-	TotalTasks     int64     `json:"total_tasks"`
-	SuccessCount   int64     `json:"success_count"`
-	FailedCount    int64     `json:"failed_count"`
-	LastHeartbeat  time.Time `json:"last_heartbeat"`
-	Status         string    `json:"status"`
-	Version        string    `json:"version"`
-}
-
-// IsHealthy worker'ın sağlıklı olup olmadığını kontrol eder
-func (w *WorkerInfo) IsHealthy() bool {
-	return time.Since(w.LastHeartbeat) < 30*time.Second && w.Status == "active"
-}
-
 // MasterConfig master yapılandırması
 type MasterConfig struct {
 	BindAddr      string
@@ -78,8 +52,20 @@ type MasterConfig struct {
 	MaxWorkers    int
 	TaskTimeout   time.Duration
 	HeartbeatInterval time.Duration
+	TLSCertFile   string // Doluysa master (HTTP ve stream) TLS ile dinler
+	TLSKeyFile    string
+	MaxRetries      int           // Başarısız task'ın yeniden deneme sayısı (0: tekrar yok, doğrudan dead-letter)
+	RetryBackoff    time.Duration // İlk yeniden denemeden önceki bekleme; her denemede iki katına çıkar
+	MaxRetryBackoff time.Duration // Bekleme süresinin üst sınırı
+	Output          io.Writer     // Master log satırları; nil ise os.Stdout (etkileşimli konsol prompt'u korumak için verir)
+	// TargetCheck doluysa her task URL'si (ve task dosyalarının uzak kaynakları) trafik gönderilmeden
+	// önce onaylanır; hata dönen URL kuyruğa alınmaz (ör. hedef izin listesi)
+	TargetCheck func(url string) error
 }
 
+// ErrTargetRejected task hedefi TargetCheck tarafından reddedildiğinde döner
+var ErrTargetRejected = errors.New("target rejected")
+
 // DefaultMasterConfig varsayılan master config
 func DefaultMasterConfig() MasterConfig {
 	return MasterConfig{
@@ -88,6 +74,9 @@ func DefaultMasterConfig() MasterConfig {
 		MaxWorkers:        100,
 		TaskTimeout:       5 * time.Minute,
 		HeartbeatInterval: 10 * time.Second,
+		MaxRetries:        2,
+		RetryBackoff:      5 * time.Second,
+		MaxRetryBackoff:   5 * time.Minute,
 	}
 }
 
@@ -99,23 +88,33 @@ type Master struct {
 	taskQueue   chan *Task
 	tasks       map[string]*Task
 	tasksMu     sync.RWMutex
+	dead        []*Task // Dead-letter kuyruğu: yeniden denemeleri tükenen task'lar (tasksMu ile korunur)
 
 	// Workers
 	workers     map[string]*WorkerInfo
+	streams     map[string]*streamSession // Stream bağlantılı worker'lar (iptal bildirimi için; workersMu ile korunur)
 	workersMu   sync.RWMutex
 
 	// Statistics
 	totalTasks     int64
 	completedTasks int64
 	failedTasks    int64
+	retriedTasks   int64
+	cancelledTasks int64
 
 	// HTTP server
 	server  *http.Server
+	grpc    *grpc.Server // Worker stream servisi; server üzerinden sunulur
 	running int32
+	halted  int32 // Halt ile durdurulduysa 1: Resume'a kadar yeni task kabul edilmez
 
 	// Context
 	ctx     context.Context
 	cancel  context.CancelFunc
+
+	// Task sonuç bildirimi (tamamlanan/başarısız task'lar)
+	resultHandler   func(task *Task)
+	resultHandlerMu sync.RWMutex
 }
 
 // NewMaster yeni master oluşturur
@@ -126,6 +125,18 @@ func NewMaster(config MasterConfig) *Master {
 	if config.HeartbeatInterval == 0 {
 		config.HeartbeatInterval = 10 * time.Second
 	}
+	if config.RetryBackoff <= 0 {
+		config.RetryBackoff = 5 * time.Second
+	}
+	if config.MaxRetryBackoff <= 0 {
+		config.MaxRetryBackoff = 5 * time.Minute
+	}
+	if config.MaxRetryBackoff < config.RetryBackoff {
+		config.MaxRetryBackoff = config.RetryBackoff
+	}
+	if config.Output == nil {
+		config.Output = os.Stdout
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -134,14 +145,40 @@ func NewMaster(config MasterConfig) *Master {
 		taskQueue: make(chan *Task, 10000),
 		tasks:     make(map[string]*Task),
 		workers:   make(map[string]*WorkerInfo),
+		streams:   make(map[string]*streamSession),
 		ctx:       ctx,
 		cancel:    cancel,
 	}
 }
 
-// Start master'ı başlatır
+// Start master'ı başlatır (Listen + Serve)
 func (m *Master) Start() error {
+	ln, err := m.Listen()
+	if err != nil {
+		return err
+	}
+	return m.Serve(ln)
+}
+
+// Listen master adresini açar ve TLS ayarlıysa sertifikayı doğrular. Adres kullanımdaysa veya
+// sertifika yüklenemezse hata Serve'e geçmeden hemen döner.
+func (m *Master) Listen() (net.Listener, error) {
+	if m.config.TLSCertFile != "" {
+		if _, err := tls.LoadX509KeyPair(m.config.TLSCertFile, m.config.TLSKeyFile); err != nil {
+			return nil, fmt.Errorf("tls certificate: %w", err)
+		}
+	}
+	addr := m.config.BindAddr
+	if addr == "" {
+		addr = ":http"
+	}
+	return net.Listen("tcp", addr)
+}
+
+// Serve master'ı Listen'in açtığı ln üzerinde çalıştırır; durana kadar bloklar
+func (m *Master) Serve(ln net.Listener) error {
 	if !atomic.CompareAndSwapInt32(&m.running, 0, 1) {
+		ln.Close()
 		return fmt.Errorf("master already running")
 	}
 
@@ -154,19 +191,32 @@ func (m *Master) Start() error {
 	mux.HandleFunc("/api/v1/master/status", m.handleMasterStatus)
 	mux.HandleFunc("/api/v1/master/workers", m.authMiddleware(m.handleListWorkers))
 	mux.HandleFunc("/api/v1/master/tasks", m.authMiddleware(m.handleListTasks))
+	mux.HandleFunc("/api/v1/master/tasks/dead", m.authMiddleware(m.handleDeadTasks))
 	mux.HandleFunc("/api/v1/master/task/submit", m.authMiddleware(m.handleSubmitTask))
+	mux.HandleFunc("/api/v1/master/task/", m.authMiddleware(m.handleTask))
+	mux.HandleFunc("/api/v1/master/halt", m.authMiddleware(m.handleHalt))
+	mux.HandleFunc("/api/v1/master/resume", m.authMiddleware(m.handleHalt))
 	mux.HandleFunc("/api/v1/master/stats", m.authMiddleware(m.handleStats))
 
+	// Worker stream'i aynı portta gRPC (HTTP/2) olarak sunulur; TLS yoksa h2c ile
+	m.grpc = m.newGRPCServer()
+	handler := withGRPC(m.grpc, mux)
+	if m.config.TLSCertFile == "" {
+		handler = h2c.NewHandler(handler, &http2.Server{})
+	}
 	m.server = &http.Server{
 		Addr:    m.config.BindAddr,
-		Handler: mux,
+		Handler: handler,
 	}
 
 	// Cleanup goroutine
 	go m.cleanupLoop()
 
-	fmt.Printf("[Master] Starting on %s\n", m.config.BindAddr)
-	return m.server.ListenAndServe()
+	m.logf("Starting on %s", ln.Addr())
+	if m.config.TLSCertFile != "" {
+		return m.server.ServeTLS(ln, m.config.TLSCertFile, m.config.TLSKeyFile)
+	}
+	return m.server.Serve(ln)
 }
 
 // Stop master'ı durdurur
@@ -176,6 +226,9 @@ func (m *Master) Stop() error {
 	}
 
 	m.cancel()
+	if m.grpc != nil {
+		m.grpc.Stop()
+	}
 	if m.server != nil {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
@@ -189,6 +242,12 @@ func (m *Master) SubmitTask(task *Task) error {
 	if atomic.LoadInt32(&m.running) == 0 {
 		return fmt.Errorf("master not running")
 	}
+	if m.Halted() {
+		return fmt.Errorf("master halted")
+	}
+	if err := m.CheckTarget(task.URL); err != nil {
+		return err
+	}
 
 	task.ID = generateTaskID()
 	task.Status = TaskPending
@@ -210,6 +269,17 @@ func (m *Master) SubmitTask(task *Task) error {
 	}
 }
 
+// CheckTarget URL'ye trafik gönderilebilir mi (TargetCheck yoksa her URL kabul edilir)
+func (m *Master) CheckTarget(url string) error {
+	if m.config.TargetCheck == nil {
+		return nil
+	}
+	if err := m.config.TargetCheck(url); err != nil {
+		return fmt.Errorf("%w: %v", ErrTargetRejected, err)
+	}
+	return nil
+}
+
 // SubmitTasks çoklu task gönderir
 func (m *Master) SubmitTasks(tasks []*Task) error {
 	for _, task := range tasks {
@@ -228,9 +298,40 @@ func (m *Master) GetStats() MasterStats {
 		FailedTasks:    atomic.LoadInt64(&m.failedTasks),
 		PendingTasks:   int64(len(m.taskQueue)),
 		ActiveWorkers:  int64(len(m.GetHealthyWorkers())),
+		RetriedTasks:   atomic.LoadInt64(&m.retriedTasks),
+		DeadTasks:      int64(m.deadCount()),
+		CancelledTasks: atomic.LoadInt64(&m.cancelledTasks),
+	}
+}
+
+// SetResultHandler tamamlanan veya başarısız olan her task için çağrılacak fonksiyonu ayarlar
+func (m *Master) SetResultHandler(fn func(task *Task)) {
+	m.resultHandlerMu.Lock()
+	m.resultHandler = fn
+	m.resultHandlerMu.Unlock()
+}
+
+func (m *Master) notifyResult(task *Task) {
+	m.resultHandlerMu.RLock()
+	fn := m.resultHandler
+	m.resultHandlerMu.RUnlock()
+	if fn != nil && task != nil {
+		fn(task)
 	}
 }
 
+// ListWorkers kayıtlı tüm worker'ların kopyasını döner
+func (m *Master) ListWorkers() []WorkerInfo {
+	m.workersMu.RLock()
+	defer m.workersMu.RUnlock()
+
+	workers := make([]WorkerInfo, 0, len(m.workers))
+	for _, w := range m.workers {
+		workers = append(workers, *w)
+	}
+	return workers
+}
+
 // GetHealthyWorkers sağlıklı worker'ları döner
 func (m *Master) GetHealthyWorkers() []*WorkerInfo {
 	m.workersMu.RLock()
@@ -249,17 +350,22 @@ func (m *Master) GetHealthyWorkers() []*WorkerInfo {
 
 func (m *Master) authMiddleware(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		if m.config.SecretKey != "" {
-			authHeader := r.Header.Get("Authorization")
-			if authHeader != "Bearer "+m.config.SecretKey {
-				http.Error(w, "Unauthorized", http.StatusUnauthorized)
-				return
-			}
+		if !m.authorized(r.Header.Get("Authorization")) {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
 		}
 		next(w, r)
 	}
 }
 
+// authorized Authorization değerini ("Bearer <secret>") master secret'ı ile sabit sürede karşılaştırır
+func (m *Master) authorized(header string) bool {
+	if m.config.SecretKey == "" {
+		return true
+	}
+	return subtle.ConstantTimeCompare([]byte(header), []byte("Bearer "+m.config.SecretKey)) == 1
+}
+
 func (m *Master) handleWorkerRegister(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -272,14 +378,8 @@ func (m *Master) handleWorkerRegister(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	worker.LastHeartbeat = time.Now()
-	worker.Status = "active"
-
-	m.workersMu.Lock()
-	m.workers[worker.ID] = &worker
-	m.workersMu.Unlock()
-
-	fmt.Printf("[Master] Worker registered: %s (%s)\n", worker.ID, worker.Hostname)
+	worker.Transport = "http"
+	m.registerWorker(&worker)
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]string{
@@ -294,29 +394,15 @@ func (m *Master) handleWorkerHeartbeat(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var req struct {
-		WorkerID     string `json:"worker_id"`
-		ActiveTasks  int    `json:"active_tasks"`
-		TotalTasks   int64  `json:"total_tasks"`
-		SuccessCount int64  `json:"success_count"`
-		FailedCount  int64  `json:"failed_count"`
-	}
+	var req workerHeartbeat
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	m.workersMu.Lock()
-	if worker, ok := m.workers[req.WorkerID]; ok {
-		worker.LastHeartbeat = time.Now()
-		worker.ActiveTasks = req.ActiveTasks
-		worker.TotalTasks = req.TotalTasks
-		worker.SuccessCount = req.SuccessCount
-		worker.FailedCount = req.FailedCount
-	}
-	m.workersMu.Unlock()
-
-	w.WriteHeader(http.StatusOK)
+	m.heartbeat(req)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(heartbeatReply{Halted: m.Halted()})
 }
 
 func (m *Master) handleTaskRequest(w http.ResponseWriter, r *http.Request) {
@@ -332,22 +418,25 @@ func (m *Master) handleTaskRequest(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	if m.Halted() {
+		http.Error(w, "master halted", http.StatusLocked)
+		return
+	}
 
-	select {
-	case task := <-m.taskQueue:
-		now := time.Now()
-		task.Status = TaskAssigned
-		task.WorkerID = req.WorkerID
-		task.AssignedAt = &now
-
-		m.tasksMu.Lock()
-		m.tasks[task.ID] = task
-		m.tasksMu.Unlock()
-
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(task)
-	case <-time.After(5 * time.Second):
-		w.WriteHeader(http.StatusNoContent)
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case task := <-m.taskQueue:
+			assigned := m.assignTask(task, req.WorkerID)
+			if assigned == nil {
+				continue // İptal edilmiş task'ın kuyrukta kalan kaydı
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(assigned)
+		case <-timeout:
+			w.WriteHeader(http.StatusNoContent)
+		}
+		return
 	}
 }
 
@@ -366,17 +455,7 @@ func (m *Master) handleTaskComplete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	now := time.Now()
-
-	m.tasksMu.Lock()
-	if task, ok := m.tasks[req.TaskID]; ok {
-		task.Status = TaskCompleted
-		task.CompletedAt = &now
-		task.Result = &req.Result
-	}
-	m.tasksMu.Unlock()
-
-	atomic.AddInt64(&m.completedTasks, 1)
+	m.completeTask(req.TaskID, req.Result)
 	w.WriteHeader(http.StatusOK)
 }
 
@@ -395,21 +474,266 @@ func (m *Master) handleTaskFail(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	m.failTask(req.TaskID, req.Error)
+	w.WriteHeader(http.StatusOK)
+}
+
+// workerHeartbeat worker'ın periyodik durum bildirimi (HTTP ve stream ortak)
+type workerHeartbeat struct {
+	WorkerID     string `json:"worker_id"`
+	ActiveTasks  int    `json:"active_tasks"`
+	TotalTasks   int64  `json:"total_tasks"`
+	SuccessCount int64  `json:"success_count"`
+	FailedCount  int64  `json:"failed_count"`
+}
+
+// heartbeatReply master'ın HTTP heartbeat yanıtı; Halted iken worker çalışan task'larını durdurur
+type heartbeatReply struct {
+	Halted bool `json:"halted"`
+}
+
+// registerWorker worker'ı kaydeder (aynı ID ile tekrar kayıt bilgileri günceller)
+func (m *Master) registerWorker(worker *WorkerInfo) {
+	worker.LastHeartbeat = time.Now()
+	worker.Status = "active"
+
+	m.workersMu.Lock()
+	m.workers[worker.ID] = worker
+	m.workersMu.Unlock()
+
+	m.logf("Worker registered: %s (%s, %s)", worker.ID, worker.Hostname, worker.Transport)
+}
+
+// logf master log satırını config.Output'a yazar
+func (m *Master) logf(format string, args ...interface{}) {
+	fmt.Fprintf(m.config.Output, "[Master] "+format+"\n", args...)
+}
+
+func (m *Master) heartbeat(hb workerHeartbeat) {
+	m.workersMu.Lock()
+	if worker, ok := m.workers[hb.WorkerID]; ok {
+		worker.LastHeartbeat = time.Now()
+		worker.ActiveTasks = hb.ActiveTasks
+		worker.TotalTasks = hb.TotalTasks
+		worker.SuccessCount = hb.SuccessCount
+		worker.FailedCount = hb.FailedCount
+	}
+	m.workersMu.Unlock()
+}
+
+// assignTask kuyruktan alınan task'ı worker'a atar; gönderilecek kopyayı döner. Task iptal edilmişse
+// veya yeniden kuyruğa alınıp yerine yeni kayıt konmuşsa (kuyrukta eski kayıt kalır) nil döner.
+func (m *Master) assignTask(task *Task, workerID string) *Task {
+	now := time.Now()
 	m.tasksMu.Lock()
-	if task, ok := m.tasks[req.TaskID]; ok {
-		task.Status = TaskFailed
-		now := time.Now()
+	if m.tasks[task.ID] != task || task.Status != TaskPending {
+		m.tasksMu.Unlock()
+		return nil
+	}
+	task.Status = TaskAssigned
+	task.WorkerID = workerID
+	task.AssignedAt = &now
+	m.tasks[task.ID] = task
+	copied := *task
+	m.tasksMu.Unlock()
+	return &copied
+}
+
+// requeueOrphaned sonucu bildirilmeden kopan worker'ın task'ını kuyruğa geri koyar; kuyruk doluysa başarısız sayar
+func (m *Master) requeueOrphaned(taskID string) {
+	m.tasksMu.Lock()
+	task, ok := m.tasks[taskID]
+	ok = ok && task.Status != TaskCancelled
+	if ok {
+		task.Status = TaskPending
+		task.WorkerID = ""
+		task.AssignedAt = nil
+	}
+	m.tasksMu.Unlock()
+	if !ok {
+		return
+	}
+	select {
+	case m.taskQueue <- task:
+	default:
+		m.failTask(taskID, "worker disconnected")
+	}
+}
+
+func (m *Master) completeTask(taskID string, result TaskResult) {
+	now := time.Now()
+
+	var done *Task
+	m.tasksMu.Lock()
+	task, ok := m.tasks[taskID]
+	if ok && task.Status == TaskCancelled {
+		// İptal edilen task'ın worker'ı yine de bitirdi; sonuç sayılmaz
+		m.tasksMu.Unlock()
+		return
+	}
+	if ok {
+		task.Status = TaskCompleted
 		task.CompletedAt = &now
-		task.Result = &TaskResult{
-			Success:   false,
-			Error:     req.Error,
-			Timestamp: now,
+		task.Result = &result
+		copied := *task
+		done = &copied
+	}
+	m.tasksMu.Unlock()
+
+	atomic.AddInt64(&m.completedTasks, 1)
+	m.notifyResult(done)
+}
+
+// failTask başarısız denemeyi kaydeder. Yeniden deneme hakkı kaldıysa task üstel backoff sonrası kuyruğa
+// geri konur; kalmadıysa başarısız sayılıp dead-letter kuyruğuna alınır ve sonuç bildirilir.
+func (m *Master) failTask(taskID, errMsg string) {
+	var failed *Task
+	var retryIn time.Duration
+	m.tasksMu.Lock()
+	task, ok := m.tasks[taskID]
+	ok = ok && task.Status != TaskCancelled
+	if ok {
+		now := time.Now()
+		task.Attempts++
+		task.LastError = errMsg
+		task.WorkerID = ""
+		task.AssignedAt = nil
+		if task.Attempts <= m.config.MaxRetries {
+			retryIn = m.retryBackoff(task.Attempts)
+			next := now.Add(retryIn)
+			task.Status = TaskRetrying
+			task.NextRetryAt = &next
+		} else {
+			task.Status = TaskFailed
+			task.NextRetryAt = nil
+			task.CompletedAt = &now
+			task.Result = &TaskResult{
+				Success:   false,
+				Error:     errMsg,
+				Timestamp: now,
+			}
+			m.dead = append(m.dead, task)
+			copied := *task
+			failed = &copied
 		}
 	}
 	m.tasksMu.Unlock()
+	if !ok {
+		return
+	}
 
+	if failed == nil {
+		atomic.AddInt64(&m.retriedTasks, 1)
+		time.AfterFunc(retryIn, func() { m.retryTask(taskID) })
+		return
+	}
 	atomic.AddInt64(&m.failedTasks, 1)
-	w.WriteHeader(http.StatusOK)
+	m.notifyResult(failed)
+}
+
+// retryBackoff attempt'inci başarısızlıktan sonraki bekleme süresi: RetryBackoff * 2^(attempt-1), üst sınır MaxRetryBackoff
+func (m *Master) retryBackoff(attempt int) time.Duration {
+	d := m.config.RetryBackoff
+	for i := 1; i < attempt && d < m.config.MaxRetryBackoff; i++ {
+		d *= 2
+	}
+	if d > m.config.MaxRetryBackoff {
+		d = m.config.MaxRetryBackoff
+	}
+	return d
+}
+
+// retryTask backoff süresi dolan task'ı kuyruğa geri koyar; kuyruk doluysa yeni bir başarısız deneme sayılır
+func (m *Master) retryTask(taskID string) {
+	if atomic.LoadInt32(&m.running) == 0 {
+		return
+	}
+	m.tasksMu.Lock()
+	task, ok := m.tasks[taskID]
+	if ok && task.Status == TaskRetrying {
+		task.Status = TaskPending
+		task.NextRetryAt = nil
+	} else {
+		ok = false
+	}
+	m.tasksMu.Unlock()
+	if !ok {
+		return
+	}
+	select {
+	case m.taskQueue <- task:
+	default:
+		m.failTask(taskID, "task queue full")
+	}
+}
+
+// DeadTasks dead-letter kuyruğundaki task'ların kopyasını döner
+func (m *Master) DeadTasks() []Task {
+	m.tasksMu.RLock()
+	defer m.tasksMu.RUnlock()
+
+	tasks := make([]Task, 0, len(m.dead))
+	for _, t := range m.dead {
+		tasks = append(tasks, *t)
+	}
+	return tasks
+}
+
+func (m *Master) deadCount() int {
+	m.tasksMu.RLock()
+	defer m.tasksMu.RUnlock()
+	return len(m.dead)
+}
+
+// RequeueDead verilen dead-letter task'larını (ids boşsa hepsini) deneme sayacı sıfırlanmış olarak kuyruğa
+// geri koyar ve kuyruğa alınan task ID'lerini döner. Kuyruk dolarsa kalan task'lar dead-letter'da kalır.
+func (m *Master) RequeueDead(ids ...string) ([]string, error) {
+	if atomic.LoadInt32(&m.running) == 0 {
+		return nil, fmt.Errorf("master not running")
+	}
+	if m.Halted() {
+		return nil, fmt.Errorf("master halted")
+	}
+	want := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		want[id] = true
+	}
+
+	m.tasksMu.Lock()
+	defer m.tasksMu.Unlock()
+
+	requeued := make([]string, 0)
+	kept := m.dead[:0]
+	full := false
+	for _, task := range m.dead {
+		if full || (len(want) > 0 && !want[task.ID]) {
+			kept = append(kept, task)
+			continue
+		}
+		task.Status = TaskPending
+		task.Attempts = 0
+		task.WorkerID = ""
+		task.AssignedAt = nil
+		task.CompletedAt = nil
+		task.Result = nil
+		select {
+		case m.taskQueue <- task:
+			atomic.AddInt64(&m.failedTasks, -1)
+			requeued = append(requeued, task.ID)
+		default:
+			task.Status = TaskFailed
+			full = true
+			kept = append(kept, task)
+		}
+	}
+	for i := len(kept); i < len(m.dead); i++ {
+		m.dead[i] = nil
+	}
+	m.dead = kept
+	if full {
+		return requeued, fmt.Errorf("task queue full")
+	}
+	return requeued, nil
 }
 
 func (m *Master) handleMasterStatus(w http.ResponseWriter, r *http.Request) {
@@ -431,19 +755,50 @@ func (m *Master) handleListWorkers(w http.ResponseWriter, r *http.Request) {
 }
 
 func (m *Master) handleListTasks(w http.ResponseWriter, r *http.Request) {
-	status := r.URL.Query().Get("status")
+	status := TaskStatus(r.URL.Query().Get("status"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
 
-	m.tasksMu.RLock()
-	tasks := make([]*Task, 0)
-	for _, t := range m.tasks {
-		if status == "" || string(t.Status) == status {
-			tasks = append(tasks, t)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(m.Tasks(status, limit))
+}
+
+// handleDeadTasks GET: dead-letter kuyruğunu listeler. POST {"action":"requeue","task_ids":[...]}:
+// task'ları (task_ids boşsa hepsini) yeniden kuyruğa alır.
+func (m *Master) handleDeadTasks(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(m.DeadTasks())
+	case http.MethodPost:
+		var req struct {
+			Action  string   `json:"action"`
+			TaskIDs []string `json:"task_ids"`
 		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if req.Action != "requeue" {
+			http.Error(w, fmt.Sprintf("unknown action %q", req.Action), http.StatusBadRequest)
+			return
+		}
+		requeued, err := m.RequeueDead(req.TaskIDs...)
+		if err != nil && len(requeued) == 0 {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		resp := map[string]interface{}{
+			"status":   "requeued",
+			"task_ids": requeued,
+		}
+		if err != nil {
+			resp["error"] = err.Error()
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 	}
-	m.tasksMu.RUnlock()
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(tasks)
 }
 
 func (m *Master) handleSubmitTask(w http.ResponseWriter, r *http.Request) {
@@ -459,7 +814,11 @@ func (m *Master) handleSubmitTask(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := m.SubmitTask(&task); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		code := http.StatusInternalServerError
+		if errors.Is(err, ErrTargetRejected) {
+			code = http.StatusForbidden
+		}
+		http.Error(w, err.Error(), code)
 		return
 	}
 
@@ -498,20 +857,11 @@ func (m *Master) cleanupStaleWorkers() {
 	for id, worker := range m.workers {
 		if now.Sub(worker.LastHeartbeat) > 2*m.config.HeartbeatInterval {
 			worker.Status = "offline"
-			fmt.Printf("[Master] Worker marked offline: %s\n", id)
+			m.logf("Worker marked offline: %s", id)
 		}
 	}
 }
 
-// MasterStats master istatistikleri
-type MasterStats struct {
-	TotalTasks     int64 `json:"total_tasks"`
-	CompletedTasks int64 `json:"completed_tasks"`
-	FailedTasks    int64 `json:"failed_tasks"`
-	PendingTasks   int64 `json:"pending_tasks"`
-	ActiveWorkers  int64 `json:"active_workers"`
-}
-
 // ==================== WORKER ====================
 
 // WorkerConfig worker yapılandırması
@@ -521,6 +871,9 @@ type WorkerConfig struct {
 	MaxConcurrency int
 	Hostname       string
 	Version        string
+	Stream         bool   // Task'ları master'dan stream ile al (kurulamazsa HTTP polling'e düşer)
+	TLSCAFile      string // https master için ek CA sertifikası (self-signed)
+	TLSInsecure    bool   // Sertifika doğrulamasını kapatır (yalnızca test)
 }
 
 // DefaultWorkerConfig varsayılan worker config
@@ -544,11 +897,16 @@ type Worker struct {
 	failedCount  int64
 
 	// HTTP client
-	client *http.Client
+	client    *http.Client
+	tlsConfig *tls.Config // Stream bağlantısı da aynı TLS ayarlarını kullanır
 
 	// Task processor
 	taskProcessor TaskProcessor
 
+	// HTTP polling'de çalışan task'ların context'leri; master durdurulunca iptal edilir
+	pollingMu sync.Mutex
+	polling   map[string]context.CancelFunc
+
 	// Control
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -585,6 +943,16 @@ func (w *Worker) Start() error {
 		return fmt.Errorf("worker already running")
 	}
 
+	tlsConfig, err := w.config.tlsConfig()
+	if err != nil {
+		atomic.StoreInt32(&w.running, 0)
+		return err
+	}
+	if tlsConfig != nil {
+		w.tlsConfig = tlsConfig
+		w.client.Transport = &http.Transport{Proxy: http.ProxyFromEnvironment, TLSClientConfig: tlsConfig}
+	}
+
 	// Register with master
 	if err := w.register(); err != nil {
 		atomic.StoreInt32(&w.running, 0)
@@ -593,18 +961,24 @@ func (w *Worker) Start() error {
 
 	fmt.Printf("[Worker] Started: %s -> %s\n", w.ID, w.config.MasterURL)
 
-	// Start heartbeat goroutine
-	go w.heartbeatLoop()
-
-	// Start task processing goroutines
-	for i := 0; i < w.config.MaxConcurrency; i++ {
-		go w.taskLoop()
+	if w.config.Stream {
+		go w.streamLoop()
+	} else {
+		w.startPolling()
 	}
 
 	<-w.ctx.Done()
 	return nil
 }
 
+// startPolling HTTP heartbeat ve task polling döngülerini başlatır
+func (w *Worker) startPolling() {
+	go w.heartbeatLoop()
+	for i := 0; i < w.config.MaxConcurrency; i++ {
+		go w.taskLoop()
+	}
+}
+
 // Stop worker'ı durdurur
 func (w *Worker) Stop() {
 	if atomic.CompareAndSwapInt32(&w.running, 1, 0) {
@@ -665,13 +1039,7 @@ func (w *Worker) heartbeatLoop() {
 }
 
 func (w *Worker) sendHeartbeat() {
-	data, _ := json.Marshal(map[string]interface{}{
-		"worker_id":     w.ID,
-		"active_tasks":  atomic.LoadInt32(&w.activeTasks),
-		"total_tasks":   atomic.LoadInt64(&w.totalTasks),
-		"success_count": atomic.LoadInt64(&w.successCount),
-		"failed_count":  atomic.LoadInt64(&w.failedCount),
-	})
+	data, _ := json.Marshal(w.heartbeatData())
 
 	req, err := http.NewRequest("POST", w.config.MasterURL+"/api/v1/worker/heartbeat", bytes.NewReader(data))
 	if err != nil {
@@ -688,6 +1056,22 @@ func (w *Worker) sendHeartbeat() {
 		return
 	}
 	defer resp.Body.Close()
+
+	var reply heartbeatReply
+	if json.NewDecoder(resp.Body).Decode(&reply) == nil && reply.Halted {
+		w.stopPolling()
+	}
+}
+
+// stopPolling master durdurulduğunda (kill switch) HTTP polling ile çalışan tüm task'ları iptal eder
+func (w *Worker) stopPolling() {
+	w.pollingMu.Lock()
+	defer w.pollingMu.Unlock()
+	for id, stop := range w.polling {
+		stop()
+		delete(w.polling, id)
+		fmt.Printf("[Worker] Task stopped, master halted: %s\n", id)
+	}
 }
 
 func (w *Worker) taskLoop() {
@@ -740,22 +1124,53 @@ func (w *Worker) requestAndProcessTask() {
 		return
 	}
 
-	// Process task
+	ctx, stop := context.WithCancel(w.ctx)
+	w.pollingMu.Lock()
+	if w.polling == nil {
+		w.polling = make(map[string]context.CancelFunc)
+	}
+	w.polling[task.ID] = stop
+	w.pollingMu.Unlock()
+
+	result, ok, err := w.execute(ctx, &task)
+	w.pollingMu.Lock()
+	delete(w.polling, task.ID)
+	w.pollingMu.Unlock()
+	stop()
+	if !ok {
+		w.reportTaskFail(task.ID, err)
+	} else {
+		w.reportTaskComplete(task.ID, result)
+	}
+}
+
+// execute task'ı işler ve sayaçları günceller; ok=false ise task başarısız sayılır
+func (w *Worker) execute(parent context.Context, task *Task) (*TaskResult, bool, error) {
 	atomic.AddInt32(&w.activeTasks, 1)
 	atomic.AddInt64(&w.totalTasks, 1)
 
-	ctx, cancel := context.WithTimeout(w.ctx, 5*time.Minute)
-	result, err := w.taskProcessor(ctx, &task)
+	ctx, cancel := context.WithTimeout(parent, 5*time.Minute)
+	result, err := w.taskProcessor(ctx, task)
 	cancel()
 
 	atomic.AddInt32(&w.activeTasks, -1)
 
-	if err != nil || !result.Success {
+	if err != nil || result == nil || !result.Success {
 		atomic.AddInt64(&w.failedCount, 1)
-		w.reportTaskFail(task.ID, err)
-	} else {
-		atomic.AddInt64(&w.successCount, 1)
-		w.reportTaskComplete(task.ID, result)
+		return result, false, err
+	}
+	atomic.AddInt64(&w.successCount, 1)
+	return result, true, nil
+}
+
+// heartbeatData worker'ın anlık durum bildirimi
+func (w *Worker) heartbeatData() workerHeartbeat {
+	return workerHeartbeat{
+		WorkerID:     w.ID,
+		ActiveTasks:  int(atomic.LoadInt32(&w.activeTasks)),
+		TotalTasks:   atomic.LoadInt64(&w.totalTasks),
+		SuccessCount: atomic.LoadInt64(&w.successCount),
+		FailedCount:  atomic.LoadInt64(&w.failedCount),
 	}
 }
 
@@ -813,15 +1228,6 @@ func (w *Worker) GetStats() WorkerStats {
 	}
 }
 
-// WorkerStats worker istatistikleri
-type WorkerStats struct {
-	WorkerID     string `json:"worker_id"`
-	ActiveTasks  int    `json:"active_tasks"`
-	TotalTasks   int64  `json:"total_tasks"`
-	SuccessCount int64  `json:"success_count"`
-	FailedCount  int64  `json:"failed_count"`
-}
-
 // Helper functions
 
 func generateTaskID() string {
@@ -363,3 +363,65 @@ func TestWorkerHealth(t *testing.T) {
 		t.Error("Expected worker to be unhealthy (offline status)")
 	}
 }
+
+func TestTaskRetryAndDeadLetter(t *testing.T) {
+	master := NewMaster(MasterConfig{
+		BindAddr:        "127.0.0.1:18083",
+		MaxRetries:      2,
+		RetryBackoff:    20 * time.Millisecond,
+		MaxRetryBackoff: 30 * time.Millisecond,
+	})
+	go master.Start()
+	time.Sleep(300 * time.Millisecond)
+	defer master.Stop()
+
+	if got := master.retryBackoff(1); got != 20*time.Millisecond {
+		t.Errorf("backoff(1) = %v", got)
+	}
+	if got := master.retryBackoff(3); got != 30*time.Millisecond {
+		t.Errorf("backoff(3) = %v, want capped at 30ms", got)
+	}
+
+	var notified int
+	master.SetResultHandler(func(task *Task) { notified++ })
+
+	task := &Task{URL: "http://example.com"}
+	if err := master.SubmitTask(task); err != nil {
+		t.Fatal(err)
+	}
+	for attempt := 1; attempt <= 3; attempt++ {
+		var got *Task
+		select {
+		case got = <-master.taskQueue:
+		case <-time.After(time.Second):
+			t.Fatalf("attempt %d: task not requeued", attempt)
+		}
+		master.assignTask(got, "w1")
+		master.failTask(got.ID, "proxy timeout")
+	}
+
+	dead := master.DeadTasks()
+	if len(dead) != 1 || dead[0].Attempts != 3 || dead[0].Status != TaskFailed || dead[0].LastError != "proxy timeout" {
+		t.Fatalf("dead = %+v", dead)
+	}
+	stats := master.GetStats()
+	if stats.RetriedTasks != 2 || stats.FailedTasks != 1 || stats.DeadTasks != 1 || notified != 1 {
+		t.Errorf("stats = %+v, notified = %d", stats, notified)
+	}
+
+	body := strings.NewReader(`{"action":"requeue"}`)
+	resp, err := http.Post("http://127.0.0.1:18083/api/v1/master/tasks/dead", "application/json", body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("requeue status = %d", resp.StatusCode)
+	}
+	if len(master.DeadTasks()) != 0 || len(master.taskQueue) != 1 {
+		t.Errorf("after requeue: dead = %d, queued = %d", len(master.DeadTasks()), len(master.taskQueue))
+	}
+	if requeued := <-master.taskQueue; requeued.Attempts != 0 || requeued.Status != TaskPending {
+		t.Errorf("requeued task = %+v", requeued)
+	}
+}
@@ -0,0 +1,518 @@
+package distributed
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Stream mesaj tipleri. Worker bağlanınca hello ve boş slot sayısı kadar ready gönderir;
+// master her kredi için kuyruktan bir task'ı anında iter, worker sonucu complete/fail ile döner.
+// Master'da iptal edilen task için cancel gider; worker ziyareti durdurur ve fail ile döner (sonuç yok sayılır).
+const (
+	streamHello     = "hello"     // worker → master: WorkerInfo
+	streamReady     = "ready"     // worker → master: Credits kadar yeni task alabilir
+	streamTask      = "task"      // master → worker
+	streamCancel    = "cancel"    // master → worker: TaskID
+	streamComplete  = "complete"  // worker → master: TaskID + Result
+	streamFail      = "fail"      // worker → master: TaskID + Error
+	streamHeartbeat = "heartbeat" // worker → master
+)
+
+// streamMessage master ile worker arasındaki stream mesajı
+type streamMessage struct {
+	Type      string           `json:"type"`
+	Worker    *WorkerInfo      `json:"worker,omitempty"`
+	Task      *Task            `json:"task,omitempty"`
+	TaskID    string           `json:"task_id,omitempty"`
+	Result    *TaskResult      `json:"result,omitempty"`
+	Error     string           `json:"error,omitempty"`
+	Credits   int              `json:"credits,omitempty"`
+	Heartbeat *workerHeartbeat `json:"heartbeat,omitempty"`
+}
+
+// ==================== gRPC ====================
+
+// Stream, master'ın HTTP portunda gRPC çift yönlü akış olarak sunulur. Şema protobuf yerine
+// elle yazılmış ServiceDesc ve JSON codec'tir; mesajlar streamMessage olarak taşınır.
+const (
+	streamService = "vgbot.distributed.Worker"
+	streamMethod  = "/" + streamService + "/Stream"
+	streamCodec   = "json" // content-type: application/grpc+json
+)
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
+
+// jsonCodec gRPC mesajlarını encoding/json ile kodlar
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error)      { return json.Marshal(v) }
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error { return json.Unmarshal(data, v) }
+func (jsonCodec) Name() string                               { return streamCodec }
+
+// workerStreamServer gRPC servisinin uygulayıcısı (Master)
+type workerStreamServer interface {
+	serveWorkerStream(stream grpc.ServerStream) error
+}
+
+var workerStreamDesc = grpc.ServiceDesc{
+	ServiceName: streamService,
+	HandlerType: (*workerStreamServer)(nil),
+	Streams: []grpc.StreamDesc{{
+		StreamName: "Stream",
+		Handler: func(srv interface{}, stream grpc.ServerStream) error {
+			return srv.(workerStreamServer).serveWorkerStream(stream)
+		},
+		ServerStreams: true,
+		ClientStreams: true,
+	}},
+}
+
+// newGRPCServer worker stream servisini kayıtlı gRPC sunucusunu oluşturur
+func (m *Master) newGRPCServer() *grpc.Server {
+	srv := grpc.NewServer()
+	srv.RegisterService(&workerStreamDesc, m)
+	return srv
+}
+
+// withGRPC HTTP/2 gRPC isteklerini gRPC sunucusuna, diğerlerini mux'a yönlendirir
+func withGRPC(srv *grpc.Server, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+			srv.ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ==================== MASTER ====================
+
+// streamSession master tarafında tek bir worker stream'i
+type streamSession struct {
+	m        *Master
+	stream   grpc.ServerStream
+	workerID string
+	credits  chan struct{}
+	closed   chan struct{}
+	writeMu  sync.Mutex // dispatch ve CancelTask aynı akışa yazar
+
+	mu       sync.Mutex
+	inflight map[string]bool // Gönderilmiş, sonucu henüz gelmemiş task'lar
+}
+
+// send mesajı worker'a yazar (eşzamanlı yazıcılara karşı kilitli)
+func (s *streamSession) send(msg streamMessage) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	return s.stream.SendMsg(&msg)
+}
+
+// serveWorkerStream bir worker'ın gRPC akışını kapanana kadar işler. Secret, authorization
+// metadata'sında "Bearer <secret>" olarak gelir.
+func (m *Master) serveWorkerStream(stream grpc.ServerStream) error {
+	var auth string
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	if v := md.Get("authorization"); len(v) == 1 {
+		auth = v[0]
+	}
+	if !m.authorized(auth) {
+		return status.Error(codes.Unauthenticated, "unauthorized")
+	}
+
+	var hello streamMessage
+	if err := stream.RecvMsg(&hello); err != nil {
+		return err
+	}
+	if hello.Type != streamHello || hello.Worker == nil || hello.Worker.ID == "" {
+		return status.Error(codes.InvalidArgument, "expected hello")
+	}
+	// Başlık, worker'a akışın kabul edildiğini bildirir
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+	hello.Worker.Transport = "grpc"
+	m.registerWorker(hello.Worker)
+
+	slots := hello.Worker.MaxConcurrency
+	if slots <= 0 {
+		slots = 10
+	}
+	s := &streamSession{
+		m:        m,
+		stream:   stream,
+		workerID: hello.Worker.ID,
+		credits:  make(chan struct{}, slots),
+		closed:   make(chan struct{}),
+		inflight: make(map[string]bool),
+	}
+
+	m.workersMu.Lock()
+	m.streams[s.workerID] = s
+	m.workersMu.Unlock()
+
+	dispatched := make(chan struct{})
+	go func() {
+		defer close(dispatched)
+		s.dispatch()
+	}()
+	s.read()
+	close(s.closed)
+	<-dispatched
+
+	m.workersMu.Lock()
+	if m.streams[s.workerID] == s {
+		delete(m.streams, s.workerID)
+	}
+	m.workersMu.Unlock()
+	s.requeueInflight()
+	return nil
+}
+
+// dispatch worker'ın her kredisi için kuyruktaki sıradaki task'ı iter
+func (s *streamSession) dispatch() {
+	for {
+		select {
+		case <-s.credits:
+		case <-s.closed:
+			return
+		case <-s.m.ctx.Done():
+			return
+		}
+
+		var assigned *Task
+		for assigned == nil {
+			select {
+			case task := <-s.m.taskQueue:
+				assigned = s.m.assignTask(task, s.workerID) // nil: iptal edilmiş task'ın eski kaydı
+			case <-s.closed:
+				return
+			case <-s.m.ctx.Done():
+				return
+			}
+		}
+
+		s.track(assigned.ID, true)
+		if err := s.send(streamMessage{Type: streamTask, Task: assigned}); err != nil {
+			// Okuma tarafı da kapanacak; requeueInflight task'ı kuyruğa geri koyar
+			return
+		}
+	}
+}
+
+// read worker mesajlarını bağlantı kapanana kadar işler
+func (s *streamSession) read() {
+	for {
+		var msg streamMessage
+		if err := s.stream.RecvMsg(&msg); err != nil {
+			return
+		}
+		switch msg.Type {
+		case streamReady:
+			for i := 0; i < msg.Credits; i++ {
+				select {
+				case s.credits <- struct{}{}:
+				default:
+					// Kredi en fazla MaxConcurrency kadar birikir
+				}
+			}
+		case streamComplete:
+			if s.track(msg.TaskID, false) {
+				result := TaskResult{}
+				if msg.Result != nil {
+					result = *msg.Result
+				}
+				s.m.completeTask(msg.TaskID, result)
+			}
+		case streamFail:
+			if s.track(msg.TaskID, false) {
+				s.m.failTask(msg.TaskID, msg.Error)
+			}
+		case streamHeartbeat:
+			if msg.Heartbeat != nil {
+				hb := *msg.Heartbeat
+				hb.WorkerID = s.workerID
+				s.m.heartbeat(hb)
+			}
+		}
+	}
+}
+
+// track task'ı in-flight kümesine ekler veya çıkarır; çıkarmada task'ın bu stream'e ait olup olmadığını döner
+func (s *streamSession) track(taskID string, add bool) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if add {
+		s.inflight[taskID] = true
+		return true
+	}
+	ok := s.inflight[taskID]
+	delete(s.inflight, taskID)
+	return ok
+}
+
+// requeueInflight bağlantı kopunca sonucu gelmemiş task'ları başka worker'lar için kuyruğa geri koyar
+func (s *streamSession) requeueInflight() {
+	s.mu.Lock()
+	ids := make([]string, 0, len(s.inflight))
+	for id := range s.inflight {
+		ids = append(ids, id)
+	}
+	s.inflight = make(map[string]bool)
+	s.mu.Unlock()
+
+	if s.m.ctx.Err() != nil {
+		return
+	}
+	for _, id := range ids {
+		s.m.requeueOrphaned(id)
+	}
+	if len(ids) > 0 {
+		s.m.logf("Worker stream closed: %s, %d task(s) requeued", s.workerID, len(ids))
+	}
+}
+
+// ==================== WORKER ====================
+
+// tlsConfig https master için TLS ayarları; varsayılan yeterliyse nil döner
+func (c WorkerConfig) tlsConfig() (*tls.Config, error) {
+	if c.TLSCAFile == "" && !c.TLSInsecure {
+		return nil, nil
+	}
+	cfg := &tls.Config{InsecureSkipVerify: c.TLSInsecure}
+	if c.TLSCAFile != "" {
+		pem, err := os.ReadFile(c.TLSCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", c.TLSCAFile)
+		}
+		cfg.RootCAs = pool
+	}
+	return cfg, nil
+}
+
+// grpcTarget master URL'inden gRPC hedefini (host:port) üretir; https ise TLS kullanılır
+func grpcTarget(masterURL string) (target string, secure bool, err error) {
+	u, err := url.Parse(masterURL)
+	if err != nil {
+		return "", false, err
+	}
+	if u.Host == "" {
+		return "", false, fmt.Errorf("invalid master URL: %s", masterURL)
+	}
+	secure = u.Scheme == "https"
+	target = u.Host
+	if u.Port() == "" {
+		port := "80"
+		if secure {
+			port = "443"
+		}
+		target = net.JoinHostPort(u.Hostname(), port)
+	}
+	return target, secure, nil
+}
+
+// streamLoop stream bağlantısını kurar, koparsa yeniden bağlanır; bağlantı kurulamazsa HTTP polling'e düşer
+func (w *Worker) streamLoop() {
+	for {
+		ws, err := w.dialStream()
+		if err != nil {
+			if w.ctx.Err() != nil {
+				return
+			}
+			fmt.Printf("[Worker] Stream unavailable (%v), falling back to HTTP polling\n", err)
+			w.startPolling()
+			return
+		}
+		err = w.runStream(ws)
+		if w.ctx.Err() != nil {
+			return
+		}
+		fmt.Printf("[Worker] Stream closed (%v), reconnecting...\n", err)
+		select {
+		case <-time.After(2 * time.Second):
+		case <-w.ctx.Done():
+			return
+		}
+	}
+}
+
+// workerStream worker tarafında master'a açılmış gRPC akışı
+type workerStream struct {
+	ctx    context.Context // İptal edilince akış ve bağlantı kapanır
+	cancel context.CancelFunc
+	conn   *grpc.ClientConn
+	stream grpc.ClientStream
+	target string
+}
+
+func (ws *workerStream) close() {
+	ws.cancel()
+	ws.conn.Close()
+}
+
+// dialStream master'a gRPC akışı açar ve hello gönderir. Master akışı kabul edince başlık döner;
+// eski (gRPC'siz) master, yanlış secret veya zaman aşımı hata olarak döner.
+func (w *Worker) dialStream() (*workerStream, error) {
+	target, secure, err := grpcTarget(w.config.MasterURL)
+	if err != nil {
+		return nil, err
+	}
+	creds := insecure.NewCredentials()
+	if secure {
+		cfg := w.tlsConfig
+		if cfg == nil {
+			cfg = &tls.Config{}
+		}
+		creds = credentials.NewTLS(cfg)
+	}
+	conn, err := grpc.NewClient(target, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(w.ctx)
+	ws := &workerStream{ctx: ctx, cancel: cancel, conn: conn, target: target}
+	if w.config.SecretKey != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+w.config.SecretKey)
+	}
+	ws.stream, err = conn.NewStream(ctx, &workerStreamDesc.Streams[0], streamMethod, grpc.CallContentSubtype(streamCodec))
+	if err != nil {
+		ws.close()
+		return nil, err
+	}
+
+	timeout := time.AfterFunc(10*time.Second, cancel)
+	info := *w.info
+	// io.EOF: master akışı kapattı; asıl hata Header'dan döner
+	ws.stream.SendMsg(&streamMessage{Type: streamHello, Worker: &info})
+	_, err = ws.stream.Header()
+	if !timeout.Stop() && err == nil {
+		err = fmt.Errorf("stream handshake timed out")
+	}
+	if err != nil {
+		ws.close()
+		return nil, err
+	}
+	return ws, nil
+}
+
+// runStream tek bir stream bağlantısını koparana kadar işler. Bağlantı koparsa işlenen task'lar iptal edilir;
+// master sonucu gelmeyen task'ları yeniden kuyruğa alır.
+func (w *Worker) runStream(ws *workerStream) error {
+	ctx := ws.ctx
+
+	var writeMu sync.Mutex
+	send := func(msg streamMessage) error {
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		return ws.stream.SendMsg(&msg)
+	}
+
+	var wg sync.WaitGroup
+	defer func() {
+		ws.close()
+		wg.Wait()
+	}()
+
+	if err := send(streamMessage{Type: streamReady, Credits: w.config.MaxConcurrency}); err != nil {
+		return err
+	}
+	fmt.Printf("[Worker] Stream connected: grpc://%s\n", ws.target)
+
+	// Heartbeat; gönderilemezse akışı kapatıp okumayı da sonlandırır
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(10 * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				hb := w.heartbeatData()
+				if send(streamMessage{Type: streamHeartbeat, Heartbeat: &hb}) != nil {
+					ws.cancel()
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// Master'ın iptal edebilmesi için çalışan task'ların context'leri
+	var runningMu sync.Mutex
+	running := make(map[string]context.CancelFunc)
+
+	for {
+		var msg streamMessage
+		if err := ws.stream.RecvMsg(&msg); err != nil {
+			return err
+		}
+		if msg.Type == streamCancel {
+			runningMu.Lock()
+			if stop, ok := running[msg.TaskID]; ok {
+				stop()
+				fmt.Printf("[Worker] Task cancelled by master: %s\n", msg.TaskID)
+			}
+			runningMu.Unlock()
+			continue
+		}
+		if msg.Type != streamTask || msg.Task == nil {
+			continue
+		}
+		taskCtx, stop := context.WithCancel(ctx)
+		runningMu.Lock()
+		running[msg.Task.ID] = stop
+		runningMu.Unlock()
+		wg.Add(1)
+		go func(task *Task) {
+			defer wg.Done()
+			result, ok, err := w.execute(taskCtx, task)
+			runningMu.Lock()
+			delete(running, task.ID)
+			runningMu.Unlock()
+			stop()
+			if ctx.Err() != nil {
+				return
+			}
+			reply := streamMessage{Type: streamComplete, TaskID: task.ID, Result: result}
+			if !ok {
+				reply = streamMessage{Type: streamFail, TaskID: task.ID}
+				if err != nil {
+					reply.Error = err.Error()
+				} else if result != nil {
+					reply.Error = result.Error
+				}
+			}
+			if send(reply) == nil {
+				send(streamMessage{Type: streamReady, Credits: 1})
+			}
+		}(msg.Task)
+	}
+}
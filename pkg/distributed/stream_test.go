@@ -0,0 +1,253 @@
+package distributed
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func startTestMaster(t *testing.T, addr, secret string) *Master {
+	t.Helper()
+	master := NewMaster(MasterConfig{BindAddr: addr, SecretKey: secret, HeartbeatInterval: time.Second})
+	go master.Start()
+	time.Sleep(300 * time.Millisecond)
+	t.Cleanup(func() { master.Stop() })
+	return master
+}
+
+func okProcessor(ctx context.Context, task *Task) (*TaskResult, error) {
+	return &TaskResult{Success: true, StatusCode: 200, Timestamp: time.Now()}, nil
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func TestStreamWorkerProcessesTasks(t *testing.T) {
+	master := startTestMaster(t, "127.0.0.1:18090", "stream-secret")
+
+	worker := NewWorker(WorkerConfig{
+		MasterURL:      "http://127.0.0.1:18090",
+		SecretKey:      "stream-secret",
+		MaxConcurrency: 2,
+		Stream:         true,
+	}, okProcessor)
+	go worker.Start()
+	defer worker.Stop()
+
+	waitFor(t, func() bool {
+		ws := master.ListWorkers()
+		return len(ws) == 1 && ws[0].Transport == "grpc"
+	})
+	for i := 0; i < 5; i++ {
+		if err := master.SubmitTask(&Task{URL: "http://example.com/"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	waitFor(t, func() bool { return master.GetStats().CompletedTasks == 5 })
+}
+
+// writeTestCert 127.0.0.1 için self-signed sertifika ve anahtar yazar
+func writeTestCert(t *testing.T) (certFile, keyFile string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "vgbot-test"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	certFile, keyFile = filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)
+	os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600)
+	return certFile, keyFile
+}
+
+func TestStreamOverTLS(t *testing.T) {
+	certFile, keyFile := writeTestCert(t)
+	master := NewMaster(MasterConfig{BindAddr: "127.0.0.1:18095", SecretKey: "tls-secret",
+		HeartbeatInterval: time.Second, TLSCertFile: certFile, TLSKeyFile: keyFile})
+	go master.Start()
+	time.Sleep(300 * time.Millisecond)
+	defer master.Stop()
+
+	worker := NewWorker(WorkerConfig{
+		MasterURL:      "https://127.0.0.1:18095",
+		SecretKey:      "tls-secret",
+		MaxConcurrency: 1,
+		Stream:         true,
+		TLSCAFile:      certFile,
+	}, okProcessor)
+	go worker.Start()
+	defer worker.Stop()
+
+	waitFor(t, func() bool {
+		ws := master.ListWorkers()
+		return len(ws) == 1 && ws[0].Transport == "grpc"
+	})
+	if err := master.SubmitTask(&Task{URL: "http://example.com/"}); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool { return master.GetStats().CompletedTasks == 1 })
+}
+
+func TestStreamFallsBackToHTTP(t *testing.T) {
+	master := startTestMaster(t, "127.0.0.1:18091", "")
+
+	// gRPC sunmayan (eski, yalnızca HTTP/1.1) master'ı taklit eden proxy
+	target, _ := url.Parse("http://127.0.0.1:18091")
+	proxy := httputil.NewSingleHostReverseProxy(target)
+	legacy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxy.ServeHTTP(w, r)
+	}))
+	defer legacy.Close()
+
+	worker := NewWorker(WorkerConfig{MasterURL: legacy.URL, MaxConcurrency: 1, Stream: true}, okProcessor)
+	go worker.Start()
+	defer worker.Stop()
+
+	waitFor(t, func() bool { return len(master.ListWorkers()) == 1 })
+	if err := master.SubmitTask(&Task{URL: "http://example.com/"}); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool { return master.GetStats().CompletedTasks == 1 })
+	if got := master.ListWorkers()[0].Transport; got != "http" {
+		t.Errorf("transport = %q, want http", got)
+	}
+}
+
+// openTestStream master'a ham gRPC akışı açar
+func openTestStream(t *testing.T, addr, secret string) (grpc.ClientStream, context.CancelFunc) {
+	t.Helper()
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	if secret != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+secret)
+	}
+	stream, err := conn.NewStream(ctx, &workerStreamDesc.Streams[0], streamMethod, grpc.CallContentSubtype(streamCodec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return stream, func() {
+		cancel()
+		conn.Close()
+	}
+}
+
+func TestStreamRequiresSecretMetadata(t *testing.T) {
+	master := startTestMaster(t, "127.0.0.1:18094", "stream-secret")
+
+	stream, closeStream := openTestStream(t, "127.0.0.1:18094", "wrong")
+	defer closeStream()
+	stream.SendMsg(&streamMessage{Type: streamHello, Worker: &WorkerInfo{ID: "w1", MaxConcurrency: 1}})
+	var msg streamMessage
+	if err := stream.RecvMsg(&msg); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("err = %v, want Unauthenticated", err)
+	}
+	if n := len(master.ListWorkers()); n != 0 {
+		t.Errorf("workers = %d, want 0", n)
+	}
+}
+
+func TestStreamRequeuesOnDisconnect(t *testing.T) {
+	master := startTestMaster(t, "127.0.0.1:18092", "")
+
+	stream, closeStream := openTestStream(t, "127.0.0.1:18092", "")
+	stream.SendMsg(&streamMessage{Type: streamHello, Worker: &WorkerInfo{ID: "w1", MaxConcurrency: 1}})
+	stream.SendMsg(&streamMessage{Type: streamReady, Credits: 1})
+	master.SubmitTask(&Task{URL: "http://example.com/"})
+
+	var msg streamMessage
+	if err := stream.RecvMsg(&msg); err != nil || msg.Type != streamTask || msg.Task.WorkerID != "w1" {
+		t.Fatalf("msg = %+v, err = %v", msg, err)
+	}
+	closeStream()
+
+	waitFor(t, func() bool { return master.GetStats().PendingTasks == 1 })
+	if stats := master.GetStats(); stats.CompletedTasks+stats.FailedTasks != 0 {
+		t.Errorf("stats = %+v", stats)
+	}
+}
+
+func TestStreamCancelStopsTask(t *testing.T) {
+	master := startTestMaster(t, "127.0.0.1:18093", "")
+
+	stopped := make(chan struct{})
+	worker := NewWorker(WorkerConfig{MasterURL: "http://127.0.0.1:18093", MaxConcurrency: 1, Stream: true},
+		func(ctx context.Context, task *Task) (*TaskResult, error) {
+			<-ctx.Done()
+			close(stopped)
+			return nil, ctx.Err()
+		})
+	go worker.Start()
+	defer worker.Stop()
+
+	waitFor(t, func() bool { return len(master.ListWorkers()) == 1 })
+	task := &Task{URL: "http://example.com/"}
+	master.SubmitTask(task)
+	waitFor(t, func() bool { got, _ := master.GetTask(task.ID); return got.Status == TaskAssigned })
+
+	if _, err := master.CancelTask(task.ID); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("worker did not stop the cancelled task")
+	}
+	// Worker'ın fail yanıtı yok sayılır: task iptal durumunda kalır, yeniden denenmez
+	time.Sleep(200 * time.Millisecond)
+	if got, _ := master.GetTask(task.ID); got.Status != TaskCancelled || got.Attempts != 0 {
+		t.Errorf("task = %+v", got)
+	}
+	if stats := master.GetStats(); stats.FailedTasks+stats.RetriedTasks != 0 {
+		t.Errorf("stats = %+v", stats)
+	}
+}
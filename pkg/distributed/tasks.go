@@ -0,0 +1,240 @@
+package distributed
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// GetTask task'ın kopyasını döner
+func (m *Master) GetTask(id string) (Task, bool) {
+	m.tasksMu.RLock()
+	defer m.tasksMu.RUnlock()
+	task, ok := m.tasks[id]
+	if !ok {
+		return Task{}, false
+	}
+	return *task, true
+}
+
+// Tasks task'ların kopyalarını en yeniden eskiye döner; status boşsa hepsi, limit <= 0 ise sınırsız
+func (m *Master) Tasks(status TaskStatus, limit int) []Task {
+	m.tasksMu.RLock()
+	tasks := make([]Task, 0, len(m.tasks))
+	for _, t := range m.tasks {
+		if status == "" || t.Status == status {
+			tasks = append(tasks, *t)
+		}
+	}
+	m.tasksMu.RUnlock()
+
+	sort.Slice(tasks, func(i, j int) bool {
+		if !tasks[i].CreatedAt.Equal(tasks[j].CreatedAt) {
+			return tasks[i].CreatedAt.After(tasks[j].CreatedAt)
+		}
+		return tasks[i].ID > tasks[j].ID
+	})
+	if limit > 0 && len(tasks) > limit {
+		tasks = tasks[:limit]
+	}
+	return tasks
+}
+
+// CancelTask bekleyen, yeniden denenecek veya bir worker'da çalışan task'ı iptal eder. Kuyruktaki kaydı
+// dağıtılmaz; stream bağlantılı worker'a iptal bildirilir ve ziyaret durdurulur, HTTP polling worker'ı
+// ziyareti bitirir ama sonucu yok sayılır. Bitmiş (completed/failed) task iptal edilemez.
+func (m *Master) CancelTask(id string) (Task, error) {
+	m.tasksMu.Lock()
+	task, ok := m.tasks[id]
+	if !ok {
+		m.tasksMu.Unlock()
+		return Task{}, fmt.Errorf("task not found: %s", id)
+	}
+	switch task.Status {
+	case TaskCompleted, TaskFailed, TaskCancelled:
+		status := task.Status
+		m.tasksMu.Unlock()
+		return Task{}, fmt.Errorf("task %s is already %s", id, status)
+	}
+	now := time.Now()
+	workerID := task.WorkerID
+	task.Status = TaskCancelled
+	task.CompletedAt = &now
+	task.NextRetryAt = nil
+	copied := *task
+	m.tasksMu.Unlock()
+
+	atomic.AddInt64(&m.cancelledTasks, 1)
+	if workerID != "" {
+		m.workersMu.RLock()
+		s := m.streams[workerID]
+		m.workersMu.RUnlock()
+		if s != nil {
+			s.send(streamMessage{Type: streamCancel, TaskID: id})
+		}
+	}
+	m.logf("Task cancelled: %s", id)
+	return copied, nil
+}
+
+// RequeueTask tek bir task'ı yeniden kuyruğa alır: dead-letter'daki başarısız veya iptal edilmiş task deneme
+// sayacı sıfırlanarak, backoff bekleyen task beklemeden kuyruğa girer. Bekleyen, çalışan veya tamamlanmış
+// task yeniden kuyruğa alınamaz.
+func (m *Master) RequeueTask(id string) (Task, error) {
+	if atomic.LoadInt32(&m.running) == 0 {
+		return Task{}, fmt.Errorf("master not running")
+	}
+	if m.Halted() {
+		return Task{}, fmt.Errorf("master halted")
+	}
+	m.tasksMu.Lock()
+	task, ok := m.tasks[id]
+	if !ok {
+		m.tasksMu.Unlock()
+		return Task{}, fmt.Errorf("task not found: %s", id)
+	}
+	switch task.Status {
+	case TaskFailed:
+		m.tasksMu.Unlock()
+		if _, err := m.RequeueDead(id); err != nil {
+			return Task{}, err
+		}
+	case TaskCancelled:
+		// Kuyrukta eski kayıt kalmış olabilir: yeni kayıt konur, assignTask eskisini atlar
+		fresh := *task
+		fresh.Status = TaskPending
+		fresh.Attempts = 0
+		fresh.LastError = ""
+		fresh.WorkerID = ""
+		fresh.AssignedAt = nil
+		fresh.CompletedAt = nil
+		fresh.NextRetryAt = nil
+		fresh.Result = nil
+		select {
+		case m.taskQueue <- &fresh:
+			m.tasks[id] = &fresh
+			m.tasksMu.Unlock()
+			atomic.AddInt64(&m.cancelledTasks, -1)
+		default:
+			m.tasksMu.Unlock()
+			return Task{}, fmt.Errorf("task queue full")
+		}
+	case TaskRetrying:
+		task.Status = TaskPending
+		task.NextRetryAt = nil
+		select {
+		case m.taskQueue <- task:
+			m.tasksMu.Unlock()
+		default:
+			task.Status = TaskRetrying
+			m.tasksMu.Unlock()
+			return Task{}, fmt.Errorf("task queue full")
+		}
+	default:
+		status := task.Status
+		m.tasksMu.Unlock()
+		return Task{}, fmt.Errorf("task %s is %s; only failed, cancelled or retrying tasks can be requeued", id, status)
+	}
+	m.logf("Task requeued: %s", id)
+	copied, _ := m.GetTask(id)
+	return copied, nil
+}
+
+// Halt tüm açık (bekleyen, yeniden denenecek, çalışan) task'ları iptal eder ve Resume çağrılana kadar
+// yeni task ve requeue kabul etmez (acil durdurma). İptal edilen task sayısını döner.
+func (m *Master) Halt() int {
+	atomic.StoreInt32(&m.halted, 1)
+	m.tasksMu.RLock()
+	var open []string
+	for id, t := range m.tasks {
+		switch t.Status {
+		case TaskCompleted, TaskFailed, TaskCancelled:
+		default:
+			open = append(open, id)
+		}
+	}
+	m.tasksMu.RUnlock()
+
+	cancelled := 0
+	for _, id := range open {
+		if _, err := m.CancelTask(id); err == nil {
+			cancelled++
+		}
+	}
+	m.logf("Master halted: %d tasks cancelled", cancelled)
+	return cancelled
+}
+
+// Resume Halt'ı kaldırır; iptal edilen task'lar kendiliğinden geri gelmez
+func (m *Master) Resume() {
+	if atomic.CompareAndSwapInt32(&m.halted, 1, 0) {
+		m.logf("Master resumed")
+	}
+}
+
+// Halted master Halt ile durdurulmuş mu
+func (m *Master) Halted() bool {
+	return atomic.LoadInt32(&m.halted) == 1
+}
+
+// handleHalt POST /api/v1/master/halt açık task'ları iptal edip master'ı durdurur (dashboard kill switch'i
+// ayrı çalışan master'a böyle ulaşır); POST /api/v1/master/resume durdurmayı kaldırır
+func (m *Master) handleHalt(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	resp := map[string]interface{}{}
+	if strings.HasSuffix(r.URL.Path, "/resume") {
+		m.Resume()
+	} else {
+		resp["cancelled_tasks"] = m.Halt()
+	}
+	resp["halted"] = m.Halted()
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// handleTask /api/v1/master/task/{id}: GET ayrıntı; POST /cancel iptal, POST /requeue yeniden kuyruğa al
+func (m *Master) handleTask(w http.ResponseWriter, r *http.Request) {
+	id, action, _ := strings.Cut(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/v1/master/task/"), "/"), "/")
+	if id == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	var task Task
+	var err error
+	switch {
+	case action == "" && r.Method == http.MethodGet:
+		var ok bool
+		if task, ok = m.GetTask(id); !ok {
+			http.Error(w, "task not found", http.StatusNotFound)
+			return
+		}
+	case action == "cancel" && r.Method == http.MethodPost:
+		task, err = m.CancelTask(id)
+	case action == "requeue" && r.Method == http.MethodPost:
+		task, err = m.RequeueTask(id)
+	case action != "" && action != "cancel" && action != "requeue":
+		http.NotFound(w, r)
+		return
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err != nil {
+		code := http.StatusConflict
+		if _, ok := m.GetTask(id); !ok {
+			code = http.StatusNotFound
+		}
+		http.Error(w, err.Error(), code)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(task)
+}
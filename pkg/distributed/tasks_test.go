@@ -0,0 +1,185 @@
+package distributed
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCancelAndRequeueTask(t *testing.T) {
+	master := startTestMaster(t, "127.0.0.1:18084", "")
+	post := func(path string) *http.Response {
+		resp, err := http.Post("http://127.0.0.1:18084/api/v1/master/task/"+path, "application/json", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp
+	}
+
+	first, second := &Task{URL: "http://example.com/a"}, &Task{URL: "http://example.com/b"}
+	master.SubmitTask(first)
+	master.SubmitTask(second)
+
+	// Bekleyen task iptal edilir; kuyruktaki kaydı dağıtılmaz
+	if resp := post(first.ID + "/cancel"); resp.StatusCode != http.StatusOK {
+		t.Fatalf("cancel = %d", resp.StatusCode)
+	}
+	if got := master.assignTask(<-master.taskQueue, "w1"); got != nil {
+		t.Fatalf("cancelled task assigned: %+v", got)
+	}
+	running := master.assignTask(<-master.taskQueue, "w1")
+	if running == nil || running.ID != second.ID {
+		t.Fatalf("assigned = %+v", running)
+	}
+
+	// Çalışan task iptal edilince worker'ın geç gelen sonucu sayılmaz
+	if _, err := master.CancelTask(second.ID); err != nil {
+		t.Fatal(err)
+	}
+	master.completeTask(second.ID, TaskResult{Success: true})
+	if task, _ := master.GetTask(second.ID); task.Status != TaskCancelled || task.Result != nil {
+		t.Errorf("late result recorded: %+v", task)
+	}
+	if stats := master.GetStats(); stats.CompletedTasks != 0 || stats.CancelledTasks != 2 {
+		t.Errorf("stats = %+v", stats)
+	}
+
+	if resp := post(first.ID + "/requeue"); resp.StatusCode != http.StatusOK {
+		t.Fatalf("requeue = %d", resp.StatusCode)
+	}
+	if got := master.assignTask(<-master.taskQueue, "w2"); got == nil || got.ID != first.ID || got.WorkerID != "w2" {
+		t.Fatalf("requeued task = %+v", got)
+	}
+	if resp := post(first.ID + "/requeue"); resp.StatusCode != http.StatusConflict {
+		t.Errorf("requeue assigned task = %d, want 409", resp.StatusCode)
+	}
+	if resp := post("task_missing/cancel"); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("cancel missing = %d, want 404", resp.StatusCode)
+	}
+
+	resp, err := http.Get("http://127.0.0.1:18084/api/v1/master/task/" + first.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var shown Task
+	json.NewDecoder(resp.Body).Decode(&shown)
+	if shown.Status != TaskAssigned || master.GetStats().CancelledTasks != 1 {
+		t.Errorf("shown = %+v", shown)
+	}
+	if tasks := master.Tasks(TaskCancelled, 0); len(tasks) != 1 || tasks[0].ID != second.ID {
+		t.Errorf("cancelled tasks = %+v", tasks)
+	}
+}
+
+func TestHaltAndResume(t *testing.T) {
+	master := startTestMaster(t, "127.0.0.1:18085", "")
+	pending, running := &Task{URL: "http://example.com/a"}, &Task{URL: "http://example.com/b"}
+	master.SubmitTask(running)
+	master.SubmitTask(pending)
+	master.assignTask(<-master.taskQueue, "w1")
+
+	if n := master.Halt(); n != 2 {
+		t.Fatalf("halt cancelled %d tasks, want 2", n)
+	}
+	if err := master.SubmitTask(&Task{URL: "http://example.com/c"}); err == nil {
+		t.Error("halted master accepted a task")
+	}
+	if _, err := master.RequeueTask(pending.ID); err == nil {
+		t.Error("halted master requeued a task")
+	}
+	if _, err := master.RequeueDead(); err == nil {
+		t.Error("halted master requeued dead tasks")
+	}
+	if n := master.Halt(); n != 0 {
+		t.Errorf("second halt cancelled %d tasks", n)
+	}
+
+	// Polling workers get no new task and stop the ones they run on the next heartbeat
+	resp, err := http.Post("http://127.0.0.1:18085/api/v1/worker/task/request", "application/json", strings.NewReader(`{"worker_id":"w1"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusLocked {
+		t.Errorf("task request while halted = %d, want 423", resp.StatusCode)
+	}
+	worker := NewWorker(WorkerConfig{MasterURL: "http://127.0.0.1:18085"}, okProcessor)
+	taskCtx, stop := context.WithCancel(context.Background())
+	defer stop()
+	worker.polling = map[string]context.CancelFunc{running.ID: stop}
+	worker.sendHeartbeat()
+	if taskCtx.Err() == nil {
+		t.Error("worker kept running a task after the master halted")
+	}
+
+	master.Resume()
+	if err := master.SubmitTask(&Task{URL: "http://example.com/c"}); err != nil || master.Halted() {
+		t.Errorf("resumed master: %v", err)
+	}
+}
+
+func TestHaltEndpoint(t *testing.T) {
+	master := startTestMaster(t, "127.0.0.1:18087", "halt-secret")
+	master.SubmitTask(&Task{URL: "http://example.com/a"})
+
+	post := func(path, secret string) (int, map[string]interface{}) {
+		req, _ := http.NewRequest(http.MethodPost, "http://127.0.0.1:18087/api/v1/master/"+path, nil)
+		req.Header.Set("Authorization", "Bearer "+secret)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		var body map[string]interface{}
+		json.NewDecoder(resp.Body).Decode(&body)
+		return resp.StatusCode, body
+	}
+
+	if code, _ := post("halt", "wrong"); code != http.StatusUnauthorized || master.Halted() {
+		t.Fatalf("unauthorized halt = %d, halted %v", code, master.Halted())
+	}
+	if code, body := post("halt", "halt-secret"); code != 200 || body["cancelled_tasks"] != float64(1) || !master.Halted() {
+		t.Fatalf("halt = %d %v", code, body)
+	}
+	if code, body := post("resume", "halt-secret"); code != 200 || body["halted"] != false || master.Halted() {
+		t.Fatalf("resume = %d %v", code, body)
+	}
+}
+
+func TestSubmitTaskTargetCheck(t *testing.T) {
+	master := NewMaster(MasterConfig{BindAddr: "127.0.0.1:18086", HeartbeatInterval: time.Second,
+		TargetCheck: func(u string) error {
+			if !strings.Contains(u, "allowed.example") {
+				return errors.New("not whitelisted")
+			}
+			return nil
+		}})
+	go master.Start()
+	time.Sleep(300 * time.Millisecond)
+	defer master.Stop()
+
+	if err := master.SubmitTask(&Task{URL: "https://other.example/"}); !errors.Is(err, ErrTargetRejected) {
+		t.Fatalf("err = %v, want ErrTargetRejected", err)
+	}
+	resp, err := http.Post("http://127.0.0.1:18086/api/v1/master/task/submit", "application/json",
+		strings.NewReader(`{"url":"https://other.example/"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("submit = %d, want 403", resp.StatusCode)
+	}
+	if err := master.SubmitTask(&Task{URL: "https://allowed.example/"}); err != nil {
+		t.Fatal(err)
+	}
+	if stats := master.GetStats(); stats.TotalTasks != 1 {
+		t.Errorf("total = %d, want 1", stats.TotalTasks)
+	}
+}
@@ -0,0 +1,154 @@
+// Package errclass simulator, proxy ve analytics paketlerinin paylaştığı hata sınıflarını tanımlar.
+// Retry mantığı, metrik etiketleri ve raporlar hata mesajı yerine sınıfa göre dallanır.
+package errclass
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Sınıf etiketleri (metrik label'ı ve rapor anahtarı olarak kullanılır)
+const (
+	ProxyDead         = "proxy_dead"
+	NavigationTimeout = "navigation_timeout"
+	AnalyticsMissing  = "analytics_missing"
+	Banned            = "banned"
+	Network           = "network"
+	Functional        = "functional" // Sayfa yüklendi ama içerik doğrulaması başarısız
+	Other             = "other"
+)
+
+// Paylaşılan hata türleri; errors.Is ile kontrol edilir
+var (
+	ErrProxyDead         = errors.New("proxy dead")
+	ErrNavigationTimeout = errors.New("navigation timeout")
+	ErrAnalyticsMissing  = errors.New("analytics tag missing")
+	ErrBanned            = errors.New("banned")
+	ErrNetwork           = errors.New("network error")
+	ErrFunctional        = errors.New("content check failed")
+)
+
+// Wrap err'i verilen sınıfla sarar (err nil ise nil)
+func Wrap(class error, err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, class) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", class, err)
+}
+
+// Status HTTP durum kodunu sınıflandırır; engelleme kodları (403, 429) ErrBanned döner
+func Status(code int) error {
+	switch code {
+	case 403, 429:
+		return fmt.Errorf("%w: HTTP %d", ErrBanned, code)
+	}
+	return nil
+}
+
+// Classify ham hatayı (chromedp/net mesajları) tipli hataya sarar.
+// Zaten sınıflandırılmış hatalar olduğu gibi döner.
+func Classify(err error) error {
+	if err == nil || Of(err) != Other {
+		return err
+	}
+	switch classOfMessage(err.Error()) {
+	case ProxyDead:
+		return Wrap(ErrProxyDead, err)
+	case NavigationTimeout:
+		return Wrap(ErrNavigationTimeout, err)
+	case Banned:
+		return Wrap(ErrBanned, err)
+	case Network:
+		return Wrap(ErrNetwork, err)
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return Wrap(ErrNavigationTimeout, err)
+	}
+	return err
+}
+
+// Of hatanın sınıf etiketini döner (nil için "")
+func Of(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, ErrProxyDead):
+		return ProxyDead
+	case errors.Is(err, ErrNavigationTimeout):
+		return NavigationTimeout
+	case errors.Is(err, ErrAnalyticsMissing):
+		return AnalyticsMissing
+	case errors.Is(err, ErrBanned):
+		return Banned
+	case errors.Is(err, ErrNetwork):
+		return Network
+	case errors.Is(err, ErrFunctional):
+		return Functional
+	}
+	return Other
+}
+
+// OfMessage yalnızca mesajı bilinen hatayı sınıflandırır (worker sonuçları, eski raporlar)
+func OfMessage(msg string) string {
+	if msg == "" {
+		return ""
+	}
+	return classOfMessage(msg)
+}
+
+// Retryable aynı ziyaretin (başka bir proxy/visitor ile) tekrar denenmesi anlamlı mı
+func Retryable(err error) bool {
+	switch Of(err) {
+	case ProxyDead, NavigationTimeout, Network, Banned:
+		return true
+	}
+	return false
+}
+
+// ProxyFault hata proxy'den mi kaynaklanıyor (proxy havuzdan çıkarılmalı)
+func ProxyFault(err error) bool {
+	switch Of(err) {
+	case ProxyDead, Banned:
+		return true
+	}
+	return false
+}
+
+// classOfMessage string eşleştirmesinin yapıldığı tek yer
+func classOfMessage(msg string) string {
+	m := strings.ToLower(msg)
+	switch {
+	case containsAny(m, "err_proxy_connection_failed", "err_tunnel_connection_failed",
+		"err_socks_connection_failed", "proxyconnect", "proxy error", "proxy authentication required",
+		"err_no_supported_proxies", "proxy dead"):
+		return ProxyDead
+	case containsAny(m, "err_timed_out", "err_connection_timed_out", "context deadline exceeded",
+		"navigation timeout", "client.timeout exceeded", "i/o timeout"):
+		return NavigationTimeout
+	case containsAny(m, "http 403", "http 429", "err_blocked", "captcha", "banned"):
+		return Banned
+	case containsAny(m, "analytics tag missing"):
+		return AnalyticsMissing
+	case containsAny(m, "content check failed"):
+		return Functional
+	case containsAny(m, "err_name_not_resolved", "err_connection_refused", "err_connection_reset",
+		"err_connection_closed", "err_internet_disconnected", "err_address_unreachable",
+		"no such host", "connection refused", "connection reset", "network error"):
+		return Network
+	}
+	return Other
+}
+
+func containsAny(s string, subs ...string) bool {
+	for _, sub := range subs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
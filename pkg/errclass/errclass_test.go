@@ -0,0 +1,47 @@
+package errclass
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestClassify(t *testing.T) {
+	cases := []struct {
+		err  error
+		want string
+	}{
+		{nil, ""},
+		{errors.New("page load error net::ERR_PROXY_CONNECTION_FAILED"), ProxyDead},
+		{errors.New("net::ERR_TIMED_OUT"), NavigationTimeout},
+		{fmt.Errorf("run: %w", context.DeadlineExceeded), NavigationTimeout},
+		{errors.New("net::ERR_NAME_NOT_RESOLVED"), Network},
+		{Status(429), Banned},
+		{fmt.Errorf("event errors: %w", errors.Join(ErrAnalyticsMissing)), AnalyticsMissing},
+		{fmt.Errorf("%w: /blog/*: status 500, want 200", ErrFunctional), Functional},
+		{errors.New("something else"), Other},
+	}
+	for _, c := range cases {
+		if got := Of(Classify(c.err)); got != c.want {
+			t.Errorf("Of(Classify(%v)) = %q, want %q", c.err, got, c.want)
+		}
+	}
+}
+
+func TestProxyFaultAndRetryable(t *testing.T) {
+	dead := Wrap(ErrProxyDead, errors.New("dial tcp: refused"))
+	if !ProxyFault(dead) || !Retryable(dead) {
+		t.Error("dead proxy should be a retryable proxy fault")
+	}
+	timeout := Classify(errors.New("net::ERR_TIMED_OUT"))
+	if ProxyFault(timeout) {
+		t.Error("timeout should not evict the proxy")
+	}
+	if Retryable(ErrAnalyticsMissing) {
+		t.Error("missing analytics is not retryable")
+	}
+	if Status(200) != nil {
+		t.Error("200 is not banned")
+	}
+}
@@ -3,7 +3,8 @@ package geo
 import (
 	"math/rand"
 	"sync"
-	"time"
+
+	"vgbot/pkg/utils"
 )
 
 // GeoLocation coğrafi konum bilgisi
@@ -26,6 +27,7 @@ type GeoConfig struct {
 	Countries     []string // Hedef ülkeler
 	Cities        []string // Hedef şehirler (opsiyonel)
 	Distribution  map[string]int // Ülke dağılımı (yüzde)
+	Seeder        *utils.Seeder  // Çalıştırmanın seed kaynağı (nil = tekrarlanamaz)
 }
 
 // GeoManager coğrafi hedefleme yöneticisi
@@ -317,7 +319,7 @@ var turkishCities = map[string]GeoLocation{
 func NewGeoManager(config GeoConfig) *GeoManager {
 	return &GeoManager{
 		config: config,
-		rng:    rand.New(rand.NewSource(time.Now().UnixNano())),
+		rng:    config.Seeder.NewRand(),
 	}
 }
 
@@ -0,0 +1,224 @@
+// Package googleauth Google Service Account (JWT bearer) ile OAuth access token alır.
+// GSC sorguları ve BigQuery export aynı kodu farklı scope'larla kullanır.
+package googleauth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OAuth scope'ları
+const (
+	ScopeWebmastersReadOnly = "https://www.googleapis.com/auth/webmasters.readonly"
+	ScopeBigQueryInsert     = "https://www.googleapis.com/auth/bigquery.insertdata"
+)
+
+// TokenURL Google OAuth token endpoint'i
+var TokenURL = "https://oauth2.googleapis.com/token"
+
+// ServiceAccount Service Account JSON anahtarının kullanılan alanları
+type ServiceAccount struct {
+	Type        string `json:"type"`
+	ProjectID   string `json:"project_id"`
+	PrivateKey  string `json:"private_key"`
+	ClientEmail string `json:"client_email"`
+}
+
+// ParseServiceAccount Service Account JSON'ını parse edip zorunlu alanları doğrular
+func ParseServiceAccount(data []byte) (*ServiceAccount, error) {
+	var sa ServiceAccount
+	if err := json.Unmarshal(data, &sa); err != nil {
+		return nil, fmt.Errorf("Invalid Service Account JSON format: %w", err)
+	}
+	if sa.Type != "service_account" {
+		return nil, fmt.Errorf("Invalid credential type. Expected 'service_account', got '%s'", sa.Type)
+	}
+	if sa.PrivateKey == "" || sa.ClientEmail == "" {
+		return nil, fmt.Errorf("Service Account JSON missing required fields (private_key or client_email)")
+	}
+	return &sa, nil
+}
+
+// AccessToken scope için JWT oluşturup access token ile değiştirir
+func AccessToken(ctx context.Context, clientEmail, privateKey, scope string) (string, error) {
+	jwt, err := CreateJWT(clientEmail, privateKey, scope)
+	if err != nil {
+		return "", fmt.Errorf("JWT oluşturma hatası: %w", err)
+	}
+	token, err := ExchangeJWT(ctx, jwt)
+	if err != nil {
+		return "", fmt.Errorf("Access token alma hatası: %w", err)
+	}
+	return token, nil
+}
+
+// CreateJWT Service Account için scope'lu JWT oluşturur
+func CreateJWT(clientEmail, privateKey, scope string) (string, error) {
+	// JWT Header
+	header := map[string]string{
+		"alg": "RS256",
+		"typ": "JWT",
+	}
+	headerJSON, _ := json.Marshal(header)
+	headerB64 := base64.RawURLEncoding.EncodeToString(headerJSON)
+
+	// JWT Claims
+	now := time.Now().Unix()
+	claims := map[string]interface{}{
+		"iss":   clientEmail,
+		"scope": scope,
+		"aud":   "https://oauth2.googleapis.com/token",
+		"iat":   now,
+		"exp":   now + 3600,
+	}
+	claimsJSON, _ := json.Marshal(claims)
+	claimsB64 := base64.RawURLEncoding.EncodeToString(claimsJSON)
+
+	// Signature
+	signatureInput := headerB64 + "." + claimsB64
+	signature, err := signRS256(signatureInput, privateKey)
+	if err != nil {
+		return "", err
+	}
+
+	return signatureInput + "." + signature, nil
+}
+
+// ExchangeJWT JWT'yi access token ile değiştirir
+func ExchangeJWT(ctx context.Context, jwt string) (string, error) {
+	token, _, err := exchangeJWT(ctx, jwt)
+	return token, err
+}
+
+// exchangeJWT access token'ı ve geçerlilik süresini döner
+func exchangeJWT(ctx context.Context, jwt string) (string, time.Duration, error) {
+	data := url.Values{}
+	data.Set("grant_type", "urn:ietf:params:oauth:grant-type:jwt-bearer")
+	data.Set("assertion", jwt)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, TokenURL, strings.NewReader(data.Encode()))
+	if err != nil {
+		return "", 0, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := (&http.Client{Timeout: 30 * time.Second}).Do(req)
+	if err != nil {
+		return "", 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return "", 0, fmt.Errorf("token exchange hatası (%d): %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var tokenResponse struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+		TokenType   string `json:"token_type"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResponse); err != nil {
+		return "", 0, err
+	}
+	if tokenResponse.ExpiresIn <= 0 {
+		tokenResponse.ExpiresIn = 3600
+	}
+
+	return tokenResponse.AccessToken, time.Duration(tokenResponse.ExpiresIn) * time.Second, nil
+}
+
+// tokenRefreshMargin token'ın süresi dolmadan bu kadar önce yenilenir
+const tokenRefreshMargin = 5 * time.Minute
+
+// TokenSource Service Account access token'ını önbellekte tutar ve süresi dolmak üzereyken yeniler;
+// her istekte JWT imzalayıp token değiştirmeyi önler. Eşzamanlı kullanım için güvenlidir.
+type TokenSource struct {
+	account *ServiceAccount
+	scope   string
+	mu      sync.Mutex
+	token   string
+	expiry  time.Time
+}
+
+// NewTokenSource scope için token kaynağı oluşturur
+func NewTokenSource(account *ServiceAccount, scope string) *TokenSource {
+	return &TokenSource{account: account, scope: scope}
+}
+
+// Token önbellekteki token'ı döner; yoksa veya süresi dolmak üzereyse yenisini alır
+func (ts *TokenSource) Token(ctx context.Context) (string, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if ts.token != "" && time.Until(ts.expiry) > tokenRefreshMargin {
+		return ts.token, nil
+	}
+	jwt, err := CreateJWT(ts.account.ClientEmail, ts.account.PrivateKey, ts.scope)
+	if err != nil {
+		return "", fmt.Errorf("JWT oluşturma hatası: %w", err)
+	}
+	token, ttl, err := exchangeJWT(ctx, jwt)
+	if err != nil {
+		return "", fmt.Errorf("Access token alma hatası: %w", err)
+	}
+	ts.token, ts.expiry = token, time.Now().Add(ttl)
+	return token, nil
+}
+
+// Invalidate önbellekteki token'ı düşürür (API 401 döndüğünde); sonraki Token yenisini alır
+func (ts *TokenSource) Invalidate() {
+	ts.mu.Lock()
+	ts.token = ""
+	ts.mu.Unlock()
+}
+
+// signRS256 RS256 imzalama
+func signRS256(input, privateKeyPEM string) (string, error) {
+	// PEM formatındaki private key'i parse et
+	block, _ := pem.Decode([]byte(privateKeyPEM))
+	if block == nil {
+		return "", fmt.Errorf("PEM block bulunamadı")
+	}
+
+	var privateKey interface{}
+	var err error
+
+	// PKCS#8 veya PKCS#1 formatını dene
+	privateKey, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		privateKey, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return "", fmt.Errorf("private key parse hatası: %w", err)
+		}
+	}
+
+	rsaKey, ok := privateKey.(*rsa.PrivateKey)
+	if !ok {
+		return "", fmt.Errorf("RSA private key değil")
+	}
+
+	// SHA256 hash
+	hashed := sha256.Sum256([]byte(input))
+
+	// RSA imzala
+	signature, err := rsa.SignPKCS1v15(nil, rsaKey, crypto.SHA256, hashed[:])
+	if err != nil {
+		return "", fmt.Errorf("imzalama hatası: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(signature), nil
+}
@@ -0,0 +1,96 @@
+package googleauth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCreateJWTSignsWithScope(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, _ := x509.MarshalPKCS8PrivateKey(key)
+	keyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+
+	jwt, err := CreateJWT("sa@example.iam.gserviceaccount.com", keyPEM, ScopeBigQueryInsert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		t.Fatalf("jwt parts = %d", len(parts))
+	}
+	claimsJSON, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var claims map[string]interface{}
+	json.Unmarshal(claimsJSON, &claims)
+	if claims["scope"] != ScopeBigQueryInsert || claims["iss"] != "sa@example.iam.gserviceaccount.com" {
+		t.Fatalf("claims = %v", claims)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	hashed := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, hashed[:], sig); err != nil {
+		t.Fatalf("signature: %v", err)
+	}
+}
+
+func TestParseServiceAccount(t *testing.T) {
+	if _, err := ParseServiceAccount([]byte(`{"type":"authorized_user"}`)); err == nil {
+		t.Fatal("expected error for non service_account type")
+	}
+	if _, err := ParseServiceAccount([]byte(`{"type":"service_account","client_email":"a@b"}`)); err == nil {
+		t.Fatal("expected error for missing private_key")
+	}
+	sa, err := ParseServiceAccount([]byte(`{"type":"service_account","project_id":"p","client_email":"a@b","private_key":"k"}`))
+	if err != nil || sa.ProjectID != "p" {
+		t.Fatalf("sa = %+v, err = %v", sa, err)
+	}
+}
+
+func TestTokenSourceCachesAndRefreshes(t *testing.T) {
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+	der, _ := x509.MarshalPKCS8PrivateKey(key)
+	sa := &ServiceAccount{ClientEmail: "sa@example.iam.gserviceaccount.com",
+		PrivateKey: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))}
+
+	issued, ttl := 0, 3600
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		issued++
+		fmt.Fprintf(w, `{"access_token":"tok%d","expires_in":%d}`, issued, ttl)
+	}))
+	defer srv.Close()
+	defer func(u string) { TokenURL = u }(TokenURL)
+	TokenURL = srv.URL
+
+	ts := NewTokenSource(sa, ScopeWebmastersReadOnly)
+	for i := 0; i < 3; i++ {
+		if tok, err := ts.Token(context.Background()); err != nil || tok != "tok1" {
+			t.Fatalf("Token = %q, %v", tok, err)
+		}
+	}
+	ts.Invalidate()
+	// Süresi yenileme payından kısa token her çağrıda yenilenir
+	ttl = 60
+	ts.Token(context.Background())
+	if tok, _ := ts.Token(context.Background()); tok != "tok3" || issued != 3 {
+		t.Errorf("after invalidate/expiry: %q issued %d", tok, issued)
+	}
+}
@@ -0,0 +1,315 @@
+// Package gsc Google Search Console Search Analytics API istemcisi. Access token önbellekte tutulup
+// süresi dolmadan yenilenir, sonuçlar sayfalanarak (istek başına 25.000 satıra kadar) alınır ve
+// property + tarih aralığı + boyutlara göre diskte önbelleğe yazılır.
+package gsc
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"vgbot/pkg/googleauth"
+)
+
+// APIBase Search Console API kök adresi (testlerde değiştirilir)
+var APIBase = "https://www.googleapis.com/webmasters/v3"
+
+// Search Analytics boyutları
+const (
+	DimensionQuery   = "query"
+	DimensionPage    = "page"
+	DimensionCountry = "country"
+	DimensionDevice  = "device"
+	DimensionDate    = "date"
+)
+
+const (
+	// MaxPageSize API'nin istek başına döndüğü en fazla satır
+	MaxPageSize = 25000
+	// DefaultRowLimit Query.RowLimit verilmezse toplam satır sınırı
+	DefaultRowLimit = 1000
+	// MaxRowLimit toplam satır sınırının üst değeri
+	MaxRowLimit = 100000
+)
+
+// dateLayout API'nin tarih biçimi
+const dateLayout = "2006-01-02"
+
+// Query Search Analytics sorgusu
+type Query struct {
+	StartDate  string   // YYYY-MM-DD
+	EndDate    string   // YYYY-MM-DD (dahil)
+	Dimensions []string // query, page, country, device, date; boşsa yalnızca query
+	RowLimit   int      // Toplam satır sınırı (0 = DefaultRowLimit); gerekirse birden çok sayfa istenir
+	Refresh    bool     // Önbelleği atla (yeni sonuç yine önbelleğe yazılır)
+}
+
+// Row sorgu sonucu; yalnızca istenen boyutların alanları dolu olur
+type Row struct {
+	Query       string  `json:"query,omitempty"`
+	Page        string  `json:"page,omitempty"`
+	Country     string  `json:"country,omitempty"` // ISO 3166-1 alpha-3, küçük harf (ör. tur)
+	Device      string  `json:"device,omitempty"`
+	Date        string  `json:"date,omitempty"`
+	Clicks      int     `json:"clicks"`
+	Impressions int     `json:"impressions"`
+	CTR         float64 `json:"ctr"`
+	Position    float64 `json:"position"`
+}
+
+// Config istemci ayarları
+type Config struct {
+	CacheDir string        // Sonuç önbelleği dizini (boş = kapalı)
+	CacheTTL time.Duration // Önbellekteki sonuç bu süre kullanılır (<= 0 = kapalı)
+	PageSize int           // İstek başına satır (0 = MaxPageSize)
+}
+
+// Client Search Console istemcisi; eşzamanlı kullanım için güvenlidir
+type Client struct {
+	tokens *googleauth.TokenSource
+	http   *http.Client
+	cfg    Config
+}
+
+// NewClient Service Account ile istemci oluşturur
+func NewClient(account *googleauth.ServiceAccount, cfg Config) *Client {
+	if cfg.PageSize <= 0 || cfg.PageSize > MaxPageSize {
+		cfg.PageSize = MaxPageSize
+	}
+	return &Client{
+		tokens: googleauth.NewTokenSource(account, googleauth.ScopeWebmastersReadOnly),
+		http:   &http.Client{Timeout: 30 * time.Second},
+		cfg:    cfg,
+	}
+}
+
+// NormalizeProperty property adresini API biçimine getirir; yalnızca domain girildiyse (örn. eros.sh)
+// domain property (sc-domain:) olarak kabul edilir
+func NormalizeProperty(raw string) string {
+	p := strings.TrimSuffix(strings.TrimSpace(raw), "/")
+	if p == "" || strings.HasPrefix(p, "http://") || strings.HasPrefix(p, "https://") || strings.HasPrefix(p, "sc-domain:") {
+		return p
+	}
+	return "sc-domain:" + p
+}
+
+// LastDays son days günün tarih aralığı; GSC verisi gecikmeli geldiğinden bitiş dündür
+func LastDays(days int, now time.Time) (start, end string) {
+	if days <= 0 {
+		days = 28
+	}
+	return now.AddDate(0, 0, -days).Format(dateLayout), now.AddDate(0, 0, -1).Format(dateLayout)
+}
+
+// validate sorguyu doğrular ve varsayılanları uygular
+func (q Query) validate() (Query, error) {
+	start, err1 := time.Parse(dateLayout, q.StartDate)
+	end, err2 := time.Parse(dateLayout, q.EndDate)
+	if err1 != nil || err2 != nil || end.Before(start) {
+		return q, fmt.Errorf("geçersiz tarih aralığı %q - %q (YYYY-MM-DD)", q.StartDate, q.EndDate)
+	}
+	q.Dimensions = append([]string(nil), q.Dimensions...)
+	if len(q.Dimensions) == 0 {
+		q.Dimensions = []string{DimensionQuery}
+	}
+	seen := map[string]bool{}
+	for i, d := range q.Dimensions {
+		d = strings.ToLower(strings.TrimSpace(d))
+		switch d {
+		case DimensionQuery, DimensionPage, DimensionCountry, DimensionDevice, DimensionDate:
+		default:
+			return q, fmt.Errorf("geçersiz boyut %q (query, page, country, device, date)", d)
+		}
+		if seen[d] {
+			return q, fmt.Errorf("boyut tekrarlanmış: %s", d)
+		}
+		seen[d] = true
+		q.Dimensions[i] = d
+	}
+	if q.RowLimit <= 0 {
+		q.RowLimit = DefaultRowLimit
+	}
+	q.RowLimit = min(q.RowLimit, MaxRowLimit)
+	return q, nil
+}
+
+// SearchAnalytics sorguyu çalıştırır. Önbellekte taze sonuç varsa cached=true ile onu döner; yoksa
+// satırlar RowLimit'e veya son sayfaya kadar sayfalanarak alınır ve önbelleğe yazılır.
+func (c *Client) SearchAnalytics(ctx context.Context, property string, q Query) (rows []Row, cached bool, err error) {
+	property = NormalizeProperty(property)
+	if property == "" {
+		return nil, false, fmt.Errorf("property URL gerekli")
+	}
+	if q, err = q.validate(); err != nil {
+		return nil, false, err
+	}
+	path := c.cachePath(property, q)
+	if e, ok := c.load(path, time.Now()); ok && !q.Refresh {
+		return e.Rows, true, nil
+	}
+
+	rows = []Row{}
+	for len(rows) < q.RowLimit {
+		size := min(c.cfg.PageSize, q.RowLimit-len(rows))
+		page, err := c.fetchPage(ctx, property, q, len(rows), size)
+		if err != nil {
+			return nil, false, err
+		}
+		rows = append(rows, page...)
+		if len(page) < size {
+			break
+		}
+	}
+	if path != "" {
+		c.save(path, cacheEntry{Property: property, StartDate: q.StartDate, EndDate: q.EndDate,
+			Dimensions: q.Dimensions, FetchedAt: time.Now(), Rows: rows})
+	}
+	return rows, false, nil
+}
+
+// fetchPage startRow'dan itibaren en fazla size satır ister; token reddedilirse bir kez yenileyip dener
+func (c *Client) fetchPage(ctx context.Context, property string, q Query, startRow, size int) ([]Row, error) {
+	body, _ := json.Marshal(map[string]interface{}{
+		"startDate":  q.StartDate,
+		"endDate":    q.EndDate,
+		"dimensions": q.Dimensions,
+		"rowLimit":   size,
+		"startRow":   startRow,
+	})
+	apiURL := fmt.Sprintf("%s/sites/%s/searchAnalytics/query", APIBase, url.QueryEscape(property))
+
+	for attempt := 0; ; attempt++ {
+		token, err := c.tokens.Token(ctx)
+		if err != nil {
+			return nil, err
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiURL, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := c.http.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode == http.StatusUnauthorized && attempt == 0 {
+			// Token iptal edilmiş veya süresi erken dolmuş olabilir
+			resp.Body.Close()
+			c.tokens.Invalidate()
+			continue
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			bodyBytes, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+			return nil, fmt.Errorf("GSC API hatası (%d): %s", resp.StatusCode, strings.TrimSpace(string(bodyBytes)))
+		}
+		return decodeRows(resp.Body, q.Dimensions)
+	}
+}
+
+// decodeRows API yanıtındaki satırları boyut sırasına göre Row'a çevirir
+func decodeRows(r io.Reader, dims []string) ([]Row, error) {
+	var resp struct {
+		Rows []struct {
+			Keys        []string `json:"keys"`
+			Clicks      float64  `json:"clicks"`
+			Impressions float64  `json:"impressions"`
+			CTR         float64  `json:"ctr"`
+			Position    float64  `json:"position"`
+		} `json:"rows"`
+	}
+	if err := json.NewDecoder(r).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("GSC yanıt parse hatası: %w", err)
+	}
+	rows := make([]Row, 0, len(resp.Rows))
+	for _, raw := range resp.Rows {
+		row := Row{Clicks: int(raw.Clicks), Impressions: int(raw.Impressions), CTR: raw.CTR, Position: raw.Position}
+		for i, key := range raw.Keys {
+			if i >= len(dims) {
+				break
+			}
+			switch dims[i] {
+			case DimensionQuery:
+				row.Query = key
+			case DimensionPage:
+				row.Page = key
+			case DimensionCountry:
+				row.Country = key
+			case DimensionDevice:
+				row.Device = key
+			case DimensionDate:
+				row.Date = key
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// ============================================================================
+// DİSK ÖNBELLEĞİ
+// ============================================================================
+
+type cacheEntry struct {
+	Property   string    `json:"property"`
+	StartDate  string    `json:"start_date"`
+	EndDate    string    `json:"end_date"`
+	Dimensions []string  `json:"dimensions"`
+	FetchedAt  time.Time `json:"fetched_at"`
+	Rows       []Row     `json:"rows"`
+}
+
+// unsafeFileChars önbellek dosya adında kullanılmayan karakterler
+var unsafeFileChars = regexp.MustCompile(`[^a-zA-Z0-9.-]+`)
+
+// cachePath property, tarih aralığı, boyutlar ve satır sınırına ait önbellek dosyası (önbellek kapalıysa "").
+// Dosya adı okunabilir olsun diye property ve tarihleri içerir; çakışmayı özet önler.
+func (c *Client) cachePath(property string, q Query) string {
+	if c.cfg.CacheDir == "" || c.cfg.CacheTTL <= 0 {
+		return ""
+	}
+	key := fmt.Sprintf("%s|%s|%s|%s|%d", property, q.StartDate, q.EndDate, strings.Join(q.Dimensions, ","), q.RowLimit)
+	sum := sha256.Sum256([]byte(key))
+	name := strings.Trim(unsafeFileChars.ReplaceAllString(property, "_"), "_")
+	return filepath.Join(c.cfg.CacheDir, fmt.Sprintf("%s_%s_%s_%s.json", name, q.StartDate, q.EndDate, hex.EncodeToString(sum[:6])))
+}
+
+func (c *Client) load(path string, now time.Time) (cacheEntry, bool) {
+	var e cacheEntry
+	if path == "" {
+		return e, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || json.Unmarshal(data, &e) != nil {
+		return e, false
+	}
+	return e, now.Sub(e.FetchedAt) <= c.cfg.CacheTTL
+}
+
+// save girdiyi geçici dosyaya yazıp yerine taşır (yarım yazılmış dosya okunmaz); hata önbelleği atlatır
+func (c *Client) save(path string, e cacheEntry) {
+	data, err := json.Marshal(e)
+	if err != nil || os.MkdirAll(c.cfg.CacheDir, 0755) != nil {
+		return
+	}
+	tmp := path + ".tmp"
+	if os.WriteFile(tmp, data, 0644) != nil {
+		return
+	}
+	if os.Rename(tmp, path) != nil {
+		os.Remove(tmp)
+	}
+}
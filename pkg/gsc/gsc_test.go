@@ -0,0 +1,128 @@
+package gsc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"vgbot/pkg/googleauth"
+)
+
+// testAccount token uç noktası sahte sunucuya yönlendirilmiş Service Account; her token isteğinde
+// sırayla tok1, tok2... verilir
+func testAccount(t *testing.T, tokens *int) *googleauth.ServiceAccount {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, _ := x509.MarshalPKCS8PrivateKey(key)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*tokens++
+		fmt.Fprintf(w, `{"access_token":"tok%d","expires_in":3600}`, *tokens)
+	}))
+	t.Cleanup(srv.Close)
+	old := googleauth.TokenURL
+	googleauth.TokenURL = srv.URL
+	t.Cleanup(func() { googleauth.TokenURL = old })
+	return &googleauth.ServiceAccount{Type: "service_account", ClientEmail: "sa@example.iam.gserviceaccount.com",
+		PrivateKey: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))}
+}
+
+func TestSearchAnalyticsPaginatesAndCaches(t *testing.T) {
+	var tokens, calls int
+	var starts []int
+	rejected := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.EscapedPath() != "/sites/sc-domain%3Aexample.com/searchAnalytics/query" {
+			t.Errorf("path %s", r.URL.EscapedPath())
+		}
+		// İlk istekte token reddedilir: istemci yeni token alıp tekrar denemeli
+		if !rejected {
+			rejected = true
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer tok2" {
+			t.Errorf("auth %q", r.Header.Get("Authorization"))
+		}
+		var req struct {
+			Dimensions []string `json:"dimensions"`
+			RowLimit   int      `json:"rowLimit"`
+			StartRow   int      `json:"startRow"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		starts = append(starts, req.StartRow)
+		var rows []map[string]interface{}
+		for i := req.StartRow; i < min(req.StartRow+req.RowLimit, 5); i++ {
+			rows = append(rows, map[string]interface{}{
+				"keys":   []string{fmt.Sprintf("/p%d", i), fmt.Sprintf("q%d", i), "tur"},
+				"clicks": float64(i), "impressions": 10.0, "ctr": 0.1, "position": 2.5,
+			})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"rows": rows})
+	}))
+	defer srv.Close()
+	defer func(u string) { APIBase = u }(APIBase)
+	APIBase = srv.URL
+
+	c := NewClient(testAccount(t, &tokens), Config{CacheDir: t.TempDir(), CacheTTL: time.Hour, PageSize: 2})
+	q := Query{StartDate: "2026-09-01", EndDate: "2026-09-28", Dimensions: []string{"page", "Query", "country"}, RowLimit: 10}
+	rows, cached, err := c.SearchAnalytics(context.Background(), "example.com", q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cached || len(rows) != 5 || fmt.Sprint(starts) != "[0 2 4]" || tokens != 2 {
+		t.Fatalf("cached %v rows %d starts %v tokens %d", cached, len(rows), starts, tokens)
+	}
+	if r := rows[3]; r.Page != "/p3" || r.Query != "q3" || r.Country != "tur" || r.Clicks != 3 {
+		t.Errorf("row = %+v", r)
+	}
+
+	// Aynı property + tarih aralığı önbellekten gelir; başka aralık veya refresh API'ye gider
+	calls = 0
+	if rows, cached, err = c.SearchAnalytics(context.Background(), "sc-domain:example.com", q); err != nil || !cached || len(rows) != 5 || calls != 0 {
+		t.Errorf("cached call: cached %v rows %d calls %d err %v", cached, len(rows), calls, err)
+	}
+	q.EndDate = "2026-09-27"
+	if _, cached, _ = c.SearchAnalytics(context.Background(), "example.com", q); cached || calls == 0 {
+		t.Error("different date range served from cache")
+	}
+	q.Refresh, calls = true, 0
+	if _, cached, _ = c.SearchAnalytics(context.Background(), "example.com", q); cached || calls == 0 {
+		t.Error("refresh served from cache")
+	}
+	if tokens != 2 {
+		t.Errorf("token exchanged %d times, want 2 (cached between requests)", tokens)
+	}
+}
+
+func TestQueryValidate(t *testing.T) {
+	for _, q := range []Query{
+		{StartDate: "2026-09-28", EndDate: "2026-09-01"},
+		{StartDate: "2026-09-01", EndDate: "2026-09-28", Dimensions: []string{"city"}},
+		{StartDate: "2026-09-01", EndDate: "2026-09-28", Dimensions: []string{"query", "query"}},
+	} {
+		if _, err := q.validate(); err == nil {
+			t.Errorf("validate(%+v): expected error", q)
+		}
+	}
+	q, err := Query{StartDate: "2026-09-01", EndDate: "2026-09-28", RowLimit: 1 << 30}.validate()
+	if err != nil || q.RowLimit != MaxRowLimit || len(q.Dimensions) != 1 || q.Dimensions[0] != DimensionQuery {
+		t.Errorf("defaults = %+v, %v", q, err)
+	}
+	if p := NormalizeProperty(" eros.sh/ "); p != "sc-domain:eros.sh" {
+		t.Errorf("NormalizeProperty = %q", p)
+	}
+	if start, end := LastDays(0, time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)); start != "2026-09-19" || end != "2026-10-16" {
+		t.Errorf("LastDays = %s - %s", start, end)
+	}
+}
@@ -23,6 +23,7 @@ const (
 	MsgReportHTML      = "report_html"
 	MsgSitemapFound    = "sitemap_found"
 	MsgSitemapNone     = "sitemap_none"
+	MsgSitemapCached   = "sitemap_cached"
 	// v2.2.0 - New messages
 	MsgProxyFetch      = "proxy_fetch"
 	MsgProxyFetchErr   = "proxy_fetch_err"
@@ -139,6 +140,82 @@ const (
 	MsgWebInterface = "web_interface"
 	MsgOpenBrowser  = "open_browser"
 	MsgStopHint     = "stop_hint"
+	// v3.1.0 - Time window messages
+	MsgWindowPlan    = "window_plan"
+	MsgWindowInvalid = "window_invalid"
+	MsgWindowPaused  = "window_paused"
+	MsgWindowResumed = "window_resumed"
+	// v3.1.0 - Reproducible run
+	MsgRunSeed = "run_seed"
+	// v3.1.0 - Replay
+	MsgReplayStart    = "replay_start"
+	MsgReplaySeed     = "replay_seed"
+	MsgReplayProgress = "replay_progress"
+	// v3.1.0 - CLI seed/replay flags
+	MsgCLIFlagSeed   = "cli_flag_seed"
+	MsgCLIFlagReplay = "cli_flag_replay"
+	MsgCLIFlagDryRun = "cli_flag_dry_run"
+	MsgCLIFlagAssert = "cli_flag_assert"
+	MsgDryRunPlan    = "dry_run_plan"
+	// v3.1.0 - Hot proxy add
+	MsgProxyHotAdd = "proxy_hot_add"
+	// v3.1.0 - Proxy usage limits
+	MsgProxyLimits = "proxy_limits"
+	MsgProxyAllLimited = "proxy_all_limited"
+	MsgBrowserPool      = "browser_pool"
+	MsgBrowserPoolSkip  = "browser_pool_skip"
+	// v3.1.0 - Proxy health scoring
+	MsgProxyHealth      = "proxy_health"
+	MsgProxyQuarantined = "proxy_quarantined"
+	MsgProxyBlacklisted = "proxy_blacklisted"
+	// v3.1.0 - Security audit
+	MsgSecurityAudit = "security_audit"
+	// v3.1.0 - Multi-property GA4
+	MsgGA4Split = "ga4_split"
+	MsgGA4EventMapping = "ga4_event_mapping"
+	MsgGA4ServerEndpoint = "ga4_server_endpoint"
+	MsgGA4TrafficType = "ga4_traffic_type"
+	MsgAnalyticsBackend = "analytics_backend"
+	// v3.1.0 - BigQuery export
+	MsgBigQueryEnabled = "bigquery_enabled"
+	MsgBigQueryError   = "bigquery_error"
+	MsgBigQueryDone    = "bigquery_done"
+	// v3.1.0 - JSON Lines hit log
+	MsgHitLogEnabled = "hit_log_enabled"
+	MsgHitLogError   = "hit_log_error"
+	MsgSessionLogEnabled = "session_log_enabled"
+	MsgSessionLogError   = "session_log_error"
+	// v3.1.0 - Closed-loop load test
+	MsgClosedLoopPlan = "closed_loop_plan"
+	MsgClosedLoopPool = "closed_loop_pool"
+	MsgLoadLevel      = "load_level"
+	MsgLoadStage      = "load_stage"
+	MsgLoadProfile    = "load_profile"
+	MsgSLAAssertion   = "sla_assertion"
+	MsgSLAPassed      = "sla_passed"
+	MsgSLAFailed      = "sla_failed"
+	MsgCLISLAExit     = "cli_sla_exit"
+	// v3.1.0 - HTTP/3
+	MsgHTTP3Enabled = "http3_enabled"
+	MsgHTTP3Proxy   = "http3_proxy"
+	// v3.1.0 - Warm-up
+	MsgWarmup = "warmup"
+	// v3.1.0 - Content checks
+	MsgContentChecks = "content_checks"
+	// v3.1.0 - Traffic marker
+	MsgTrafficMarker = "traffic_marker"
+	// v3.1.0 - Landing page mix
+	MsgLandingMix = "landing_mix"
+	// v3.1.0 - A/B experiment split
+	MsgExperimentSplit = "experiment_split"
+	// v3.1.0 - Terms of use & target whitelist
+	MsgTermsOfUse           = "terms_of_use"
+	MsgTermsAcceptPrompt    = "terms_accept_prompt"
+	MsgTermsDeclined        = "terms_declined"
+	MsgTargetNotWhitelisted = "target_not_whitelisted"
+	MsgTargetConfirmPrompt  = "target_confirm_prompt"
+	MsgTargetNotConfirmed   = "target_not_confirmed"
+	MsgTargetConfirmed      = "target_confirmed"
 )
 
 var tr = map[string]string{
@@ -161,6 +238,7 @@ var tr = map[string]string{
 	MsgReportHTML:      "HTML rapor: %s",
 	MsgSitemapFound:    "Sitemap bulundu: %d URL (anasayfa ağırlığı %%%d)",
 	MsgSitemapNone:     "Sitemap bulunamadı, sayfa keşfi kullanılıyor.",
+	MsgSitemapCached:   "Sitemap önbellekten okundu (%d saatte bir yenilenir)",
 	// v2.2.0 - New messages
 	MsgProxyFetch:      "Proxy listeleri çekiliyor...",
 	MsgProxyFetchErr:   "Proxy çekme hatası: %s",
@@ -277,6 +355,86 @@ var tr = map[string]string{
 	MsgWebInterface: "VGBot - Web Arayüzü",
 	MsgOpenBrowser:  "Tarayıcınızda açın: %s",
 	MsgStopHint:     "Durdurmak için Ctrl+C",
+	// v3.1.0 - Time window messages
+	MsgWindowPlan:    "🕒 Zaman penceresi: %s",
+	MsgWindowInvalid: "⚠️ Geçersiz zaman penceresi, kısıt uygulanmıyor: %v",
+	MsgWindowPaused:  "⏸ Zaman penceresi dışında, %s saatine kadar bekleniyor",
+	MsgWindowResumed: "▶ Zaman penceresi açıldı, trafik devam ediyor",
+	// v3.1.0 - Reproducible run
+	MsgRunSeed: "🎲 Seed: %d (aynı seed ile çalıştırma tekrarlanabilir)",
+	// v3.1.0 - Replay
+	MsgReplayStart:    "🔁 Replay: %s (%d hit, %s)",
+	MsgReplaySeed:     "🎲 Kaynak çalıştırmanın seed'i: %d",
+	MsgReplayProgress: "🔁 Replay ilerleme: %d/%d",
+	// v3.1.0 - CLI seed/replay flags
+	MsgCLIFlagSeed:   "-seed          : RNG seed (aynı seed = aynı çalıştırma)",
+	MsgCLIFlagReplay: "-replay        : Önceki raporu tekrar oynat (JSON/CSV)",
+	MsgCLIFlagDryRun: "-dry-run       : Planı yazdır, sayfa isteği yapma",
+	MsgCLIFlagAssert: "-assert        : SLA koşulu (ör. \"p95_latency<800ms\"), tekrarlanabilir; sağlanmazsa çıkış kodu 2",
+	MsgDryRunPlan:    "🧪 Dry-run planı (sayfa isteği yapılmadı):",
+	// v3.1.0 - Hot proxy add
+	MsgProxyHotAdd: "➕ Çalışma sırasında %d proxy eklendi (%d geçersiz, %d ölü) — havuz: %d",
+	// v3.1.0 - Proxy usage limits
+	MsgProxyLimits: "🚦 Proxy sınırları: saatte en fazla %d ziyaret, %d art arda kullanımdan sonra %d dk dinlenme (0 = sınırsız)",
+	MsgProxyAllLimited: "⏳ Tüm proxy'ler saatlik sınırda veya dinlenmede; uygun proxy bekleniyor (havuz: %d)",
+	MsgBrowserPool:      "🔥 Tarayıcı havuzu: %d-%d sıcak Chrome (otomatik ölçekleme: %v), instance %d ziyaret veya %d dk sonra yenilenir",
+	MsgBrowserPoolSkip:  "ℹ️ Tarayıcı havuzu kullanılmıyor: proxy havuzu, geri dönen ziyaretçi ve giriş profilleri ziyaret başına ayrı Chrome süreci ister",
+	// v3.1.0 - Proxy health scoring
+	MsgProxyHealth:      "🩺 Proxy sağlık skoru: %d altına düşen proxy %d dk karantinaya, %d. karantinada kara listeye alınır",
+	MsgProxyQuarantined: "🩺 Proxy karantinada: %s (%d dk)",
+	MsgProxyBlacklisted: "⛔ Proxy kara listede: %s (tekrarlayan karantina)",
+	// v3.1.0 - Security audit
+	MsgSecurityAudit: "🔒 Güvenlik denetimi: %s, sertifika %d gün geçerli, bulgular: %s",
+	// v3.1.0 - Multi-property GA4
+	MsgGA4Split: "📊 GA4 mülk dağılımı: %s",
+	MsgGA4EventMapping: "📊 GA4 event eşlemesi: %s",
+	MsgGA4ServerEndpoint: "📊 GA4 sunucu taraflı uç nokta (sGTM): %s",
+	MsgGA4TrafficType: "🏷️ GA4 event'leri traffic_type=%s ile etiketleniyor (GA4 iç trafik filtresiyle ayrılabilir)",
+	MsgAnalyticsBackend: "📊 Analytics backend: %s (sayfadaki izleyici yoksa page view HTTP API ile gönderilir; gtag enjekte edilmez)",
+	// v3.1.0 - BigQuery export
+	MsgBigQueryEnabled: "📤 BigQuery export aktif: %s",
+	MsgBigQueryError:   "⚠️ BigQuery export hatası: %v",
+	MsgBigQueryDone:    "📤 BigQuery: %d satır yazıldı, %d satır başarısız",
+	// v3.1.0 - JSON Lines hit log
+	MsgHitLogEnabled: "📝 Hit log (JSON Lines): %s",
+	MsgHitLogError:   "⚠️ Hit log hatası: %v",
+	MsgSessionLogEnabled: "📝 Oturum logu (JSON Lines): %s",
+	MsgSessionLogError:   "⚠️ Oturum logu hatası: %v",
+	// v3.1.0 - Closed-loop load test
+	MsgClosedLoopPlan: "🔁 Closed-loop yük testi: %s (HPM sınırı uygulanmaz)",
+	MsgClosedLoopPool: "⚠️ Closed-loop modu public proxy havuzuyla desteklenmiyor; HPM tabanlı mod kullanılıyor",
+	MsgLoadLevel:      "📈 %s: %d hit (%d başarılı), %.1f hit/dk, ort. %.0f ms, p95 %d ms",
+	MsgLoadStage:      "📶 Yük aşaması %s: %d %s, %s",
+	MsgLoadProfile:    "📶 Yük profili: %s",
+	MsgSLAAssertion:   "   %s %s (ölçülen %.1f%s)",
+	MsgSLAPassed:      "✅ SLA geçti: %d/%d koşul",
+	MsgSLAFailed:      "❌ SLA başarısız: %d/%d koşul geçti",
+	MsgCLISLAExit:     "SLA koşulları sağlanmadı, çıkış kodu %d",
+	// v3.1.0 - HTTP/3
+	MsgHTTP3Enabled: "⚡ HTTP/3 (QUIC): tarayıcısız istekler önce QUIC dener, desteklemeyen host'ta HTTP/2'ye düşer",
+	MsgHTTP3Proxy:   "⚠️ HTTP/3 proxy üzerinden kullanılamaz; tarayıcısız istekler HTTP/2 ile gider",
+	// v3.1.0 - Warm-up
+	MsgWarmup: "🔥 Isınma: ilk %v içindeki hit'ler istatistik ve SLA dışında tutulur",
+	// v3.1.0 - Content checks
+	MsgContentChecks: "🧪 İçerik kontrolleri (eşleşen ziyaretlerin %%%d'i): %s",
+	// v3.1.0 - Traffic marker
+	MsgTrafficMarker: "🏷 Trafik işareti: %s (analytics'te bu işaretle filtrelenebilir)",
+	// v3.1.0 - Landing page mix
+	MsgLandingMix: "🛬 Giriş sayfası dağılımı: %s",
+	// v3.1.0 - A/B experiment split
+	MsgExperimentSplit: "🧪 A/B deney bölmesi: %s (GA event'leri experiment_variant ile etiketlenir)",
+	// v3.1.0 - Terms of use & target whitelist
+	MsgTermsOfUse: "VGBot yalnızca sahibi olduğunuz veya test etme yetkiniz bulunan sitelerde performans, SEO ve analytics testi için kullanılabilir. Devam ederek:\n" +
+		"- Üçüncü taraf sitelere, reklamlara veya ücretli tıklama kampanyalarına yapay trafik göndermeyeceğinizi,\n" +
+		"- Analytics verilerini yanıltmak veya hizmet kesintisine yol açmak amacıyla kullanmayacağınızı,\n" +
+		"- Hedef sitelerin kullanım koşullarına ve yürürlükteki mevzuata uyacağınızı\n" +
+		"kabul edersiniz. Onayınız ve izin listesine eklenen her domain denetim kaydına yazılır.",
+	MsgTermsAcceptPrompt:    "Kullanım koşullarını kabul ediyorsanız 'kabul' yazın: ",
+	MsgTermsDeclined:        "Kullanım koşulları kabul edilmedi; çalıştırma başlatılmadı.",
+	MsgTargetNotWhitelisted: "⚠️ %s izin listesinde değil. Yalnızca kontrol ettiğiniz domain'lere trafik gönderilebilir.",
+	MsgTargetConfirmPrompt:  "%s domain'ini kontrol ettiğinizi onaylamak için domain'i aynen yazın: ",
+	MsgTargetNotConfirmed:   "Domain onaylanmadı; çalıştırma başlatılmadı.",
+	MsgTargetConfirmed:      "✅ %s izin listesine eklendi (%s)",
 }
 
 var en = map[string]string{
@@ -299,6 +457,7 @@ var en = map[string]string{
 	MsgReportHTML:      "HTML report: %s",
 	MsgSitemapFound:    "Sitemap found: %d URLs (homepage weight %d%%)",
 	MsgSitemapNone:     "Sitemap not found, using page discovery.",
+	MsgSitemapCached:   "Sitemap loaded from cache (refreshed every %d hours)",
 	// v2.2.0 - New messages
 	MsgProxyFetch:      "Fetching proxy lists...",
 	MsgProxyFetchErr:   "Proxy fetch error: %s",
@@ -415,6 +574,86 @@ var en = map[string]string{
 	MsgWebInterface: "VGBot - Web Interface",
 	MsgOpenBrowser:  "Open in browser: %s",
 	MsgStopHint:     "Press Ctrl+C to stop",
+	// v3.1.0 - Time window messages
+	MsgWindowPlan:    "🕒 Time window: %s",
+	MsgWindowInvalid: "⚠️ Invalid time window, no restriction applied: %v",
+	MsgWindowPaused:  "⏸ Outside time window, waiting until %s",
+	MsgWindowResumed: "▶ Time window open, resuming traffic",
+	// v3.1.0 - Reproducible run
+	MsgRunSeed: "🎲 Seed: %d (reuse this seed to replay the run)",
+	// v3.1.0 - Replay
+	MsgReplayStart:    "🔁 Replay: %s (%d hits, %s)",
+	MsgReplaySeed:     "🎲 Source run seed: %d",
+	MsgReplayProgress: "🔁 Replay progress: %d/%d",
+	// v3.1.0 - CLI seed/replay flags
+	MsgCLIFlagSeed:   "-seed          : RNG seed (same seed = same run)",
+	MsgCLIFlagReplay: "-replay        : Replay a previous report (JSON/CSV)",
+	MsgCLIFlagDryRun: "-dry-run       : Print the plan without making page requests",
+	MsgCLIFlagAssert: "-assert        : SLA assertion (e.g. \"p95_latency<800ms\"), repeatable; exit code 2 if not met",
+	MsgDryRunPlan:    "🧪 Dry-run plan (no page requests made):",
+	// v3.1.0 - Hot proxy add
+	MsgProxyHotAdd: "➕ %d proxies added mid-run (%d invalid, %d dead) — pool: %d",
+	// v3.1.0 - Proxy usage limits
+	MsgProxyLimits: "🚦 Proxy limits: max %d visits/hour, rest after %d consecutive uses for %d min (0 = unlimited)",
+	MsgProxyAllLimited: "⏳ All proxies are at their hourly cap or cooling down; waiting for one to free up (pool: %d)",
+	MsgBrowserPool:      "🔥 Browser pool: %d-%d warm Chrome instances (auto-scaling: %v), each recycled after %d visits or %d min",
+	MsgBrowserPoolSkip:  "ℹ️ Browser pool not used: proxy pools, returning-visitor and login profiles need a separate Chrome process per visit",
+	// v3.1.0 - Proxy health scoring
+	MsgProxyHealth:      "🩺 Proxy health scoring: proxies scoring below %d are quarantined for %d min and blacklisted on quarantine #%d",
+	MsgProxyQuarantined: "🩺 Proxy quarantined: %s (%d min)",
+	MsgProxyBlacklisted: "⛔ Proxy blacklisted: %s (repeated quarantine)",
+	// v3.1.0 - Security audit
+	MsgSecurityAudit: "🔒 Security audit: %s, certificate valid for %d days, findings: %s",
+	// v3.1.0 - Multi-property GA4
+	MsgGA4Split: "📊 GA4 property split: %s",
+	MsgGA4EventMapping: "📊 GA4 event mapping: %s",
+	MsgGA4ServerEndpoint: "📊 GA4 server-side endpoint (sGTM): %s",
+	MsgGA4TrafficType: "🏷️ GA4 events labeled traffic_type=%s (can be separated with a GA4 internal traffic filter)",
+	MsgAnalyticsBackend: "📊 Analytics backend: %s (page views go through the HTTP API when the page has no tracker; gtag is not injected)",
+	// v3.1.0 - BigQuery export
+	MsgBigQueryEnabled: "📤 BigQuery export enabled: %s",
+	MsgBigQueryError:   "⚠️ BigQuery export error: %v",
+	MsgBigQueryDone:    "📤 BigQuery: %d rows written, %d rows failed",
+	// v3.1.0 - JSON Lines hit log
+	MsgHitLogEnabled: "📝 Hit log (JSON Lines): %s",
+	MsgHitLogError:   "⚠️ Hit log error: %v",
+	MsgSessionLogEnabled: "📝 Session log (JSON Lines): %s",
+	MsgSessionLogError:   "⚠️ Session log error: %v",
+	// v3.1.0 - Closed-loop load test
+	MsgClosedLoopPlan: "🔁 Closed-loop load test: %s (HPM limit not applied)",
+	MsgClosedLoopPool: "⚠️ Closed-loop mode is not supported with the public proxy pool; using HPM-based mode",
+	MsgLoadLevel:      "📈 %s: %d hits (%d successful), %.1f hits/min, avg %.0f ms, p95 %d ms",
+	MsgLoadStage:      "📶 Load stage %s: %d %s, %s",
+	MsgLoadProfile:    "📶 Load profile: %s",
+	MsgSLAAssertion:   "   %s %s (measured %.1f%s)",
+	MsgSLAPassed:      "✅ SLA passed: %d/%d assertions",
+	MsgSLAFailed:      "❌ SLA failed: %d/%d assertions passed",
+	MsgCLISLAExit:     "SLA assertions not met, exit code %d",
+	// v3.1.0 - HTTP/3
+	MsgHTTP3Enabled: "⚡ HTTP/3 (QUIC): non-browser requests try QUIC first and fall back to HTTP/2 on hosts without it",
+	MsgHTTP3Proxy:   "⚠️ HTTP/3 cannot be used through a proxy; non-browser requests use HTTP/2",
+	// v3.1.0 - Warm-up
+	MsgWarmup: "🔥 Warm-up: hits in the first %v are excluded from statistics and SLA assertions",
+	// v3.1.0 - Content checks
+	MsgContentChecks: "🧪 Content checks (%d%% of matching visits): %s",
+	// v3.1.0 - Traffic marker
+	MsgTrafficMarker: "🏷 Traffic marker: %s (filter it out in analytics views)",
+	// v3.1.0 - Landing page mix
+	MsgLandingMix: "🛬 Landing page mix: %s",
+	// v3.1.0 - A/B experiment split
+	MsgExperimentSplit: "🧪 A/B experiment split: %s (GA events tagged with experiment_variant)",
+	// v3.1.0 - Terms of use & target whitelist
+	MsgTermsOfUse: "VGBot may only be used for performance, SEO and analytics testing of sites you own or are authorized to test. By continuing you agree:\n" +
+		"- not to send synthetic traffic to third-party sites, ads or paid click campaigns,\n" +
+		"- not to use it to mislead analytics data or cause service disruption,\n" +
+		"- to comply with the target sites' terms and applicable law.\n" +
+		"Your acknowledgment and every domain added to the whitelist are written to the audit log.",
+	MsgTermsAcceptPrompt:    "Type 'accept' to accept the terms of use: ",
+	MsgTermsDeclined:        "Terms of use not accepted; the run was not started.",
+	MsgTargetNotWhitelisted: "⚠️ %s is not on the whitelist. Traffic can only be sent to domains you control.",
+	MsgTargetConfirmPrompt:  "To confirm you control %s, type the domain exactly: ",
+	MsgTargetNotConfirmed:   "Domain not confirmed; the run was not started.",
+	MsgTargetConfirmed:      "✅ %s added to the whitelist (%s)",
 }
 
 // T locale'e göre mesajı çevirir ve formatlar
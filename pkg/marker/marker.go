@@ -0,0 +1,94 @@
+// Package marker simüle edilen trafiği site sahiplerinin analytics'te filtreleyebilmesi için
+// işaretler: her navigasyona sorgu parametresi (?vgbot=1) ve/veya hedef domaine çerez eklenir.
+package marker
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Modlar
+const (
+	ModeQuery  = "query"  // Navigasyon URL'lerine sorgu parametresi
+	ModeCookie = "cookie" // Hedef domaine çerez
+	ModeBoth   = "both"   // İkisi birden
+)
+
+// tokenRe işaret adı/değeri: URL ve çerezde kaçış gerektirmeyen karakterler
+var tokenRe = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// Marker trafik işareti; nil Marker hiçbir şey eklemez
+type Marker struct {
+	Name   string
+	Value  string
+	Query  bool
+	Cookie bool
+}
+
+// Parse "ad=değer" işaretini moda göre çözer; spec boşsa nil döner (işaret kapalı)
+func Parse(spec, mode string) (*Marker, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return nil, nil
+	}
+	name, value, _ := strings.Cut(spec, "=")
+	name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+	if value == "" {
+		value = "1"
+	}
+	if !tokenRe.MatchString(name) || !tokenRe.MatchString(value) {
+		return nil, fmt.Errorf("geçersiz trafik işareti %q (ör. vgbot=1)", spec)
+	}
+	m := &Marker{Name: name, Value: value}
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case "", ModeQuery:
+		m.Query = true
+	case ModeCookie:
+		m.Cookie = true
+	case ModeBoth:
+		m.Query, m.Cookie = true, true
+	default:
+		return nil, fmt.Errorf("geçersiz trafik işareti modu %q (query, cookie, both)", mode)
+	}
+	return m, nil
+}
+
+// ApplyURL Query modunda URL'ye işaret parametresini ekler (varsa değiştirir)
+func (m *Marker) ApplyURL(raw string) string {
+	if m == nil || !m.Query {
+		return raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+	q := u.Query()
+	q.Set(m.Name, m.Value)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// CookieValue Cookie modunda "ad=değer" döner, aksi halde ""
+func (m *Marker) CookieValue() string {
+	if m == nil || !m.Cookie {
+		return ""
+	}
+	return m.Name + "=" + m.Value
+}
+
+// String rapor için işaretin okunur açıklaması (ör. "query ?vgbot=1, cookie vgbot=1")
+func (m *Marker) String() string {
+	if m == nil {
+		return ""
+	}
+	var parts []string
+	if m.Query {
+		parts = append(parts, "query ?"+m.Name+"="+m.Value)
+	}
+	if m.Cookie {
+		parts = append(parts, "cookie "+m.Name+"="+m.Value)
+	}
+	return strings.Join(parts, ", ")
+}
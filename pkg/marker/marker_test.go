@@ -0,0 +1,47 @@
+package marker
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	if m, err := Parse("", "query"); m != nil || err != nil {
+		t.Fatalf("empty = %v, %v", m, err)
+	}
+	m, err := Parse("vgbot", "both")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Name != "vgbot" || m.Value != "1" || !m.Query || !m.Cookie {
+		t.Fatalf("marker = %+v", m)
+	}
+	if got := m.String(); got != "query ?vgbot=1, cookie vgbot=1" {
+		t.Errorf("String = %q", got)
+	}
+	for _, bad := range [][2]string{{"vg bot=1", ""}, {"=1", ""}, {"vgbot=<b>", ""}, {"vgbot=1", "header"}} {
+		if _, err := Parse(bad[0], bad[1]); err == nil {
+			t.Errorf("%q/%q accepted", bad[0], bad[1])
+		}
+	}
+}
+
+func TestApplyURL(t *testing.T) {
+	m, _ := Parse("vgbot=1", "")
+	if got := m.ApplyURL("https://example.com/p?a=2#top"); got != "https://example.com/p?a=2&vgbot=1#top" {
+		t.Errorf("ApplyURL = %q", got)
+	}
+	if got := m.CookieValue(); got != "" {
+		t.Errorf("query-only CookieValue = %q", got)
+	}
+
+	c, _ := Parse("vgbot=1", "cookie")
+	if got := c.ApplyURL("https://example.com/"); got != "https://example.com/" {
+		t.Errorf("cookie-only ApplyURL = %q", got)
+	}
+	if got := c.CookieValue(); got != "vgbot=1" {
+		t.Errorf("CookieValue = %q", got)
+	}
+
+	var off *Marker
+	if got := off.ApplyURL("https://example.com/"); got != "https://example.com/" {
+		t.Errorf("nil ApplyURL = %q", got)
+	}
+}
@@ -0,0 +1,91 @@
+package marker
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Split A/B deney bölmesi: her oturum ağırlığa göre bir varyanta atanır ve varyant,
+// deney parametresiyle (çerez ve/veya sorgu parametresi) siteye iletilir
+type Split struct {
+	Param    string
+	query    bool
+	cookie   bool
+	variants []string
+	cum      []int // Kümülatif ağırlıklar
+	total    int
+}
+
+// ParseSplit "varyant=ağırlık" girdilerini çözer (ağırlık yoksa 1); param veya varyant yoksa nil döner
+func ParseSplit(param, mode string, variants []string) (*Split, error) {
+	param = strings.TrimSpace(param)
+	if param == "" || len(variants) == 0 {
+		return nil, nil
+	}
+	// Mod doğrulaması ve query/cookie bayrakları Parse ile aynı
+	probe, err := Parse(param+"=x", mode)
+	if err != nil {
+		return nil, err
+	}
+	s := &Split{Param: probe.Name, query: probe.Query, cookie: probe.Cookie}
+	seen := map[string]bool{}
+	for _, v := range variants {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		name, weight := v, 1
+		if n, w, ok := strings.Cut(v, "="); ok {
+			wi, err := strconv.Atoi(strings.TrimSpace(w))
+			if err != nil || wi < 0 {
+				return nil, fmt.Errorf("geçersiz varyant ağırlığı %q (ör. b=50)", v)
+			}
+			name, weight = strings.TrimSpace(n), wi
+		}
+		if !tokenRe.MatchString(name) {
+			return nil, fmt.Errorf("geçersiz varyant adı %q", name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("varyant iki kez tanımlı: %s", name)
+		}
+		seen[name] = true
+		if weight == 0 {
+			continue
+		}
+		s.total += weight
+		s.variants = append(s.variants, name)
+		s.cum = append(s.cum, s.total)
+	}
+	if s.total == 0 {
+		return nil, fmt.Errorf("deney için en az bir varyant gerekli (ör. control=50, b=50)")
+	}
+	return s, nil
+}
+
+// Pick ağırlığa göre bir varyant seçer ve onu taşıyan işareti döner; intn rand.Intn benzeri olmalı
+func (s *Split) Pick(intn func(n int) int) (string, *Marker) {
+	if s == nil {
+		return "", nil
+	}
+	n := intn(s.total)
+	v := s.variants[len(s.variants)-1]
+	for i, c := range s.cum {
+		if n < c {
+			v = s.variants[i]
+			break
+		}
+	}
+	return v, &Marker{Name: s.Param, Value: v, Query: s.query, Cookie: s.cookie}
+}
+
+// Describe bölmeyi log için döner (ör. "exp: control %50, b %50")
+func (s *Split) Describe() string {
+	parts := make([]string, len(s.variants))
+	prev := 0
+	for i, v := range s.variants {
+		parts[i] = fmt.Sprintf("%s %%%.0f", v, float64(s.cum[i]-prev)*100/float64(s.total))
+		prev = s.cum[i]
+	}
+	return s.Param + ": " + strings.Join(parts, ", ")
+}
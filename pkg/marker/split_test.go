@@ -0,0 +1,38 @@
+package marker
+
+import "testing"
+
+func TestParseSplit(t *testing.T) {
+	if s, err := ParseSplit("", "cookie", []string{"a"}); s != nil || err != nil {
+		t.Fatalf("empty param = %v, %v", s, err)
+	}
+	s, err := ParseSplit("exp", "cookie", []string{"control=75", " b=25 ", "c=0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Describe(); got != "exp: control %75, b %25" {
+		t.Errorf("Describe = %q", got)
+	}
+	for _, bad := range [][]string{{"a=x"}, {"a=-1"}, {"a", "a"}, {"a b"}, {"a=0"}} {
+		if _, err := ParseSplit("exp", "", bad); err == nil {
+			t.Errorf("%q accepted", bad)
+		}
+	}
+	if _, err := ParseSplit("exp", "header", []string{"a"}); err == nil {
+		t.Error("bad mode accepted")
+	}
+}
+
+func TestSplitPick(t *testing.T) {
+	s, _ := ParseSplit("exp", "both", []string{"control=75", "b=25"})
+	for n, want := range map[int]string{0: "control", 74: "control", 75: "b", 99: "b"} {
+		v, m := s.Pick(func(int) int { return n })
+		if v != want || m.Name != "exp" || m.Value != want || !m.Query || !m.Cookie {
+			t.Errorf("Pick(%d) = %q, %+v", n, v, m)
+		}
+	}
+	var off *Split
+	if v, m := off.Pick(func(int) int { return 0 }); v != "" || m != nil {
+		t.Errorf("nil Pick = %q, %v", v, m)
+	}
+}
@@ -9,7 +9,10 @@ import (
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"vgbot/pkg/browserpool"
 )
 
 // MetricsCollector holds all application metrics with Prometheus compatibility
@@ -21,6 +24,7 @@ type MetricsCollector struct {
 
 	// Performans metrikleri
 	ResponseTime prometheus.Histogram
+	HitDuration  *prometheus.HistogramVec // Sonuç bazlı (success/failure)
 	ProxyLatency *prometheus.HistogramVec // Proxy bazlı
 
 	// Aktif durum
@@ -37,6 +41,17 @@ type MetricsCollector struct {
 	ProxySuccess *prometheus.CounterVec
 	ProxyFailure *prometheus.CounterVec
 
+	// Hata sınıfları (errclass etiketi)
+	ErrorsByClass *prometheus.CounterVec
+	ProxyErrors   *prometheus.CounterVec // Proxy + hata sınıfı
+
+	// Tarayıcı havuzu (browserpool)
+	BrowserPoolInstances *prometheus.GaugeVec // state: warm, in_use, launching
+	BrowserPoolEvents    *prometheus.GaugeVec // event: launched, recycled, reused, exhausted, launch_error (çalışma başından beri)
+
+	// Collector'a ait registry (Go runtime ve process metrikleri dahil)
+	registry *prometheus.Registry
+
 	// Internal tracking
 	mu           sync.RWMutex
 	startTime    time.Time
@@ -47,6 +62,7 @@ type MetricsCollector struct {
 	bounceCount  int64
 	errorCount   int64
 	totalHits    int64
+	browserPool  *browserpool.Stats // nil = havuz kapalı
 }
 
 // RateCalculator calculates hits per minute using a sliding window
@@ -123,11 +139,15 @@ func (rc *RateCalculator) Stop() {
 // Namespace for all metrics
 const namespace = "vgbot"
 
+// hitDurationBuckets covers full browser visits (page load + dwell), which run far past DefBuckets' 10s
+var hitDurationBuckets = []float64{.5, 1, 2, 5, 10, 20, 30, 60, 120, 300}
+
 // NewMetricsCollector creates and initializes a new metrics collector
 func NewMetricsCollector() *MetricsCollector {
 	mc := &MetricsCollector{
 		startTime:  time.Now(),
 		hitsPerMin: NewRateCalculator(time.Minute),
+		registry:   prometheus.NewRegistry(),
 	}
 
 	// Hit Counter
@@ -152,6 +172,14 @@ func NewMetricsCollector() *MetricsCollector {
 		Buckets:   prometheus.DefBuckets,
 	})
 
+	// Hit Duration Histogram (per result)
+	mc.HitDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "hit_duration_seconds",
+		Help:      "Hit duration distribution by result",
+		Buckets:   hitDurationBuckets,
+	}, []string{"result"})
+
 	// Proxy Latency Histogram (per proxy)
 	mc.ProxyLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
 		Namespace: namespace,
@@ -216,6 +244,34 @@ func NewMetricsCollector() *MetricsCollector {
 		Help:      "Total failed requests per proxy",
 	}, []string{"proxy"})
 
+	// Errors by class (errclass: proxy_dead, navigation_timeout, banned, ...)
+	mc.ErrorsByClass = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "errors_total",
+		Help:      "Total classified errors by error class",
+	}, []string{"class"})
+
+	// Errors by proxy and class
+	mc.ProxyErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "proxy_errors_total",
+		Help:      "Total classified errors per proxy",
+	}, []string{"proxy", "class"})
+
+	// Browser pool instances by state
+	mc.BrowserPoolInstances = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "browser_pool_instances",
+		Help:      "Warm Chrome pool instances by state",
+	}, []string{"state"})
+
+	// Browser pool lifecycle counts of the current run
+	mc.BrowserPoolEvents = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "browser_pool_events",
+		Help:      "Warm Chrome pool lifecycle events since the run started",
+	}, []string{"event"})
+
 	// Register all metrics
 	mc.register()
 
@@ -225,12 +281,15 @@ func NewMetricsCollector() *MetricsCollector {
 	return mc
 }
 
-// register registers all metrics with Prometheus
+// register registers all metrics, plus Go runtime and process metrics, with the collector's registry
 func (mc *MetricsCollector) register() {
-	prometheus.MustRegister(
+	mc.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
 		mc.HitCounter,
 		mc.HitRate,
 		mc.ResponseTime,
+		mc.HitDuration,
 		mc.ProxyLatency,
 		mc.ActiveSessions,
 		mc.ActiveProxies,
@@ -240,9 +299,18 @@ func (mc *MetricsCollector) register() {
 		mc.ErrorRate,
 		mc.ProxySuccess,
 		mc.ProxyFailure,
+		mc.ErrorsByClass,
+		mc.ProxyErrors,
+		mc.BrowserPoolInstances,
+		mc.BrowserPoolEvents,
 	)
 }
 
+// Registry returns the registry holding this collector's metrics
+func (mc *MetricsCollector) Registry() *prometheus.Registry {
+	return mc.registry
+}
+
 // updateLoop periodically updates calculated metrics
 func (mc *MetricsCollector) updateLoop() {
 	ticker := time.NewTicker(5 * time.Second)
@@ -285,6 +353,15 @@ func (mc *MetricsCollector) RecordResponseTime(duration time.Duration) {
 	mc.ResponseTime.Observe(duration.Seconds())
 }
 
+// RecordHitDuration records a hit's duration labeled by result
+func (mc *MetricsCollector) RecordHitDuration(duration time.Duration, success bool) {
+	result := "success"
+	if !success {
+		result = "failure"
+	}
+	mc.HitDuration.WithLabelValues(result).Observe(duration.Seconds())
+}
+
 // RecordProxyLatency records proxy-specific latency
 func (mc *MetricsCollector) RecordProxyLatency(proxy string, duration time.Duration) {
 	mc.ProxyLatency.WithLabelValues(proxy).Observe(duration.Seconds())
@@ -310,6 +387,22 @@ func (mc *MetricsCollector) RecordFailure(proxy string) {
 	}
 }
 
+// RecordErrorClass records a classified error (errclass label)
+func (mc *MetricsCollector) RecordErrorClass(class string) {
+	if class == "" {
+		return
+	}
+	mc.ErrorsByClass.WithLabelValues(class).Inc()
+}
+
+// RecordProxyError records a classified error for a proxy
+func (mc *MetricsCollector) RecordProxyError(proxy, class string) {
+	if proxy == "" || class == "" {
+		return
+	}
+	mc.ProxyErrors.WithLabelValues(proxy, class).Inc()
+}
+
 // RecordBounce records a bounce
 func (mc *MetricsCollector) RecordBounce() {
 	mc.mu.Lock()
@@ -341,6 +434,26 @@ func (mc *MetricsCollector) SetQueueSize(size int64) {
 	mc.mu.Unlock()
 }
 
+// SetBrowserPool sets the warm Chrome pool stats; nil clears them (pool disabled or run finished)
+func (mc *MetricsCollector) SetBrowserPool(s *browserpool.Stats) {
+	if s == nil {
+		mc.BrowserPoolInstances.Reset()
+		mc.BrowserPoolEvents.Reset()
+	} else {
+		mc.BrowserPoolInstances.WithLabelValues("warm").Set(float64(s.Warm))
+		mc.BrowserPoolInstances.WithLabelValues("in_use").Set(float64(s.InUse))
+		mc.BrowserPoolInstances.WithLabelValues("launching").Set(float64(s.Launching))
+		mc.BrowserPoolEvents.WithLabelValues("launched").Set(float64(s.Launched))
+		mc.BrowserPoolEvents.WithLabelValues("recycled").Set(float64(s.Recycled))
+		mc.BrowserPoolEvents.WithLabelValues("reused").Set(float64(s.Reused))
+		mc.BrowserPoolEvents.WithLabelValues("exhausted").Set(float64(s.Exhausted))
+		mc.BrowserPoolEvents.WithLabelValues("launch_error").Set(float64(s.LaunchErrors))
+	}
+	mc.mu.Lock()
+	mc.browserPool = s
+	mc.mu.Unlock()
+}
+
 // GetSnapshot returns current metrics snapshot
 func (mc *MetricsCollector) GetSnapshot() Snapshot {
 	mc.mu.RLock()
@@ -360,6 +473,7 @@ func (mc *MetricsCollector) GetSnapshot() Snapshot {
 		BounceRate:      calculateRate(mc.bounceCount, mc.totalHits),
 		ErrorRate:       calculateRate(mc.errorCount, mc.totalHits),
 		UptimeSeconds:   time.Since(mc.startTime).Seconds(),
+		BrowserPool:     mc.browserPool,
 	}
 }
 
@@ -378,6 +492,7 @@ type Snapshot struct {
 	BounceRate     float64   `json:"bounce_rate"`
 	ErrorRate      float64   `json:"error_rate"`
 	UptimeSeconds  float64   `json:"uptime_seconds"`
+	BrowserPool    *browserpool.Stats `json:"browser_pool,omitempty"`
 }
 
 func calculateRate(part, total int64) float64 {
@@ -387,9 +502,10 @@ func calculateRate(part, total int64) float64 {
 	return float64(part) / float64(total)
 }
 
-// MetricsHandler returns HTTP handler for Prometheus metrics
+// MetricsHandler returns HTTP handler for Prometheus metrics (text exposition format, gzip when accepted)
 func (mc *MetricsCollector) MetricsHandler() http.Handler {
-	return promhttp.Handler()
+	return promhttp.InstrumentMetricHandler(mc.registry,
+		promhttp.HandlerFor(mc.registry, promhttp.HandlerOpts{Registry: mc.registry}))
 }
 
 // JSONHandler returns metrics in JSON format
@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"vgbot/pkg/browserpool"
+)
+
+func TestCollectorExposition(t *testing.T) {
+	// Her collector kendi registry'sini kullanır; ikinci örnek duplicate kayıt panic'i vermemeli
+	_ = NewMetricsCollector()
+	mc := NewMetricsCollector()
+
+	mc.RecordHit()
+	mc.RecordHitDuration(15*time.Second, true)
+	mc.RecordHitDuration(2*time.Second, false)
+	mc.RecordSuccess("10.0.0.1:8080")
+	mc.RecordProxyError("10.0.0.2:3128", "proxy_dead")
+	mc.RecordProxyError("", "proxy_dead")
+	mc.SetBrowserPool(&browserpool.Stats{Warm: 2, InUse: 3, Launched: 6, Recycled: 1})
+
+	rec := httptest.NewRecorder()
+	mc.MetricsHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := io.ReadAll(rec.Body)
+	out := string(body)
+
+	for _, want := range []string{
+		"vgbot_hits_total 1",
+		`vgbot_hit_duration_seconds_bucket{result="success",le="20"} 1`,
+		`vgbot_hit_duration_seconds_count{result="failure"} 1`,
+		`vgbot_proxy_success_total{proxy="10.0.0.1:8080"} 1`,
+		`vgbot_proxy_errors_total{class="proxy_dead",proxy="10.0.0.2:3128"} 1`,
+		`vgbot_browser_pool_instances{state="in_use"} 3`,
+		`vgbot_browser_pool_events{event="launched"} 6`,
+		"go_goroutines",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("exposition missing %q", want)
+		}
+	}
+	if strings.Count(out, "vgbot_proxy_errors_total{") != 1 {
+		t.Errorf("empty proxy label recorded:\n%s", out)
+	}
+	if p := mc.GetSnapshot().BrowserPool; p == nil || p.Warm != 2 {
+		t.Errorf("snapshot browser pool = %+v", p)
+	}
+	mc.SetBrowserPool(nil)
+	if mc.GetSnapshot().BrowserPool != nil {
+		t.Error("browser pool not cleared")
+	}
+}
@@ -0,0 +1,186 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+)
+
+// HistoryWindow dakikalık geçmişin varsayılan saklama süresi
+const HistoryWindow = 24 * time.Hour
+
+// HistoryPoint bir dakikanın metrik özeti
+type HistoryPoint struct {
+	Minute    time.Time `json:"minute"`
+	Hits      int64     `json:"hits"`            // Bu dakikadaki hit
+	Success   int64     `json:"success"`         // Bu dakikadaki başarılı hit
+	Errors    int64     `json:"errors"`          // Bu dakikadaki hatalı hit
+	TotalHits int64     `json:"total_hits"`      // Geçmiş boyunca kümülatif hit (sunucu yeniden başlatmaları dahil)
+	Stage     string    `json:"stage,omitempty"` // Bu dakikadaki yük profili aşaması
+}
+
+// History saklama süresi boyunca dakikalık metrikleri tutar ve depoya yazar; dashboard grafiği
+// sayfa yenilendiğinde veya sunucu yeniden başladığında buradan doldurulur
+type History struct {
+	mu        sync.Mutex
+	store     MetricsStore
+	retention time.Duration
+	points    []HistoryPoint
+	base      int64    // Önceki süreçlerden devralınan kümülatif hit
+	last      Snapshot // Bu süreçte son görülen sayaçlar
+	stage     string   // Aktif yük profili aşaması (sonraki noktalara yazılır)
+}
+
+// NewHistory path'teki JSON geçmişi yükler (yoksa boş başlar, path boşsa yalnızca bellekte);
+// HistoryWindow dışındaki noktalar atılır
+func NewHistory(path string) *History {
+	return NewHistoryStore(newJSONStore(path), HistoryWindow)
+}
+
+// NewHistoryStore geçmişi store'dan yükler; retention'dan (<= 0 = HistoryWindow) eski noktalar atılır
+func NewHistoryStore(store MetricsStore, retention time.Duration) *History {
+	if retention <= 0 {
+		retention = HistoryWindow
+	}
+	h := &History{store: store, retention: retention}
+	now := time.Now()
+	points, err := store.Load(now.Add(-retention))
+	if err != nil {
+		return h
+	}
+	h.points = points
+	h.trim(now)
+	if n := len(h.points); n > 0 {
+		h.base = h.points[n-1].TotalHits
+	}
+	return h
+}
+
+// Record snapshot'ı dakikasının kovasına işler; yeni dakikaya geçildiğinde biten dakika ve yenisi
+// depoya yazılır, saklama süresini aşan noktalar silinir
+func (h *History) Record(s Snapshot) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	hits := delta(s.TotalHits, h.last.TotalHits)
+	success := delta(s.SuccessCount, h.last.SuccessCount)
+	errs := delta(s.ErrorCount, h.last.ErrorCount)
+	if s.TotalHits < h.last.TotalHits {
+		// Collector sıfırlandı: önceki toplam kümülatife eklenir
+		h.base += h.last.TotalHits
+	}
+	h.last = s
+
+	minute := s.Timestamp.Truncate(time.Minute)
+	n := len(h.points)
+	if n > 0 && h.points[n-1].Minute.Equal(minute) {
+		p := &h.points[n-1]
+		p.Hits += hits
+		p.Success += success
+		p.Errors += errs
+		p.TotalHits = h.base + s.TotalHits
+		if h.stage != "" {
+			p.Stage = h.stage
+		}
+		return nil
+	}
+	h.points = append(h.points, HistoryPoint{
+		Minute:    minute,
+		Hits:      hits,
+		Success:   success,
+		Errors:    errs,
+		TotalHits: h.base + s.TotalHits,
+		Stage:     h.stage,
+	})
+	if n > 0 {
+		// Biten dakikanın son hali
+		if err := h.store.Put(h.points[n-1]); err != nil {
+			return err
+		}
+	}
+	if h.trim(minute) {
+		if err := h.store.Prune(minute.Add(-h.retention)); err != nil {
+			return err
+		}
+	}
+	return h.saveLocked()
+}
+
+// SetStage aktif yük profili aşamasını ayarlar; sonraki Record'lar dakikanın noktasına yazar ("" aşama yok)
+func (h *History) SetStage(stage string) {
+	h.mu.Lock()
+	h.stage = stage
+	h.mu.Unlock()
+}
+
+// Points geçmişin kopyasını eskiden yeniye döner
+func (h *History) Points() []HistoryPoint {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]HistoryPoint(nil), h.points...)
+}
+
+// PointsSince since'ten yeni noktaların kopyası
+func (h *History) PointsSince(since time.Time) []HistoryPoint {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	i := 0
+	for i < len(h.points) && !h.points[i].Minute.After(since) {
+		i++
+	}
+	return append([]HistoryPoint(nil), h.points[i:]...)
+}
+
+// Retention noktaların saklandığı süre
+func (h *History) Retention() time.Duration {
+	return h.retention
+}
+
+// Offset canlı collector toplamına eklenecek kümülatif hit (önceki süreçlerden devralınan)
+func (h *History) Offset() int64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.base
+}
+
+// Save son (devam eden) dakikayı depoya yazar (kapanışta son dakikayı kaybetmemek için)
+func (h *History) Save() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.saveLocked()
+}
+
+// Close son dakikayı yazar ve depoyu kapatır
+func (h *History) Close() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	err := h.saveLocked()
+	if cerr := h.store.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+func (h *History) saveLocked() error {
+	if n := len(h.points); n > 0 {
+		return h.store.Put(h.points[n-1])
+	}
+	return nil
+}
+
+// trim now'dan saklama süresinden eski noktaları bellekten atar; nokta atıldıysa true döner
+func (h *History) trim(now time.Time) bool {
+	cutoff := now.Add(-h.retention)
+	i := 0
+	for i < len(h.points) && !h.points[i].Minute.After(cutoff) {
+		i++
+	}
+	h.points = h.points[i:]
+	return i > 0
+}
+
+// delta sayaç artışı; sayaç sıfırlandıysa mevcut değer artış sayılır
+func delta(cur, prev int64) int64 {
+	if cur < prev {
+		return cur
+	}
+	return cur - prev
+}
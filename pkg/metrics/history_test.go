@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestHistoryRecordAndReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.json")
+	t0 := time.Now().Truncate(time.Minute)
+
+	h := NewHistory(path)
+	h.Record(Snapshot{Timestamp: t0, TotalHits: 3, SuccessCount: 2, ErrorCount: 1})
+	h.Record(Snapshot{Timestamp: t0.Add(30 * time.Second), TotalHits: 5, SuccessCount: 4, ErrorCount: 1})
+	h.Record(Snapshot{Timestamp: t0.Add(time.Minute), TotalHits: 9, SuccessCount: 8, ErrorCount: 1})
+
+	pts := h.Points()
+	if len(pts) != 2 || pts[0].Hits != 5 || pts[0].Success != 4 || pts[1].Hits != 4 || pts[1].TotalHits != 9 {
+		t.Fatalf("points = %+v", pts)
+	}
+	if err := h.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Yeniden başlatma: collector sıfırdan sayar, kümülatif toplam devam eder
+	r := NewHistory(path)
+	if r.Offset() != 9 || len(r.Points()) != 2 {
+		t.Fatalf("reloaded offset = %d, points = %d", r.Offset(), len(r.Points()))
+	}
+	r.Record(Snapshot{Timestamp: t0.Add(2 * time.Minute), TotalHits: 2, SuccessCount: 2})
+	pts = r.Points()
+	if last := pts[len(pts)-1]; last.Hits != 2 || last.TotalHits != 11 {
+		t.Fatalf("after restart = %+v", last)
+	}
+}
+
+func TestHistoryDropsOldPoints(t *testing.T) {
+	h := NewHistory("")
+	now := time.Now()
+	h.Record(Snapshot{Timestamp: now.Add(-HistoryWindow - time.Hour), TotalHits: 1})
+	h.Record(Snapshot{Timestamp: now, TotalHits: 2})
+	if pts := h.Points(); len(pts) != 1 || pts[0].Hits != 1 {
+		t.Fatalf("points = %+v", pts)
+	}
+}
+
+func TestHistoryStage(t *testing.T) {
+	h := NewHistory("")
+	t0 := time.Now().Truncate(time.Minute)
+	h.Record(Snapshot{Timestamp: t0, TotalHits: 1})
+	h.SetStage("step 1/4")
+	h.Record(Snapshot{Timestamp: t0.Add(30 * time.Second), TotalHits: 2})
+	h.SetStage("")
+	h.Record(Snapshot{Timestamp: t0.Add(40 * time.Second), TotalHits: 3})
+	h.Record(Snapshot{Timestamp: t0.Add(time.Minute), TotalHits: 4})
+
+	pts := h.Points()
+	if len(pts) != 2 || pts[0].Stage != "step 1/4" || pts[1].Stage != "" {
+		t.Fatalf("points = %+v", pts)
+	}
+}
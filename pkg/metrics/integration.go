@@ -25,6 +25,7 @@ func (h *SimulatorHooks) OnHitStart() {
 // OnHitComplete records a completed hit
 func (h *SimulatorHooks) OnHitComplete(proxy string, duration time.Duration, success bool) {
 	h.collector.RecordResponseTime(duration)
+	h.collector.RecordHitDuration(duration, success)
 	if proxy != "" {
 		h.collector.RecordProxyLatency(proxy, duration)
 	}
@@ -143,6 +144,7 @@ func (h *SimulatorHooks) StartTimer(proxy string) *Timer {
 func (t *Timer) Stop(success bool) time.Duration {
 	duration := time.Since(t.start)
 	t.collector.RecordResponseTime(duration)
+	t.collector.RecordHitDuration(duration, success)
 	if t.proxy != "" {
 		t.collector.RecordProxyLatency(t.proxy, duration)
 	}
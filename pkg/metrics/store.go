@@ -0,0 +1,210 @@
+package metrics
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// Metrik deposu türleri (config metrics_store)
+const (
+	StoreJSON = "json" // Tek JSON dosyası; her dakika tamamı yeniden yazılır (kısa saklama için)
+	StoreBolt = "bolt" // BoltDB; dakika başına bir kayıt, uzun saklama için
+)
+
+// MetricsStore dakikalık metrik noktalarının kalıcı deposu. History noktaları buraya yazar ve
+// açılışta buradan yükler; dashboard grafiği sunucu yeniden başlasa da geçmişi gösterir.
+type MetricsStore interface {
+	// Load since'ten yeni noktaları eskiden yeniye döner
+	Load(since time.Time) ([]HistoryPoint, error)
+	// Put noktayı dakikasına yazar (aynı dakika varsa üzerine yazılır)
+	Put(p HistoryPoint) error
+	// Prune before'dan eski noktaları siler
+	Prune(before time.Time) error
+	Close() error
+}
+
+// OpenStore türüne göre depoyu açar; boş tür JSON'dur
+func OpenStore(kind, path string) (MetricsStore, error) {
+	switch kind {
+	case "", StoreJSON:
+		return newJSONStore(path), nil
+	case StoreBolt:
+		return OpenBoltStore(path)
+	default:
+		return nil, fmt.Errorf("geçersiz metrics_store %q (json veya bolt)", kind)
+	}
+}
+
+// ============================================================================
+// JSON
+// ============================================================================
+
+// jsonStore noktaları bellekte tutar ve her Put'ta dosyayı yeniden yazar (path boşsa yalnızca bellekte)
+type jsonStore struct {
+	mu     sync.Mutex
+	path   string
+	points []HistoryPoint
+}
+
+func newJSONStore(path string) *jsonStore {
+	s := &jsonStore{path: path}
+	if path == "" {
+		return s
+	}
+	if data, err := os.ReadFile(path); err == nil {
+		json.Unmarshal(data, &s.points)
+	}
+	return s
+}
+
+func (s *jsonStore) Load(since time.Time) ([]HistoryPoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []HistoryPoint
+	for _, p := range s.points {
+		if p.Minute.After(since) {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+
+func (s *jsonStore) Put(p HistoryPoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := sort.Search(len(s.points), func(i int) bool { return !s.points[i].Minute.Before(p.Minute) })
+	switch {
+	case i < len(s.points) && s.points[i].Minute.Equal(p.Minute):
+		s.points[i] = p
+	default:
+		s.points = append(s.points, HistoryPoint{})
+		copy(s.points[i+1:], s.points[i:])
+		s.points[i] = p
+	}
+	return s.saveLocked()
+}
+
+func (s *jsonStore) Prune(before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := 0
+	for i < len(s.points) && !s.points[i].Minute.After(before) {
+		i++
+	}
+	if i == 0 {
+		return nil
+	}
+	s.points = s.points[i:]
+	return s.saveLocked()
+}
+
+func (s *jsonStore) Close() error { return nil }
+
+func (s *jsonStore) saveLocked() error {
+	if s.path == "" {
+		return nil
+	}
+	data, err := json.Marshal(s.points)
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(s.path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// ============================================================================
+// BOLTDB
+// ============================================================================
+
+// boltMinutes dakikalık noktaların bucket'ı; anahtar dakikanın Unix saniyesi (big-endian, sıralı)
+var boltMinutes = []byte("minutes")
+
+// BoltStore noktaları BoltDB dosyasında dakika başına bir kayıt olarak tutar
+type BoltStore struct {
+	db *bolt.DB
+}
+
+// OpenBoltStore path'teki veritabanını açar (yoksa oluşturur)
+func OpenBoltStore(path string) (*BoltStore, error) {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, err
+		}
+	}
+	db, err := bolt.Open(path, 0644, &bolt.Options{Timeout: 2 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("metrik veritabanı açılamadı: %w", err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(boltMinutes)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &BoltStore{db: db}, nil
+}
+
+// minuteKey zamanın sıralı anahtarı; 1970 öncesi (ör. sıfır zaman) en küçük anahtara düşer
+func minuteKey(t time.Time) []byte {
+	k := make([]byte, 8)
+	binary.BigEndian.PutUint64(k, uint64(max(t.Unix(), 0)))
+	return k
+}
+
+func (s *BoltStore) Load(since time.Time) ([]HistoryPoint, error) {
+	var out []HistoryPoint
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(boltMinutes).Cursor()
+		for k, v := c.Seek(minuteKey(since.Add(time.Second))); k != nil; k, v = c.Next() {
+			var p HistoryPoint
+			if json.Unmarshal(v, &p) == nil {
+				out = append(out, p)
+			}
+		}
+		return nil
+	})
+	return out, err
+}
+
+func (s *BoltStore) Put(p HistoryPoint) error {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(boltMinutes).Put(minuteKey(p.Minute), data)
+	})
+}
+
+func (s *BoltStore) Prune(before time.Time) error {
+	end := minuteKey(before)
+	return s.db.Update(func(tx *bolt.Tx) error {
+		c := tx.Bucket(boltMinutes).Cursor()
+		for k, _ := c.First(); k != nil && string(k) <= string(end); k, _ = c.First() {
+			if err := c.Delete(); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *BoltStore) Close() error { return s.db.Close() }
@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBoltStoreReloadAndRetention(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.db")
+	store, err := OpenBoltStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now().Truncate(time.Minute)
+	retention := 48 * time.Hour
+
+	h := NewHistoryStore(store, retention)
+	h.Record(Snapshot{Timestamp: now.Add(-30 * time.Hour), TotalHits: 4})
+	h.Record(Snapshot{Timestamp: now.Add(-time.Minute), TotalHits: 6})
+	h.Record(Snapshot{Timestamp: now, TotalHits: 7})
+	if err := h.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// 24 saatten eski nokta da saklama süresi içinde olduğundan yeniden yüklenir
+	store, err = OpenBoltStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := NewHistoryStore(store, retention)
+	defer r.Close()
+	pts := r.Points()
+	if len(pts) != 3 || pts[0].Hits != 4 || pts[2].TotalHits != 7 || r.Offset() != 7 {
+		t.Fatalf("reloaded = %+v, offset = %d", pts, r.Offset())
+	}
+	if recent := r.PointsSince(now.Add(-HistoryWindow)); len(recent) != 2 {
+		t.Fatalf("last 24h = %+v", recent)
+	}
+
+	// Saklama süresini aşan nokta depodan da silinir
+	r.Record(Snapshot{Timestamp: now.Add(19 * time.Hour), TotalHits: 1})
+	stored, err := store.Load(time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stored) != 3 || !stored[0].Minute.Equal(now.Add(-time.Minute)) {
+		t.Fatalf("stored = %+v", stored)
+	}
+}
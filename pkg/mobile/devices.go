@@ -541,6 +541,16 @@ func GetRandomDevice() DeviceProfile {
 	return allDevices[mobileRandInt(len(allDevices))]
 }
 
+// FindDeviceByUserAgent user agent'ı birebir eşleşen cihazı döner (replay için)
+func FindDeviceByUserAgent(ua string) (DeviceProfile, bool) {
+	for _, d := range allDevices {
+		if d.UserAgent == ua {
+			return d, true
+		}
+	}
+	return DeviceProfile{}, false
+}
+
 // GetDevicesByPlatform platforma göre filtreler
 func GetDevicesByPlatform(platform string) []DeviceProfile {
 	var out []DeviceProfile
@@ -612,6 +622,12 @@ func GetRandomDeviceByBrand(brand DeviceBrand) DeviceProfile {
 
 // GetRandomDeviceFiltered tip ve markalara göre filtrelenmiş rastgele cihaz
 func GetRandomDeviceFiltered(deviceType string, brands []string) DeviceProfile {
+	candidates := FilterDevices(deviceType, brands)
+	return candidates[mobileRandInt(len(candidates))]
+}
+
+// FilterDevices GetRandomDeviceFiltered'ın seçtiği aday cihazları döner (eşleşme yoksa tüm cihazlar)
+func FilterDevices(deviceType string, brands []string) []DeviceProfile {
 	var candidates []DeviceProfile
 
 	// Önce tipe göre filtrele
@@ -640,9 +656,9 @@ func GetRandomDeviceFiltered(deviceType string, brands []string) DeviceProfile {
 	}
 
 	if len(candidates) == 0 {
-		return GetRandomDevice()
+		return allDevices
 	}
-	return candidates[mobileRandInt(len(candidates))]
+	return candidates
 }
 
 // GetAvailableBrands mevcut markaları döner
@@ -58,6 +58,7 @@ func DefaultPoolConfig() PoolConfig {
 type ConnectionPool struct {
 	config    PoolConfig
 	transport *http.Transport
+	h3        *HTTP3FallbackTransport // set when HTTP/3 is enabled
 	mu        sync.RWMutex
 	metrics   *PoolMetrics
 }
@@ -79,6 +80,12 @@ func NewConnectionPool(config PoolConfig) *ConnectionPool {
 	}
 	
 	pool.transport = pool.createTransport()
+	if config.EnableHTTP3 {
+		pool.config.EnableHTTP3 = false
+		if err := pool.EnableHTTP3(); err != nil {
+			fmt.Printf("[Network] HTTP/3 disabled: %v\n", err)
+		}
+	}
 	return pool
 }
 
@@ -118,10 +125,16 @@ func (p *ConnectionPool) createTransport() *http.Transport {
 	return transport
 }
 
-// GetClient returns http.Client with pooled transport
+// GetClient returns http.Client with pooled transport (HTTP/3 with HTTP/2 fallback when enabled)
 func (p *ConnectionPool) GetClient() *http.Client {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	var rt http.RoundTripper = p.transport
+	if p.h3 != nil {
+		rt = p.h3
+	}
 	return &http.Client{
-		Transport: p.transport,
+		Transport: rt,
 		Timeout:   30 * time.Second,
 	}
 }
@@ -151,8 +164,21 @@ func (p *ConnectionPool) EnableHTTP3() error {
 		return nil
 	}
 	
+	h3, err := NewHTTP3Transport(p.transport.TLSClientConfig)
+	if err != nil {
+		return err
+	}
+	h3.Enable()
+	p.h3 = NewHTTP3FallbackTransport(h3, p.transport)
 	p.config.EnableHTTP3 = true
-	return fmt.Errorf("HTTP/3 support requires quic-go dependency")
+	return nil
+}
+
+// HTTP3Enabled reports whether clients from this pool negotiate HTTP/3
+func (p *ConnectionPool) HTTP3Enabled() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.config.EnableHTTP3
 }
 
 // TraceInfo connection trace information
@@ -181,6 +207,9 @@ func GetGlobalPool() *ConnectionPool {
 func ResetGlobalPool(config PoolConfig) {
 	if globalPool != nil {
 		globalPool.CloseIdleConnections()
+		if globalPool.h3 != nil {
+			globalPool.h3.http3Transport.Close()
+		}
 	}
 	globalPool = NewConnectionPool(config)
 }
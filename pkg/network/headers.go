@@ -0,0 +1,63 @@
+package network
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// CampaignHeaders builds the headers added to every request of a campaign: an
+// "Authorization: Basic" header when user is set, plus extra "Name: value" entries
+// (e.g. "X-Staging-Token: abc"). An explicit Authorization entry overrides basic auth.
+func CampaignHeaders(user, pass string, extra []string) (map[string]string, error) {
+	h := map[string]string{}
+	if user != "" {
+		h["Authorization"] = "Basic " + base64.StdEncoding.EncodeToString([]byte(user+":"+pass))
+	} else if pass != "" {
+		return nil, fmt.Errorf("basic auth password set without user")
+	}
+	for _, e := range extra {
+		e = strings.TrimSpace(e)
+		if e == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(e, ":")
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if !ok || name == "" || strings.ContainsAny(name, " \t\r\n") || strings.ContainsAny(value, "\r\n") {
+			return nil, fmt.Errorf("invalid header %q (want Name: value)", e)
+		}
+		h[http.CanonicalHeaderKey(name)] = value
+	}
+	if len(h) == 0 {
+		return nil, nil
+	}
+	return h, nil
+}
+
+// HeaderTransport adds fixed headers to requests that do not already set them.
+// When Host is set only requests for that host get the headers, so credentials
+// do not follow redirects or links to other hosts.
+type HeaderTransport struct {
+	Base    http.RoundTripper // nil = http.DefaultTransport
+	Headers map[string]string
+	Host    string // "" = every request
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *HeaderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if len(t.Headers) == 0 || (t.Host != "" && !strings.EqualFold(req.URL.Hostname(), t.Host)) {
+		return base.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	for k, v := range t.Headers {
+		if req.Header.Get(k) == "" {
+			req.Header.Set(k, v)
+		}
+	}
+	return base.RoundTrip(req)
+}
@@ -0,0 +1,80 @@
+package network
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCampaignHeaders(t *testing.T) {
+	h, err := CampaignHeaders("staging", "pw", []string{"x-staging-token: abc", " X-Env :  preprod ", ""})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h["Authorization"] != "Basic c3RhZ2luZzpwdw==" {
+		t.Errorf("Authorization = %q", h["Authorization"])
+	}
+	if h["X-Staging-Token"] != "abc" || h["X-Env"] != "preprod" {
+		t.Errorf("headers = %v", h)
+	}
+	if h, err := CampaignHeaders("", "", nil); err != nil || h != nil {
+		t.Errorf("empty = %v, %v", h, err)
+	}
+	for _, bad := range [][]string{{"NoColon"}, {": value"}, {"Bad Name: x"}, {"X-A: b\r\nX-B: c"}} {
+		if _, err := CampaignHeaders("", "", bad); err == nil {
+			t.Errorf("%q accepted", bad)
+		}
+	}
+	if _, err := CampaignHeaders("", "pw", nil); err == nil {
+		t.Error("password without user accepted")
+	}
+}
+
+func TestHeaderTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if u, p, ok := r.BasicAuth(); !ok || u != "staging" || p != "pw" || r.Header.Get("X-Staging-Token") != "abc" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer srv.Close()
+
+	h, _ := CampaignHeaders("staging", "pw", []string{"X-Staging-Token: abc"})
+	client := &http.Client{Transport: &HeaderTransport{Headers: h}}
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d", resp.StatusCode)
+	}
+}
+
+func TestHeaderTransportHostScope(t *testing.T) {
+	var leaked string
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		leaked = r.Header.Get("X-Staging-Token")
+	}))
+	defer other.Close()
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Staging-Token") != "abc" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		// Aynı sunucuya localhost adıyla yönlendir: farklı host sayılır
+		http.Redirect(w, r, fmt.Sprintf("http://localhost:%d/", other.Listener.Addr().(*net.TCPAddr).Port), http.StatusFound)
+	}))
+	defer target.Close()
+
+	client := &http.Client{Transport: &HeaderTransport{Headers: map[string]string{"X-Staging-Token": "abc"}, Host: "127.0.0.1"}}
+	resp, err := client.Get(target.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || leaked != "" {
+		t.Fatalf("status = %d, leaked token %q", resp.StatusCode, leaked)
+	}
+}
@@ -0,0 +1,119 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// HostMap maps production hosts to a staging target (IP or host, optionally with port).
+// Connections for a mapped host are dialed to the target while URLs, Host headers, TLS SNI,
+// referrers and analytics payloads keep the production host (dark-launch rehearsal).
+type HostMap map[string]string
+
+// ParseHostMap parses "example.com=10.0.0.5" / "www.example.com=staging.internal:8443" entries
+func ParseHostMap(entries []string) (HostMap, error) {
+	m := HostMap{}
+	for _, e := range entries {
+		e = strings.TrimSpace(e)
+		if e == "" {
+			continue
+		}
+		from, to, ok := strings.Cut(e, "=")
+		from = strings.ToLower(strings.TrimSpace(from))
+		to = strings.TrimSpace(to)
+		if !ok || from == "" || to == "" {
+			return nil, fmt.Errorf("invalid host mapping %q (want production.host=staging-target)", e)
+		}
+		if strings.ContainsAny(from, ":/ ,") {
+			return nil, fmt.Errorf("invalid production host %q", from)
+		}
+		host, port := to, ""
+		if h, p, err := net.SplitHostPort(to); err == nil {
+			host, port = h, p
+		}
+		if host == "" || strings.ContainsAny(host, "/ ,") || strings.ContainsAny(port, "/ ,") {
+			return nil, fmt.Errorf("invalid staging target %q", to)
+		}
+		if _, dup := m[from]; dup {
+			return nil, fmt.Errorf("duplicate host mapping for %s", from)
+		}
+		m[from] = to
+	}
+	return m, nil
+}
+
+// Hosts returns the mapped production hosts in sorted order
+func (m HostMap) Hosts() []string {
+	hosts := make([]string, 0, len(m))
+	for h := range m {
+		hosts = append(hosts, h)
+	}
+	sort.Strings(hosts)
+	return hosts
+}
+
+// Target returns the dial address for addr ("host:port"); unmapped addresses are returned unchanged.
+// A target without a port keeps the original port.
+func (m HostMap) Target(addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	to, ok := m[strings.ToLower(host)]
+	if !ok {
+		return addr
+	}
+	if _, _, err := net.SplitHostPort(to); err == nil {
+		return to
+	}
+	return net.JoinHostPort(to, port)
+}
+
+// ResolverRules returns the value for Chrome's --host-resolver-rules flag ("" if empty)
+func (m HostMap) ResolverRules() string {
+	rules := make([]string, 0, len(m))
+	for _, h := range m.Hosts() {
+		rules = append(rules, "MAP "+h+" "+m[h])
+	}
+	return strings.Join(rules, ", ")
+}
+
+var (
+	hostMapMu     sync.RWMutex
+	activeHostMap HostMap
+)
+
+// SetHostMap sets the process-wide host mapping used by MappedDialContext (nil disables it)
+func SetHostMap(m HostMap) {
+	hostMapMu.Lock()
+	activeHostMap = m
+	hostMapMu.Unlock()
+}
+
+// ActiveHostMap returns the process-wide host mapping
+func ActiveHostMap() HostMap {
+	hostMapMu.RLock()
+	defer hostMapMu.RUnlock()
+	return activeHostMap
+}
+
+// DialFunc is the signature of http.Transport.DialContext
+type DialFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// MappedDialContext wraps dial (nil = net.Dialer) so connections to mapped hosts go to their
+// staging target. The mapping is read on every dial, so SetHostMap takes effect immediately.
+func MappedDialContext(dial DialFunc) DialFunc {
+	if dial == nil {
+		dial = (&net.Dialer{}).DialContext
+	}
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if m := ActiveHostMap(); len(m) > 0 {
+			addr = m.Target(addr)
+		}
+		return dial(ctx, network, addr)
+	}
+}
@@ -0,0 +1,61 @@
+package network
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseHostMap(t *testing.T) {
+	m, err := ParseHostMap([]string{"Example.com=10.0.0.5", " www.example.com = staging.internal:8443 ", ""})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := m.Target("example.com:443"); got != "10.0.0.5:443" {
+		t.Errorf("Target = %q", got)
+	}
+	if got := m.Target("www.example.com:443"); got != "staging.internal:8443" {
+		t.Errorf("Target = %q", got)
+	}
+	if got := m.Target("other.com:80"); got != "other.com:80" {
+		t.Errorf("unmapped Target = %q", got)
+	}
+	if got := m.ResolverRules(); got != "MAP example.com 10.0.0.5, MAP www.example.com staging.internal:8443" {
+		t.Errorf("ResolverRules = %q", got)
+	}
+
+	for _, bad := range [][]string{{"example.com"}, {"=10.0.0.5"}, {"a.com=1.1.1.1", "a.com=2.2.2.2"}, {"https://a.com=1.1.1.1"}} {
+		if _, err := ParseHostMap(bad); err == nil {
+			t.Errorf("%v: expected error", bad)
+		}
+	}
+}
+
+func TestMappedDialContextKeepsHostHeader(t *testing.T) {
+	var gotHost string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHost = r.Host
+		io.WriteString(w, "staging")
+	}))
+	defer srv.Close()
+
+	m, err := ParseHostMap([]string{"prod.example=" + strings.TrimPrefix(srv.URL, "http://")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetHostMap(m)
+	defer SetHostMap(nil)
+
+	client := &http.Client{Transport: &http.Transport{DialContext: MappedDialContext(nil)}}
+	resp, err := client.Get("http://prod.example/page")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "staging" || gotHost != "prod.example" {
+		t.Fatalf("body %q host %q", body, gotHost)
+	}
+}
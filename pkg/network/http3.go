@@ -1,15 +1,15 @@
-//go:build http3
-// +build http3
-
 // HTTP/3 QUIC Support for VGBot
-// Requires: go get github.com/quic-go/quic-go
 package network
 
 import (
+	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/quic-go/quic-go"
 	"github.com/quic-go/quic-go/http3"
@@ -20,7 +20,9 @@ type HTTP3Config struct {
 	Enabled            bool
 	MaxHeaderBytes     int
 	MaxIncomingStreams int64
-	Allow0RTT          bool // 0-RTT support for faster reconnections
+	Allow0RTT          bool          // 0-RTT support for faster reconnections
+	HandshakeTimeout   time.Duration // QUIC handshake limit before falling back to TCP
+	BrokenHostTTL      time.Duration // How long a host that failed over QUIC skips HTTP/3
 }
 
 // DefaultHTTP3Config returns default HTTP/3 configuration
@@ -30,55 +32,99 @@ func DefaultHTTP3Config() HTTP3Config {
 		MaxHeaderBytes:     1 << 20, // 1MB
 		MaxIncomingStreams: 100,
 		Allow0RTT:          true,
+		HandshakeTimeout:   3 * time.Second,
+		BrokenHostTTL:      5 * time.Minute,
 	}
 }
 
-// HTTP3Transport wraps http3.RoundTripper
+// HTTP3Transport wraps http3.Transport
 type HTTP3Transport struct {
 	config    HTTP3Config
-	transport *http3.RoundTripper
+	transport *http3.Transport
 	mu        sync.RWMutex
+
+	udpOnce sync.Once
+	udp     *quic.Transport // Shared UDP socket for all QUIC connections, opened on first dial
+	udpErr  error
 }
 
 // NewHTTP3Transport creates HTTP/3 transport
 func NewHTTP3Transport(tlsConfig *tls.Config) (*HTTP3Transport, error) {
-	// Clone TLS config for HTTP/3
+	// QUIC requires TLS 1.3; clone so the caller's TCP config is untouched
 	if tlsConfig == nil {
-		tlsConfig = &tls.Config{
-			MinVersion: tls.VersionTLS13,
-			MaxVersion: tls.VersionTLS13,
-		}
+		tlsConfig = &tls.Config{}
+	} else {
+		tlsConfig = tlsConfig.Clone()
 	}
-	
-	transport := &http3.RoundTripper{
-		TLSClientConfig: tlsConfig,
-		QuicConfig: &quic.Config{
-			MaxIncomingStreams: 100,
-			Allow0RTT:          true,
+	tlsConfig.MinVersion = tls.VersionTLS13
+	tlsConfig.MaxVersion = tls.VersionTLS13
+
+	config := DefaultHTTP3Config()
+	transport := &http3.Transport{
+		TLSClientConfig:        tlsConfig,
+		MaxResponseHeaderBytes: int64(config.MaxHeaderBytes),
+		QUICConfig: &quic.Config{
+			MaxIncomingStreams:   config.MaxIncomingStreams,
+			Allow0RTT:            config.Allow0RTT,
+			HandshakeIdleTimeout: config.HandshakeTimeout,
 		},
 	}
-	
-	return &HTTP3Transport{
-		config:    DefaultHTTP3Config(),
+
+	h := &HTTP3Transport{
+		config:    config,
 		transport: transport,
-	}, nil
+	}
+	transport.Dial = h.dial
+	return h, nil
+}
+
+// dial opens a QUIC connection to addr. Like MappedDialContext it sends mapped hosts to their
+// staging target; the TLS server name is already set from the request host, so SNI and
+// certificate checks still use the production name.
+func (h *HTTP3Transport) dial(ctx context.Context, addr string, tlsCfg *tls.Config, cfg *quic.Config) (quic.EarlyConnection, error) {
+	h.udpOnce.Do(func() {
+		conn, err := net.ListenUDP("udp", nil)
+		if err != nil {
+			h.udpErr = err
+			return
+		}
+		h.udp = &quic.Transport{Conn: conn}
+	})
+	if h.udpErr != nil {
+		return nil, h.udpErr
+	}
+
+	if m := ActiveHostMap(); len(m) > 0 {
+		addr = m.Target(addr)
+	}
+	udpAddr, err := net.ResolveUDPAddr("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return h.udp.DialEarly(ctx, udpAddr, tlsCfg, cfg)
 }
 
 // RoundTrip implements http.RoundTripper
 func (h *HTTP3Transport) RoundTrip(req *http.Request) (*http.Response, error) {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
-	
+
 	if !h.config.Enabled {
 		return nil, fmt.Errorf("HTTP/3 is disabled")
 	}
-	
+
 	return h.transport.RoundTrip(req)
 }
 
 // Close closes the transport
 func (h *HTTP3Transport) Close() error {
-	return h.transport.Close()
+	err := h.transport.Close()
+	// Mark the socket closed so a late dial cannot reopen it
+	h.udpOnce.Do(func() { h.udpErr = net.ErrClosed })
+	if h.udp != nil {
+		h.udp.Close()
+	}
+	return err
 }
 
 // Enable enables HTTP/3
@@ -102,39 +148,88 @@ func (h *HTTP3Transport) IsEnabled() bool {
 	return h.config.Enabled
 }
 
-// HTTP3FallbackTransport tries HTTP/3 first, falls back to HTTP/2/1
+// HTTP3FallbackTransport tries HTTP/3 first, falls back to HTTP/2/1.
+// Hosts that fail over QUIC go straight to the fallback for BrokenHostTTL, so a site without
+// HTTP/3 costs one handshake timeout instead of one per request. Requests that would go through
+// an HTTP proxy always use the fallback: QUIC cannot be tunnelled through CONNECT.
 type HTTP3FallbackTransport struct {
 	http3Transport *HTTP3Transport
 	httpTransport  http.RoundTripper
 	mu             sync.RWMutex
+	broken         map[string]time.Time // host -> HTTP/3 retry time
 }
 
 // NewHTTP3FallbackTransport creates fallback transport
 func NewHTTP3FallbackTransport(http3Transport *HTTP3Transport, httpTransport http.RoundTripper) *HTTP3FallbackTransport {
+	if httpTransport == nil {
+		httpTransport = http.DefaultTransport
+	}
 	return &HTTP3FallbackTransport{
 		http3Transport: http3Transport,
 		httpTransport:  httpTransport,
+		broken:         make(map[string]time.Time),
 	}
 }
 
 // RoundTrip implements http.RoundTripper with fallback
 func (f *HTTP3FallbackTransport) RoundTrip(req *http.Request) (*http.Response, error) {
-	f.mu.RLock()
-	http3Enabled := f.http3Transport.IsEnabled()
-	f.mu.RUnlock()
-	
-	if !http3Enabled {
+	if !f.useHTTP3(req) {
 		return f.httpTransport.RoundTrip(req)
 	}
-	
+
 	// Try HTTP/3 first
 	resp, err := f.http3Transport.RoundTrip(req)
-	if err != nil {
-		// Fallback to HTTP/2 or HTTP/1.1
-		return f.httpTransport.RoundTrip(req)
+	if err == nil {
+		return resp, nil
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	f.broken[req.URL.Host] = time.Now().Add(f.http3Transport.config.BrokenHostTTL)
+	f.mu.Unlock()
+
+	// Fallback to HTTP/2 or HTTP/1.1 with a fresh body
+	if req.Body != nil && req.Body != http.NoBody {
+		body, berr := req.GetBody()
+		if berr != nil {
+			return nil, err
+		}
+		req = req.Clone(req.Context())
+		req.Body = body
+	}
+	return f.httpTransport.RoundTrip(req)
+}
+
+// useHTTP3 reports whether the request should be attempted over QUIC
+func (f *HTTP3FallbackTransport) useHTTP3(req *http.Request) bool {
+	if req.URL.Scheme != "https" || !f.http3Transport.IsEnabled() {
+		return false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false // body could not be replayed on fallback
+	}
+	if t, ok := f.httpTransport.(*http.Transport); ok && t.Proxy != nil {
+		if u, err := t.Proxy(req); err != nil || u != nil {
+			return false
+		}
+	}
+
+	f.mu.RLock()
+	until, broken := f.broken[req.URL.Host]
+	f.mu.RUnlock()
+	if broken && time.Now().Before(until) {
+		return false
+	}
+	return true
+}
+
+// CloseIdleConnections closes idle TCP connections of the fallback transport
+func (f *HTTP3FallbackTransport) CloseIdleConnections() {
+	if t, ok := f.httpTransport.(interface{ CloseIdleConnections() }); ok {
+		t.CloseIdleConnections()
 	}
-	
-	return resp, nil
 }
 
 // EnableHTTP3 enables HTTP/3
@@ -157,3 +252,17 @@ func (f *HTTP3FallbackTransport) IsHTTP3Enabled() bool {
 	defer f.mu.RUnlock()
 	return f.http3Transport.IsEnabled()
 }
+
+// NewHTTP3Client returns a client that negotiates HTTP/3 and falls back to base (nil = http.DefaultTransport)
+func NewHTTP3Client(base http.RoundTripper, timeout time.Duration) (*http.Client, error) {
+	var tlsConfig *tls.Config
+	if t, ok := base.(*http.Transport); ok {
+		tlsConfig = t.TLSClientConfig
+	}
+	h3, err := NewHTTP3Transport(tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	h3.Enable()
+	return &http.Client{Transport: NewHTTP3FallbackTransport(h3, base), Timeout: timeout}, nil
+}
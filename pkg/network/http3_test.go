@@ -0,0 +1,144 @@
+package network
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/quic-go/quic-go/http3"
+)
+
+func TestHTTP3ClientNegotiatesQUIC(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, r.Proto)
+	})
+	tcp := httptest.NewUnstartedServer(handler)
+	tcp.EnableHTTP2 = true
+	tcp.StartTLS()
+	defer tcp.Close()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := &http3.Server{Handler: handler, TLSConfig: http3.ConfigureTLSConfig(tcp.TLS)}
+	go srv.Serve(conn)
+	defer srv.Close()
+
+	client, err := NewHTTP3Client(tcp.Client().Transport, 10*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.Get(fmt.Sprintf("https://%s/", conn.LocalAddr()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.Proto != "HTTP/3.0" || string(body) != "HTTP/3.0" {
+		t.Errorf("Proto = %q, server saw %q", resp.Proto, body)
+	}
+}
+
+func TestHTTP3ClientFollowsHostMap(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, r.Host)
+	})
+	tcp := httptest.NewUnstartedServer(handler)
+	tcp.EnableHTTP2 = true
+	tcp.StartTLS()
+	defer tcp.Close()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := &http3.Server{Handler: handler, TLSConfig: http3.ConfigureTLSConfig(tcp.TLS)}
+	go srv.Serve(conn)
+	defer srv.Close()
+
+	// example.com is in the httptest certificate, so the handshake only succeeds if the
+	// QUIC dial goes to the mapped address while SNI keeps the production name
+	m, err := ParseHostMap([]string{"example.com=" + conn.LocalAddr().String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetHostMap(m)
+	defer SetHostMap(nil)
+
+	client, err := NewHTTP3Client(tcp.Client().Transport, 10*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.Get("https://example.com/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.Proto != "HTTP/3.0" || string(body) != "example.com" {
+		t.Errorf("Proto = %q, server saw host %q", resp.Proto, body)
+	}
+}
+
+func TestHTTP3ClientFallsBackToHTTP2(t *testing.T) {
+	tcp := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "ok")
+	}))
+	tcp.EnableHTTP2 = true
+	tcp.StartTLS()
+	defer tcp.Close()
+
+	client, err := NewHTTP3Client(tcp.Client().Transport, 10*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	get := func() string {
+		resp, err := client.Get(tcp.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp.Proto
+	}
+	if got := get(); got != "HTTP/2.0" {
+		t.Errorf("first Proto = %q, want HTTP/2.0", got)
+	}
+
+	// Host is remembered as broken: the second request must not wait for another QUIC handshake
+	start := time.Now()
+	if got := get(); got != "HTTP/2.0" {
+		t.Errorf("second Proto = %q, want HTTP/2.0", got)
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("second request took %v, QUIC was retried", d)
+	}
+}
+
+func TestHTTP3FallbackSkipsProxiedAndPlainRequests(t *testing.T) {
+	h3, err := NewHTTP3Transport(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h3.Enable()
+	direct := NewHTTP3FallbackTransport(h3, &http.Transport{})
+	proxyURL, _ := url.Parse("http://127.0.0.1:3128")
+	proxied := NewHTTP3FallbackTransport(h3, &http.Transport{Proxy: http.ProxyURL(proxyURL)})
+
+	tls, _ := http.NewRequest(http.MethodGet, "https://example.com/", nil)
+	plain, _ := http.NewRequest(http.MethodGet, "http://example.com/", nil)
+	if !direct.useHTTP3(tls) {
+		t.Error("direct https request should try HTTP/3")
+	}
+	if direct.useHTTP3(plain) {
+		t.Error("plain http request must not try HTTP/3")
+	}
+	if proxied.useHTTP3(tls) {
+		t.Error("proxied request must not try HTTP/3")
+	}
+}
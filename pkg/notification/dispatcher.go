@@ -0,0 +1,153 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Notifier bir bildirim kanalı (Telegram, webhook)
+type Notifier interface {
+	IsEnabled() bool
+	ReportInterval() time.Duration
+	SendSimulationStart(domain string, durationMin int, hpm int, concurrent int) error
+	SendSimulationEnd(stats SimulationStats) error
+	SendError(errMsg string) error
+	SendMonitorAlert(targetURL, probe string, down bool, detail string) error
+	SendPeriodicReport(stats SimulationStats) error
+}
+
+// dispatcherTick periyodik rapor döngüsünün kanalları kontrol etme aralığı
+const dispatcherTick = 30 * time.Second
+
+// Dispatcher bildirimleri açık olan tüm kanallara gönderir. Periyodik raporlar tek bir döngüden,
+// her kanalın kendi aralığıyla gönderilir.
+type Dispatcher struct {
+	channels []Notifier
+	tick     time.Duration
+
+	mu      sync.Mutex
+	stopCh  chan struct{}
+	running bool
+}
+
+// NewDispatcher kanalları birleştirir
+func NewDispatcher(channels ...Notifier) *Dispatcher {
+	return &Dispatcher{channels: channels, tick: dispatcherTick}
+}
+
+// IsEnabled en az bir kanal açık mı
+func (d *Dispatcher) IsEnabled() bool {
+	for _, c := range d.channels {
+		if c.IsEnabled() {
+			return true
+		}
+	}
+	return false
+}
+
+// each açık kanallara gönderir; hatalar birleştirilir, bir kanalın hatası diğerlerini engellemez
+func (d *Dispatcher) each(fn func(Notifier) error) error {
+	var errs []error
+	for _, c := range d.channels {
+		if c.IsEnabled() {
+			errs = append(errs, fn(c))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// SendSimulationStart simülasyon başlangıç bildirimi
+func (d *Dispatcher) SendSimulationStart(domain string, durationMin int, hpm int, concurrent int) error {
+	return d.each(func(c Notifier) error { return c.SendSimulationStart(domain, durationMin, hpm, concurrent) })
+}
+
+// SendSimulationEnd simülasyon bitiş bildirimi
+func (d *Dispatcher) SendSimulationEnd(stats SimulationStats) error {
+	return d.each(func(c Notifier) error { return c.SendSimulationEnd(stats) })
+}
+
+// SendError hata bildirimi
+func (d *Dispatcher) SendError(errMsg string) error {
+	return d.each(func(c Notifier) error { return c.SendError(errMsg) })
+}
+
+// SendMonitorAlert uptime izleme uyarısı
+func (d *Dispatcher) SendMonitorAlert(targetURL, probe string, down bool, detail string) error {
+	return d.each(func(c Notifier) error { return c.SendMonitorAlert(targetURL, probe, down, detail) })
+}
+
+// StartPeriodicReporting periyodik rapor döngüsünü arka planda başlatır; ctx iptal edilince kendiliğinden durur.
+// Her kanal kendi aralığı dolduğunda rapor alır (ilk rapor bir aralık sonra).
+func (d *Dispatcher) StartPeriodicReporting(ctx context.Context, statsFn func() SimulationStats) {
+	if stopCh, ok := d.begin(); ok {
+		go d.loop(ctx, stopCh, statsFn)
+	}
+}
+
+// RunPeriodicReporting StartPeriodicReporting ile aynıdır ama döngü bitene (ctx iptali veya
+// StopPeriodicReporting) kadar bloklar; çağıran goroutine izlenebilir, döngü sızmaz.
+func (d *Dispatcher) RunPeriodicReporting(ctx context.Context, statsFn func() SimulationStats) {
+	if stopCh, ok := d.begin(); ok {
+		d.loop(ctx, stopCh, statsFn)
+	}
+}
+
+// begin döngüyü çalışıyor olarak işaretler; zaten çalışıyorsa false döner
+func (d *Dispatcher) begin() (chan struct{}, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.running {
+		return nil, false
+	}
+	d.running = true
+	d.stopCh = make(chan struct{})
+	return d.stopCh, true
+}
+
+func (d *Dispatcher) loop(ctx context.Context, stopCh chan struct{}, statsFn func() SimulationStats) {
+	ticker := time.NewTicker(d.tick)
+	defer ticker.Stop()
+	last := make([]time.Time, len(d.channels))
+	for i := range last {
+		last[i] = time.Now()
+	}
+	for {
+		select {
+		case now := <-ticker.C:
+			var stats *SimulationStats
+			for i, c := range d.channels {
+				if !c.IsEnabled() || now.Sub(last[i]) < c.ReportInterval() {
+					continue
+				}
+				if stats == nil {
+					s := statsFn()
+					stats = &s
+				}
+				last[i] = now
+				_ = c.SendPeriodicReport(*stats)
+			}
+		case <-ctx.Done():
+			d.mu.Lock()
+			if d.running && d.stopCh == stopCh {
+				close(stopCh)
+				d.running = false
+			}
+			d.mu.Unlock()
+			return
+		case <-stopCh:
+			return
+		}
+	}
+}
+
+// StopPeriodicReporting periyodik rapor döngüsünü durdurur
+func (d *Dispatcher) StopPeriodicReporting() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.running {
+		close(d.stopCh)
+		d.running = false
+	}
+}
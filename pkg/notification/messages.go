@@ -0,0 +1,118 @@
+package notification
+
+import (
+	"fmt"
+	"time"
+)
+
+// Bildirim metinleri tüm kanallarda (Telegram, webhook) aynıdır
+
+// startMessage simülasyon başlangıç metni
+func startMessage(domain string, durationMin int, hpm int, concurrent int) string {
+	return fmt.Sprintf(
+		"🚀 Simülasyon Başladı\n\n"+
+			"🌐 Domain: %s\n"+
+			"⏱ Süre: %d dakika\n"+
+			"📊 HPM: %d\n"+
+			"🔄 Eşzamanlı: %d\n"+
+			"🕐 Başlangıç: %s",
+		domain,
+		durationMin,
+		hpm,
+		concurrent,
+		time.Now().Format("15:04:05"),
+	)
+}
+
+// endMessage simülasyon bitiş metni
+func endMessage(stats SimulationStats) string {
+	return fmt.Sprintf(
+		"✅ Simülasyon Tamamlandı\n\n"+
+			"🌐 Domain: %s\n"+
+			"📊 Toplam Hit: %d\n"+
+			"✓ Başarılı: %d\n"+
+			"✗ Başarısız: %d\n"+
+			"📈 Başarı Oranı: %.1f%%\n"+
+			"⏱ Süre: %s\n"+
+			"📊 Ortalama HPM: %.1f\n"+
+			"🕐 Bitiş: %s",
+		stats.Domain,
+		stats.TotalHits,
+		stats.SuccessfulHits,
+		stats.FailedHits,
+		stats.SuccessRate,
+		formatDuration(stats.Duration),
+		stats.HitsPerMinute,
+		time.Now().Format("15:04:05"),
+	)
+}
+
+// errorMessage hata bildirimi metni
+func errorMessage(errMsg string) string {
+	return fmt.Sprintf(
+		"⚠️ Hata Bildirimi\n\n"+
+			"🔴 Hata: %s\n"+
+			"🕐 Zaman: %s",
+		errMsg,
+		time.Now().Format("15:04:05"),
+	)
+}
+
+// monitorMessage uptime uyarısı metni
+func monitorMessage(targetURL, probe string, down bool, detail string) string {
+	title, icon := "Erişilebilir", "🟢"
+	if down {
+		title, icon = "Erişilemiyor", "🔴"
+	}
+	return fmt.Sprintf(
+		"%s Uptime: %s\n\n"+
+			"🌐 URL: %s\n"+
+			"📍 Kontrol noktası: %s\n"+
+			"ℹ️ %s\n"+
+			"🕐 Zaman: %s",
+		icon, title,
+		targetURL,
+		probe,
+		detail,
+		time.Now().Format("15:04:05"),
+	)
+}
+
+// reportMessage periyodik durum raporu metni
+func reportMessage(stats SimulationStats) string {
+	return fmt.Sprintf(
+		"📊 Durum Raporu\n\n"+
+			"🌐 Domain: %s\n"+
+			"📊 Toplam Hit: %d\n"+
+			"✓ Başarılı: %d\n"+
+			"✗ Başarısız: %d\n"+
+			"📈 Başarı Oranı: %.1f%%\n"+
+			"⏱ Geçen Süre: %s\n"+
+			"📊 HPM: %.1f\n"+
+			"🔗 Aktif Proxy: %d\n"+
+			"🕐 Rapor Zamanı: %s",
+		stats.Domain,
+		stats.TotalHits,
+		stats.SuccessfulHits,
+		stats.FailedHits,
+		stats.SuccessRate,
+		formatDuration(stats.Duration),
+		stats.HitsPerMinute,
+		stats.ActiveProxies,
+		time.Now().Format("15:04:05"),
+	)
+}
+
+// formatDuration süreyi okunabilir formata çevirir
+func formatDuration(d time.Duration) string {
+	h := int(d.Hours())
+	m := int(d.Minutes()) % 60
+	s := int(d.Seconds()) % 60
+	if h > 0 {
+		return fmt.Sprintf("%dsa %ddk %dsn", h, m, s)
+	}
+	if m > 0 {
+		return fmt.Sprintf("%ddk %dsn", m, s)
+	}
+	return fmt.Sprintf("%dsn", s)
+}
@@ -0,0 +1,68 @@
+package notification
+
+import (
+	"sync"
+	"time"
+)
+
+// ErrorBurstRule aynı hata sınıfı window içinde threshold kez görülünce tetiklenir. Tetiklenen sınıf
+// window boyunca tekrar tetiklenmez; böylece süren bir kesinti her hatada bildirim üretmez.
+type ErrorBurstRule struct {
+	threshold int
+	window    time.Duration
+
+	mu    sync.Mutex
+	seen  map[string][]time.Time // Sınıf → window içindeki hata zamanları
+	fired map[string]time.Time   // Sınıf → son tetiklenme
+}
+
+// NewErrorBurstRule kural oluşturur; threshold <= 0 ise kural kapalıdır (Observe hiç tetiklenmez)
+func NewErrorBurstRule(threshold int, window time.Duration) *ErrorBurstRule {
+	if window <= 0 {
+		window = 5 * time.Minute
+	}
+	return &ErrorBurstRule{
+		threshold: threshold,
+		window:    window,
+		seen:      make(map[string][]time.Time),
+		fired:     make(map[string]time.Time),
+	}
+}
+
+// Window kuralın pencere süresi
+func (r *ErrorBurstRule) Window() time.Duration {
+	return r.window
+}
+
+// Observe at anındaki hatayı sayar; eşiğe ulaşıldıysa true döner. Sayı pencere içindeki hatalardır
+// (en fazla threshold)
+func (r *ErrorBurstRule) Observe(class string, at time.Time) (int, bool) {
+	if r == nil || r.threshold <= 0 {
+		return 0, false
+	}
+	if class == "" {
+		class = "unknown"
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cutoff := at.Add(-r.window)
+	times := r.seen[class]
+	i := 0
+	for i < len(times) && !times[i].After(cutoff) {
+		i++
+	}
+	times = append(times[i:], at)
+	if len(times) > r.threshold {
+		// Eşik için son threshold hata yeterli; bellek sınırlı kalır
+		times = times[len(times)-r.threshold:]
+	}
+	r.seen[class] = times
+	if len(times) < r.threshold {
+		return len(times), false
+	}
+	if last, ok := r.fired[class]; ok && at.Sub(last) < r.window {
+		return len(times), false
+	}
+	r.fired[class] = at
+	return len(times), true
+}
@@ -0,0 +1,38 @@
+package notification
+
+import (
+	"testing"
+	"time"
+)
+
+func TestErrorBurstRule(t *testing.T) {
+	r := NewErrorBurstRule(3, time.Minute)
+	t0 := time.Now()
+	at := func(sec int) time.Time { return t0.Add(time.Duration(sec) * time.Second) }
+
+	r.Observe("timeout", at(0))
+	r.Observe("proxy", at(1))
+	if _, fire := r.Observe("timeout", at(5)); fire {
+		t.Fatal("fired below threshold")
+	}
+	// İlk hata pencereden çıktı: eşik dolmaz
+	if n, fire := r.Observe("timeout", at(61)); fire || n != 2 {
+		t.Fatalf("after window = %d, %v", n, fire)
+	}
+	if n, fire := r.Observe("timeout", at(62)); !fire || n != 3 {
+		t.Fatalf("burst = %d, %v", n, fire)
+	}
+	// Pencere boyunca tekrar tetiklenmez, sonra tekrar tetiklenebilir
+	if _, fire := r.Observe("timeout", at(70)); fire {
+		t.Fatal("fired again within cooldown")
+	}
+	r.Observe("timeout", at(120))
+	r.Observe("timeout", at(121))
+	if _, fire := r.Observe("timeout", at(123)); !fire {
+		t.Fatal("did not fire after cooldown")
+	}
+
+	if _, fire := NewErrorBurstRule(0, time.Minute).Observe("timeout", t0); fire {
+		t.Fatal("disabled rule fired")
+	}
+}
@@ -2,6 +2,7 @@
 package notification
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -183,56 +184,22 @@ func (t *TelegramNotifier) sendRawMessage(text string) error {
 
 // SendSimulationStart simülasyon başlangıç bildirimi
 func (t *TelegramNotifier) SendSimulationStart(domain string, durationMin int, hpm int, concurrent int) error {
-	msg := fmt.Sprintf(
-		"🚀 Simülasyon Başladı\n\n"+
-			"🌐 Domain: %s\n"+
-			"⏱ Süre: %d dakika\n"+
-			"📊 HPM: %d\n"+
-			"🔄 Eşzamanlı: %d\n"+
-			"🕐 Başlangıç: %s",
-		domain,
-		durationMin,
-		hpm,
-		concurrent,
-		time.Now().Format("15:04:05"),
-	)
-	return t.sendRawMessage(msg)
+	return t.sendRawMessage(startMessage(domain, durationMin, hpm, concurrent))
 }
 
 // SendSimulationEnd simülasyon bitiş bildirimi
 func (t *TelegramNotifier) SendSimulationEnd(stats SimulationStats) error {
-	msg := fmt.Sprintf(
-		"✅ Simülasyon Tamamlandı\n\n"+
-			"🌐 Domain: %s\n"+
-			"📊 Toplam Hit: %d\n"+
-			"✓ Başarılı: %d\n"+
-			"✗ Başarısız: %d\n"+
-			"📈 Başarı Oranı: %.1f%%\n"+
-			"⏱ Süre: %s\n"+
-			"📊 Ortalama HPM: %.1f\n"+
-			"🕐 Bitiş: %s",
-		stats.Domain,
-		stats.TotalHits,
-		stats.SuccessfulHits,
-		stats.FailedHits,
-		stats.SuccessRate,
-		formatDuration(stats.Duration),
-		stats.HitsPerMinute,
-		time.Now().Format("15:04:05"),
-	)
-	return t.sendRawMessage(msg)
+	return t.sendRawMessage(endMessage(stats))
 }
 
 // SendError hata bildirimi
 func (t *TelegramNotifier) SendError(errMsg string) error {
-	msg := fmt.Sprintf(
-		"⚠️ Hata Bildirimi\n\n"+
-			"🔴 Hata: %s\n"+
-			"🕐 Zaman: %s",
-		errMsg,
-		time.Now().Format("15:04:05"),
-	)
-	return t.sendRawMessage(msg)
+	return t.sendRawMessage(errorMessage(errMsg))
+}
+
+// SendMonitorAlert uptime izleme uyarısı: hedef erişilemez (down) veya tekrar erişilebilir
+func (t *TelegramNotifier) SendMonitorAlert(targetURL, probe string, down bool, detail string) error {
+	return t.sendRawMessage(monitorMessage(targetURL, probe, down, detail))
 }
 
 // SendPeriodicReport periyodik durum raporu
@@ -240,29 +207,14 @@ func (t *TelegramNotifier) SendPeriodicReport(stats SimulationStats) error {
 	t.mu.Lock()
 	t.lastReport = time.Now()
 	t.mu.Unlock()
+	return t.sendRawMessage(reportMessage(stats))
+}
 
-	msg := fmt.Sprintf(
-		"📊 Durum Raporu\n\n"+
-			"🌐 Domain: %s\n"+
-			"📊 Toplam Hit: %d\n"+
-			"✓ Başarılı: %d\n"+
-			"✗ Başarısız: %d\n"+
-			"📈 Başarı Oranı: %.1f%%\n"+
-			"⏱ Geçen Süre: %s\n"+
-			"📊 HPM: %.1f\n"+
-			"🔗 Aktif Proxy: %d\n"+
-			"🕐 Rapor Zamanı: %s",
-		stats.Domain,
-		stats.TotalHits,
-		stats.SuccessfulHits,
-		stats.FailedHits,
-		stats.SuccessRate,
-		formatDuration(stats.Duration),
-		stats.HitsPerMinute,
-		stats.ActiveProxies,
-		time.Now().Format("15:04:05"),
-	)
-	return t.sendRawMessage(msg)
+// ReportInterval periyodik rapor aralığı
+func (t *TelegramNotifier) ReportInterval() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.reportInterval
 }
 
 // ShouldSendReport periyodik rapor zamanı geldi mi
@@ -275,15 +227,16 @@ func (t *TelegramNotifier) ShouldSendReport() bool {
 	return time.Since(t.lastReport) >= t.reportInterval
 }
 
-// StartPeriodicReporting periyodik rapor gönderimini başlatır
-func (t *TelegramNotifier) StartPeriodicReporting(statsFn func() SimulationStats) {
+// StartPeriodicReporting periyodik rapor gönderimini başlatır; ctx iptal edilince kendiliğinden durur
+func (t *TelegramNotifier) StartPeriodicReporting(ctx context.Context, statsFn func() SimulationStats) {
 	t.mu.Lock()
 	if t.running {
 		t.mu.Unlock()
 		return
 	}
 	t.running = true
-	t.stopCh = make(chan struct{})
+	stopCh := make(chan struct{})
+	t.stopCh = stopCh
 	interval := t.reportInterval
 	t.mu.Unlock()
 
@@ -297,7 +250,16 @@ func (t *TelegramNotifier) StartPeriodicReporting(statsFn func() SimulationStats
 					stats := statsFn()
 					_ = t.SendPeriodicReport(stats)
 				}
-			case <-t.stopCh:
+			case <-ctx.Done():
+				// Çalıştırma bitti/iptal edildi: döngüyü kapat (Stop çağrılmasa da sızmaz)
+				t.mu.Lock()
+				if t.running && t.stopCh == stopCh {
+					close(stopCh)
+					t.running = false
+				}
+				t.mu.Unlock()
+				return
+			case <-stopCh:
 				return
 			}
 		}
@@ -313,17 +275,3 @@ func (t *TelegramNotifier) StopPeriodicReporting() {
 		t.running = false
 	}
 }
-
-// formatDuration süreyi okunabilir formata çevirir
-func formatDuration(d time.Duration) string {
-	h := int(d.Hours())
-	m := int(d.Minutes()) % 60
-	s := int(d.Seconds()) % 60
-	if h > 0 {
-		return fmt.Sprintf("%dsa %ddk %dsn", h, m, s)
-	}
-	if m > 0 {
-		return fmt.Sprintf("%ddk %dsn", m, s)
-	}
-	return fmt.Sprintf("%dsn", s)
-}
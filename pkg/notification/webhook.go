@@ -0,0 +1,195 @@
+package notification
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Webhook payload formatları
+const (
+	WebhookSlack      = "slack"      // {"text": ...}; Mattermost incoming webhook'ları da bu formatı kabul eder
+	WebhookMattermost = "mattermost" // slack ile aynı gövde
+	WebhookDiscord    = "discord"    // {"content": ...}
+	WebhookJSON       = "json"       // {"event", "text", "timestamp", "stats"}: kendi servisleriniz için
+)
+
+// discordMaxContent Discord mesaj içeriği sınırı
+const discordMaxContent = 2000
+
+// WebhookNotifier genel webhook bildirim servisi (Slack/Discord/Mattermost uyumlu)
+type WebhookNotifier struct {
+	mu             sync.Mutex
+	url            string
+	format         string
+	enabled        bool
+	reportInterval time.Duration
+	httpClient     *http.Client
+}
+
+// WebhookConfig webhook yapılandırması
+type WebhookConfig struct {
+	URL            string
+	Format         string // slack (varsayılan), mattermost, discord, json
+	Enabled        bool
+	ReportInterval int // dakika cinsinden
+}
+
+// ValidateWebhook adresi ve formatı doğrular (boş adres geçerlidir: kanal kapalı)
+func ValidateWebhook(rawURL, format string) error {
+	switch format {
+	case "", WebhookSlack, WebhookMattermost, WebhookDiscord, WebhookJSON:
+	default:
+		return fmt.Errorf("geçersiz webhook formatı %q (slack, mattermost, discord, json)", format)
+	}
+	if rawURL == "" {
+		return nil
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return fmt.Errorf("geçersiz webhook adresi (http(s)://... bekleniyor)")
+	}
+	return nil
+}
+
+// NewWebhookNotifier yeni webhook notifier oluşturur
+func NewWebhookNotifier(cfg WebhookConfig) *WebhookNotifier {
+	w := &WebhookNotifier{httpClient: &http.Client{Timeout: 10 * time.Second}, reportInterval: 10 * time.Minute}
+	w.UpdateConfig(cfg)
+	return w
+}
+
+// IsEnabled bildirim aktif mi
+func (w *WebhookNotifier) IsEnabled() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.enabled && w.url != ""
+}
+
+// UpdateConfig yapılandırmayı günceller
+func (w *WebhookNotifier) UpdateConfig(cfg WebhookConfig) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.url = strings.TrimSpace(cfg.URL)
+	w.format = cfg.Format
+	if w.format == "" {
+		w.format = WebhookSlack
+	}
+	w.enabled = cfg.Enabled
+	if cfg.ReportInterval > 0 {
+		w.reportInterval = time.Duration(cfg.ReportInterval) * time.Minute
+	}
+}
+
+// ReportInterval periyodik rapor aralığı
+func (w *WebhookNotifier) ReportInterval() time.Duration {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.reportInterval
+}
+
+// TestConnection webhook'a test mesajı gönderir (kanal kapalı olsa da)
+func (w *WebhookNotifier) TestConnection() error {
+	w.mu.Lock()
+	target, format := w.url, w.format
+	w.mu.Unlock()
+	if target == "" {
+		return fmt.Errorf("webhook adresi boş")
+	}
+	return w.post(target, format, "test", "✅ VGBot webhook bağlantı testi başarılı!", nil)
+}
+
+// send kanal açıksa event'i gönderir
+func (w *WebhookNotifier) send(event, text string, stats *SimulationStats) error {
+	w.mu.Lock()
+	target, format, enabled := w.url, w.format, w.enabled
+	w.mu.Unlock()
+	if !enabled || target == "" {
+		return nil
+	}
+	return w.post(target, format, event, text, stats)
+}
+
+func (w *WebhookNotifier) post(target, format, event, text string, stats *SimulationStats) error {
+	body, err := webhookPayload(format, event, text, stats, time.Now())
+	if err != nil {
+		return err
+	}
+	resp, err := w.httpClient.Post(target, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("webhook gönderilemedi: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook hatası (%d): %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// webhookPayload formatına göre JSON gövdeyi oluşturur
+func webhookPayload(format, event, text string, stats *SimulationStats, now time.Time) ([]byte, error) {
+	switch format {
+	case WebhookDiscord:
+		if r := []rune(text); len(r) > discordMaxContent {
+			text = string(r[:discordMaxContent])
+		}
+		return json.Marshal(map[string]string{"content": text})
+	case WebhookJSON:
+		payload := map[string]interface{}{
+			"event":     event,
+			"text":      text,
+			"timestamp": now.UTC().Format(time.RFC3339),
+		}
+		if stats != nil {
+			payload["stats"] = map[string]interface{}{
+				"domain":          stats.Domain,
+				"total_hits":      stats.TotalHits,
+				"successful_hits": stats.SuccessfulHits,
+				"failed_hits":     stats.FailedHits,
+				"success_rate":    stats.SuccessRate,
+				"duration_sec":    int64(stats.Duration.Seconds()),
+				"hits_per_minute": stats.HitsPerMinute,
+				"active_proxies":  stats.ActiveProxies,
+			}
+		}
+		return json.Marshal(payload)
+	default:
+		return json.Marshal(map[string]string{"text": text})
+	}
+}
+
+// SendSimulationStart simülasyon başlangıç bildirimi
+func (w *WebhookNotifier) SendSimulationStart(domain string, durationMin int, hpm int, concurrent int) error {
+	return w.send("simulation_start", startMessage(domain, durationMin, hpm, concurrent), &SimulationStats{Domain: domain})
+}
+
+// SendSimulationEnd simülasyon bitiş bildirimi
+func (w *WebhookNotifier) SendSimulationEnd(stats SimulationStats) error {
+	return w.send("simulation_end", endMessage(stats), &stats)
+}
+
+// SendError hata bildirimi
+func (w *WebhookNotifier) SendError(errMsg string) error {
+	return w.send("error", errorMessage(errMsg), nil)
+}
+
+// SendMonitorAlert uptime izleme uyarısı
+func (w *WebhookNotifier) SendMonitorAlert(targetURL, probe string, down bool, detail string) error {
+	event := "monitor_up"
+	if down {
+		event = "monitor_down"
+	}
+	return w.send(event, monitorMessage(targetURL, probe, down, detail), nil)
+}
+
+// SendPeriodicReport periyodik durum raporu
+func (w *WebhookNotifier) SendPeriodicReport(stats SimulationStats) error {
+	return w.send("periodic_report", reportMessage(stats), &stats)
+}
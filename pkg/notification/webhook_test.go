@@ -0,0 +1,212 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestValidateWebhook(t *testing.T) {
+	for _, ok := range [][2]string{{"", ""}, {"https://hooks.slack.com/services/x", "slack"}, {"http://mm.local/hooks/x", "mattermost"}} {
+		if err := ValidateWebhook(ok[0], ok[1]); err != nil {
+			t.Errorf("%v: %v", ok, err)
+		}
+	}
+	for _, bad := range [][2]string{{"hooks.slack.com/x", ""}, {"ftp://x/y", ""}, {"https://x/y", "teams"}} {
+		if err := ValidateWebhook(bad[0], bad[1]); err == nil {
+			t.Errorf("%v accepted", bad)
+		}
+	}
+}
+
+func TestWebhookPayloadFormats(t *testing.T) {
+	stats := &SimulationStats{Domain: "site.example", TotalHits: 10, SuccessfulHits: 9, Duration: 90 * time.Second}
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	var slack map[string]string
+	body, _ := webhookPayload(WebhookSlack, "simulation_end", "hello", stats, now)
+	if json.Unmarshal(body, &slack); len(slack) != 1 || slack["text"] != "hello" {
+		t.Errorf("slack = %s", body)
+	}
+
+	var discord map[string]string
+	body, _ = webhookPayload(WebhookDiscord, "error", strings.Repeat("ş", 2100), nil, now)
+	if json.Unmarshal(body, &discord); len([]rune(discord["content"])) != discordMaxContent {
+		t.Errorf("discord content not truncated: %d runes", len([]rune(discord["content"])))
+	}
+
+	var generic struct {
+		Event     string                 `json:"event"`
+		Text      string                 `json:"text"`
+		Timestamp string                 `json:"timestamp"`
+		Stats     map[string]interface{} `json:"stats"`
+	}
+	body, _ = webhookPayload(WebhookJSON, "simulation_end", "hello", stats, now)
+	if err := json.Unmarshal(body, &generic); err != nil {
+		t.Fatal(err)
+	}
+	if generic.Event != "simulation_end" || generic.Timestamp != "2024-01-02T03:04:05Z" ||
+		generic.Stats["domain"] != "site.example" || generic.Stats["total_hits"] != float64(10) || generic.Stats["duration_sec"] != float64(90) {
+		t.Errorf("json = %s", body)
+	}
+}
+
+func TestWebhookNotifierSend(t *testing.T) {
+	var mu sync.Mutex
+	var got []string
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		got = append(got, string(b))
+		code := status
+		mu.Unlock()
+		w.WriteHeader(code)
+	}))
+	defer srv.Close()
+
+	n := NewWebhookNotifier(WebhookConfig{URL: srv.URL, Format: WebhookDiscord})
+	if n.IsEnabled() {
+		t.Fatal("disabled notifier reports enabled")
+	}
+	if err := n.SendError("x"); err != nil || len(got) != 0 {
+		t.Fatalf("disabled notifier sent: %v %v", err, got)
+	}
+	// Test mesajı kanal kapalıyken de gider
+	if err := n.TestConnection(); err != nil || len(got) != 1 || !strings.Contains(got[0], `"content"`) {
+		t.Fatalf("test = %v %v", err, got)
+	}
+
+	n.UpdateConfig(WebhookConfig{URL: srv.URL, Enabled: true, ReportInterval: 5})
+	if !n.IsEnabled() || n.ReportInterval() != 5*time.Minute {
+		t.Fatalf("enabled=%v interval=%v", n.IsEnabled(), n.ReportInterval())
+	}
+	if err := n.SendMonitorAlert("https://site.example", "local", true, "timeout"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(got[1], `"text"`) || !strings.Contains(got[1], "Erişilemiyor") {
+		t.Errorf("slack alert = %s", got[1])
+	}
+
+	mu.Lock()
+	status = http.StatusForbidden
+	mu.Unlock()
+	if err := n.SendError("x"); err == nil || !strings.Contains(err.Error(), "403") {
+		t.Errorf("error status = %v", err)
+	}
+}
+
+// fakeNotifier çağrıları sayan kanal
+type fakeNotifier struct {
+	mu       sync.Mutex
+	enabled  bool
+	interval time.Duration
+	calls    map[string]int
+}
+
+func newFake(enabled bool, interval time.Duration) *fakeNotifier {
+	return &fakeNotifier{enabled: enabled, interval: interval, calls: map[string]int{}}
+}
+
+func (f *fakeNotifier) record(name string) error {
+	f.mu.Lock()
+	f.calls[name]++
+	f.mu.Unlock()
+	return nil
+}
+
+func (f *fakeNotifier) count(name string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls[name]
+}
+
+func (f *fakeNotifier) IsEnabled() bool               { return f.enabled }
+func (f *fakeNotifier) ReportInterval() time.Duration { return f.interval }
+func (f *fakeNotifier) SendSimulationStart(string, int, int, int) error {
+	return f.record("start")
+}
+func (f *fakeNotifier) SendSimulationEnd(SimulationStats) error { return f.record("end") }
+func (f *fakeNotifier) SendError(string) error                  { return f.record("error") }
+func (f *fakeNotifier) SendMonitorAlert(string, string, bool, string) error {
+	return f.record("monitor")
+}
+func (f *fakeNotifier) SendPeriodicReport(SimulationStats) error { return f.record("report") }
+
+func TestDispatcher(t *testing.T) {
+	on, off := newFake(true, 20*time.Millisecond), newFake(false, time.Millisecond)
+	d := NewDispatcher(on, off)
+	if !d.IsEnabled() || NewDispatcher(off).IsEnabled() {
+		t.Fatal("IsEnabled")
+	}
+	d.SendSimulationStart("site.example", 1, 1, 1)
+	d.SendMonitorAlert("u", "p", true, "")
+	if on.count("start") != 1 || on.count("monitor") != 1 || off.count("start") != 0 {
+		t.Fatalf("fan-out on=%v off=%v", on.calls, off.calls)
+	}
+
+	d.tick = 5 * time.Millisecond
+	ctx, cancel := context.WithCancel(context.Background())
+	d.StartPeriodicReporting(ctx, func() SimulationStats { return SimulationStats{} })
+	time.Sleep(120 * time.Millisecond)
+	cancel()
+	time.Sleep(20 * time.Millisecond)
+	reports := on.count("report")
+	if reports < 2 || reports > 6 {
+		t.Errorf("reports = %d, want ~5 (every 20ms over 120ms)", reports)
+	}
+	if off.count("report") != 0 {
+		t.Error("disabled channel got a report")
+	}
+	time.Sleep(50 * time.Millisecond)
+	if on.count("report") != reports {
+		t.Error("reporting continued after ctx cancel")
+	}
+}
+
+func TestDispatcherRunBlocksUntilStopped(t *testing.T) {
+	d := NewDispatcher(newFake(true, time.Millisecond))
+	d.tick = time.Millisecond
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		d.RunPeriodicReporting(ctx, func() SimulationStats { return SimulationStats{} })
+		close(done)
+	}()
+	select {
+	case <-done:
+		t.Fatal("RunPeriodicReporting returned before cancel")
+	case <-time.After(20 * time.Millisecond):
+	}
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("RunPeriodicReporting did not return after cancel")
+	}
+
+	// Durdurulduktan sonra yeniden başlatılabilir; Stop döngüyü bitirir
+	done = make(chan struct{})
+	go func() {
+		d.RunPeriodicReporting(context.Background(), func() SimulationStats { return SimulationStats{} })
+		close(done)
+	}()
+	for running := false; !running; {
+		time.Sleep(time.Millisecond)
+		d.mu.Lock()
+		running = d.running
+		d.mu.Unlock()
+	}
+	d.StopPeriodicReporting()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("RunPeriodicReporting did not return after StopPeriodicReporting")
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"sync"
 	"sync/atomic"
 	"time"
+
+	"vgbot/pkg/utils"
 )
 
 // Result proxy kullanım sonucu
@@ -282,11 +284,11 @@ type RandomSelector struct {
 	rng *rand.Rand
 }
 
-// NewRandomSelector yeni rastgele seçici oluşturur
-func NewRandomSelector() *RandomSelector {
+// NewRandomSelector yeni rastgele seçici oluşturur (seeder nil ise seçimler tekrarlanamaz)
+func NewRandomSelector(seeder *utils.Seeder) *RandomSelector {
 	return &RandomSelector{
 		BaseSelector: NewBaseSelector(),
-		rng:        rand.New(rand.NewSource(time.Now().UnixNano())),
+		rng:        seeder.NewRand(),
 	}
 }
 
@@ -477,12 +479,12 @@ type GeoSelector struct {
 	rng                *rand.Rand
 }
 
-// NewGeoSelector yeni geo seçici oluşturur
-func NewGeoSelector(countries []string) *GeoSelector {
+// NewGeoSelector yeni geo seçici oluşturur (seeder nil ise seçimler tekrarlanamaz)
+func NewGeoSelector(countries []string, seeder *utils.Seeder) *GeoSelector {
 	return &GeoSelector{
 		BaseSelector:       NewBaseSelector(),
 		preferredCountries: countries,
-		rng:                rand.New(rand.NewSource(time.Now().UnixNano())),
+		rng:                seeder.NewRand(),
 	}
 }
 
@@ -557,11 +559,11 @@ type WeightedSelector struct {
 	rng *rand.Rand
 }
 
-// NewWeightedSelector yeni ağırlıklı seçici oluşturur
-func NewWeightedSelector() *WeightedSelector {
+// NewWeightedSelector yeni ağırlıklı seçici oluşturur (seeder nil ise seçimler tekrarlanamaz)
+func NewWeightedSelector(seeder *utils.Seeder) *WeightedSelector {
 	return &WeightedSelector{
 		BaseSelector: NewBaseSelector(),
-		rng:        rand.New(rand.NewSource(time.Now().UnixNano())),
+		rng:        seeder.NewRand(),
 	}
 }
 
@@ -692,13 +694,13 @@ const (
 	SelectorWeighted     SelectorType = "weighted"
 )
 
-// NewSelector seçici tipine göre yeni seçici oluşturur
-func NewSelector(selectorType SelectorType) Selector {
+// NewSelector seçici tipine göre yeni seçici oluşturur; rastgele seçiciler seeder'dan beslenir
+func NewSelector(selectorType SelectorType, seeder *utils.Seeder) Selector {
 	switch selectorType {
 	case SelectorRoundRobin:
 		return NewRoundRobinSelector()
 	case SelectorRandom:
-		return NewRandomSelector()
+		return NewRandomSelector(seeder)
 	case SelectorLeastUsed:
 		return NewLeastUsedSelector()
 	case SelectorFastest:
@@ -706,21 +708,21 @@ func NewSelector(selectorType SelectorType) Selector {
 	case SelectorSuccessRate:
 		return NewSuccessRateSelector()
 	case SelectorGeo:
-		return NewGeoSelector(nil)
+		return NewGeoSelector(nil, seeder)
 	case SelectorWeighted:
-		return NewWeightedSelector()
+		return NewWeightedSelector(seeder)
 	default:
 		return NewRoundRobinSelector()
 	}
 }
 
-// NewSelectorFromString string'den seçici oluşturur
-func NewSelectorFromString(name string) Selector {
+// NewSelectorFromString string'den seçici oluşturur; rastgele seçiciler seeder'dan beslenir
+func NewSelectorFromString(name string, seeder *utils.Seeder) Selector {
 	switch name {
 	case "round-robin":
 		return NewRoundRobinSelector()
 	case "random":
-		return NewRandomSelector()
+		return NewRandomSelector(seeder)
 	case "least-used":
 		return NewLeastUsedSelector()
 	case "fastest":
@@ -728,9 +730,9 @@ func NewSelectorFromString(name string) Selector {
 	case "success-rate":
 		return NewSuccessRateSelector()
 	case "geo":
-		return NewGeoSelector(nil)
+		return NewGeoSelector(nil, seeder)
 	case "weighted":
-		return NewWeightedSelector()
+		return NewWeightedSelector(seeder)
 	default:
 		return NewRoundRobinSelector()
 	}
@@ -63,7 +63,7 @@ func TestRoundRobinSelector(t *testing.T) {
 
 func TestRandomSelector(t *testing.T) {
 	pool := &MockLivePool{proxies: createTestProxies()}
-	selector := NewRandomSelector()
+	selector := NewRandomSelector(nil)
 	metrics := NewMetricsCollector()
 	
 	// Boş pool testi
@@ -111,7 +111,7 @@ func TestGeoSelector(t *testing.T) {
 	pool := &MockLivePool{proxies: createTestProxies()}
 	
 	// US proxy'leri seç
-	selector := NewGeoSelector([]string{"US"})
+	selector := NewGeoSelector([]string{"US"}, nil)
 	metrics := NewMetricsCollector()
 	
 	// Birçok seçim yap ve hepsinin US olup olmadığını kontrol et
@@ -134,7 +134,7 @@ func TestGeoSelector(t *testing.T) {
 	}
 	
 	// DE ve FR için test
-	selectorDE := NewGeoSelector([]string{"DE", "FR"})
+	selectorDE := NewGeoSelector([]string{"DE", "FR"}, nil)
 	for i := 0; i < 50; i++ {
 		p := selectorDE.Select(pool, metrics)
 		if p == nil {
@@ -151,7 +151,7 @@ func TestGeoSelector(t *testing.T) {
 	}
 	
 	// Olmayan ülke için rastgele seçim
-	selectorXX := NewGeoSelector([]string{"XX"})
+	selectorXX := NewGeoSelector([]string{"XX"}, nil)
 	p := selectorXX.Select(pool, metrics)
 	if p == nil {
 		t.Fatal("Expected proxy for non-existent country (fallback), got nil")
@@ -162,7 +162,7 @@ func TestGeoSelector(t *testing.T) {
 
 func TestWeightedSelector(t *testing.T) {
 	pool := &MockLivePool{proxies: createTestProxies()}
-	selector := NewWeightedSelector()
+	selector := NewWeightedSelector(nil)
 	metrics := NewMetricsCollector()
 	
 	// Bazı proxy'lere başarı metrikleri ekle
@@ -321,7 +321,7 @@ func TestNewSelectorFactory(t *testing.T) {
 	}
 	
 	for _, tt := range tests {
-		selector := NewSelector(tt.selectorType)
+		selector := NewSelector(tt.selectorType, nil)
 		if selector.Name() != tt.expectedName {
 			t.Errorf("NewSelector(%s) = %s, expected %s", tt.selectorType, selector.Name(), tt.expectedName)
 		}
@@ -344,7 +344,7 @@ func TestNewSelectorFromString(t *testing.T) {
 	}
 	
 	for _, tt := range tests {
-		selector := NewSelectorFromString(tt.name)
+		selector := NewSelectorFromString(tt.name, nil)
 		if selector.Name() != tt.expectedName {
 			t.Errorf("NewSelectorFromString(%s) = %s, expected %s", tt.name, selector.Name(), tt.expectedName)
 		}
@@ -378,7 +378,7 @@ func TestSelectorManager(t *testing.T) {
 	}
 	
 	// Seçici değiştir
-	manager.SetSelector(NewRandomSelector())
+	manager.SetSelector(NewRandomSelector(nil))
 	if manager.CurrentSelectorName() != "random" {
 		t.Errorf("Expected selector name 'random', got %s", manager.CurrentSelectorName())
 	}
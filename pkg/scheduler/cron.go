@@ -0,0 +1,147 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cron 5 alanlı cron ifadesi: dakika saat ayın-günü ay haftanın-günü.
+// Desteklenen: "*", "5", "1-5", "1,15", "*/10", "9-17/2"; haftanın günü 0-7 (0 ve 7 = Pazar).
+// Kısaltmalar: @hourly, @daily, @weekly, @monthly.
+type Cron struct {
+	expr   string
+	minute [60]bool
+	hour   [24]bool
+	dom    [32]bool
+	month  [13]bool
+	dow    [7]bool
+	// Ayın günü ve haftanın günü ikisi de kısıtlıysa cron geleneğine göre biri yeterlidir
+	domAny, dowAny bool
+}
+
+var cronAliases = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+}
+
+// ParseCron cron ifadesini ayrıştırır
+func ParseCron(expr string) (*Cron, error) {
+	expr = strings.TrimSpace(expr)
+	spec := expr
+	if alias, ok := cronAliases[strings.ToLower(spec)]; ok {
+		spec = alias
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron %q: 5 alan bekleniyor (dakika saat gün ay haftagünü)", expr)
+	}
+	c := &Cron{expr: expr, domAny: fields[2] == "*", dowAny: fields[4] == "*"}
+	var dow [8]bool
+	for _, f := range []struct {
+		name     string
+		field    string
+		min, max int
+		set      []bool
+	}{
+		{"dakika", fields[0], 0, 59, c.minute[:]},
+		{"saat", fields[1], 0, 23, c.hour[:]},
+		{"gün", fields[2], 1, 31, c.dom[:]},
+		{"ay", fields[3], 1, 12, c.month[:]},
+		{"haftagünü", fields[4], 0, 7, dow[:]},
+	} {
+		if err := parseCronField(f.field, f.min, f.max, f.set); err != nil {
+			return nil, fmt.Errorf("cron %q, %s: %w", expr, f.name, err)
+		}
+	}
+	copy(c.dow[:], dow[:7])
+	c.dow[0] = c.dow[0] || dow[7]
+	return c, nil
+}
+
+// parseCronField virgülle ayrılmış parçaları set'e işler
+func parseCronField(field string, min, max int, set []bool) error {
+	for _, part := range strings.Split(field, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepStr)
+			if err != nil || n <= 0 {
+				return fmt.Errorf("geçersiz adım %q", stepStr)
+			}
+			step = n
+		}
+		lo, hi := min, max
+		if rng != "*" {
+			a, b, isRange := strings.Cut(rng, "-")
+			var err error
+			if lo, err = strconv.Atoi(a); err != nil {
+				return fmt.Errorf("geçersiz değer %q", part)
+			}
+			hi = lo
+			if isRange {
+				if hi, err = strconv.Atoi(b); err != nil {
+					return fmt.Errorf("geçersiz değer %q", part)
+				}
+			} else if hasStep {
+				hi = max
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return fmt.Errorf("%q %d-%d aralığı dışında", part, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			set[v] = true
+		}
+	}
+	return nil
+}
+
+// String ifadenin orijinal hali
+func (c *Cron) String() string {
+	return c.expr
+}
+
+// dayMatches t'nin günü ifadeye uyuyor mu
+func (c *Cron) dayMatches(t time.Time) bool {
+	dom, dow := c.dom[t.Day()], c.dow[int(t.Weekday())]
+	switch {
+	case c.domAny && c.dowAny:
+		return true
+	case c.domAny:
+		return dow
+	case c.dowAny:
+		return dom
+	default:
+		return dom || dow
+	}
+}
+
+// Next after'dan sonraki ilk eşleşen dakikayı döner (t'nin saat diliminde); 5 yıl içinde yoksa sıfır zaman
+func (c *Cron) Next(after time.Time) time.Time {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := after.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if !c.month[int(t.Month())] {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !c.hour[t.Hour()] {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if !c.minute[t.Minute()] {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
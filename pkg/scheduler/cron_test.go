@@ -0,0 +1,45 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronErrors(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8", "*/0 * * * *", "5-1 * * * *", "a * * * *"} {
+		if _, err := ParseCron(expr); err == nil {
+			t.Errorf("ParseCron(%q) accepted", expr)
+		}
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	// 2024-01-12 Cuma
+	fri := time.Date(2024, 1, 12, 10, 7, 30, 0, time.UTC)
+	cases := []struct {
+		expr  string
+		after time.Time
+		want  time.Time
+	}{
+		{"*/15 * * * *", fri, time.Date(2024, 1, 12, 10, 15, 0, 0, time.UTC)},
+		{"0 9 * * 1-5", fri, time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)},
+		{"30 8,20 * * *", fri, time.Date(2024, 1, 12, 20, 30, 0, 0, time.UTC)},
+		{"0 0 * * 7", fri, time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", fri, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"5/20 10 * * *", fri, time.Date(2024, 1, 12, 10, 25, 0, 0, time.UTC)},
+		// Ayın günü ve haftanın günü birlikte: biri yeterli (13'ü veya Cuma)
+		{"0 12 13 * 5", fri, time.Date(2024, 1, 12, 12, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", fri, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// Tam dakikada: aynı dakika değil sonraki eşleşme
+		{"0 10 * * *", time.Date(2024, 1, 12, 10, 0, 0, 0, time.UTC), time.Date(2024, 1, 13, 10, 0, 0, 0, time.UTC)},
+	}
+	for _, c := range cases {
+		cron, err := ParseCron(c.expr)
+		if err != nil {
+			t.Fatalf("ParseCron(%q): %v", c.expr, err)
+		}
+		if got := cron.Next(c.after); !got.Equal(c.want) {
+			t.Errorf("%q.Next(%s) = %s, want %s", c.expr, c.after, got, c.want)
+		}
+	}
+}
@@ -5,25 +5,103 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 	"sync"
 	"time"
 )
 
 // Job zamanlı iş tanımı
 type Job struct {
-	ID             string    `json:"id"`
-	Name           string    `json:"name"`
-	Enabled        bool      `json:"enabled"`
-	DaysOfWeek     []string  `json:"days_of_week"`     // "monday","tuesday",... veya "daily","weekday","weekend"
-	StartHour      int       `json:"start_hour"`        // Başlangıç saati (0-23)
-	StartMinute    int       `json:"start_minute"`      // Başlangıç dakikası (0-59)
-	Duration       int       `json:"duration"`          // Süre (dakika)
-	Domain         string    `json:"domain"`            // Hedef domain (boşsa mevcut config kullanılır)
-	HitsPerMinute  int       `json:"hits_per_minute"`   // HPM override (0 = mevcut config)
-	MaxConcurrent  int       `json:"max_concurrent"`    // Concurrent override (0 = mevcut config)
-	LastRun        time.Time `json:"last_run"`
-	NextRun        time.Time `json:"next_run"`
-	RunCount       int       `json:"run_count"`
+	ID              string      `json:"id"`
+	Name            string      `json:"name"`
+	Enabled         bool        `json:"enabled"`
+	DaysOfWeek      []string    `json:"days_of_week"`               // "monday","tuesday",... veya "daily","weekday","weekend"
+	StartHour       int         `json:"start_hour"`                 // Başlangıç saati (0-23)
+	StartMinute     int         `json:"start_minute"`               // Başlangıç dakikası (0-59)
+	Duration        int         `json:"duration"`                   // Süre (dakika)
+	Domain          string      `json:"domain"`                     // Hedef domain (boşsa mevcut config kullanılır)
+	HitsPerMinute   int         `json:"hits_per_minute"`            // HPM override (0 = mevcut config)
+	MaxConcurrent   int         `json:"max_concurrent"`             // Concurrent override (0 = mevcut config)
+	ActiveWindows   []string    `json:"active_windows,omitempty"`   // Yalnızca bu aralıklarda çalış ("weekday 09:00-18:00")
+	BlackoutWindows []string    `json:"blackout_windows,omitempty"` // Asla çalışma ("02:00-05:00")
+	WeeklyPlan      *WeeklyPlan `json:"weekly_plan,omitempty"`      // Gün bazlı hedef hit + saatlik eğri (StartHour/Duration'ı ezer)
+	Cron            string      `json:"cron,omitempty"`             // Cron ifadesi ("0 9 * * 1-5"); doluysa DaysOfWeek/StartHour/StartMinute yerine kullanılır
+	RunAt           *time.Time  `json:"run_at,omitempty"`           // Tek seferlik iş: bu anda bir kez çalışır, sonra devre dışı kalır
+	LastRun         time.Time   `json:"last_run"`
+	NextRun         time.Time   `json:"next_run"`
+	RunCount        int         `json:"run_count"`
+	LastStatus      string      `json:"last_status,omitempty"` // Son tetiklemenin sonucu (Status* sabitleri)
+	LastError       string      `json:"last_error,omitempty"`
+}
+
+// Son tetikleme durumları
+const (
+	StatusStarted  = "started"  // Simülasyon başlatıldı
+	StatusFinished = "finished" // Süre doldu, durduruldu
+	StatusFailed   = "failed"   // Başlatılamadı (LastError)
+	StatusSkipped  = "skipped"  // Başka iş çalışıyordu veya zaman penceresi dışındaydı
+	StatusMissed   = "missed"   // Zamanında tetiklenemedi (ör. sunucu kapalıydı)
+)
+
+// Validate iş tanımını doğrular
+func (j *Job) Validate() error {
+	if _, err := NewWindowPlan(j.ActiveWindows, j.BlackoutWindows); err != nil {
+		return fmt.Errorf("geçersiz zaman penceresi: %w", err)
+	}
+	if err := j.WeeklyPlan.Validate(); err != nil {
+		return fmt.Errorf("geçersiz haftalık plan: %w", err)
+	}
+	if j.Cron != "" {
+		if _, err := ParseCron(j.Cron); err != nil {
+			return err
+		}
+	}
+	if j.StartHour < 0 || j.StartHour > 23 || j.StartMinute < 0 || j.StartMinute > 59 {
+		return fmt.Errorf("start_hour 0-23, start_minute 0-59 arası olmalı")
+	}
+	for _, d := range j.DaysOfWeek {
+		if !isValidDay(strings.ToLower(strings.TrimSpace(d))) {
+			return fmt.Errorf("geçersiz gün: %q", d)
+		}
+	}
+	if j.Duration < 0 || j.HitsPerMinute < 0 || j.MaxConcurrent < 0 {
+		return fmt.Errorf("duration, hits_per_minute ve max_concurrent negatif olamaz")
+	}
+	return nil
+}
+
+// OneShot tek seferlik iş mi
+func (j *Job) OneShot() bool {
+	return j.RunAt != nil && j.WeeklyPlan == nil
+}
+
+// Next after'dan sonraki tetikleme anını döner (after'ın saat diliminde); yoksa sıfır zaman.
+// Öncelik: haftalık plan (her saat başı) > tek seferlik > cron > gün/saat/dakika.
+func (j *Job) Next(after time.Time) time.Time {
+	loc := after.Location()
+	switch {
+	case j.WeeklyPlan != nil:
+		return time.Date(after.Year(), after.Month(), after.Day(), after.Hour()+1, 0, 0, 0, loc)
+	case j.RunAt != nil:
+		// Henüz çalışmadıysa geçmiş olsa da döner; scheduler gecikmeyi kaçırılmış sayar
+		if j.LastRun.Before(*j.RunAt) {
+			return j.RunAt.In(loc)
+		}
+		return time.Time{}
+	case j.Cron != "":
+		c, err := ParseCron(j.Cron)
+		if err != nil {
+			return time.Time{}
+		}
+		return c.Next(after)
+	}
+	for d := 0; d <= 7; d++ {
+		t := time.Date(after.Year(), after.Month(), after.Day()+d, j.StartHour, j.StartMinute, 0, 0, loc)
+		if t.After(after) && dayMatches(j.DaysOfWeek, t) {
+			return t
+		}
+	}
+	return time.Time{}
 }
 
 // JobStorage iş kalıcılığı
@@ -43,6 +121,15 @@ func NewJobStorage(filePath string) *JobStorage {
 	return s
 }
 
+// Reload dosya yolunu değiştirip işleri yeniden yükler (ör. yedekten geri yükleme sonrası)
+func (s *JobStorage) Reload(filePath string) error {
+	s.mu.Lock()
+	s.filePath = filePath
+	s.jobs = make([]*Job, 0)
+	s.mu.Unlock()
+	return s.Load()
+}
+
 // Load dosyadan işleri yükler
 func (s *JobStorage) Load() error {
 	s.mu.Lock()
@@ -78,8 +165,11 @@ func (s *JobStorage) Save() error {
 	return os.WriteFile(s.filePath, data, 0644)
 }
 
-// AddJob yeni iş ekler
+// AddJob yeni iş ekler (ID boşsa üretilir)
 func (s *JobStorage) AddJob(job *Job) error {
+	if job.ID == "" {
+		job.ID = fmt.Sprintf("job_%d", time.Now().UnixNano())
+	}
 	s.mu.Lock()
 	// ID kontrolü
 	for _, j := range s.jobs {
@@ -123,25 +213,47 @@ func (s *JobStorage) UpdateJob(job *Job) error {
 	return s.Save()
 }
 
-// GetJob ID'ye göre iş döner
+// Update ID'li işi kilit altında fn ile değiştirip kaydeder
+func (s *JobStorage) Update(id string, fn func(job *Job)) error {
+	s.mu.Lock()
+	var found bool
+	for _, j := range s.jobs {
+		if j.ID == id {
+			fn(j)
+			found = true
+			break
+		}
+	}
+	s.mu.Unlock()
+	if !found {
+		return fmt.Errorf("iş bulunamadı: %s", id)
+	}
+	return s.Save()
+}
+
+// GetJob ID'ye göre işin kopyasını döner
 func (s *JobStorage) GetJob(id string) *Job {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	for _, j := range s.jobs {
 		if j.ID == id {
-			return j
+			copied := *j
+			return &copied
 		}
 	}
 	return nil
 }
 
-// ListJobs tüm işleri listeler
+// ListJobs tüm işlerin kopyalarını listeler (scheduler işleri eşzamanlı güncelleyebilir)
 func (s *JobStorage) ListJobs() []*Job {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	result := make([]*Job, len(s.jobs))
-	copy(result, s.jobs)
+	for i, j := range s.jobs {
+		copied := *j
+		result[i] = &copied
+	}
 	return result
 }
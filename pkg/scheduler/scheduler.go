@@ -4,13 +4,13 @@ package scheduler
 import (
 	"context"
 	"log"
-	"strings"
 	"sync"
 	"time"
 )
 
-// SimulationStartFunc simülasyon başlatma callback fonksiyonu
-type SimulationStartFunc func(domain string, durationMin int, hpm int, maxConcurrent int) error
+// SimulationStartFunc simülasyon başlatma callback fonksiyonu; job'un Domain/MaxConcurrent alanları
+// override'dır (boş/0 = mevcut config), hpm 0 ise mevcut config'in HPM'i kullanılır
+type SimulationStartFunc func(job Job, durationMin int, hpm int) error
 
 // SimulationStopFunc simülasyon durdurma callback fonksiyonu
 type SimulationStopFunc func() error
@@ -18,6 +18,7 @@ type SimulationStopFunc func() error
 // Scheduler zamanlı görev yöneticisi
 type Scheduler struct {
 	mu             sync.Mutex
+	runMu          sync.Mutex // startFn/stopFn çağrılarını sıralar (devir ile süre dolumu çakışmasın)
 	storage        *JobStorage
 	running        bool
 	cancel         context.CancelFunc
@@ -26,6 +27,7 @@ type Scheduler struct {
 	activeJobID    string
 	activeJobTimer *time.Timer
 	location       *time.Location
+	windows        *WindowPlan
 }
 
 // NewScheduler yeni scheduler oluşturur
@@ -85,8 +87,11 @@ func (s *Scheduler) Stop() {
 		s.cancel()
 	}
 	if s.activeJobTimer != nil {
+		// Çalışan simülasyon kendi süresi dolunca biter; yeniden başlatmada işler engellenmesin
 		s.activeJobTimer.Stop()
+		s.activeJobTimer = nil
 	}
+	s.activeJobID = ""
 	s.running = false
 	log.Println("[SCHEDULER] Scheduler durduruldu")
 }
@@ -98,11 +103,12 @@ func (s *Scheduler) IsRunning() bool {
 	return s.running
 }
 
-// loop ana scheduler döngüsü - her dakika kontrol eder
+// loop ana scheduler döngüsü - başlangıçta ve her 30 saniyede bir kontrol eder
 func (s *Scheduler) loop(ctx context.Context) {
 	ticker := time.NewTicker(30 * time.Second)
 	defer ticker.Stop()
 
+	s.checkAndRunJobs()
 	for {
 		select {
 		case <-ctx.Done():
@@ -113,125 +119,194 @@ func (s *Scheduler) loop(ctx context.Context) {
 	}
 }
 
-// checkAndRunJobs zamanı gelen işleri kontrol eder ve çalıştırır
-func (s *Scheduler) checkAndRunJobs() {
+// missedGrace planlanan andan bu kadar geç fark edilen tetikleme çalıştırılmaz (kaçırıldı sayılır)
+const missedGrace = 5 * time.Minute
+
+// now scheduler saat dilimindeki şu an
+func (s *Scheduler) now() time.Time {
 	s.mu.Lock()
-	if s.activeJobID != "" {
-		// Zaten çalışan iş var
-		s.mu.Unlock()
-		return
-	}
+	loc := s.location
 	s.mu.Unlock()
+	return time.Now().In(loc)
+}
 
-	now := time.Now().In(s.location)
-	jobs := s.storage.ListJobs()
-
-	for _, job := range jobs {
+// checkAndRunJobs zamanı gelen işleri çalıştırır. NextRun'ı olmayan (yeni eklenen, güncellenen)
+// işlerin sonraki çalışma zamanı hesaplanır; NextRun dosyada tutulduğundan yeniden başlatmada korunur.
+func (s *Scheduler) checkAndRunJobs() {
+	now := s.now()
+	for _, job := range s.storage.ListJobs() {
 		if !job.Enabled {
 			continue
 		}
-
-		// Bugün çalışmalı mı kontrol et
-		if !s.shouldRunToday(job, now) {
-			continue
-		}
-
-		// Saat ve dakika kontrolü
-		if now.Hour() != job.StartHour || now.Minute() != job.StartMinute {
+		if job.NextRun.IsZero() {
+			if next := job.Next(now); !next.IsZero() {
+				_ = s.storage.Update(job.ID, func(j *Job) { j.NextRun = next })
+			}
 			continue
 		}
-
-		// Son 2 dakika içinde çalıştıysa atla (duplicate önleme)
-		if !job.LastRun.IsZero() && time.Since(job.LastRun) < 2*time.Minute {
+		if now.Before(job.NextRun) {
 			continue
 		}
-
-		// İşi çalıştır
-		s.runJob(job)
-		break // Bir seferde bir iş
+		s.fire(job, now)
 	}
 }
 
-// shouldRunToday iş bugün çalışmalı mı
-func (s *Scheduler) shouldRunToday(job *Job, now time.Time) bool {
-	if len(job.DaysOfWeek) == 0 {
-		return true // Boşsa her gün
-	}
-
-	today := strings.ToLower(now.Weekday().String())
-	isWeekday := now.Weekday() >= time.Monday && now.Weekday() <= time.Friday
-	isWeekend := now.Weekday() == time.Saturday || now.Weekday() == time.Sunday
-
-	for _, day := range job.DaysOfWeek {
-		day = strings.ToLower(strings.TrimSpace(day))
-		switch day {
-		case "daily":
-			return true
-		case "weekday", "weekdays":
-			if isWeekday {
-				return true
-			}
-		case "weekend", "weekends":
-			if isWeekend {
-				return true
+// fire zamanı gelen işi çalıştırır (veya atlar) ve sonucu ile sonraki çalışma zamanını kaydeder
+func (s *Scheduler) fire(job *Job, now time.Time) {
+	status, errMsg := "", ""
+	switch active := s.GetActiveJobID(); {
+	case now.Sub(job.NextRun) > missedGrace:
+		status = StatusMissed
+		log.Printf("[SCHEDULER] İş zamanında çalıştırılamadı, atlanıyor: %s (%s)", job.Name, job.NextRun.Format(time.RFC3339))
+	case active != "" && active != job.ID:
+		// Aynı işin süren çalıştırması engel değil; runJobWith onu yenisine devreder
+		status, errMsg = StatusSkipped, "başka bir iş çalışıyor"
+	case !s.inWindow(job, now):
+		status, errMsg = StatusSkipped, "zaman penceresi dışında"
+		log.Printf("[SCHEDULER] İş zaman penceresi dışında, atlanıyor: %s", job.Name)
+	default:
+		duration, hpm := job.Duration, job.HitsPerMinute
+		if duration <= 0 {
+			duration = 60
+		}
+		if job.WeeklyPlan != nil {
+			// Haftalık plan: her saat başında o saatin payı kadar çalıştır
+			hpm, duration = job.WeeklyPlan.Slot(now)
+			if f := SpikeFactor(job.WeeklyPlan.Spikes, now); f != 1 && hpm > 0 {
+				log.Printf("[SCHEDULER] Event spike aktif: %s (x%.2f, %d HPM)", job.Name, f, hpm)
 			}
-		default:
-			if day == today {
-				return true
+		}
+		if hpm > 0 || job.WeeklyPlan == nil {
+			status = StatusStarted
+			if err := s.runJobWith(job, duration, hpm); err != nil {
+				status, errMsg = StatusFailed, err.Error()
 			}
 		}
 	}
 
-	return false
+	_ = s.storage.Update(job.ID, func(j *Job) {
+		if status != "" {
+			j.LastStatus, j.LastError = status, errMsg
+		}
+		if status == StatusStarted {
+			j.LastRun = now
+			j.RunCount++
+		}
+		if j.OneShot() {
+			j.Enabled = false
+			j.NextRun = time.Time{}
+			return
+		}
+		j.NextRun = j.Next(now)
+	})
 }
 
-// runJob işi çalıştırır
-func (s *Scheduler) runJob(job *Job) {
+// inWindow global ve işe özel aktif/blackout pencereleri now anına izin veriyor mu
+func (s *Scheduler) inWindow(job *Job, now time.Time) bool {
 	s.mu.Lock()
-	s.activeJobID = job.ID
+	global := s.windows
 	s.mu.Unlock()
+	if !global.Allowed(now) {
+		return false
+	}
+	plan, err := NewWindowPlan(job.ActiveWindows, job.BlackoutWindows)
+	if err != nil {
+		log.Printf("[SCHEDULER] Geçersiz zaman penceresi (%s): %v", job.Name, err)
+		return false
+	}
+	return plan.Allowed(now)
+}
 
-	domain := job.Domain
-	hpm := job.HitsPerMinute
-	maxConcurrent := job.MaxConcurrent
-	duration := job.Duration
+// SetWindowPlan tüm işler için geçerli aktif/blackout pencerelerini ayarlar (nil = kısıt yok)
+func (s *Scheduler) SetWindowPlan(p *WindowPlan) {
+	s.mu.Lock()
+	s.windows = p
+	s.mu.Unlock()
+}
 
-	if duration <= 0 {
-		duration = 60
+// runJobWith işi verilen süre (dakika) ve HPM ile başlatır; süre sonunda stopFn çağrılır.
+// Aynı işin önceki çalıştırması hâlâ sürüyorsa (ör. haftalık planın bir önceki saati) önce o durdurulur.
+func (s *Scheduler) runJobWith(job *Job, duration int, hpm int) error {
+	s.runMu.Lock()
+	defer s.runMu.Unlock()
+
+	s.mu.Lock()
+	rollover := s.activeJobID == job.ID && s.activeJobTimer != nil
+	if rollover {
+		s.activeJobTimer.Stop()
+		s.activeJobTimer = nil
+	}
+	s.activeJobID = job.ID
+	s.mu.Unlock()
+
+	if rollover {
+		log.Printf("[SCHEDULER] Önceki çalıştırma devrediliyor: %s", job.Name)
+		if s.stopFn != nil {
+			_ = s.stopFn()
+		}
 	}
 
 	log.Printf("[SCHEDULER] İş başlatılıyor: %s (Domain: %s, Süre: %d dk, HPM: %d)",
-		job.Name, domain, duration, hpm)
+		job.Name, job.Domain, duration, hpm)
 
 	// Simülasyonu başlat
 	if s.startFn != nil {
-		if err := s.startFn(domain, duration, hpm, maxConcurrent); err != nil {
+		if err := s.startFn(*job, duration, hpm); err != nil {
 			log.Printf("[SCHEDULER] İş başlatma hatası: %v", err)
 			s.mu.Lock()
 			s.activeJobID = ""
 			s.mu.Unlock()
-			return
+			return err
 		}
 	}
 
-	// İş bilgilerini güncelle
-	job.LastRun = time.Now()
-	job.RunCount++
-	_ = s.storage.UpdateJob(job)
-
 	// Süre sonunda otomatik durdur
+	jobID := job.ID
 	s.mu.Lock()
-	s.activeJobTimer = time.AfterFunc(time.Duration(duration)*time.Minute, func() {
-		log.Printf("[SCHEDULER] İş süresi doldu, durduruluyor: %s", job.Name)
-		if s.stopFn != nil {
-			_ = s.stopFn()
-		}
+	var timer *time.Timer
+	timer = time.AfterFunc(time.Duration(duration)*time.Minute, func() {
+		s.runMu.Lock()
+		defer s.runMu.Unlock()
 		s.mu.Lock()
+		if s.activeJobTimer != timer {
+			// Çalıştırma yenisine devredildi veya scheduler durduruldu
+			s.mu.Unlock()
+			return
+		}
 		s.activeJobID = ""
 		s.activeJobTimer = nil
 		s.mu.Unlock()
+
+		log.Printf("[SCHEDULER] İş süresi doldu, durduruluyor: %s", job.Name)
+		if s.stopFn != nil {
+			_ = s.stopFn()
+		}
+		_ = s.storage.Update(jobID, func(j *Job) {
+			if j.LastStatus == StatusStarted {
+				j.LastStatus = StatusFinished
+			}
+		})
 	})
+	s.activeJobTimer = timer
+	s.mu.Unlock()
+	return nil
+}
+
+// Status scheduler ve işlerin anlık durumu (API için)
+type Status struct {
+	Running     bool   `json:"running"`
+	ActiveJobID string `json:"active_job_id,omitempty"`
+	Timezone    string `json:"timezone"`
+	Jobs        []*Job `json:"jobs"`
+}
+
+// Status anlık durumu döner
+func (s *Scheduler) Status() Status {
+	s.mu.Lock()
+	st := Status{Running: s.running, ActiveJobID: s.activeJobID, Timezone: s.location.String()}
 	s.mu.Unlock()
+	st.Jobs = s.storage.ListJobs()
+	return st
 }
 
 // GetActiveJobID çalışan işin ID'sini döner
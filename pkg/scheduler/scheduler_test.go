@@ -0,0 +1,111 @@
+package scheduler
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCheckAndRunJobs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.json")
+	storage := NewJobStorage(path)
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	storage.AddJob(&Job{ID: "cron", Name: "cron", Enabled: true, Cron: "*/5 * * * *", Domain: "a.example", Duration: 30, NextRun: now.Add(-time.Minute)})
+	storage.AddJob(&Job{ID: "missed", Enabled: true, RunAt: &past, NextRun: past})
+	storage.AddJob(&Job{ID: "new", Enabled: true, StartHour: 3})
+	storage.AddJob(&Job{ID: "off", Enabled: false, NextRun: past})
+
+	var started []Job
+	s := NewScheduler(storage, func(job Job, durationMin, hpm int) error {
+		started = append(started, job)
+		if durationMin != 30 {
+			t.Errorf("duration = %d", durationMin)
+		}
+		return nil
+	}, nil)
+	s.checkAndRunJobs()
+	s.mu.Lock()
+	if s.activeJobTimer != nil {
+		s.activeJobTimer.Stop()
+	}
+	s.mu.Unlock()
+
+	if len(started) != 1 || started[0].Domain != "a.example" {
+		t.Fatalf("started = %+v", started)
+	}
+	if s.GetActiveJobID() != "cron" {
+		t.Errorf("active = %q", s.GetActiveJobID())
+	}
+
+	// Yeniden başlatma: durum dosyadan okunur
+	reloaded := NewJobStorage(path)
+	cron := reloaded.GetJob("cron")
+	if cron.LastStatus != StatusStarted || cron.RunCount != 1 || !cron.NextRun.After(now) || cron.NextRun.Minute()%5 != 0 {
+		t.Errorf("cron = %+v", cron)
+	}
+	if missed := reloaded.GetJob("missed"); missed.LastStatus != StatusMissed || missed.Enabled || !missed.NextRun.IsZero() {
+		t.Errorf("missed = %+v", missed)
+	}
+	if j := reloaded.GetJob("new"); j.NextRun.IsZero() || j.NextRun.Hour() != 3 || j.LastStatus != "" {
+		t.Errorf("new = %+v", j)
+	}
+	if off := reloaded.GetJob("off"); off.LastStatus != "" {
+		t.Errorf("disabled job fired: %+v", off)
+	}
+}
+
+func TestBusySchedulerSkipsJob(t *testing.T) {
+	storage := NewJobStorage(filepath.Join(t.TempDir(), "jobs.json"))
+	storage.AddJob(&Job{ID: "a", Enabled: true, Cron: "@hourly", NextRun: time.Now().Add(-time.Minute)})
+	s := NewScheduler(storage, func(Job, int, int) error {
+		t.Error("job started while another is active")
+		return nil
+	}, nil)
+	s.activeJobID = "other"
+	s.checkAndRunJobs()
+	if j := storage.GetJob("a"); j.LastStatus != StatusSkipped || j.RunCount != 0 || !j.NextRun.After(time.Now()) {
+		t.Errorf("job = %+v", j)
+	}
+}
+
+func TestWeeklyPlanJobRollsOverHourly(t *testing.T) {
+	storage := NewJobStorage(filepath.Join(t.TempDir(), "jobs.json"))
+	// 4320 / 24 = 180 hit/saat -> 3 HPM, 60 dakika: önceki saatin çalıştırması bir sonraki tetiklemede hâlâ sürer
+	plan := &WeeklyPlan{Days: map[string]*DayPlan{"monday": {Enabled: true, TargetHits: 4320, Curve: "flat"}}}
+	first := time.Date(2024, 6, 3, 10, 0, 0, 0, time.Local)
+	storage.AddJob(&Job{ID: "w", Name: "weekly", Enabled: true, WeeklyPlan: plan, NextRun: first})
+
+	var starts, stops int
+	s := NewScheduler(storage, func(job Job, durationMin, hpm int) error {
+		starts++
+		if durationMin != 60 || hpm != 3 {
+			t.Errorf("slot = %d dk, %d HPM", durationMin, hpm)
+		}
+		return nil
+	}, func() error {
+		stops++
+		return nil
+	})
+	s.fire(storage.GetJob("w"), first)
+	second := storage.GetJob("w")
+	if !second.NextRun.Equal(first.Add(time.Hour)) {
+		t.Fatalf("next run = %v", second.NextRun)
+	}
+	s.fire(second, second.NextRun)
+	s.mu.Lock()
+	if s.activeJobTimer != nil {
+		s.activeJobTimer.Stop()
+	}
+	s.mu.Unlock()
+
+	if starts != 2 || stops != 1 {
+		t.Errorf("starts = %d, stops = %d, want 2/1", starts, stops)
+	}
+	if j := storage.GetJob("w"); j.LastStatus != StatusStarted || j.RunCount != 2 || !j.LastRun.Equal(second.NextRun) {
+		t.Errorf("job = %+v", j)
+	}
+	if s.GetActiveJobID() != "w" {
+		t.Errorf("active = %q", s.GetActiveJobID())
+	}
+}
@@ -0,0 +1,124 @@
+package scheduler
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Ramp şekilleri
+const (
+	RampStep     = "step"     // Başlangıçta tam çarpan, bitişte normale dön
+	RampLinear   = "linear"   // RampMinutes boyunca doğrusal yükseliş, tepe, doğrusal iniş
+	RampTriangle = "triangle" // Sürenin ortasında tepe yapan üçgen
+)
+
+// EventSpike temel planın üzerine bindirilen tek seferlik trafik artışı (Black Friday vb.)
+type EventSpike struct {
+	Name            string    `json:"name"`
+	Start           time.Time `json:"start"`
+	DurationMinutes int       `json:"duration_minutes"`
+	Multiplier      float64   `json:"multiplier"`             // Tepe noktasında temel trafiğin katı (ör. 3.0)
+	Ramp            string    `json:"ramp,omitempty"`         // step | linear | triangle (varsayılan linear)
+	RampMinutes     int       `json:"ramp_minutes,omitempty"` // linear için yükseliş/iniş süresi (varsayılan sürenin %25'i)
+}
+
+// Validate spike tanımını doğrular
+func (e EventSpike) Validate() error {
+	if e.Start.IsZero() {
+		return fmt.Errorf("spike %q: başlangıç zamanı gerekli", e.Name)
+	}
+	if e.DurationMinutes <= 0 {
+		return fmt.Errorf("spike %q: süre pozitif olmalı", e.Name)
+	}
+	if e.Multiplier <= 0 {
+		return fmt.Errorf("spike %q: çarpan pozitif olmalı", e.Name)
+	}
+	switch e.Ramp {
+	case "", RampStep, RampLinear, RampTriangle:
+	default:
+		return fmt.Errorf("spike %q: bilinmeyen ramp %q", e.Name, e.Ramp)
+	}
+	if e.RampMinutes < 0 || e.RampMinutes*2 > e.DurationMinutes {
+		return fmt.Errorf("spike %q: ramp_minutes sürenin yarısını geçemez", e.Name)
+	}
+	return nil
+}
+
+// End spike bitiş zamanı
+func (e EventSpike) End() time.Time {
+	return e.Start.Add(time.Duration(e.DurationMinutes) * time.Minute)
+}
+
+// Factor t anındaki trafik çarpanı (spike dışında 1)
+func (e EventSpike) Factor(t time.Time) float64 {
+	if t.Before(e.Start) || !t.Before(e.End()) {
+		return 1
+	}
+	return 1 + (e.Multiplier-1)*e.shape(t)
+}
+
+// shape 0..1 arası ramp yoğunluğu
+func (e EventSpike) shape(t time.Time) float64 {
+	total := float64(e.DurationMinutes)
+	elapsed := t.Sub(e.Start).Minutes()
+	switch e.Ramp {
+	case RampStep:
+		return 1
+	case RampTriangle:
+		half := total / 2
+		return 1 - math.Abs(elapsed-half)/half
+	default:
+		ramp := float64(e.RampMinutes)
+		if ramp <= 0 {
+			ramp = total / 4
+		}
+		if elapsed < ramp {
+			return elapsed / ramp
+		}
+		if left := total - elapsed; left < ramp {
+			return left / ramp
+		}
+		return 1
+	}
+}
+
+// SpikeFactor t anında aktif spike'lar arasından en yüksek çarpanı döner (aktif spike yoksa 1)
+func SpikeFactor(spikes []EventSpike, t time.Time) float64 {
+	factor, active := 1.0, false
+	for _, e := range spikes {
+		if t.Before(e.Start) || !t.Before(e.End()) {
+			continue
+		}
+		if f := e.Factor(t); !active || f > factor {
+			factor, active = f, true
+		}
+	}
+	return factor
+}
+
+// DescribeSpikes spike listesini okunabilir satırlar olarak döner (log / dry-run çıktısı için)
+func DescribeSpikes(spikes []EventSpike) []string {
+	var lines []string
+	for _, e := range spikes {
+		ramp := e.Ramp
+		if ramp == "" {
+			ramp = RampLinear
+		}
+		lines = append(lines, fmt.Sprintf("spike %s: %s → %s x%.2f (%s)",
+			e.Name, e.Start.Format("2006-01-02 15:04"), e.End().Format("2006-01-02 15:04"), e.Multiplier, ramp))
+	}
+	return lines
+}
+
+// averageSpikeFactor [from, from+minutes) aralığındaki ortalama çarpan (dakikalık örnekleme)
+func averageSpikeFactor(spikes []EventSpike, from time.Time, minutes int) float64 {
+	if len(spikes) == 0 || minutes <= 0 {
+		return 1
+	}
+	var sum float64
+	for i := 0; i < minutes; i++ {
+		sum += SpikeFactor(spikes, from.Add(time.Duration(i)*time.Minute))
+	}
+	return sum / float64(minutes)
+}
@@ -0,0 +1,67 @@
+package scheduler
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestEventSpikeFactor(t *testing.T) {
+	start := at(time.Friday, 10, 0)
+	linear := EventSpike{Name: "bf", Start: start, DurationMinutes: 120, Multiplier: 3, RampMinutes: 30}
+	if err := linear.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		t    time.Time
+		want float64
+	}{
+		{at(time.Friday, 9, 59), 1},
+		{at(time.Friday, 10, 15), 2}, // rampanın yarısı
+		{at(time.Friday, 11, 0), 3},  // tepe
+		{at(time.Friday, 11, 45), 2}, // iniş
+		{at(time.Friday, 12, 0), 1},  // bitiş hariç
+	}
+	for _, c := range cases {
+		if got := linear.Factor(c.t); math.Abs(got-c.want) > 1e-9 {
+			t.Errorf("linear.Factor(%s) = %.2f, want %.2f", c.t.Format("15:04"), got, c.want)
+		}
+	}
+
+	tri := EventSpike{Name: "tri", Start: start, DurationMinutes: 60, Multiplier: 5, Ramp: RampTriangle}
+	if got := tri.Factor(at(time.Friday, 10, 30)); got != 5 {
+		t.Errorf("triangle peak = %.2f, want 5", got)
+	}
+
+	step := EventSpike{Name: "step", Start: start, DurationMinutes: 60, Multiplier: 2, Ramp: RampStep}
+	if got := SpikeFactor([]EventSpike{step, tri}, at(time.Friday, 10, 1)); got != 2 {
+		t.Errorf("overlapping spikes should use the highest factor, got %.2f", got)
+	}
+}
+
+func TestWeeklyPlanSlotWithSpike(t *testing.T) {
+	p := &WeeklyPlan{
+		Days: map[string]*DayPlan{"friday": {Enabled: true, TargetHits: 2400, Curve: "flat"}},
+		Spikes: []EventSpike{
+			{Name: "bf", Start: at(time.Friday, 10, 0), DurationMinutes: 60, Multiplier: 3, Ramp: RampStep},
+		},
+	}
+	if err := p.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	// 100 hit x3 = 300 hit -> 5 HPM, 60 dakika
+	hpm, minutes := p.Slot(at(time.Friday, 10, 0))
+	if hpm != 5 || minutes != 60 {
+		t.Errorf("Slot during spike = %d HPM / %d min, want 5 / 60", hpm, minutes)
+	}
+	if hpm, _ := p.Slot(at(time.Friday, 11, 0)); hpm != 2 {
+		t.Errorf("Slot after spike = %d HPM, want 2", hpm)
+	}
+
+	p.Spikes[0].Ramp = "spiky"
+	if err := p.Validate(); err == nil {
+		t.Error("unknown ramp should fail validation")
+	}
+}
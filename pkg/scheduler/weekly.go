@@ -0,0 +1,163 @@
+package scheduler
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// HourlyCurves saatlik trafik dağılımı ön ayarları (24 ağırlık, 00:00'dan başlayarak)
+var HourlyCurves = map[string][]float64{
+	"flat": {
+		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+	},
+	// Gece düşük, öğlen ve akşam tepe yapan tipik ziyaretçi eğrisi
+	"diurnal": {
+		2, 1, 1, 1, 1, 2, 3, 5, 7, 8, 9, 10,
+		10, 9, 9, 9, 9, 9, 10, 11, 11, 9, 6, 4,
+	},
+	// Mesai saatlerinde yoğun B2B eğrisi
+	"business": {
+		1, 1, 1, 1, 1, 1, 2, 4, 9, 12, 12, 11,
+		9, 11, 12, 12, 11, 8, 5, 3, 2, 2, 1, 1,
+	},
+}
+
+// DayPlan bir günün hedef hit sayısı ve saatlik dağılımı
+type DayPlan struct {
+	Enabled     bool      `json:"enabled"`
+	TargetHits  int       `json:"target_hits"`            // Gün boyunca hedef toplam hit
+	Curve       string    `json:"curve,omitempty"`        // HourlyCurves anahtarı (varsayılan "diurnal")
+	HourlyCurve []float64 `json:"hourly_curve,omitempty"` // Özel 24 saatlik ağırlık (Curve'ü ezer)
+}
+
+// WeeklyPlan gün adına ("monday".."sunday") göre haftalık trafik planı
+type WeeklyPlan struct {
+	Days   map[string]*DayPlan `json:"days"`
+	Spikes []EventSpike        `json:"spikes,omitempty"` // Temel planın üzerine bindirilen tek seferlik artışlar
+}
+
+// Validate planı doğrular
+func (p *WeeklyPlan) Validate() error {
+	if p == nil {
+		return nil
+	}
+	for name, d := range p.Days {
+		if d == nil {
+			continue
+		}
+		if !isWeekdayName(name) {
+			return fmt.Errorf("geçersiz gün: %q", name)
+		}
+		if d.TargetHits < 0 {
+			return fmt.Errorf("%s: hedef hit negatif olamaz", name)
+		}
+		if len(d.HourlyCurve) > 0 {
+			if len(d.HourlyCurve) != 24 {
+				return fmt.Errorf("%s: hourly_curve 24 değer içermeli", name)
+			}
+			for _, v := range d.HourlyCurve {
+				if v < 0 {
+					return fmt.Errorf("%s: hourly_curve negatif değer içeremez", name)
+				}
+			}
+		} else if d.Curve != "" {
+			if _, ok := HourlyCurves[d.Curve]; !ok {
+				return fmt.Errorf("%s: bilinmeyen eğri %q", name, d.Curve)
+			}
+		}
+	}
+	for _, e := range p.Spikes {
+		if err := e.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Day t'nin gününe ait planı döner (yoksa veya kapalıysa nil)
+func (p *WeeklyPlan) Day(t time.Time) *DayPlan {
+	if p == nil {
+		return nil
+	}
+	d := p.Days[strings.ToLower(t.Weekday().String())]
+	if d == nil || !d.Enabled || d.TargetHits <= 0 {
+		return nil
+	}
+	return d
+}
+
+// HitsForHour t'nin saatine düşen hit sayısı (gün hedefi x saat ağırlığı)
+func (p *WeeklyPlan) HitsForHour(t time.Time) float64 {
+	d := p.Day(t)
+	if d == nil {
+		return 0
+	}
+	curve := d.curve()
+	var sum float64
+	for _, v := range curve {
+		sum += v
+	}
+	if sum <= 0 {
+		return 0
+	}
+	return float64(d.TargetHits) * curve[t.Hour()] / sum
+}
+
+// Slot t anından saat sonuna kadar çalıştırılacak HPM ve süreyi (dakika) döner.
+// Saatin kalan kısmına düşen hit'ler HPM >= 1 olacak şekilde en kısa sürede gönderilir.
+// Aktif event spike'ları kalan dakikaların ortalama çarpanı kadar hedefi büyütür.
+func (p *WeeklyPlan) Slot(t time.Time) (hpm int, minutes int) {
+	remaining := 60 - t.Minute()
+	hits := p.HitsForHour(t) * float64(remaining) / 60
+	if p != nil {
+		hits *= averageSpikeFactor(p.Spikes, t.Truncate(time.Minute), remaining)
+	}
+	if hits < 1 {
+		return 0, 0
+	}
+	hpm = int(math.Ceil(hits / float64(remaining)))
+	minutes = int(math.Ceil(hits / float64(hpm)))
+	if minutes > remaining {
+		minutes = remaining
+	}
+	return hpm, minutes
+}
+
+// DailyTotals haftanın her günü için hedef hit'i döner (pazartesiden başlayarak)
+func (p *WeeklyPlan) DailyTotals() []string {
+	var out []string
+	for i := 1; i <= 7; i++ {
+		wd := time.Weekday(i % 7)
+		name := strings.ToLower(wd.String())
+		hits := 0
+		if p != nil {
+			if d := p.Days[name]; d != nil && d.Enabled {
+				hits = d.TargetHits
+			}
+		}
+		out = append(out, fmt.Sprintf("%s: %d", name, hits))
+	}
+	return out
+}
+
+func (d *DayPlan) curve() []float64 {
+	if len(d.HourlyCurve) == 24 {
+		return d.HourlyCurve
+	}
+	if c, ok := HourlyCurves[d.Curve]; ok {
+		return c
+	}
+	return HourlyCurves["diurnal"]
+}
+
+func isWeekdayName(name string) bool {
+	for wd := time.Sunday; wd <= time.Saturday; wd++ {
+		if name == strings.ToLower(wd.String()) {
+			return true
+		}
+	}
+	return false
+}
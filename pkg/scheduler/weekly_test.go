@@ -0,0 +1,49 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWeeklyPlanSlot(t *testing.T) {
+	p := &WeeklyPlan{Days: map[string]*DayPlan{
+		"monday":   {Enabled: true, TargetHits: 2400, Curve: "flat"},
+		"saturday": {Enabled: false, TargetHits: 2400},
+	}}
+	if err := p.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	// 2400 / 24 = 100 hit/saat -> 2 HPM, 50 dakika
+	hpm, minutes := p.Slot(at(time.Monday, 10, 0))
+	if hpm != 2 || minutes != 50 {
+		t.Errorf("Slot(Monday 10:00) = %d HPM / %d min, want 2 / 50", hpm, minutes)
+	}
+
+	// Saatin yarısı geçmişse kalan pay: 50 hit -> 2 HPM, 25 dakika
+	hpm, minutes = p.Slot(at(time.Monday, 10, 30))
+	if hpm != 2 || minutes != 25 {
+		t.Errorf("Slot(Monday 10:30) = %d HPM / %d min, want 2 / 25", hpm, minutes)
+	}
+
+	if hpm, _ := p.Slot(at(time.Saturday, 10, 0)); hpm != 0 {
+		t.Errorf("disabled day should not run, got %d HPM", hpm)
+	}
+	if hpm, _ := p.Slot(at(time.Tuesday, 10, 0)); hpm != 0 {
+		t.Errorf("missing day should not run, got %d HPM", hpm)
+	}
+}
+
+func TestWeeklyPlanValidate(t *testing.T) {
+	bad := []*WeeklyPlan{
+		{Days: map[string]*DayPlan{"funday": {Enabled: true}}},
+		{Days: map[string]*DayPlan{"monday": {TargetHits: -1}}},
+		{Days: map[string]*DayPlan{"monday": {Curve: "unknown"}}},
+		{Days: map[string]*DayPlan{"monday": {HourlyCurve: []float64{1, 2}}}},
+	}
+	for i, p := range bad {
+		if err := p.Validate(); err == nil {
+			t.Errorf("case %d: expected validation error", i)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package scheduler
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Window gün filtresi + saat aralığı. Örnekler:
+//
+//	"02:00-05:00"            her gün 02:00–05:00
+//	"weekday 09:00-18:00"    hafta içi mesai saatleri
+//	"saturday,sunday 22:00-06:00"  gece yarısını aşan aralık (başladığı güne aittir)
+type Window struct {
+	Days  []string // Job.DaysOfWeek ile aynı değerler; boşsa her gün
+	Start int      // gün başından itibaren dakika
+	End   int      // gün başından itibaren dakika (Start'tan küçükse ertesi güne taşar)
+}
+
+// ParseWindow "[günler] HH:MM-HH:MM" formatındaki pencereyi ayrıştırır
+func ParseWindow(spec string) (Window, error) {
+	fields := strings.Fields(strings.TrimSpace(spec))
+	if len(fields) == 0 || len(fields) > 2 {
+		return Window{}, fmt.Errorf("geçersiz zaman penceresi: %q", spec)
+	}
+
+	var w Window
+	rangePart := fields[len(fields)-1]
+	if len(fields) == 2 {
+		for _, d := range strings.Split(fields[0], ",") {
+			d = strings.ToLower(strings.TrimSpace(d))
+			if d == "" {
+				continue
+			}
+			if !isValidDay(d) {
+				return Window{}, fmt.Errorf("geçersiz gün: %q", d)
+			}
+			w.Days = append(w.Days, d)
+		}
+	}
+
+	parts := strings.SplitN(rangePart, "-", 2)
+	if len(parts) != 2 {
+		return Window{}, fmt.Errorf("geçersiz saat aralığı: %q", rangePart)
+	}
+	start, err := parseClock(parts[0])
+	if err != nil {
+		return Window{}, err
+	}
+	end, err := parseClock(parts[1])
+	if err != nil {
+		return Window{}, err
+	}
+	if start == end {
+		return Window{}, fmt.Errorf("boş saat aralığı: %q", rangePart)
+	}
+	w.Start, w.End = start, end
+	return w, nil
+}
+
+// Contains t anının pencere içinde olup olmadığını döner
+func (w Window) Contains(t time.Time) bool {
+	m := t.Hour()*60 + t.Minute()
+	if w.Start < w.End {
+		return m >= w.Start && m < w.End && dayMatches(w.Days, t)
+	}
+	// Gece yarısını aşan aralık: 22:00-06:00
+	if m >= w.Start {
+		return dayMatches(w.Days, t)
+	}
+	if m < w.End {
+		return dayMatches(w.Days, t.AddDate(0, 0, -1))
+	}
+	return false
+}
+
+// String pencereyi ParseWindow ile uyumlu biçimde döner
+func (w Window) String() string {
+	r := fmt.Sprintf("%02d:%02d-%02d:%02d", w.Start/60, w.Start%60, w.End/60, w.End%60)
+	if len(w.Days) == 0 {
+		return r
+	}
+	return strings.Join(w.Days, ",") + " " + r
+}
+
+// WindowPlan aktif (yalnızca bu saatlerde çalış) ve blackout (asla çalışma) pencereleri.
+// Blackout her zaman önceliklidir; aktif pencere yoksa blackout dışındaki her an serbesttir.
+type WindowPlan struct {
+	Active   []Window
+	Blackout []Window
+}
+
+// NewWindowPlan string tanımlardan plan oluşturur; ikisi de boşsa nil döner
+func NewWindowPlan(active, blackout []string) (*WindowPlan, error) {
+	p := &WindowPlan{}
+	for _, spec := range active {
+		if strings.TrimSpace(spec) == "" {
+			continue
+		}
+		w, err := ParseWindow(spec)
+		if err != nil {
+			return nil, err
+		}
+		p.Active = append(p.Active, w)
+	}
+	for _, spec := range blackout {
+		if strings.TrimSpace(spec) == "" {
+			continue
+		}
+		w, err := ParseWindow(spec)
+		if err != nil {
+			return nil, err
+		}
+		p.Blackout = append(p.Blackout, w)
+	}
+	if len(p.Active) == 0 && len(p.Blackout) == 0 {
+		return nil, nil
+	}
+	return p, nil
+}
+
+// Allowed t anında trafik gönderilebilir mi (nil plan her zaman true)
+func (p *WindowPlan) Allowed(t time.Time) bool {
+	if p == nil {
+		return true
+	}
+	for _, b := range p.Blackout {
+		if b.Contains(t) {
+			return false
+		}
+	}
+	if len(p.Active) == 0 {
+		return true
+	}
+	for _, a := range p.Active {
+		if a.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// NextAllowed t'den sonraki ilk izinli dakikayı döner; 8 gün içinde yoksa sıfır zaman
+func (p *WindowPlan) NextAllowed(t time.Time) time.Time {
+	if p.Allowed(t) {
+		return t
+	}
+	cur := t.Truncate(time.Minute)
+	for i := 0; i < 8*24*60; i++ {
+		cur = cur.Add(time.Minute)
+		if p.Allowed(cur) {
+			return cur
+		}
+	}
+	return time.Time{}
+}
+
+// Describe planı okunabilir satırlar olarak döner (log / dry-run çıktısı için)
+func (p *WindowPlan) Describe() []string {
+	if p == nil {
+		return nil
+	}
+	var lines []string
+	for _, a := range p.Active {
+		lines = append(lines, "active   "+a.String())
+	}
+	for _, b := range p.Blackout {
+		lines = append(lines, "blackout "+b.String())
+	}
+	return lines
+}
+
+func parseClock(s string) (int, error) {
+	var h, m int
+	if _, err := fmt.Sscanf(strings.TrimSpace(s), "%d:%d", &h, &m); err != nil {
+		return 0, fmt.Errorf("geçersiz saat: %q", s)
+	}
+	if h == 24 && m == 0 {
+		return 24 * 60, nil
+	}
+	if h < 0 || h > 23 || m < 0 || m > 59 {
+		return 0, fmt.Errorf("geçersiz saat: %q", s)
+	}
+	return h*60 + m, nil
+}
+
+func isValidDay(d string) bool {
+	switch d {
+	case "daily", "weekday", "weekdays", "weekend", "weekends":
+		return true
+	}
+	for wd := time.Sunday; wd <= time.Saturday; wd++ {
+		if d == strings.ToLower(wd.String()) {
+			return true
+		}
+	}
+	return false
+}
+
+// dayMatches gün listesi ("daily", "weekday", "monday", ...) t'nin gününü kapsıyor mu
+func dayMatches(days []string, t time.Time) bool {
+	if len(days) == 0 {
+		return true // Boşsa her gün
+	}
+
+	today := strings.ToLower(t.Weekday().String())
+	isWeekday := t.Weekday() >= time.Monday && t.Weekday() <= time.Friday
+	isWeekend := t.Weekday() == time.Saturday || t.Weekday() == time.Sunday
+
+	for _, day := range days {
+		day = strings.ToLower(strings.TrimSpace(day))
+		switch day {
+		case "daily":
+			return true
+		case "weekday", "weekdays":
+			if isWeekday {
+				return true
+			}
+		case "weekend", "weekends":
+			if isWeekend {
+				return true
+			}
+		default:
+			if day == today {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,100 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func at(day time.Weekday, hour, minute int) time.Time {
+	// 2024-01-07 bir Pazar günüdür
+	return time.Date(2024, 1, 7+int(day), hour, minute, 0, 0, time.UTC)
+}
+
+func TestParseWindow(t *testing.T) {
+	cases := []struct {
+		spec    string
+		wantErr bool
+		str     string
+	}{
+		{"02:00-05:00", false, "02:00-05:00"},
+		{"weekday 09:00-18:00", false, "weekday 09:00-18:00"},
+		{"Saturday,sunday 22:00-06:00", false, "saturday,sunday 22:00-06:00"},
+		{"00:00-24:00", false, "00:00-24:00"},
+		{"", true, ""},
+		{"09:00", true, ""},
+		{"25:00-26:00", true, ""},
+		{"someday 09:00-10:00", true, ""},
+		{"10:00-10:00", true, ""},
+	}
+	for _, c := range cases {
+		w, err := ParseWindow(c.spec)
+		if (err != nil) != c.wantErr {
+			t.Errorf("ParseWindow(%q) err = %v, wantErr %v", c.spec, err, c.wantErr)
+			continue
+		}
+		if err == nil && w.String() != c.str {
+			t.Errorf("ParseWindow(%q).String() = %q, want %q", c.spec, w.String(), c.str)
+		}
+	}
+}
+
+func TestWindowContainsOvernight(t *testing.T) {
+	w, err := ParseWindow("friday 22:00-06:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !w.Contains(at(time.Friday, 23, 0)) {
+		t.Error("Friday 23:00 should be inside")
+	}
+	if !w.Contains(at(time.Saturday, 5, 59)) {
+		t.Error("Saturday 05:59 belongs to Friday's window")
+	}
+	if w.Contains(at(time.Saturday, 6, 0)) {
+		t.Error("Saturday 06:00 should be outside (end is exclusive)")
+	}
+	if w.Contains(at(time.Friday, 5, 0)) {
+		t.Error("Friday 05:00 belongs to Thursday and should be outside")
+	}
+}
+
+func TestWindowPlanAllowed(t *testing.T) {
+	p, err := NewWindowPlan([]string{"weekday 09:00-18:00"}, []string{"12:00-13:00"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !p.Allowed(at(time.Monday, 10, 0)) {
+		t.Error("Monday 10:00 should be allowed")
+	}
+	if p.Allowed(at(time.Monday, 12, 30)) {
+		t.Error("blackout must win over active window")
+	}
+	if p.Allowed(at(time.Sunday, 10, 0)) {
+		t.Error("Sunday is outside the weekday window")
+	}
+
+	next := p.NextAllowed(at(time.Monday, 12, 15))
+	if want := at(time.Monday, 13, 0); !next.Equal(want) {
+		t.Errorf("NextAllowed = %v, want %v", next, want)
+	}
+	next = p.NextAllowed(at(time.Friday, 19, 0))
+	if want := at(time.Monday, 9, 0).AddDate(0, 0, 7); !next.Equal(want) {
+		t.Errorf("NextAllowed over weekend = %v, want %v", next, want)
+	}
+}
+
+func TestNilWindowPlan(t *testing.T) {
+	p, err := NewWindowPlan(nil, []string{" "})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p != nil {
+		t.Fatal("empty specs should produce a nil plan")
+	}
+	if !p.Allowed(time.Now()) {
+		t.Error("nil plan should allow everything")
+	}
+	if len(p.Describe()) != 0 {
+		t.Error("nil plan should describe nothing")
+	}
+}
@@ -0,0 +1,185 @@
+// Package secaudit hedefin TLS sertifika zincirini ve güvenlik header'larını (HSTS, CSP, X-Frame-Options...)
+// aracın zaten yaptığı bir yanıttan özetler; ek istek atmaz.
+package secaudit
+
+import (
+	"crypto/tls"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Bulgu kodları (UI ve rapor bunları gösterir)
+const (
+	FindingPlainHTTP        = "plain_http"         // Yanıt TLS olmadan geldi
+	FindingLegacyTLS        = "legacy_tls"         // TLS 1.2'den eski sürüm müzakere edildi
+	FindingCertExpired      = "cert_expired"       // Zincirdeki bir sertifikanın süresi dolmuş
+	FindingCertExpiring     = "cert_expiring"      // Zincirdeki bir sertifika ExpiryWarnDays içinde doluyor
+	FindingNoHSTS           = "no_hsts"            // Strict-Transport-Security yok
+	FindingShortHSTS        = "hsts_short"         // HSTS max-age 180 günden kısa
+	FindingNoCSP            = "no_csp"             // Content-Security-Policy yok
+	FindingCSPUnsafeInline  = "csp_unsafe_inline"  // CSP script-src/default-src 'unsafe-inline' içeriyor
+	FindingNoFrameOptions   = "no_frame_options"   // X-Frame-Options ve CSP frame-ancestors yok (clickjacking)
+	FindingNoContentTypeOpt = "no_nosniff"         // X-Content-Type-Options: nosniff yok
+	FindingNoReferrerPolicy = "no_referrer_policy" // Referrer-Policy yok
+)
+
+// ExpiryWarnDays bu kadar günden az kalan sertifikalar için uyarı üretilir
+const ExpiryWarnDays = 30
+
+// hstsMinAge tarayıcıların preload listesi için beklediği en kısa max-age (180 gün)
+const hstsMinAge = 180 * 24 * 3600
+
+// Denetlenen güvenlik header'ları (rapordaki sıra)
+var auditedHeaders = []string{
+	"Strict-Transport-Security",
+	"Content-Security-Policy",
+	"X-Frame-Options",
+	"X-Content-Type-Options",
+	"Referrer-Policy",
+	"Permissions-Policy",
+	"Cross-Origin-Opener-Policy",
+}
+
+// Certificate zincirdeki tek sertifika
+type Certificate struct {
+	Subject  string    `json:"subject"`
+	Issuer   string    `json:"issuer"`
+	NotAfter time.Time `json:"not_after"`
+	DaysLeft int       `json:"days_left"`
+}
+
+// HSTS Strict-Transport-Security header'ının çözülmüş hali
+type HSTS struct {
+	MaxAge            int64 `json:"max_age"`
+	IncludeSubDomains bool  `json:"include_subdomains"`
+	Preload           bool  `json:"preload"`
+}
+
+// Header denetlenen bir güvenlik header'ı; Value boşsa yanıtta yok
+type Header struct {
+	Name  string `json:"name"`
+	Value string `json:"value,omitempty"`
+}
+
+// Summary hedefin güvenlik özeti
+type Summary struct {
+	URL         string        `json:"url"`
+	CheckedAt   time.Time     `json:"checked_at"`
+	TLSVersion  string        `json:"tls_version,omitempty"`
+	CipherSuite string        `json:"cipher_suite,omitempty"`
+	Chain       []Certificate `json:"chain,omitempty"`
+	ExpiresAt   *time.Time    `json:"expires_at,omitempty"` // Zincirde en erken dolan sertifika
+	DaysLeft    int           `json:"days_left"`
+	HSTS        *HSTS         `json:"hsts,omitempty"`
+	Headers     []Header      `json:"headers"`
+	Findings    []string      `json:"findings"`
+}
+
+// FromResponse yanıtın TLS durumu ve header'larından güvenlik özetini çıkarır (gövde okunmaz)
+func FromResponse(resp *http.Response, now time.Time) *Summary {
+	s := &Summary{CheckedAt: now, Findings: []string{}}
+	if resp.Request != nil && resp.Request.URL != nil {
+		s.URL = resp.Request.URL.String()
+	}
+	s.auditTLS(resp.TLS, now)
+	s.auditHeaders(resp.Header)
+	return s
+}
+
+func (s *Summary) auditTLS(state *tls.ConnectionState, now time.Time) {
+	if state == nil {
+		s.Findings = append(s.Findings, FindingPlainHTTP)
+		return
+	}
+	s.TLSVersion = tls.VersionName(state.Version)
+	s.CipherSuite = tls.CipherSuiteName(state.CipherSuite)
+	if state.Version < tls.VersionTLS12 {
+		s.Findings = append(s.Findings, FindingLegacyTLS)
+	}
+	for _, cert := range state.PeerCertificates {
+		c := Certificate{
+			Subject:  cert.Subject.CommonName,
+			Issuer:   cert.Issuer.CommonName,
+			NotAfter: cert.NotAfter,
+			DaysLeft: int(cert.NotAfter.Sub(now).Hours() / 24),
+		}
+		if c.Subject == "" {
+			c.Subject = cert.Subject.String()
+		}
+		s.Chain = append(s.Chain, c)
+		if s.ExpiresAt == nil || cert.NotAfter.Before(*s.ExpiresAt) {
+			at := cert.NotAfter
+			s.ExpiresAt = &at
+			s.DaysLeft = c.DaysLeft
+		}
+	}
+	switch {
+	case s.ExpiresAt == nil:
+	case !now.Before(*s.ExpiresAt):
+		s.Findings = append(s.Findings, FindingCertExpired)
+	case s.DaysLeft < ExpiryWarnDays:
+		s.Findings = append(s.Findings, FindingCertExpiring)
+	}
+}
+
+func (s *Summary) auditHeaders(h http.Header) {
+	for _, name := range auditedHeaders {
+		s.Headers = append(s.Headers, Header{Name: name, Value: h.Get(name)})
+	}
+	if v := h.Get("Strict-Transport-Security"); v != "" {
+		s.HSTS = parseHSTS(v)
+		if s.HSTS.MaxAge < hstsMinAge {
+			s.Findings = append(s.Findings, FindingShortHSTS)
+		}
+	} else if s.TLSVersion != "" {
+		s.Findings = append(s.Findings, FindingNoHSTS)
+	}
+	csp := strings.ToLower(h.Get("Content-Security-Policy"))
+	if csp == "" {
+		s.Findings = append(s.Findings, FindingNoCSP)
+	} else if cspUnsafeInline(csp) {
+		s.Findings = append(s.Findings, FindingCSPUnsafeInline)
+	}
+	if h.Get("X-Frame-Options") == "" && !strings.Contains(csp, "frame-ancestors") {
+		s.Findings = append(s.Findings, FindingNoFrameOptions)
+	}
+	if !strings.EqualFold(strings.TrimSpace(h.Get("X-Content-Type-Options")), "nosniff") {
+		s.Findings = append(s.Findings, FindingNoContentTypeOpt)
+	}
+	if h.Get("Referrer-Policy") == "" {
+		s.Findings = append(s.Findings, FindingNoReferrerPolicy)
+	}
+}
+
+func parseHSTS(v string) *HSTS {
+	out := &HSTS{}
+	for _, part := range strings.Split(v, ";") {
+		part = strings.TrimSpace(part)
+		key, val, _ := strings.Cut(part, "=")
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "max-age":
+			out.MaxAge, _ = strconv.ParseInt(strings.Trim(strings.TrimSpace(val), `"`), 10, 64)
+		case "includesubdomains":
+			out.IncludeSubDomains = true
+		case "preload":
+			out.Preload = true
+		}
+	}
+	return out
+}
+
+// cspUnsafeInline script-src (yoksa default-src) yönergesi 'unsafe-inline' içeriyor mu
+func cspUnsafeInline(csp string) bool {
+	directives := map[string]string{}
+	for _, d := range strings.Split(csp, ";") {
+		name, value, _ := strings.Cut(strings.TrimSpace(d), " ")
+		directives[name] = value
+	}
+	src, ok := directives["script-src"]
+	if !ok {
+		src = directives["default-src"]
+	}
+	return strings.Contains(src, "'unsafe-inline'")
+}
@@ -0,0 +1,55 @@
+package secaudit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFromResponseTLS(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Strict-Transport-Security", "max-age=63072000; includeSubDomains; preload")
+		w.Header().Set("Content-Security-Policy", "default-src 'self'; script-src 'self' 'unsafe-inline'; frame-ancestors 'none'")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+	}))
+	defer srv.Close()
+	resp, err := srv.Client().Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	s := FromResponse(resp, time.Now())
+	if s.TLSVersion != "TLS 1.3" || len(s.Chain) == 0 || s.ExpiresAt == nil || s.DaysLeft < ExpiryWarnDays {
+		t.Errorf("tls = %q chain %d expires %v (%d days)", s.TLSVersion, len(s.Chain), s.ExpiresAt, s.DaysLeft)
+	}
+	if s.HSTS == nil || s.HSTS.MaxAge != 63072000 || !s.HSTS.IncludeSubDomains || !s.HSTS.Preload {
+		t.Errorf("HSTS = %+v", s.HSTS)
+	}
+	if want := []string{FindingCSPUnsafeInline, FindingNoReferrerPolicy}; !reflect.DeepEqual(s.Findings, want) {
+		t.Errorf("findings = %v, want %v", s.Findings, want)
+	}
+
+	// Sertifika 10 gün sonra dolacakmış gibi denetle
+	soon := s.ExpiresAt.Add(-10 * 24 * time.Hour)
+	if got := FromResponse(resp, soon).Findings; got[0] != FindingCertExpiring {
+		t.Errorf("findings 10 days before expiry = %v", got)
+	}
+	if got := FromResponse(resp, s.ExpiresAt.Add(time.Hour)).Findings; got[0] != FindingCertExpired {
+		t.Errorf("findings after expiry = %v", got)
+	}
+}
+
+func TestFromResponsePlainHTTP(t *testing.T) {
+	resp := &http.Response{Header: http.Header{"Strict-Transport-Security": {"max-age=3600"}}}
+	s := FromResponse(resp, time.Now())
+	want := []string{FindingPlainHTTP, FindingShortHSTS, FindingNoCSP, FindingNoFrameOptions, FindingNoContentTypeOpt, FindingNoReferrerPolicy}
+	if !reflect.DeepEqual(s.Findings, want) {
+		t.Errorf("findings = %v, want %v", s.Findings, want)
+	}
+	if len(s.Headers) != len(auditedHeaders) || s.Headers[0].Value != "max-age=3600" {
+		t.Errorf("headers = %+v", s.Headers)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"net/url"
 	"sync"
 	"time"
+
+	"vgbot/pkg/utils"
 )
 
 // Keyword anahtar kelime
@@ -23,11 +25,11 @@ type KeywordManager struct {
 	rng      *rand.Rand
 }
 
-// NewKeywordManager yeni keyword manager
-func NewKeywordManager(keywords []Keyword) *KeywordManager {
+// NewKeywordManager yeni keyword manager; seçimler seeder'dan türetilir (nil = tekrarlanamaz)
+func NewKeywordManager(keywords []Keyword, seeder *utils.Seeder) *KeywordManager {
 	return &KeywordManager{
 		Keywords: keywords,
-		rng:      rand.New(rand.NewSource(time.Now().UnixNano())),
+		rng:      seeder.NewRand(),
 	}
 }
 
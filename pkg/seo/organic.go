@@ -4,9 +4,10 @@ import (
 	"context"
 	"math/rand"
 	"sync"
-	"time"
 
 	"github.com/chromedp/chromedp"
+
+	"vgbot/pkg/utils"
 )
 
 // OrganicTraffic organik trafik simülatörü
@@ -18,13 +19,13 @@ type OrganicTraffic struct {
 	rng           *rand.Rand
 }
 
-// NewOrganicTraffic yeni organik trafik simülatörü
-func NewOrganicTraffic(keywords []Keyword, targetDomain string, ctr float64) *OrganicTraffic {
+// NewOrganicTraffic yeni organik trafik simülatörü; seçimler seeder'dan türetilir (nil = tekrarlanamaz)
+func NewOrganicTraffic(keywords []Keyword, targetDomain string, ctr float64, seeder *utils.Seeder) *OrganicTraffic {
 	return &OrganicTraffic{
-		Keywords:     NewKeywordManager(keywords),
+		Keywords:     NewKeywordManager(keywords, seeder),
 		TargetDomain: targetDomain,
 		ClickThrough: ctr,
-		rng:          rand.New(rand.NewSource(time.Now().UnixNano())),
+		rng:          seeder.NewRand(),
 	}
 }
 
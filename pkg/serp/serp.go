@@ -11,6 +11,8 @@ import (
 
 	"github.com/chromedp/cdproto/cdp"
 	"github.com/chromedp/chromedp"
+
+	"vgbot/pkg/utils"
 )
 
 // SERPConfig SERP CTR manipülasyonu yapılandırması
@@ -23,6 +25,7 @@ type SERPConfig struct {
 	ScrollBehavior string   // "natural", "fast", "slow"
 	Language       string   // Arama dili (tr, en, de, vb.)
 	Country        string   // Ülke kodu (tr, us, de, vb.)
+	Seeder         *utils.Seeder // Çalıştırmanın seed kaynağı (nil = tekrarlanamaz)
 }
 
 // SERPResult SERP işlem sonucu
@@ -70,7 +73,7 @@ func NewSERPClicker(config SERPConfig) *SERPClicker {
 	
 	return &SERPClicker{
 		config: config,
-		rng:    rand.New(rand.NewSource(time.Now().UnixNano())),
+		rng:    config.Seeder.NewRand(),
 	}
 }
 
@@ -0,0 +1,85 @@
+package sitemap
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Cache sitemap'ten çıkarılan URL listesini diskte TTL ile tutar; her simülasyon başlangıcında
+// sitemap ağacının (index + alt sitemapler) yeniden indirilmesini önler.
+type Cache struct {
+	Dir string
+	TTL time.Duration // <= 0 ise önbellek kapalı
+}
+
+type cacheEntry struct {
+	BaseURL   string    `json:"base_url"`
+	FetchedAt time.Time `json:"fetched_at"`
+	URLs      []string  `json:"urls"`
+}
+
+// Fetch önbellekte taze liste varsa onu, yoksa sitemap.Fetch sonucunu döner ve kaydeder.
+// cached=true ise liste diskten okunmuştur. Boş sonuç önbelleğe yazılmaz.
+func (c *Cache) Fetch(baseURL string, client *http.Client) (urls []string, cached bool, err error) {
+	if c == nil || c.TTL <= 0 || c.Dir == "" {
+		urls, err = Fetch(baseURL, client)
+		return urls, false, err
+	}
+	path := c.path(baseURL)
+	if e, ok := c.load(path, time.Now()); ok {
+		return e.URLs, true, nil
+	}
+	urls, err = Fetch(baseURL, client)
+	if err == nil && len(urls) > 0 {
+		c.save(path, cacheEntry{BaseURL: normalizeBase(baseURL), FetchedAt: time.Now(), URLs: urls})
+	}
+	return urls, false, err
+}
+
+// path baseURL'e ait önbellek dosyası
+func (c *Cache) path(baseURL string) string {
+	sum := sha256.Sum256([]byte(normalizeBase(baseURL)))
+	return filepath.Join(c.Dir, hex.EncodeToString(sum[:8])+".json")
+}
+
+func (c *Cache) load(path string, now time.Time) (cacheEntry, bool) {
+	var e cacheEntry
+	data, err := os.ReadFile(path)
+	if err != nil || json.Unmarshal(data, &e) != nil {
+		return e, false
+	}
+	if len(e.URLs) == 0 || now.Sub(e.FetchedAt) > c.TTL {
+		return e, false
+	}
+	return e, true
+}
+
+// save girdiyi geçici dosyaya yazıp yerine taşır (yarım yazılmış dosya okunmaz); hata önbelleği atlatır
+func (c *Cache) save(path string, e cacheEntry) {
+	data, err := json.Marshal(e)
+	if err != nil || os.MkdirAll(c.Dir, 0755) != nil {
+		return
+	}
+	tmp := path + ".tmp"
+	if os.WriteFile(tmp, data, 0644) != nil {
+		return
+	}
+	if os.Rename(tmp, path) != nil {
+		os.Remove(tmp)
+	}
+}
+
+// normalizeBase Fetch'in kullandığı kök adres (şema eklenmiş, sondaki / atılmış)
+func normalizeBase(baseURL string) string {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	if !strings.HasPrefix(baseURL, "http") {
+		baseURL = "https://" + baseURL
+	}
+	return baseURL
+}
@@ -1,18 +1,25 @@
 package sitemap
 
 import (
+	"bytes"
+	"compress/gzip"
 	"encoding/xml"
+	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strings"
 	"time"
+
+	"vgbot/pkg/network"
 )
 
 const (
 	defaultTimeout = 15 * time.Second
 	maxURLs        = 500
 	maxChildMaps   = 10
+	maxBodySize    = 2 * 1024 * 1024  // Sıkıştırılmış yanıt sınırı
+	maxXMLSize     = 10 * 1024 * 1024 // Açılmış (gzip) sitemap sınırı
 )
 
 // URLSet sitemap urlset (tek sitemap)
@@ -31,16 +38,31 @@ type SitemapIndex struct {
 	} `xml:"sitemap"`
 }
 
+// NewClient sitemap istekleri için varsayılan istemci; headers yalnızca baseURL host'una giden
+// isteklere eklenir (basic auth, staging token)
+func NewClient(baseURL string, headers map[string]string) *http.Client {
+	var host string
+	if u, err := url.Parse(normalizeBase(baseURL)); err == nil {
+		host = u.Hostname()
+	}
+	// host_map (staging provası) etkinse bağlantılar staging hedefine gider
+	return &http.Client{Timeout: defaultTimeout, Transport: &network.HeaderTransport{
+		Base: &http.Transport{
+			Proxy:       http.ProxyFromEnvironment,
+			DialContext: network.MappedDialContext(nil),
+		},
+		Headers: headers,
+		Host:    host,
+	}}
+}
+
 // Fetch baseURL'den sitemap.xml veya robots.txt'teki Sitemap ile URL listesi döner.
 // Aynı domain'deki, http(s) URL'leri döner; en fazla maxURLs kadar.
 func Fetch(baseURL string, client *http.Client) ([]string, error) {
 	if client == nil {
-		client = &http.Client{Timeout: defaultTimeout}
-	}
-	baseURL = strings.TrimSuffix(baseURL, "/")
-	if !strings.HasPrefix(baseURL, "http") {
-		baseURL = "https://" + baseURL
+		client = NewClient(baseURL, nil)
 	}
+	baseURL = normalizeBase(baseURL)
 	base, err := url.Parse(baseURL)
 	if err != nil {
 		return nil, err
@@ -83,6 +105,19 @@ func Fetch(baseURL string, client *http.Client) ([]string, error) {
 	return nil, nil
 }
 
+// FetchURL doğrudan verilen sitemap veya sitemap index URL'sindeki sayfaları döner (.xml.gz dahil).
+// Yalnızca sitemap ile aynı domain'deki URL'ler; en fazla maxURLs kadar.
+func FetchURL(sitemapURL string, client *http.Client) ([]string, error) {
+	if client == nil {
+		client = NewClient(sitemapURL, nil)
+	}
+	base, err := url.Parse(sitemapURL)
+	if err != nil || base.Hostname() == "" {
+		return nil, fmt.Errorf("geçersiz sitemap URL: %q", sitemapURL)
+	}
+	return fetchAndParse(client, sitemapURL, base, base.Hostname(), 0)
+}
+
 func fetchAndParse(client *http.Client, u string, base *url.URL, domain string, depth int) ([]string, error) {
 	if depth > 2 {
 		return nil, nil
@@ -92,7 +127,7 @@ func fetchAndParse(client *http.Client, u string, base *url.URL, domain string,
 		return nil, err
 	}
 	req.Header.Set("User-Agent", "VGBot/3.0")
-	req.Header.Set("Accept", "application/xml, text/xml, */*")
+	req.Header.Set("Accept", "application/xml, text/xml, application/gzip, */*")
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, err
@@ -101,10 +136,13 @@ func fetchAndParse(client *http.Client, u string, base *url.URL, domain string,
 	if resp.StatusCode != http.StatusOK {
 		return nil, nil
 	}
-	data, err := io.ReadAll(io.LimitReader(resp.Body, 2*1024*1024))
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
 	if err != nil {
 		return nil, err
 	}
+	if data, err = gunzip(data); err != nil {
+		return nil, err
+	}
 
 	// Sitemap index mi?
 	var idx SitemapIndex
@@ -156,6 +194,20 @@ func fetchAndParse(client *http.Client, u string, base *url.URL, domain string,
 	return dedupeAndFilter(out, domain), nil
 }
 
+// gunzip .xml.gz sitemap'lerini açar; gzip değilse veriyi aynen döner.
+// Content-Encoding ile gelen gzip'i http.Transport zaten açar, burada dosyanın kendisi sıkıştırılmıştır.
+func gunzip(data []byte) ([]byte, error) {
+	if len(data) < 2 || data[0] != 0x1f || data[1] != 0x8b {
+		return data, nil
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	return io.ReadAll(io.LimitReader(zr, maxXMLSize))
+}
+
 func dedupeAndFilter(urls []string, domain string) []string {
 	seen := make(map[string]bool)
 	var out []string
@@ -0,0 +1,92 @@
+package sitemap
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func gz(t *testing.T, s string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write([]byte(s))
+	zw.Close()
+	return buf.Bytes()
+}
+
+// newSitemapServer index → gzip'li alt sitemap → nested index → düz sitemap ağacı sunar
+func newSitemapServer(t *testing.T, fetches *int32) *httptest.Server {
+	var srv *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc("/sitemap.xml", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(fetches, 1)
+		fmt.Fprintf(w, `<sitemapindex><sitemap><loc>%[1]s/posts.xml.gz</loc></sitemap><sitemap><loc>%[1]s/nested.xml</loc></sitemap></sitemapindex>`, srv.URL)
+	})
+	mux.HandleFunc("/posts.xml.gz", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/gzip")
+		w.Write(gz(t, fmt.Sprintf(`<urlset><url><loc>%[1]s/a</loc></url><url><loc>%[1]s/b</loc></url><url><loc>https://other.example/x</loc></url></urlset>`, srv.URL)))
+	})
+	mux.HandleFunc("/nested.xml", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `<sitemapindex><sitemap><loc>%s/pages.xml</loc></sitemap></sitemapindex>`, srv.URL)
+	})
+	mux.HandleFunc("/pages.xml", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `<urlset><url><loc>%[1]s/c</loc></url><url><loc>%[1]s/a</loc></url></urlset>`, srv.URL)
+	})
+	srv = httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestFetchIndexGzipAndNested(t *testing.T) {
+	var fetches int32
+	srv := newSitemapServer(t, &fetches)
+	urls, err := Fetch(srv.URL, srv.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{srv.URL + "/a", srv.URL + "/b", srv.URL + "/c"}
+	if !reflect.DeepEqual(urls, want) {
+		t.Errorf("urls = %v, want %v", urls, want)
+	}
+}
+
+func TestCacheFetch(t *testing.T) {
+	var fetches int32
+	srv := newSitemapServer(t, &fetches)
+	c := &Cache{Dir: t.TempDir(), TTL: time.Hour}
+
+	first, cached, err := c.Fetch(srv.URL, srv.Client())
+	if err != nil || cached || len(first) != 3 {
+		t.Fatalf("first = %v cached=%v err=%v", first, cached, err)
+	}
+	second, cached, err := c.Fetch(srv.URL+"/", srv.Client())
+	if err != nil || !cached || !reflect.DeepEqual(first, second) {
+		t.Fatalf("second = %v cached=%v err=%v", second, cached, err)
+	}
+	if n := atomic.LoadInt32(&fetches); n != 1 {
+		t.Errorf("sitemap downloaded %d times, want 1", n)
+	}
+
+	// Süresi dolan liste yeniden indirilir
+	c.TTL = time.Nanosecond
+	time.Sleep(time.Millisecond)
+	if _, cached, _ := c.Fetch(srv.URL, srv.Client()); cached {
+		t.Error("expired entry used")
+	}
+	if n := atomic.LoadInt32(&fetches); n != 2 {
+		t.Errorf("sitemap downloaded %d times, want 2", n)
+	}
+
+	// Kapalı önbellek diske yazmaz/okumaz
+	off := &Cache{Dir: t.TempDir()}
+	if _, cached, _ := off.Fetch(srv.URL, srv.Client()); cached {
+		t.Error("disabled cache used")
+	}
+}
@@ -6,9 +6,10 @@ import (
 	"fmt"
 	"math/rand"
 	"runtime"
-	"time"
 
 	"github.com/chromedp/chromedp"
+
+	"vgbot/pkg/utils"
 )
 
 // VMType virtual machine types
@@ -31,6 +32,7 @@ type VMConfig struct {
 	HideVMIndicators     bool
 	SpoofHardwareIDs     bool
 	RandomizeVMParams    bool
+	Seeder               *utils.Seeder // Run's RNG source (nil = not reproducible)
 }
 
 // DefaultVMConfig returns default config
@@ -54,7 +56,7 @@ type VMFingerprintSpoofer struct {
 func NewVMFingerprintSpoofer(config VMConfig) *VMFingerprintSpoofer {
 	return &VMFingerprintSpoofer{
 		Config: config,
-		rng:    rand.New(rand.NewSource(time.Now().UnixNano())),
+		rng:    config.Seeder.NewRand(),
 	}
 }
 
@@ -0,0 +1,196 @@
+// Package storage rapor, tarayıcı profili ve oturum dizinlerinin disk kullanımını ölçer ve
+// saklama politikasına (en fazla yaş, en fazla boyut) göre eski girdileri siler.
+// Yalnızca aracın kendi ad desenleriyle eşleşen girdilere dokunulur; çalışma dizini, kök
+// dizin ve korunan dizinler (ör. config dizini) hiçbir zaman temizlenmez.
+package storage
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Target temizlik/ölçüm hedefi
+type Target struct {
+	Category  string
+	Dir       string
+	Patterns  []string // Aracın bu dizine yazdığı girdi adları (filepath.Match); eşleşmeyenlere dokunulmaz
+	Protected []string // Temizlenmesi reddedilen dizinler (ör. config dizini); çalışma dizini her zaman korunur
+}
+
+// matches girdi adı hedefin desenlerinden biriyle eşleşiyor mu
+func (t Target) matches(name string) bool {
+	for _, p := range t.Patterns {
+		if ok, _ := filepath.Match(p, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckDir dir'in temizlenebilir olup olmadığını döner. Boş yol, ".", kök dizin, çalışma dizini
+// ve protected dizinleri ile bunların üst dizinleri reddedilir.
+func CheckDir(dir string, protected ...string) error {
+	if strings.TrimSpace(dir) == "" {
+		return fmt.Errorf("dizin boş")
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	if abs == filepath.Dir(abs) {
+		return fmt.Errorf("%s kök dizin; temizlenmez", dir)
+	}
+	if wd, err := os.Getwd(); err == nil {
+		protected = append(protected, wd)
+	}
+	for _, p := range protected {
+		if p == "" {
+			continue
+		}
+		pa, err := filepath.Abs(p)
+		if err != nil {
+			continue
+		}
+		if rel, err := filepath.Rel(abs, pa); err == nil && (rel == "." || (rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)))) {
+			return fmt.Errorf("%s korunan bir dizini (%s) kapsıyor; temizlenmez", dir, p)
+		}
+	}
+	return nil
+}
+
+// Policy saklama politikası; sıfır alanlar kapalıdır
+type Policy struct {
+	MaxAge   time.Duration // Bundan eski girdiler silinir
+	MaxBytes int64         // Dizin bu boyutu aşarsa en eski girdilerden başlanarak silinir
+}
+
+// Enabled politikanın en az bir sınırı var mı
+func (p Policy) Enabled() bool {
+	return p.MaxAge > 0 || p.MaxBytes > 0
+}
+
+// Usage bir dizinin kullanım özeti
+type Usage struct {
+	Category string    `json:"category"`
+	Dir      string    `json:"dir"`
+	Entries  int       `json:"entries"` // Üst düzey girdi (dosya veya profil dizini) sayısı
+	Bytes    int64     `json:"bytes"`
+	Oldest   time.Time `json:"oldest,omitempty"`
+}
+
+// Result temizlik sonucu
+type Result struct {
+	Category string `json:"category"`
+	Removed  int    `json:"removed"`
+	Freed    int64  `json:"freed"`
+	Error    string `json:"error,omitempty"`
+}
+
+// entry dizinin üst düzey girdisi; dizinler (ör. tarayıcı profili) bütün olarak değerlendirilir
+type entry struct {
+	path  string
+	bytes int64
+	mod   time.Time // Dizinlerde içindeki en yeni dosyanın zamanı (boş dizinde dizinin kendisi)
+}
+
+// scan hedef dizinin desenlerle eşleşen üst düzey girdilerini eskiden yeniye döner; dizin yoksa boş döner
+func scan(t Target) ([]entry, error) {
+	items, err := os.ReadDir(t.Dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	out := make([]entry, 0, len(items))
+	for _, it := range items {
+		if !t.matches(it.Name()) {
+			continue
+		}
+		e := entry{path: filepath.Join(t.Dir, it.Name())}
+		_ = filepath.WalkDir(e.path, func(_ string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return nil
+			}
+			if !d.IsDir() {
+				e.bytes += info.Size()
+				if info.ModTime().After(e.mod) {
+					e.mod = info.ModTime()
+				}
+			}
+			return nil
+		})
+		if e.mod.IsZero() {
+			if info, err := it.Info(); err == nil {
+				e.mod = info.ModTime()
+			}
+		}
+		out = append(out, e)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].mod.Before(out[j].mod) })
+	return out, nil
+}
+
+// Measure hedefin (aracın girdilerinin) kullanımını döner
+func Measure(t Target) (Usage, error) {
+	u := Usage{Category: t.Category, Dir: t.Dir}
+	entries, err := scan(t)
+	if err != nil {
+		return u, err
+	}
+	u.Entries = len(entries)
+	for _, e := range entries {
+		u.Bytes += e.bytes
+	}
+	if len(entries) > 0 {
+		u.Oldest = entries[0].mod
+	}
+	return u, nil
+}
+
+// Cleanup politikaya göre hedefteki eski girdileri siler: önce MaxAge'den eskiler,
+// ardından boyut MaxBytes altına inene kadar en eskiler. Güvensiz dizinlerde (CheckDir) hiçbir şey silinmez.
+func Cleanup(t Target, p Policy, now time.Time) Result {
+	res := Result{Category: t.Category}
+	if !p.Enabled() {
+		return res
+	}
+	if err := CheckDir(t.Dir, t.Protected...); err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	entries, err := scan(t)
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	var total int64
+	for _, e := range entries {
+		total += e.bytes
+	}
+	for _, e := range entries {
+		expired := p.MaxAge > 0 && now.Sub(e.mod) > p.MaxAge
+		over := p.MaxBytes > 0 && total > p.MaxBytes
+		if !expired && !over {
+			// Girdiler eskiden yeniye sıralı: sonrakiler de ne eski ne fazla
+			break
+		}
+		if err := os.RemoveAll(e.path); err != nil {
+			res.Error = err.Error()
+			continue
+		}
+		res.Removed++
+		res.Freed += e.bytes
+		total -= e.bytes
+	}
+	return res
+}
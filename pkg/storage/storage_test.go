@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeAged(t *testing.T, path string, size int, age time.Duration) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, make([]byte, size), 0644); err != nil {
+		t.Fatal(err)
+	}
+	mod := time.Now().Add(-age)
+	if err := os.Chtimes(path, mod, mod); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func reportsTarget(dir string) Target {
+	return Target{Category: "reports", Dir: dir, Patterns: []string{"vgbot_*", "sessions"}}
+}
+
+func TestCleanupMaxAge(t *testing.T) {
+	dir := t.TempDir()
+	writeAged(t, filepath.Join(dir, "vgbot_report_old.json"), 100, 10*24*time.Hour)
+	writeAged(t, filepath.Join(dir, "sessions", "cache", "data"), 50, 10*24*time.Hour)
+	writeAged(t, filepath.Join(dir, "vgbot_report_new.json"), 10, time.Hour)
+
+	res := Cleanup(reportsTarget(dir), Policy{MaxAge: 7 * 24 * time.Hour}, time.Now())
+	if res.Removed != 2 || res.Freed != 150 || res.Error != "" {
+		t.Fatalf("result = %+v", res)
+	}
+	u, _ := Measure(reportsTarget(dir))
+	if u.Entries != 1 || u.Bytes != 10 {
+		t.Fatalf("usage after = %+v", u)
+	}
+}
+
+func TestCleanupMaxBytesRemovesOldestFirst(t *testing.T) {
+	dir := t.TempDir()
+	writeAged(t, filepath.Join(dir, "vgbot_a"), 100, 3*time.Hour)
+	writeAged(t, filepath.Join(dir, "vgbot_b"), 100, 2*time.Hour)
+	writeAged(t, filepath.Join(dir, "vgbot_c"), 100, time.Hour)
+
+	res := Cleanup(reportsTarget(dir), Policy{MaxBytes: 150}, time.Now())
+	if res.Removed != 2 {
+		t.Fatalf("result = %+v", res)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "vgbot_c")); err != nil {
+		t.Fatalf("newest entry removed: %v", err)
+	}
+}
+
+func TestMeasureMissingDir(t *testing.T) {
+	u, err := Measure(Target{Category: "sessions", Dir: filepath.Join(t.TempDir(), "missing"), Patterns: []string{"*"}})
+	if err != nil || u.Entries != 0 {
+		t.Fatalf("usage = %+v, err = %v", u, err)
+	}
+	if res := Cleanup(Target{Category: "sessions", Dir: "missing"}, Policy{}, time.Now()); res.Removed != 0 {
+		t.Fatalf("disabled policy removed %d", res.Removed)
+	}
+}
+
+func TestCleanupSkipsForeignEntries(t *testing.T) {
+	dir := t.TempDir()
+	writeAged(t, filepath.Join(dir, "vgbot_report_old.json"), 100, 10*24*time.Hour)
+	writeAged(t, filepath.Join(dir, "notes.txt"), 100, 10*24*time.Hour)
+	writeAged(t, filepath.Join(dir, "runs.jsonl"), 100, 10*24*time.Hour)
+
+	res := Cleanup(reportsTarget(dir), Policy{MaxAge: time.Hour, MaxBytes: 1}, time.Now())
+	if res.Removed != 1 || res.Error != "" {
+		t.Fatalf("result = %+v", res)
+	}
+	for _, name := range []string{"notes.txt", "runs.jsonl"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("%s removed: %v", name, err)
+		}
+	}
+}
+
+func TestCleanupRefusesUnsafeDirs(t *testing.T) {
+	wd, _ := os.Getwd()
+	cfgDir := t.TempDir()
+	writeAged(t, filepath.Join(cfgDir, "vgbot_report_old.json"), 100, 10*24*time.Hour)
+
+	for _, dir := range []string{"", ".", "/", wd, filepath.Dir(wd), cfgDir, filepath.Dir(cfgDir)} {
+		target := reportsTarget(dir)
+		target.Protected = []string{cfgDir}
+		if res := Cleanup(target, Policy{MaxAge: time.Hour}, time.Now()); res.Error == "" || res.Removed != 0 {
+			t.Errorf("dir %q: result = %+v, want refusal", dir, res)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(cfgDir, "vgbot_report_old.json")); err != nil {
+		t.Fatalf("protected dir cleaned: %v", err)
+	}
+	if err := CheckDir(filepath.Join(cfgDir, "reports"), cfgDir); err != nil {
+		t.Errorf("sub directory refused: %v", err)
+	}
+}
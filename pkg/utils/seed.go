@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Seeder bir çalıştırmanın seed'inden bağımsız RNG kaynakları türetir. Eşzamanlı kampanyalar
+// birbirinin dizisini bozmasın diye her simülatör kendi Seeder'ını taşır ve rastgelelik kullanan
+// bileşenlere geçirir. Paket düzeyi (global) seed yoktur: nil Seeder her kaynağı zamana göre
+// tohumlar, yani tekrarlanamaz.
+type Seeder struct {
+	mu    sync.Mutex
+	seed  int64
+	fixed bool
+	n     int64
+}
+
+// NewSeeder seed'e bağlı yeni bir Seeder döner. seed 0 ise rastgele bir seed üretilir.
+func NewSeeder(seed int64) *Seeder {
+	return &Seeder{seed: pickSeed(seed), fixed: true}
+}
+
+// pickSeed 0 yerine kullanıcının yazıp tekrar girebileceği (ve JS number'a sığan) kısa bir seed üretir
+func pickSeed(seed int64) int64 {
+	if seed == 0 {
+		seed = time.Now().UnixNano()&0x7fffffff | 1
+	}
+	return seed
+}
+
+// Seed etkin seed'i döner (nil Seeder için 0)
+func (s *Seeder) Seed() int64 {
+	if s == nil {
+		return 0
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.fixed {
+		return 0
+	}
+	return s.seed
+}
+
+// NewSource seed'den türetilmiş yeni bir rand.Source döner.
+// Aynı seed ile aynı sırada oluşturulan kaynaklar aynı diziyi üretir; nil Seeder zamana göre tohumlar.
+func (s *Seeder) NewSource() rand.Source {
+	if s == nil {
+		return rand.NewSource(time.Now().UnixNano())
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.fixed {
+		return rand.NewSource(time.Now().UnixNano())
+	}
+	s.n++
+	return rand.NewSource(splitmix64(uint64(s.seed) + uint64(s.n)*0x9e3779b97f4a7c15))
+}
+
+// NewRand NewSource ile tohumlanmış *rand.Rand döner (eşzamanlı kullanım için güvenli değildir)
+func (s *Seeder) NewRand() *rand.Rand {
+	return rand.New(s.NewSource())
+}
+
+// splitmix64 ardışık sayaçları birbirinden bağımsız seed'lere dağıtır
+func splitmix64(x uint64) int64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return int64(x)
+}
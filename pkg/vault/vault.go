@@ -0,0 +1,257 @@
+// Package vault giriş bilgilerini (kullanıcı adı/şifre) parola ile şifrelenmiş tek bir dosyada saklar.
+// Dosya AES-256-GCM ile şifrelenir; anahtar, dosyadaki rastgele tuz ve parola'dan scrypt ile türetilir.
+// scrypt parametreleri dosya başlığında saklanır; başlık şifreli içeriğe ek veri olarak bağlanır.
+// Aynı anahtar Seal/Unseal ile profil çerezleri gibi diğer hassas verileri şifrelemek için kullanılır.
+package vault
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// KeyEnvVar vault parolasının okunduğu ortam değişkeni (parola config'e yazılmaz)
+const KeyEnvVar = "VGBOT_VAULT_KEY"
+
+// fileMagic vault dosyasının başlığı (biçim sürümü dahil).
+// Biçim: magic | scrypt log2(N), r, p (birer bayt) | tuz | nonce+şifreli içerik
+const fileMagic = "VGVAULT2"
+
+const (
+	saltSize   = 16
+	minKeySize = 8
+	keySize    = 32
+)
+
+// kdfParams scrypt maliyet parametreleri (N = 1<<LogN)
+type kdfParams struct {
+	LogN, R, P uint8
+}
+
+// defaultKDF yeni dosyalar için scrypt parametreleri (~32 MB bellek)
+var defaultKDF = kdfParams{LogN: 15, R: 8, P: 1}
+
+// valid dosyadan okunan parametrelerin makul aralıkta olup olmadığı; aşırı değerler
+// (bozuk veya kasıtlı dosya) açılışta bellek/CPU tüketmesin
+func (k kdfParams) valid() bool {
+	return k.LogN >= 10 && k.LogN <= 22 && k.R >= 1 && k.R <= 32 && k.P >= 1 && k.P <= 16
+}
+
+func (k kdfParams) header() []byte {
+	return []byte{k.LogN, k.R, k.P}
+}
+
+// ErrNoKey parola verilmediğinde döner
+var ErrNoKey = errors.New("vault parolası yok (" + KeyEnvVar + " ortam değişkenini ayarlayın)")
+
+// ErrBadKey parola yanlışsa veya dosya bozuksa döner
+var ErrBadKey = errors.New("vault açılamadı: parola yanlış veya dosya bozuk")
+
+// Credential tek bir giriş bilgisi
+type Credential struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// Vault şifreli giriş bilgisi deposu; tüm metodlar eşzamanlı kullanım için güvenlidir
+type Vault struct {
+	mu      sync.Mutex
+	path    string
+	salt    []byte
+	kdf     kdfParams
+	aead    cipher.AEAD
+	entries map[string]Credential
+}
+
+// Open path'teki vault'u passphrase ile açar; dosya yoksa boş vault döner (ilk Set'te oluşturulur)
+func Open(path, passphrase string) (*Vault, error) {
+	if passphrase == "" {
+		return nil, ErrNoKey
+	}
+	if len(passphrase) < minKeySize {
+		return nil, fmt.Errorf("vault parolası en az %d karakter olmalı", minKeySize)
+	}
+	v := &Vault{path: path, kdf: defaultKDF, entries: map[string]Credential{}}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		if err := v.rekey(passphrase); err != nil {
+			return nil, err
+		}
+		return v, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	hdrSize := len(fileMagic) + 3 + saltSize
+	if len(data) < hdrSize || string(data[:len(fileMagic)]) != fileMagic {
+		return nil, fmt.Errorf("%s bir vault dosyası değil", path)
+	}
+	p := data[len(fileMagic):]
+	v.kdf = kdfParams{LogN: p[0], R: p[1], P: p[2]}
+	if !v.kdf.valid() {
+		return nil, fmt.Errorf("%s: geçersiz KDF parametreleri", path)
+	}
+	v.salt = append([]byte{}, p[3:3+saltSize]...)
+	if v.aead, err = newAEAD(passphrase, v.salt, v.kdf); err != nil {
+		return nil, err
+	}
+	plain, err := v.unseal(data[hdrSize:], data[:hdrSize])
+	if err != nil {
+		return nil, ErrBadKey
+	}
+	if err := json.Unmarshal(plain, &v.entries); err != nil {
+		return nil, ErrBadKey
+	}
+	return v, nil
+}
+
+// OpenFromEnv vault'u KeyEnvVar'daki parola ile açar
+func OpenFromEnv(path string) (*Vault, error) {
+	return Open(path, os.Getenv(KeyEnvVar))
+}
+
+// Path vault dosyasının yolu
+func (v *Vault) Path() string {
+	return v.path
+}
+
+// rekey yeni rastgele tuz üretir ve v.kdf ile anahtarı türetir
+func (v *Vault) rekey(passphrase string) error {
+	v.salt = make([]byte, saltSize)
+	if _, err := io.ReadFull(rand.Reader, v.salt); err != nil {
+		return err
+	}
+	aead, err := newAEAD(passphrase, v.salt, v.kdf)
+	if err != nil {
+		return err
+	}
+	v.aead = aead
+	return nil
+}
+
+// newAEAD tuz ve paroladan scrypt ile AES-256-GCM şifreleyici türetir
+func newAEAD(passphrase string, salt []byte, k kdfParams) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, 1<<k.LogN, int(k.R), int(k.P), keySize)
+	if err != nil {
+		return nil, err
+	}
+	return gcm(key)
+}
+
+func gcm(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Get adlı giriş bilgisini döner
+func (v *Vault) Get(name string) (Credential, bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	c, ok := v.entries[name]
+	return c, ok
+}
+
+// Names kayıtlı giriş bilgilerinin adlarını sıralı döner (şifreler hiçbir zaman listelenmez)
+func (v *Vault) Names() []string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	names := make([]string, 0, len(v.entries))
+	for n := range v.entries {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Set giriş bilgisini ekler/günceller ve dosyayı yazar
+func (v *Vault) Set(name string, c Credential) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("giriş bilgisi adı boş")
+	}
+	if c.Username == "" {
+		return fmt.Errorf("kullanıcı adı boş")
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.entries[name] = c
+	return v.saveLocked()
+}
+
+// Delete giriş bilgisini siler ve dosyayı yazar
+func (v *Vault) Delete(name string) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if _, ok := v.entries[name]; !ok {
+		return nil
+	}
+	delete(v.entries, name)
+	return v.saveLocked()
+}
+
+func (v *Vault) saveLocked() error {
+	plain, err := json.Marshal(v.entries)
+	if err != nil {
+		return err
+	}
+	hdr := append([]byte(fileMagic), v.kdf.header()...)
+	hdr = append(hdr, v.salt...)
+	sealed, err := v.seal(plain, hdr)
+	if err != nil {
+		return err
+	}
+	out := append(hdr, sealed...)
+	if dir := filepath.Dir(v.path); dir != "" {
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return err
+		}
+	}
+	// Önce geçici dosyaya yaz: yarım kalan yazma vault'u bozmasın
+	tmp := v.path + ".tmp"
+	if err := os.WriteFile(tmp, out, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, v.path)
+}
+
+// Seal data'yı vault anahtarıyla şifreler (profil çerezleri vb. için)
+func (v *Vault) Seal(data []byte) ([]byte, error) {
+	return v.seal(data, nil)
+}
+
+// Unseal Seal ile şifrelenmiş veriyi çözer
+func (v *Vault) Unseal(data []byte) ([]byte, error) {
+	return v.unseal(data, nil)
+}
+
+// seal data'yı şifreler; ad (dosya başlığı gibi) şifrelenmeden içeriğe bağlanır
+func (v *Vault) seal(data, ad []byte) ([]byte, error) {
+	nonce := make([]byte, v.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return v.aead.Seal(nonce, nonce, data, ad), nil
+}
+
+func (v *Vault) unseal(data, ad []byte) ([]byte, error) {
+	n := v.aead.NonceSize()
+	if len(data) < n {
+		return nil, fmt.Errorf("şifreli veri çok kısa")
+	}
+	return v.aead.Open(nil, data[:n], data[n:], ad)
+}
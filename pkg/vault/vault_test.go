@@ -0,0 +1,101 @@
+package vault
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestVaultRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vault.enc")
+	v, err := Open(path, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := v.Set("shop", Credential{Username: "alice", Password: "s3cret"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.Set("blog", Credential{Username: "bob"}); err != nil {
+		t.Fatal(err)
+	}
+
+	raw, _ := os.ReadFile(path)
+	if len(raw) == 0 {
+		t.Fatal("vault file not written")
+	}
+	for _, secret := range []string{"alice", "s3cret"} {
+		if bytes.Contains(raw, []byte(secret)) {
+			t.Fatalf("vault file contains plaintext %q", secret)
+		}
+	}
+
+	v2, err := Open(path, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := v2.Names(); !reflect.DeepEqual(got, []string{"blog", "shop"}) {
+		t.Fatalf("names = %v", got)
+	}
+	if c, ok := v2.Get("shop"); !ok || c.Password != "s3cret" {
+		t.Fatalf("shop = %+v, %v", c, ok)
+	}
+	if err := v2.Delete("blog"); err != nil {
+		t.Fatal(err)
+	}
+	v3, _ := Open(path, "correct horse")
+	if _, ok := v3.Get("blog"); ok {
+		t.Fatal("deleted entry still present")
+	}
+}
+
+func TestVaultWrongKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vault.enc")
+	v, _ := Open(path, "correct horse")
+	if err := v.Set("shop", Credential{Username: "alice", Password: "x"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(path, "wrong horse"); !errors.Is(err, ErrBadKey) {
+		t.Fatalf("err = %v, want ErrBadKey", err)
+	}
+	if _, err := Open(path, ""); !errors.Is(err, ErrNoKey) {
+		t.Fatalf("err = %v, want ErrNoKey", err)
+	}
+}
+
+func TestSealUnseal(t *testing.T) {
+	v, _ := Open(filepath.Join(t.TempDir(), "vault.enc"), "correct horse")
+	sealed, err := v.Seal([]byte("cookie=1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain, err := v.Unseal(sealed)
+	if err != nil || string(plain) != "cookie=1" {
+		t.Fatalf("unseal = %q, %v", plain, err)
+	}
+	sealed[len(sealed)-1] ^= 1
+	if _, err := v.Unseal(sealed); err == nil {
+		t.Fatal("tampered data unsealed")
+	}
+}
+
+func TestVaultHeaderStoresKDFParams(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vault.enc")
+	v, _ := Open(path, "correct horse")
+	if err := v.Set("shop", Credential{Username: "alice"}); err != nil {
+		t.Fatal(err)
+	}
+	raw, _ := os.ReadFile(path)
+	hdr := raw[len(fileMagic) : len(fileMagic)+3]
+	if string(raw[:len(fileMagic)]) != fileMagic || !bytes.Equal(hdr, defaultKDF.header()) {
+		t.Fatalf("header = %q %v", raw[:len(fileMagic)], hdr)
+	}
+	// Başlık içeriğe bağlı: parametreler değiştirilirse dosya açılmaz
+	raw[len(fileMagic)+2]++
+	os.WriteFile(path, raw, 0600)
+	if _, err := Open(path, "correct horse"); !errors.Is(err, ErrBadKey) {
+		t.Fatalf("tampered header: err = %v, want ErrBadKey", err)
+	}
+}